@@ -56,6 +56,17 @@ function build::frontend {
   done
 }
 
+function compress::frontend {
+  say "\nPrecompressing frontend assets"
+  files=($(find ${FRONTEND_DIR} -type f \( -name '*.js' -o -name '*.css' -o -name '*.svg' -o -name '*.json' \)))
+  for file in "${files[@]}"; do
+    gzip -k -9 -f ${file}
+    if command -v brotli > /dev/null; then
+      brotli -k -f -q 11 ${file}
+    fi
+  done
+}
+
 function build::backend {
   say "\nBuilding backend"
   ${GULP_BIN} backend:prod
@@ -125,6 +136,7 @@ npm run postversion
 
 if [ "${FRONTEND_ONLY}" = true ] ; then
   build::frontend
+  compress::frontend
   exit
 fi
 
@@ -135,6 +147,7 @@ else
 fi
 
 build::frontend
+compress::frontend
 copy::frontend
 copy::supported-locales
 copy::dockerfile
@@ -0,0 +1,113 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package activation provides listeners passed to Dashboard by a service manager, i.e. systemd socket
+// activation or a zero-downtime restart wrapper, using the LISTEN_FDS protocol.
+package activation
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+)
+
+const (
+	// listenFdsStart is the first file descriptor passed by the service manager. Descriptors 0-2 are stdio.
+	listenFdsStart = 3
+
+	// SecureName is a name of the passed socket, set by FileDescriptorName= of the systemd socket unit, that
+	// is used by the HTTPS server.
+	SecureName = "https"
+	// InsecureName is a name of the passed socket, that is used by the HTTP server.
+	InsecureName = "http"
+)
+
+// NamedListener is a listener passed by the service manager together with its name from LISTEN_FDNAMES.
+// Name is empty if the service manager did not name passed sockets.
+type NamedListener struct {
+	net.Listener
+	Name string
+}
+
+// Listeners returns listeners passed to the process in the order of passed file descriptors. Returns nil if
+// the process was not started with passed sockets. Environment variables of the protocol are unset, so that
+// they are not inherited by child processes.
+func Listeners() ([]NamedListener, error) {
+	pid, fds, names := os.Getenv("LISTEN_PID"), os.Getenv("LISTEN_FDS"), os.Getenv("LISTEN_FDNAMES")
+	os.Unsetenv("LISTEN_PID")
+	os.Unsetenv("LISTEN_FDS")
+	os.Unsetenv("LISTEN_FDNAMES")
+
+	// Sockets passed to the parent process are not meant for this one.
+	if len(pid) > 0 && pid != strconv.Itoa(os.Getpid()) {
+		return nil, nil
+	}
+	return listeners(fds, names, listenFdsStart)
+}
+
+func listeners(fds, names string, firstFd int) ([]NamedListener, error) {
+	if len(fds) == 0 {
+		return nil, nil
+	}
+
+	count, err := strconv.Atoi(fds)
+	if err != nil || count < 0 {
+		return nil, fmt.Errorf("invalid LISTEN_FDS value %q", fds)
+	}
+
+	nameList := strings.Split(names, ":")
+	result := make([]NamedListener, 0, count)
+	for i := 0; i < count; i++ {
+		name := ""
+		if len(names) > 0 && i < len(nameList) {
+			name = nameList[i]
+		}
+
+		file := os.NewFile(uintptr(firstFd+i), name)
+		// FileListener duplicates the descriptor, so the original one is closed.
+		listener, err := net.FileListener(file)
+		file.Close()
+		if err != nil {
+			closeAll(result)
+			return nil, fmt.Errorf("passed file descriptor %d is not a listening socket: %s", firstFd+i, err)
+		}
+		result = append(result, NamedListener{Listener: listener, Name: name})
+	}
+	return result, nil
+}
+
+// Pick returns listener with given name. If none of the listeners is named, listener at given position is
+// returned instead. Returns nil if there is no such listener.
+func Pick(listeners []NamedListener, name string, position int) net.Listener {
+	named := false
+	for _, listener := range listeners {
+		if listener.Name == name {
+			return listener
+		}
+		named = named || len(listener.Name) > 0
+	}
+
+	if !named && position < len(listeners) {
+		return listeners[position]
+	}
+	return nil
+}
+
+func closeAll(listeners []NamedListener) {
+	for _, listener := range listeners {
+		listener.Close()
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package activation
+
+import (
+	"net"
+	"os"
+	"strconv"
+	"testing"
+)
+
+func passedListener(t *testing.T) (*os.File, string) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+
+	file, err := listener.(*net.TCPListener).File()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return file, listener.Addr().String()
+}
+
+func TestListeners(t *testing.T) {
+	file, addr := passedListener(t)
+
+	result, err := listeners("1", "https", int(file.Fd()))
+	if err != nil {
+		t.Fatalf("listeners() returned error: %s", err)
+	}
+	defer closeAll(result)
+
+	if len(result) != 1 || result[0].Name != "https" || result[0].Addr().String() != addr {
+		t.Fatalf("listeners() == %v, expected single https listener on %s", result, addr)
+	}
+}
+
+func TestListenersNotPassed(t *testing.T) {
+	os.Setenv("LISTEN_PID", strconv.Itoa(os.Getpid()+1))
+	os.Setenv("LISTEN_FDS", "1")
+
+	result, err := Listeners()
+	if err != nil || result != nil {
+		t.Errorf("Listeners() == (%v, %v), expected no listeners passed to other process", result, err)
+	}
+	if len(os.Getenv("LISTEN_FDS")) > 0 {
+		t.Error("Listeners() expected to unset LISTEN_FDS")
+	}
+
+	if _, err := listeners("foo", "", listenFdsStart); err == nil {
+		t.Error("listeners() expected error for invalid LISTEN_FDS")
+	}
+}
+
+func TestPick(t *testing.T) {
+	unnamed := []NamedListener{{Listener: &net.TCPListener{}}, {Listener: &net.TCPListener{}}}
+	named := []NamedListener{{Listener: &net.TCPListener{}, Name: InsecureName},
+		{Listener: &net.TCPListener{}, Name: SecureName}}
+
+	cases := []struct {
+		listeners []NamedListener
+		name      string
+		position  int
+		expected  net.Listener
+	}{
+		{unnamed, SecureName, 0, unnamed[0]},
+		{unnamed, InsecureName, 1, unnamed[1]},
+		{unnamed, InsecureName, 2, nil},
+		{named, SecureName, 0, named[1]},
+		{named, InsecureName, 1, named[0]},
+		{named, "metrics", 0, nil},
+		{nil, SecureName, 0, nil},
+	}
+
+	for _, c := range cases {
+		if actual := Pick(c.listeners, c.name, c.position); actual != c.expected {
+			t.Errorf("Pick(%v, %s, %d) == %v, expected %v", c.listeners, c.name, c.position, actual, c.expected)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alerting
+
+import (
+	"fmt"
+
+	apps "k8s.io/api/apps/v1"
+	batch "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+)
+
+const (
+	// crashLoopBackOffReason is the waiting reason of containers that are restarted with back-off.
+	crashLoopBackOffReason = "CrashLoopBackOff"
+
+	// progressDeadlineExceededReason is the reason of Progressing condition of deployments that failed to roll out.
+	progressDeadlineExceededReason = "ProgressDeadlineExceeded"
+)
+
+// Alert is a notification about a failure of a workload.
+type Alert struct {
+	// Kind, namespace and name of the failed resource.
+	Kind      api.ResourceKind `json:"kind"`
+	Namespace string           `json:"namespace"`
+	Name      string           `json:"name"`
+
+	// Machine readable reason, i.e. CrashLoopBackOff.
+	Reason string `json:"reason"`
+
+	// Human readable description of the failure.
+	Message string `json:"message"`
+
+	// Link to the resource in Dashboard. Empty when external URL of Dashboard is not configured.
+	URL string `json:"url,omitempty"`
+}
+
+// key identifies alerts of the same failure of the same resource.
+func (self Alert) key() string {
+	return fmt.Sprintf("%s/%s/%s/%s", self.Kind, self.Namespace, self.Name, self.Reason)
+}
+
+// getPodAlerts returns alerts about containers of the pod that started crash looping with the update.
+func getPodAlerts(old, new *v1.Pod) []Alert {
+	alerts := make([]Alert, 0)
+	crashLooping := make(map[string]bool)
+	for _, status := range append(append([]v1.ContainerStatus{}, old.Status.InitContainerStatuses...),
+		old.Status.ContainerStatuses...) {
+		crashLooping[status.Name] = isCrashLooping(status)
+	}
+
+	for _, status := range append(append([]v1.ContainerStatus{}, new.Status.InitContainerStatuses...),
+		new.Status.ContainerStatuses...) {
+		if !isCrashLooping(status) || crashLooping[status.Name] {
+			continue
+		}
+
+		message := fmt.Sprintf("Container %s of pod %s/%s is crash looping after %d restarts", status.Name,
+			new.Namespace, new.Name, status.RestartCount)
+		if terminated := status.LastTerminationState.Terminated; terminated != nil {
+			message += fmt.Sprintf(", last exit code %d (%s)", terminated.ExitCode, terminated.Reason)
+		}
+		alerts = append(alerts, Alert{Kind: api.ResourceKindPod, Namespace: new.Namespace, Name: new.Name,
+			Reason: crashLoopBackOffReason, Message: message})
+	}
+	return alerts
+}
+
+func isCrashLooping(status v1.ContainerStatus) bool {
+	return status.State.Waiting != nil && status.State.Waiting.Reason == crashLoopBackOffReason
+}
+
+// getJobAlerts returns alert when the job failed with the update.
+func getJobAlerts(old, new *batch.Job) []Alert {
+	failed := getJobFailedCondition(new)
+	if failed == nil || getJobFailedCondition(old) != nil {
+		return nil
+	}
+
+	return []Alert{{Kind: api.ResourceKindJob, Namespace: new.Namespace, Name: new.Name, Reason: failed.Reason,
+		Message: fmt.Sprintf("Job %s/%s failed: %s", new.Namespace, new.Name, failed.Message)}}
+}
+
+func getJobFailedCondition(job *batch.Job) *batch.JobCondition {
+	for i, condition := range job.Status.Conditions {
+		if condition.Type == batch.JobFailed && condition.Status == v1.ConditionTrue {
+			return &job.Status.Conditions[i]
+		}
+	}
+	return nil
+}
+
+// getDeploymentAlerts returns alert when rollout of the deployment exceeded its progress deadline with the update.
+func getDeploymentAlerts(old, new *apps.Deployment) []Alert {
+	if !isRolloutFailed(new) || isRolloutFailed(old) {
+		return nil
+	}
+
+	return []Alert{{Kind: api.ResourceKindDeployment, Namespace: new.Namespace, Name: new.Name,
+		Reason: progressDeadlineExceededReason,
+		Message: fmt.Sprintf("Rollout of deployment %s/%s failed: %s", new.Namespace, new.Name,
+			getRolloutMessage(new))}}
+}
+
+func isRolloutFailed(deployment *apps.Deployment) bool {
+	for _, condition := range deployment.Status.Conditions {
+		if condition.Type == apps.DeploymentProgressing {
+			return condition.Status == v1.ConditionFalse && condition.Reason == progressDeadlineExceededReason
+		}
+	}
+	return false
+}
+
+func getRolloutMessage(deployment *apps.Deployment) string {
+	for _, condition := range deployment.Status.Conditions {
+		if condition.Type == apps.DeploymentProgressing {
+			return condition.Message
+		}
+	}
+	return ""
+}
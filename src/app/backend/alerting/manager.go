@@ -0,0 +1,170 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alerting
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	apps "k8s.io/api/apps/v1"
+	batch "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/kubernetes/dashboard/src/app/backend/args"
+)
+
+const (
+	// notifyTimeout is the timeout of a single webhook request.
+	notifyTimeout = 10 * time.Second
+
+	// alertCooldown is the time during which repeated alerts about the same failure of the same resource are not
+	// sent, i.e. when a container keeps switching between crash loop back-off and running.
+	alertCooldown = time.Hour
+
+	// alertQueueSize is the number of alerts waiting to be sent. Alerts are dropped when receivers are so slow
+	// that the queue is full, so that event handlers of the informers are never blocked.
+	alertQueueSize = 100
+)
+
+// AlertManager watches workloads and notifies about their failures. Only transitions to the failed state are
+// reported, so that existing failures are not reported again on every restart of Dashboard.
+type AlertManager struct {
+	notifiers    []Notifier
+	dashboardURL string
+	now          func() time.Time
+	queue        chan Alert
+
+	mux      sync.Mutex
+	lastSent map[string]time.Time
+}
+
+// Enabled returns true when any alert receiver is configured.
+func (self *AlertManager) Enabled() bool {
+	return len(self.notifiers) > 0
+}
+
+// Watch starts watching pods, jobs and deployments in all namespaces using the given client, until the stop
+// channel is closed. Alerts are sent to receivers by a single worker, so that slow receivers do not stall the
+// informers. It does nothing when no alert receiver is configured.
+func (self *AlertManager) Watch(client kubernetes.Interface, stopCh <-chan struct{}) {
+	if !self.Enabled() {
+		return
+	}
+
+	log.Printf("Watching workloads for failures, alerts are sent to %d receivers", len(self.notifiers))
+	factory := informers.NewSharedInformerFactory(client, 0)
+	factory.Core().V1().Pods().Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(old, new interface{}) {
+			self.notify(getPodAlerts(old.(*v1.Pod), new.(*v1.Pod)))
+		},
+	})
+	factory.Batch().V1().Jobs().Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(old, new interface{}) {
+			self.notify(getJobAlerts(old.(*batch.Job), new.(*batch.Job)))
+		},
+	})
+	factory.Apps().V1().Deployments().Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(old, new interface{}) {
+			self.notify(getDeploymentAlerts(old.(*apps.Deployment), new.(*apps.Deployment)))
+		},
+	})
+	go self.run(stopCh)
+	factory.Start(stopCh)
+}
+
+// notify queues alerts that were not sent recently. It never blocks.
+func (self *AlertManager) notify(alerts []Alert) {
+	for _, alert := range alerts {
+		if !self.shouldSend(alert) {
+			continue
+		}
+
+		if len(self.dashboardURL) > 0 {
+			alert.URL = fmt.Sprintf("%s/#/%s/%s/%s", self.dashboardURL, alert.Kind, alert.Namespace, alert.Name)
+		}
+
+		select {
+		case self.queue <- alert:
+		default:
+			log.Printf("Alert queue is full, dropping alert: %s", alert.Message)
+		}
+	}
+}
+
+// run sends queued alerts until the stop channel is closed.
+func (self *AlertManager) run(stopCh <-chan struct{}) {
+	for {
+		select {
+		case alert := <-self.queue:
+			self.send(alert)
+		case <-stopCh:
+			return
+		}
+	}
+}
+
+func (self *AlertManager) send(alert Alert) {
+	log.Printf("Sending alert: %s", alert.Message)
+	for _, notifier := range self.notifiers {
+		if err := notifier.Notify(alert); err != nil {
+			log.Printf("Cannot send alert: %s", err)
+		}
+	}
+}
+
+func (self *AlertManager) shouldSend(alert Alert) bool {
+	self.mux.Lock()
+	defer self.mux.Unlock()
+
+	now := self.now()
+	if lastSent, ok := self.lastSent[alert.key()]; ok && now.Sub(lastSent) < alertCooldown {
+		return false
+	}
+	self.lastSent[alert.key()] = now
+
+	// Forget old alerts, so that the map does not grow with deleted resources.
+	for key, lastSent := range self.lastSent {
+		if now.Sub(lastSent) >= alertCooldown {
+			delete(self.lastSent, key)
+		}
+	}
+	return true
+}
+
+// NewAlertManager creates alert manager sending alerts to receivers configured by arguments.
+func NewAlertManager() *AlertManager {
+	client := &http.Client{Timeout: notifyTimeout}
+	notifiers := make([]Notifier, 0)
+	for _, url := range args.Holder.GetAlertWebhookURLs() {
+		notifiers = append(notifiers, &webhookNotifier{url: url, client: client})
+	}
+	for _, url := range args.Holder.GetAlertSlackWebhookURLs() {
+		notifiers = append(notifiers, &slackNotifier{url: url, client: client})
+	}
+
+	return newAlertManager(notifiers, strings.TrimSuffix(args.Holder.GetDashboardURL(), "/"))
+}
+
+func newAlertManager(notifiers []Notifier, dashboardURL string) *AlertManager {
+	return &AlertManager{notifiers: notifiers, dashboardURL: dashboardURL, now: time.Now,
+		queue: make(chan Alert, alertQueueSize), lastSent: make(map[string]time.Time)}
+}
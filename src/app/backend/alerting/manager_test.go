@@ -0,0 +1,174 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alerting
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	apps "k8s.io/api/apps/v1"
+	batch "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type fakeNotifier struct {
+	mux    sync.Mutex
+	alerts []Alert
+}
+
+func (self *fakeNotifier) Notify(alert Alert) error {
+	self.mux.Lock()
+	defer self.mux.Unlock()
+	self.alerts = append(self.alerts, alert)
+	return nil
+}
+
+func (self *fakeNotifier) count() int {
+	self.mux.Lock()
+	defer self.mux.Unlock()
+	return len(self.alerts)
+}
+
+// blockingNotifier blocks every notification until the channel is closed.
+type blockingNotifier chan struct{}
+
+func (self blockingNotifier) Notify(alert Alert) error {
+	<-self
+	return nil
+}
+
+func newPod(waitingReason string) *v1.Pod {
+	status := v1.ContainerStatus{Name: "app", RestartCount: 5}
+	if len(waitingReason) > 0 {
+		status.State.Waiting = &v1.ContainerStateWaiting{Reason: waitingReason}
+	}
+	return &v1.Pod{
+		ObjectMeta: metaV1.ObjectMeta{Name: "web", Namespace: "default"},
+		Status:     v1.PodStatus{ContainerStatuses: []v1.ContainerStatus{status}},
+	}
+}
+
+func TestGetAlerts(t *testing.T) {
+	if alerts := getPodAlerts(newPod(""), newPod(crashLoopBackOffReason)); len(alerts) != 1 ||
+		alerts[0].Message != "Container app of pod default/web is crash looping after 5 restarts" {
+		t.Errorf("Expected crash loop alert, got %#v", alerts)
+	}
+	if alerts := getPodAlerts(newPod(crashLoopBackOffReason), newPod(crashLoopBackOffReason)); len(alerts) != 0 {
+		t.Errorf("Expected no alert for pod that was already crash looping, got %#v", alerts)
+	}
+
+	failedJob := &batch.Job{ObjectMeta: metaV1.ObjectMeta{Name: "migrate", Namespace: "default"},
+		Status: batch.JobStatus{Conditions: []batch.JobCondition{{Type: batch.JobFailed, Status: v1.ConditionTrue,
+			Reason: "BackoffLimitExceeded", Message: "Job has reached the specified backoff limit"}}}}
+	if alerts := getJobAlerts(&batch.Job{}, failedJob); len(alerts) != 1 || alerts[0].Reason != "BackoffLimitExceeded" {
+		t.Errorf("Expected failed job alert, got %#v", alerts)
+	}
+
+	failedDeployment := &apps.Deployment{ObjectMeta: metaV1.ObjectMeta{Name: "web", Namespace: "default"},
+		Status: apps.DeploymentStatus{Conditions: []apps.DeploymentCondition{{Type: apps.DeploymentProgressing,
+			Status: v1.ConditionFalse, Reason: progressDeadlineExceededReason}}}}
+	if alerts := getDeploymentAlerts(&apps.Deployment{}, failedDeployment); len(alerts) != 1 {
+		t.Errorf("Expected failed rollout alert, got %#v", alerts)
+	}
+	if alerts := getDeploymentAlerts(failedDeployment, failedDeployment); len(alerts) != 0 {
+		t.Errorf("Expected no alert for deployment that already failed, got %#v", alerts)
+	}
+}
+
+func TestAlertManagerNotify(t *testing.T) {
+	notifier := &fakeNotifier{}
+	manager := newAlertManager([]Notifier{notifier}, "https://dashboard.example.com")
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	manager.now = func() time.Time { return now }
+
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	go manager.run(stopCh)
+
+	alerts := getPodAlerts(newPod(""), newPod(crashLoopBackOffReason))
+	manager.notify(alerts)
+	manager.notify(alerts)
+	waitForAlerts(t, notifier, 1)
+	if len(notifier.alerts) != 1 || notifier.alerts[0].URL != "https://dashboard.example.com/#/pod/default/web" {
+		t.Fatalf("Expected single alert with link, got %#v", notifier.alerts)
+	}
+
+	now = now.Add(alertCooldown)
+	manager.notify(alerts)
+	waitForAlerts(t, notifier, 2)
+}
+
+func TestAlertManagerNotifyWithSlowReceiver(t *testing.T) {
+	receiver := make(blockingNotifier)
+	defer close(receiver)
+	manager := newAlertManager([]Notifier{receiver}, "")
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	go manager.run(stopCh)
+
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < 2*alertQueueSize; i++ {
+			pod := newPod(crashLoopBackOffReason)
+			pod.Name = fmt.Sprintf("web-%d", i)
+			manager.notify(getPodAlerts(newPod(""), pod))
+		}
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected alerts to be queued without waiting for the receiver")
+	}
+}
+
+func waitForAlerts(t *testing.T, notifier *fakeNotifier, expected int) {
+	deadline := time.Now().Add(5 * time.Second)
+	for notifier.count() < expected && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(10 * time.Millisecond)
+	if count := notifier.count(); count != expected {
+		t.Fatalf("Expected %d alerts to be sent, got %d", expected, count)
+	}
+}
+
+func TestSlackNotifier(t *testing.T) {
+	var body map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	notifier := &slackNotifier{url: server.URL, client: server.Client()}
+	err := notifier.Notify(Alert{Message: "Job default/migrate failed", URL: "https://dashboard/#/job/default/migrate"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := ":rotating_light: Job default/migrate failed (<https://dashboard/#/job/default/migrate|open in Dashboard>)"
+	if body["text"] != expected {
+		t.Errorf("Expected text %q, got %q", expected, body["text"])
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alerting
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// Notifier delivers alerts to an external system.
+type Notifier interface {
+	// Notify sends the alert.
+	Notify(alert Alert) error
+}
+
+// webhookNotifier posts alerts as JSON to a generic webhook.
+type webhookNotifier struct {
+	url    string
+	client *http.Client
+}
+
+// Notify implements Notifier interface. See Notifier for more information.
+func (self *webhookNotifier) Notify(alert Alert) error {
+	return postJSON(self.client, self.url, alert)
+}
+
+// slackNotifier posts alerts to Slack incoming webhook.
+type slackNotifier struct {
+	url    string
+	client *http.Client
+}
+
+// Notify implements Notifier interface. See Notifier for more information.
+func (self *slackNotifier) Notify(alert Alert) error {
+	text := fmt.Sprintf(":rotating_light: %s", alert.Message)
+	if len(alert.URL) > 0 {
+		text += fmt.Sprintf(" (<%s|open in Dashboard>)", alert.URL)
+	}
+	return postJSON(self.client, self.url, map[string]string{"text": text})
+}
+
+func postJSON(client *http.Client, url string, body interface{}) error {
+	content, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Post(url, "application/json", bytes.NewReader(content))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("webhook returned %s", resp.Status)
+	}
+	return nil
+}
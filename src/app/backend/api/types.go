@@ -142,6 +142,15 @@ const (
 	ResourceKindRoleBinding              = "rolebinding"
 	ResourceKindPlugin                   = "plugin"
 	ResourceKindEndpoint                 = "endpoint"
+	ResourceKindVirtualService           = "virtualservice"
+	ResourceKindDestinationRule          = "destinationrule"
+	ResourceKindGateway                  = "gateway"
+	ResourceKindKnativeService           = "knativeservice"
+	ResourceKindRevision                 = "revision"
+	ResourceKindCertificate              = "certificate"
+	ResourceKindBackup                   = "backup"
+	ResourceKindRestore                  = "restore"
+	ResourceKindConstraint               = "constraint"
 )
 
 // Scalable method return whether ResourceKind is scalable.
@@ -14,7 +14,10 @@
 
 package args
 
-import "net"
+import (
+	"net"
+	"sync"
+)
 
 var builder = &holderBuilder{holder: Holder}
 
@@ -22,150 +25,489 @@ var builder = &holderBuilder{holder: Holder}
 // that modifies singleton instance of argument holder.
 type holderBuilder struct {
 	holder *holder
+
+	// updateMux makes sure that only one update is in progress.
+	updateMux sync.Mutex
+	mux       sync.Mutex
+	// staged collects values set during update, that are published at once when the update ends.
+	staged *holderValues
+}
+
+// set applies change to a copy of current argument values and publishes the copy, or adds the change to the
+// staged values when an update is in progress.
+func (self *holderBuilder) set(change func(values *holderValues)) *holderBuilder {
+	self.mux.Lock()
+	defer self.mux.Unlock()
+
+	if self.staged != nil {
+		change(self.staged)
+		return self
+	}
+
+	values := *self.holder.load()
+	change(&values)
+	self.holder.values.Store(&values)
+	return self
+}
+
+// Update calls update, that sets arguments with the builder, and publishes all of the values at once when it
+// returns. Readers of the holder see either the old or the new values, never a mix of them.
+func (self *holderBuilder) Update(update func()) {
+	self.updateMux.Lock()
+	defer self.updateMux.Unlock()
+
+	self.mux.Lock()
+	values := *self.holder.load()
+	self.staged = &values
+	self.mux.Unlock()
+
+	update()
+
+	self.mux.Lock()
+	defer self.mux.Unlock()
+	self.holder.values.Store(self.staged)
+	self.staged = nil
 }
 
 // SetInsecurePort 'insecure-port' argument of Dashboard binary.
 func (self *holderBuilder) SetInsecurePort(port int) *holderBuilder {
-	self.holder.insecurePort = port
-	return self
+	return self.set(func(values *holderValues) {
+		values.insecurePort = port
+	})
 }
 
 // SetPort 'port' argument of Dashboard binary.
 func (self *holderBuilder) SetPort(port int) *holderBuilder {
-	self.holder.port = port
-	return self
+	return self.set(func(values *holderValues) {
+		values.port = port
+	})
 }
 
 // SetTokenTTL 'token-ttl' argument of Dashboard binary.
 func (self *holderBuilder) SetTokenTTL(ttl int) *holderBuilder {
-	self.holder.tokenTTL = ttl
-	return self
+	return self.set(func(values *holderValues) {
+		values.tokenTTL = ttl
+	})
 }
 
 // SetMetricClientCheckPeriod 'metric-client-check-period' argument of Dashboard binary.
 func (self *holderBuilder) SetMetricClientCheckPeriod(period int) *holderBuilder {
-	self.holder.metricClientCheckPeriod = period
-	return self
+	return self.set(func(values *holderValues) {
+		values.metricClientCheckPeriod = period
+	})
 }
 
 // SetInsecureBindAddress 'insecure-bind-address' argument of Dashboard binary.
 func (self *holderBuilder) SetInsecureBindAddress(ip net.IP) *holderBuilder {
-	self.holder.insecureBindAddress = ip
-	return self
+	return self.set(func(values *holderValues) {
+		values.insecureBindAddress = ip
+	})
 }
 
 // SetBindAddress 'bind-address' argument of Dashboard binary.
 func (self *holderBuilder) SetBindAddress(ip net.IP) *holderBuilder {
-	self.holder.bindAddress = ip
-	return self
+	return self.set(func(values *holderValues) {
+		values.bindAddress = ip
+	})
 }
 
 // SetDefaultCertDir 'default-cert-dir' argument of Dashboard binary.
 func (self *holderBuilder) SetDefaultCertDir(certDir string) *holderBuilder {
-	self.holder.defaultCertDir = certDir
-	return self
+	return self.set(func(values *holderValues) {
+		values.defaultCertDir = certDir
+	})
 }
 
 // SetCertFile 'tls-cert-file' argument of Dashboard binary.
 func (self *holderBuilder) SetCertFile(certFile string) *holderBuilder {
-	self.holder.certFile = certFile
-	return self
+	return self.set(func(values *holderValues) {
+		values.certFile = certFile
+	})
 }
 
 // SetKeyFile 'tls-key-file' argument of Dashboard binary.
 func (self *holderBuilder) SetKeyFile(keyFile string) *holderBuilder {
-	self.holder.keyFile = keyFile
-	return self
+	return self.set(func(values *holderValues) {
+		values.keyFile = keyFile
+	})
 }
 
 // SetApiServerHost 'api-server-host' argument of Dashboard binary.
 func (self *holderBuilder) SetApiServerHost(apiServerHost string) *holderBuilder {
-	self.holder.apiServerHost = apiServerHost
-	return self
+	return self.set(func(values *holderValues) {
+		values.apiServerHost = apiServerHost
+	})
 }
 
 // SetMetricsProvider 'metrics-provider' argument of Dashboard binary.
 func (self *holderBuilder) SetMetricsProvider(metricsProvider string) *holderBuilder {
-	self.holder.metricsProvider = metricsProvider
-	return self
+	return self.set(func(values *holderValues) {
+		values.metricsProvider = metricsProvider
+	})
 }
 
 // SetHeapsterHost 'heapster-host' argument of Dashboard binary.
 func (self *holderBuilder) SetHeapsterHost(heapsterHost string) *holderBuilder {
-	self.holder.heapsterHost = heapsterHost
-	return self
+	return self.set(func(values *holderValues) {
+		values.heapsterHost = heapsterHost
+	})
 }
 
 // SetSidecarHost 'sidecar-host' argument of Dashboard binary.
 func (self *holderBuilder) SetSidecarHost(sidecarHost string) *holderBuilder {
-	self.holder.sidecarHost = sidecarHost
-	return self
+	return self.set(func(values *holderValues) {
+		values.sidecarHost = sidecarHost
+	})
 }
 
 // SetKubeConfigFile 'kubeconfig' argument of Dashboard binary.
 func (self *holderBuilder) SetKubeConfigFile(kubeConfigFile string) *holderBuilder {
-	self.holder.kubeConfigFile = kubeConfigFile
-	return self
+	return self.set(func(values *holderValues) {
+		values.kubeConfigFile = kubeConfigFile
+	})
 }
 
 // SetSystemBanner 'system-banner' argument of Dashboard binary.
 func (self *holderBuilder) SetSystemBanner(systemBanner string) *holderBuilder {
-	self.holder.systemBanner = systemBanner
-	return self
+	return self.set(func(values *holderValues) {
+		values.systemBanner = systemBanner
+	})
 }
 
 // SetSystemBannerSeverity 'system-banner-severity' argument of Dashboard binary.
 func (self *holderBuilder) SetSystemBannerSeverity(systemBannerSeverity string) *holderBuilder {
-	self.holder.systemBannerSeverity = systemBannerSeverity
-	return self
+	return self.set(func(values *holderValues) {
+		values.systemBannerSeverity = systemBannerSeverity
+	})
 }
 
 // SetLogLevel 'api-log-level' argument of Dashboard binary.
 func (self *holderBuilder) SetAPILogLevel(apiLogLevel string) *holderBuilder {
-	self.holder.apiLogLevel = apiLogLevel
-	return self
+	return self.set(func(values *holderValues) {
+		values.apiLogLevel = apiLogLevel
+	})
 }
 
 // SetAuthenticationMode 'authentication-mode' argument of Dashboard binary.
 func (self *holderBuilder) SetAuthenticationMode(authMode []string) *holderBuilder {
-	self.holder.authenticationMode = authMode
-	return self
+	return self.set(func(values *holderValues) {
+		values.authenticationMode = authMode
+	})
 }
 
 // SetAutoGenerateCertificates 'auto-generate-certificates' argument of Dashboard binary.
 func (self *holderBuilder) SetAutoGenerateCertificates(autoGenerateCertificates bool) *holderBuilder {
-	self.holder.autoGenerateCertificates = autoGenerateCertificates
-	return self
+	return self.set(func(values *holderValues) {
+		values.autoGenerateCertificates = autoGenerateCertificates
+	})
 }
 
 // SetEnableInsecureLogin 'enable-insecure-login' argument of Dashboard binary.
 func (self *holderBuilder) SetEnableInsecureLogin(enableInsecureLogin bool) *holderBuilder {
-	self.holder.enableInsecureLogin = enableInsecureLogin
-	return self
+	return self.set(func(values *holderValues) {
+		values.enableInsecureLogin = enableInsecureLogin
+	})
 }
 
 // SetDisableSettingsAuthorizer 'disable-settings-authorizer' argument of Dashboard binary.
 func (self *holderBuilder) SetDisableSettingsAuthorizer(disableSettingsAuthorizer bool) *holderBuilder {
-	self.holder.disableSettingsAuthorizer = disableSettingsAuthorizer
-	return self
+	return self.set(func(values *holderValues) {
+		values.disableSettingsAuthorizer = disableSettingsAuthorizer
+	})
 }
 
 // SetEnableSkipLogin 'enable-skip-login' argument of Dashboard binary.
 func (self *holderBuilder) SetEnableSkipLogin(enableSkipLogin bool) *holderBuilder {
-	self.holder.enableSkipLogin = enableSkipLogin
-	return self
+	return self.set(func(values *holderValues) {
+		values.enableSkipLogin = enableSkipLogin
+	})
 }
 
 // SetNamespace 'namespace' argument of Dashboard binary.
 func (self *holderBuilder) SetNamespace(namespace string) *holderBuilder {
-	self.holder.namespace = namespace
-	return self
+	return self.set(func(values *holderValues) {
+		values.namespace = namespace
+	})
 }
 
 // SetLocaleConfig 'locale-config' argument of Dashboard binary.
 func (self *holderBuilder) SetLocaleConfig(localeConfig string) *holderBuilder {
-	self.holder.localeConfig = localeConfig
-	return self
+	return self.set(func(values *holderValues) {
+		values.localeConfig = localeConfig
+	})
+}
+
+// SetEnableMetricsReadinessCheck 'enable-metrics-readiness-check' argument of Dashboard binary.
+func (self *holderBuilder) SetEnableMetricsReadinessCheck(enableMetricsReadinessCheck bool) *holderBuilder {
+	return self.set(func(values *holderValues) {
+		values.enableMetricsReadinessCheck = enableMetricsReadinessCheck
+	})
+}
+
+// SetChartRepositories 'chart-repositories' argument of Dashboard binary.
+func (self *holderBuilder) SetChartRepositories(chartRepositories map[string]string) *holderBuilder {
+	return self.set(func(values *holderValues) {
+		values.chartRepositories = chartRepositories
+	})
+}
+
+// SetMinResourceAutoRefreshInterval 'min-resource-auto-refresh-interval' argument of Dashboard binary.
+func (self *holderBuilder) SetMinResourceAutoRefreshInterval(minResourceAutoRefreshInterval int) *holderBuilder {
+	return self.set(func(values *holderValues) {
+		values.minResourceAutoRefreshInterval = minResourceAutoRefreshInterval
+	})
+}
+
+// SetFeatureGates 'feature-gates' argument of Dashboard binary.
+func (self *holderBuilder) SetFeatureGates(featureGates string) *holderBuilder {
+	return self.set(func(values *holderValues) {
+		values.featureGates = featureGates
+	})
+}
+
+// SetLoginNotice 'login-notice' argument of Dashboard binary.
+func (self *holderBuilder) SetLoginNotice(loginNotice string) *holderBuilder {
+	return self.set(func(values *holderValues) {
+		values.loginNotice = loginNotice
+	})
+}
+
+// SetLoginNoticeAcknowledgmentRequired 'login-notice-acknowledgment-required' argument of Dashboard binary.
+func (self *holderBuilder) SetLoginNoticeAcknowledgmentRequired(loginNoticeAcknowledgmentRequired bool) *holderBuilder {
+	return self.set(func(values *holderValues) {
+		values.loginNoticeAcknowledgmentRequired = loginNoticeAcknowledgmentRequired
+	})
+}
+
+// SetLogFormat 'log-format' argument of Dashboard binary.
+func (self *holderBuilder) SetLogFormat(logFormat string) *holderBuilder {
+	return self.set(func(values *holderValues) {
+		values.logFormat = logFormat
+	})
+}
+
+// SetTracingSampleRate 'tracing-sample-rate' argument of Dashboard binary.
+func (self *holderBuilder) SetTracingSampleRate(tracingSampleRate float64) *holderBuilder {
+	return self.set(func(values *holderValues) {
+		values.tracingSampleRate = tracingSampleRate
+	})
+}
+
+// SetEnableProfiling 'enable-profiling' argument of Dashboard binary.
+func (self *holderBuilder) SetEnableProfiling(enableProfiling bool) *holderBuilder {
+	return self.set(func(values *holderValues) {
+		values.enableProfiling = enableProfiling
+	})
+}
+
+// SetProfilingPort 'profiling-port' argument of Dashboard binary.
+func (self *holderBuilder) SetProfilingPort(profilingPort int) *holderBuilder {
+	return self.set(func(values *holderValues) {
+		values.profilingPort = profilingPort
+	})
+}
+
+// SetAccessLogFormat 'access-log-format' argument of Dashboard binary.
+func (self *holderBuilder) SetAccessLogFormat(accessLogFormat string) *holderBuilder {
+	return self.set(func(values *holderValues) {
+		values.accessLogFormat = accessLogFormat
+	})
+}
+
+// SetSlowRequestThreshold 'slow-request-threshold' argument of Dashboard binary.
+func (self *holderBuilder) SetSlowRequestThreshold(slowRequestThreshold int) *holderBuilder {
+	return self.set(func(values *holderValues) {
+		values.slowRequestThreshold = slowRequestThreshold
+	})
+}
+
+// SetServiceNodePortRange 'service-node-port-range' argument of Dashboard binary.
+func (self *holderBuilder) SetServiceNodePortRange(serviceNodePortRange string) *holderBuilder {
+	return self.set(func(values *holderValues) {
+		values.serviceNodePortRange = serviceNodePortRange
+	})
+}
+
+// SetAPIServerRequestTimeout 'apiserver-request-timeout' argument of Dashboard binary.
+func (self *holderBuilder) SetAPIServerRequestTimeout(apiserverRequestTimeout int) *holderBuilder {
+	return self.set(func(values *holderValues) {
+		values.apiserverRequestTimeout = apiserverRequestTimeout
+	})
+}
+
+// SetAPIServerRequestRetries 'apiserver-request-retries' argument of Dashboard binary.
+func (self *holderBuilder) SetAPIServerRequestRetries(apiserverRequestRetries int) *holderBuilder {
+	return self.set(func(values *holderValues) {
+		values.apiserverRequestRetries = apiserverRequestRetries
+	})
+}
+
+// SetVeleroNamespace 'velero-namespace' argument of Dashboard binary.
+func (self *holderBuilder) SetVeleroNamespace(veleroNamespace string) *holderBuilder {
+	return self.set(func(values *holderValues) {
+		values.veleroNamespace = veleroNamespace
+	})
+}
+
+// SetImageScannerURL 'image-scanner-url' argument of Dashboard binary.
+func (self *holderBuilder) SetImageScannerURL(imageScannerURL string) *holderBuilder {
+	return self.set(func(values *holderValues) {
+		values.imageScannerURL = imageScannerURL
+	})
+}
+
+// SetAlertWebhookURLs 'alert-webhook-url' argument of Dashboard binary.
+func (self *holderBuilder) SetAlertWebhookURLs(alertWebhookURLs []string) *holderBuilder {
+	return self.set(func(values *holderValues) {
+		values.alertWebhookURLs = alertWebhookURLs
+	})
+}
+
+// SetAlertSlackWebhookURLs 'alert-slack-webhook-url' argument of Dashboard binary.
+func (self *holderBuilder) SetAlertSlackWebhookURLs(alertSlackWebhookURLs []string) *holderBuilder {
+	return self.set(func(values *holderValues) {
+		values.alertSlackWebhookURLs = alertSlackWebhookURLs
+	})
+}
+
+// SetDashboardURL 'dashboard-url' argument of Dashboard binary.
+func (self *holderBuilder) SetDashboardURL(dashboardURL string) *holderBuilder {
+	return self.set(func(values *holderValues) {
+		values.dashboardURL = dashboardURL
+	})
+}
+
+// SetCostCPUCoreMonth 'cost-cpu-core-month' argument of Dashboard binary.
+func (self *holderBuilder) SetCostCPUCoreMonth(costCPUCoreMonth float64) *holderBuilder {
+	return self.set(func(values *holderValues) {
+		values.costCPUCoreMonth = costCPUCoreMonth
+	})
+}
+
+// SetCostMemoryGiBMonth 'cost-memory-gib-month' argument of Dashboard binary.
+func (self *holderBuilder) SetCostMemoryGiBMonth(costMemoryGiBMonth float64) *holderBuilder {
+	return self.set(func(values *holderValues) {
+		values.costMemoryGiBMonth = costMemoryGiBMonth
+	})
+}
+
+// SetCostCurrency 'cost-currency' argument of Dashboard binary.
+func (self *holderBuilder) SetCostCurrency(costCurrency string) *holderBuilder {
+	return self.set(func(values *holderValues) {
+		values.costCurrency = costCurrency
+	})
+}
+
+// SetPrometheusHost 'prometheus-host' argument of Dashboard binary.
+func (self *holderBuilder) SetPrometheusHost(prometheusHost string) *holderBuilder {
+	return self.set(func(values *holderValues) {
+		values.prometheusHost = prometheusHost
+	})
+}
+
+// SetFalcoToken 'falco-token' argument of Dashboard binary.
+func (self *holderBuilder) SetFalcoToken(falcoToken string) *holderBuilder {
+	return self.set(func(values *holderValues) {
+		values.falcoToken = falcoToken
+	})
+}
+
+// SetFalcoEventLimit 'falco-event-limit' argument of Dashboard binary.
+func (self *holderBuilder) SetFalcoEventLimit(falcoEventLimit int) *holderBuilder {
+	return self.set(func(values *holderValues) {
+		values.falcoEventLimit = falcoEventLimit
+	})
+}
+
+// SetShutdownGracePeriod 'shutdown-grace-period' argument of Dashboard binary.
+func (self *holderBuilder) SetShutdownGracePeriod(shutdownGracePeriod int) *holderBuilder {
+	return self.set(func(values *holderValues) {
+		values.shutdownGracePeriod = shutdownGracePeriod
+	})
+}
+
+// SetShutdownDrainDelay 'shutdown-drain-delay' argument of Dashboard binary.
+func (self *holderBuilder) SetShutdownDrainDelay(shutdownDrainDelay int) *holderBuilder {
+	return self.set(func(values *holderValues) {
+		values.shutdownDrainDelay = shutdownDrainDelay
+	})
+}
+
+// SetEnableHTTPSRedirect 'enable-https-redirect' argument of Dashboard binary.
+func (self *holderBuilder) SetEnableHTTPSRedirect(enableHTTPSRedirect bool) *holderBuilder {
+	return self.set(func(values *holderValues) {
+		values.enableHTTPSRedirect = enableHTTPSRedirect
+	})
+}
+
+// SetMaxRequestBodySize 'max-request-body-size' argument of Dashboard binary.
+func (self *holderBuilder) SetMaxRequestBodySize(maxRequestBodySize int64) *holderBuilder {
+	return self.set(func(values *holderValues) {
+		values.maxRequestBodySize = maxRequestBodySize
+	})
+}
+
+// SetMaxConcurrentRequestsPerClient 'max-concurrent-requests-per-client' argument of Dashboard binary.
+func (self *holderBuilder) SetMaxConcurrentRequestsPerClient(maxConcurrentRequestsPerClient int) *holderBuilder {
+	return self.set(func(values *holderValues) {
+		values.maxConcurrentRequestsPerClient = maxConcurrentRequestsPerClient
+	})
+}
+
+// SetTrustedProxies 'trusted-proxies' argument of Dashboard binary.
+func (self *holderBuilder) SetTrustedProxies(trustedProxies []string) *holderBuilder {
+	return self.set(func(values *holderValues) {
+		values.trustedProxies = trustedProxies
+	})
+}
+
+// SetManifestURLAllowedHosts 'manifest-url-allowed-hosts' argument of Dashboard binary.
+func (self *holderBuilder) SetManifestURLAllowedHosts(manifestURLAllowedHosts []string) *holderBuilder {
+	return self.set(func(values *holderValues) {
+		values.manifestURLAllowedHosts = manifestURLAllowedHosts
+	})
+}
+
+// SetOIDCIssuerURL 'oidc-issuer-url' argument of Dashboard binary.
+func (self *holderBuilder) SetOIDCIssuerURL(oidcIssuerURL string) *holderBuilder {
+	return self.set(func(values *holderValues) {
+		values.oidcIssuerURL = oidcIssuerURL
+	})
+}
+
+// SetKubeBenchImage 'kube-bench-image' argument of Dashboard binary.
+func (self *holderBuilder) SetKubeBenchImage(kubeBenchImage string) *holderBuilder {
+	return self.set(func(values *holderValues) {
+		values.kubeBenchImage = kubeBenchImage
+	})
+}
+
+// SetExecRecordingDir 'exec-recording-dir' argument of Dashboard binary.
+func (self *holderBuilder) SetExecRecordingDir(execRecordingDir string) *holderBuilder {
+	return self.set(func(values *holderValues) {
+		values.execRecordingDir = execRecordingDir
+	})
+}
+
+// SetEnableProxyImpersonation 'enable-proxy-impersonation' argument of Dashboard binary.
+func (self *holderBuilder) SetEnableProxyImpersonation(enableProxyImpersonation bool) *holderBuilder {
+	return self.set(func(values *holderValues) {
+		values.enableProxyImpersonation = enableProxyImpersonation
+	})
+}
+
+// SetImpersonatingProxies 'impersonating-proxies' argument of Dashboard binary.
+func (self *holderBuilder) SetImpersonatingProxies(impersonatingProxies []string) *holderBuilder {
+	return self.set(func(values *holderValues) {
+		values.impersonatingProxies = impersonatingProxies
+	})
+}
+
+// SetProxyImpersonationGroups 'proxy-impersonation-groups' argument of Dashboard binary.
+func (self *holderBuilder) SetProxyImpersonationGroups(proxyImpersonationGroups []string) *holderBuilder {
+	return self.set(func(values *holderValues) {
+		values.proxyImpersonationGroups = proxyImpersonationGroups
+	})
 }
 
 // GetHolderBuilder returns singleton instance of argument holder builder.
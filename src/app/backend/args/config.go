@@ -0,0 +1,169 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package args
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/pflag"
+	"gopkg.in/yaml.v2"
+)
+
+// ConfigFile applies values of flags from a YAML file, i.e. `enable-skip-login: true`, to the flag set. Flags
+// given on the command line take precedence over the file. Lists and maps are given as YAML sequences and
+// mappings.
+type ConfigFile struct {
+	path  string
+	flags *pflag.FlagSet
+	// commandLine holds names of flags given on the command line.
+	commandLine map[string]bool
+	// applied holds names of flags set from the file, so that they can be reset when removed from it.
+	applied  map[string]bool
+	checksum [sha256.Size]byte
+}
+
+// NewConfigFile creates ConfigFile for given path. It has to be created after the command line is parsed.
+func NewConfigFile(path string, flags *pflag.FlagSet) *ConfigFile {
+	commandLine := make(map[string]bool)
+	flags.Visit(func(flag *pflag.Flag) {
+		commandLine[flag.Name] = true
+	})
+
+	return &ConfigFile{path: path, flags: flags, commandLine: commandLine, applied: make(map[string]bool)}
+}
+
+// Load reads the file and applies it to the flag set. Returns false if content of the file did not change since
+// it was applied last time. Flags removed from the file are reset to their defaults.
+func (self *ConfigFile) Load() (bool, error) {
+	content, err := ioutil.ReadFile(self.path)
+	if err != nil {
+		return false, err
+	}
+
+	checksum := sha256.Sum256(content)
+	if checksum == self.checksum {
+		return false, nil
+	}
+
+	values := make(map[string]interface{})
+	if err := yaml.Unmarshal(content, &values); err != nil {
+		return false, fmt.Errorf("invalid config file %s: %s", self.path, err)
+	}
+
+	// All names are checked first, so that a typo does not leave the flag set half applied.
+	for name := range values {
+		if self.flags.Lookup(name) == nil {
+			return false, fmt.Errorf("unknown flag %s in config file %s", name, self.path)
+		}
+	}
+
+	for name := range self.applied {
+		if _, ok := values[name]; !ok {
+			flag := self.flags.Lookup(name)
+			if err := setFlag(flag, defaultValues(flag)); err != nil {
+				return false, err
+			}
+			delete(self.applied, name)
+		}
+	}
+
+	for name, value := range values {
+		if self.commandLine[name] {
+			continue
+		}
+
+		if err := setFlag(self.flags.Lookup(name), toStrings(value)); err != nil {
+			return false, fmt.Errorf("invalid value of %s in config file %s: %s", name, self.path, err)
+		}
+		self.applied[name] = true
+	}
+
+	self.checksum = checksum
+	return true, nil
+}
+
+// Watch checks the file for changes every period and calls onChange after changes are applied. Content of the
+// file is compared, not its modification time, because files mounted from config maps are replaced by symlink
+// swaps. Invalid changes are logged and skipped. It never returns.
+func (self *ConfigFile) Watch(period time.Duration, onChange func()) {
+	for range time.Tick(period) {
+		changed, err := self.Load()
+		if err != nil {
+			log.Printf("Could not reload config file. Reason: %s", err)
+			continue
+		}
+
+		if changed {
+			log.Printf("Config file %s changed, applying new settings", self.path)
+			onChange()
+		}
+	}
+}
+
+// setFlag sets value of the flag. Lists are replaced as a whole instead of being appended to. Maps can only be
+// merged into, so removed entries stay set until restart.
+func setFlag(flag *pflag.Flag, values []string) error {
+	if sliceValue, ok := flag.Value.(pflag.SliceValue); ok {
+		return sliceValue.Replace(values)
+	}
+	if flag.Value.Type() == "stringToString" && len(values) == 0 {
+		return nil
+	}
+	return flag.Value.Set(strings.Join(values, ","))
+}
+
+// defaultValues returns default value of the flag in the form accepted by setFlag.
+func defaultValues(flag *pflag.Flag) []string {
+	if _, ok := flag.Value.(pflag.SliceValue); ok {
+		value := strings.Trim(flag.DefValue, "[]")
+		if len(value) == 0 {
+			return []string{}
+		}
+		return strings.Split(value, ",")
+	}
+	if flag.Value.Type() == "stringToString" {
+		return []string{}
+	}
+	return []string{flag.DefValue}
+}
+
+// toStrings converts YAML value to values of the flag. Mappings are converted to sorted key=value pairs.
+func toStrings(value interface{}) []string {
+	switch typed := value.(type) {
+	case nil:
+		return []string{""}
+	case []interface{}:
+		result := make([]string, 0, len(typed))
+		for _, item := range typed {
+			result = append(result, fmt.Sprint(item))
+		}
+		return result
+	case map[interface{}]interface{}:
+		result := make([]string, 0, len(typed))
+		for key, item := range typed {
+			result = append(result, fmt.Sprintf("%v=%v", key, item))
+		}
+		sort.Strings(result)
+		return result
+	default:
+		return []string{fmt.Sprint(typed)}
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package args
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/spf13/pflag"
+)
+
+func TestConfigFile_Load(t *testing.T) {
+	dir, err := ioutil.TempDir("", "config-file")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "config.yaml")
+
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	port := flags.Int("port", 8443, "")
+	banner := flags.String("system-banner", "", "")
+	modes := flags.StringSlice("authentication-mode", []string{"token"}, "")
+	repositories := flags.StringToString("chart-repositories", map[string]string{}, "")
+	if err := flags.Parse([]string{"--port=9443"}); err != nil {
+		t.Fatal(err)
+	}
+	configFile := NewConfigFile(path, flags)
+
+	write := func(content string) {
+		if err := ioutil.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write(`
+port: 7443
+system-banner: Maintenance tonight
+authentication-mode: [token, basic]
+chart-repositories:
+  stable: https://charts.example.com
+`)
+	if changed, err := configFile.Load(); err != nil || !changed {
+		t.Fatalf("Load() == (%t, %v), expected config to be applied", changed, err)
+	}
+	if *port != 9443 || *banner != "Maintenance tonight" || !reflect.DeepEqual(*modes, []string{"token", "basic"}) ||
+		(*repositories)["stable"] != "https://charts.example.com" {
+		t.Errorf("Load() applied port=%d system-banner=%s authentication-mode=%v chart-repositories=%v", *port,
+			*banner, *modes, *repositories)
+	}
+
+	if changed, err := configFile.Load(); err != nil || changed {
+		t.Errorf("Load() == (%t, %v), expected no changes", changed, err)
+	}
+
+	write("authentication-mode: [basic]\n")
+	if changed, err := configFile.Load(); err != nil || !changed {
+		t.Fatalf("Load() == (%t, %v), expected config to be applied", changed, err)
+	}
+	if *banner != "" || !reflect.DeepEqual(*modes, []string{"basic"}) {
+		t.Errorf("Load() expected removed flag to be reset and list to be replaced, got system-banner=%s "+
+			"authentication-mode=%v", *banner, *modes)
+	}
+
+	write("system-baner: typo\n")
+	if _, err := configFile.Load(); err == nil {
+		t.Error("Load() expected error for unknown flag")
+	}
+	if !reflect.DeepEqual(*modes, []string{"basic"}) {
+		t.Errorf("Load() expected to keep previous values on error, got authentication-mode=%v", *modes)
+	}
+}
@@ -16,15 +16,33 @@ package args
 
 import (
 	"net"
+	"sync/atomic"
 
 	"github.com/kubernetes/dashboard/src/app/backend/cert/api"
 )
 
-var Holder = &holder{}
+var Holder = newHolder()
 
 // Argument holder structure. It is private to make sure that only 1 instance can be created. It holds all
-// arguments values passed to Dashboard binary.
+// arguments values passed to Dashboard binary. Values are kept in an immutable snapshot, that is replaced as a
+// whole when arguments change, so that they can be read by requests while the config file is reloaded.
 type holder struct {
+	values atomic.Value
+}
+
+func newHolder() *holder {
+	result := &holder{}
+	result.values.Store(&holderValues{})
+	return result
+}
+
+// load returns current snapshot of the argument values. It must not be modified.
+func (self *holder) load() *holderValues {
+	return self.values.Load().(*holderValues)
+}
+
+// holderValues are values of all arguments of Dashboard binary.
+type holderValues struct {
 	insecurePort            int
 	port                    int
 	tokenTTL                int
@@ -54,133 +72,369 @@ type holder struct {
 
 	enableSkipLogin bool
 
-	localeConfig string
+	localeConfig                      string
+	enableMetricsReadinessCheck       bool
+	chartRepositories                 map[string]string
+	minResourceAutoRefreshInterval    int
+	featureGates                      string
+	loginNotice                       string
+	loginNoticeAcknowledgmentRequired bool
+	logFormat                         string
+	tracingSampleRate                 float64
+	enableProfiling                   bool
+	profilingPort                     int
+	accessLogFormat                   string
+	slowRequestThreshold              int
+	serviceNodePortRange              string
+	apiserverRequestTimeout           int
+	apiserverRequestRetries           int
+	veleroNamespace                   string
+	imageScannerURL                   string
+	alertWebhookURLs                  []string
+	alertSlackWebhookURLs             []string
+	dashboardURL                      string
+	costCPUCoreMonth                  float64
+	costMemoryGiBMonth                float64
+	costCurrency                      string
+	prometheusHost                    string
+	falcoToken                        string
+	falcoEventLimit                   int
+	shutdownGracePeriod               int
+	shutdownDrainDelay                int
+	enableHTTPSRedirect               bool
+	maxRequestBodySize                int64
+	maxConcurrentRequestsPerClient    int
+	trustedProxies                    []string
+	execRecordingDir                  string
+	enableProxyImpersonation          bool
+	impersonatingProxies              []string
+	proxyImpersonationGroups          []string
+	kubeBenchImage                    string
+	oidcIssuerURL                     string
+	manifestURLAllowedHosts           []string
 }
 
 // GetInsecurePort 'insecure-port' argument of Dashboard binary.
 func (self *holder) GetInsecurePort() int {
-	return self.insecurePort
+	return self.load().insecurePort
 }
 
 // GetPort 'port' argument of Dashboard binary.
 func (self *holder) GetPort() int {
-	return self.port
+	return self.load().port
 }
 
 // GetTokenTTL 'token-ttl' argument of Dashboard binary.
 func (self *holder) GetTokenTTL() int {
-	return self.tokenTTL
+	return self.load().tokenTTL
 }
 
 // GetMetricClientCheckPeriod 'metric-client-check-period' argument of Dashboard binary.
 func (self *holder) GetMetricClientCheckPeriod() int {
-	return self.metricClientCheckPeriod
+	return self.load().metricClientCheckPeriod
 }
 
 // GetInsecureBindAddress 'insecure-bind-address' argument of Dashboard binary.
 func (self *holder) GetInsecureBindAddress() net.IP {
-	return self.insecureBindAddress
+	return self.load().insecureBindAddress
 }
 
 // GetBindAddress 'bind-address' argument of Dashboard binary.
 func (self *holder) GetBindAddress() net.IP {
-	return self.bindAddress
+	return self.load().bindAddress
 }
 
 // GetDefaultCertDir 'default-cert-dir' argument of Dashboard binary.
 func (self *holder) GetDefaultCertDir() string {
-	return self.defaultCertDir
+	return self.load().defaultCertDir
 }
 
 // GetCertFile 'tls-cert-file' argument of Dashboard binary.
 func (self *holder) GetCertFile() string {
-	if len(self.certFile) == 0 && self.autoGenerateCertificates {
+	values := self.load()
+	if len(values.certFile) == 0 && values.autoGenerateCertificates {
 		return api.DashboardCertName
 	}
 
-	return self.certFile
+	return values.certFile
 }
 
 // GetKeyFile 'tls-key-file' argument of Dashboard binary.
 func (self *holder) GetKeyFile() string {
-	if len(self.keyFile) == 0 && self.autoGenerateCertificates {
+	values := self.load()
+	if len(values.keyFile) == 0 && values.autoGenerateCertificates {
 		return api.DashboardKeyName
 	}
 
-	return self.keyFile
+	return values.keyFile
 }
 
 // GetApiServerHost 'apiserver-host' argument of Dashboard binary.
 func (self *holder) GetApiServerHost() string {
-	return self.apiServerHost
+	return self.load().apiServerHost
 }
 
 // GetMetricsProvider 'metrics-provider' argument of Dashboard binary.
 func (self *holder) GetMetricsProvider() string {
-	return self.metricsProvider
+	return self.load().metricsProvider
 }
 
 // GetHeapsterHost 'heapster-host' argument of Dashboard binary.
 func (self *holder) GetHeapsterHost() string {
-	return self.heapsterHost
+	return self.load().heapsterHost
 }
 
 // GetSidecarHost 'sidecar-host' argument of Dashboard binary.
 func (self *holder) GetSidecarHost() string {
-	return self.sidecarHost
+	return self.load().sidecarHost
 }
 
 // GetKubeConfigFile 'kubeconfig' argument of Dashboard binary.
 func (self *holder) GetKubeConfigFile() string {
-	return self.kubeConfigFile
+	return self.load().kubeConfigFile
 }
 
 // GetSystemBanner 'system-banner' argument of Dashboard binary.
 func (self *holder) GetSystemBanner() string {
-	return self.systemBanner
+	return self.load().systemBanner
 }
 
 // GetSystemBannerSeverity 'system-banner-severity' argument of Dashboard binary.
 func (self *holder) GetSystemBannerSeverity() string {
-	return self.systemBannerSeverity
+	return self.load().systemBannerSeverity
 }
 
 // LogLevel 'api-log-level' argument of Dashboard binary.
 func (self *holder) GetAPILogLevel() string {
-	return self.apiLogLevel
+	return self.load().apiLogLevel
 }
 
 // GetAuthenticationMode 'authentication-mode' argument of Dashboard binary.
 func (self *holder) GetAuthenticationMode() []string {
-	return self.authenticationMode
+	return self.load().authenticationMode
 }
 
 // GetAutoGenerateCertificates 'auto-generate-certificates' argument of Dashboard binary.
 func (self *holder) GetAutoGenerateCertificates() bool {
-	return self.autoGenerateCertificates
+	return self.load().autoGenerateCertificates
 }
 
 // GetEnableInsecureLogin 'enable-insecure-login' argument of Dashboard binary.
 func (self *holder) GetEnableInsecureLogin() bool {
-	return self.enableInsecureLogin
+	return self.load().enableInsecureLogin
 }
 
 // GetDisableSettingsAuthorizer 'disable-settings-authorizer' argument of Dashboard binary.
 func (self *holder) GetDisableSettingsAuthorizer() bool {
-	return self.disableSettingsAuthorizer
+	return self.load().disableSettingsAuthorizer
 }
 
 // GetEnableSkipLogin 'enable-skip-login' argument of Dashboard binary.
 func (self *holder) GetEnableSkipLogin() bool {
-	return self.enableSkipLogin
+	return self.load().enableSkipLogin
 }
 
 // GetNamespace 'namespace' argument of Dashboard binary.
 func (self *holder) GetNamespace() string {
-	return self.namespace
+	return self.load().namespace
 }
 
 // GetLocaleConfig 'locale-config' argument of Dashboard binary.
 func (self *holder) GetLocaleConfig() string {
-	return self.localeConfig
+	return self.load().localeConfig
+}
+
+// GetEnableMetricsReadinessCheck 'enable-metrics-readiness-check' argument of Dashboard binary.
+func (self *holder) GetEnableMetricsReadinessCheck() bool {
+	return self.load().enableMetricsReadinessCheck
+}
+
+// GetChartRepositories 'chart-repositories' argument of Dashboard binary.
+func (self *holder) GetChartRepositories() map[string]string {
+	return self.load().chartRepositories
+}
+
+// GetMinResourceAutoRefreshInterval 'min-resource-auto-refresh-interval' argument of Dashboard binary.
+func (self *holder) GetMinResourceAutoRefreshInterval() int {
+	return self.load().minResourceAutoRefreshInterval
+}
+
+// GetFeatureGates 'feature-gates' argument of Dashboard binary.
+func (self *holder) GetFeatureGates() string {
+	return self.load().featureGates
+}
+
+// GetLoginNotice 'login-notice' argument of Dashboard binary.
+func (self *holder) GetLoginNotice() string {
+	return self.load().loginNotice
+}
+
+// GetLoginNoticeAcknowledgmentRequired 'login-notice-acknowledgment-required' argument of Dashboard binary.
+func (self *holder) GetLoginNoticeAcknowledgmentRequired() bool {
+	return self.load().loginNoticeAcknowledgmentRequired
+}
+
+// GetLogFormat 'log-format' argument of Dashboard binary.
+func (self *holder) GetLogFormat() string {
+	return self.load().logFormat
+}
+
+// GetTracingSampleRate 'tracing-sample-rate' argument of Dashboard binary.
+func (self *holder) GetTracingSampleRate() float64 {
+	return self.load().tracingSampleRate
+}
+
+// GetEnableProfiling 'enable-profiling' argument of Dashboard binary.
+func (self *holder) GetEnableProfiling() bool {
+	return self.load().enableProfiling
+}
+
+// GetProfilingPort 'profiling-port' argument of Dashboard binary.
+func (self *holder) GetProfilingPort() int {
+	return self.load().profilingPort
+}
+
+// GetAccessLogFormat 'access-log-format' argument of Dashboard binary.
+func (self *holder) GetAccessLogFormat() string {
+	return self.load().accessLogFormat
+}
+
+// GetSlowRequestThreshold 'slow-request-threshold' argument of Dashboard binary.
+func (self *holder) GetSlowRequestThreshold() int {
+	return self.load().slowRequestThreshold
+}
+
+// GetServiceNodePortRange 'service-node-port-range' argument of Dashboard binary.
+func (self *holder) GetServiceNodePortRange() string {
+	return self.load().serviceNodePortRange
+}
+
+// GetAPIServerRequestTimeout 'apiserver-request-timeout' argument of Dashboard binary.
+func (self *holder) GetAPIServerRequestTimeout() int {
+	return self.load().apiserverRequestTimeout
+}
+
+// GetAPIServerRequestRetries 'apiserver-request-retries' argument of Dashboard binary.
+func (self *holder) GetAPIServerRequestRetries() int {
+	return self.load().apiserverRequestRetries
+}
+
+// GetVeleroNamespace 'velero-namespace' argument of Dashboard binary.
+func (self *holder) GetVeleroNamespace() string {
+	return self.load().veleroNamespace
+}
+
+// GetImageScannerURL 'image-scanner-url' argument of Dashboard binary.
+func (self *holder) GetImageScannerURL() string {
+	return self.load().imageScannerURL
+}
+
+// GetAlertWebhookURLs 'alert-webhook-url' argument of Dashboard binary.
+func (self *holder) GetAlertWebhookURLs() []string {
+	return self.load().alertWebhookURLs
+}
+
+// GetAlertSlackWebhookURLs 'alert-slack-webhook-url' argument of Dashboard binary.
+func (self *holder) GetAlertSlackWebhookURLs() []string {
+	return self.load().alertSlackWebhookURLs
+}
+
+// GetDashboardURL 'dashboard-url' argument of Dashboard binary.
+func (self *holder) GetDashboardURL() string {
+	return self.load().dashboardURL
+}
+
+// GetCostCPUCoreMonth 'cost-cpu-core-month' argument of Dashboard binary.
+func (self *holder) GetCostCPUCoreMonth() float64 {
+	return self.load().costCPUCoreMonth
+}
+
+// GetCostMemoryGiBMonth 'cost-memory-gib-month' argument of Dashboard binary.
+func (self *holder) GetCostMemoryGiBMonth() float64 {
+	return self.load().costMemoryGiBMonth
+}
+
+// GetCostCurrency 'cost-currency' argument of Dashboard binary.
+func (self *holder) GetCostCurrency() string {
+	return self.load().costCurrency
+}
+
+// GetPrometheusHost 'prometheus-host' argument of Dashboard binary.
+func (self *holder) GetPrometheusHost() string {
+	return self.load().prometheusHost
+}
+
+// GetFalcoToken 'falco-token' argument of Dashboard binary.
+func (self *holder) GetFalcoToken() string {
+	return self.load().falcoToken
+}
+
+// GetFalcoEventLimit 'falco-event-limit' argument of Dashboard binary.
+func (self *holder) GetFalcoEventLimit() int {
+	return self.load().falcoEventLimit
+}
+
+// GetShutdownGracePeriod 'shutdown-grace-period' argument of Dashboard binary.
+func (self *holder) GetShutdownGracePeriod() int {
+	return self.load().shutdownGracePeriod
+}
+
+// GetShutdownDrainDelay 'shutdown-drain-delay' argument of Dashboard binary.
+func (self *holder) GetShutdownDrainDelay() int {
+	return self.load().shutdownDrainDelay
+}
+
+// GetEnableHTTPSRedirect 'enable-https-redirect' argument of Dashboard binary.
+func (self *holder) GetEnableHTTPSRedirect() bool {
+	return self.load().enableHTTPSRedirect
+}
+
+// GetMaxRequestBodySize 'max-request-body-size' argument of Dashboard binary.
+func (self *holder) GetMaxRequestBodySize() int64 {
+	return self.load().maxRequestBodySize
+}
+
+// GetMaxConcurrentRequestsPerClient 'max-concurrent-requests-per-client' argument of Dashboard binary.
+func (self *holder) GetMaxConcurrentRequestsPerClient() int {
+	return self.load().maxConcurrentRequestsPerClient
+}
+
+// GetTrustedProxies 'trusted-proxies' argument of Dashboard binary.
+func (self *holder) GetTrustedProxies() []string {
+	return self.load().trustedProxies
+}
+
+// GetExecRecordingDir 'exec-recording-dir' argument of Dashboard binary.
+func (self *holder) GetExecRecordingDir() string {
+	return self.load().execRecordingDir
+}
+
+// GetEnableProxyImpersonation 'enable-proxy-impersonation' argument of Dashboard binary.
+func (self *holder) GetEnableProxyImpersonation() bool {
+	return self.load().enableProxyImpersonation
+}
+
+// GetImpersonatingProxies 'impersonating-proxies' argument of Dashboard binary.
+func (self *holder) GetImpersonatingProxies() []string {
+	return self.load().impersonatingProxies
+}
+
+// GetProxyImpersonationGroups 'proxy-impersonation-groups' argument of Dashboard binary.
+func (self *holder) GetProxyImpersonationGroups() []string {
+	return self.load().proxyImpersonationGroups
+}
+
+// GetKubeBenchImage 'kube-bench-image' argument of Dashboard binary.
+func (self *holder) GetKubeBenchImage() string {
+	return self.load().kubeBenchImage
+}
+
+// GetManifestURLAllowedHosts 'manifest-url-allowed-hosts' argument of Dashboard binary.
+func (self *holder) GetManifestURLAllowedHosts() []string {
+	return self.load().manifestURLAllowedHosts
+}
+
+// GetOIDCIssuerURL 'oidc-issuer-url' argument of Dashboard binary.
+func (self *holder) GetOIDCIssuerURL() string {
+	return self.load().oidcIssuerURL
 }
@@ -0,0 +1,60 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package args
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestHolderBuilderUpdate(t *testing.T) {
+	defer func() { builder.SetLoginNotice("").SetNamespace("") }()
+	builder.SetLoginNotice("old").SetNamespace("old")
+
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+
+			if values := Holder.load(); values.loginNotice != values.namespace {
+				t.Errorf("Snapshot mixes old and new values: %q and %q", values.loginNotice, values.namespace)
+				return
+			}
+		}
+	}()
+
+	for _, value := range []string{"first", "second", "third"} {
+		builder.Update(func() {
+			builder.SetLoginNotice(value)
+			if Holder.GetLoginNotice() == value {
+				t.Errorf("Value %q was published before the update ended", value)
+			}
+			builder.SetNamespace(value)
+		})
+	}
+	close(stop)
+	wg.Wait()
+
+	if Holder.GetLoginNotice() != "third" || Holder.GetNamespace() != "third" {
+		t.Errorf("Expected values of the last update, got %q and %q", Holder.GetLoginNotice(), Holder.GetNamespace())
+	}
+}
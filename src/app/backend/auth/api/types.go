@@ -29,6 +29,10 @@ const (
 
 	// Expiration time (in seconds) of tokens generated by dashboard. Default: 15 min.
 	DefaultTokenTTL = 900
+
+	// Time (in seconds) before expiration of a bearer token stored in the token generated by dashboard, when
+	// the bearer token is refreshed, if it can be refreshed. Default: 2 min.
+	CredentialRefreshWindow = 120
 )
 
 // AuthenticationModes represents auth modes supported by dashboard.
@@ -85,6 +89,10 @@ type AuthManager interface {
 	// Refresh takes valid token that hasn't expired yet and returns a new one with expiration time set to TokenTTL. In
 	// case provided token has expired, token expiration error is returned.
 	Refresh(string) (string, error)
+	// RefreshCredentials takes valid token and returns a new one, if bearer token stored in it expires within
+	// CredentialRefreshWindow and it can be refreshed, i.e. OIDC ID token obtained from kubeconfig with refresh
+	// token. Empty string is returned when credentials do not need to be refreshed.
+	RefreshCredentials(string) (string, error)
 	// AuthenticationModes returns array of auth modes supported by dashboard.
 	AuthenticationModes() []AuthenticationMode
 	// AuthenticationSkippable tells if the Skip button should be enabled or not
@@ -127,6 +135,16 @@ type LoginSpec struct {
 	// KubeConfig is the content of users' kubeconfig file. It will be parsed and auth data will be extracted.
 	// Kubeconfig can not contain any paths. All data has to be provided within the file.
 	KubeConfig string `json:"kubeconfig,omitempty"`
+	// NoticeAcknowledged is true when user acknowledged login notice.
+	NoticeAcknowledged bool `json:"noticeAcknowledged,omitempty"`
+}
+
+// LoginNoticeResponse contains notice, i.e. legal disclaimer, that is displayed on the login page.
+type LoginNoticeResponse struct {
+	// Message of the notice. Notice is not displayed when empty.
+	Message string `json:"message"`
+	// AcknowledgmentRequired is true when user has to acknowledge the notice before logging in.
+	AcknowledgmentRequired bool `json:"acknowledgmentRequired"`
 }
 
 // AuthResponse is returned from our backend as a response for login/refresh requests. It contains generated JWEToken
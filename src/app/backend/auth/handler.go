@@ -15,18 +15,32 @@
 package auth
 
 import (
+	"fmt"
+	"log"
+	"net"
 	"net/http"
+	"os"
+	"time"
 
 	"github.com/emicklei/go-restful"
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	"github.com/kubernetes/dashboard/src/app/backend/args"
 	authApi "github.com/kubernetes/dashboard/src/app/backend/auth/api"
+	"github.com/kubernetes/dashboard/src/app/backend/client"
+	clientapi "github.com/kubernetes/dashboard/src/app/backend/client/api"
 	"github.com/kubernetes/dashboard/src/app/backend/errors"
 	"github.com/kubernetes/dashboard/src/app/backend/validation"
 )
 
 // AuthHandler manages all endpoints related to dashboard auth, such as login.
 type AuthHandler struct {
-	manager authApi.AuthManager
+	manager       authApi.AuthManager
+	clientManager clientapi.ClientManager
+
+	// podName is a name of the Dashboard pod, that login notice acknowledgments are recorded as events of.
+	podName string
 }
 
 // Install creates new endpoints for dashboard auth, such as login. It allows user to log in to dashboard using
@@ -54,23 +68,37 @@ func (self AuthHandler) Install(ws *restful.WebService) {
 		ws.GET("/login/skippable").
 			To(self.handleLoginSkippable).
 			Writes(authApi.LoginSkippableResponse{}))
+	ws.Route(
+		ws.GET("/login/notice").
+			To(self.handleLoginNotice).
+			Writes(authApi.LoginNoticeResponse{}))
+	ws.Route(
+		ws.POST("/login/notice/acknowledgment").
+			To(self.handleLoginNoticeAcknowledgment))
 }
 
 func (self AuthHandler) handleLogin(request *restful.Request, response *restful.Response) {
 	loginSpec := new(authApi.LoginSpec)
 	if err := request.ReadEntity(loginSpec); err != nil {
-		response.AddHeader("Content-Type", "text/plain")
-		response.WriteErrorString(errors.HandleHTTPError(err), err.Error()+"\n")
+		errors.WriteError(request, response, err)
+		return
+	}
+
+	if args.Holder.GetLoginNoticeAcknowledgmentRequired() && !loginSpec.NoticeAcknowledged {
+		errors.WriteError(request, response, errors.NewForbidden(errors.MsgLoginNoticeNotAcknowledgedError))
 		return
 	}
 
 	loginResponse, err := self.manager.Login(loginSpec)
 	if err != nil {
-		response.AddHeader("Content-Type", "text/plain")
-		response.WriteErrorString(errors.HandleHTTPError(err), err.Error()+"\n")
+		errors.WriteError(request, response, err)
 		return
 	}
 
+	if loginSpec.NoticeAcknowledged && len(loginResponse.Errors) == 0 {
+		self.recordNoticeAcknowledgment(request, loginResponse.JWEToken)
+	}
+
 	response.WriteHeaderAndEntity(http.StatusOK, loginResponse)
 }
 
@@ -81,15 +109,13 @@ func (self *AuthHandler) handleLoginStatus(request *restful.Request, response *r
 func (self *AuthHandler) handleJWETokenRefresh(request *restful.Request, response *restful.Response) {
 	tokenRefreshSpec := new(authApi.TokenRefreshSpec)
 	if err := request.ReadEntity(tokenRefreshSpec); err != nil {
-		response.AddHeader("Content-Type", "text/plain")
-		response.WriteErrorString(errors.HandleHTTPError(err), err.Error()+"\n")
+		errors.WriteError(request, response, err)
 		return
 	}
 
 	refreshedJWEToken, err := self.manager.Refresh(tokenRefreshSpec.JWEToken)
 	if err != nil {
-		response.AddHeader("Content-Type", "text/plain")
-		response.WriteErrorString(errors.HandleHTTPError(err), err.Error()+"\n")
+		errors.WriteError(request, response, err)
 		return
 	}
 
@@ -107,7 +133,100 @@ func (self *AuthHandler) handleLoginSkippable(request *restful.Request, response
 	response.WriteHeaderAndEntity(http.StatusOK, authApi.LoginSkippableResponse{Skippable: self.manager.AuthenticationSkippable()})
 }
 
-// NewAuthHandler created AuthHandler instance.
-func NewAuthHandler(manager authApi.AuthManager) AuthHandler {
-	return AuthHandler{manager: manager}
+func (self *AuthHandler) handleLoginNotice(request *restful.Request, response *restful.Response) {
+	response.WriteHeaderAndEntity(http.StatusOK, authApi.LoginNoticeResponse{
+		Message:                args.Holder.GetLoginNotice(),
+		AcknowledgmentRequired: args.Holder.GetLoginNoticeAcknowledgmentRequired(),
+	})
+}
+
+// Users that skip login page acknowledge the notice without logging in, so it is recorded separately.
+func (self *AuthHandler) handleLoginNoticeAcknowledgment(request *restful.Request, response *restful.Response) {
+	self.recordNoticeAcknowledgment(request, "")
+	response.WriteHeader(http.StatusOK)
+}
+
+// recordNoticeAcknowledgment records acknowledgment of the login notice in the log and as an event of the
+// Dashboard pod, so that it outlives the pod. User is resolved from the token generated by the login or, if it is
+// empty, from credentials of the request. Users that skipped login are recorded as "-".
+func (self *AuthHandler) recordNoticeAcknowledgment(request *restful.Request, jweToken string) {
+	if len(args.Holder.GetLoginNotice()) == 0 {
+		return
+	}
+
+	if len(jweToken) > 0 {
+		// Credentials of the login request are not used, only the verified ones stored in the generated token.
+		loginRequest := *request.Request
+		loginRequest.Header = http.Header{}
+		loginRequest.Header.Set(client.JWETokenHeader, jweToken)
+		request = restful.NewRequest(&loginRequest)
+	}
+
+	username, err := self.clientManager.Username(request)
+	if err != nil || len(username) == 0 {
+		username = "-"
+	}
+	// Remote address of requests forwarded by trusted proxies is already replaced with address of the client.
+	host, _, err := net.SplitHostPort(request.Request.RemoteAddr)
+	if err != nil {
+		host = request.Request.RemoteAddr
+	}
+
+	message := fmt.Sprintf("Login notice acknowledged by user %s from %s", username, host)
+	log.Print(message)
+	if len(self.podName) == 0 {
+		return
+	}
+
+	now := metaV1.NewTime(time.Now())
+	namespace := args.Holder.GetNamespace()
+	_, err = self.clientManager.InsecureClient().CoreV1().Events(namespace).Create(&v1.Event{
+		ObjectMeta: metaV1.ObjectMeta{GenerateName: self.podName + ".", Namespace: namespace},
+		InvolvedObject: v1.ObjectReference{
+			APIVersion: "v1",
+			Kind:       "Pod",
+			Namespace:  namespace,
+			Name:       self.podName,
+		},
+		Reason:         "LoginNoticeAcknowledged",
+		Message:        message,
+		Source:         v1.EventSource{Component: "kubernetes-dashboard"},
+		FirstTimestamp: now,
+		LastTimestamp:  now,
+		Count:          1,
+		Type:           v1.EventTypeNormal,
+	})
+	if err != nil {
+		log.Printf("Could not record login notice acknowledgment as an event. Reason: %s", err)
+	}
+}
+
+// CreateCredentialRefreshFilter returns filter, that refreshes credentials stored in the token of the request, if
+// they expire soon, so that users are not logged out in the middle of their work. Refreshed token is used for the
+// request and is returned in the response header, so that the frontend can roll the session. Refresh errors are
+// only logged, request is processed with original credentials.
+func CreateCredentialRefreshFilter(manager authApi.AuthManager) restful.FilterFunction {
+	return func(request *restful.Request, response *restful.Response, chain *restful.FilterChain) {
+		jweToken := request.HeaderParameter(client.JWETokenHeader)
+		if len(jweToken) == 0 || len(request.HeaderParameter("Authorization")) > 0 {
+			chain.ProcessFilter(request, response)
+			return
+		}
+
+		refreshed, err := manager.RefreshCredentials(jweToken)
+		if err != nil {
+			log.Printf("Could not refresh credentials of the request. Reason: %s", err)
+		} else if len(refreshed) > 0 {
+			request.Request.Header.Set(client.JWETokenHeader, refreshed)
+			response.AddHeader(client.JWETokenHeader, refreshed)
+		}
+
+		chain.ProcessFilter(request, response)
+	}
+}
+
+// NewAuthHandler created AuthHandler instance. Name of the Dashboard pod is taken from POD_NAME environment
+// variable.
+func NewAuthHandler(manager authApi.AuthManager, clientManager clientapi.ClientManager) AuthHandler {
+	return AuthHandler{manager: manager, clientManager: clientManager, podName: os.Getenv("POD_NAME")}
 }
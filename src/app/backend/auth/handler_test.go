@@ -15,13 +15,21 @@
 package auth
 
 import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
 	restful "github.com/emicklei/go-restful"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/kubernetes/dashboard/src/app/backend/args"
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
 )
 
 func TestIntegrationHandler_Install(t *testing.T) {
-	iHandler := NewAuthHandler(nil)
+	iHandler := NewAuthHandler(nil, nil)
 	ws := new(restful.WebService)
 	iHandler.Install(ws)
 
@@ -29,3 +37,73 @@ func TestIntegrationHandler_Install(t *testing.T) {
 		t.Error("Failed to install routes.")
 	}
 }
+
+func TestAuthHandler_LoginNotice(t *testing.T) {
+	args.GetHolderBuilder().SetLoginNotice("Authorized use only").SetLoginNoticeAcknowledgmentRequired(true)
+	defer func() {
+		args.GetHolderBuilder().SetLoginNotice("").SetLoginNoticeAcknowledgmentRequired(false)
+	}()
+
+	handler := NewAuthHandler(nil, &fakeClientManager{})
+	ws := new(restful.WebService)
+	ws.Produces(restful.MIME_JSON).Consumes(restful.MIME_JSON)
+	handler.Install(ws)
+	container := restful.NewContainer()
+	container.Add(ws)
+
+	cases := []struct {
+		method       string
+		url          string
+		body         string
+		expectedCode int
+		expectedBody string
+	}{
+		{http.MethodGet, "/login/notice", "", http.StatusOK,
+			`"message": "Authorized use only"`},
+		{http.MethodPost, "/login", `{"token":"token"}`, http.StatusForbidden,
+			errors.MsgLoginNoticeNotAcknowledgedError},
+		{http.MethodPost, "/login/notice/acknowledgment", "", http.StatusOK, ""},
+	}
+
+	for _, c := range cases {
+		request := httptest.NewRequest(c.method, c.url, strings.NewReader(c.body))
+		request.Header.Set("Content-Type", restful.MIME_JSON)
+		recorder := httptest.NewRecorder()
+		container.ServeHTTP(recorder, request)
+		if recorder.Code != c.expectedCode || !strings.Contains(recorder.Body.String(), c.expectedBody) {
+			t.Errorf("%s %s returned %d %s, expected %d %s", c.method, c.url, recorder.Code,
+				recorder.Body.String(), c.expectedCode, c.expectedBody)
+		}
+	}
+}
+
+func TestAuthHandler_RecordNoticeAcknowledgment(t *testing.T) {
+	namespace := args.Holder.GetNamespace()
+	args.GetHolderBuilder().SetLoginNotice("Authorized use only").SetNamespace("kubernetes-dashboard")
+	defer func() {
+		args.GetHolderBuilder().SetLoginNotice("").SetNamespace(namespace)
+	}()
+
+	client := fake.NewSimpleClientset()
+	handler := NewAuthHandler(nil, &fakeClientManager{username: "alice", insecureClient: client})
+	handler.podName = "kubernetes-dashboard-1"
+
+	request := httptest.NewRequest(http.MethodPost, "/login/notice/acknowledgment", nil)
+	request.RemoteAddr = "192.168.0.10:43210"
+	handler.recordNoticeAcknowledgment(restful.NewRequest(request), "")
+
+	events, err := client.CoreV1().Events("kubernetes-dashboard").List(metaV1.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events.Items) != 1 {
+		t.Fatalf("recordNoticeAcknowledgment() recorded %d events, expected 1", len(events.Items))
+	}
+
+	event := events.Items[0]
+	expectedMessage := "Login notice acknowledged by user alice from 192.168.0.10"
+	if event.Message != expectedMessage || event.InvolvedObject.Name != "kubernetes-dashboard-1" {
+		t.Errorf("recordNoticeAcknowledgment() recorded %q for %s, expected %q for kubernetes-dashboard-1",
+			event.Message, event.InvolvedObject.Name, expectedMessage)
+	}
+}
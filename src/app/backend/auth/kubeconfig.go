@@ -40,10 +40,17 @@ type userEntry struct {
 }
 
 type authProviderConfig struct {
-	AccessToken string `yaml:"access-token"`
+	AccessToken  string `yaml:"access-token"`
+	IDToken      string `yaml:"id-token"`
+	RefreshToken string `yaml:"refresh-token"`
+	IssuerURL    string `yaml:"idp-issuer-url"`
+	ClientID     string `yaml:"client-id"`
+	ClientSecret string `yaml:"client-secret"`
+	CAData       string `yaml:"idp-certificate-authority-data"`
 }
 
 type authProviderInfo struct {
+	Name   string             `yaml:"name"`
 	Config authProviderConfig `yaml:"config"`
 }
 
@@ -115,10 +122,14 @@ func (self *kubeConfigAuthenticator) getCurrentUserInfo(config kubeConfig) (user
 
 // Returns auth info structure based on provided user info or error in case not enough data has been provided.
 func (self *kubeConfigAuthenticator) getAuthInfo(info userInfo) (api.AuthInfo, error) {
-	// If "token" is empty for the current "user" entry, fallback to the value of "auth-provider.config.access-token".
+	// If "token" is empty for the current "user" entry, fallback to the value of "auth-provider.config.access-token",
+	// or of "auth-provider.config.id-token" for OIDC auth provider.
 	if len(info.Token) == 0 {
 		info.Token = info.AuthProvider.Config.AccessToken
 	}
+	if len(info.Token) == 0 && info.AuthProvider.Name == oidcAuthProviderName {
+		info.Token = info.AuthProvider.Config.IDToken
+	}
 
 	if len(info.Token) == 0 && (len(info.Password) == 0 || len(info.Username) == 0) {
 		return api.AuthInfo{}, errors.NewInvalid("Not enough data to create auth info structure.")
@@ -127,6 +138,7 @@ func (self *kubeConfigAuthenticator) getAuthInfo(info userInfo) (api.AuthInfo, e
 	result := api.AuthInfo{}
 	if self.authModes.IsEnabled(authApi.Token) {
 		result.Token = info.Token
+		result.AuthProvider = self.getRefreshableAuthProvider(info.AuthProvider)
 	}
 
 	if self.authModes.IsEnabled(authApi.Basic) {
@@ -137,6 +149,30 @@ func (self *kubeConfigAuthenticator) getAuthInfo(info userInfo) (api.AuthInfo, e
 	return result, nil
 }
 
+// Returns OIDC auth provider config needed to refresh ID token before it expires. Nil is returned if ID token can
+// not be refreshed.
+func (self *kubeConfigAuthenticator) getRefreshableAuthProvider(info authProviderInfo) *api.AuthProviderConfig {
+	if info.Name != oidcAuthProviderName || len(info.Config.RefreshToken) == 0 {
+		return nil
+	}
+
+	config := map[string]string{
+		oidcIDToken:      info.Config.IDToken,
+		oidcRefreshToken: info.Config.RefreshToken,
+		oidcIssuerURL:    info.Config.IssuerURL,
+		oidcClientID:     info.Config.ClientID,
+		oidcClientSecret: info.Config.ClientSecret,
+		oidcCAData:       info.Config.CAData,
+	}
+	for key, value := range config {
+		if len(value) == 0 {
+			delete(config, key)
+		}
+	}
+
+	return &api.AuthProviderConfig{Name: oidcAuthProviderName, Config: config}
+}
+
 // NewBasicAuthenticator returns Authenticator based on LoginSpec.
 func NewKubeConfigAuthenticator(spec *authApi.LoginSpec, authModes authApi.AuthenticationModes) authApi.Authenticator {
 	return &kubeConfigAuthenticator{
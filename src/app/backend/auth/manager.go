@@ -15,8 +15,11 @@
 package auth
 
 import (
+	"time"
+
 	"k8s.io/client-go/tools/clientcmd/api"
 
+	"github.com/kubernetes/dashboard/src/app/backend/args"
 	authApi "github.com/kubernetes/dashboard/src/app/backend/auth/api"
 	clientapi "github.com/kubernetes/dashboard/src/app/backend/client/api"
 	"github.com/kubernetes/dashboard/src/app/backend/errors"
@@ -28,6 +31,7 @@ type authManager struct {
 	clientManager           clientapi.ClientManager
 	authenticationModes     authApi.AuthenticationModes
 	authenticationSkippable bool
+	oidcRefresher           *oidcRefresher
 }
 
 // Login implements auth manager. See AuthManager interface for more information.
@@ -56,11 +60,36 @@ func (self authManager) Login(spec *authApi.LoginSpec) (*authApi.AuthResponse, e
 	return &authApi.AuthResponse{JWEToken: token, Errors: nonCriticalErrors}, nil
 }
 
-// Refresh implements auth manager. See AuthManager interface for more information.
+// Refresh implements auth manager. See AuthManager interface for more information. Credentials stored in the token
+// are refreshed as well if they expire soon.
 func (self authManager) Refresh(jweToken string) (string, error) {
+	token, err := self.RefreshCredentials(jweToken)
+	if err != nil || len(token) > 0 {
+		return token, err
+	}
+
 	return self.tokenManager.Refresh(jweToken)
 }
 
+// RefreshCredentials implements auth manager. See AuthManager interface for more information.
+func (self authManager) RefreshCredentials(jweToken string) (string, error) {
+	authInfo, err := self.tokenManager.Decrypt(jweToken)
+	if err != nil {
+		return "", err
+	}
+
+	if authInfo == nil || !isOIDCRefreshable(authInfo, args.Holder.GetOIDCIssuerURL()) ||
+		!expiresWithin(authInfo.Token, authApi.CredentialRefreshWindow*time.Second) {
+		return "", nil
+	}
+
+	if err := self.oidcRefresher.refresh(authInfo); err != nil {
+		return "", err
+	}
+
+	return self.tokenManager.Generate(*authInfo)
+}
+
 func (self authManager) AuthenticationModes() []authApi.AuthenticationMode {
 	return self.authenticationModes.Array()
 }
@@ -101,5 +130,6 @@ func NewAuthManager(clientManager clientapi.ClientManager, tokenManager authApi.
 		clientManager:           clientManager,
 		authenticationModes:     authenticationModes,
 		authenticationSkippable: authenticationSkippable,
+		oidcRefresher:           newOIDCRefresher(),
 	}
 }
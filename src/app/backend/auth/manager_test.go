@@ -42,6 +42,8 @@ func areErrorsEqual(err1, err2 error) bool {
 
 type fakeClientManager struct {
 	HasAccessError error
+	username       string
+	insecureClient kubernetes.Interface
 }
 
 func (self *fakeClientManager) Client(req *restful.Request) (kubernetes.Interface, error) {
@@ -57,7 +59,7 @@ func (self *fakeClientManager) PluginClient(req *restful.Request) (pluginclients
 }
 
 func (self *fakeClientManager) InsecureClient() kubernetes.Interface {
-	return nil
+	return self.insecureClient
 }
 
 func (self *fakeClientManager) InsecureAPIExtensionsClient() apiextensionsclientset.Interface {
@@ -70,6 +72,14 @@ func (self *fakeClientManager) InsecurePluginClient() pluginclientset.Interface
 
 func (self *fakeClientManager) SetTokenManager(manager authApi.TokenManager) {}
 
+func (self *fakeClientManager) Username(req *restful.Request) (string, error) {
+	return self.username, nil
+}
+
+func (self *fakeClientManager) KnownUsername(req *restful.Request) string {
+	return ""
+}
+
 func (self *fakeClientManager) Config(req *restful.Request) (*rest.Config, error) {
 	return nil, nil
 }
@@ -78,6 +88,10 @@ func (self *fakeClientManager) ClientCmdConfig(req *restful.Request) (clientcmd.
 	return clientcmd.NewDefaultClientConfig(api.Config{}, &clientcmd.ConfigOverrides{}), nil
 }
 
+func (self *fakeClientManager) ConfigMode() clientapi.ConfigMode {
+	return clientapi.InClusterConfigMode
+}
+
 func (self *fakeClientManager) CSRFKey() string {
 	return ""
 }
@@ -97,6 +111,7 @@ func (self *fakeClientManager) CanI(req *restful.Request, ssar *v1.SelfSubjectAc
 type fakeTokenManager struct {
 	GeneratedToken string
 	Error          error
+	AuthInfo       *api.AuthInfo
 }
 
 func (self *fakeTokenManager) Refresh(string) (string, error) {
@@ -106,11 +121,12 @@ func (self *fakeTokenManager) Refresh(string) (string, error) {
 func (self *fakeTokenManager) SetTokenTTL(time.Duration) {}
 
 func (self *fakeTokenManager) Generate(authInfo api.AuthInfo) (string, error) {
+	self.AuthInfo = &authInfo
 	return self.GeneratedToken, self.Error
 }
 
 func (self *fakeTokenManager) Decrypt(jweToken string) (*api.AuthInfo, error) {
-	return nil, nil
+	return self.AuthInfo, nil
 }
 
 func TestAuthManager_Login(t *testing.T) {
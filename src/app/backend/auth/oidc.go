@@ -0,0 +1,252 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/client-go/tools/clientcmd/api"
+
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+)
+
+// Name and config keys of the OIDC auth provider in kubeconfig files. See
+// https://kubernetes.io/docs/reference/access-authn-authz/authentication/#using-kubectl.
+const (
+	oidcAuthProviderName = "oidc"
+	oidcIDToken          = "id-token"
+	oidcRefreshToken     = "refresh-token"
+	oidcIssuerURL        = "idp-issuer-url"
+	oidcClientID         = "client-id"
+	oidcClientSecret     = "client-secret"
+	oidcCAData           = "idp-certificate-authority-data"
+)
+
+const (
+	// oidcRequestTimeout is a timeout of requests to the identity provider.
+	oidcRequestTimeout = 10 * time.Second
+
+	// oidcRefreshResultTTL is how long the result of a refresh is returned for the same refresh token. Parallel
+	// requests of the frontend carry the same token until it receives the refreshed one.
+	oidcRefreshResultTTL = time.Minute
+)
+
+// isOIDCRefreshable returns true if bearer token of the auth info is an OIDC ID token of the allowed issuer, that
+// can be refreshed. Nothing is refreshed when no issuer is allowed, so that the identity provider set in uploaded
+// kubeconfig files can not make Dashboard send requests to arbitrary hosts.
+func isOIDCRefreshable(authInfo *api.AuthInfo, allowedIssuerURL string) bool {
+	provider := authInfo.AuthProvider
+	return provider != nil && provider.Name == oidcAuthProviderName && len(provider.Config[oidcRefreshToken]) > 0 &&
+		len(allowedIssuerURL) > 0 && normalizeIssuerURL(provider.Config[oidcIssuerURL]) ==
+		normalizeIssuerURL(allowedIssuerURL) && len(provider.Config[oidcClientID]) > 0
+}
+
+func normalizeIssuerURL(issuerURL string) string {
+	return strings.TrimSuffix(strings.TrimSpace(issuerURL), "/")
+}
+
+// oidcRefresh is a single refresh of the ID token. Done channel is closed when the refresh is finished.
+type oidcRefresh struct {
+	done         chan struct{}
+	idToken      string
+	refreshToken string
+	err          error
+	expires      time.Time
+}
+
+// oidcRefresher makes sure, that a refresh token is exchanged only once. Identity providers, that rotate refresh
+// tokens, revoke all tokens of the user when a refresh token is used twice. Concurrent refreshes with the same
+// refresh token wait for the first one, and its result is reused for a while afterwards.
+type oidcRefresher struct {
+	mu        sync.Mutex
+	refreshes map[string]*oidcRefresh
+}
+
+func newOIDCRefresher() *oidcRefresher {
+	return &oidcRefresher{refreshes: make(map[string]*oidcRefresh)}
+}
+
+// refresh updates bearer token and auth provider config of the auth info in place with refreshed tokens.
+func (self *oidcRefresher) refresh(authInfo *api.AuthInfo) error {
+	config := authInfo.AuthProvider.Config
+	hash := sha256.Sum256([]byte(config[oidcRefreshToken]))
+	key := hex.EncodeToString(hash[:])
+
+	self.mu.Lock()
+	now := time.Now()
+	current, ok := self.refreshes[key]
+	if !ok || (!current.expires.IsZero() && now.After(current.expires)) {
+		for k, refresh := range self.refreshes {
+			if !refresh.expires.IsZero() && now.After(refresh.expires) {
+				delete(self.refreshes, k)
+			}
+		}
+
+		current = &oidcRefresh{done: make(chan struct{})}
+		self.refreshes[key] = current
+		self.mu.Unlock()
+
+		current.idToken, current.refreshToken, current.err = refreshOIDCToken(config)
+		self.mu.Lock()
+		current.expires = time.Now().Add(oidcRefreshResultTTL)
+		self.mu.Unlock()
+		close(current.done)
+	} else {
+		self.mu.Unlock()
+		<-current.done
+	}
+
+	if current.err != nil {
+		return current.err
+	}
+
+	authInfo.Token = current.idToken
+	config[oidcIDToken] = current.idToken
+	// Identity providers can rotate refresh tokens.
+	if len(current.refreshToken) > 0 {
+		config[oidcRefreshToken] = current.refreshToken
+	}
+	return nil
+}
+
+// expiresWithin returns true if given bearer token is a JWT, that expires within given duration. Signature is not
+// verified, it is up to the apiserver.
+func expiresWithin(token string, window time.Duration) bool {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return false
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return false
+	}
+
+	claims := struct {
+		Exp int64 `json:"exp"`
+	}{}
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.Exp == 0 {
+		return false
+	}
+
+	return time.Unix(claims.Exp, 0).Before(time.Now().Add(window))
+}
+
+// refreshOIDCToken exchanges refresh token from the auth provider config for a new ID token at the token endpoint
+// of the identity provider. New ID token is returned together with the new refresh token, if the identity provider
+// rotated it.
+func refreshOIDCToken(config map[string]string) (string, string, error) {
+	client, err := oidcHTTPClient(config[oidcCAData])
+	if err != nil {
+		return "", "", err
+	}
+
+	tokenEndpoint, err := discoverTokenEndpoint(client, config[oidcIssuerURL])
+	if err != nil {
+		return "", "", err
+	}
+
+	form := url.Values{
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {config[oidcRefreshToken]},
+		"client_id":     {config[oidcClientID]},
+	}
+	if len(config[oidcClientSecret]) > 0 {
+		form.Set("client_secret", config[oidcClientSecret])
+	}
+
+	response, err := client.PostForm(tokenEndpoint, form)
+	if err != nil {
+		return "", "", err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return "", "", errors.NewUnauthorized(fmt.Sprintf(
+			"could not refresh OIDC token, identity provider returned %s", response.Status))
+	}
+
+	tokens := struct {
+		IDToken      string `json:"id_token"`
+		RefreshToken string `json:"refresh_token"`
+	}{}
+	if err := json.NewDecoder(response.Body).Decode(&tokens); err != nil {
+		return "", "", err
+	}
+	if len(tokens.IDToken) == 0 {
+		return "", "", errors.NewUnauthorized(
+			"could not refresh OIDC token, identity provider did not return ID token")
+	}
+
+	return tokens.IDToken, tokens.RefreshToken, nil
+}
+
+// discoverTokenEndpoint returns token endpoint of the issuer from its OpenID provider configuration.
+func discoverTokenEndpoint(client *http.Client, issuerURL string) (string, error) {
+	response, err := client.Get(strings.TrimSuffix(issuerURL, "/") + "/.well-known/openid-configuration")
+	if err != nil {
+		return "", err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("could not discover OIDC provider configuration of %s, got %s", issuerURL,
+			response.Status)
+	}
+
+	configuration := struct {
+		TokenEndpoint string `json:"token_endpoint"`
+	}{}
+	if err := json.NewDecoder(response.Body).Decode(&configuration); err != nil {
+		return "", err
+	}
+	if len(configuration.TokenEndpoint) == 0 {
+		return "", fmt.Errorf("OIDC provider configuration of %s does not contain token endpoint", issuerURL)
+	}
+	return configuration.TokenEndpoint, nil
+}
+
+// oidcHTTPClient returns client used to talk to the identity provider. Certificate authority data is base64
+// encoded PEM, as in kubeconfig files. System roots are used when it is empty.
+func oidcHTTPClient(caData string) (*http.Client, error) {
+	client := &http.Client{Timeout: oidcRequestTimeout}
+	if len(caData) == 0 {
+		return client, nil
+	}
+
+	pem, err := base64.StdEncoding.DecodeString(caData)
+	if err != nil {
+		return nil, err
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, errors.NewInvalid("could not parse certificate authority data of the OIDC identity provider")
+	}
+
+	client.Transport = &http.Transport{Proxy: http.ProxyFromEnvironment, TLSClientConfig: &tls.Config{RootCAs: pool}}
+	return client, nil
+}
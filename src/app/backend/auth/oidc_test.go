@@ -0,0 +1,179 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"k8s.io/client-go/tools/clientcmd/api"
+
+	"github.com/kubernetes/dashboard/src/app/backend/args"
+	authApi "github.com/kubernetes/dashboard/src/app/backend/auth/api"
+)
+
+func fakeJWT(exp time.Time) string {
+	payload, _ := json.Marshal(map[string]int64{"exp": exp.Unix()})
+	return "eyJhbGciOiJSUzI1NiJ9." + base64.RawURLEncoding.EncodeToString(payload) + ".signature"
+}
+
+func TestExpiresWithin(t *testing.T) {
+	cases := []struct {
+		token    string
+		expected bool
+	}{
+		{fakeJWT(time.Now().Add(time.Minute)), true},
+		{fakeJWT(time.Now().Add(-time.Minute)), true},
+		{fakeJWT(time.Now().Add(time.Hour)), false},
+		{"opaque-token", false},
+	}
+
+	for _, c := range cases {
+		if actual := expiresWithin(c.token, 2*time.Minute); actual != c.expected {
+			t.Errorf("expiresWithin(%s) == %t, expected %t", c.token, actual, c.expected)
+		}
+	}
+}
+
+func TestAuthManager_RefreshCredentials(t *testing.T) {
+	refreshedIDToken := fakeJWT(time.Now().Add(time.Hour))
+	var refreshes int32
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/.well-known/openid-configuration":
+			fmt.Fprintf(w, `{"token_endpoint": "%s/token"}`, server.URL)
+		case "/token":
+			if r.PostFormValue("grant_type") != "refresh_token" || r.PostFormValue("refresh_token") != "refresh" ||
+				r.PostFormValue("client_id") != "dashboard" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			atomic.AddInt32(&refreshes, 1)
+			fmt.Fprintf(w, `{"id_token": "%s", "refresh_token": "rotated"}`, refreshedIDToken)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	newAuthInfo := func(idToken string) *api.AuthInfo {
+		return &api.AuthInfo{
+			Token: idToken,
+			AuthProvider: &api.AuthProviderConfig{Name: "oidc", Config: map[string]string{
+				oidcIDToken:      idToken,
+				oidcRefreshToken: "refresh",
+				oidcIssuerURL:    server.URL,
+				oidcClientID:     "dashboard",
+			}},
+		}
+	}
+
+	tokenManager := &fakeTokenManager{GeneratedToken: "rolled-token", AuthInfo: newAuthInfo(fakeJWT(time.Now().Add(time.Minute)))}
+	manager := NewAuthManager(&fakeClientManager{}, tokenManager, authApi.AuthenticationModes{}, false)
+
+	token, err := manager.RefreshCredentials("token")
+	if err != nil || len(token) > 0 {
+		t.Fatalf("RefreshCredentials() == (%s, %v), expected no refresh when no issuer is allowed", token, err)
+	}
+
+	args.GetHolderBuilder().SetOIDCIssuerURL(server.URL + "/")
+	defer func() { args.GetHolderBuilder().SetOIDCIssuerURL("") }()
+
+	tokenManager.AuthInfo = newAuthInfo(fakeJWT(time.Now().Add(time.Hour)))
+	token, err = manager.RefreshCredentials("token")
+	if err != nil || len(token) > 0 {
+		t.Fatalf("RefreshCredentials() == (%s, %v), expected no refresh of token, that does not expire soon", token, err)
+	}
+
+	tokenManager.AuthInfo = newAuthInfo(fakeJWT(time.Now().Add(time.Minute)))
+	token, err = manager.RefreshCredentials("token")
+	if err != nil {
+		t.Fatalf("RefreshCredentials() returned error: %v", err)
+	}
+	if token != "rolled-token" {
+		t.Errorf("RefreshCredentials() == %s, expected rolled-token", token)
+	}
+
+	authInfo := tokenManager.AuthInfo
+	if authInfo.Token != refreshedIDToken || authInfo.AuthProvider.Config[oidcIDToken] != refreshedIDToken ||
+		authInfo.AuthProvider.Config[oidcRefreshToken] != "rotated" {
+		t.Errorf("RefreshCredentials() generated token from %#v, expected refreshed ID token and rotated refresh token",
+			authInfo)
+	}
+
+	tokenManager.AuthInfo = newAuthInfo(fakeJWT(time.Now().Add(time.Minute)))
+	if token, err = manager.RefreshCredentials("token"); err != nil || token != "rolled-token" ||
+		atomic.LoadInt32(&refreshes) != 1 {
+		t.Errorf("RefreshCredentials() == (%s, %v) after %d refreshes, expected result of the first refresh to be "+
+			"reused", token, err, refreshes)
+	}
+
+	tokenManager.AuthInfo = newAuthInfo(fakeJWT(time.Now().Add(time.Minute)))
+	tokenManager.AuthInfo.AuthProvider.Config[oidcRefreshToken] = "revoked"
+	if _, err := manager.RefreshCredentials("token"); err == nil {
+		t.Error("RefreshCredentials() expected error when identity provider rejects refresh token")
+	}
+}
+
+func TestOIDCRefresher_Refresh(t *testing.T) {
+	var refreshes int32
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/.well-known/openid-configuration":
+			fmt.Fprintf(w, `{"token_endpoint": "%s/token"}`, server.URL)
+		case "/token":
+			// Refresh token is rotated, so that it can be used only once.
+			if atomic.AddInt32(&refreshes, 1) > 1 {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			time.Sleep(10 * time.Millisecond)
+			fmt.Fprint(w, `{"id_token": "refreshed", "refresh_token": "rotated"}`)
+		}
+	}))
+	defer server.Close()
+
+	refresher := newOIDCRefresher()
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			authInfo := &api.AuthInfo{AuthProvider: &api.AuthProviderConfig{Name: "oidc", Config: map[string]string{
+				oidcRefreshToken: "refresh",
+				oidcIssuerURL:    server.URL,
+				oidcClientID:     "dashboard",
+			}}}
+			if err := refresher.refresh(authInfo); err != nil || authInfo.Token != "refreshed" ||
+				authInfo.AuthProvider.Config[oidcRefreshToken] != "rotated" {
+				t.Errorf("refresh() == %v with %#v, expected refreshed tokens", err, authInfo)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if refreshes != 1 {
+		t.Errorf("Expected refresh token to be used once, got %d refreshes", refreshes)
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// BrandingConfigMapName contains a name of config map, that stores operator-defined branding.
+	BrandingConfigMapName = "kubernetes-dashboard-branding"
+
+	// TitleKey is a branding config map key which maps to the title.
+	TitleKey = "title"
+
+	// LogoURLKey is a branding config map key which maps to the logo URL.
+	LogoURLKey = "logoURL"
+
+	// ThemeKey is a branding config map key which maps to the theme.
+	ThemeKey = "theme"
+
+	// PrimaryColorKey is a branding config map key which maps to the primary color.
+	PrimaryColorKey = "primaryColor"
+
+	// EnvironmentLabelKey is a branding config map key which maps to the environment label.
+	EnvironmentLabelKey = "environmentLabel"
+
+	// EnvironmentColorKey is a branding config map key which maps to the environment label color.
+	EnvironmentColorKey = "environmentColor"
+)
+
+// BrandingManager is used for branding management.
+type BrandingManager interface {
+	// Get branding from config map. Missing or invalid values are left empty.
+	Get(client kubernetes.Interface) Branding
+}
+
+// Branding represents operator-defined look of Dashboard, that makes clusters visually distinguishable.
+type Branding struct {
+	// Title shown in the toolbar and in the browser tab.
+	Title string `json:"title"`
+
+	// LogoURL is an http or https URL of the logo image.
+	LogoURL string `json:"logoURL"`
+
+	// Theme forces light or dark theme. User is free to choose when empty.
+	Theme Theme `json:"theme"`
+
+	// PrimaryColor is a hex color of the toolbar, i.e. #326de6.
+	PrimaryColor string `json:"primaryColor"`
+
+	// EnvironmentLabel is shown next to the title, i.e. PRODUCTION.
+	EnvironmentLabel string `json:"environmentLabel"`
+
+	// EnvironmentColor is a hex color of the environment label.
+	EnvironmentColor string `json:"environmentColor"`
+}
+
+// Theme represents theme forced by branding.
+type Theme string
+
+const (
+	// ThemeLight forces light theme.
+	ThemeLight Theme = "light"
+
+	// ThemeDark forces dark theme.
+	ThemeDark Theme = "dark"
+)
@@ -0,0 +1,47 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package branding
+
+import (
+	"net/http"
+
+	restful "github.com/emicklei/go-restful"
+
+	"github.com/kubernetes/dashboard/src/app/backend/branding/api"
+	clientapi "github.com/kubernetes/dashboard/src/app/backend/client/api"
+)
+
+// BrandingHandler manages all endpoints related to branding management.
+type BrandingHandler struct {
+	manager       api.BrandingManager
+	clientManager clientapi.ClientManager
+}
+
+// Install creates new endpoints for branding management.
+func (self *BrandingHandler) Install(ws *restful.WebService) {
+	ws.Route(
+		ws.GET("/branding").
+			To(self.handleGet).
+			Writes(api.Branding{}))
+}
+
+func (self *BrandingHandler) handleGet(request *restful.Request, response *restful.Response) {
+	response.WriteHeaderAndEntity(http.StatusOK, self.manager.Get(self.clientManager.InsecureClient()))
+}
+
+// NewBrandingHandler creates BrandingHandler.
+func NewBrandingHandler(manager api.BrandingManager, clientManager clientapi.ClientManager) BrandingHandler {
+	return BrandingHandler{manager: manager, clientManager: clientManager}
+}
@@ -0,0 +1,99 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package branding
+
+import (
+	"log"
+	"net/url"
+	"regexp"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/kubernetes/dashboard/src/app/backend/args"
+	"github.com/kubernetes/dashboard/src/app/backend/branding/api"
+)
+
+// colorRegexp matches short and long hex colors. Other CSS values are not accepted, so that branding cannot be used
+// to inject styles.
+var colorRegexp = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// BrandingManager is a structure containing all branding manager members.
+type BrandingManager struct{}
+
+// NewBrandingManager creates new branding manager.
+func NewBrandingManager() api.BrandingManager {
+	return &BrandingManager{}
+}
+
+// Get implements BrandingManager interface. Check it for more information.
+func (bm *BrandingManager) Get(client kubernetes.Interface) api.Branding {
+	configMap, err := client.CoreV1().ConfigMaps(args.Holder.GetNamespace()).
+		Get(api.BrandingConfigMapName, metav1.GetOptions{})
+	if err != nil {
+		log.Printf("Cannot find branding config map: %s", err.Error())
+		return api.Branding{}
+	}
+
+	return unmarshal(configMap.Data)
+}
+
+func unmarshal(data map[string]string) api.Branding {
+	return api.Branding{
+		Title:            strings.TrimSpace(data[api.TitleKey]),
+		LogoURL:          validURL(data[api.LogoURLKey]),
+		Theme:            validTheme(data[api.ThemeKey]),
+		PrimaryColor:     validColor(data[api.PrimaryColorKey]),
+		EnvironmentLabel: strings.TrimSpace(data[api.EnvironmentLabelKey]),
+		EnvironmentColor: validColor(data[api.EnvironmentColorKey]),
+	}
+}
+
+func validURL(value string) string {
+	value = strings.TrimSpace(value)
+	if len(value) == 0 {
+		return ""
+	}
+
+	u, err := url.Parse(value)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || len(u.Host) == 0 {
+		log.Printf("Ignoring invalid branding logo URL: %s", value)
+		return ""
+	}
+
+	return value
+}
+
+func validTheme(value string) api.Theme {
+	theme := api.Theme(strings.TrimSpace(value))
+	switch theme {
+	case "", api.ThemeLight, api.ThemeDark:
+		return theme
+	}
+
+	log.Printf("Ignoring invalid branding theme: %s", value)
+	return ""
+}
+
+func validColor(value string) string {
+	value = strings.TrimSpace(value)
+	if len(value) == 0 || colorRegexp.MatchString(value) {
+		return value
+	}
+
+	log.Printf("Ignoring invalid branding color: %s", value)
+	return ""
+}
@@ -0,0 +1,72 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package branding
+
+import (
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/kubernetes/dashboard/src/app/backend/args"
+	"github.com/kubernetes/dashboard/src/app/backend/branding/api"
+)
+
+func TestBrandingManager_Get(t *testing.T) {
+	cases := []struct {
+		objects  []runtime.Object
+		expected api.Branding
+	}{
+		{nil, api.Branding{}},
+		{
+			[]runtime.Object{&v1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: api.BrandingConfigMapName, Namespace: args.Holder.GetNamespace()},
+				Data: map[string]string{
+					api.TitleKey:            "Payments",
+					api.LogoURLKey:          "https://example.com/logo.png",
+					api.ThemeKey:            "dark",
+					api.PrimaryColorKey:     "#326de6",
+					api.EnvironmentLabelKey: " PRODUCTION ",
+					api.EnvironmentColorKey: "#f00",
+				},
+			}},
+			api.Branding{Title: "Payments", LogoURL: "https://example.com/logo.png", Theme: api.ThemeDark,
+				PrimaryColor: "#326de6", EnvironmentLabel: "PRODUCTION", EnvironmentColor: "#f00"},
+		},
+		{
+			[]runtime.Object{&v1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: api.BrandingConfigMapName, Namespace: args.Holder.GetNamespace()},
+				Data: map[string]string{
+					api.TitleKey:            "Staging",
+					api.LogoURLKey:          "javascript:alert(1)",
+					api.ThemeKey:            "blue",
+					api.PrimaryColorKey:     "red; background: url(x)",
+					api.EnvironmentColorKey: "#12345",
+				},
+			}},
+			api.Branding{Title: "Staging"},
+		},
+	}
+
+	for _, c := range cases {
+		actual := NewBrandingManager().Get(fake.NewSimpleClientset(c.objects...))
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("Get() == %#v, expected %#v", actual, c.expected)
+		}
+	}
+}
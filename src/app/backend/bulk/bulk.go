@@ -0,0 +1,178 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulk
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	clientapi "github.com/kubernetes/dashboard/src/app/backend/client/api"
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/scaling"
+)
+
+// Action is a verb that can be executed on many resources at once.
+type Action string
+
+const (
+	// ActionDelete deletes every target. Works for every kind supported by the resource verber.
+	ActionDelete Action = "delete"
+	// ActionScale sets replicas of every target to BulkActionSpec.Replicas.
+	ActionScale Action = "scale"
+	// ActionRestart triggers rolling restart of every target. Works for deployments, daemon sets and stateful
+	// sets only.
+	ActionRestart Action = "restart"
+)
+
+// maxConcurrency is the maximum number of requests sent to the apiserver in parallel by a single bulk action.
+const maxConcurrency = 10
+
+// restartedAtAnnotation is the same annotation that 'kubectl rollout restart' sets on the pod template.
+const restartedAtAnnotation = "kubectl.kubernetes.io/restartedAt"
+
+// Target identifies a single resource that action should be executed on.
+type Target struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+}
+
+// BulkActionSpec is a specification of an action that should be executed on a list of targets.
+type BulkActionSpec struct {
+	Action  Action   `json:"action"`
+	Targets []Target `json:"targets"`
+	// Desired number of replicas. Required by scale action only.
+	Replicas *int32 `json:"replicas,omitempty"`
+}
+
+// TargetResult is an outcome of action executed on a single target.
+type TargetResult struct {
+	Target
+	Success bool   `json:"success"`
+	Error   string `json:"error,omitempty"`
+}
+
+// Summary aggregates outcomes of all targets.
+type Summary struct {
+	Total     int `json:"total"`
+	Succeeded int `json:"succeeded"`
+	Failed    int `json:"failed"`
+}
+
+// BulkActionResult is a response of the bulk action endpoint. Results are returned in the same order as targets
+// of the request.
+type BulkActionResult struct {
+	Summary Summary        `json:"summary"`
+	Results []TargetResult `json:"results"`
+}
+
+// TargetFunc executes action on a single target.
+type TargetFunc func(target Target) error
+
+// ExecuteBulkAction validates given spec and executes its action on all of the targets. Failure on a single
+// target does not stop execution on the remaining ones, it is reported in the result instead.
+func ExecuteBulkAction(client kubernetes.Interface, verber clientapi.ResourceVerber, cfg *rest.Config,
+	spec *BulkActionSpec) (*BulkActionResult, error) {
+	var fn TargetFunc
+	switch spec.Action {
+	case ActionDelete:
+		fn = func(target Target) error {
+			return verber.Delete(target.Kind, len(target.Namespace) > 0, target.Namespace, target.Name)
+		}
+	case ActionScale:
+		if spec.Replicas == nil || *spec.Replicas < 0 {
+			return nil, errors.NewBadRequest("scale action requires non-negative number of replicas")
+		}
+		replicas := strconv.Itoa(int(*spec.Replicas))
+		fn = func(target Target) error {
+			_, err := scaling.ScaleResource(cfg, target.Kind, target.Namespace, target.Name, replicas, false)
+			return err
+		}
+	case ActionRestart:
+		fn = func(target Target) error {
+			return RestartResource(client, target)
+		}
+	default:
+		return nil, errors.NewBadRequest(fmt.Sprintf("unsupported bulk action: %s", spec.Action))
+	}
+
+	return Execute(spec.Targets, fn, maxConcurrency), nil
+}
+
+// Execute runs fn on every target with at most concurrency calls in flight and collects the outcomes.
+func Execute(targets []Target, fn TargetFunc, concurrency int) *BulkActionResult {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	results := make([]TargetResult, len(targets))
+	semaphore := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, target := range targets {
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func(i int, target Target) {
+			defer func() {
+				<-semaphore
+				wg.Done()
+			}()
+
+			results[i] = TargetResult{Target: target, Success: true}
+			if err := fn(target); err != nil {
+				results[i].Success = false
+				results[i].Error = errors.LocalizeError(err).Error()
+			}
+		}(i, target)
+	}
+	wg.Wait()
+
+	summary := Summary{Total: len(results)}
+	for _, result := range results {
+		if result.Success {
+			summary.Succeeded++
+		} else {
+			summary.Failed++
+		}
+	}
+
+	return &BulkActionResult{Summary: summary, Results: results}
+}
+
+// RestartResource triggers rolling restart of given target by updating an annotation of its pod template.
+func RestartResource(client kubernetes.Interface, target Target) error {
+	patch := []byte(fmt.Sprintf(`{"spec":{"template":{"metadata":{"annotations":{"%s":"%s"}}}}}`,
+		restartedAtAnnotation, time.Now().Format(time.RFC3339)))
+
+	var err error
+	switch strings.ToLower(target.Kind) {
+	case "deployment":
+		_, err = client.AppsV1().Deployments(target.Namespace).Patch(target.Name, types.StrategicMergePatchType, patch)
+	case "daemonset":
+		_, err = client.AppsV1().DaemonSets(target.Namespace).Patch(target.Name, types.StrategicMergePatchType, patch)
+	case "statefulset":
+		_, err = client.AppsV1().StatefulSets(target.Namespace).Patch(target.Name, types.StrategicMergePatchType, patch)
+	default:
+		err = errors.NewBadRequest(fmt.Sprintf("restart is not supported for kind: %s", target.Kind))
+	}
+
+	return err
+}
@@ -0,0 +1,113 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulk
+
+import (
+	"errors"
+	"reflect"
+	"sync/atomic"
+	"testing"
+
+	apps "k8s.io/api/apps/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestExecute(t *testing.T) {
+	targets := []Target{
+		{Kind: "deployment", Namespace: "default", Name: "a"},
+		{Kind: "deployment", Namespace: "default", Name: "b"},
+		{Kind: "deployment", Namespace: "default", Name: "c"},
+	}
+
+	var inFlight, maxInFlight int32
+	result := Execute(targets, func(target Target) error {
+		current := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			max := atomic.LoadInt32(&maxInFlight)
+			if current <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, current) {
+				break
+			}
+		}
+
+		if target.Name == "b" {
+			return errors.New("not found")
+		}
+		return nil
+	}, 2)
+
+	expected := &BulkActionResult{
+		Summary: Summary{Total: 3, Succeeded: 2, Failed: 1},
+		Results: []TargetResult{
+			{Target: targets[0], Success: true},
+			{Target: targets[1], Success: false, Error: "not found"},
+			{Target: targets[2], Success: true},
+		},
+	}
+
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Execute() == %#v, expected %#v", result, expected)
+	}
+
+	if maxInFlight > 2 {
+		t.Errorf("Expected at most 2 concurrent calls, got %d", maxInFlight)
+	}
+}
+
+func TestRestartResource(t *testing.T) {
+	cases := []struct {
+		target      Target
+		expectedErr bool
+	}{
+		{Target{Kind: "deployment", Namespace: "default", Name: "dp"}, false},
+		{Target{Kind: "deployment", Namespace: "default", Name: "missing"}, true},
+		{Target{Kind: "pod", Namespace: "default", Name: "dp"}, true},
+	}
+
+	for _, c := range cases {
+		client := fake.NewSimpleClientset(&apps.Deployment{
+			ObjectMeta: metaV1.ObjectMeta{Name: "dp", Namespace: "default"},
+		})
+
+		err := RestartResource(client, c.target)
+		if (err != nil) != c.expectedErr {
+			t.Errorf("RestartResource(%#v) returned error %v, expected error: %t", c.target, err, c.expectedErr)
+			continue
+		}
+
+		if c.expectedErr {
+			continue
+		}
+
+		actual, _ := client.AppsV1().Deployments("default").Get("dp", metaV1.GetOptions{})
+		if _, ok := actual.Spec.Template.Annotations[restartedAtAnnotation]; !ok {
+			t.Errorf("Expected pod template of %#v to have %s annotation", c.target, restartedAtAnnotation)
+		}
+	}
+}
+
+func TestExecuteBulkActionValidation(t *testing.T) {
+	cases := []*BulkActionSpec{
+		{Action: "unknown"},
+		{Action: ActionScale},
+	}
+
+	for _, c := range cases {
+		if _, err := ExecuteBulkAction(fake.NewSimpleClientset(), nil, nil, c); err == nil {
+			t.Errorf("ExecuteBulkAction(%#v) expected error", c)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"time"
+
+	"k8s.io/client-go/rest"
+
+	backendapi "github.com/kubernetes/dashboard/src/app/backend/api"
+)
+
+// ChartManager is responsible for listing charts of configured Helm repositories and managing releases
+// installed from them.
+type ChartManager interface {
+	// ListRepositories returns all configured chart repositories.
+	ListRepositories() RepositoryList
+	// ListCharts returns latest versions of all charts available in given repository.
+	ListCharts(repository string) (*ChartList, error)
+	// Render renders chart templates with given values without contacting the cluster.
+	Render(spec *ChartSpec) (*RenderedChart, error)
+	// Install installs a new release of a chart. Given check is applied to namespaces of all rendered objects
+	// before anything is installed.
+	Install(cfg *rest.Config, spec *ChartSpec, check NamespaceCheck) (*Release, error)
+	// Upgrade upgrades an existing release to a new chart version or values. Given check is applied to
+	// namespaces of all rendered objects before anything is upgraded.
+	Upgrade(cfg *rest.Config, spec *ChartSpec, check NamespaceCheck) (*Release, error)
+	// Uninstall removes release and all of its resources.
+	Uninstall(cfg *rest.Config, namespace, name string) (*Release, error)
+	// ListReleases returns releases from given namespace or from all namespaces if namespace is empty.
+	ListReleases(cfg *rest.Config, namespace string) (*ReleaseList, error)
+}
+
+// NamespaceCheck returns error when objects must not be created in given namespace. Chart templates can set
+// namespaces of their objects, so the check is applied to every rendered object.
+type NamespaceCheck func(namespace string) error
+
+// Repository is a Helm chart repository configured with --chart-repositories flag.
+type Repository struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+// RepositoryList is a list of configured chart repositories.
+type RepositoryList struct {
+	Items []Repository `json:"items"`
+}
+
+// Chart is a single chart version available in a repository.
+type Chart struct {
+	Name        string `json:"name"`
+	Version     string `json:"version"`
+	AppVersion  string `json:"appVersion"`
+	Description string `json:"description"`
+	Icon        string `json:"icon,omitempty"`
+	Deprecated  bool   `json:"deprecated"`
+}
+
+// ChartList is a list of charts available in a repository.
+type ChartList struct {
+	Repository Repository `json:"repository"`
+	Items      []Chart    `json:"items"`
+}
+
+// ChartSpec is a specification of a chart that should be rendered, installed or upgraded.
+type ChartSpec struct {
+	// Name of the repository configured with --chart-repositories flag.
+	Repository string `json:"repository"`
+	// Name of the chart.
+	Chart string `json:"chart"`
+	// Version constraint of the chart. Latest version is used when empty.
+	Version string `json:"version,omitempty"`
+	// Name of the release.
+	ReleaseName string `json:"releaseName"`
+	// Namespace of the release.
+	Namespace string `json:"namespace"`
+	// Values in YAML format that override default values of the chart.
+	Values string `json:"values,omitempty"`
+}
+
+// RenderedChart is a result of rendering chart templates.
+type RenderedChart struct {
+	// All rendered manifests concatenated into a single multi-document YAML.
+	Manifest string `json:"manifest"`
+	// Rendered NOTES.txt of the chart.
+	Notes string `json:"notes,omitempty"`
+}
+
+// Release is a single installation of a chart in the cluster.
+type Release struct {
+	Name         string    `json:"name"`
+	Namespace    string    `json:"namespace"`
+	Revision     int       `json:"revision"`
+	Chart        string    `json:"chart"`
+	ChartVersion string    `json:"chartVersion"`
+	AppVersion   string    `json:"appVersion"`
+	Status       string    `json:"status"`
+	Description  string    `json:"description,omitempty"`
+	LastDeployed time.Time `json:"lastDeployed"`
+}
+
+// ReleaseList is a list of releases.
+type ReleaseList struct {
+	ListMeta backendapi.ListMeta `json:"listMeta"`
+	Items    []Release           `json:"items"`
+}
@@ -0,0 +1,66 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chart
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+// restClientGetter implements genericclioptions.RESTClientGetter required by Helm on top of the config of
+// the user that made the request, so all of the Helm actions are executed with user's privileges.
+type restClientGetter struct {
+	config    *rest.Config
+	namespace string
+}
+
+// ToRESTConfig implements RESTClientGetter interface.
+func (self *restClientGetter) ToRESTConfig() (*rest.Config, error) {
+	return rest.CopyConfig(self.config), nil
+}
+
+// ToDiscoveryClient implements RESTClientGetter interface.
+func (self *restClientGetter) ToDiscoveryClient() (discovery.CachedDiscoveryInterface, error) {
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(self.config)
+	if err != nil {
+		return nil, err
+	}
+
+	return memory.NewMemCacheClient(discoveryClient), nil
+}
+
+// ToRESTMapper implements RESTClientGetter interface.
+func (self *restClientGetter) ToRESTMapper() (meta.RESTMapper, error) {
+	discoveryClient, err := self.ToDiscoveryClient()
+	if err != nil {
+		return nil, err
+	}
+
+	mapper := restmapper.NewDeferredDiscoveryRESTMapper(discoveryClient)
+	return restmapper.NewShortcutExpander(mapper, discoveryClient), nil
+}
+
+// ToRawKubeConfigLoader implements RESTClientGetter interface. Helm uses only the namespace of returned
+// config, it is used for all resources of the release that do not specify one.
+func (self *restClientGetter) ToRawKubeConfigLoader() clientcmd.ClientConfig {
+	return clientcmd.NewDefaultClientConfig(clientcmdapi.Config{}, &clientcmd.ConfigOverrides{
+		Context: clientcmdapi.Context{Namespace: self.namespace},
+	})
+}
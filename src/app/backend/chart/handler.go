@@ -0,0 +1,209 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chart
+
+import (
+	"net/http"
+
+	restful "github.com/emicklei/go-restful"
+
+	"github.com/kubernetes/dashboard/src/app/backend/chart/api"
+	clientapi "github.com/kubernetes/dashboard/src/app/backend/client/api"
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/handler/parser"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+)
+
+// ChartHandler manages all endpoints related to Helm charts and releases.
+type ChartHandler struct {
+	manager       api.ChartManager
+	clientManager clientapi.ClientManager
+
+	// checkNamespace returns error when release must not be installed in or listed from given namespace.
+	checkNamespace func(request *restful.Request, namespace string) error
+}
+
+// Install creates new endpoints for chart management.
+func (self *ChartHandler) Install(ws *restful.WebService) {
+	ws.Route(
+		ws.GET("/chart/repository").
+			To(self.handleListRepositories).
+			Writes(api.RepositoryList{}))
+	ws.Route(
+		ws.GET("/chart/repository/{repository}").
+			To(self.handleListCharts).
+			Writes(api.ChartList{}))
+	ws.Route(
+		ws.POST("/chart/render").
+			To(self.handleRender).
+			Reads(api.ChartSpec{}).
+			Writes(api.RenderedChart{}))
+
+	ws.Route(
+		ws.GET("/chart/release").
+			To(self.handleListReleases).
+			Writes(api.ReleaseList{}))
+	ws.Route(
+		ws.GET("/chart/release/{namespace}").
+			To(self.handleListReleases).
+			Writes(api.ReleaseList{}))
+	ws.Route(
+		ws.POST("/chart/release").
+			To(self.handleInstall).
+			Reads(api.ChartSpec{}).
+			Writes(api.Release{}))
+	ws.Route(
+		ws.PUT("/chart/release/{namespace}/{release}").
+			To(self.handleUpgrade).
+			Reads(api.ChartSpec{}).
+			Writes(api.Release{}))
+	ws.Route(
+		ws.DELETE("/chart/release/{namespace}/{release}").
+			To(self.handleUninstall).
+			Writes(api.Release{}))
+}
+
+func (self *ChartHandler) handleListRepositories(request *restful.Request, response *restful.Response) {
+	response.WriteHeaderAndEntity(http.StatusOK, self.manager.ListRepositories())
+}
+
+func (self *ChartHandler) handleListCharts(request *restful.Request, response *restful.Response) {
+	result, err := self.manager.ListCharts(request.PathParameter("repository"))
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (self *ChartHandler) handleRender(request *restful.Request, response *restful.Response) {
+	spec := new(api.ChartSpec)
+	if err := request.ReadEntity(spec); err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	result, err := self.manager.Render(spec)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (self *ChartHandler) handleListReleases(request *restful.Request, response *restful.Response) {
+	cfg, err := self.clientManager.Config(request)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	result, err := self.manager.ListReleases(cfg, request.PathParameter("namespace"))
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	items := make([]api.Release, 0, len(result.Items))
+	for _, release := range result.Items {
+		if self.checkNamespace(request, release.Namespace) == nil {
+			items = append(items, release)
+		}
+	}
+
+	dataSelect := parser.ParseDataSelectPathParameter(request)
+	cells, filteredTotal := dataselect.GenericDataSelectWithFilter(toCells(items), dataSelect)
+	result.Items = fromCells(cells)
+	result.ListMeta.TotalItems = filteredTotal
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (self *ChartHandler) handleInstall(request *restful.Request, response *restful.Response) {
+	spec := new(api.ChartSpec)
+	if err := request.ReadEntity(spec); err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+	if err := self.checkNamespace(request, spec.Namespace); err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	cfg, err := self.clientManager.Config(request)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	result, err := self.manager.Install(cfg, spec, self.namespaceCheck(request))
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusCreated, result)
+}
+
+func (self *ChartHandler) handleUpgrade(request *restful.Request, response *restful.Response) {
+	spec := new(api.ChartSpec)
+	if err := request.ReadEntity(spec); err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+	spec.Namespace = request.PathParameter("namespace")
+	spec.ReleaseName = request.PathParameter("release")
+
+	cfg, err := self.clientManager.Config(request)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	result, err := self.manager.Upgrade(cfg, spec, self.namespaceCheck(request))
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (self *ChartHandler) handleUninstall(request *restful.Request, response *restful.Response) {
+	cfg, err := self.clientManager.Config(request)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	result, err := self.manager.Uninstall(cfg, request.PathParameter("namespace"),
+		request.PathParameter("release"))
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+// namespaceCheck returns checkNamespace bound to given request, that is applied to namespaces of rendered objects.
+func (self *ChartHandler) namespaceCheck(request *restful.Request) api.NamespaceCheck {
+	return func(namespace string) error {
+		return self.checkNamespace(request, namespace)
+	}
+}
+
+// NewChartHandler creates ChartHandler. Namespaces of installed and listed releases are checked with given
+// function, as unlike path parameters they are not covered by namespace restriction filters.
+func NewChartHandler(manager api.ChartManager, clientManager clientapi.ClientManager,
+	checkNamespace func(request *restful.Request, namespace string) error) ChartHandler {
+	return ChartHandler{manager: manager, clientManager: clientManager, checkNamespace: checkNamespace}
+}
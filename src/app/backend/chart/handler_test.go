@@ -0,0 +1,120 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chart
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	restful "github.com/emicklei/go-restful"
+	"k8s.io/client-go/rest"
+
+	"github.com/kubernetes/dashboard/src/app/backend/chart/api"
+	"github.com/kubernetes/dashboard/src/app/backend/client"
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+)
+
+// fakeChartManager lists releases from fixed namespaces and installs releases with objects in given namespace.
+type fakeChartManager struct {
+	api.ChartManager
+	objectNamespace string
+	installed       bool
+}
+
+func (self *fakeChartManager) ListReleases(cfg *rest.Config, namespace string) (*api.ReleaseList, error) {
+	return &api.ReleaseList{Items: []api.Release{
+		{Name: "web", Namespace: "team-a"},
+		{Name: "db", Namespace: "team-b"},
+		{Name: "cache", Namespace: "team-a"},
+	}}, nil
+}
+
+func (self *fakeChartManager) Install(cfg *rest.Config, spec *api.ChartSpec, check api.NamespaceCheck) (
+	*api.Release, error) {
+	if err := check(self.objectNamespace); err != nil {
+		return nil, err
+	}
+	self.installed = true
+	return &api.Release{Name: spec.ReleaseName, Namespace: spec.Namespace}, nil
+}
+
+func newTestChartContainer(manager api.ChartManager) *restful.Container {
+	checkNamespace := func(request *restful.Request, namespace string) error {
+		if len(namespace) > 0 && namespace != "team-a" {
+			return errors.NewForbidden("namespace " + namespace + " is restricted")
+		}
+		return nil
+	}
+
+	handler := NewChartHandler(manager, client.NewClientManager("", "http://localhost:8080"), checkNamespace)
+	ws := new(restful.WebService).Consumes(restful.MIME_JSON).Produces(restful.MIME_JSON)
+	handler.Install(ws)
+	container := restful.NewContainer()
+	container.Add(ws)
+	return container
+}
+
+func TestChartHandler_ListReleases(t *testing.T) {
+	container := newTestChartContainer(&fakeChartManager{})
+	recorder := httptest.NewRecorder()
+	container.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/chart/release", nil))
+
+	if recorder.Code != http.StatusOK || !bytes.Contains(recorder.Body.Bytes(), []byte(`"team-a"`)) ||
+		bytes.Contains(recorder.Body.Bytes(), []byte(`"team-b"`)) {
+		t.Errorf("Expected only releases from allowed namespaces, got %d %s", recorder.Code, recorder.Body)
+	}
+
+	recorder = httptest.NewRecorder()
+	container.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet,
+		"/chart/release?itemsPerPage=1&page=1&sortBy=a,name", nil))
+
+	result := new(api.ReleaseList)
+	if err := json.Unmarshal(recorder.Body.Bytes(), result); err != nil {
+		t.Fatalf("Cannot decode release list: %v", err)
+	}
+	if len(result.Items) != 1 || result.Items[0].Name != "cache" || result.ListMeta.TotalItems != 2 {
+		t.Errorf("Expected first of 2 allowed releases sorted by name, got %#v", result)
+	}
+}
+
+func TestChartHandler_InstallNamespaceCheck(t *testing.T) {
+	cases := []struct {
+		namespace       string
+		objectNamespace string
+		expected        int
+	}{
+		{"team-a", "team-a", http.StatusCreated},
+		{"team-b", "team-a", http.StatusForbidden},
+		{"team-a", "kube-system", http.StatusForbidden},
+	}
+
+	for _, c := range cases {
+		manager := &fakeChartManager{objectNamespace: c.objectNamespace}
+		container := newTestChartContainer(manager)
+		body := `{"repository":"test","chart":"demo","releaseName":"rel","namespace":"` + c.namespace + `"}`
+		request := httptest.NewRequest(http.MethodPost, "/chart/release", bytes.NewBufferString(body))
+		request.Header.Set("Content-Type", "application/json")
+		recorder := httptest.NewRecorder()
+		container.ServeHTTP(recorder, request)
+
+		if recorder.Code != c.expected || manager.installed != (c.expected == http.StatusCreated) {
+			t.Errorf("Install to %s with objects in %s returned %d %s (installed %t), expected %d", c.namespace,
+				c.objectNamespace, recorder.Code, recorder.Body, manager.installed, c.expected)
+		}
+	}
+}
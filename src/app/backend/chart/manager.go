@@ -0,0 +1,496 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chart
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"helm.sh/helm/v3/pkg/action"
+	helmchart "helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/release"
+	"helm.sh/helm/v3/pkg/releaseutil"
+	"helm.sh/helm/v3/pkg/repo"
+	"helm.sh/helm/v3/pkg/storage"
+	"helm.sh/helm/v3/pkg/storage/driver"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/yaml"
+
+	backendapi "github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/chart/api"
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+)
+
+const (
+	// Helm storage driver. Helm 3 stores releases as secrets in the namespace of the release by default.
+	storageDriver = "secret"
+	// Timeout of requests to chart repositories.
+	repositoryTimeout = 30 * time.Second
+	// Maximum size in bytes of a repository index. Indexes of large public repositories have tens of megabytes.
+	maxIndexSize = 64 << 20
+	// Maximum size in bytes of a chart archive.
+	maxChartSize = 16 << 20
+)
+
+// ChartManager is a structure containing all chart manager members.
+type ChartManager struct {
+	repositories map[string]string
+	client       *http.Client
+}
+
+// NewChartManager creates new chart manager for given repositories. Repositories are given as a map of
+// repository name to its URL.
+func NewChartManager(repositories map[string]string) api.ChartManager {
+	return &ChartManager{
+		repositories: repositories,
+		client:       &http.Client{Timeout: repositoryTimeout},
+	}
+}
+
+// ListRepositories implements chart manager interface. See ChartManager for more information.
+func (self *ChartManager) ListRepositories() api.RepositoryList {
+	result := api.RepositoryList{Items: make([]api.Repository, 0, len(self.repositories))}
+	for name, url := range self.repositories {
+		result.Items = append(result.Items, api.Repository{Name: name, URL: url})
+	}
+
+	sort.Slice(result.Items, func(i, j int) bool { return result.Items[i].Name < result.Items[j].Name })
+	return result
+}
+
+// ListCharts implements chart manager interface. See ChartManager for more information.
+func (self *ChartManager) ListCharts(repository string) (*api.ChartList, error) {
+	repo, err := self.getRepository(repository)
+	if err != nil {
+		return nil, err
+	}
+
+	index, err := self.loadIndex(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &api.ChartList{Repository: repo, Items: make([]api.Chart, 0, len(index.Entries))}
+	for _, versions := range index.Entries {
+		// Entries are sorted from the newest version.
+		if len(versions) == 0 || versions[0].Metadata == nil {
+			continue
+		}
+
+		latest := versions[0]
+		result.Items = append(result.Items, api.Chart{
+			Name:        latest.Name,
+			Version:     latest.Version,
+			AppVersion:  latest.AppVersion,
+			Description: latest.Description,
+			Icon:        latest.Icon,
+			Deprecated:  latest.Deprecated,
+		})
+	}
+
+	sort.Slice(result.Items, func(i, j int) bool { return result.Items[i].Name < result.Items[j].Name })
+	return result, nil
+}
+
+// Render implements chart manager interface. See ChartManager for more information.
+func (self *ChartManager) Render(spec *api.ChartSpec) (*api.RenderedChart, error) {
+	chart, values, err := self.loadChart(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	config := &action.Configuration{Releases: storage.Init(driver.NewMemory()), Log: debugLog}
+	install := action.NewInstall(config)
+	install.DryRun = true
+	install.ClientOnly = true
+	install.Replace = true
+	install.ReleaseName = spec.ReleaseName
+	install.Namespace = spec.Namespace
+
+	rel, err := install.Run(chart, values)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := strings.Builder{}
+	manifest.WriteString(rel.Manifest)
+	for _, hook := range rel.Hooks {
+		fmt.Fprintf(&manifest, "---\n# Source: %s\n%s\n", hook.Path, hook.Manifest)
+	}
+
+	result := &api.RenderedChart{Manifest: manifest.String()}
+	if rel.Info != nil {
+		result.Notes = rel.Info.Notes
+	}
+
+	return result, nil
+}
+
+// Install implements chart manager interface. See ChartManager for more information. Release is rendered with
+// a dry run first, so that namespaces of its objects can be checked before anything is created.
+func (self *ChartManager) Install(cfg *rest.Config, spec *api.ChartSpec, check api.NamespaceCheck) (*api.Release,
+	error) {
+	chart, values, err := self.loadChart(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := newActionConfig(cfg, spec.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	install := action.NewInstall(config)
+	install.ReleaseName = spec.ReleaseName
+	install.Namespace = spec.Namespace
+
+	install.DryRun = true
+	rendered, err := install.Run(chart, values)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkReleaseNamespaces(rendered, check); err != nil {
+		return nil, err
+	}
+
+	install.DryRun = false
+	rel, err := install.Run(chart, values)
+	if err != nil {
+		return nil, err
+	}
+
+	return toRelease(rel), nil
+}
+
+// Upgrade implements chart manager interface. See ChartManager for more information. Release is rendered with
+// a dry run first, so that namespaces of its objects can be checked before anything is changed.
+func (self *ChartManager) Upgrade(cfg *rest.Config, spec *api.ChartSpec, check api.NamespaceCheck) (*api.Release,
+	error) {
+	chart, values, err := self.loadChart(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := newActionConfig(cfg, spec.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	upgrade := action.NewUpgrade(config)
+	upgrade.Namespace = spec.Namespace
+
+	upgrade.DryRun = true
+	rendered, err := upgrade.Run(spec.ReleaseName, chart, values)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkReleaseNamespaces(rendered, check); err != nil {
+		return nil, err
+	}
+
+	upgrade.DryRun = false
+	rel, err := upgrade.Run(spec.ReleaseName, chart, values)
+	if err != nil {
+		return nil, err
+	}
+
+	return toRelease(rel), nil
+}
+
+// Uninstall implements chart manager interface. See ChartManager for more information.
+func (self *ChartManager) Uninstall(cfg *rest.Config, namespace, name string) (*api.Release, error) {
+	config, err := newActionConfig(cfg, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := action.NewUninstall(config).Run(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return toRelease(response.Release), nil
+}
+
+// ListReleases implements chart manager interface. See ChartManager for more information.
+func (self *ChartManager) ListReleases(cfg *rest.Config, namespace string) (*api.ReleaseList, error) {
+	config, err := newActionConfig(cfg, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	list := action.NewList(config)
+	list.All = true
+	list.AllNamespaces = len(namespace) == 0
+	list.StateMask = action.ListAll
+
+	releases, err := list.Run()
+	if err != nil {
+		return nil, err
+	}
+
+	return toReleaseList(releases), nil
+}
+
+func (self *ChartManager) getRepository(name string) (api.Repository, error) {
+	url, ok := self.repositories[name]
+	if !ok {
+		return api.Repository{}, errors.NewNotFound(fmt.Sprintf("chart repository %s is not configured", name))
+	}
+
+	return api.Repository{Name: name, URL: url}, nil
+}
+
+// loadIndex downloads index file of given repository.
+func (self *ChartManager) loadIndex(repository api.Repository) (*repo.IndexFile, error) {
+	data, err := self.download(strings.TrimSuffix(repository.URL, "/")+"/index.yaml", maxIndexSize)
+	if err != nil {
+		return nil, err
+	}
+
+	index := &repo.IndexFile{}
+	if err := yaml.Unmarshal(data, index); err != nil {
+		return nil, err
+	}
+
+	index.SortEntries()
+	return index, nil
+}
+
+// loadChart downloads chart archive matching given spec and parses its values.
+func (self *ChartManager) loadChart(spec *api.ChartSpec) (*helmchart.Chart, map[string]interface{}, error) {
+	repository, err := self.getRepository(spec.Repository)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	index, err := self.loadIndex(repository)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	version, err := index.Get(spec.Chart, spec.Version)
+	if err != nil {
+		return nil, nil, errors.NewNotFound(fmt.Sprintf("chart %s (%s) not found in repository %s: %s",
+			spec.Chart, spec.Version, spec.Repository, err.Error()))
+	}
+
+	if len(version.URLs) == 0 {
+		return nil, nil, errors.NewInternal(fmt.Sprintf("chart %s %s has no download URL", version.Name,
+			version.Version))
+	}
+
+	url, err := repo.ResolveReferenceURL(repository.URL, version.URLs[0])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	data, err := self.download(url, maxChartSize)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	chart, err := loader.LoadArchive(bytes.NewReader(data))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	values, err := chartutil.ReadValues([]byte(spec.Values))
+	if err != nil {
+		return nil, nil, errors.NewBadRequest(fmt.Sprintf("invalid chart values: %s", err.Error()))
+	}
+
+	return chart, values, nil
+}
+
+// download returns content of given url. Error is returned when the content is larger than given limit.
+func (self *ChartManager) download(url string, limit int64) ([]byte, error) {
+	response, err := self.client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, errors.NewInternal(fmt.Sprintf("failed to download %s: %s", url, response.Status))
+	}
+
+	data, err := ioutil.ReadAll(io.LimitReader(response.Body, limit+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if int64(len(data)) > limit {
+		return nil, errors.NewBadRequest(fmt.Sprintf("%s is larger than %d bytes", url, limit))
+	}
+
+	return data, nil
+}
+
+// newActionConfig initializes Helm action configuration that uses credentials of given config and stores
+// releases in given namespace.
+func newActionConfig(cfg *rest.Config, namespace string) (*action.Configuration, error) {
+	config := new(action.Configuration)
+	getter := &restClientGetter{config: cfg, namespace: namespace}
+	if err := config.Init(getter, namespace, storageDriver, debugLog); err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
+// renderedObject is a part of a rendered manifest needed to find out the namespace of the object.
+type renderedObject struct {
+	Kind     string `json:"kind"`
+	Metadata struct {
+		Name      string `json:"name"`
+		Namespace string `json:"namespace"`
+	} `json:"metadata"`
+	Items []renderedObject `json:"items"`
+}
+
+// checkReleaseNamespaces applies given check to the namespace of the release and to namespaces of all objects of
+// its manifest and hooks. Objects without namespace are created in the namespace of the release. For namespace
+// objects the created namespace is checked.
+func checkReleaseNamespaces(rel *release.Release, check api.NamespaceCheck) error {
+	if check == nil {
+		return nil
+	}
+	if err := check(rel.Namespace); err != nil {
+		return err
+	}
+
+	manifests := []string{rel.Manifest}
+	for _, hook := range rel.Hooks {
+		manifests = append(manifests, hook.Manifest)
+	}
+
+	for _, manifest := range manifests {
+		for _, document := range releaseutil.SplitManifests(manifest) {
+			object := renderedObject{}
+			if err := yaml.Unmarshal([]byte(document), &object); err != nil {
+				return errors.NewBadRequest(fmt.Sprintf("invalid rendered manifest: %s", err.Error()))
+			}
+			if err := checkObjectNamespace(object, check); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func checkObjectNamespace(object renderedObject, check api.NamespaceCheck) error {
+	namespace := object.Metadata.Namespace
+	if object.Kind == "Namespace" {
+		namespace = object.Metadata.Name
+	}
+	if len(namespace) > 0 {
+		if err := check(namespace); err != nil {
+			return err
+		}
+	}
+
+	for _, item := range object.Items {
+		if err := checkObjectNamespace(item, check); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// debugLog discards verbose logs of Helm actions. Errors are returned and logged by the handler.
+func debugLog(format string, v ...interface{}) {}
+
+func toReleaseList(releases []*release.Release) *api.ReleaseList {
+	result := &api.ReleaseList{
+		ListMeta: backendapi.ListMeta{TotalItems: len(releases)},
+		Items:    make([]api.Release, 0, len(releases)),
+	}
+
+	for _, rel := range releases {
+		result.Items = append(result.Items, *toRelease(rel))
+	}
+
+	return result
+}
+
+func toRelease(rel *release.Release) *api.Release {
+	result := &api.Release{
+		Name:      rel.Name,
+		Namespace: rel.Namespace,
+		Revision:  rel.Version,
+	}
+
+	if rel.Chart != nil && rel.Chart.Metadata != nil {
+		result.Chart = rel.Chart.Metadata.Name
+		result.ChartVersion = rel.Chart.Metadata.Version
+		result.AppVersion = rel.Chart.Metadata.AppVersion
+	}
+
+	if rel.Info != nil {
+		result.Status = rel.Info.Status.String()
+		result.Description = rel.Info.Description
+		result.LastDeployed = rel.Info.LastDeployed.Time
+	}
+
+	return result
+}
+
+// ReleaseCell is a wrapper of Release, that implements DataCell interface. Releases are sorted by time of the last
+// deployment and filtered by name.
+type ReleaseCell api.Release
+
+// GetProperty implements DataCell interface. See DataCell for more information.
+func (self ReleaseCell) GetProperty(name dataselect.PropertyName) dataselect.ComparableValue {
+	switch name {
+	case dataselect.NameProperty:
+		return dataselect.StdComparableString(self.Name)
+	case dataselect.CreationTimestampProperty:
+		return dataselect.StdComparableTime(self.LastDeployed)
+	case dataselect.NamespaceProperty:
+		return dataselect.StdComparableString(self.Namespace)
+	case dataselect.StatusProperty:
+		return dataselect.StdComparableString(self.Status)
+	default:
+		// if name is not supported then just return a constant dummy value, sort will have no effect.
+		return nil
+	}
+}
+
+func toCells(std []api.Release) []dataselect.DataCell {
+	cells := make([]dataselect.DataCell, len(std))
+	for i := range std {
+		cells[i] = ReleaseCell(std[i])
+	}
+	return cells
+}
+
+func fromCells(cells []dataselect.DataCell) []api.Release {
+	std := make([]api.Release, len(cells))
+	for i := range std {
+		std[i] = api.Release(cells[i].(ReleaseCell))
+	}
+	return std
+}
@@ -0,0 +1,202 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chart
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	helmchart "helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/release"
+	"helm.sh/helm/v3/pkg/repo"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kubernetes/dashboard/src/app/backend/chart/api"
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+)
+
+// newTestRepository serves a chart repository with a single chart from a temporary directory.
+func newTestRepository(t *testing.T) (*httptest.Server, func()) {
+	dir, err := ioutil.TempDir("", "chart-repository")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	chart := &helmchart.Chart{
+		Metadata: &helmchart.Metadata{APIVersion: helmchart.APIVersionV2, Name: "demo", Version: "0.1.0",
+			AppVersion: "1.0", Description: "Demo chart"},
+		Templates: []*helmchart.File{{
+			Name: "templates/configmap.yaml",
+			Data: []byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: {{ .Release.Name }}\n" +
+				"data:\n  greeting: {{ .Values.greeting }}\n"),
+		}},
+		Raw: []*helmchart.File{{Name: chartutil.ValuesfileName, Data: []byte("greeting: hello\n")}},
+	}
+
+	archive, err := chartutil.Save(chart, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	index := repo.NewIndexFile()
+	index.Add(chart.Metadata, filepath.Base(archive), "", "")
+	data, err := yaml.Marshal(index)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ioutil.WriteFile(filepath.Join(dir, "index.yaml"), data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	server := httptest.NewServer(http.FileServer(http.Dir(dir)))
+	return server, func() {
+		server.Close()
+		os.RemoveAll(dir)
+	}
+}
+
+func TestChartManager_ListRepositories(t *testing.T) {
+	manager := NewChartManager(map[string]string{"stable": "https://stable", "incubator": "https://incubator"})
+	expected := api.RepositoryList{Items: []api.Repository{
+		{Name: "incubator", URL: "https://incubator"},
+		{Name: "stable", URL: "https://stable"},
+	}}
+
+	if actual := manager.ListRepositories(); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("ListRepositories() == %#v, expected %#v", actual, expected)
+	}
+}
+
+func TestChartManager_ListCharts(t *testing.T) {
+	server, cleanup := newTestRepository(t)
+	defer cleanup()
+
+	manager := NewChartManager(map[string]string{"test": server.URL})
+	actual, err := manager.ListCharts("test")
+	if err != nil {
+		t.Fatalf("ListCharts() returned unexpected error: %s", err)
+	}
+
+	expected := &api.ChartList{
+		Repository: api.Repository{Name: "test", URL: server.URL},
+		Items:      []api.Chart{{Name: "demo", Version: "0.1.0", AppVersion: "1.0", Description: "Demo chart"}},
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("ListCharts() == %#v, expected %#v", actual, expected)
+	}
+
+	if _, err := manager.ListCharts("missing"); err == nil {
+		t.Error("Expected error for repository that is not configured")
+	}
+}
+
+func TestChartManager_Render(t *testing.T) {
+	server, cleanup := newTestRepository(t)
+	defer cleanup()
+
+	manager := NewChartManager(map[string]string{"test": server.URL})
+	cases := []struct {
+		spec     *api.ChartSpec
+		expected string
+	}{
+		{
+			&api.ChartSpec{Repository: "test", Chart: "demo", ReleaseName: "rel", Namespace: "default"},
+			"greeting: hello",
+		},
+		{
+			&api.ChartSpec{Repository: "test", Chart: "demo", Version: "0.1.0", ReleaseName: "rel",
+				Namespace: "default", Values: "greeting: hi"},
+			"greeting: hi",
+		},
+	}
+
+	for _, c := range cases {
+		actual, err := manager.Render(c.spec)
+		if err != nil {
+			t.Errorf("Render(%#v) returned unexpected error: %s", c.spec, err)
+			continue
+		}
+
+		if !strings.Contains(actual.Manifest, c.expected) || !strings.Contains(actual.Manifest, "name: rel") {
+			t.Errorf("Render(%#v) == %q, expected it to contain %q", c.spec, actual.Manifest, c.expected)
+		}
+	}
+}
+
+func TestChartManager_DownloadLimit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(strings.Repeat("a", 100)))
+	}))
+	defer server.Close()
+
+	manager := &ChartManager{client: http.DefaultClient}
+	if _, err := manager.download(server.URL, 100); err != nil {
+		t.Errorf("download() returned unexpected error for content within the limit: %s", err)
+	}
+
+	if _, err := manager.download(server.URL, 99); err == nil {
+		t.Error("download() expected to return error for content over the limit")
+	}
+}
+
+func TestCheckReleaseNamespaces(t *testing.T) {
+	check := func(namespace string) error {
+		if namespace != "team-a" {
+			return errors.NewForbidden("namespace " + namespace + " is restricted")
+		}
+		return nil
+	}
+	manifest := "---\n# Source: demo/templates/configmap.yaml\napiVersion: v1\nkind: ConfigMap\nmetadata:\n" +
+		"  name: rel\n"
+
+	cases := []struct {
+		rel      *release.Release
+		expected bool
+	}{
+		{&release.Release{Namespace: "team-a", Manifest: manifest}, true},
+		{&release.Release{Namespace: "team-a", Manifest: manifest + "---\napiVersion: v1\nkind: Secret\n" +
+			"metadata:\n  name: token\n  namespace: team-a\n"}, true},
+		{&release.Release{Namespace: "team-b", Manifest: manifest}, false},
+		{&release.Release{Namespace: "team-a", Manifest: manifest + "---\napiVersion: v1\nkind: Secret\n" +
+			"metadata:\n  name: token\n  namespace: kube-system\n"}, false},
+		{&release.Release{Namespace: "team-a", Manifest: "apiVersion: v1\nkind: Namespace\nmetadata:\n" +
+			"  name: team-b\n"}, false},
+		{&release.Release{Namespace: "team-a", Manifest: "apiVersion: v1\nkind: List\nitems:\n" +
+			"- apiVersion: v1\n  kind: ConfigMap\n  metadata:\n    name: rel\n    namespace: team-b\n"}, false},
+		{&release.Release{Namespace: "team-a", Manifest: manifest, Hooks: []*release.Hook{{Path: "hook.yaml",
+			Manifest: "apiVersion: batch/v1\nkind: Job\nmetadata:\n  name: migrate\n  namespace: team-b\n"}}},
+			false},
+	}
+
+	for _, c := range cases {
+		err := checkReleaseNamespaces(c.rel, check)
+		if (err == nil) != c.expected {
+			t.Errorf("checkReleaseNamespaces(%q, %v) returned %v, expected allowed %t", c.rel.Manifest,
+				c.rel.Hooks, err, c.expected)
+		}
+	}
+
+	if err := checkReleaseNamespaces(&release.Release{Namespace: "team-b"}, nil); err != nil {
+		t.Errorf("checkReleaseNamespaces() without check returned unexpected error: %s", err)
+	}
+}
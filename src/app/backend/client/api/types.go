@@ -19,6 +19,7 @@ import (
 	v1 "k8s.io/api/authorization/v1"
 	apiextensionsclientset "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
@@ -52,12 +53,29 @@ type ClientManager interface {
 	HasAccess(authInfo api.AuthInfo) error
 	VerberClient(req *restful.Request, config *rest.Config) (ResourceVerber, error)
 	SetTokenManager(manager authApi.TokenManager)
+	Username(req *restful.Request) (string, error)
+	KnownUsername(req *restful.Request) string
+	ConfigMode() ConfigMode
 }
 
+// ConfigMode describes how Dashboard connects to the apiserver.
+type ConfigMode string
+
+const (
+	// ExplicitConfigMode is used when --apiserver-host or --kubeconfig flag is set.
+	ExplicitConfigMode ConfigMode = "explicit"
+	// KubeConfigMode is used when default kubeconfig file, i.e. $KUBECONFIG or ~/.kube/config, exists.
+	KubeConfigMode ConfigMode = "kubeconfig"
+	// InClusterConfigMode is used when Dashboard runs in a pod and connects using its service account.
+	InClusterConfigMode ConfigMode = "in-cluster"
+)
+
 // ResourceVerber is responsible for performing generic CRUD operations on all supported resources.
 type ResourceVerber interface {
 	Put(kind string, namespaceSet bool, namespace string, name string,
 		object *runtime.Unknown) error
+	Patch(kind string, namespaceSet bool, namespace string, name string, patchType types.PatchType,
+		data []byte) (runtime.Object, error)
 	Get(kind string, namespaceSet bool, namespace string, name string) (runtime.Object, error)
 	Delete(kind string, namespaceSet bool, namespace string, name string) error
 }
@@ -0,0 +1,209 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/emicklei/go-restful"
+	authv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/client-go/tools/clientcmd/api"
+
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+)
+
+const (
+	// identityCacheTTL is how long a verified user name is reused for the same credentials.
+	identityCacheTTL = time.Minute
+
+	// maxIdentityCacheSize is a maximum number of cached user names. Cache is cleared when it is full.
+	maxIdentityCacheSize = 10000
+)
+
+// identityCacheEntry is a user name verified for a set of credentials.
+type identityCacheEntry struct {
+	username string
+	expires  time.Time
+}
+
+// identityCache caches verified user names by hash of the credentials, so that the apiserver is not asked to
+// review the same token on every request. Nil cache does not cache anything.
+type identityCache struct {
+	mu      sync.Mutex
+	entries map[string]identityCacheEntry
+}
+
+func newIdentityCache() *identityCache {
+	return &identityCache{entries: make(map[string]identityCacheEntry)}
+}
+
+func (self *identityCache) get(key string) (string, bool) {
+	if self == nil {
+		return "", false
+	}
+
+	self.mu.Lock()
+	defer self.mu.Unlock()
+	entry, ok := self.entries[key]
+	if !ok || time.Now().After(entry.expires) {
+		return "", false
+	}
+	return entry.username, true
+}
+
+func (self *identityCache) set(key, username string) {
+	if self == nil {
+		return
+	}
+
+	self.mu.Lock()
+	defer self.mu.Unlock()
+	now := time.Now()
+	if len(self.entries) >= maxIdentityCacheSize {
+		for k, entry := range self.entries {
+			if now.After(entry.expires) {
+				delete(self.entries, k)
+			}
+		}
+	}
+	if len(self.entries) >= maxIdentityCacheSize {
+		self.entries = make(map[string]identityCacheEntry)
+	}
+	self.entries[key] = identityCacheEntry{username: username, expires: now.Add(identityCacheTTL)}
+}
+
+// identityKey returns hash of the credentials and of the impersonated user of the auth info.
+func identityKey(authInfo *api.AuthInfo) string {
+	hash := sha256.Sum256([]byte(strings.Join([]string{authInfo.Token, authInfo.Username, authInfo.Password,
+		authInfo.Impersonate}, "\x00")))
+	return hex.EncodeToString(hash[:])
+}
+
+// Username returns verified name of the user authenticated by the request. Users authenticated by a trusted
+// proxy are taken as they are. Bearer tokens are resolved with a token review and basic auth credentials are
+// checked against the apiserver. Impersonated user is returned only when the authenticated user is allowed to
+// impersonate it.
+func (self *clientManager) Username(req *restful.Request) (string, error) {
+	if authInfo := self.proxyImpersonationInfo(req); authInfo != nil {
+		return authInfo.Impersonate, nil
+	}
+
+	authInfo, err := self.extractAuthInfo(req)
+	if err != nil {
+		return "", err
+	}
+
+	key := identityKey(authInfo)
+	if username, ok := self.identities.get(key); ok {
+		return username, nil
+	}
+
+	username, err := self.verifyUsername(authInfo)
+	if err != nil {
+		return "", err
+	}
+
+	self.identities.set(key, username)
+	return username, nil
+}
+
+// KnownUsername returns name of the user authenticated by the request if it was already verified by Username.
+// Empty string is returned otherwise. It never calls the apiserver, so it is cheap enough to be used in logs.
+func (self *clientManager) KnownUsername(req *restful.Request) string {
+	if authInfo := self.proxyImpersonationInfo(req); authInfo != nil {
+		return authInfo.Impersonate
+	}
+
+	authInfo, err := self.extractAuthInfo(req)
+	if err != nil {
+		return ""
+	}
+
+	username, _ := self.identities.get(identityKey(authInfo))
+	return username
+}
+
+func (self *clientManager) verifyUsername(authInfo *api.AuthInfo) (string, error) {
+	var user authv1.UserInfo
+	switch {
+	case len(authInfo.Token) > 0:
+		review, err := self.InsecureClient().AuthenticationV1().TokenReviews().Create(&authv1.TokenReview{
+			Spec: authv1.TokenReviewSpec{Token: authInfo.Token},
+		})
+		if err != nil {
+			return "", err
+		}
+
+		if !review.Status.Authenticated {
+			return "", errors.NewUnauthorized(errors.MsgLoginUnauthorizedError)
+		}
+		user = review.Status.User
+	case len(authInfo.Username) > 0:
+		// Impersonation is checked below, so that the check is done with the basic auth user only.
+		if err := self.HasAccess(api.AuthInfo{Username: authInfo.Username, Password: authInfo.Password}); err != nil {
+			return "", errors.NewUnauthorized(errors.MsgLoginUnauthorizedError)
+		}
+		user = authv1.UserInfo{Username: authInfo.Username}
+	default:
+		return "", errors.NewUnauthorized(errors.MsgLoginUnauthorizedError)
+	}
+
+	if len(authInfo.Impersonate) == 0 {
+		return user.Username, nil
+	}
+
+	if err := self.checkImpersonation(user, authInfo.Impersonate); err != nil {
+		return "", err
+	}
+	return authInfo.Impersonate, nil
+}
+
+// checkImpersonation returns forbidden error unless the given user is allowed to impersonate the other one.
+func (self *clientManager) checkImpersonation(user authv1.UserInfo, impersonate string) error {
+	extra := make(map[string]authorizationv1.ExtraValue, len(user.Extra))
+	for key, value := range user.Extra {
+		extra[key] = authorizationv1.ExtraValue(value)
+	}
+
+	review, err := self.InsecureClient().AuthorizationV1().SubjectAccessReviews().Create(
+		&authorizationv1.SubjectAccessReview{
+			Spec: authorizationv1.SubjectAccessReviewSpec{
+				User:   user.Username,
+				Groups: user.Groups,
+				UID:    user.UID,
+				Extra:  extra,
+				ResourceAttributes: &authorizationv1.ResourceAttributes{
+					Verb:     "impersonate",
+					Resource: "users",
+					Name:     impersonate,
+				},
+			},
+		})
+	if err != nil {
+		return err
+	}
+
+	if !review.Status.Allowed {
+		return errors.NewForbidden(fmt.Sprintf("user %q is not allowed to impersonate user %q", user.Username,
+			impersonate))
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/emicklei/go-restful"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd/api"
+
+	"github.com/kubernetes/dashboard/src/app/backend/args"
+)
+
+// impersonatingProxyKey is a context key of requests sent by authenticating proxies.
+type impersonatingProxyKey struct{}
+
+// WithImpersonatingProxy marks the request as sent by an authenticating proxy set by --impersonating-proxies.
+// Impersonation headers of such requests can be used without credentials, see proxyImpersonationInfo.
+func WithImpersonatingProxy(r *http.Request) *http.Request {
+	return r.WithContext(context.WithValue(r.Context(), impersonatingProxyKey{}, true))
+}
+
+func isSentByImpersonatingProxy(req *restful.Request) bool {
+	trusted, _ := req.Request.Context().Value(impersonatingProxyKey{}).(bool)
+	return trusted
+}
+
+// setImpersonation copies Impersonate-User, Impersonate-Group and Impersonate-Extra- headers of the request to
+// the auth info. Groups and extra fields are used only together with the user.
+func setImpersonation(req *restful.Request, authInfo *api.AuthInfo) {
+	impersonationHeader := req.HeaderParameter("Impersonate-User")
+	if len(impersonationHeader) == 0 {
+		return
+	}
+
+	authInfo.Impersonate = impersonationHeader
+	if groupsImpersonationHeader := req.Request.Header["Impersonate-Group"]; len(groupsImpersonationHeader) > 0 {
+		authInfo.ImpersonateGroups = groupsImpersonationHeader
+	}
+
+	for headerName, headerValues := range req.Request.Header {
+		if strings.HasPrefix(headerName, ImpersonateUserExtraHeader) {
+			extraName := headerName[len(ImpersonateUserExtraHeader):]
+			if authInfo.ImpersonateUserExtra == nil {
+				authInfo.ImpersonateUserExtra = make(map[string][]string)
+			}
+			authInfo.ImpersonateUserExtra[extraName] = headerValues
+		}
+	}
+}
+
+// proxyImpersonationInfo returns auth info of the user impersonated with privileges of Dashboard service account.
+// It is used when proxy impersonation is enabled and the request was sent by an authenticating proxy, that
+// authenticated the user, without any credentials. Only groups set by --proxy-impersonation-groups are
+// impersonated. Nil is returned otherwise.
+func (self *clientManager) proxyImpersonationInfo(req *restful.Request) *api.AuthInfo {
+	if !args.Holder.GetEnableProxyImpersonation() || !isSentByImpersonatingProxy(req) {
+		return nil
+	}
+
+	if len(self.extractTokenFromHeader(req.HeaderParameter("Authorization"))) > 0 ||
+		len(self.extractJWEToken(req)) > 0 {
+		return nil
+	}
+
+	authInfo := &api.AuthInfo{}
+	setImpersonation(req, authInfo)
+	if len(authInfo.Impersonate) == 0 {
+		return nil
+	}
+	authInfo.ImpersonateGroups = allowedGroups(authInfo.ImpersonateGroups, args.Holder.GetProxyImpersonationGroups())
+	return authInfo
+}
+
+// allowedGroups returns groups, that are in the allowed list. Nil is returned if there are none, so that no
+// groups are impersonated.
+func allowedGroups(groups, allowed []string) []string {
+	var result []string
+	for _, group := range groups {
+		for _, a := range allowed {
+			if group == a {
+				result = append(result, group)
+				break
+			}
+		}
+	}
+	return result
+}
+
+// toImpersonationConfig converts impersonation fields of the auth info to the rest config ones.
+func toImpersonationConfig(authInfo *api.AuthInfo) rest.ImpersonationConfig {
+	return rest.ImpersonationConfig{
+		UserName: authInfo.Impersonate,
+		Groups:   authInfo.ImpersonateGroups,
+		Extra:    authInfo.ImpersonateUserExtra,
+	}
+}
@@ -15,16 +15,22 @@
 package client
 
 import (
+	"context"
 	"log"
+	"net/http"
+	"net/url"
+	"os"
 	"strings"
 
 	"github.com/emicklei/go-restful"
 	v1 "k8s.io/api/authorization/v1"
 	apiextensionsclientset "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	utilnet "k8s.io/apimachinery/pkg/util/net"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/client-go/tools/clientcmd/api"
+	"k8s.io/client-go/transport"
 
 	pluginclientset "github.com/kubernetes/dashboard/src/app/backend/plugin/client/clientset/versioned"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/customresourcedefinition"
@@ -34,6 +40,7 @@ import (
 	clientapi "github.com/kubernetes/dashboard/src/app/backend/client/api"
 	"github.com/kubernetes/dashboard/src/app/backend/client/csrf"
 	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/tracing"
 )
 
 // Dashboard UI default values for client configs.
@@ -50,10 +57,16 @@ const (
 	DefaultCmdConfigName = "kubernetes"
 	// Header name that contains token used for authorization. See TokenManager for more information.
 	JWETokenHeader = "jweToken"
+	// Cookie name that contains token used for authorization. Frontend stores the token in a same-site cookie, so
+	// that it is also sent with requests, that can not set headers, i.e. downloads and WebSocket connections.
+	JWETokenCookie = "jweToken"
 	// Default http header for user-agent
 	DefaultUserAgent = "dashboard"
 	//Impersonation Extra header
 	ImpersonateUserExtraHeader = "Impersonate-Extra-"
+	// Header name that identifies request in logs of Dashboard and of the apiserver. Incoming value is reused,
+	// so that requests can be correlated with logs of a proxy in front of Dashboard.
+	RequestIDHeader = "X-Request-Id"
 )
 
 // VERSION of this binary
@@ -68,8 +81,11 @@ type clientManager struct {
 	kubeConfigPath string
 	// Address of apiserver host in format 'protocol://address:port'
 	apiserverHost string
+	// Determines which of the above is used to connect to the apiserver. Explicit flags are preferred over
+	// default kubeconfig file, which is preferred over in-cluster config.
+	configMode clientapi.ConfigMode
 	// Initialized on clientManager creation and used if kubeconfigPath and apiserverHost are
-	// empty
+	// empty and there is no default kubeconfig file
 	inClusterConfig *rest.Config
 	// Responsible for decrypting tokens coming in request header. Used for authentication.
 	tokenManager authApi.TokenManager
@@ -86,6 +102,8 @@ type clientManager struct {
 	// to service account used by dashboard or kubeconfig file if it was passed during dashboard
 	// init.
 	insecureConfig *rest.Config
+	// Caches user names verified by Username.
+	identities *identityCache
 }
 
 // Client returns a kubernetes client. In case dashboard login is enabled and option to skip
@@ -100,7 +118,12 @@ func (self *clientManager) Client(req *restful.Request) (kubernetes.Interface, e
 		return self.secureClient(req)
 	}
 
-	return self.InsecureClient(), nil
+	cfg, err := self.insecureRequestConfig(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return kubernetes.NewForConfig(cfg)
 }
 
 // APIExtensionsClient returns an API Extensions client. In case dashboard login is enabled and
@@ -115,7 +138,12 @@ func (self *clientManager) APIExtensionsClient(req *restful.Request) (apiextensi
 		return self.secureAPIExtensionsClient(req)
 	}
 
-	return self.InsecureAPIExtensionsClient(), nil
+	cfg, err := self.insecureRequestConfig(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return apiextensionsclientset.NewForConfig(cfg)
 }
 
 // PluginClient returns a plugin client. In case dashboard login is enabled and
@@ -130,7 +158,12 @@ func (self *clientManager) PluginClient(req *restful.Request) (pluginclientset.I
 		return self.securePluginClient(req)
 	}
 
-	return self.InsecurePluginClient(), nil
+	cfg, err := self.insecureRequestConfig(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return pluginclientset.NewForConfig(cfg)
 }
 
 // Config returns a rest config. In case dashboard login is enabled and option to skip
@@ -145,7 +178,7 @@ func (self *clientManager) Config(req *restful.Request) (*rest.Config, error) {
 		return self.secureConfig(req)
 	}
 
-	return self.InsecureConfig(), nil
+	return self.insecureRequestConfig(req)
 }
 
 // InsecureClient returns kubernetes client that was created without providing auth info. It uses
@@ -286,21 +319,32 @@ func (self *clientManager) initConfig(cfg *rest.Config) {
 	cfg.UserAgent = DefaultUserAgent + "/" + Version
 }
 
+// ConfigMode returns the way Dashboard connects to the apiserver.
+func (self *clientManager) ConfigMode() clientapi.ConfigMode {
+	return self.configMode
+}
+
 // Returns rest Config based on provided apiserverHost and kubeConfigPath flags. If both are
-// empty then in-cluster config will be used and if it is nil the error is returned.
+// empty then default kubeconfig file or in-cluster config will be used and if there is none the error
+// is returned.
 func (self *clientManager) buildConfigFromFlags(apiserverHost, kubeConfigPath string) (
 	*rest.Config, error) {
-	if len(kubeConfigPath) > 0 || len(apiserverHost) > 0 {
+	switch self.configMode {
+	case clientapi.ExplicitConfigMode:
 		return clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
 			&clientcmd.ClientConfigLoadingRules{ExplicitPath: kubeConfigPath},
 			&clientcmd.ConfigOverrides{ClusterInfo: api.Cluster{Server: apiserverHost}}).ClientConfig()
+	case clientapi.KubeConfigMode:
+		return clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+			clientcmd.NewDefaultClientConfigLoadingRules(), &clientcmd.ConfigOverrides{}).ClientConfig()
 	}
 
 	if self.isRunningInCluster() {
 		return self.inClusterConfig, nil
 	}
 
-	return nil, errors.NewInvalid("could not create client config")
+	return nil, errors.NewInvalid("could not create client config, set --apiserver-host or --kubeconfig flag " +
+		"when Dashboard does not run in a cluster")
 }
 
 // Based on auth info and rest config creates client cmd config.
@@ -312,6 +356,12 @@ func (self *clientManager) buildCmdConfig(authInfo *api.AuthInfo, cfg *rest.Conf
 		CertificateAuthorityData: cfg.TLSClientConfig.CAData,
 		InsecureSkipTLSVerify:    cfg.TLSClientConfig.Insecure,
 	}
+	// Auth provider config is kept in the auth info only to refresh the bearer token before it expires. Token
+	// itself is sent to the apiserver, so that no auth provider plugin is needed.
+	if authInfo != nil && authInfo.AuthProvider != nil {
+		authInfo = authInfo.DeepCopy()
+		authInfo.AuthProvider = nil
+	}
 	cmdCfg.AuthInfos[DefaultCmdConfigName] = authInfo
 	cmdCfg.Contexts[DefaultCmdConfigName] = &api.Context{
 		Cluster:  DefaultCmdConfigName,
@@ -328,36 +378,14 @@ func (self *clientManager) buildCmdConfig(authInfo *api.AuthInfo, cfg *rest.Conf
 // Extracts authorization information from the request header
 func (self *clientManager) extractAuthInfo(req *restful.Request) (*api.AuthInfo, error) {
 	authHeader := req.HeaderParameter("Authorization")
-	impersonationHeader := req.HeaderParameter("Impersonate-User")
-	jweToken := req.HeaderParameter(JWETokenHeader)
+	jweToken := self.extractJWEToken(req)
 
 	// Authorization header will be more important than our token
 	token := self.extractTokenFromHeader(authHeader)
 	if len(token) > 0 {
 
 		authInfo := &api.AuthInfo{Token: token}
-
-		if len(impersonationHeader) > 0 {
-			//there's an impersonation header, lets make sure to add it
-			authInfo.Impersonate = impersonationHeader
-
-			//Check for impersonated groups
-			if groupsImpersonationHeader := req.Request.Header["Impersonate-Group"]; len(groupsImpersonationHeader) > 0 {
-				authInfo.ImpersonateGroups = groupsImpersonationHeader
-			}
-
-			//check for extra fields
-			for headerName, headerValues := range req.Request.Header {
-				if strings.HasPrefix(headerName, ImpersonateUserExtraHeader) {
-					extraName := headerName[len(ImpersonateUserExtraHeader):]
-					if authInfo.ImpersonateUserExtra == nil {
-						authInfo.ImpersonateUserExtra = make(map[string][]string)
-					}
-					authInfo.ImpersonateUserExtra[extraName] = headerValues
-				}
-			}
-		}
-
+		setImpersonation(req, authInfo)
 		return authInfo, nil
 	}
 
@@ -365,15 +393,43 @@ func (self *clientManager) extractAuthInfo(req *restful.Request) (*api.AuthInfo,
 		return self.tokenManager.Decrypt(jweToken)
 	}
 
+	if authInfo := self.proxyImpersonationInfo(req); authInfo != nil {
+		return authInfo, nil
+	}
+
 	return nil, errors.NewUnauthorized(errors.MsgLoginUnauthorizedError)
 }
 
 // Checks if request headers contain any auth information without parsing.
 func (self *clientManager) containsAuthInfo(req *restful.Request) bool {
 	authHeader := req.HeaderParameter("Authorization")
-	jweToken := req.HeaderParameter(JWETokenHeader)
+	jweToken := self.extractJWEToken(req)
+
+	return len(authHeader) > 0 || len(jweToken) > 0 || self.proxyImpersonationInfo(req) != nil
+}
+
+// extractJWEToken returns token from the JWE token header. Cookie is used only by read-only requests, as other
+// requests are protected against CSRF attacks by the header, that can not be set cross-site.
+func (self *clientManager) extractJWEToken(req *restful.Request) string {
+	if jweToken := req.HeaderParameter(JWETokenHeader); len(jweToken) > 0 {
+		return jweToken
+	}
+
+	if req.Request.Method != http.MethodGet && req.Request.Method != http.MethodHead {
+		return ""
+	}
+
+	cookie, err := req.Request.Cookie(JWETokenCookie)
+	if err != nil {
+		return ""
+	}
 
-	return len(authHeader) > 0 || len(jweToken) > 0
+	// Frontend encodes cookie values, as serialized token contains characters not allowed in cookies.
+	jweToken, err := url.PathUnescape(cookie.Value)
+	if err != nil {
+		return ""
+	}
+	return jweToken
 }
 
 func (self *clientManager) extractTokenFromHeader(authHeader string) string {
@@ -441,6 +497,11 @@ func (self *clientManager) securePluginClient(req *restful.Request) (pluginclien
 }
 
 func (self *clientManager) secureConfig(req *restful.Request) (*rest.Config, error) {
+	// Users authenticated by a trusted proxy have no credentials, that could be used in the client config.
+	if self.proxyImpersonationInfo(req) != nil {
+		return self.insecureRequestConfig(req)
+	}
+
 	cmdConfig, err := self.ClientCmdConfig(req)
 	if err != nil {
 		return nil, err
@@ -452,19 +513,73 @@ func (self *clientManager) secureConfig(req *restful.Request) (*rest.Config, err
 	}
 
 	self.initConfig(cfg)
+	self.bindConfig(cfg, req)
 	return cfg, nil
 }
 
+// insecureRequestConfig returns insecure config bound to the given request. See bindConfig for more information.
+// User authenticated by a trusted proxy is impersonated, see proxyImpersonationInfo.
+func (self *clientManager) insecureRequestConfig(req *restful.Request) (*rest.Config, error) {
+	cfg, err := self.buildConfigFromFlags(self.apiserverHost, self.kubeConfigPath)
+	if err != nil {
+		return nil, err
+	}
+
+	// In-cluster config is shared, so it can not be modified.
+	cfg = rest.CopyConfig(cfg)
+	if authInfo := self.proxyImpersonationInfo(req); authInfo != nil {
+		cfg.Impersonate = toImpersonationConfig(authInfo)
+	}
+	self.initConfig(cfg)
+	self.bindConfig(cfg, req)
+	return cfg, nil
+}
+
+// bindConfig makes every request sent with the given config traced, instrumented, identified, retried after
+// transient errors and canceled together with the Dashboard request it was made for.
+func (self *clientManager) bindConfig(cfg *rest.Config, req *restful.Request) {
+	ctx := req.Request.Context()
+	cfg.WrapTransport = transport.Wrappers(cfg.WrapTransport, tracing.WrapTransport(ctx), instrumentTransport,
+		forwardRequestID(req.HeaderParameter(RequestIDHeader)), retryTransport,
+		withRequestContext(ctx, requestTimeout()))
+}
+
 // Initializes client manager
 func (self *clientManager) init() {
+	self.initConfigMode()
 	self.initInClusterConfig()
 	self.initInsecureClients()
 	self.initCSRFKey()
 }
 
-// Initializes in-cluster config if apiserverHost and kubeConfigPath were not provided.
+// Initializes config mode. Explicit flags take precedence, then default kubeconfig file is used if it exists.
+// In-cluster config is used as the last resort.
+func (self *clientManager) initConfigMode() {
+	switch {
+	case len(self.apiserverHost) > 0 || len(self.kubeConfigPath) > 0:
+		self.configMode = clientapi.ExplicitConfigMode
+	case defaultKubeConfigExists():
+		self.configMode = clientapi.KubeConfigMode
+	default:
+		self.configMode = clientapi.InClusterConfigMode
+	}
+}
+
+// Returns true if any of the default kubeconfig files, i.e. listed in $KUBECONFIG or ~/.kube/config, exists.
+func defaultKubeConfigExists() bool {
+	for _, path := range clientcmd.NewDefaultClientConfigLoadingRules().GetLoadingPrecedence() {
+		if _, err := os.Stat(path); err == nil {
+			log.Printf("Found default kubeconfig file: %s", path)
+			return true
+		}
+	}
+	return false
+}
+
+// Initializes in-cluster config if apiserverHost and kubeConfigPath were not provided and there is no
+// default kubeconfig file.
 func (self *clientManager) initInClusterConfig() {
-	if len(self.apiserverHost) > 0 || len(self.kubeConfigPath) > 0 {
+	if self.configMode != clientapi.InClusterConfigMode {
 		log.Print("Skipping in-cluster config")
 		return
 	}
@@ -524,6 +639,8 @@ func (self *clientManager) initInsecureConfig() {
 	}
 
 	self.initConfig(cfg)
+	cfg.WrapTransport = transport.Wrappers(cfg.WrapTransport, tracing.WrapTransport(context.Background()),
+		instrumentTransport, retryTransport, withRequestContext(context.Background(), requestTimeout()))
 	self.insecureConfig = cfg
 }
 
@@ -538,8 +655,42 @@ func NewClientManager(kubeConfigPath, apiserverHost string) clientapi.ClientMana
 	result := &clientManager{
 		kubeConfigPath: kubeConfigPath,
 		apiserverHost:  apiserverHost,
+		identities:     newIdentityCache(),
 	}
 
 	result.init()
 	return result
 }
+
+// forwardRequestID returns function that wraps round tripper, so that every request sent to the apiserver
+// carries ID of the Dashboard request it was made for. Only clients created for a single request can forward
+// the ID.
+func forwardRequestID(requestID string) transport.WrapperFunc {
+	return func(rt http.RoundTripper) http.RoundTripper {
+		if len(requestID) == 0 {
+			return rt
+		}
+		return &requestIDRoundTripper{requestID: requestID, rt: rt}
+	}
+}
+
+// requestIDRoundTripper sets request ID header on requests that do not have it yet.
+type requestIDRoundTripper struct {
+	requestID string
+	rt        http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (self *requestIDRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if len(req.Header.Get(RequestIDHeader)) == 0 {
+		req = utilnet.CloneRequest(req)
+		req.Header.Set(RequestIDHeader, self.requestID)
+	}
+
+	return self.rt.RoundTrip(req)
+}
+
+// WrappedRoundTripper allows client-go to cancel requests made with wrapped round tripper.
+func (self *requestIDRoundTripper) WrappedRoundTripper() http.RoundTripper {
+	return self.rt
+}
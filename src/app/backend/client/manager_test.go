@@ -16,13 +16,25 @@ package client
 
 import (
 	"crypto/tls"
+	"io/ioutil"
 	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
 	"testing"
 
 	restful "github.com/emicklei/go-restful"
 	"github.com/kubernetes/dashboard/src/app/backend/args"
+	clientapi "github.com/kubernetes/dashboard/src/app/backend/client/api"
 	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	authv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/rest"
+	core "k8s.io/client-go/testing"
 )
 
 func TestNewClientManager(t *testing.T) {
@@ -391,6 +403,84 @@ func TestNoImpersonationUserWithNoBearerClient(t *testing.T) {
 	}
 }
 
+func TestProxyImpersonation(t *testing.T) {
+	defer args.GetHolderBuilder().SetEnableProxyImpersonation(false).SetProxyImpersonationGroups(nil).
+		SetEnableSkipLogin(false)
+	cases := []struct {
+		info                      string
+		enabled                   bool
+		trusted                   bool
+		header                    http.Header
+		expectedImpersonationUser string
+		expectedGroups            []string
+		expectedToken             string
+	}{
+		{
+			"trusted proxy",
+			true, true,
+			http.Header{"Impersonate-User": {"jane"}, "Impersonate-Group": {"developers", "testers"}},
+			"jane", []string{"developers", "testers"}, "",
+		},
+		{
+			"groups outside of the allowed list",
+			true, true,
+			http.Header{"Impersonate-User": {"jane"}, "Impersonate-Group": {"system:masters", "developers"}},
+			"jane", []string{"developers"}, "",
+		},
+		{
+			"impersonation disabled",
+			false, true,
+			http.Header{"Impersonate-User": {"jane"}},
+			"", nil, "",
+		},
+		{
+			"untrusted client",
+			true, false,
+			http.Header{"Impersonate-User": {"jane"}},
+			"", nil, "",
+		},
+		{
+			"credentials of the request are used",
+			true, true,
+			http.Header{"Authorization": {"Bearer test-token"}, "Impersonate-User": {"jane"}},
+			"jane", nil, "test-token",
+		},
+	}
+
+	for _, c := range cases {
+		args.GetHolderBuilder().SetEnableProxyImpersonation(c.enabled).SetEnableSkipLogin(true).
+			SetProxyImpersonationGroups([]string{"developers", "testers"})
+		httpRequest := &http.Request{Header: c.header, TLS: &tls.ConnectionState{}}
+		if c.trusted {
+			httpRequest = WithImpersonatingProxy(httpRequest)
+		}
+		request := restful.NewRequest(httpRequest)
+
+		manager := NewClientManager("", "https://localhost:8080")
+		cfg, err := manager.Config(request)
+		if err != nil {
+			t.Fatalf("%s: Config() returned error: %s", c.info, err)
+		}
+
+		if cfg.Impersonate.UserName != c.expectedImpersonationUser ||
+			!reflect.DeepEqual(cfg.Impersonate.Groups, c.expectedGroups) || cfg.BearerToken != c.expectedToken {
+			t.Errorf("%s: expected user %q, groups %v and token %q, got %q, %v and %q", c.info,
+				c.expectedImpersonationUser, c.expectedGroups, c.expectedToken, cfg.Impersonate.UserName,
+				cfg.Impersonate.Groups, cfg.BearerToken)
+		}
+
+		// Users impersonated with credentials of the request are verified, see TestUsername.
+		if len(c.expectedToken) > 0 {
+			continue
+		}
+
+		username, err := manager.Username(request)
+		if len(c.expectedImpersonationUser) > 0 && (err != nil || username != c.expectedImpersonationUser) {
+			t.Errorf("%s: expected username %q, got %q, %v", c.info, c.expectedImpersonationUser, username, err)
+		}
+	}
+}
+
 func TestImpersonationOneGroupClient(t *testing.T) {
 	args.GetHolderBuilder().SetEnableSkipLogin(true)
 	cases := []struct {
@@ -591,3 +681,208 @@ func TestImpersonationExtrasClient(t *testing.T) {
 		}
 	}
 }
+
+func TestUsername(t *testing.T) {
+	cases := []struct {
+		header      map[string][]string
+		expected    string
+		expectedErr bool
+	}{
+		{map[string][]string{"Authorization": {"Bearer valid-token"}}, "jane", false},
+		{map[string][]string{"Authorization": {"Bearer invalid-token"}}, "", true},
+		{map[string][]string{"Authorization": {"Bearer valid-token"}, "Impersonate-User": {"john"}}, "john", false},
+		{map[string][]string{"Authorization": {"Bearer valid-token"}, "Impersonate-User": {"admin"}}, "", true},
+		{map[string][]string{"Authorization": {"Bearer invalid-token"}, "Impersonate-User": {"john"}}, "", true},
+		{map[string][]string{"Impersonate-User": {"john"}}, "", true},
+		{map[string][]string{}, "", true},
+	}
+
+	for _, c := range cases {
+		client := fake.NewSimpleClientset()
+		client.PrependReactor("create", "tokenreviews", func(action core.Action) (bool, runtime.Object, error) {
+			review := action.(core.CreateAction).GetObject().(*authv1.TokenReview)
+			if review.Spec.Token == "valid-token" {
+				review.Status = authv1.TokenReviewStatus{Authenticated: true,
+					User: authv1.UserInfo{Username: "jane", Groups: []string{"support"}}}
+			}
+			return true, review, nil
+		})
+		client.PrependReactor("create", "subjectaccessreviews", func(action core.Action) (bool, runtime.Object, error) {
+			review := action.(core.CreateAction).GetObject().(*authorizationv1.SubjectAccessReview)
+			review.Status.Allowed = review.Spec.User == "jane" && review.Spec.Groups[0] == "support" &&
+				review.Spec.ResourceAttributes.Verb == "impersonate" && review.Spec.ResourceAttributes.Name == "john"
+			return true, review, nil
+		})
+
+		manager := NewClientManager("", "http://localhost:8080").(*clientManager)
+		manager.insecureClient = client
+		request := &restful.Request{Request: &http.Request{Header: http.Header(c.header)}}
+
+		actual, err := manager.Username(request)
+		if (err != nil) != c.expectedErr {
+			t.Errorf("Username(%v): returned error %v, expected error: %t", c.header, err, c.expectedErr)
+		}
+
+		if actual != c.expected {
+			t.Errorf("Username(%v): expected %q, got %q", c.header, c.expected, actual)
+		}
+	}
+}
+
+func TestExtractJWEToken(t *testing.T) {
+	token := `{"protected":"eyJhbGciOiJSU0EtT0FFUC0yNTYifQ","encrypted_key":"a+b/c"}`
+	cookie := &http.Cookie{Name: JWETokenCookie, Value: url.PathEscape(token)}
+	cases := []struct {
+		info     string
+		method   string
+		header   string
+		cookie   *http.Cookie
+		expected string
+	}{
+		{"header", http.MethodPost, "header-token", nil, "header-token"},
+		{"header takes precedence over cookie", http.MethodGet, "header-token", cookie, "header-token"},
+		{"cookie of read-only request", http.MethodGet, "", cookie, token},
+		{"cookie of modifying request", http.MethodPost, "", cookie, ""},
+		{"no token", http.MethodGet, "", nil, ""},
+	}
+
+	manager := NewClientManager("", "http://localhost:8080").(*clientManager)
+	for _, c := range cases {
+		request := httptest.NewRequest(c.method, "/api/v1/pod/default", nil)
+		if len(c.header) > 0 {
+			request.Header.Set(JWETokenHeader, c.header)
+		}
+		if c.cookie != nil {
+			request.AddCookie(c.cookie)
+		}
+
+		if actual := manager.extractJWEToken(restful.NewRequest(request)); actual != c.expected {
+			t.Errorf("%s: expected token %q, got %q", c.info, c.expected, actual)
+		}
+	}
+}
+
+func TestForwardRequestID(t *testing.T) {
+	cases := []struct {
+		requestID string
+		header    string
+		expected  string
+	}{
+		{"abc", "", "abc"},
+		{"abc", "def", "def"},
+		{"", "", ""},
+	}
+
+	for _, c := range cases {
+		var actual string
+		rt := forwardRequestID(c.requestID)(roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			actual = req.Header.Get(RequestIDHeader)
+			return &http.Response{StatusCode: http.StatusOK}, nil
+		}))
+
+		req, _ := http.NewRequest(http.MethodGet, "https://apiserver/api/v1/pods", nil)
+		if len(c.header) > 0 {
+			req.Header.Set(RequestIDHeader, c.header)
+		}
+		rt.RoundTrip(req)
+
+		if actual != c.expected {
+			t.Errorf("Expected request ID %q to be forwarded as %q, got %q", c.requestID, c.expected, actual)
+		}
+	}
+}
+
+func TestConfigMode(t *testing.T) {
+	dir, err := ioutil.TempDir("", "kubeconfig")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	kubeConfig := filepath.Join(dir, "config")
+	err = ioutil.WriteFile(kubeConfig, []byte(`apiVersion: v1
+kind: Config
+clusters:
+- name: test
+  cluster:
+    server: http://localhost:8081
+users:
+- name: test
+contexts:
+- name: test
+  context:
+    cluster: test
+    user: test
+current-context: test
+`), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.Setenv("KUBECONFIG", os.Getenv("KUBECONFIG"))
+	defer os.Setenv("HOME", os.Getenv("HOME"))
+	os.Setenv("HOME", dir)
+
+	cases := []struct {
+		kubeConfigEnv, kubeConfigPath, apiserverHost string
+		expected                                     clientapi.ConfigMode
+	}{
+		{kubeConfig, "", "http://localhost:8080", clientapi.ExplicitConfigMode},
+		{kubeConfig, kubeConfig, "", clientapi.ExplicitConfigMode},
+		{kubeConfig, "", "", clientapi.KubeConfigMode},
+		{filepath.Join(dir, "missing"), "", "", clientapi.InClusterConfigMode},
+	}
+
+	for _, c := range cases {
+		os.Setenv("KUBECONFIG", c.kubeConfigEnv)
+		manager := &clientManager{kubeConfigPath: c.kubeConfigPath, apiserverHost: c.apiserverHost}
+		manager.initConfigMode()
+
+		if manager.ConfigMode() != c.expected {
+			t.Errorf("ConfigMode() with KUBECONFIG=%s, kubeconfig=%s and apiserver-host=%s == %s, expected %s",
+				c.kubeConfigEnv, c.kubeConfigPath, c.apiserverHost, manager.ConfigMode(), c.expected)
+		}
+	}
+
+	os.Setenv("KUBECONFIG", kubeConfig)
+	cfg, err := NewClientManager("", "").Config(&restful.Request{Request: &http.Request{TLS: &tls.ConnectionState{}}})
+	if err != nil {
+		t.Fatalf("Config() returned error: %s", err)
+	}
+	if cfg.Host != "http://localhost:8081" {
+		t.Errorf("Config() host == %s, expected host from default kubeconfig file", cfg.Host)
+	}
+}
+
+func TestUsernameIsCached(t *testing.T) {
+	reviews := 0
+	client := fake.NewSimpleClientset()
+	client.PrependReactor("create", "tokenreviews", func(action core.Action) (bool, runtime.Object, error) {
+		reviews++
+		review := action.(core.CreateAction).GetObject().(*authv1.TokenReview)
+		review.Status = authv1.TokenReviewStatus{Authenticated: true, User: authv1.UserInfo{Username: "jane"}}
+		return true, review, nil
+	})
+
+	manager := NewClientManager("", "http://localhost:8080").(*clientManager)
+	manager.insecureClient = client
+	for _, token := range []string{"first-token", "first-token", "second-token"} {
+		request := &restful.Request{Request: &http.Request{Header: http.Header{"Authorization": {"Bearer " + token}}}}
+		if _, err := manager.Username(request); err != nil {
+			t.Fatalf("Username(%s) returned unexpected error: %s", token, err)
+		}
+
+		if user := manager.KnownUsername(request); user != "jane" {
+			t.Errorf("KnownUsername(%s) == %q, expected jane", token, user)
+		}
+	}
+
+	request := &restful.Request{Request: &http.Request{Header: http.Header{"Authorization": {"Bearer third-token"}}}}
+	if user := manager.KnownUsername(request); len(user) > 0 {
+		t.Errorf("KnownUsername() == %q for not verified token, expected empty name", user)
+	}
+
+	if reviews != 2 {
+		t.Errorf("Expected one token review per token, got %d reviews", reviews)
+	}
+}
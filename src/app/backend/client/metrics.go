@@ -0,0 +1,154 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/client-go/tools/metrics"
+)
+
+var (
+	upstreamRequestDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name: "dashboard_apiserver_client_request_duration_seconds",
+			Help: "Latency distribution in seconds of requests made by dashboard to the apiserver, broken out for " +
+				"each verb and URL template.",
+			// Use buckets ranging from 5 ms to ~20 seconds.
+			Buckets: prometheus.ExponentialBuckets(0.005, 2.0, 13),
+		},
+		[]string{"verb", "url"},
+	)
+	upstreamRequestCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "dashboard_apiserver_client_requests_total",
+			Help: "Counter of requests made by dashboard to the apiserver broken out for each method, host and " +
+				"HTTP response code.",
+		},
+		[]string{"code", "method", "host"},
+	)
+	upstreamRequestErrors = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "dashboard_apiserver_client_request_errors_total",
+			Help: "Counter of failed requests made by dashboard to the apiserver broken out for each verb, " +
+				"resource and HTTP response code. Requests that did not get any response have '<error>' code.",
+		},
+		[]string{"verb", "resource", "code"},
+	)
+)
+
+// Initialize upstream call metrics in prometheus and hook them into the client-go REST client, so every
+// request made with clients created by client manager is tracked.
+func init() {
+	prometheus.MustRegister(upstreamRequestDuration)
+	prometheus.MustRegister(upstreamRequestCounter)
+	prometheus.MustRegister(upstreamRequestErrors)
+	metrics.Register(latencyMetric{}, resultMetric{})
+}
+
+// Implements client-go LatencyMetric interface.
+type latencyMetric struct{}
+
+// Observe records duration of a single request. URL path is a template with resource names stripped by the
+// REST client, i.e. /api/v1/namespaces/{namespace}/pods/{name}.
+func (latencyMetric) Observe(verb string, u url.URL, latency time.Duration) {
+	upstreamRequestDuration.WithLabelValues(verb, u.Path).Observe(latency.Seconds())
+}
+
+// Implements client-go ResultMetric interface.
+type resultMetric struct{}
+
+// Increment counts a single request outcome.
+func (resultMetric) Increment(code, method, host string) {
+	upstreamRequestCounter.WithLabelValues(code, method, host).Inc()
+}
+
+// instrumentTransport wraps round tripper of the apiserver client, so that failed requests are counted per
+// verb and resource and slow requests are logged. Client-go result metric does not know the requested resource.
+func instrumentTransport(rt http.RoundTripper) http.RoundTripper {
+	return &instrumentedRoundTripper{rt: rt}
+}
+
+// instrumentedRoundTripper counts requests that failed or got response with error status code and logs
+// requests slower than configured threshold.
+type instrumentedRoundTripper struct {
+	rt http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (self *instrumentedRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	response, err := self.rt.RoundTrip(req)
+	switch {
+	case err != nil:
+		upstreamRequestErrors.WithLabelValues(req.Method, resourceFromPath(req.URL.Path), "<error>").Inc()
+	case response.StatusCode >= http.StatusBadRequest:
+		upstreamRequestErrors.WithLabelValues(req.Method, resourceFromPath(req.URL.Path),
+			strconv.Itoa(response.StatusCode)).Inc()
+	}
+
+	if threshold := slowRequestThreshold(); threshold > 0 && !isStreamingRequest(req) {
+		if err != nil {
+			if time.Since(start) > threshold {
+				logSlowRequest(req, time.Since(start), 0, -1)
+			}
+		} else if response.StatusCode != http.StatusSwitchingProtocols {
+			response.Body = newSlowRequestBody(req, response, start, threshold)
+		}
+	}
+
+	return response, err
+}
+
+// WrappedRoundTripper allows client-go to cancel requests made with wrapped round tripper.
+func (self *instrumentedRoundTripper) WrappedRoundTripper() http.RoundTripper {
+	return self.rt
+}
+
+// resourceFromPath returns resource requested by given apiserver path, i.e. pods for
+// /api/v1/namespaces/default/pods/foo and pods/log for its logs. Names are omitted, so that the number of label
+// values stays bounded. Paths outside of API groups, i.e. /version, can be requested through the proxy endpoint,
+// so all of them share the "other" value.
+func resourceFromPath(path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	switch {
+	case segments[0] == "api" && len(segments) >= 2:
+		segments = segments[2:]
+	case segments[0] == "apis" && len(segments) >= 3:
+		segments = segments[3:]
+	case segments[0] == "api" || segments[0] == "apis":
+		return "discovery"
+	default:
+		return "other"
+	}
+
+	if len(segments) > 2 && segments[0] == "namespaces" {
+		segments = segments[2:]
+	}
+
+	switch len(segments) {
+	case 0:
+		return "discovery"
+	case 1, 2:
+		return segments[0]
+	default:
+		return segments[0] + "/" + segments[2]
+	}
+}
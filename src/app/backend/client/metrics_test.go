@@ -0,0 +1,91 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (self roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return self(req)
+}
+
+func TestResourceFromPath(t *testing.T) {
+	cases := []struct {
+		path     string
+		expected string
+	}{
+		{"/api/v1/namespaces/default/pods/foo", "pods"},
+		{"/api/v1/namespaces/default/pods/foo/log", "pods/log"},
+		{"/api/v1/namespaces/default/pods", "pods"},
+		{"/api/v1/namespaces/default", "namespaces"},
+		{"/api/v1/namespaces", "namespaces"},
+		{"/api/v1/nodes/node-1/proxy/stats", "nodes/proxy"},
+		{"/apis/apps/v1/namespaces/kube-system/deployments/dashboard/scale", "deployments/scale"},
+		{"/apis/apps/v1", "discovery"},
+		{"/api", "discovery"},
+		{"/version", "other"},
+		{"/logs/kube-apiserver.log", "other"},
+	}
+
+	for _, c := range cases {
+		if actual := resourceFromPath(c.path); actual != c.expected {
+			t.Errorf("resourceFromPath(%s) == %s, expected %s", c.path, actual, c.expected)
+		}
+	}
+}
+
+func TestInstrumentTransport(t *testing.T) {
+	cases := []struct {
+		path string
+		code int
+		err  error
+		// Label values of the counter that is expected to change.
+		labels []string
+	}{
+		{"/api/v1/namespaces/default/pods/foo", http.StatusOK, nil, []string{"GET", "pods", "200"}},
+		{"/api/v1/namespaces/default/secrets/foo", http.StatusForbidden, nil, []string{"GET", "secrets", "403"}},
+		{"/api/v1/nodes", 0, errors.New("connection refused"), []string{"GET", "nodes", "<error>"}},
+	}
+
+	for _, c := range cases {
+		rt := instrumentTransport(roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if c.err != nil {
+				return nil, c.err
+			}
+			return &http.Response{StatusCode: c.code}, nil
+		}))
+
+		counter := upstreamRequestErrors.WithLabelValues(c.labels...)
+		before := testutil.ToFloat64(counter)
+		rt.RoundTrip(httptest.NewRequest(http.MethodGet, c.path, nil))
+
+		expected := before
+		if c.err != nil || c.code >= http.StatusBadRequest {
+			expected++
+		}
+
+		if actual := testutil.ToFloat64(counter); actual != expected {
+			t.Errorf("Expected error counter %v for %s to be %v, but was %v", c.labels, c.path, expected, actual)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"io"
+	"io/ioutil"
+	"log"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	utilnet "k8s.io/apimachinery/pkg/util/net"
+
+	"github.com/kubernetes/dashboard/src/app/backend/args"
+)
+
+const (
+	// Delay before the first retry of a failed request. It is doubled with every following retry.
+	retryInitialBackoff = 200 * time.Millisecond
+	// Longest delay between retries, including delays requested by the apiserver with Retry-After header.
+	retryMaxBackoff = 5 * time.Second
+)
+
+// retriableStatusCodes are response codes of transient apiserver errors, i.e. throttling and timeouts.
+var retriableStatusCodes = []int{http.StatusTooManyRequests, http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout}
+
+// requestRetries returns number of times idempotent requests are retried. 0 disables retries.
+func requestRetries() int {
+	return args.Holder.GetAPIServerRequestRetries()
+}
+
+// retryTransport wraps round tripper of the apiserver client, so that idempotent requests are retried with
+// exponential backoff after transient errors, before the error is surfaced to the user.
+func retryTransport(rt http.RoundTripper) http.RoundTripper {
+	return &retryRoundTripper{rt: rt}
+}
+
+// retryRoundTripper retries GET requests that failed because of connection resets, throttling or apiserver
+// timeouts. Retries stop as soon as the request context is done.
+type retryRoundTripper struct {
+	rt http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (self *retryRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	retries := requestRetries()
+	if retries <= 0 || !isIdempotent(req) || isLongRunning(req) {
+		return self.rt.RoundTrip(req)
+	}
+
+	backoff := retryInitialBackoff
+	for attempt := 1; ; attempt++ {
+		response, err := self.rt.RoundTrip(req)
+		delay, retry := retryDelay(req, response, err, backoff)
+		if !retry || attempt > retries {
+			return response, err
+		}
+
+		if response != nil {
+			log.Printf("Retrying %s %s in %s after response %d (attempt %d of %d)", req.Method, req.URL.Path,
+				delay, response.StatusCode, attempt, retries)
+			io.Copy(ioutil.Discard, response.Body)
+			response.Body.Close()
+		} else {
+			log.Printf("Retrying %s %s in %s after error: %s (attempt %d of %d)", req.Method, req.URL.Path,
+				delay, err, attempt, retries)
+		}
+
+		select {
+		case <-time.After(delay):
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+
+		backoff *= 2
+		if backoff > retryMaxBackoff {
+			backoff = retryMaxBackoff
+		}
+	}
+}
+
+// WrappedRoundTripper allows client-go to cancel requests made with wrapped round tripper.
+func (self *retryRoundTripper) WrappedRoundTripper() http.RoundTripper {
+	return self.rt
+}
+
+// isIdempotent returns true for requests that can be safely sent more than once.
+func isIdempotent(req *http.Request) bool {
+	return req.Method == http.MethodGet || req.Method == http.MethodHead
+}
+
+// retryDelay returns time to wait before the request is retried and whether it should be retried at all.
+func retryDelay(req *http.Request, response *http.Response, err error, backoff time.Duration) (time.Duration,
+	bool) {
+	if req.Context().Err() != nil {
+		return 0, false
+	}
+
+	if err != nil {
+		netErr, ok := err.(net.Error)
+		transient := utilnet.IsConnectionReset(err) || utilnet.IsProbableEOF(err) || (ok && netErr.Timeout())
+		return backoff, transient
+	}
+
+	for _, code := range retriableStatusCodes {
+		if response.StatusCode == code {
+			return retryAfter(response, backoff), true
+		}
+	}
+
+	return 0, false
+}
+
+// retryAfter returns delay requested by the apiserver with Retry-After header, if any, or given backoff.
+func retryAfter(response *http.Response, backoff time.Duration) time.Duration {
+	seconds, err := strconv.Atoi(response.Header.Get("Retry-After"))
+	if err != nil || seconds < 0 {
+		return backoff
+	}
+
+	delay := time.Duration(seconds) * time.Second
+	if delay > retryMaxBackoff {
+		return retryMaxBackoff
+	}
+	return delay
+}
@@ -0,0 +1,87 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kubernetes/dashboard/src/app/backend/args"
+)
+
+func TestRetryTransport(t *testing.T) {
+	args.GetHolderBuilder().SetAPIServerRequestRetries(2)
+	defer args.GetHolderBuilder().SetAPIServerRequestRetries(0)
+
+	cases := []struct {
+		info             string
+		method           string
+		failures         int
+		fail             func(w http.ResponseWriter)
+		expectedAttempts int
+		expectedCode     int
+	}{
+		{"throttled request is retried", http.MethodGet, 2, func(w http.ResponseWriter) {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		}, 3, http.StatusOK},
+		{"apiserver timeout is retried", http.MethodGet, 1, func(w http.ResponseWriter) {
+			w.WriteHeader(http.StatusGatewayTimeout)
+		}, 2, http.StatusOK},
+		{"reset connection is retried", http.MethodGet, 1, func(w http.ResponseWriter) {
+			conn, _, _ := w.(http.Hijacker).Hijack()
+			conn.Close()
+		}, 2, http.StatusOK},
+		{"error is returned when retries are exhausted", http.MethodGet, 5, func(w http.ResponseWriter) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}, 3, http.StatusServiceUnavailable},
+		{"not found is not retried", http.MethodGet, 1, func(w http.ResponseWriter) {
+			w.WriteHeader(http.StatusNotFound)
+		}, 1, http.StatusNotFound},
+		{"non-idempotent request is not retried", http.MethodPost, 1, func(w http.ResponseWriter) {
+			w.WriteHeader(http.StatusGatewayTimeout)
+		}, 1, http.StatusGatewayTimeout},
+	}
+
+	for _, c := range cases {
+		attempts := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			attempts++
+			if attempts <= c.failures {
+				c.fail(w)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		}))
+
+		req, _ := http.NewRequest(c.method, server.URL+"/api/v1/pods", nil)
+		client := &http.Client{Transport: retryTransport(http.DefaultTransport)}
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", c.info, err)
+		} else {
+			resp.Body.Close()
+			if resp.StatusCode != c.expectedCode {
+				t.Errorf("%s: expected code %d, got %d", c.info, c.expectedCode, resp.StatusCode)
+			}
+		}
+
+		if attempts != c.expectedAttempts {
+			t.Errorf("%s: expected %d attempts, got %d", c.info, c.expectedAttempts, attempts)
+		}
+		server.Close()
+	}
+}
@@ -0,0 +1,165 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/client-go/kubernetes/scheme"
+
+	"github.com/kubernetes/dashboard/src/app/backend/args"
+	"github.com/kubernetes/dashboard/src/app/backend/logging"
+)
+
+// maxSlowRequestBufferSize is a maximum number of bytes of slow response body kept to count returned items.
+// Items of bigger responses are not counted.
+const maxSlowRequestBufferSize = 1 << 20
+
+// slowRequestThreshold returns duration after which upstream requests are logged as slow. 0 disables logging.
+func slowRequestThreshold() time.Duration {
+	return time.Duration(args.Holder.GetSlowRequestThreshold()) * time.Millisecond
+}
+
+// isStreamingRequest returns true for watches and followed logs, which are expected to be long running.
+func isStreamingRequest(req *http.Request) bool {
+	query := req.URL.Query()
+	return query.Get("watch") == "true" || query.Get("watch") == "1" || query.Get("follow") == "true"
+}
+
+// slowRequestBody measures time until the response body is read and logs the request when it was slow. Body
+// of requests that were already slow when headers arrived is kept, so that the number of returned items can be
+// logged. Bodies of fast requests and bodies over maxSlowRequestBufferSize are not kept to avoid doubling memory
+// used by big lists.
+type slowRequestBody struct {
+	io.ReadCloser
+	req       *http.Request
+	start     time.Time
+	threshold time.Duration
+	bytes     int64
+	buffer    *bytes.Buffer
+	once      sync.Once
+}
+
+func newSlowRequestBody(req *http.Request, response *http.Response, start time.Time,
+	threshold time.Duration) *slowRequestBody {
+	body := &slowRequestBody{
+		ReadCloser: response.Body,
+		req:        req,
+		start:      start,
+		threshold:  threshold,
+	}
+
+	if time.Since(start) > threshold {
+		body.buffer = &bytes.Buffer{}
+	}
+
+	return body
+}
+
+// Read implements io.Reader.
+func (self *slowRequestBody) Read(p []byte) (int, error) {
+	n, err := self.ReadCloser.Read(p)
+	self.bytes += int64(n)
+	if self.buffer != nil {
+		if self.buffer.Len()+n > maxSlowRequestBufferSize {
+			self.buffer = nil
+		} else {
+			self.buffer.Write(p[:n])
+		}
+	}
+	if err == io.EOF {
+		self.finish()
+	}
+	return n, err
+}
+
+// Close implements io.Closer.
+func (self *slowRequestBody) Close() error {
+	err := self.ReadCloser.Close()
+	self.finish()
+	return err
+}
+
+func (self *slowRequestBody) finish() {
+	self.once.Do(func() {
+		duration := time.Since(self.start)
+		if duration <= self.threshold {
+			return
+		}
+
+		items := -1
+		if self.buffer != nil {
+			items = countItems(self.buffer.Bytes())
+			self.buffer = nil
+		}
+		logSlowRequest(self.req, duration, self.bytes, items)
+	})
+}
+
+// countItems returns number of items in the encoded list or -1 when the response is not a list.
+func countItems(data []byte) int {
+	obj, _, err := scheme.Codecs.UniversalDeserializer().Decode(data, nil, nil)
+	if err == nil {
+		if meta.IsListType(obj) {
+			return meta.LenList(obj)
+		}
+		return -1
+	}
+
+	// Custom resources are not registered in the scheme, but they are always encoded as JSON.
+	list := struct {
+		Items []json.RawMessage `json:"items"`
+	}{}
+	if json.Unmarshal(data, &list) != nil || list.Items == nil {
+		return -1
+	}
+	return len(list.Items)
+}
+
+// logSlowRequest logs request with its resource and selectors. Negative items mean that the number of returned
+// items is not known.
+func logSlowRequest(req *http.Request, duration time.Duration, size int64, items int) {
+	query := req.URL.Query()
+	fields := logging.Fields{
+		"upstream":   "apiserver",
+		"verb":       req.Method,
+		"resource":   resourceFromPath(req.URL.Path),
+		"path":       req.URL.Path,
+		"durationMs": float64(duration) / float64(time.Millisecond),
+		"bytes":      size,
+	}
+
+	for _, selector := range []string{"labelSelector", "fieldSelector", "limit"} {
+		if value := query.Get(selector); len(value) > 0 {
+			fields[selector] = value
+		}
+	}
+	if items >= 0 {
+		fields["items"] = items
+	}
+	if requestID := req.Header.Get(RequestIDHeader); len(requestID) > 0 {
+		fields["requestID"] = requestID
+	}
+
+	logging.Log(logging.LevelWarning, fmt.Sprintf("Slow apiserver request %s %s", req.Method, req.URL.Path),
+		fields)
+}
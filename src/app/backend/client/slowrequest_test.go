@@ -0,0 +1,124 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kubernetes/dashboard/src/app/backend/args"
+	"github.com/kubernetes/dashboard/src/app/backend/logging"
+)
+
+func TestCountItems(t *testing.T) {
+	podList, _ := json.Marshal(&v1.PodList{
+		TypeMeta: metaV1.TypeMeta{Kind: "PodList", APIVersion: "v1"},
+		Items:    []v1.Pod{{}, {}, {}},
+	})
+	pod, _ := json.Marshal(&v1.Pod{TypeMeta: metaV1.TypeMeta{Kind: "Pod", APIVersion: "v1"}})
+
+	cases := []struct {
+		data     []byte
+		expected int
+	}{
+		{podList, 3},
+		{pod, -1},
+		{[]byte(`{"apiVersion":"example.com/v1","kind":"FooList","items":[{},{}]}`), 2},
+		{[]byte(`not json`), -1},
+	}
+
+	for _, c := range cases {
+		if actual := countItems(c.data); actual != c.expected {
+			t.Errorf("countItems(%s) == %d, expected %d", c.data, actual, c.expected)
+		}
+	}
+}
+
+func TestInstrumentTransportSlowRequests(t *testing.T) {
+	defer func() {
+		args.GetHolderBuilder().SetSlowRequestThreshold(0)
+		logging.Init(string(logging.TextFormat), os.Stdout)
+	}()
+	args.GetHolderBuilder().SetSlowRequestThreshold(10)
+
+	body := `{"apiVersion":"example.com/v1","kind":"FooList","items":[{},{}]}`
+	cases := []struct {
+		path     string
+		delay    time.Duration
+		expected []string
+	}{
+		{"/apis/example.com/v1/foos?labelSelector=app%3Dweb", 20 * time.Millisecond,
+			[]string{`"resource":"foos"`, `"labelSelector":"app=web"`, `"items":2`}},
+		{"/apis/example.com/v1/foos", 0, nil},
+		{"/apis/example.com/v1/foos?watch=true", 20 * time.Millisecond, nil},
+	}
+
+	for _, c := range cases {
+		out := &bytes.Buffer{}
+		logging.Init(string(logging.JSONFormat), out)
+
+		rt := instrumentTransport(roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			time.Sleep(c.delay)
+			return &http.Response{StatusCode: http.StatusOK, Body: ioutil.NopCloser(strings.NewReader(body))}, nil
+		}))
+
+		response, err := rt.RoundTrip(httptest.NewRequest(http.MethodGet, c.path, nil))
+		if err != nil {
+			t.Fatal(err)
+		}
+		ioutil.ReadAll(response.Body)
+		response.Body.Close()
+
+		if len(c.expected) == 0 && out.Len() > 0 {
+			t.Errorf("Expected request %s not to be logged, got %s", c.path, out.String())
+		}
+
+		for _, expected := range c.expected {
+			if !strings.Contains(out.String(), expected) {
+				t.Errorf("Expected log of slow request %s to contain %s, got %s", c.path, expected, out.String())
+			}
+		}
+	}
+}
+
+func TestSlowRequestBodyIsNotBufferedOverLimit(t *testing.T) {
+	data := `{"kind":"List","items":[` + strings.Repeat(`{},`, maxSlowRequestBufferSize/3) + `{}]}`
+	response := &http.Response{Body: ioutil.NopCloser(strings.NewReader(data))}
+	body := newSlowRequestBody(httptest.NewRequest(http.MethodGet, "/api/v1/pods", nil), response,
+		time.Now().Add(-time.Second), time.Millisecond)
+
+	// Body is read without reaching EOF, which would release the buffer anyway.
+	if _, err := io.ReadFull(body, make([]byte, len(data))); err != nil {
+		t.Fatal(err)
+	}
+	if body.buffer != nil {
+		t.Errorf("Expected body over %d bytes not to be buffered, got %d buffered bytes", maxSlowRequestBufferSize,
+			body.buffer.Len())
+	}
+	if body.bytes != int64(len(data)) {
+		t.Errorf("Expected %d read bytes, got %d", len(data), body.bytes)
+	}
+}
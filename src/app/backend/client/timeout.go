@@ -0,0 +1,121 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"k8s.io/client-go/transport"
+
+	"github.com/kubernetes/dashboard/src/app/backend/args"
+)
+
+// longRunningSubresources are subresources that keep connection to the apiserver open for as long as the user
+// needs it, so they can not be limited by the request timeout.
+var longRunningSubresources = []string{"/exec", "/attach", "/portforward", "/log"}
+
+// requestTimeout returns time after which requests sent to the apiserver are canceled.
+func requestTimeout() time.Duration {
+	return time.Duration(args.Holder.GetAPIServerRequestTimeout()) * time.Second
+}
+
+// withRequestContext returns function that wraps round tripper, so that every request sent to the apiserver is
+// canceled together with the Dashboard request it was made for and does not take longer than given timeout.
+// Long-running requests, i.e. watches, log streams and exec sessions, are left intact, because they are often
+// served after the Dashboard request has finished.
+func withRequestContext(ctx context.Context, timeout time.Duration) transport.WrapperFunc {
+	return func(rt http.RoundTripper) http.RoundTripper {
+		return &contextRoundTripper{ctx: ctx, timeout: timeout, rt: rt}
+	}
+}
+
+// contextRoundTripper binds requests to the context of a Dashboard request and applies request timeout.
+type contextRoundTripper struct {
+	ctx     context.Context
+	timeout time.Duration
+	rt      http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (self *contextRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if isLongRunning(req) {
+		return self.rt.RoundTrip(req)
+	}
+
+	var ctx context.Context
+	var cancel context.CancelFunc
+	if self.timeout > 0 {
+		ctx, cancel = context.WithTimeout(req.Context(), self.timeout)
+	} else {
+		ctx, cancel = context.WithCancel(req.Context())
+	}
+
+	if self.ctx != nil && self.ctx.Done() != nil {
+		go func() {
+			select {
+			case <-self.ctx.Done():
+				cancel()
+			case <-ctx.Done():
+			}
+		}()
+	}
+
+	resp, err := self.rt.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	// Context has to stay alive until the whole response body is read.
+	resp.Body = &cancelOnCloseBody{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// WrappedRoundTripper allows client-go to cancel requests made with wrapped round tripper.
+func (self *contextRoundTripper) WrappedRoundTripper() http.RoundTripper {
+	return self.rt
+}
+
+// cancelOnCloseBody cancels request context once response body is closed.
+type cancelOnCloseBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+// Close implements io.Closer.
+func (self *cancelOnCloseBody) Close() error {
+	err := self.ReadCloser.Close()
+	self.cancel()
+	return err
+}
+
+// isLongRunning returns true for streaming requests and requests to subresources that keep connection open.
+func isLongRunning(req *http.Request) bool {
+	if isStreamingRequest(req) || len(req.Header.Get("Upgrade")) > 0 {
+		return true
+	}
+
+	for _, subresource := range longRunningSubresources {
+		if strings.HasSuffix(req.URL.Path, subresource) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,86 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+)
+
+func TestWithRequestContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("delay") == "true" {
+			select {
+			case <-time.After(300 * time.Millisecond):
+			case <-r.Context().Done():
+				return
+			}
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	cases := []struct {
+		info     string
+		path     string
+		canceled bool
+		timeout  bool
+	}{
+		{"fast request succeeds", "/api/v1/pods", false, false},
+		{"slow request times out", "/api/v1/pods?delay=true", false, true},
+		{"slow watch is not limited", "/api/v1/pods?delay=true&watch=true", false, false},
+		{"slow log stream is not limited", "/api/v1/namespaces/a/pods/b/log?delay=true", false, false},
+		{"request is canceled with dashboard request", "/api/v1/pods?delay=true", true, true},
+	}
+
+	for _, c := range cases {
+		ctx, cancel := context.WithCancel(context.Background())
+		timeout := 50 * time.Millisecond
+		if c.canceled {
+			timeout = 0
+			cancel()
+		}
+
+		client := &http.Client{Transport: withRequestContext(ctx, timeout)(http.DefaultTransport)}
+		resp, err := client.Get(server.URL + c.path)
+		if c.timeout {
+			if err == nil {
+				t.Errorf("%s: expected request to fail", c.info)
+			}
+		} else if err != nil {
+			t.Errorf("%s: unexpected error: %v", c.info, err)
+		} else {
+			body, err := ioutil.ReadAll(resp.Body)
+			resp.Body.Close()
+			if err != nil || string(body) != "ok" {
+				t.Errorf("%s: expected body %q, got %q (error: %v)", c.info, "ok", body, err)
+			}
+		}
+
+		if c.timeout && !c.canceled && err != nil {
+			if code := errors.TranslateError(err).Code; code != http.StatusGatewayTimeout {
+				t.Errorf("%s: expected error to be translated to %d, got %d", c.info, http.StatusGatewayTimeout,
+					code)
+			}
+		}
+		cancel()
+	}
+}
@@ -21,6 +21,7 @@ import (
 	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	restclient "k8s.io/client-go/rest"
 
 	"github.com/kubernetes/dashboard/src/app/backend/api"
@@ -159,6 +160,7 @@ func (verber *resourceVerber) getCRDGroupAndVersion(kind string) (info crdInfo,
 type RESTClient interface {
 	Delete() *restclient.Request
 	Put() *restclient.Request
+	Patch(pt types.PatchType) *restclient.Request
 	Get() *restclient.Request
 }
 
@@ -212,6 +214,36 @@ func (verber *resourceVerber) Put(kind string, namespaceSet bool, namespace stri
 	return req.Do().Error()
 }
 
+// Patch applies the patch of the given type to the resource of the given kind in the given namespace with the
+// given name and returns the patched resource. Custom resources do not support strategic merge patches, so a
+// JSON merge patch is used for them instead.
+func (verber *resourceVerber) Patch(kind string, namespaceSet bool, namespace string, name string,
+	patchType types.PatchType, data []byte) (runtime.Object, error) {
+
+	if _, ok := api.KindToAPIMapping[kind]; !ok && patchType == types.StrategicMergePatchType {
+		patchType = types.MergePatchType
+	}
+
+	client, resourceSpec, err := verber.getResourceSpecFromKind(kind, namespaceSet)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &runtime.Unknown{}
+	req := client.Patch(patchType).
+		Resource(resourceSpec.Resource).
+		Name(name).
+		SetHeader("Accept", "application/json").
+		Body(data)
+
+	if resourceSpec.Namespaced {
+		req.Namespace(namespace)
+	}
+
+	err = req.Do().Into(result)
+	return result, err
+}
+
 // Get gets the resource of the given kind in the given namespace with the given name.
 func (verber *resourceVerber) Get(kind string, namespaceSet bool, namespace string, name string) (runtime.Object, error) {
 	client, resourceSpec, err := verber.getResourceSpecFromKind(kind, namespaceSet)
@@ -23,6 +23,7 @@ import (
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
 	restclient "k8s.io/client-go/rest"
 	"k8s.io/client-go/rest/fake"
@@ -67,6 +68,10 @@ func (c *FakeRESTClient) Put() *restclient.Request {
 	return restclient.NewRequestWithClient(&url.URL{Path: "/api/v1/"}, "", restclient.ClientContentConfig{}, fake.CreateHTTPClient(NewFakeClientFunc(c))).Verb("PUT")
 }
 
+func (c *FakeRESTClient) Patch(pt types.PatchType) *restclient.Request {
+	return restclient.NewRequestWithClient(&url.URL{Path: "/api/v1/"}, "", restclient.ClientContentConfig{}, fake.CreateHTTPClient(NewFakeClientFunc(c))).Verb("PATCH").SetHeader("Content-Type", string(pt))
+}
+
 func (c *FakeRESTClient) Get() *restclient.Request {
 	return restclient.NewRequestWithClient(&url.URL{Path: "/api/v1/"}, "", restclient.ClientContentConfig{}, fake.CreateHTTPClient(NewFakeClientFunc(c))).Verb("GET")
 }
@@ -182,6 +187,16 @@ func TestPutShouldRespectNamespacednessOfResourceKind(t *testing.T) {
 	}
 }
 
+func TestPatchShouldRespectNamespacednessOfResourceKind(t *testing.T) {
+	verber := resourceVerber{client: &FakeRESTClient{}}
+
+	_, err := verber.Patch("service", false, "", "baz", types.StrategicMergePatchType, nil)
+
+	if !reflect.DeepEqual(err, errors.NewInvalid("Set no namespace for namespaced resource kind: service")) {
+		t.Fatalf("Expected error on verber patch but got %#v", err)
+	}
+}
+
 func TestDeleteShouldRespectNamespacednessOfResourceKind(t *testing.T) {
 	verber := resourceVerber{client: &FakeRESTClient{}}
 
@@ -212,6 +227,16 @@ func TestPutShouldRespectNotNamespacednessOfResourceKind(t *testing.T) {
 	}
 }
 
+func TestPatchShouldRespectNotNamespacednessOfResourceKind(t *testing.T) {
+	verber := resourceVerber{client: &FakeRESTClient{}}
+
+	_, err := verber.Patch("namespace", true, "bar", "baz", types.StrategicMergePatchType, nil)
+
+	if !reflect.DeepEqual(err, errors.NewInvalid("Set namespace for not-namespaced resource kind: namespace")) {
+		t.Fatalf("Expected error on verber patch but got %#v", err)
+	}
+}
+
 func TestDeleteShouldRespectNotNamespacednessOfResourceKind(t *testing.T) {
 	verber := resourceVerber{client: &FakeRESTClient{}}
 
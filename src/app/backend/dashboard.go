@@ -15,6 +15,7 @@
 package main
 
 import (
+	"context"
 	"crypto/elliptic"
 	"crypto/tls"
 	"flag"
@@ -23,11 +24,17 @@ import (
 	"net"
 	"net/http"
 	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
 	"time"
 
-	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/util/wait"
 
+	"github.com/kubernetes/dashboard/src/app/backend/activation"
+	"github.com/kubernetes/dashboard/src/app/backend/alerting"
 	"github.com/kubernetes/dashboard/src/app/backend/args"
 	"github.com/kubernetes/dashboard/src/app/backend/auth"
 	authApi "github.com/kubernetes/dashboard/src/app/backend/auth/api"
@@ -36,27 +43,33 @@ import (
 	"github.com/kubernetes/dashboard/src/app/backend/cert/ecdsa"
 	"github.com/kubernetes/dashboard/src/app/backend/client"
 	clientapi "github.com/kubernetes/dashboard/src/app/backend/client/api"
+	"github.com/kubernetes/dashboard/src/app/backend/features"
 	"github.com/kubernetes/dashboard/src/app/backend/handler"
 	"github.com/kubernetes/dashboard/src/app/backend/integration"
 	integrationapi "github.com/kubernetes/dashboard/src/app/backend/integration/api"
+	"github.com/kubernetes/dashboard/src/app/backend/logging"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/kubebench"
 	"github.com/kubernetes/dashboard/src/app/backend/settings"
 	"github.com/kubernetes/dashboard/src/app/backend/sync"
 	"github.com/kubernetes/dashboard/src/app/backend/systembanner"
+	"github.com/kubernetes/dashboard/src/app/backend/tracing"
+	"github.com/kubernetes/dashboard/src/app/backend/validation"
 )
 
 var (
-	argInsecurePort        = pflag.Int("insecure-port", 9090, "The port to listen to for incoming HTTP requests.")
+	argInsecurePort        = pflag.Int("insecure-port", 9090, "The port to listen to for incoming HTTP requests, when no certificates are configured, or for redirects to HTTPS when --enable-https-redirect is set. Set to 0 to disable the insecure listener and refuse to start without certificates.")
 	argPort                = pflag.Int("port", 8443, "The secure port to listen to for incoming HTTPS requests.")
-	argInsecureBindAddress = pflag.IP("insecure-bind-address", net.IPv4(127, 0, 0, 1), "The IP address on which to serve the --insecure-port (set to 127.0.0.1 for all interfaces).")
-	argBindAddress         = pflag.IP("bind-address", net.IPv4(0, 0, 0, 0), "The IP address on which to serve the --port (set to 0.0.0.0 for all interfaces).")
+	argInsecureBindAddress = pflag.IP("insecure-bind-address", net.IPv4(127, 0, 0, 1), "The IPv4 or IPv6 address on which to serve the --insecure-port (set to 0.0.0.0 or :: for all interfaces).")
+	argBindAddress         = pflag.IP("bind-address", net.IPv4(0, 0, 0, 0), "The IPv4 or IPv6 address on which to serve the --port (set to 0.0.0.0 or :: for all interfaces).")
 	argDefaultCertDir      = pflag.String("default-cert-dir", "/certs", "Directory path containing '--tls-cert-file' and '--tls-key-file' files. Used also when auto-generating certificates flag is set.")
 	argCertFile            = pflag.String("tls-cert-file", "", "File containing the default x509 Certificate for HTTPS.")
 	argKeyFile             = pflag.String("tls-key-file", "", "File containing the default x509 private key matching --tls-cert-file.")
 	argApiserverHost       = pflag.String("apiserver-host", "", "The address of the Kubernetes Apiserver "+
 		"to connect to in the format of protocol://address:port, e.g., "+
-		"http://localhost:8080. If not specified, the assumption is that the binary runs inside a "+
-		"Kubernetes cluster and local discovery is attempted.")
-	argMetricsProvider = pflag.String("metrics-provider", "sidecar", "Select provider type for metrics. 'none' will not check metrics.")
+		"http://localhost:8080. If neither this nor --kubeconfig is specified, default kubeconfig file is used "+
+		"if it exists, otherwise the assumption is that the binary runs inside a Kubernetes cluster and local "+
+		"discovery is attempted.")
+	argMetricsProvider = pflag.String("metrics-provider", "sidecar", "Select provider type for metrics: 'sidecar', 'heapster', 'metrics-server' or 'prometheus'. 'none' will not check metrics.")
 	argHeapsterHost    = pflag.String("heapster-host", "", "The address of the Heapster Apiserver "+
 		"to connect to in the format of protocol://address:port, e.g., "+
 		"http://localhost:8082. If not specified, the assumption is that the binary runs inside a "+
@@ -65,7 +78,11 @@ var (
 		"to connect to in the format of protocol://address:port, e.g., "+
 		"http://localhost:8000. If not specified, the assumption is that the binary runs inside a "+
 		"Kubernetes cluster and service proxy will be used.")
-	argKubeConfigFile     = pflag.String("kubeconfig", "", "Path to kubeconfig file with authorization and master location information.")
+	argPrometheusHost = pflag.String("prometheus-host", "", "The address of the Prometheus server, that collects "+
+		"Istio or Linkerd metrics, in the format of protocol://address:port, e.g., http://prometheus.istio-system:9090. "+
+		"When set, request rate, error rate and latency of services are shown on service details. Also used as the "+
+		"source of CPU and memory usage with metrics-provider set to 'prometheus'.")
+	argKubeConfigFile     = pflag.String("kubeconfig", "", "Path to kubeconfig file with authorization and master location information. Takes precedence over default kubeconfig file and in-cluster config.")
 	argTokenTTL           = pflag.Int("token-ttl", int(authApi.DefaultTokenTTL), "Expiration time (in seconds) of JWE tokens generated by dashboard. '0' never expires")
 	argAuthenticationMode = pflag.StringSlice("authentication-mode", []string{authApi.Token.String()}, "Enables authentication options that will be reflected on login screen. Supported values: token, basic. "+
 		"Note that basic option should only be used if apiserver has '--authorization-mode=ABAC' and '--basic-auth-file' flags set.")
@@ -79,8 +96,51 @@ var (
 	argDisableSettingsAuthorizer = pflag.Bool("disable-settings-authorizer", false, "When enabled, Dashboard settings page will not require user to be logged in and authorized to access settings page. (default false)")
 	argNamespace                 = pflag.String("namespace", getEnv("POD_NAMESPACE", "kube-system"), "When non-default namespace is used, create encryption key in the specified namespace.")
 	localeConfig                 = pflag.String("locale-config", "./locale_conf.json", "File containing the configuration of locales")
+
+	argEnableMetricsReadinessCheck       = pflag.Bool("enable-metrics-readiness-check", false, "When enabled, readiness probe will also fail if configured metrics provider is not reachable. (default false)")
+	argChartRepositories                 = pflag.StringToString("chart-repositories", map[string]string{}, "Helm chart repositories available for deployment, in the format of name=url, i.e. stable=https://kubernetes-charts.storage.googleapis.com.")
+	argMinResourceAutoRefreshInterval    = pflag.Int("min-resource-auto-refresh-interval", 5, "Minimum number of seconds between auto-refreshes of resources that can be configured in settings. Lower values are raised to it, 0 still disables auto-refresh.")
+	argFeatureGates                      = pflag.String("feature-gates", "", "Comma separated list of feature=enabled pairs that toggle Dashboard features, i.e. exec=true,logsDownload=false. Supported features are exec, logsDownload, appDeployment and kubeBench. All features except for kubeBench are enabled by default.")
+	argLoginNotice                       = pflag.String("login-notice", "", "When non-empty displays notice, i.e. legal disclaimer, on the login page. Accepts simple HTML tags.")
+	argLoginNoticeAcknowledgmentRequired = pflag.Bool("login-notice-acknowledgment-required", false, "When enabled, users have to acknowledge login notice before they log in. Acknowledgments are logged. (default false)")
+	argLogFormat                         = pflag.String("log-format", "text", "Format of log output. Should be one of 'text|json'. JSON format writes structured entries, i.e. to fit centralized log pipelines.")
+	argTracingSampleRate                 = pflag.Float64("tracing-sample-rate", 0, "Fraction of API requests that are traced, between 0 and 1. Spans of traced requests and of upstream calls to the apiserver and metrics providers are written to the log and trace headers are propagated. 0 disables tracing.")
+	argEnableProfiling                   = pflag.Bool("enable-profiling", false, "When enabled, pprof profiles and goroutine and heap dumps are served under /debug/ on --profiling-port. Endpoints are bound to localhost only. (default false)")
+	argProfilingPort                     = pflag.Int("profiling-port", 6060, "The localhost port to serve profiling endpoints on when --enable-profiling is set.")
+	argAccessLogFormat                   = pflag.String("access-log-format", "", "When non-empty, every HTTP request is written to the access log in given format. Should be one of 'common|combined|json'. Only JSON format includes request duration.")
+	argSlowRequestThreshold              = pflag.Int("slow-request-threshold", 0, "Requests to the apiserver and metrics providers that take longer than given number of milliseconds are logged with their resource, selector and item count. 0 disables logging.")
+	argServiceNodePortRange              = pflag.String("service-node-port-range", "30000-32767", "Port range reserved for services with node ports. Has to match --service-node-port-range of the apiserver, so node ports of deployed apps are validated before the service is created.")
+	argAPIServerRequestTimeout           = pflag.Int("apiserver-request-timeout", 60, "Time in seconds after which requests sent to the apiserver on behalf of a Dashboard request are canceled and a gateway timeout is returned. Watches, log streams and exec sessions are not limited. Set to 0 to disable.")
+	argAPIServerRequestRetries           = pflag.Int("apiserver-request-retries", 3, "Number of times idempotent requests to the apiserver are retried after connection resets, throttling (429) and apiserver timeouts, with exponential backoff. Set to 0 to disable.")
+	argVeleroNamespace                   = pflag.String("velero-namespace", "velero", "Namespace that Velero is installed in. Backups triggered from Dashboard are created in this namespace.")
+	argImageScannerURL                   = pflag.String("image-scanner-url", "", "URL of the image vulnerability scanner implementing Harbor pluggable scanner API, i.e. harbor-scanner-trivy or harbor-scanner-clair. Vulnerability scanning is disabled when empty.")
+	argAlertWebhookURLs                  = pflag.StringSlice("alert-webhook-url", []string{}, "URLs that alerts about crash looping containers, failed jobs and failed rollouts are posted to as JSON. Can be given multiple times. Service account of Dashboard needs permission to watch pods, jobs and deployments.")
+	argAlertSlackWebhookURLs             = pflag.StringSlice("alert-slack-webhook-url", []string{}, "Slack incoming webhook URLs that alerts about crash looping containers, failed jobs and failed rollouts are posted to. Can be given multiple times.")
+	argDashboardURL                      = pflag.String("dashboard-url", "", "External URL of Dashboard, i.e. https://dashboard.example.com, used for links to resources in alerts.")
+	argCostCPUCoreMonth                  = pflag.Float64("cost-cpu-core-month", 20, "Price of one CPU core per month used to estimate cost of namespaces and workloads.")
+	argCostMemoryGiBMonth                = pflag.Float64("cost-memory-gib-month", 2.5, "Price of one GiB of memory per month used to estimate cost of namespaces and workloads.")
+	argCostCurrency                      = pflag.String("cost-currency", "USD", "Currency of the prices used to estimate cost of namespaces and workloads.")
+	argFalcoToken                        = pflag.String("falco-token", "", "Token that Falco HTTP output has to pass in the token query parameter when posting alerts to /api/v1/falco/event. Receiving of Falco alerts is disabled when empty.")
+	argFalcoEventLimit                   = pflag.Int("falco-event-limit", 1000, "Number of the most recent Falco alerts kept in memory.")
+	argEnableHTTPSRedirect               = pflag.Bool("enable-https-redirect", false, "When enabled and Dashboard is served over HTTPS, plain HTTP requests to --insecure-bind-address and --insecure-port are redirected to the HTTPS port. (default false)")
+	argMaxRequestBodySize                = pflag.Int64("max-request-body-size", 10*1024*1024, "Maximum size in bytes of bodies of create and update requests. Larger requests are rejected before they are read. Set to 0 to disable the limit.")
+	argMaxConcurrentRequestsPerClient    = pflag.Int("max-concurrent-requests-per-client", 50, "Maximum number of requests to the API, that a single user or IP address can have in flight. Users are told apart only once their credentials were verified. Watches, log streams and exec sessions are not counted. Requests above the limit are rejected with 429 status. Set to 0 to disable the limit.")
+	argTrustedProxies                    = pflag.StringSlice("trusted-proxies", []string{}, "CIDRs or IP addresses of proxies, i.e. ingress controllers, whose X-Forwarded-For and X-Real-IP headers are used to determine address of the client for logs and rate limiting. Headers of other clients are ignored.")
+	argEnableProxyImpersonation          = pflag.Bool("enable-proxy-impersonation", false, "When enabled, Impersonate-User, Impersonate-Group and Impersonate-Extra- headers of requests sent by --impersonating-proxies without credentials are used to impersonate the user with Dashboard service account, that needs to be allowed to impersonate users and groups. (default false)")
+	argImpersonatingProxies              = pflag.StringSlice("impersonating-proxies", []string{}, "CIDRs or IP addresses of authenticating proxies, whose impersonation headers are used when --enable-proxy-impersonation is set. Only the address of the connection is checked, forwarding headers are not used. The proxies have to remove Impersonate- headers sent by clients. Required by --enable-proxy-impersonation.")
+	argProxyImpersonationGroups          = pflag.StringSlice("proxy-impersonation-groups", []string{}, "Groups, that authenticating proxies can impersonate. Other Impersonate-Group headers of requests without credentials are ignored. No groups are impersonated when empty.")
+	argExecRecordingDir                  = pflag.String("exec-recording-dir", "", "Directory that terminal exec sessions are recorded to, including input and output with timestamps. Recordings can be listed and replayed by cluster administrators. Exec sessions are refused when recording fails. Recording is disabled when empty.")
+	argOIDCIssuerURL                     = pflag.String("oidc-issuer-url", "", "URL of the OIDC identity provider, whose ID tokens from uploaded kubeconfig files are refreshed with their refresh tokens before they expire. Tokens of other issuers are not refreshed. Refreshing is disabled when empty.")
+	argKubeBenchImage                    = pflag.String("kube-bench-image", "", "Image of kube-bench jobs started from Dashboard, pinned by digest, i.e. aquasec/kube-bench@sha256:... Jobs run with access to the host of the node, so starting them additionally requires the kubeBench feature gate. Starting kube-bench jobs is refused when empty.")
+	argManifestURLAllowedHosts           = pflag.StringSlice("manifest-url-allowed-hosts", []string{}, "Hosts that manifests can be deployed from by their https URL, i.e. raw.githubusercontent.com. Hosts resolving to loopback, private or link-local addresses are refused. Deploying manifests from URLs is disabled when empty.")
+	argConfigFile                        = pflag.String("config-file", "", "Path to a YAML file with values of any of the flags, i.e. 'enable-skip-login: true'. Flags given on the command line take precedence. File is checked for changes every 10 seconds and settings, that are read for every request, i.e. feature gates and request limits, are applied without restart.")
+	argShutdownGracePeriod               = pflag.Int("shutdown-grace-period", 20, "Time in seconds that in-flight requests and streams are given to finish after the drain delay, before remaining connections are dropped.")
+	argShutdownDrainDelay                = pflag.Int("shutdown-drain-delay", 5, "Time in seconds between SIGTERM and closing of the listeners, during which the readiness probe fails and requests are still served, so that endpoints are updated before connections are refused. Termination grace period of the pod has to be longer than the sum of the drain delay and the shutdown grace period.")
 )
 
+// configFileCheckPeriod is how often the config file is checked for changes.
+const configFileCheckPeriod = 10 * time.Second
+
 func main() {
 	// Set logging output to standard console out
 	log.SetOutput(os.Stdout)
@@ -89,9 +149,40 @@ func main() {
 	pflag.Parse()
 	_ = flag.CommandLine.Parse(make([]string, 0)) // Init for glog calls in kubernetes packages
 
+	var configFile *args.ConfigFile
+	if len(*argConfigFile) > 0 {
+		configFile = args.NewConfigFile(*argConfigFile, pflag.CommandLine)
+		if _, err := configFile.Load(); err != nil {
+			log.Fatalf("Error while loading config file. Reason: %s", err)
+		}
+		log.Printf("Using config file: %s", *argConfigFile)
+	}
+
 	// Initializes dashboard arguments holder so we can read them in other packages
 	initArgHolder()
 
+	if err := logging.Init(args.Holder.GetLogFormat(), os.Stdout); err != nil {
+		log.Fatalf("Error while configuring logging. Reason: %s", err)
+	}
+
+	if err := tracing.Init(args.Holder.GetTracingSampleRate()); err != nil {
+		log.Fatalf("Error while configuring tracing. Reason: %s", err)
+	}
+
+	if err := validateListenPorts(); err != nil {
+		log.Fatalf("Error while validating listen ports. Reason: %s", err)
+	}
+
+	if _, err := validation.ServiceNodePortRange(); err != nil {
+		log.Fatalf("Error while parsing service node port range. Reason: %s", err)
+	}
+
+	if image := args.Holder.GetKubeBenchImage(); len(image) > 0 {
+		if err := kubebench.ValidateImage(image); err != nil {
+			log.Fatalf("Error while parsing kube-bench image. Reason: %s", err)
+		}
+	}
+
 	if args.Holder.GetApiServerHost() != "" {
 		log.Printf("Using apiserver-host location: %s", args.Holder.GetApiServerHost())
 	}
@@ -103,23 +194,44 @@ func main() {
 	}
 
 	clientManager := client.NewClientManager(args.Holder.GetKubeConfigFile(), args.Holder.GetApiServerHost())
+	log.Printf("Connecting to the apiserver using %s config", clientManager.ConfigMode())
 	versionInfo, err := clientManager.InsecureClient().Discovery().ServerVersion()
 	if err != nil {
 		handleFatalInitError(err)
 	}
 
-	log.Printf("Successful initial request to the apiserver, version: %s", versionInfo.String())
+	log.Printf("Successful initial request to the apiserver using %s config, version: %s",
+		clientManager.ConfigMode(), versionInfo.String())
 
 	// Init auth manager
 	authManager := initAuthManager(clientManager)
 
 	// Init settings manager
 	settingsManager := settings.NewSettingsManager()
+	settingsManager.Watch(clientManager.InsecureClient(), wait.NeverStop)
+
+	// Init alerting about workload failures
+	alerting.NewAlertManager().Watch(clientManager.InsecureClient(), wait.NeverStop)
 
 	// Init system banner manager
 	systemBannerManager := systembanner.NewSystemBannerManager(args.Holder.GetSystemBanner(),
 		args.Holder.GetSystemBannerSeverity())
 
+	// Init feature manager
+	featureManager, err := features.NewFeatureManager(args.Holder.GetFeatureGates())
+	if err != nil {
+		log.Fatalf("Error while parsing feature gates. Reason: %s", err)
+	}
+
+	if configFile != nil {
+		go configFile.Watch(configFileCheckPeriod, func() {
+			args.GetHolderBuilder().Update(initArgHolder)
+			if err := featureManager.SetGates(args.Holder.GetFeatureGates()); err != nil {
+				log.Printf("Could not apply feature gates from config file. Reason: %s", err)
+			}
+		})
+	}
+
 	// Init integrations
 	integrationManager := integration.NewIntegrationManager(clientManager)
 
@@ -130,6 +242,12 @@ func main() {
 	case "heapster":
 		integrationManager.Metric().ConfigureHeapster(args.Holder.GetHeapsterHost()).
 			EnableWithRetry(integrationapi.HeapsterIntegrationID, time.Duration(args.Holder.GetMetricClientCheckPeriod()))
+	case "metrics-server":
+		integrationManager.Metric().ConfigureMetricsServer().
+			EnableWithRetry(integrationapi.MetricsServerIntegrationID, time.Duration(args.Holder.GetMetricClientCheckPeriod()))
+	case "prometheus":
+		integrationManager.Metric().ConfigurePrometheusMetrics(args.Holder.GetPrometheusHost()).
+			EnableWithRetry(integrationapi.PrometheusIntegrationID, time.Duration(args.Holder.GetMetricClientCheckPeriod()))
 	case "none":
 		log.Print("no metrics provider selected, will not check metrics.")
 	default:
@@ -139,12 +257,18 @@ func main() {
 			EnableWithRetry(integrationapi.SidecarIntegrationID, time.Duration(args.Holder.GetMetricClientCheckPeriod()))
 	}
 
+	if prometheusHost := args.Holder.GetPrometheusHost(); len(prometheusHost) > 0 {
+		integrationManager.Metric().ConfigurePrometheus(prometheusHost).
+			EnableTrafficWithRetry(time.Duration(args.Holder.GetMetricClientCheckPeriod()))
+	}
+
 	apiHandler, err := handler.CreateHTTPAPIHandler(
 		integrationManager,
 		clientManager,
 		authManager,
 		settingsManager,
-		systemBannerManager)
+		systemBannerManager,
+		featureManager)
 	if err != nil {
 		handleFatalInitError(err)
 	}
@@ -169,29 +293,167 @@ func main() {
 		servingCerts = []tls.Certificate{servingCert}
 	}
 
+	healthHandler := handler.CreateHealthHandler(clientManager, integrationManager)
+
 	// Run a HTTP server that serves static public files from './public' and handles API calls.
 	http.Handle("/", handler.MakeGzipHandler(handler.CreateLocaleHandler()))
-	http.Handle("/api/", apiHandler)
+	apiRootHandler := handler.CreateConcurrencyLimitHandler(apiHandler, args.Holder.GetMaxConcurrentRequestsPerClient,
+		handler.KnownUser(clientManager))
+	apiRootHandler = handler.CreateRequestLimitHandler(apiRootHandler, args.Holder.GetMaxRequestBodySize)
+	http.Handle("/api/", tracing.Handler(apiRootHandler))
 	http.Handle("/config", handler.AppHandler(handler.ConfigHandler))
 	http.Handle("/api/sockjs/", handler.CreateAttachHandler("/api/sockjs"))
-	http.Handle("/metrics", prometheus.Handler())
+	http.Handle("/metrics", promhttp.Handler())
+	http.HandleFunc("/healthz", healthHandler.ServeLiveness)
+	http.HandleFunc("/readyz", healthHandler.ServeReadiness)
+
+	if args.Holder.GetEnableProfiling() {
+		debugAddr := fmt.Sprintf("127.0.0.1:%d", args.Holder.GetProfilingPort())
+		log.Printf("Serving profiling endpoints on %s%s", debugAddr, handler.DebugPath)
+		go func() { log.Fatal(http.ListenAndServe(debugAddr, handler.CreateDebugHandler())) }()
+	}
+
+	trustedProxies, err := handler.ParseTrustedProxies(args.Holder.GetTrustedProxies())
+	if err != nil {
+		log.Fatalf("Error while parsing trusted proxies. Reason: %s", err)
+	}
+	impersonatingProxies, err := handler.ParseTrustedProxies(args.Holder.GetImpersonatingProxies())
+	if err != nil {
+		log.Fatalf("Error while parsing impersonating proxies. Reason: %s", err)
+	}
+	if args.Holder.GetEnableProxyImpersonation() && len(impersonatingProxies) == 0 {
+		log.Fatal("Proxy impersonation requires addresses of authenticating proxies in --impersonating-proxies")
+	}
+
+	rootHandler := handler.CreateRequestIDHandler(handler.CreateRecoveryHandler(handler.CreateTenantHandler(http.DefaultServeMux)))
+	if len(args.Holder.GetAccessLogFormat()) > 0 {
+		rootHandler, err = handler.CreateAccessLogHandler(rootHandler, args.Holder.GetAccessLogFormat(), os.Stdout,
+			handler.KnownUser(clientManager))
+		if err != nil {
+			log.Fatalf("Error while configuring access log. Reason: %s", err)
+		}
+	}
+	// Real address of the client has to be known before anything is logged.
+	rootHandler = handler.CreateRealIPHandler(rootHandler, trustedProxies, impersonatingProxies)
+
+	// Sockets passed by a service manager are used instead of opening new ones.
+	listeners, err := activation.Listeners()
+	if err != nil {
+		log.Fatalf("Error while using passed sockets. Reason: %s", err)
+	}
+	if len(listeners) > 0 {
+		log.Printf("Using %d socket(s) passed by the service manager", len(listeners))
+	}
 
 	// Listen for http or https
+	var server, redirectServer *http.Server
+	insecureListener := activation.Pick(listeners, activation.InsecureName, 0)
 	if servingCerts != nil {
-		log.Printf("Serving securely on HTTPS port: %d", args.Holder.GetPort())
-		secureAddr := fmt.Sprintf("%s:%d", args.Holder.GetBindAddress(), args.Holder.GetPort())
-		server := &http.Server{
+		secureAddr := listenAddress(args.Holder.GetBindAddress(), args.Holder.GetPort())
+		server = &http.Server{
 			Addr:      secureAddr,
-			Handler:   http.DefaultServeMux,
+			Handler:   rootHandler,
 			TLSConfig: &tls.Config{Certificates: servingCerts},
 		}
-		go func() { log.Fatal(server.ListenAndServeTLS("", "")) }()
+		listener := listen(activation.Pick(listeners, activation.SecureName, 0), secureAddr)
+		log.Printf("Serving securely on HTTPS address: %s", listener.Addr())
+		go func() { handleServeError(server.ServeTLS(listener, "", "")) }()
+
+		redirectListener := activation.Pick(listeners, activation.InsecureName, 1)
+		if args.Holder.GetEnableHTTPSRedirect() && (args.Holder.GetInsecurePort() != 0 || redirectListener != nil) {
+			addr := listenAddress(args.Holder.GetInsecureBindAddress(), args.Holder.GetInsecurePort())
+			redirectListener = listen(redirectListener, addr)
+			log.Printf("Redirecting HTTP requests on address %s to HTTPS", redirectListener.Addr())
+			redirectServer = &http.Server{Addr: addr, Handler: handler.CreateHTTPSRedirectHandler(args.Holder.GetPort())}
+			go func() { handleServeError(redirectServer.Serve(redirectListener)) }()
+		}
+	} else if args.Holder.GetInsecurePort() == 0 && insecureListener == nil {
+		log.Fatal("Insecure listener is disabled with --insecure-port=0, but no serving certificates are " +
+			"configured. Set --auto-generate-certificates or --tls-cert-file and --tls-key-file.")
 	} else {
-		log.Printf("Serving insecurely on HTTP port: %d", args.Holder.GetInsecurePort())
-		addr := fmt.Sprintf("%s:%d", args.Holder.GetInsecureBindAddress(), args.Holder.GetInsecurePort())
-		go func() { log.Fatal(http.ListenAndServe(addr, nil)) }()
+		addr := listenAddress(args.Holder.GetInsecureBindAddress(), args.Holder.GetInsecurePort())
+		server = &http.Server{Addr: addr, Handler: rootHandler}
+		insecureListener = listen(insecureListener, addr)
+		log.Printf("Serving insecurely on HTTP address: %s", insecureListener.Addr())
+		go func() { handleServeError(server.Serve(insecureListener)) }()
+	}
+
+	shutdownGracefully(server, redirectServer)
+}
+
+// validateListenPorts checks, that listen ports are valid TCP ports. Insecure port can be 0, which disables
+// the insecure listener.
+func validateListenPorts() error {
+	if port := args.Holder.GetPort(); port < 1 || port > 65535 {
+		return fmt.Errorf("--port has to be between 1 and 65535, got %d", port)
+	}
+	if port := args.Holder.GetInsecurePort(); port < 0 || port > 65535 {
+		return fmt.Errorf("--insecure-port has to be between 0 and 65535, got %d", port)
+	}
+	return nil
+}
+
+// listenAddress joins IP and port into an address, that net.Listen accepts. IPv6 addresses are enclosed
+// in brackets.
+func listenAddress(ip net.IP, port int) string {
+	return net.JoinHostPort(ip.String(), strconv.Itoa(port))
+}
+
+// listen returns passed listener, or opens a new TCP listener on given address if no listener was passed.
+func listen(passed net.Listener, addr string) net.Listener {
+	if passed != nil {
+		return passed
+	}
+
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		log.Fatal(err)
+	}
+	return listener
+}
+
+// handleServeError exits when the server stops for any other reason than graceful shutdown.
+func handleServeError(err error) {
+	if err != http.ErrServerClosed {
+		log.Fatal(err)
+	}
+}
+
+// shutdownGracefully blocks until SIGTERM or interrupt is received. Then the readiness probe starts failing and
+// requests are still served for the drain delay, so that no traffic is routed to closed listeners. After that it
+// stops accepting new connections, closes long-lived streams, i.e. terminal sessions and event watches, and waits
+// up to the grace period for in-flight requests and WebSocket streams to finish before remaining connections are
+// dropped. Nil servers are skipped.
+func shutdownGracefully(servers ...*http.Server) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGTERM, os.Interrupt)
+	sig := <-signals
+
+	drainDelay := time.Duration(args.Holder.GetShutdownDrainDelay()) * time.Second
+	gracePeriod := time.Duration(args.Holder.GetShutdownGracePeriod()) * time.Second
+	log.Printf("Received %s, shutting down after drain delay of %s with grace period of %s", sig, drainDelay,
+		gracePeriod)
+	handler.StartShutdown()
+	time.Sleep(drainDelay)
+	handler.CloseStreams()
+
+	ctx, cancel := context.WithTimeout(context.Background(), gracePeriod)
+	defer cancel()
+	for _, server := range servers {
+		if server == nil {
+			continue
+		}
+		if err := server.Shutdown(ctx); err != nil {
+			log.Printf("In-flight requests did not finish in time, dropping remaining connections. Reason: %s", err)
+			server.Close()
+		}
+	}
+
+	// Hijacked connections are not tracked by the server, so they are waited for separately.
+	if err := handler.WaitForStreams(ctx); err != nil {
+		log.Printf("WebSocket streams did not close in time. Reason: %s", err)
 	}
-	select {}
+	log.Print("Shutdown complete")
 }
 
 func initAuthManager(clientManager clientapi.ClientManager) authApi.AuthManager {
@@ -240,6 +502,7 @@ func initArgHolder() {
 	builder.SetMetricsProvider(*argMetricsProvider)
 	builder.SetHeapsterHost(*argHeapsterHost)
 	builder.SetSidecarHost(*argSidecarHost)
+	builder.SetPrometheusHost(*argPrometheusHost)
 	builder.SetKubeConfigFile(*argKubeConfigFile)
 	builder.SetSystemBanner(*argSystemBanner)
 	builder.SetSystemBannerSeverity(*argSystemBannerSeverity)
@@ -251,6 +514,44 @@ func initArgHolder() {
 	builder.SetEnableSkipLogin(*argEnableSkip)
 	builder.SetNamespace(*argNamespace)
 	builder.SetLocaleConfig(*localeConfig)
+	builder.SetEnableMetricsReadinessCheck(*argEnableMetricsReadinessCheck)
+	builder.SetChartRepositories(*argChartRepositories)
+	builder.SetMinResourceAutoRefreshInterval(*argMinResourceAutoRefreshInterval)
+	builder.SetFeatureGates(*argFeatureGates)
+	builder.SetLoginNotice(*argLoginNotice)
+	builder.SetLoginNoticeAcknowledgmentRequired(*argLoginNoticeAcknowledgmentRequired)
+	builder.SetLogFormat(*argLogFormat)
+	builder.SetTracingSampleRate(*argTracingSampleRate)
+	builder.SetEnableProfiling(*argEnableProfiling)
+	builder.SetProfilingPort(*argProfilingPort)
+	builder.SetAccessLogFormat(*argAccessLogFormat)
+	builder.SetSlowRequestThreshold(*argSlowRequestThreshold)
+	builder.SetServiceNodePortRange(*argServiceNodePortRange)
+	builder.SetAPIServerRequestTimeout(*argAPIServerRequestTimeout)
+	builder.SetAPIServerRequestRetries(*argAPIServerRequestRetries)
+	builder.SetVeleroNamespace(*argVeleroNamespace)
+	builder.SetImageScannerURL(*argImageScannerURL)
+	builder.SetAlertWebhookURLs(*argAlertWebhookURLs)
+	builder.SetAlertSlackWebhookURLs(*argAlertSlackWebhookURLs)
+	builder.SetDashboardURL(*argDashboardURL)
+	builder.SetCostCPUCoreMonth(*argCostCPUCoreMonth)
+	builder.SetCostMemoryGiBMonth(*argCostMemoryGiBMonth)
+	builder.SetCostCurrency(*argCostCurrency)
+	builder.SetFalcoToken(*argFalcoToken)
+	builder.SetFalcoEventLimit(*argFalcoEventLimit)
+	builder.SetShutdownGracePeriod(*argShutdownGracePeriod)
+	builder.SetShutdownDrainDelay(*argShutdownDrainDelay)
+	builder.SetEnableHTTPSRedirect(*argEnableHTTPSRedirect)
+	builder.SetMaxRequestBodySize(*argMaxRequestBodySize)
+	builder.SetMaxConcurrentRequestsPerClient(*argMaxConcurrentRequestsPerClient)
+	builder.SetTrustedProxies(*argTrustedProxies)
+	builder.SetEnableProxyImpersonation(*argEnableProxyImpersonation)
+	builder.SetImpersonatingProxies(*argImpersonatingProxies)
+	builder.SetProxyImpersonationGroups(*argProxyImpersonationGroups)
+	builder.SetExecRecordingDir(*argExecRecordingDir)
+	builder.SetKubeBenchImage(*argKubeBenchImage)
+	builder.SetOIDCIssuerURL(*argOIDCIssuerURL)
+	builder.SetManifestURLAllowedHosts(*argManifestURLAllowedHosts)
 }
 
 /**
@@ -78,6 +78,18 @@ func NewNotFound(reason string) *errors.StatusError {
 	}
 }
 
+// NewForbidden returns an error indicating that the requested action is forbidden.
+func NewForbidden(reason string) *errors.StatusError {
+	return &errors.StatusError{
+		ErrStatus: metav1.Status{
+			Status:  metav1.StatusFailure,
+			Code:    http.StatusForbidden,
+			Reason:  metav1.StatusReasonForbidden,
+			Message: reason,
+		},
+	}
+}
+
 // NewInternal return a statusError
 // which is an error intended for consumption by a REST API server; it can also be
 // reconstructed by clients from a REST response. Public to allow easy type switches.
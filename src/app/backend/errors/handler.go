@@ -48,6 +48,18 @@ func AppendError(err error, nonCriticalErrors []error) ([]error, error) {
 	return nonCriticalErrors, nil
 }
 
+// AppendOptionalError handles single error, that occurred during retrieval of an optional part of API GET call
+// response, i.e. events or pods of a resource detail. Such error never fails the whole response. It is always
+// returned as a part of non-critical error array, so that parts that were retrieved successfully can still be
+// displayed.
+func AppendOptionalError(err error, nonCriticalErrors []error) []error {
+	if err != nil {
+		log.Printf("Optional part of the response could not be retrieved: %s", err)
+		nonCriticalErrors = appendMissing(nonCriticalErrors, LocalizeError(err))
+	}
+	return nonCriticalErrors
+}
+
 // MergeErrors merges multiple non-critical error arrays into one array.
 func MergeErrors(errorArraysToMerge ...[]error) (mergedErrors []error) {
 	for _, errorArray := range errorArraysToMerge {
@@ -112,6 +124,17 @@ func IsNotFoundError(err error) bool {
 	return status.ErrStatus.Code == http.StatusNotFound
 }
 
+// IsConflictError returns true when the given error is 409-Conflict error, i.e. the object was modified
+// after it was read.
+func IsConflictError(err error) bool {
+	status, ok := err.(*errors.StatusError)
+	if !ok {
+		return false
+	}
+
+	return status.ErrStatus.Code == http.StatusConflict
+}
+
 // IsTokenExpiredError determines if the err is the MsgTokenExpiredError.
 func IsTokenExpiredError(err error) bool {
 	if err == nil {
@@ -121,24 +144,13 @@ func IsTokenExpiredError(err error) bool {
 	return err.Error() == MsgTokenExpiredError
 }
 
-// HandleInternalError writes the given error to the response and sets appropriate HTTP status headers.
-func HandleInternalError(response *restful.Response, err error) {
-	statusCode := http.StatusInternalServerError
-	statusError, ok := err.(*errors.StatusError)
-	if ok && statusError.Status().Code > 0 {
-		statusCode = int(statusError.Status().Code)
-	}
-	response.AddHeader("Content-Type", "text/plain")
-	response.WriteErrorString(statusCode, err.Error()+"\n")
+// HandleInternalError writes the given error to the response and sets appropriate HTTP status headers. Status
+// code and reason are determined by TranslateError, format of the body is negotiated by WriteError.
+func HandleInternalError(request *restful.Request, response *restful.Response, err error) {
+	WriteError(request, response, err)
 }
 
-// HandleHTTPError is used to handle HTTP Errors more accurately based on the localized consts
+// HandleHTTPError is used to handle HTTP Errors more accurately based on the localized consts and status errors.
 func HandleHTTPError(err error) int {
-	if err == nil {
-		return http.StatusInternalServerError
-	}
-	if err.Error() == MsgTokenExpiredError || err.Error() == MsgLoginUnauthorizedError || err.Error() == MsgEncryptionKeyChanged {
-		return http.StatusUnauthorized
-	}
-	return http.StatusInternalServerError
+	return TranslateError(err).Code
 }
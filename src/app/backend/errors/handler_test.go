@@ -58,3 +58,23 @@ func TestHandleHTTPError(t *testing.T) {
 		}
 	}
 }
+
+func TestAppendOptionalError(t *testing.T) {
+	cases := []struct {
+		err      error
+		existing []error
+		expected []error
+	}{
+		{nil, []error{}, []error{}},
+		{errors.NewInternal("some error"), []error{}, []error{errors.NewInternal("some error")}},
+		{errors.NewForbidden("forbidden"), []error{errors.NewForbidden("forbidden")},
+			[]error{errors.NewForbidden("forbidden")}},
+	}
+
+	for _, c := range cases {
+		actual := errors.AppendOptionalError(c.err, c.existing)
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("AppendOptionalError(%+v, %+v) == %+v, expected %+v", c.err, c.existing, actual, c.expected)
+		}
+	}
+}
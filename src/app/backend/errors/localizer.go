@@ -28,6 +28,12 @@ const (
 	MsgEncryptionKeyChanged            = "MSG_ENCRYPTION_KEY_CHANGED"
 	MsgDashboardExclusiveResourceError = "MSG_DASHBOARD_EXCLUSIVE_RESOURCE_ERROR"
 	MsgTokenExpiredError               = "MSG_TOKEN_EXPIRED_ERROR"
+	MsgNamespaceRestrictedError        = "MSG_NAMESPACE_RESTRICTED_ERROR"
+	MsgFeatureDisabledError            = "MSG_FEATURE_DISABLED_ERROR"
+	MsgResourceKindHiddenError         = "MSG_RESOURCE_KIND_HIDDEN_ERROR"
+	MsgTenantNamespaceError            = "MSG_TENANT_NAMESPACE_ERROR"
+	MsgLoginNoticeNotAcknowledgedError = "MSG_LOGIN_NOTICE_NOT_ACKNOWLEDGED_ERROR"
+	MsgUnexpectedServerError           = "MSG_UNEXPECTED_SERVER_ERROR"
 )
 
 // This file contains all errors that should be kept in sync with:
@@ -0,0 +1,196 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package errors
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strings"
+
+	restful "github.com/emicklei/go-restful"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// APIError is a machine-readable representation of an error returned by the API. It is written instead of the
+// plain text message to clients that accept only JSON responses.
+type APIError struct {
+	// Code is the HTTP status code of the response.
+	Code int `json:"code"`
+
+	// Reason is a machine-readable description of why the request failed, i.e. "Forbidden" or "NotFound".
+	Reason metav1.StatusReason `json:"reason"`
+
+	// Message is a human-readable description of the error.
+	Message string `json:"message"`
+
+	// Kind, Group and Name identify the resource related to the error, if known.
+	Kind  string `json:"kind,omitempty"`
+	Group string `json:"group,omitempty"`
+	Name  string `json:"name,omitempty"`
+
+	// Causes lists individual problems, i.e. invalid fields, that caused the error.
+	Causes []APIErrorCause `json:"causes,omitempty"`
+
+	// RequestID identifies the failed request in Dashboard and apiserver logs.
+	RequestID string `json:"requestID,omitempty"`
+}
+
+// APIErrorCause describes a single cause of an APIError.
+type APIErrorCause struct {
+	Type    metav1.CauseType `json:"type,omitempty"`
+	Field   string           `json:"field,omitempty"`
+	Message string           `json:"message"`
+}
+
+// Error implements error interface.
+func (self *APIError) Error() string {
+	return self.Message
+}
+
+// ErrorReasonHeader is the response header that carries APIError reason, so that clients reading plain text
+// error messages can still tell errors apart.
+const ErrorReasonHeader = "X-Error-Reason"
+
+// requestIDHeader is the response header set by the request ID handler. It has to match client.RequestIDHeader.
+const requestIDHeader = "X-Request-Id"
+
+// reasonStatusCodes maps status reasons to HTTP codes for status errors that do not carry their own code.
+var reasonStatusCodes = map[metav1.StatusReason]int{
+	metav1.StatusReasonUnauthorized:     http.StatusUnauthorized,
+	metav1.StatusReasonForbidden:        http.StatusForbidden,
+	metav1.StatusReasonNotFound:         http.StatusNotFound,
+	metav1.StatusReasonAlreadyExists:    http.StatusConflict,
+	metav1.StatusReasonConflict:         http.StatusConflict,
+	metav1.StatusReasonGone:             http.StatusGone,
+	metav1.StatusReasonInvalid:          http.StatusUnprocessableEntity,
+	metav1.StatusReasonBadRequest:       http.StatusBadRequest,
+	metav1.StatusReasonMethodNotAllowed: http.StatusMethodNotAllowed,
+	metav1.StatusReasonNotAcceptable:    http.StatusNotAcceptable,
+	metav1.StatusReasonTimeout:          http.StatusGatewayTimeout,
+	metav1.StatusReasonTooManyRequests:  http.StatusTooManyRequests,
+}
+
+// unauthorizedMessages are localized messages that always mean that the user has to log in again, no matter
+// how the error carrying them was created.
+var unauthorizedMessages = []string{MsgTokenExpiredError, MsgLoginUnauthorizedError, MsgEncryptionKeyChanged}
+
+// TranslateError maps given error to the HTTP status code and machine-readable body that should be returned to
+// the client. Kubernetes status errors keep their code, reason, details and causes. Upstream timeouts are
+// reported as gateway timeouts. Other errors are treated as internal server errors.
+func TranslateError(err error) *APIError {
+	if err == nil {
+		return &APIError{
+			Code:    http.StatusInternalServerError,
+			Reason:  metav1.StatusReasonInternalError,
+			Message: http.StatusText(http.StatusInternalServerError),
+		}
+	}
+
+	result := &APIError{
+		Code:    http.StatusInternalServerError,
+		Reason:  metav1.StatusReasonInternalError,
+		Message: err.Error(),
+	}
+
+	if status, ok := err.(errors.APIStatus); ok {
+		translateStatus(status.Status(), result)
+	} else if isTimeout(err) {
+		result.Code = http.StatusGatewayTimeout
+		result.Reason = metav1.StatusReasonTimeout
+	}
+
+	for _, msg := range unauthorizedMessages {
+		if result.Message == msg {
+			result.Code = http.StatusUnauthorized
+			result.Reason = metav1.StatusReasonUnauthorized
+		}
+	}
+
+	return result
+}
+
+func translateStatus(status metav1.Status, result *APIError) {
+	if len(status.Reason) > 0 {
+		result.Reason = status.Reason
+	}
+
+	if code, ok := reasonStatusCodes[status.Reason]; status.Code <= 0 && ok {
+		result.Code = code
+	} else if status.Code > 0 {
+		result.Code = int(status.Code)
+	}
+
+	if status.Details == nil {
+		return
+	}
+
+	result.Kind = status.Details.Kind
+	result.Group = status.Details.Group
+	result.Name = status.Details.Name
+	for _, cause := range status.Details.Causes {
+		result.Causes = append(result.Causes, APIErrorCause{
+			Type:    cause.Type,
+			Field:   cause.Field,
+			Message: cause.Message,
+		})
+	}
+}
+
+// isTimeout returns true when given error was caused by an upstream request that did not finish in time.
+func isTimeout(err error) bool {
+	if netErr, ok := err.(net.Error); ok && netErr.Timeout() {
+		return true
+	}
+
+	return err == context.DeadlineExceeded || strings.Contains(err.Error(), context.DeadlineExceeded.Error())
+}
+
+// WriteError writes given error to the response using status code returned by TranslateError. Clients that
+// prefer JSON over plain text get APIError as the body, all others get the error message as plain text. Reason is always
+// set in ErrorReasonHeader and ID of the request, if known, is returned as part of APIError.
+func WriteError(request *restful.Request, response *restful.Response, err error) {
+	apiError := TranslateError(err)
+	apiError.RequestID = response.Header().Get(requestIDHeader)
+	response.AddHeader(ErrorReasonHeader, string(apiError.Reason))
+	if prefersJSON(request) {
+		response.WriteHeaderAndJson(apiError.Code, apiError, restful.MIME_JSON)
+		return
+	}
+
+	response.AddHeader("Content-Type", "text/plain")
+	response.WriteErrorString(apiError.Code, apiError.Message+"\n")
+}
+
+// prefersJSON returns true when application/json is listed in the Accept header of given request before
+// text/plain. Wildcards are ignored, so that clients sending only "*/*" keep getting plain text messages.
+func prefersJSON(request *restful.Request) bool {
+	if request == nil {
+		return false
+	}
+
+	for _, accepted := range strings.Split(request.HeaderParameter("Accept"), ",") {
+		mediaType := strings.TrimSpace(strings.Split(accepted, ";")[0])
+		switch mediaType {
+		case restful.MIME_JSON:
+			return true
+		case "text/plain":
+			return false
+		}
+	}
+
+	return false
+}
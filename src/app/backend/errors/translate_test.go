@@ -0,0 +1,141 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package errors_test
+
+import (
+	"encoding/json"
+	goerrors "errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	restful "github.com/emicklei/go-restful"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+)
+
+func TestTranslateError(t *testing.T) {
+	deployments := schema.GroupResource{Group: "apps", Resource: "deployments"}
+
+	cases := []struct {
+		err      error
+		expected *errors.APIError
+	}{
+		{
+			nil,
+			&errors.APIError{Code: 500, Reason: metav1.StatusReasonInternalError, Message: "Internal Server Error"},
+		},
+		{
+			goerrors.New("something went wrong"),
+			&errors.APIError{Code: 500, Reason: metav1.StatusReasonInternalError, Message: "something went wrong"},
+		},
+		{
+			goerrors.New(errors.MsgTokenExpiredError),
+			&errors.APIError{Code: 401, Reason: metav1.StatusReasonUnauthorized, Message: errors.MsgTokenExpiredError},
+		},
+		{
+			errors.NewForbidden("forbidden"),
+			&errors.APIError{Code: 403, Reason: metav1.StatusReasonForbidden, Message: "forbidden"},
+		},
+		{
+			k8serrors.NewNotFound(deployments, "web"),
+			&errors.APIError{Code: 404, Reason: metav1.StatusReasonNotFound, Group: "apps", Kind: "deployments",
+				Name: "web", Message: `deployments.apps "web" not found`},
+		},
+		{
+			k8serrors.NewAlreadyExists(deployments, "web"),
+			&errors.APIError{Code: 409, Reason: metav1.StatusReasonAlreadyExists, Group: "apps",
+				Kind: "deployments", Name: "web", Message: `deployments.apps "web" already exists`},
+		},
+		{
+			k8serrors.NewInvalid(schema.GroupKind{Group: "apps", Kind: "Deployment"}, "web", field.ErrorList{
+				field.Required(field.NewPath("spec", "selector"), ""),
+			}),
+			&errors.APIError{Code: 422, Reason: metav1.StatusReasonInvalid, Group: "apps", Kind: "Deployment",
+				Name: "web", Message: `Deployment.apps "web" is invalid: spec.selector: Required value`,
+				Causes: []errors.APIErrorCause{
+					{Type: metav1.CauseTypeFieldValueRequired, Field: "spec.selector", Message: "Required value"},
+				}},
+		},
+		{
+			&k8serrors.StatusError{ErrStatus: metav1.Status{Reason: metav1.StatusReasonConflict, Message: "conflict"}},
+			&errors.APIError{Code: 409, Reason: metav1.StatusReasonConflict, Message: "conflict"},
+		},
+	}
+
+	for _, c := range cases {
+		actual := errors.TranslateError(c.err)
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("TranslateError(%+v) == %+v, expected %+v", c.err, actual, c.expected)
+		}
+	}
+}
+
+func TestWriteError(t *testing.T) {
+	err := errors.NewForbidden("forbidden")
+
+	cases := []struct {
+		accept      string
+		contentType string
+		body        string
+	}{
+		{"application/json, text/plain, */*", "application/json",
+			`{"code":403,"reason":"Forbidden","message":"forbidden","requestID":"abc"}`},
+		{"text/plain, application/json", "text/plain", "forbidden\n"},
+		{"*/*", "text/plain", "forbidden\n"},
+		{"", "text/plain", "forbidden\n"},
+		{"application/json", "application/json",
+			`{"code":403,"reason":"Forbidden","message":"forbidden","requestID":"abc"}`},
+	}
+
+	for _, c := range cases {
+		httpRequest, _ := http.NewRequest(http.MethodGet, "/api/v1/test", nil)
+		httpRequest.Header.Set("Accept", c.accept)
+		recorder := httptest.NewRecorder()
+		response := restful.NewResponse(recorder)
+		response.Header().Set("X-Request-Id", "abc")
+
+		errors.WriteError(restful.NewRequest(httpRequest), response, err)
+
+		if recorder.Code != http.StatusForbidden {
+			t.Errorf("WriteError with Accept %q wrote code %d, expected %d", c.accept, recorder.Code,
+				http.StatusForbidden)
+		}
+		if actual := recorder.Header().Get("Content-Type"); !strings.HasPrefix(actual, c.contentType) {
+			t.Errorf("WriteError with Accept %q wrote content type %q, expected %q", c.accept, actual,
+				c.contentType)
+		}
+		if actual := recorder.Header().Get(errors.ErrorReasonHeader); actual != "Forbidden" {
+			t.Errorf("WriteError with Accept %q wrote reason %q, expected %q", c.accept, actual, "Forbidden")
+		}
+
+		body := recorder.Body.String()
+		if c.contentType == "application/json" {
+			decoded := new(errors.APIError)
+			json.Unmarshal(recorder.Body.Bytes(), decoded)
+			encoded, _ := json.Marshal(decoded)
+			body = string(encoded)
+		}
+		if body != c.body {
+			t.Errorf("WriteError with Accept %q wrote body %q, expected %q", c.accept, body, c.body)
+		}
+	}
+}
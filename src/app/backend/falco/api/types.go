@@ -0,0 +1,92 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"time"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+)
+
+// Priority of a Falco rule.
+type Priority string
+
+// List of Falco priorities, from the most severe.
+const (
+	PriorityEmergency     Priority = "Emergency"
+	PriorityAlert         Priority = "Alert"
+	PriorityCritical      Priority = "Critical"
+	PriorityError         Priority = "Error"
+	PriorityWarning       Priority = "Warning"
+	PriorityNotice        Priority = "Notice"
+	PriorityInformational Priority = "Informational"
+	PriorityDebug         Priority = "Debug"
+)
+
+// Priorities contains all priorities ordered from the most severe.
+var Priorities = []Priority{PriorityEmergency, PriorityAlert, PriorityCritical, PriorityError, PriorityWarning,
+	PriorityNotice, PriorityInformational, PriorityDebug}
+
+// Event is a single alert raised by Falco.
+type Event struct {
+	// Time of the system call, that triggered the rule.
+	Time time.Time `json:"time"`
+
+	// Name of the triggered rule, i.e. Terminal shell in container.
+	Rule string `json:"rule"`
+
+	Priority Priority `json:"priority"`
+
+	// Formatted message of the rule.
+	Output string `json:"output"`
+
+	// Name of the host that Falco runs on.
+	Hostname string `json:"hostname,omitempty"`
+
+	// Namespace, pod and container the event happened in. Empty for events outside of containers.
+	Namespace string `json:"namespace,omitempty"`
+	Pod       string `json:"pod,omitempty"`
+	Container string `json:"container,omitempty"`
+
+	Tags []string `json:"tags,omitempty"`
+
+	// All fields of the rule output, i.e. proc.cmdline.
+	OutputFields map[string]interface{} `json:"outputFields,omitempty"`
+}
+
+// EventList contains Falco events ordered from the newest one.
+type EventList struct {
+	ListMeta api.ListMeta `json:"listMeta"`
+	Events   []Event      `json:"events"`
+
+	// False when receiving of Falco alerts is not configured.
+	Enabled bool `json:"enabled"`
+}
+
+// EventManager keeps recent Falco events in memory.
+type EventManager interface {
+	// Enabled returns true when receiving of Falco alerts is configured.
+	Enabled() bool
+
+	// Authorize returns true when the token is the one that Falco was configured with.
+	Authorize(token string) bool
+
+	// Add stores the event. The oldest event is dropped when the limit of stored events is reached.
+	Add(event Event)
+
+	// List returns events of the namespace and pod with at least the given priority, ordered from the newest
+	// one. Empty namespace, pod or priority matches all events.
+	List(namespace, pod string, priority Priority) []Event
+}
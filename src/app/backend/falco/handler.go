@@ -0,0 +1,112 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package falco
+
+import (
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	restful "github.com/emicklei/go-restful"
+
+	clientapi "github.com/kubernetes/dashboard/src/app/backend/client/api"
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/falco/api"
+	"github.com/kubernetes/dashboard/src/app/backend/handler/parser"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+)
+
+// EventPath is the path that Falco HTTP output has to post alerts to.
+const EventPath = "/falco/event"
+
+// maxEventSize limits size of a request body with alerts.
+const maxEventSize = 1 << 20
+
+// EventHandler manages all endpoints related to Falco runtime security events.
+type EventHandler struct {
+	manager       api.EventManager
+	clientManager clientapi.ClientManager
+}
+
+// Install creates new endpoints for Falco events. Alerts are received from Falco HTTP output, that has to pass the
+// configured token in the token query parameter. Events are shown only to users, that can list pods of the
+// namespace.
+func (self *EventHandler) Install(ws *restful.WebService) {
+	ws.Route(
+		ws.POST(EventPath).
+			To(self.handleReceiveEvents))
+	ws.Route(
+		ws.GET(EventPath).
+			To(self.handleGetEvents).
+			Writes(api.EventList{}))
+	ws.Route(
+		ws.GET(EventPath + "/{namespace}").
+			To(self.handleGetEvents).
+			Writes(api.EventList{}))
+	ws.Route(
+		ws.GET(EventPath + "/{namespace}/{pod}").
+			To(self.handleGetEvents).
+			Writes(api.EventList{}))
+}
+
+func (self *EventHandler) handleReceiveEvents(request *restful.Request, response *restful.Response) {
+	token := request.QueryParameter("token")
+	if header := request.HeaderParameter("Authorization"); strings.HasPrefix(header, "Bearer ") {
+		token = strings.TrimPrefix(header, "Bearer ")
+	}
+	if !self.manager.Authorize(token) {
+		errors.HandleInternalError(request, response, errors.NewUnauthorized("invalid Falco token"))
+		return
+	}
+
+	body, err := ioutil.ReadAll(http.MaxBytesReader(response.ResponseWriter, request.Request.Body, maxEventSize))
+	if err != nil {
+		errors.HandleInternalError(request, response, errors.NewBadRequest(err.Error()))
+		return
+	}
+
+	events, err := parseEvents(body)
+	if err != nil {
+		errors.HandleInternalError(request, response, errors.NewBadRequest(err.Error()))
+		return
+	}
+
+	for _, event := range events {
+		self.manager.Add(event)
+	}
+	response.WriteHeader(http.StatusAccepted)
+}
+
+func (self *EventHandler) handleGetEvents(request *restful.Request, response *restful.Response) {
+	namespace := request.PathParameter("namespace")
+	if !self.clientManager.CanI(request, clientapi.ToSelfSubjectAccessReview(namespace, "", "pods", "list")) {
+		errors.HandleInternalError(request, response, errors.NewForbidden("access to pods of the namespace is required"))
+		return
+	}
+
+	events := self.manager.List(namespace, request.PathParameter("pod"),
+		api.Priority(request.QueryParameter("priority")))
+	dataSelect := parser.ParseDataSelectPathParameter(request)
+	cells, filteredTotal := dataselect.GenericDataSelectWithFilter(toCells(events), dataSelect)
+
+	result := api.EventList{Events: fromCells(cells), Enabled: self.manager.Enabled()}
+	result.ListMeta.TotalItems = filteredTotal
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+// NewEventHandler creates EventHandler.
+func NewEventHandler(manager api.EventManager, clientManager clientapi.ClientManager) EventHandler {
+	return EventHandler{manager: manager, clientManager: clientManager}
+}
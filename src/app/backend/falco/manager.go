@@ -0,0 +1,203 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package falco
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"encoding/json"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kubernetes/dashboard/src/app/backend/falco/api"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+)
+
+// Fields of Falco rule output, that identify the container the event happened in.
+const (
+	namespaceField = "k8s.ns.name"
+	podField       = "k8s.pod.name"
+	containerField = "container.name"
+)
+
+// eventManager implements EventManager interface. Events are kept in a ring buffer.
+type eventManager struct {
+	token  string
+	mux    sync.RWMutex
+	events []api.Event
+	// next is the index that the next event is stored at once the buffer is full.
+	next  int
+	limit int
+}
+
+// Enabled implements event manager interface. See EventManager for more information.
+func (self *eventManager) Enabled() bool {
+	return len(self.token) > 0 && self.limit > 0
+}
+
+// Authorize implements event manager interface. See EventManager for more information.
+func (self *eventManager) Authorize(token string) bool {
+	return self.Enabled() && subtle.ConstantTimeCompare([]byte(token), []byte(self.token)) == 1
+}
+
+// Add implements event manager interface. See EventManager for more information.
+func (self *eventManager) Add(event api.Event) {
+	if self.limit <= 0 {
+		return
+	}
+
+	self.mux.Lock()
+	defer self.mux.Unlock()
+
+	if len(self.events) < self.limit {
+		self.events = append(self.events, event)
+		return
+	}
+
+	self.events[self.next] = event
+	self.next = (self.next + 1) % self.limit
+}
+
+// List implements event manager interface. See EventManager for more information.
+func (self *eventManager) List(namespace, pod string, priority api.Priority) []api.Event {
+	self.mux.RLock()
+	defer self.mux.RUnlock()
+
+	result := make([]api.Event, 0)
+	for i := len(self.events) - 1; i >= 0; i-- {
+		event := self.events[(self.next+i)%len(self.events)]
+		if len(namespace) > 0 && event.Namespace != namespace {
+			continue
+		}
+		if len(pod) > 0 && event.Pod != pod {
+			continue
+		}
+		if len(priority) > 0 && severity(event.Priority) > severity(priority) {
+			continue
+		}
+		result = append(result, event)
+	}
+	return result
+}
+
+// severity returns index of the priority in priorities ordered from the most severe. Unknown priorities are the
+// least severe.
+func severity(priority api.Priority) int {
+	for i, p := range api.Priorities {
+		if strings.EqualFold(string(p), string(priority)) {
+			return i
+		}
+	}
+	// Falco accepts Info as an alias of Informational.
+	if strings.EqualFold(string(priority), "Info") {
+		return severity(api.PriorityInformational)
+	}
+	return len(api.Priorities)
+}
+
+// falcoEvent is an alert in the JSON format used by Falco outputs when json_output is enabled.
+type falcoEvent struct {
+	Time         time.Time              `json:"time"`
+	Rule         string                 `json:"rule"`
+	Priority     string                 `json:"priority"`
+	Output       string                 `json:"output"`
+	Hostname     string                 `json:"hostname"`
+	Tags         []string               `json:"tags"`
+	OutputFields map[string]interface{} `json:"output_fields"`
+}
+
+// parseEvents parses alerts sent by Falco. Falco HTTP output sends a single alert per request, but multiple
+// alerts, one per line, are accepted as well, so that output of Falco file or program output can be forwarded.
+func parseEvents(body []byte) ([]api.Event, error) {
+	result := make([]api.Event, 0)
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	for {
+		raw := falcoEvent{}
+		if err := decoder.Decode(&raw); err == io.EOF {
+			return result, nil
+		} else if err != nil {
+			return nil, err
+		}
+
+		event := api.Event{
+			Time:         raw.Time,
+			Rule:         raw.Rule,
+			Priority:     api.Priority(raw.Priority),
+			Output:       raw.Output,
+			Hostname:     raw.Hostname,
+			Tags:         raw.Tags,
+			OutputFields: raw.OutputFields,
+			Namespace:    getField(raw.OutputFields, namespaceField),
+			Pod:          getField(raw.OutputFields, podField),
+			Container:    getField(raw.OutputFields, containerField),
+		}
+		if event.Time.IsZero() {
+			event.Time = time.Now()
+		}
+		result = append(result, event)
+	}
+}
+
+func getField(fields map[string]interface{}, name string) string {
+	if value, ok := fields[name].(string); ok && value != "<NA>" {
+		return value
+	}
+	return ""
+}
+
+// NewEventManager creates event manager, that keeps at most limit most recent events. Events are accepted only
+// from Falco configured with the token.
+func NewEventManager(token string, limit int) api.EventManager {
+	return &eventManager{token: token, limit: limit, events: make([]api.Event, 0)}
+}
+
+// EventCell is a wrapper of Event, that implements DataCell interface. Events are sorted by time and filtered by
+// rule name.
+type EventCell api.Event
+
+// GetProperty implements DataCell interface. See DataCell for more information.
+func (self EventCell) GetProperty(name dataselect.PropertyName) dataselect.ComparableValue {
+	switch name {
+	case dataselect.NameProperty:
+		return dataselect.StdComparableString(self.Rule)
+	case dataselect.CreationTimestampProperty:
+		return dataselect.StdComparableTime(self.Time)
+	case dataselect.NamespaceProperty:
+		return dataselect.StdComparableString(self.Namespace)
+	case dataselect.StatusProperty:
+		return dataselect.StdComparableString(self.Priority)
+	default:
+		// if name is not supported then just return a constant dummy value, sort will have no effect.
+		return nil
+	}
+}
+
+func toCells(std []api.Event) []dataselect.DataCell {
+	cells := make([]dataselect.DataCell, len(std))
+	for i := range std {
+		cells[i] = EventCell(std[i])
+	}
+	return cells
+}
+
+func fromCells(cells []dataselect.DataCell) []api.Event {
+	std := make([]api.Event, len(cells))
+	for i := range std {
+		std[i] = api.Event(cells[i].(EventCell))
+	}
+	return std
+}
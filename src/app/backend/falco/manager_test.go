@@ -0,0 +1,113 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package falco
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/kubernetes/dashboard/src/app/backend/falco/api"
+)
+
+func TestParseEvents(t *testing.T) {
+	body := `{"output":"Notice A shell was spawned in a container (user=root k8s.ns=shop k8s.pod=web-1)",` +
+		`"priority":"Notice","rule":"Terminal shell in container","time":"2020-02-03T10:00:00.123456789Z",` +
+		`"hostname":"node-1","tags":["container","shell"],"output_fields":{"k8s.ns.name":"shop",` +
+		`"k8s.pod.name":"web-1","container.name":"nginx","proc.cmdline":"bash"}}
+{"output":"Warning Sensitive file opened","priority":"Warning","rule":"Read sensitive file untrusted",` +
+		`"time":"2020-02-03T10:00:01Z","output_fields":{"k8s.ns.name":"<NA>","k8s.pod.name":"<NA>"}}`
+
+	expected := []api.Event{
+		{
+			Time:      time.Date(2020, 2, 3, 10, 0, 0, 123456789, time.UTC),
+			Rule:      "Terminal shell in container",
+			Priority:  api.PriorityNotice,
+			Output:    "Notice A shell was spawned in a container (user=root k8s.ns=shop k8s.pod=web-1)",
+			Hostname:  "node-1",
+			Namespace: "shop",
+			Pod:       "web-1",
+			Container: "nginx",
+			Tags:      []string{"container", "shell"},
+			OutputFields: map[string]interface{}{"k8s.ns.name": "shop", "k8s.pod.name": "web-1",
+				"container.name": "nginx", "proc.cmdline": "bash"},
+		},
+		{
+			Time:         time.Date(2020, 2, 3, 10, 0, 1, 0, time.UTC),
+			Rule:         "Read sensitive file untrusted",
+			Priority:     api.PriorityWarning,
+			Output:       "Warning Sensitive file opened",
+			OutputFields: map[string]interface{}{"k8s.ns.name": "<NA>", "k8s.pod.name": "<NA>"},
+		},
+	}
+
+	actual, err := parseEvents([]byte(body))
+	if err != nil {
+		t.Fatalf("parseEvents() returned error: %s", err)
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("parseEvents() ==\n%#v\nexpected\n%#v", actual, expected)
+	}
+
+	if _, err := parseEvents([]byte("Notice A shell was spawned")); err == nil {
+		t.Error("Expected error for alerts not in JSON format")
+	}
+}
+
+func TestEventManager(t *testing.T) {
+	manager := NewEventManager("secret", 3)
+	if !manager.Enabled() || !manager.Authorize("secret") || manager.Authorize("other") || manager.Authorize("") {
+		t.Error("Expected events to be accepted only with the configured token")
+	}
+	if NewEventManager("", 3).Authorize("") {
+		t.Error("Expected events not to be accepted when token is not configured")
+	}
+
+	events := []api.Event{
+		{Rule: "1", Namespace: "shop", Pod: "web-1", Priority: api.PriorityNotice},
+		{Rule: "2", Namespace: "shop", Pod: "web-2", Priority: api.PriorityCritical},
+		{Rule: "3", Namespace: "tools", Pod: "debug", Priority: api.PriorityWarning},
+		{Rule: "4", Namespace: "shop", Pod: "web-1", Priority: "Info"},
+		{Rule: "5", Namespace: "shop", Pod: "web-1", Priority: api.PriorityError},
+	}
+	for _, event := range events {
+		manager.Add(event)
+	}
+
+	cases := []struct {
+		namespace, pod string
+		priority       api.Priority
+		expected       string
+	}{
+		// The two oldest events were dropped.
+		{"", "", "", "[5 4 3]"},
+		{"shop", "", "", "[5 4]"},
+		{"shop", "web-1", api.PriorityWarning, "[5]"},
+		{"", "", api.PriorityNotice, "[5 3]"},
+		{"default", "", "", "[]"},
+	}
+
+	for _, c := range cases {
+		rules := make([]string, 0)
+		for _, event := range manager.List(c.namespace, c.pod, c.priority) {
+			rules = append(rules, event.Rule)
+		}
+		if actual := fmt.Sprint(rules); actual != c.expected {
+			t.Errorf("List(%q, %q, %q) returned rules %s, expected %s", c.namespace, c.pod, c.priority, actual,
+				c.expected)
+		}
+	}
+}
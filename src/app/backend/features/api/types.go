@@ -0,0 +1,54 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+// FeatureManager is used to check which of the Dashboard features are enabled.
+type FeatureManager interface {
+	// IsEnabled returns true when given feature is enabled.
+	IsEnabled(feature Feature) bool
+	// List returns all known features together with their state.
+	List() FeatureGates
+}
+
+// Feature is a name of the Dashboard capability that can be toggled with feature gates.
+type Feature string
+
+// FeatureGates maps features to true when they are enabled.
+type FeatureGates map[Feature]bool
+
+const (
+	// Exec allows to open shell in containers.
+	Exec Feature = "exec"
+
+	// LogsDownload allows to download whole container logs as a file.
+	LogsDownload Feature = "logsDownload"
+
+	// AppDeployment allows to create and update resources, i.e. from deploy forms, files, git repositories,
+	// kustomizations, charts, bulk actions and the raw resource editor.
+	AppDeployment Feature = "appDeployment"
+
+	// KubeBench allows to start kube-bench jobs, that run with access to the host of the node.
+	KubeBench Feature = "kubeBench"
+)
+
+// GetDefaultFeatureGates returns all known features. All of them except for kube-bench are enabled by default.
+func GetDefaultFeatureGates() FeatureGates {
+	return FeatureGates{
+		Exec:          true,
+		LogsDownload:  true,
+		AppDeployment: true,
+		KubeBench:     false,
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package features
+
+import (
+	"net/http"
+
+	restful "github.com/emicklei/go-restful"
+
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/features/api"
+)
+
+// FeatureHandler manages all endpoints related to feature gates.
+type FeatureHandler struct {
+	manager api.FeatureManager
+}
+
+// Install creates new endpoints for feature gates.
+func (self *FeatureHandler) Install(ws *restful.WebService) {
+	ws.Route(
+		ws.GET("/features").
+			To(self.handleGet).
+			Writes(api.FeatureGates{}))
+}
+
+func (self *FeatureHandler) handleGet(request *restful.Request, response *restful.Response) {
+	response.WriteHeaderAndEntity(http.StatusOK, self.manager.List())
+}
+
+// NewFeatureHandler creates FeatureHandler.
+func NewFeatureHandler(manager api.FeatureManager) FeatureHandler {
+	return FeatureHandler{manager: manager}
+}
+
+// RequireFeature returns route filter that rejects requests when given feature is disabled.
+func RequireFeature(manager api.FeatureManager, feature api.Feature) restful.FilterFunction {
+	return func(request *restful.Request, response *restful.Response, chain *restful.FilterChain) {
+		if manager.IsEnabled(feature) {
+			chain.ProcessFilter(request, response)
+			return
+		}
+
+		errors.HandleInternalError(request, response, errors.NewForbidden(errors.MsgFeatureDisabledError))
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package features
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	restful "github.com/emicklei/go-restful"
+
+	"github.com/kubernetes/dashboard/src/app/backend/features/api"
+)
+
+func TestRequireFeature(t *testing.T) {
+	manager, err := NewFeatureManager("exec=false")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ws := new(restful.WebService)
+	handle := func(request *restful.Request, response *restful.Response) {
+		response.WriteHeader(http.StatusOK)
+	}
+	ws.Route(ws.GET("/shell").To(handle).Filter(RequireFeature(manager, api.Exec)))
+	ws.Route(ws.GET("/log").To(handle).Filter(RequireFeature(manager, api.LogsDownload)))
+	container := restful.NewContainer()
+	container.Add(ws)
+
+	cases := []struct {
+		url      string
+		expected int
+	}{
+		{"/shell", http.StatusForbidden},
+		{"/log", http.StatusOK},
+	}
+
+	for _, c := range cases {
+		recorder := httptest.NewRecorder()
+		container.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, c.url, nil))
+		if recorder.Code != c.expected {
+			t.Errorf("GET %s returned %d, expected %d", c.url, recorder.Code, c.expected)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package features
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/kubernetes/dashboard/src/app/backend/features/api"
+)
+
+// FeatureManager is a structure containing all feature manager members.
+type FeatureManager struct {
+	mux   sync.RWMutex
+	gates api.FeatureGates
+}
+
+// NewFeatureManager creates new feature manager. Gates are a comma separated list of feature=enabled pairs,
+// i.e. exec=true,logsDownload=false. Features that are not listed keep their default state.
+func NewFeatureManager(gates string) (*FeatureManager, error) {
+	result, err := parseFeatureGates(gates)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FeatureManager{gates: result}, nil
+}
+
+// SetGates replaces state of all features with given gates, in the same format as NewFeatureManager accepts.
+// Current state is kept if gates are invalid.
+func (fm *FeatureManager) SetGates(gates string) error {
+	result, err := parseFeatureGates(gates)
+	if err != nil {
+		return err
+	}
+
+	fm.mux.Lock()
+	defer fm.mux.Unlock()
+	fm.gates = result
+	return nil
+}
+
+func parseFeatureGates(gates string) (api.FeatureGates, error) {
+	result := api.GetDefaultFeatureGates()
+	for _, gate := range strings.Split(gates, ",") {
+		gate = strings.TrimSpace(gate)
+		if len(gate) == 0 {
+			continue
+		}
+
+		parts := strings.SplitN(gate, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("missing value for feature gate %s", gate)
+		}
+
+		feature := api.Feature(strings.TrimSpace(parts[0]))
+		if _, ok := result[feature]; !ok {
+			return nil, fmt.Errorf("unknown feature gate %s", feature)
+		}
+
+		enabled, err := strconv.ParseBool(strings.TrimSpace(parts[1]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid value of feature gate %s: %s", feature, err)
+		}
+
+		result[feature] = enabled
+	}
+
+	return result, nil
+}
+
+// IsEnabled implements FeatureManager interface. Check it for more information.
+func (fm *FeatureManager) IsEnabled(feature api.Feature) bool {
+	fm.mux.RLock()
+	defer fm.mux.RUnlock()
+	return fm.gates[feature]
+}
+
+// List implements FeatureManager interface. Check it for more information.
+func (fm *FeatureManager) List() api.FeatureGates {
+	fm.mux.RLock()
+	defer fm.mux.RUnlock()
+	result := make(api.FeatureGates, len(fm.gates))
+	for feature, enabled := range fm.gates {
+		result[feature] = enabled
+	}
+
+	return result
+}
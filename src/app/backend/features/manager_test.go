@@ -0,0 +1,70 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package features
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/kubernetes/dashboard/src/app/backend/features/api"
+)
+
+func TestNewFeatureManager(t *testing.T) {
+	cases := []struct {
+		gates       string
+		expected    api.FeatureGates
+		expectedErr bool
+	}{
+		{"", api.GetDefaultFeatureGates(), false},
+		{
+			"exec=false, logsDownload=true,appDeployment=0",
+			api.FeatureGates{api.Exec: false, api.LogsDownload: true, api.AppDeployment: false, api.KubeBench: false},
+			false,
+		},
+		{"exec", nil, true},
+		{"exec=maybe", nil, true},
+		{"unknown=false", nil, true},
+	}
+
+	for _, c := range cases {
+		manager, err := NewFeatureManager(c.gates)
+		if (err != nil) != c.expectedErr {
+			t.Errorf("NewFeatureManager(%s) returned error %v, expected error: %t", c.gates, err, c.expectedErr)
+			continue
+		}
+
+		if err == nil && !reflect.DeepEqual(manager.List(), c.expected) {
+			t.Errorf("NewFeatureManager(%s).List() == %#v, expected %#v", c.gates, manager.List(), c.expected)
+		}
+	}
+}
+
+func TestFeatureManager_SetGates(t *testing.T) {
+	manager, _ := NewFeatureManager("exec=false")
+
+	if err := manager.SetGates("logsDownload=false"); err != nil {
+		t.Fatalf("SetGates() returned error: %s", err)
+	}
+	if !manager.IsEnabled(api.Exec) || manager.IsEnabled(api.LogsDownload) {
+		t.Errorf("SetGates() expected to replace all gates, got %#v", manager.List())
+	}
+
+	if err := manager.SetGates("unknown=false"); err == nil {
+		t.Error("SetGates() expected error for unknown feature")
+	}
+	if manager.IsEnabled(api.LogsDownload) {
+		t.Errorf("SetGates() expected to keep current gates on error, got %#v", manager.List())
+	}
+}
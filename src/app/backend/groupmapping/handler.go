@@ -0,0 +1,118 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package groupmapping
+
+import (
+	"net/http"
+
+	restful "github.com/emicklei/go-restful"
+
+	clientapi "github.com/kubernetes/dashboard/src/app/backend/client/api"
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/handler/parser"
+)
+
+// GroupMappingHandler manages all endpoints related to mapping of identity provider groups to namespaces.
+type GroupMappingHandler struct {
+	clientManager clientapi.ClientManager
+
+	// checkNamespace returns error when bindings of given namespace must not be listed or changed.
+	checkNamespace func(request *restful.Request, namespace string) error
+}
+
+// Install creates new endpoints for group mappings. Role bindings are managed with the client of the logged in
+// user, so only users allowed to bind the mapped roles can change the mappings.
+func (self *GroupMappingHandler) Install(ws *restful.WebService) {
+	ws.Route(
+		ws.GET("/groupmapping").
+			To(self.handleGetGroupMappingList).
+			Writes(GroupMappingList{}))
+	ws.Route(
+		ws.PUT("/groupmapping").
+			To(self.handleReconcileGroupMapping).
+			Reads(GroupMapping{}).
+			Writes(GroupMapping{}))
+	ws.Route(
+		ws.DELETE("/groupmapping").
+			To(self.handleDeleteGroupMapping))
+}
+
+func (self *GroupMappingHandler) handleGetGroupMappingList(request *restful.Request, response *restful.Response) {
+	client, err := self.clientManager.Client(request)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	dataSelect := parser.ParseDataSelectPathParameter(request)
+	result, err := GetGroupMappingList(client, self.namespaceCheck(request), dataSelect)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (self *GroupMappingHandler) handleReconcileGroupMapping(request *restful.Request, response *restful.Response) {
+	client, err := self.clientManager.Client(request)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	mapping := new(GroupMapping)
+	if err := request.ReadEntity(mapping); err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	result, err := ReconcileGroupMapping(client, mapping, self.namespaceCheck(request))
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+// handleDeleteGroupMapping removes all role bindings of the group given in the group query parameter. Group names
+// can contain slashes, so they are not passed in the path.
+func (self *GroupMappingHandler) handleDeleteGroupMapping(request *restful.Request, response *restful.Response) {
+	client, err := self.clientManager.Client(request)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	mapping := &GroupMapping{Group: request.QueryParameter("group")}
+	if _, err := ReconcileGroupMapping(client, mapping, self.namespaceCheck(request)); err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+	response.WriteHeader(http.StatusOK)
+}
+
+// namespaceCheck returns checkNamespace bound to given request.
+func (self *GroupMappingHandler) namespaceCheck(request *restful.Request) NamespaceCheck {
+	return func(namespace string) error {
+		return self.checkNamespace(request, namespace)
+	}
+}
+
+// NewGroupMappingHandler creates GroupMappingHandler. Namespaces of listed and changed bindings are checked with
+// given function, as they are not covered by namespace restriction filters.
+func NewGroupMappingHandler(clientManager clientapi.ClientManager,
+	checkNamespace func(request *restful.Request, namespace string) error) GroupMappingHandler {
+	return GroupMappingHandler{clientManager: clientManager, checkNamespace: checkNamespace}
+}
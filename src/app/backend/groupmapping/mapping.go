@@ -0,0 +1,313 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package groupmapping
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"regexp"
+	"sort"
+	"strings"
+
+	rbac "k8s.io/api/rbac/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+)
+
+const (
+	// managedByLabel marks role bindings, that are managed through group mapping endpoints. Bindings created
+	// in any other way are never modified.
+	managedByLabel = "dashboard.kubernetes.io/group-mapping"
+
+	// groupHashLabel allows to select bindings of a group. Group names, i.e. LDAP distinguished names, can not be
+	// used as label values, so the group is stored in groupAnnotation instead. Selected bindings are always
+	// matched against the annotation, so that a hash collision can not affect bindings of another group.
+	groupHashLabel  = "dashboard.kubernetes.io/group-hash"
+	groupAnnotation = "dashboard.kubernetes.io/group"
+
+	namePrefix = "dashboard-group-"
+
+	// maxNameLength is the maximum length of the group part of binding names, so that names stay valid.
+	maxNameLength = 40
+
+	// hashLength is the number of hex characters of the group hash used in labels and names.
+	hashLength = 16
+)
+
+// NamespaceCheck returns error when bindings of given namespace must not be listed or changed. Namespaces of
+// bindings are sent in the body, so they are not covered by namespace restriction filters.
+type NamespaceCheck func(namespace string) error
+
+var invalidNameCharacters = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// Binding grants cluster role to a group in a single namespace.
+type Binding struct {
+	Namespace   string `json:"namespace"`
+	ClusterRole string `json:"clusterRole"`
+
+	// Name of the role binding. Set only for existing bindings.
+	Name string `json:"name,omitempty"`
+
+	// False when the role binding was modified outside of Dashboard and no longer grants only the role to the
+	// group. Reconciliation restores it.
+	InSync bool `json:"inSync"`
+}
+
+// GroupMapping maps a group of the identity provider, i.e. LDAP or OIDC group, to namespaces.
+type GroupMapping struct {
+	Group    string    `json:"group"`
+	Bindings []Binding `json:"bindings"`
+}
+
+// GroupMappingList contains mappings of all groups, by default ordered by group name.
+type GroupMappingList struct {
+	ListMeta api.ListMeta   `json:"listMeta"`
+	Items    []GroupMapping `json:"items"`
+
+	// List of non-critical errors, that occurred during resource retrieval.
+	Errors []error `json:"errors"`
+}
+
+// GetGroupMappingList returns mappings of all groups based on role bindings managed by Dashboard. Only bindings of
+// namespaces allowed by the check are returned. Groups are ordered by name, unless the data select query sorts
+// them differently.
+func GetGroupMappingList(client kubernetes.Interface, check NamespaceCheck,
+	dsQuery *dataselect.DataSelectQuery) (*GroupMappingList, error) {
+	log.Print("Getting list of group mappings")
+
+	bindings, err := listBindings(client, labels.Set{managedByLabel: "true"})
+	nonCriticalErrors, criticalError := errors.HandleError(err)
+	if criticalError != nil {
+		return nil, criticalError
+	}
+
+	groups := make(map[string]*GroupMapping)
+	for _, binding := range bindings {
+		if check(binding.Namespace) != nil {
+			continue
+		}
+		group := binding.Annotations[groupAnnotation]
+		if _, exists := groups[group]; !exists {
+			groups[group] = &GroupMapping{Group: group, Bindings: make([]Binding, 0)}
+		}
+		groups[group].Bindings = append(groups[group].Bindings, toBinding(binding, group))
+	}
+
+	items := make([]GroupMapping, 0, len(groups))
+	for _, mapping := range groups {
+		sortBindings(mapping.Bindings)
+		items = append(items, *mapping)
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].Group < items[j].Group })
+
+	cells, filteredTotal := dataselect.GenericDataSelectWithFilter(toCells(items), dsQuery)
+	result := &GroupMappingList{Items: fromCells(cells), Errors: nonCriticalErrors}
+	result.ListMeta.TotalItems = filteredTotal
+	return result, nil
+}
+
+// ReconcileGroupMapping makes role bindings of the group match the mapping. Missing bindings are created,
+// bindings modified outside of Dashboard are restored and bindings of namespaces, that are no longer mapped, are
+// deleted. Empty list of bindings removes the group mapping. Every mapped namespace has to be allowed by the
+// check, and bindings of namespaces that are not allowed are left untouched.
+func ReconcileGroupMapping(client kubernetes.Interface, mapping *GroupMapping, check NamespaceCheck) (*GroupMapping,
+	error) {
+	log.Printf("Reconciling role bindings of group %s", mapping.Group)
+
+	if len(strings.TrimSpace(mapping.Group)) == 0 {
+		return nil, errors.NewBadRequest("group is required")
+	}
+
+	desired := make(map[string]string)
+	for _, binding := range mapping.Bindings {
+		if len(binding.Namespace) == 0 || len(binding.ClusterRole) == 0 {
+			return nil, errors.NewBadRequest("namespace and cluster role are required for every binding")
+		}
+		if err := check(binding.Namespace); err != nil {
+			return nil, err
+		}
+		if role, exists := desired[binding.Namespace]; exists && role != binding.ClusterRole {
+			return nil, errors.NewBadRequest(fmt.Sprintf("namespace %s is mapped to more than one role",
+				binding.Namespace))
+		}
+		desired[binding.Namespace] = binding.ClusterRole
+	}
+
+	existing, err := listBindings(client, labels.Set{managedByLabel: "true", groupHashLabel: hash(mapping.Group)})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &GroupMapping{Group: mapping.Group, Bindings: make([]Binding, 0)}
+	for _, binding := range existing {
+		if binding.Annotations[groupAnnotation] != mapping.Group || check(binding.Namespace) != nil {
+			continue
+		}
+
+		role, wanted := desired[binding.Namespace]
+		switch {
+		case !wanted:
+			log.Printf("Deleting role binding %s/%s of group %s", binding.Namespace, binding.Name, mapping.Group)
+			err = client.RbacV1().RoleBindings(binding.Namespace).Delete(binding.Name, &metaV1.DeleteOptions{})
+		case binding.RoleRef.Name != role:
+			// Role reference of a binding can not be changed, so the binding has to be recreated.
+			err = client.RbacV1().RoleBindings(binding.Namespace).Delete(binding.Name, &metaV1.DeleteOptions{})
+			if err == nil {
+				err = createBinding(client, mapping.Group, binding.Namespace, role)
+			}
+			delete(desired, binding.Namespace)
+		case !isInSync(binding, mapping.Group):
+			log.Printf("Restoring role binding %s/%s of group %s", binding.Namespace, binding.Name, mapping.Group)
+			updated := newRoleBinding(mapping.Group, binding.Namespace, role)
+			updated.ObjectMeta.ResourceVersion = binding.ResourceVersion
+			_, err = client.RbacV1().RoleBindings(binding.Namespace).Update(updated)
+			delete(desired, binding.Namespace)
+		default:
+			delete(desired, binding.Namespace)
+		}
+
+		if err != nil && !errors.IsNotFoundError(err) {
+			return nil, err
+		}
+	}
+
+	for namespace, role := range desired {
+		if err := createBinding(client, mapping.Group, namespace, role); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, binding := range mapping.Bindings {
+		result.Bindings = appendMissing(result.Bindings, Binding{
+			Namespace:   binding.Namespace,
+			ClusterRole: binding.ClusterRole,
+			Name:        bindingName(mapping.Group, binding.ClusterRole),
+			InSync:      true,
+		})
+	}
+	sortBindings(result.Bindings)
+	return result, nil
+}
+
+func listBindings(client kubernetes.Interface, set labels.Set) ([]rbac.RoleBinding, error) {
+	list, err := client.RbacV1().RoleBindings(metaV1.NamespaceAll).List(metaV1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(set).String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return list.Items, nil
+}
+
+func createBinding(client kubernetes.Interface, group, namespace, role string) error {
+	log.Printf("Creating role binding of group %s to cluster role %s in %s namespace", group, role, namespace)
+	_, err := client.RbacV1().RoleBindings(namespace).Create(newRoleBinding(group, namespace, role))
+	return err
+}
+
+func newRoleBinding(group, namespace, role string) *rbac.RoleBinding {
+	return &rbac.RoleBinding{
+		ObjectMeta: metaV1.ObjectMeta{
+			Name:        bindingName(group, role),
+			Namespace:   namespace,
+			Labels:      map[string]string{managedByLabel: "true", groupHashLabel: hash(group)},
+			Annotations: map[string]string{groupAnnotation: group},
+		},
+		Subjects: []rbac.Subject{{Kind: rbac.GroupKind, APIGroup: rbac.GroupName, Name: group}},
+		RoleRef:  rbac.RoleRef{Kind: "ClusterRole", APIGroup: rbac.GroupName, Name: role},
+	}
+}
+
+// bindingName returns a valid name of the role binding of the group. Hash of the group keeps names of groups
+// with the same sanitized name unique.
+func bindingName(group, role string) string {
+	name := invalidNameCharacters.ReplaceAllString(strings.ToLower(group+"-"+role), "-")
+	if len(name) > maxNameLength {
+		name = name[:maxNameLength]
+	}
+	return namePrefix + strings.Trim(name, "-") + "-" + hash(group)
+}
+
+func hash(group string) string {
+	sum := sha256.Sum256([]byte(group))
+	return hex.EncodeToString(sum[:])[:hashLength]
+}
+
+// isInSync returns true when the binding grants its role only to the group.
+func isInSync(binding rbac.RoleBinding, group string) bool {
+	return binding.Annotations[groupAnnotation] == group && binding.RoleRef.Kind == "ClusterRole" &&
+		len(binding.Subjects) == 1 && binding.Subjects[0].Kind == rbac.GroupKind &&
+		binding.Subjects[0].Name == group
+}
+
+func toBinding(binding rbac.RoleBinding, group string) Binding {
+	return Binding{
+		Namespace:   binding.Namespace,
+		ClusterRole: binding.RoleRef.Name,
+		Name:        binding.Name,
+		InSync:      isInSync(binding, group),
+	}
+}
+
+func appendMissing(bindings []Binding, binding Binding) []Binding {
+	for _, b := range bindings {
+		if b.Namespace == binding.Namespace {
+			return bindings
+		}
+	}
+	return append(bindings, binding)
+}
+
+func sortBindings(bindings []Binding) {
+	sort.Slice(bindings, func(i, j int) bool { return bindings[i].Namespace < bindings[j].Namespace })
+}
+
+// GroupMappingCell is a wrapper of GroupMapping, that implements DataCell interface. Mappings are sorted and
+// filtered by group name.
+type GroupMappingCell GroupMapping
+
+// GetProperty implements DataCell interface. See DataCell for more information.
+func (self GroupMappingCell) GetProperty(name dataselect.PropertyName) dataselect.ComparableValue {
+	switch name {
+	case dataselect.NameProperty:
+		return dataselect.StdComparableString(self.Group)
+	default:
+		// if name is not supported then just return a constant dummy value, sort will have no effect.
+		return nil
+	}
+}
+
+func toCells(std []GroupMapping) []dataselect.DataCell {
+	cells := make([]dataselect.DataCell, len(std))
+	for i := range std {
+		cells[i] = GroupMappingCell(std[i])
+	}
+	return cells
+}
+
+func fromCells(cells []dataselect.DataCell) []GroupMapping {
+	std := make([]GroupMapping, len(cells))
+	for i := range std {
+		std[i] = GroupMapping(cells[i].(GroupMappingCell))
+	}
+	return std
+}
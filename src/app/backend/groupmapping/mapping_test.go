@@ -0,0 +1,191 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package groupmapping
+
+import (
+	"reflect"
+	"testing"
+
+	rbac "k8s.io/api/rbac/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+)
+
+const group = "cn=developers,ou=groups,dc=example,dc=com"
+
+func allowAll(namespace string) error {
+	return nil
+}
+
+func TestBindingName(t *testing.T) {
+	name := bindingName(group, "edit")
+	if name != "dashboard-group-cn-developers-ou-groups-dc-example-dc-co-"+hash(group) {
+		t.Errorf("Unexpected binding name %s", name)
+	}
+	if name == bindingName("CN=Developers,OU=Groups,DC=example,DC=com", "edit") {
+		t.Error("Expected different binding names of groups, that differ only in case")
+	}
+}
+
+func TestReconcileGroupMapping(t *testing.T) {
+	unmanaged := &rbac.RoleBinding{ObjectMeta: metaV1.ObjectMeta{Name: "developers", Namespace: "shop"},
+		Subjects: []rbac.Subject{{Kind: rbac.GroupKind, Name: group}},
+		RoleRef:  rbac.RoleRef{Kind: "ClusterRole", Name: "admin"}}
+	client := fake.NewSimpleClientset(unmanaged)
+
+	_, err := ReconcileGroupMapping(client, &GroupMapping{Group: group, Bindings: []Binding{
+		{Namespace: "shop", ClusterRole: "edit"},
+		{Namespace: "tools", ClusterRole: "view"},
+		{Namespace: "staging", ClusterRole: "admin"},
+	}}, allowAll)
+	if err != nil {
+		t.Fatalf("ReconcileGroupMapping() returned error: %s", err)
+	}
+
+	// Subjects of a managed binding are modified by hand.
+	tampered, _ := client.RbacV1().RoleBindings("tools").Get(bindingName(group, "view"), metaV1.GetOptions{})
+	tampered.Subjects = append(tampered.Subjects, rbac.Subject{Kind: rbac.UserKind, Name: "mallory"})
+	client.RbacV1().RoleBindings("tools").Update(tampered)
+
+	list, err := GetGroupMappingList(client, allowAll, dataselect.NoDataSelect)
+	if err != nil {
+		t.Fatalf("GetGroupMappingList() returned error: %s", err)
+	}
+	expected := []GroupMapping{{Group: group, Bindings: []Binding{
+		{Namespace: "shop", ClusterRole: "edit", Name: bindingName(group, "edit"), InSync: true},
+		{Namespace: "staging", ClusterRole: "admin", Name: bindingName(group, "admin"), InSync: true},
+		{Namespace: "tools", ClusterRole: "view", Name: bindingName(group, "view"), InSync: false},
+	}}}
+	if !reflect.DeepEqual(list.Items, expected) {
+		t.Errorf("GetGroupMappingList() ==\n%#v\nexpected\n%#v", list.Items, expected)
+	}
+
+	_, err = ReconcileGroupMapping(client, &GroupMapping{Group: group, Bindings: []Binding{
+		{Namespace: "shop", ClusterRole: "view"},
+		{Namespace: "tools", ClusterRole: "view"},
+	}}, allowAll)
+	if err != nil {
+		t.Fatalf("ReconcileGroupMapping() returned error: %s", err)
+	}
+
+	list, _ = GetGroupMappingList(client, allowAll, dataselect.NoDataSelect)
+	expected = []GroupMapping{{Group: group, Bindings: []Binding{
+		{Namespace: "shop", ClusterRole: "view", Name: bindingName(group, "view"), InSync: true},
+		{Namespace: "tools", ClusterRole: "view", Name: bindingName(group, "view"), InSync: true},
+	}}}
+	if !reflect.DeepEqual(list.Items, expected) {
+		t.Errorf("GetGroupMappingList() ==\n%#v\nexpected\n%#v", list.Items, expected)
+	}
+
+	if _, err := ReconcileGroupMapping(client, &GroupMapping{Group: group}, allowAll); err != nil {
+		t.Fatalf("ReconcileGroupMapping() returned error: %s", err)
+	}
+
+	bindings, _ := client.RbacV1().RoleBindings("").List(metaV1.ListOptions{})
+	if len(bindings.Items) != 1 || bindings.Items[0].Name != unmanaged.Name {
+		t.Errorf("Expected only the unmanaged binding to be left, got %#v", bindings.Items)
+	}
+}
+
+func TestReconcileGroupMappingValidation(t *testing.T) {
+	cases := []*GroupMapping{
+		{Group: " "},
+		{Group: group, Bindings: []Binding{{Namespace: "shop"}}},
+		{Group: group, Bindings: []Binding{{Namespace: "shop", ClusterRole: "view"},
+			{Namespace: "shop", ClusterRole: "edit"}}},
+	}
+
+	for _, c := range cases {
+		if _, err := ReconcileGroupMapping(fake.NewSimpleClientset(), c, allowAll); err == nil {
+			t.Errorf("Expected error for mapping %#v", c)
+		}
+	}
+}
+
+func TestReconcileGroupMappingHashCollision(t *testing.T) {
+	// Binding of another group with the same hash label, i.e. crafted to collide, is never modified.
+	other := newRoleBinding("cn=admins,ou=groups,dc=example,dc=com", "shop", "admin")
+	other.Labels[groupHashLabel] = hash(group)
+	client := fake.NewSimpleClientset(other)
+
+	if _, err := ReconcileGroupMapping(client, &GroupMapping{Group: group}, allowAll); err != nil {
+		t.Fatalf("ReconcileGroupMapping() returned error: %s", err)
+	}
+
+	if _, err := client.RbacV1().RoleBindings("shop").Get(other.Name, metaV1.GetOptions{}); err != nil {
+		t.Errorf("Expected binding of another group to be kept, got %s", err)
+	}
+}
+
+func TestReconcileGroupMappingNamespaceCheck(t *testing.T) {
+	check := func(namespace string) error {
+		if namespace == "kube-system" {
+			return errors.NewForbidden("namespace " + namespace + " is restricted")
+		}
+		return nil
+	}
+	hidden := newRoleBinding(group, "kube-system", "view")
+	client := fake.NewSimpleClientset(hidden)
+
+	_, err := ReconcileGroupMapping(client, &GroupMapping{Group: group, Bindings: []Binding{
+		{Namespace: "shop", ClusterRole: "edit"},
+		{Namespace: "kube-system", ClusterRole: "admin"},
+	}}, check)
+	if !errors.IsForbiddenError(err) {
+		t.Errorf("Expected forbidden error for binding in restricted namespace, got %v", err)
+	}
+
+	if _, err := ReconcileGroupMapping(client, &GroupMapping{Group: group, Bindings: []Binding{
+		{Namespace: "shop", ClusterRole: "edit"},
+	}}, check); err != nil {
+		t.Fatalf("ReconcileGroupMapping() returned error: %s", err)
+	}
+
+	list, _ := GetGroupMappingList(client, check, dataselect.NoDataSelect)
+	expected := []GroupMapping{{Group: group, Bindings: []Binding{
+		{Namespace: "shop", ClusterRole: "edit", Name: bindingName(group, "edit"), InSync: true},
+	}}}
+	if !reflect.DeepEqual(list.Items, expected) {
+		t.Errorf("GetGroupMappingList() ==\n%#v\nexpected\n%#v", list.Items, expected)
+	}
+
+	if _, err := client.RbacV1().RoleBindings("kube-system").Get(hidden.Name, metaV1.GetOptions{}); err != nil {
+		t.Errorf("Expected binding in restricted namespace to be left untouched, got %s", err)
+	}
+}
+
+func TestGetGroupMappingListDataSelect(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	for _, g := range []string{"admins", "developers", "operators"} {
+		mapping := &GroupMapping{Group: g, Bindings: []Binding{{Namespace: "shop", ClusterRole: "view"}}}
+		if _, err := ReconcileGroupMapping(client, mapping, allowAll); err != nil {
+			t.Fatalf("ReconcileGroupMapping() returned error: %s", err)
+		}
+	}
+
+	dsQuery := dataselect.NewDataSelectQuery(dataselect.NewPaginationQuery(1, 0),
+		dataselect.NewSortQuery([]string{"d", dataselect.NameProperty}), dataselect.NoFilter, dataselect.NoMetrics)
+	list, err := GetGroupMappingList(client, allowAll, dsQuery)
+	if err != nil {
+		t.Fatalf("GetGroupMappingList() returned error: %s", err)
+	}
+
+	if len(list.Items) != 1 || list.Items[0].Group != "operators" || list.ListMeta.TotalItems != 3 {
+		t.Errorf("Expected first of 3 groups sorted by name descending, got %#v", list)
+	}
+}
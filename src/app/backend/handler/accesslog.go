@@ -0,0 +1,215 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/emicklei/go-restful"
+
+	clientapi "github.com/kubernetes/dashboard/src/app/backend/client/api"
+)
+
+// AccessLogFormat is a format of access log lines.
+type AccessLogFormat string
+
+const (
+	// AccessLogCommon is the Common Log Format used i.e. by Apache and nginx.
+	AccessLogCommon AccessLogFormat = "common"
+	// AccessLogCombined is the Common Log Format extended with referer and user agent.
+	AccessLogCombined AccessLogFormat = "combined"
+	// AccessLogJSON writes one JSON object per request, including request duration.
+	AccessLogJSON AccessLogFormat = "json"
+
+	// Time layout used by common and combined formats.
+	accessLogTimeLayout = "02/Jan/2006:15:04:05 -0700"
+)
+
+// AccessLogEntry describes a single handled request.
+type AccessLogEntry struct {
+	Time       time.Time `json:"time"`
+	RequestID  string    `json:"requestID,omitempty"`
+	RemoteAddr string    `json:"remoteAddr"`
+	User       string    `json:"user,omitempty"`
+	Method     string    `json:"method"`
+	Path       string    `json:"path"`
+	Proto      string    `json:"proto"`
+	Status     int       `json:"status"`
+	Bytes      int64     `json:"bytes"`
+	DurationMs float64   `json:"durationMs"`
+	Referer    string    `json:"referer,omitempty"`
+	UserAgent  string    `json:"userAgent,omitempty"`
+}
+
+// accessLogHandler writes access log line for every request handled by wrapped handler.
+type accessLogHandler struct {
+	handler http.Handler
+	format  AccessLogFormat
+	user    func(*http.Request) string
+
+	mux sync.Mutex
+	out io.Writer
+}
+
+// CreateAccessLogHandler wraps given handler, so that every request is written to the output in given format.
+// User function resolves name of the user authenticated by the request and may return empty string when it is
+// not known.
+func CreateAccessLogHandler(handler http.Handler, format string, out io.Writer,
+	user func(*http.Request) string) (http.Handler, error) {
+	switch f := AccessLogFormat(strings.ToLower(format)); f {
+	case AccessLogCommon, AccessLogCombined, AccessLogJSON:
+		return &accessLogHandler{handler: handler, format: f, user: user, out: out}, nil
+	default:
+		return nil, fmt.Errorf("unknown access log format %q, should be one of 'common|combined|json'", format)
+	}
+}
+
+// ServeHTTP implements http.Handler.
+func (self *accessLogHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	writer := &accessLogResponseWriter{ResponseWriter: w}
+	self.handler.ServeHTTP(writer, r)
+
+	entry := &AccessLogEntry{
+		Time:       start,
+		RequestID:  RequestID(r),
+		RemoteAddr: r.RemoteAddr,
+		Method:     r.Method,
+		Path:       redactedRequestURI(r.URL),
+		Proto:      r.Proto,
+		Status:     writer.Status(),
+		Bytes:      writer.bytes,
+		DurationMs: float64(time.Since(start)) / float64(time.Millisecond),
+		Referer:    r.Referer(),
+		UserAgent:  r.UserAgent(),
+	}
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		entry.RemoteAddr = host
+	}
+	if self.user != nil {
+		entry.User = self.user(r)
+	}
+
+	self.write(entry)
+}
+
+// KnownUser returns function resolving user of API requests with client manager for the access log and the
+// concurrency limit. Only users already verified by the apiserver are returned, so that no additional token review
+// is sent and the user cannot be spoofed. Other requests, i.e. for static assets, are not authenticated, so they
+// are skipped.
+func KnownUser(manager clientapi.ClientManager) func(*http.Request) string {
+	return func(r *http.Request) string {
+		if !strings.HasPrefix(r.URL.Path, "/api/") {
+			return ""
+		}
+
+		return manager.KnownUsername(restful.NewRequest(r))
+	}
+}
+
+func (self *accessLogHandler) write(entry *AccessLogEntry) {
+	var line string
+	switch self.format {
+	case AccessLogJSON:
+		data, _ := json.Marshal(entry)
+		line = string(data)
+	case AccessLogCombined:
+		line = fmt.Sprintf("%s %q %q", formatCommonLogLine(entry), entry.Referer, entry.UserAgent)
+	default:
+		line = formatCommonLogLine(entry)
+	}
+
+	self.mux.Lock()
+	defer self.mux.Unlock()
+	fmt.Fprintln(self.out, line)
+}
+
+// formatCommonLogLine formats entry in the Common Log Format. Missing values are replaced with a dash.
+func formatCommonLogLine(entry *AccessLogEntry) string {
+	user := entry.User
+	if len(user) == 0 {
+		user = "-"
+	}
+
+	return fmt.Sprintf("%s - %s [%s] \"%s %s %s\" %d %d", entry.RemoteAddr, strings.Replace(user, " ", "_", -1),
+		entry.Time.Format(accessLogTimeLayout), entry.Method, entry.Path, entry.Proto, entry.Status, entry.Bytes)
+}
+
+// accessLogResponseWriter records status code and number of written bytes. It passes through optional
+// interfaces used by streaming and websocket handlers.
+type accessLogResponseWriter struct {
+	http.ResponseWriter
+	status int
+	bytes  int64
+}
+
+// Status returns written status code, 200 when handler did not write it explicitly.
+func (self *accessLogResponseWriter) Status() int {
+	if self.status == 0 {
+		return http.StatusOK
+	}
+	return self.status
+}
+
+// WriteHeader implements http.ResponseWriter.
+func (self *accessLogResponseWriter) WriteHeader(status int) {
+	if self.status == 0 {
+		self.status = status
+	}
+	self.ResponseWriter.WriteHeader(status)
+}
+
+// Write implements http.ResponseWriter.
+func (self *accessLogResponseWriter) Write(data []byte) (int, error) {
+	n, err := self.ResponseWriter.Write(data)
+	self.bytes += int64(n)
+	return n, err
+}
+
+// Flush implements http.Flusher.
+func (self *accessLogResponseWriter) Flush() {
+	if flusher, ok := self.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// CloseNotify implements http.CloseNotifier.
+func (self *accessLogResponseWriter) CloseNotify() <-chan bool {
+	if notifier, ok := self.ResponseWriter.(http.CloseNotifier); ok {
+		return notifier.CloseNotify()
+	}
+	return make(chan bool)
+}
+
+// Hijack implements http.Hijacker. Hijacked connections are logged with 101 status code.
+func (self *accessLogResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := self.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, fmt.Errorf("response writer does not support hijacking")
+	}
+
+	if self.status == 0 {
+		self.status = http.StatusSwitchingProtocols
+	}
+	return hijacker.Hijack()
+}
@@ -0,0 +1,95 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+)
+
+func TestAccessLogHandler(t *testing.T) {
+	wrapped := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte("hello"))
+	})
+	user := func(r *http.Request) string { return r.Header.Get("X-User") }
+
+	cases := []struct {
+		format   string
+		path     string
+		user     string
+		expected string
+	}{
+		{"common", "/config?x=1", "admin",
+			`^192\.0\.2\.1 - admin \[[^\]]+\] "GET /config\?x=1 HTTP/1\.1" 200 5$`},
+		{"COMMON", "/missing", "", `^192\.0\.2\.1 - - \[[^\]]+\] "GET /missing HTTP/1\.1" 404 19$`},
+		{"combined", "/", "", `^192\.0\.2\.1 - - \[[^\]]+\] "GET / HTTP/1\.1" 200 5 "https://example\.com/" "test"$`},
+	}
+
+	for _, c := range cases {
+		out := &bytes.Buffer{}
+		handler, err := CreateAccessLogHandler(wrapped, c.format, out, user)
+		if err != nil {
+			t.Fatalf("CreateAccessLogHandler(%s) returned unexpected error: %s", c.format, err)
+		}
+
+		request := httptest.NewRequest(http.MethodGet, c.path, nil)
+		request.Header.Set("X-User", c.user)
+		request.Header.Set("Referer", "https://example.com/")
+		request.Header.Set("User-Agent", "test")
+		handler.ServeHTTP(httptest.NewRecorder(), request)
+
+		line := bytes.TrimSuffix(out.Bytes(), []byte("\n"))
+		if !regexp.MustCompile(c.expected).Match(line) {
+			t.Errorf("Expected %s access log line to match %s, got %s", c.format, c.expected, line)
+		}
+	}
+}
+
+func TestAccessLogHandlerJSON(t *testing.T) {
+	out := &bytes.Buffer{}
+	handler, err := CreateAccessLogHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte("{}"))
+	}), "json", out, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/api/v1/pod", nil))
+
+	entry := AccessLogEntry{}
+	if err := json.Unmarshal(out.Bytes(), &entry); err != nil {
+		t.Fatalf("Access log line %s is not valid JSON: %s", out.String(), err)
+	}
+
+	if entry.Method != http.MethodPost || entry.Path != "/api/v1/pod" || entry.Status != http.StatusCreated ||
+		entry.Bytes != 2 || entry.DurationMs < 0 || len(entry.User) > 0 {
+		t.Errorf("Unexpected access log entry %#v", entry)
+	}
+}
+
+func TestCreateAccessLogHandlerUnknownFormat(t *testing.T) {
+	if _, err := CreateAccessLogHandler(http.NotFoundHandler(), "xml", &bytes.Buffer{}, nil); err == nil {
+		t.Error("Expected error for unknown access log format")
+	}
+}
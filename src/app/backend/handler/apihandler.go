@@ -15,10 +15,12 @@
 package handler
 
 import (
+	"encoding/json"
 	"log"
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/kubernetes/dashboard/src/app/backend/handler/parser"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/customresourcedefinition/types"
@@ -26,31 +28,57 @@ import (
 	"github.com/kubernetes/dashboard/src/app/backend/plugin"
 
 	"github.com/emicklei/go-restful"
+	"github.com/gorilla/websocket"
 	"golang.org/x/net/xsrftoken"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/remotecommand"
 
 	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/args"
 	"github.com/kubernetes/dashboard/src/app/backend/auth"
 	authApi "github.com/kubernetes/dashboard/src/app/backend/auth/api"
+	"github.com/kubernetes/dashboard/src/app/backend/branding"
+	"github.com/kubernetes/dashboard/src/app/backend/bulk"
+	"github.com/kubernetes/dashboard/src/app/backend/chart"
 	clientapi "github.com/kubernetes/dashboard/src/app/backend/client/api"
 	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/falco"
+	"github.com/kubernetes/dashboard/src/app/backend/features"
+	featuresApi "github.com/kubernetes/dashboard/src/app/backend/features/api"
+	"github.com/kubernetes/dashboard/src/app/backend/groupmapping"
+	"github.com/kubernetes/dashboard/src/app/backend/imagescan"
 	"github.com/kubernetes/dashboard/src/app/backend/integration"
+	"github.com/kubernetes/dashboard/src/app/backend/recording"
+	recordingApi "github.com/kubernetes/dashboard/src/app/backend/recording/api"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/certmanager"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/clusterrole"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/clusterrolebinding"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/configmap"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/container"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/controller"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/controlplane"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/cost"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/cronjob"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/customresourcedefinition"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/daemonset"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/deployment"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/diff"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/event"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/gatekeeper"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/gitops"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/horizontalpodautoscaler"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/ingress"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/istio"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/job"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/knative"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/kubebench"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/logs"
 	ns "github.com/kubernetes/dashboard/src/app/backend/resource/namespace"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/node"
@@ -65,10 +93,15 @@ import (
 	resourceService "github.com/kubernetes/dashboard/src/app/backend/resource/service"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/statefulset"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/storageclass"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/topology"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/velero"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/verticalpodautoscaler"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/workload"
 	"github.com/kubernetes/dashboard/src/app/backend/scaling"
 	"github.com/kubernetes/dashboard/src/app/backend/settings"
 	settingsApi "github.com/kubernetes/dashboard/src/app/backend/settings/api"
 	"github.com/kubernetes/dashboard/src/app/backend/systembanner"
+	"github.com/kubernetes/dashboard/src/app/backend/systeminfo"
 	"github.com/kubernetes/dashboard/src/app/backend/validation"
 )
 
@@ -78,6 +111,10 @@ const (
 
 	// ResponseLogString is a template for response log message.
 	ResponseLogString = "[%s] Outcoming response to %s with %d status code"
+
+	// registryTimeout is a timeout of requests sent to image registries during image pull validation and tag
+	// listing.
+	registryTimeout = 10 * time.Second
 )
 
 // APIHandler is a representation of API handler. Structure contains clientapi, Heapster clientapi and clientapi configuration.
@@ -85,6 +122,8 @@ type APIHandler struct {
 	iManager integration.IntegrationManager
 	cManager clientapi.ClientManager
 	sManager settingsApi.SettingsManager
+	rManager recordingApi.RecordingManager
+	fManager featuresApi.FeatureManager
 }
 
 // TerminalResponse is sent by handleExecShell. The Id is a random session id that binds the original REST request and the SockJS connection.
@@ -96,16 +135,23 @@ type TerminalResponse struct {
 // CreateHTTPAPIHandler creates a new HTTP handler that handles all requests to the API of the backend.
 func CreateHTTPAPIHandler(iManager integration.IntegrationManager, cManager clientapi.ClientManager,
 	authManager authApi.AuthManager, sManager settingsApi.SettingsManager,
-	sbManager systembanner.SystemBannerManager) (
+	sbManager systembanner.SystemBannerManager, fManager featuresApi.FeatureManager) (
 
 	http.Handler, error) {
-	apiHandler := APIHandler{iManager: iManager, cManager: cManager, sManager: sManager}
+	rManager := recording.NewRecordingManager(args.Holder.GetExecRecordingDir())
+	apiHandler := APIHandler{iManager: iManager, cManager: cManager, sManager: sManager, rManager: rManager,
+		fManager: fManager}
 	wsContainer := restful.NewContainer()
 	wsContainer.EnableContentEncoding(true)
 
 	apiV1Ws := new(restful.WebService)
 
 	InstallFilters(apiV1Ws, cManager)
+	apiV1Ws.Filter(auth.CreateCredentialRefreshFilter(authManager))
+	apiV1Ws.Filter(tenantFilter(apiV1Ws))
+	apiV1Ws.Filter(namespaceRestrictionFilter(sManager, cManager))
+	apiV1Ws.Filter(hiddenResourceKindsFilter(sManager, cManager))
+	apiV1Ws.Filter(appDeploymentFilter(fManager))
 
 	apiV1Ws.Path("/api/v1").
 		Consumes(restful.MIME_JSON).
@@ -118,15 +164,45 @@ func CreateHTTPAPIHandler(iManager integration.IntegrationManager, cManager clie
 	pluginHandler := plugin.NewPluginHandler(cManager)
 	pluginHandler.Install(apiV1Ws)
 
-	authHandler := auth.NewAuthHandler(authManager)
+	authHandler := auth.NewAuthHandler(authManager, cManager)
 	authHandler.Install(apiV1Ws)
 
 	settingsHandler := settings.NewSettingsHandler(sManager, cManager)
 	settingsHandler.Install(apiV1Ws)
 
-	systemBannerHandler := systembanner.NewSystemBannerHandler(sbManager)
+	systemBannerHandler := systembanner.NewSystemBannerHandler(sbManager, sManager, cManager)
 	systemBannerHandler.Install(apiV1Ws)
 
+	brandingHandler := branding.NewBrandingHandler(branding.NewBrandingManager(), cManager)
+	brandingHandler.Install(apiV1Ws)
+
+	scanHandler := imagescan.NewScanHandler(imagescan.NewScanManager(), cManager)
+	scanHandler.Install(apiV1Ws)
+
+	falcoHandler := falco.NewEventHandler(
+		falco.NewEventManager(args.Holder.GetFalcoToken(), args.Holder.GetFalcoEventLimit()), cManager)
+	falcoHandler.Install(apiV1Ws)
+
+	recordingHandler := recording.NewRecordingHandler(rManager, cManager)
+	recordingHandler.Install(apiV1Ws)
+
+	groupMappingHandler := groupmapping.NewGroupMappingHandler(cManager, checkNamespace)
+	groupMappingHandler.Install(apiV1Ws)
+
+	featureHandler := features.NewFeatureHandler(fManager)
+	featureHandler.Install(apiV1Ws)
+
+	systemInfoHandler := systeminfo.NewSystemInfoHandler(cManager, iManager, authManager, fManager)
+	systemInfoHandler.Install(apiV1Ws)
+
+	requireExec := features.RequireFeature(fManager, featuresApi.Exec)
+	requireLogsDownload := features.RequireFeature(fManager, featuresApi.LogsDownload)
+	requireKubeBench := features.RequireFeature(fManager, featuresApi.KubeBench)
+
+	chartHandler := chart.NewChartHandler(chart.NewChartManager(args.Holder.GetChartRepositories()), cManager,
+		checkNamespace)
+	chartHandler.Install(apiV1Ws)
+
 	apiV1Ws.Route(
 		apiV1Ws.GET("csrftoken/{action}").
 			To(apiHandler.handleGetCsrfToken).
@@ -137,21 +213,60 @@ func CreateHTTPAPIHandler(iManager integration.IntegrationManager, cManager clie
 			To(apiHandler.handleDeploy).
 			Reads(deployment.AppDeploymentSpec{}).
 			Writes(deployment.AppDeploymentSpec{}))
+	apiV1Ws.Route(
+		apiV1Ws.POST("/appdeployment/preview").
+			To(apiHandler.handlePreviewDeploy).
+			Reads(deployment.AppDeploymentSpec{}).
+			Writes(deployment.AppDeploymentPreview{}))
+	apiV1Ws.Route(
+		apiV1Ws.POST("/appdeployment/withpullsecret").
+			To(apiHandler.handleDeployWithPullSecret).
+			Reads(deployment.AppDeploymentWithPullSecretSpec{}).
+			Writes(deployment.AppDeploymentWithPullSecretSpec{}))
 	apiV1Ws.Route(
 		apiV1Ws.POST("/appdeployment/validate/name").
 			To(apiHandler.handleNameValidity).
 			Reads(validation.AppNameValiditySpec{}).
 			Writes(validation.AppNameValidity{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/appdeployment/loadbalancer").
+			To(apiHandler.handleLoadBalancerSupport).
+			Writes(validation.LoadBalancerSupport{}))
 	apiV1Ws.Route(
 		apiV1Ws.POST("/appdeployment/validate/imagereference").
 			To(apiHandler.handleImageReferenceValidity).
 			Reads(validation.ImageReferenceValiditySpec{}).
 			Writes(validation.ImageReferenceValidity{}))
+	apiV1Ws.Route(
+		apiV1Ws.POST("/appdeployment/validate/imagepull").
+			To(apiHandler.handleImagePullValidity).
+			Reads(validation.ImagePullValiditySpec{}).
+			Writes(validation.ImagePullValidity{}))
+	apiV1Ws.Route(
+		apiV1Ws.POST("/appdeployment/imagetags").
+			To(apiHandler.handleGetImageTags).
+			Reads(validation.ImageTagsSpec{}).
+			Writes(validation.ImageTags{}))
 	apiV1Ws.Route(
 		apiV1Ws.POST("/appdeployment/validate/protocol").
 			To(apiHandler.handleProtocolValidity).
 			Reads(validation.ProtocolValiditySpec{}).
 			Writes(validation.ProtocolValidity{}))
+	apiV1Ws.Route(
+		apiV1Ws.POST("/appdeployment/validate/namespace").
+			To(apiHandler.handleNamespaceValidity).
+			Reads(validation.NamespaceValiditySpec{}).
+			Writes(validation.NamespaceValidity{}))
+	apiV1Ws.Route(
+		apiV1Ws.POST("/appdeployment/validate/spec").
+			To(apiHandler.handleAppDeploymentSpecValidity).
+			Reads(deployment.AppDeploymentSpec{}).
+			Writes(validation.AppDeploymentSpecValidity{}))
+	apiV1Ws.Route(
+		apiV1Ws.POST("/appdeployment/validate/quota").
+			To(apiHandler.handleAppDeploymentQuotaCheck).
+			Reads(deployment.AppDeploymentSpec{}).
+			Writes(validation.QuotaCheck{}))
 	apiV1Ws.Route(
 		apiV1Ws.GET("/appdeployment/protocols").
 			To(apiHandler.handleGetAvailableProtocols).
@@ -163,6 +278,28 @@ func CreateHTTPAPIHandler(iManager integration.IntegrationManager, cManager clie
 			Reads(deployment.AppDeploymentFromFileSpec{}).
 			Writes(deployment.AppDeploymentFromFileResponse{}))
 
+	apiV1Ws.Route(
+		apiV1Ws.POST("/appdeploymentfromgit").
+			To(apiHandler.handleDeployFromGit).
+			Reads(deployment.GitDeploymentSpec{}).
+			Writes(deployment.GitDeploymentResponse{}))
+	apiV1Ws.Route(
+		apiV1Ws.POST("/appdeploymentfromkustomization").
+			To(apiHandler.handleDeployFromKustomization).
+			Reads(deployment.KustomizationSpec{}).
+			Writes(deployment.KustomizationResponse{}))
+	apiV1Ws.Route(
+		apiV1Ws.POST("/appdeploymentfromkustomization/render").
+			To(apiHandler.handleRenderKustomization).
+			Reads(deployment.KustomizationSpec{}).
+			Writes(deployment.KustomizationResponse{}))
+
+	apiV1Ws.Route(
+		apiV1Ws.POST("/bulk").
+			To(apiHandler.handleBulkAction).
+			Reads(bulk.BulkActionSpec{}).
+			Writes(bulk.BulkActionResult{}))
+
 	apiV1Ws.Route(
 		apiV1Ws.GET("/replicationcontroller").
 			To(apiHandler.handleGetReplicationControllerList).
@@ -240,6 +377,7 @@ func CreateHTTPAPIHandler(iManager integration.IntegrationManager, cManager clie
 	apiV1Ws.Route(
 		apiV1Ws.GET("/pod/{namespace}/{pod}/shell/{container}").
 			To(apiHandler.handleExecShell).
+			Filter(requireExec).
 			Writes(TerminalResponse{}))
 	apiV1Ws.Route(
 		apiV1Ws.GET("/pod/{namespace}/{pod}/persistentvolumeclaim").
@@ -325,6 +463,10 @@ func CreateHTTPAPIHandler(iManager integration.IntegrationManager, cManager clie
 		apiV1Ws.GET("/{kind}/{namespace}/{name}/horizontalpodautoscaler").
 			To(apiHandler.handleGetHorizontalPodAutoscalerListForResource).
 			Writes(horizontalpodautoscaler.HorizontalPodAutoscalerList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/{kind}/{namespace}/{name}/placement").
+			To(apiHandler.handleGetPodPlacement).
+			Writes(topology.PodPlacement{}))
 	apiV1Ws.Route(
 		apiV1Ws.GET("/horizontalpodautoscaler/{namespace}/{horizontalpodautoscaler}").
 			To(apiHandler.handleGetHorizontalPodAutoscalerDetail).
@@ -392,6 +534,19 @@ func CreateHTTPAPIHandler(iManager integration.IntegrationManager, cManager clie
 		apiV1Ws.GET("/namespace/{name}/event").
 			To(apiHandler.handleGetNamespaceEvents).
 			Writes(common.EventList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/namespace/{name}/overview").
+			To(apiHandler.handleGetNamespaceOverview).
+			Writes(ns.NamespaceOverview{}))
+
+	apiV1Ws.Route(
+		apiV1Ws.GET("/event/watch").
+			To(apiHandler.handleWatchEvents).
+			Writes(common.Event{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/event/{namespace}/watch").
+			To(apiHandler.handleWatchEvents).
+			Writes(common.Event{}))
 
 	apiV1Ws.Route(
 		apiV1Ws.GET("/secret").
@@ -444,6 +599,10 @@ func CreateHTTPAPIHandler(iManager integration.IntegrationManager, cManager clie
 		apiV1Ws.GET("/service/{namespace}/{service}/pod").
 			To(apiHandler.handleGetServicePods).
 			Writes(pod.PodList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/service/{namespace}/{service}/mesh").
+			To(apiHandler.handleGetServiceMeshRouting).
+			Writes(istio.ServiceMeshRouting{}))
 
 	apiV1Ws.Route(
 		apiV1Ws.GET("/ingress").
@@ -458,6 +617,107 @@ func CreateHTTPAPIHandler(iManager integration.IntegrationManager, cManager clie
 			To(apiHandler.handleGetIngressDetail).
 			Writes(ingress.IngressDetail{}))
 
+	apiV1Ws.Route(
+		apiV1Ws.GET("/certificate").
+			To(apiHandler.handleGetCertificateList).
+			Writes(certmanager.CertificateList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/certificate/{namespace}").
+			To(apiHandler.handleGetCertificateList).
+			Writes(certmanager.CertificateList{}))
+
+	apiV1Ws.Route(
+		apiV1Ws.GET("/velero/backup").
+			To(apiHandler.handleGetBackupList).
+			Writes(velero.BackupList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/velero/backup/{namespace}").
+			To(apiHandler.handleGetBackupList).
+			Writes(velero.BackupList{}))
+	apiV1Ws.Route(
+		apiV1Ws.POST("/velero/backup/{namespace}").
+			To(apiHandler.handleCreateBackup).
+			Reads(velero.BackupSpec{}).
+			Writes(velero.Backup{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/velero/restore").
+			To(apiHandler.handleGetRestoreList).
+			Writes(velero.RestoreList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/velero/restore/{namespace}").
+			To(apiHandler.handleGetRestoreList).
+			Writes(velero.RestoreList{}))
+
+	apiV1Ws.Route(
+		apiV1Ws.GET("/constraint").
+			To(apiHandler.handleGetConstraintList).
+			Writes(gatekeeper.ConstraintList{}))
+
+	apiV1Ws.Route(
+		apiV1Ws.GET("/virtualservice").
+			To(apiHandler.handleGetVirtualServiceList).
+			Writes(istio.MeshObjectList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/virtualservice/{namespace}").
+			To(apiHandler.handleGetVirtualServiceList).
+			Writes(istio.MeshObjectList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/virtualservice/{namespace}/{name}").
+			To(apiHandler.handleGetVirtualServiceDetail).
+			Writes(istio.MeshObjectDetail{}))
+
+	apiV1Ws.Route(
+		apiV1Ws.GET("/destinationrule").
+			To(apiHandler.handleGetDestinationRuleList).
+			Writes(istio.MeshObjectList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/destinationrule/{namespace}").
+			To(apiHandler.handleGetDestinationRuleList).
+			Writes(istio.MeshObjectList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/destinationrule/{namespace}/{name}").
+			To(apiHandler.handleGetDestinationRuleDetail).
+			Writes(istio.MeshObjectDetail{}))
+
+	apiV1Ws.Route(
+		apiV1Ws.GET("/gateway").
+			To(apiHandler.handleGetGatewayList).
+			Writes(istio.MeshObjectList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/gateway/{namespace}").
+			To(apiHandler.handleGetGatewayList).
+			Writes(istio.MeshObjectList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/gateway/{namespace}/{name}").
+			To(apiHandler.handleGetGatewayDetail).
+			Writes(istio.MeshObjectDetail{}))
+
+	apiV1Ws.Route(
+		apiV1Ws.GET("/knativeservice").
+			To(apiHandler.handleGetKnativeServiceList).
+			Writes(knative.ServiceList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/knativeservice/{namespace}").
+			To(apiHandler.handleGetKnativeServiceList).
+			Writes(knative.ServiceList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/knativeservice/{namespace}/{name}").
+			To(apiHandler.handleGetKnativeServiceDetail).
+			Writes(knative.ServiceDetail{}))
+
+	apiV1Ws.Route(
+		apiV1Ws.GET("/revision").
+			To(apiHandler.handleGetRevisionList).
+			Writes(knative.RevisionList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/revision/{namespace}").
+			To(apiHandler.handleGetRevisionList).
+			Writes(knative.RevisionList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/revision/{namespace}/{name}").
+			To(apiHandler.handleGetRevisionDetail).
+			Writes(knative.RevisionDetail{}))
+
 	apiV1Ws.Route(
 		apiV1Ws.GET("/statefulset").
 			To(apiHandler.handleGetStatefulSetList).
@@ -483,6 +743,48 @@ func CreateHTTPAPIHandler(iManager integration.IntegrationManager, cManager clie
 		apiV1Ws.GET("/node").
 			To(apiHandler.handleGetNodeList).
 			Writes(node.NodeList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/nodegroup").
+			To(apiHandler.handleGetNodeGroupOverview).
+			Writes(node.NodeGroupOverview{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/node/heatmap").
+			To(apiHandler.handleGetNodeHeatmap).
+			Writes(node.NodeHeatmap{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/kubebench").
+			To(apiHandler.handleGetKubeBenchRunList).
+			Writes(kubebench.RunList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/kubebench/{namespace}").
+			To(apiHandler.handleGetKubeBenchRunList).
+			Writes(kubebench.RunList{}))
+	apiV1Ws.Route(
+		apiV1Ws.POST("/kubebench/{namespace}").
+			Filter(requireKubeBench).
+			To(apiHandler.handleCreateKubeBenchRun).
+			Reads(kubebench.RunSpec{}).
+			Writes(kubebench.Run{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/cost").
+			To(apiHandler.handleGetCostReport).
+			Writes(cost.CostReport{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/cost/{namespace}").
+			To(apiHandler.handleGetCostReport).
+			Writes(cost.CostReport{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/workloadstatus").
+			To(apiHandler.handleGetWorkloadStatusSummary).
+			Writes(workload.StatusSummary{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/workloadstatus/{namespace}").
+			To(apiHandler.handleGetWorkloadStatusSummary).
+			Writes(workload.StatusSummary{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/controlplane/health").
+			To(apiHandler.handleGetControlPlaneHealth).
+			Writes(controlplane.Health{}))
 	apiV1Ws.Route(
 		apiV1Ws.GET("/node/{name}").
 			To(apiHandler.handleGetNodeDetail).
@@ -501,20 +803,80 @@ func CreateHTTPAPIHandler(iManager integration.IntegrationManager, cManager clie
 			To(apiHandler.handleDeleteResource))
 	apiV1Ws.Route(
 		apiV1Ws.GET("/_raw/{kind}/namespace/{namespace}/name/{name}").
-			To(apiHandler.handleGetResource))
+			To(apiHandler.handleGetResource).
+			Produces(restful.MIME_JSON, MIMEYAML))
 	apiV1Ws.Route(
 		apiV1Ws.PUT("/_raw/{kind}/namespace/{namespace}/name/{name}").
-			To(apiHandler.handlePutResource))
+			To(apiHandler.handlePutResource).
+			Consumes(restful.MIME_JSON, MIMEYAML))
+	apiV1Ws.Route(
+		apiV1Ws.PATCH("/_raw/{kind}/namespace/{namespace}/name/{name}").
+			To(apiHandler.handlePatchResource).
+			Consumes(patchMIMETypes...).
+			Produces(restful.MIME_JSON, MIMEYAML))
 
 	apiV1Ws.Route(
 		apiV1Ws.DELETE("/_raw/{kind}/name/{name}").
 			To(apiHandler.handleDeleteResource))
 	apiV1Ws.Route(
 		apiV1Ws.GET("/_raw/{kind}/name/{name}").
-			To(apiHandler.handleGetResource))
+			To(apiHandler.handleGetResource).
+			Produces(restful.MIME_JSON, MIMEYAML))
 	apiV1Ws.Route(
 		apiV1Ws.PUT("/_raw/{kind}/name/{name}").
-			To(apiHandler.handlePutResource))
+			To(apiHandler.handlePutResource).
+			Consumes(restful.MIME_JSON, MIMEYAML))
+	apiV1Ws.Route(
+		apiV1Ws.PATCH("/_raw/{kind}/name/{name}").
+			To(apiHandler.handlePatchResource).
+			Consumes(patchMIMETypes...).
+			Produces(restful.MIME_JSON, MIMEYAML))
+
+	apiV1Ws.Route(
+		apiV1Ws.GET("/gitops/{kind}/namespace/{namespace}/name/{name}").
+			To(apiHandler.handleGetGitOpsStatus).
+			Writes(gitops.Status{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/gitops/{kind}/name/{name}").
+			To(apiHandler.handleGetGitOpsStatus).
+			Writes(gitops.Status{}))
+
+	apiV1Ws.Route(
+		apiV1Ws.GET("/diff/{kind}/namespace/{namespace}/name/{name}").
+			To(apiHandler.handleGetResourceDiff).
+			Writes(diff.Diff{}))
+	apiV1Ws.Route(
+		apiV1Ws.POST("/diff/{kind}/namespace/{namespace}/name/{name}").
+			To(apiHandler.handleGetResourceDiff).
+			Consumes(restful.MIME_JSON, MIMEYAML).
+			Writes(diff.Diff{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/diff/{kind}/name/{name}").
+			To(apiHandler.handleGetResourceDiff).
+			Writes(diff.Diff{}))
+	apiV1Ws.Route(
+		apiV1Ws.POST("/diff/{kind}/name/{name}").
+			To(apiHandler.handleGetResourceDiff).
+			Consumes(restful.MIME_JSON, MIMEYAML).
+			Writes(diff.Diff{}))
+
+	apiV1Ws.Route(
+		apiV1Ws.GET("/_raw/{kind}/{namespace}/{name}").
+			To(apiHandler.handleGetResource).
+			Produces(restful.MIME_JSON, MIMEYAML))
+	apiV1Ws.Route(
+		apiV1Ws.PUT("/_raw/{kind}/{namespace}/{name}").
+			To(apiHandler.handlePutResource).
+			Consumes(restful.MIME_JSON, MIMEYAML))
+
+	for _, method := range []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch,
+		http.MethodDelete} {
+		apiV1Ws.Route(
+			apiV1Ws.Method(method).Path("/proxy/{subpath:*}").
+				To(apiHandler.handleProxy).
+				Consumes("*/*").
+				Produces("*/*"))
+	}
 
 	apiV1Ws.Route(
 		apiV1Ws.GET("/clusterrole").
@@ -630,9 +992,17 @@ func CreateHTTPAPIHandler(iManager integration.IntegrationManager, cManager clie
 			To(apiHandler.handleLogs).
 			Writes(logs.LogDetails{}))
 
+	apiV1Ws.Route(
+		apiV1Ws.GET("/log/stream/{namespace}/{pod}").
+			To(apiHandler.handleLogStream))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/log/stream/{namespace}/{pod}/{container}").
+			To(apiHandler.handleLogStream))
+
 	apiV1Ws.Route(
 		apiV1Ws.GET("/log/file/{namespace}/{pod}/{container}").
 			To(apiHandler.handleLogFile).
+			Filter(requireLogsDownload).
 			Writes(logs.LogDetails{}))
 
 	return wsContainer, nil
@@ -641,14 +1011,14 @@ func CreateHTTPAPIHandler(iManager integration.IntegrationManager, cManager clie
 func (apiHandler *APIHandler) handleGetClusterRoleList(request *restful.Request, response *restful.Response) {
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
 	dataSelect := parser.ParseDataSelectPathParameter(request)
 	result, err := clusterrole.GetClusterRoleList(k8sClient, dataSelect)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 	response.WriteHeaderAndEntity(http.StatusOK, result)
@@ -657,14 +1027,14 @@ func (apiHandler *APIHandler) handleGetClusterRoleList(request *restful.Request,
 func (apiHandler *APIHandler) handleGetClusterRoleDetail(request *restful.Request, response *restful.Response) {
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
 	name := request.PathParameter("name")
 	result, err := clusterrole.GetClusterRoleDetail(k8sClient, name)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 	response.WriteHeaderAndEntity(http.StatusOK, result)
@@ -673,14 +1043,14 @@ func (apiHandler *APIHandler) handleGetClusterRoleDetail(request *restful.Reques
 func (apiHandler *APIHandler) handleGetClusterRoleBindingList(request *restful.Request, response *restful.Response) {
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
 	dataSelect := parser.ParseDataSelectPathParameter(request)
 	result, err := clusterrolebinding.GetClusterRoleBindingList(k8sClient, dataSelect)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 	response.WriteHeaderAndEntity(http.StatusOK, result)
@@ -689,14 +1059,14 @@ func (apiHandler *APIHandler) handleGetClusterRoleBindingList(request *restful.R
 func (apiHandler *APIHandler) handleGetClusterRoleBindingDetail(request *restful.Request, response *restful.Response) {
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
 	name := request.PathParameter("name")
 	result, err := clusterrolebinding.GetClusterRoleBindingDetail(k8sClient, name)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 	response.WriteHeaderAndEntity(http.StatusOK, result)
@@ -705,7 +1075,7 @@ func (apiHandler *APIHandler) handleGetClusterRoleBindingDetail(request *restful
 func (apiHandler *APIHandler) handleGetRoleList(request *restful.Request, response *restful.Response) {
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
@@ -713,7 +1083,7 @@ func (apiHandler *APIHandler) handleGetRoleList(request *restful.Request, respon
 	dataSelect := parser.ParseDataSelectPathParameter(request)
 	result, err := role.GetRoleList(k8sClient, namespace, dataSelect)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 	response.WriteHeaderAndEntity(http.StatusOK, result)
@@ -722,7 +1092,7 @@ func (apiHandler *APIHandler) handleGetRoleList(request *restful.Request, respon
 func (apiHandler *APIHandler) handleGetRoleDetail(request *restful.Request, response *restful.Response) {
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
@@ -730,7 +1100,7 @@ func (apiHandler *APIHandler) handleGetRoleDetail(request *restful.Request, resp
 	name := request.PathParameter("name")
 	result, err := role.GetRoleDetail(k8sClient, namespace, name)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 	response.WriteHeaderAndEntity(http.StatusOK, result)
@@ -739,7 +1109,7 @@ func (apiHandler *APIHandler) handleGetRoleDetail(request *restful.Request, resp
 func (apiHandler *APIHandler) handleGetRoleBindingList(request *restful.Request, response *restful.Response) {
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
@@ -747,7 +1117,7 @@ func (apiHandler *APIHandler) handleGetRoleBindingList(request *restful.Request,
 	dataSelect := parser.ParseDataSelectPathParameter(request)
 	result, err := rolebinding.GetRoleBindingList(k8sClient, namespace, dataSelect)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 	response.WriteHeaderAndEntity(http.StatusOK, result)
@@ -756,7 +1126,7 @@ func (apiHandler *APIHandler) handleGetRoleBindingList(request *restful.Request,
 func (apiHandler *APIHandler) handleGetRoleBindingDetail(request *restful.Request, response *restful.Response) {
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
@@ -764,7 +1134,7 @@ func (apiHandler *APIHandler) handleGetRoleBindingDetail(request *restful.Reques
 	name := request.PathParameter("name")
 	result, err := rolebinding.GetRoleBindingDetail(k8sClient, namespace, name)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 	response.WriteHeaderAndEntity(http.StatusOK, result)
@@ -779,7 +1149,7 @@ func (apiHandler *APIHandler) handleGetCsrfToken(request *restful.Request, respo
 func (apiHandler *APIHandler) handleGetStatefulSetList(request *restful.Request, response *restful.Response) {
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
@@ -789,7 +1159,7 @@ func (apiHandler *APIHandler) handleGetStatefulSetList(request *restful.Request,
 	result, err := statefulset.GetStatefulSetList(k8sClient, namespace, dataSelect,
 		apiHandler.iManager.Metric().Client())
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 	response.WriteHeaderAndEntity(http.StatusOK, result)
@@ -798,7 +1168,7 @@ func (apiHandler *APIHandler) handleGetStatefulSetList(request *restful.Request,
 func (apiHandler *APIHandler) handleGetStatefulSetDetail(request *restful.Request, response *restful.Response) {
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
@@ -807,16 +1177,19 @@ func (apiHandler *APIHandler) handleGetStatefulSetDetail(request *restful.Reques
 	result, err := statefulset.GetStatefulSetDetail(k8sClient, apiHandler.iManager.Metric().Client(), namespace, name)
 
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
+	result.PolicyViolations, result.Errors = apiHandler.getPolicyViolations(request, "StatefulSet", namespace, name, result.Errors)
+	result.ResourceRecommendations, result.Errors = apiHandler.getResourceRecommendations(request, k8sClient,
+		"StatefulSet", namespace, name, result.Errors)
 	response.WriteHeaderAndEntity(http.StatusOK, result)
 }
 
 func (apiHandler *APIHandler) handleGetStatefulSetPods(request *restful.Request, response *restful.Response) {
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
@@ -826,7 +1199,7 @@ func (apiHandler *APIHandler) handleGetStatefulSetPods(request *restful.Request,
 	dataSelect.MetricQuery = dataselect.StandardMetrics
 	result, err := statefulset.GetStatefulSetPods(k8sClient, apiHandler.iManager.Metric().Client(), dataSelect, name, namespace)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 	response.WriteHeaderAndEntity(http.StatusOK, result)
@@ -835,7 +1208,7 @@ func (apiHandler *APIHandler) handleGetStatefulSetPods(request *restful.Request,
 func (apiHandler *APIHandler) handleGetStatefulSetEvents(request *restful.Request, response *restful.Response) {
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
@@ -844,7 +1217,7 @@ func (apiHandler *APIHandler) handleGetStatefulSetEvents(request *restful.Reques
 	dataSelect := parser.ParseDataSelectPathParameter(request)
 	result, err := event.GetResourceEvents(k8sClient, dataSelect, namespace, name)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 	response.WriteHeaderAndEntity(http.StatusOK, result)
@@ -853,7 +1226,7 @@ func (apiHandler *APIHandler) handleGetStatefulSetEvents(request *restful.Reques
 func (apiHandler *APIHandler) handleGetServiceList(request *restful.Request, response *restful.Response) {
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
@@ -861,7 +1234,7 @@ func (apiHandler *APIHandler) handleGetServiceList(request *restful.Request, res
 	dataSelect := parser.ParseDataSelectPathParameter(request)
 	result, err := resourceService.GetServiceList(k8sClient, namespace, dataSelect)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 	response.WriteHeaderAndEntity(http.StatusOK, result)
@@ -870,7 +1243,7 @@ func (apiHandler *APIHandler) handleGetServiceList(request *restful.Request, res
 func (apiHandler *APIHandler) handleGetServiceDetail(request *restful.Request, response *restful.Response) {
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
@@ -878,16 +1251,21 @@ func (apiHandler *APIHandler) handleGetServiceDetail(request *restful.Request, r
 	name := request.PathParameter("service")
 	result, err := resourceService.GetServiceDetail(k8sClient, namespace, name)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
+	result.PolicyViolations, result.Errors = apiHandler.getPolicyViolations(request, "Service", namespace, name, result.Errors)
+	if trafficClient := apiHandler.iManager.Metric().TrafficClient(); trafficClient != nil {
+		result.Traffic, err = trafficClient.ServiceTraffic(namespace, name)
+		result.Errors = errors.AppendOptionalError(err, result.Errors)
+	}
 	response.WriteHeaderAndEntity(http.StatusOK, result)
 }
 
 func (apiHandler *APIHandler) handleGetServiceEvent(request *restful.Request, response *restful.Response) {
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
@@ -897,7 +1275,7 @@ func (apiHandler *APIHandler) handleGetServiceEvent(request *restful.Request, re
 	dataSelect.MetricQuery = dataselect.StandardMetrics
 	result, err := resourceService.GetServiceEvents(k8sClient, dataSelect, namespace, name)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 	response.WriteHeaderAndEntity(http.StatusOK, result)
@@ -906,24 +1284,158 @@ func (apiHandler *APIHandler) handleGetServiceEvent(request *restful.Request, re
 func (apiHandler *APIHandler) handleGetIngressDetail(request *restful.Request, response *restful.Response) {
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	cfg, err := apiHandler.cManager.Config(request)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	discoveryClient, dynamicClient, err := newDiscoveryAndDynamicClients(cfg)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
 	namespace := request.PathParameter("namespace")
 	name := request.PathParameter("name")
-	result, err := ingress.GetIngressDetail(k8sClient, namespace, name)
+	result, err := ingress.GetIngressDetail(k8sClient, discoveryClient, dynamicClient, namespace, name)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	violations, err := gatekeeper.GetResourceViolations(discoveryClient, dynamicClient, "Ingress", namespace, name)
+	result.PolicyViolations, result.Errors = violations, errors.AppendOptionalError(err, result.Errors)
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetCertificateList(request *restful.Request, response *restful.Response) {
+	cfg, err := apiHandler.cManager.Config(request)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	discoveryClient, dynamicClient, err := newDiscoveryAndDynamicClients(cfg)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	dataSelect := parser.ParseDataSelectPathParameter(request)
+	namespace := parseNamespacePathParameter(request)
+	result, err := certmanager.GetCertificateList(discoveryClient, dynamicClient, namespace, dataSelect)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetConstraintList(request *restful.Request, response *restful.Response) {
+	cfg, err := apiHandler.cManager.Config(request)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	discoveryClient, dynamicClient, err := newDiscoveryAndDynamicClients(cfg)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	dataSelect := parser.ParseDataSelectPathParameter(request)
+	result, err := gatekeeper.GetConstraintList(discoveryClient, dynamicClient, dataSelect)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetBackupList(request *restful.Request, response *restful.Response) {
+	cfg, err := apiHandler.cManager.Config(request)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	discoveryClient, dynamicClient, err := newDiscoveryAndDynamicClients(cfg)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	dataSelect := parser.ParseDataSelectPathParameter(request)
+	namespace := request.PathParameter("namespace")
+	result, err := velero.GetBackupList(discoveryClient, dynamicClient, namespace, dataSelect)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetRestoreList(request *restful.Request, response *restful.Response) {
+	cfg, err := apiHandler.cManager.Config(request)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	discoveryClient, dynamicClient, err := newDiscoveryAndDynamicClients(cfg)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	dataSelect := parser.ParseDataSelectPathParameter(request)
+	namespace := request.PathParameter("namespace")
+	result, err := velero.GetRestoreList(discoveryClient, dynamicClient, namespace, dataSelect)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 	response.WriteHeaderAndEntity(http.StatusOK, result)
 }
 
+func (apiHandler *APIHandler) handleCreateBackup(request *restful.Request, response *restful.Response) {
+	cfg, err := apiHandler.cManager.Config(request)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	discoveryClient, dynamicClient, err := newDiscoveryAndDynamicClients(cfg)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	spec := new(velero.BackupSpec)
+	if err := request.ReadEntity(spec); err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+	spec.Namespace = request.PathParameter("namespace")
+
+	result, err := velero.CreateBackup(discoveryClient, dynamicClient, args.Holder.GetVeleroNamespace(), spec)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusCreated, result)
+}
+
 func (apiHandler *APIHandler) handleGetIngressList(request *restful.Request, response *restful.Response) {
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
@@ -931,233 +1443,921 @@ func (apiHandler *APIHandler) handleGetIngressList(request *restful.Request, res
 	namespace := parseNamespacePathParameter(request)
 	result, err := ingress.GetIngressList(k8sClient, namespace, dataSelect)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 	response.WriteHeaderAndEntity(http.StatusOK, result)
 }
 
-func (apiHandler *APIHandler) handleGetServicePods(request *restful.Request, response *restful.Response) {
-	k8sClient, err := apiHandler.cManager.Client(request)
+func (apiHandler *APIHandler) handleGetVirtualServiceList(request *restful.Request, response *restful.Response) {
+	apiHandler.handleGetMeshObjectList(request, response, api.ResourceKindVirtualService)
+}
+
+func (apiHandler *APIHandler) handleGetVirtualServiceDetail(request *restful.Request, response *restful.Response) {
+	apiHandler.handleGetMeshObjectDetail(request, response, api.ResourceKindVirtualService)
+}
+
+func (apiHandler *APIHandler) handleGetDestinationRuleList(request *restful.Request, response *restful.Response) {
+	apiHandler.handleGetMeshObjectList(request, response, api.ResourceKindDestinationRule)
+}
+
+func (apiHandler *APIHandler) handleGetDestinationRuleDetail(request *restful.Request, response *restful.Response) {
+	apiHandler.handleGetMeshObjectDetail(request, response, api.ResourceKindDestinationRule)
+}
+
+func (apiHandler *APIHandler) handleGetGatewayList(request *restful.Request, response *restful.Response) {
+	apiHandler.handleGetMeshObjectList(request, response, api.ResourceKindGateway)
+}
+
+func (apiHandler *APIHandler) handleGetGatewayDetail(request *restful.Request, response *restful.Response) {
+	apiHandler.handleGetMeshObjectDetail(request, response, api.ResourceKindGateway)
+}
+
+func (apiHandler *APIHandler) handleGetMeshObjectList(request *restful.Request, response *restful.Response,
+	kind api.ResourceKind) {
+	dynamicClient, err := apiHandler.dynamicClient(request)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	dataSelect := parser.ParseDataSelectPathParameter(request)
+	namespace := parseNamespacePathParameter(request)
+	result, err := istio.GetMeshObjectList(dynamicClient, kind, namespace, dataSelect)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetMeshObjectDetail(request *restful.Request, response *restful.Response,
+	kind api.ResourceKind) {
+	dynamicClient, err := apiHandler.dynamicClient(request)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	namespace := request.PathParameter("namespace")
+	name := request.PathParameter("name")
+	result, err := istio.GetMeshObjectDetail(dynamicClient, kind, namespace, name)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetServiceMeshRouting(request *restful.Request, response *restful.Response) {
+	dynamicClient, err := apiHandler.dynamicClient(request)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
 	namespace := request.PathParameter("namespace")
 	name := request.PathParameter("service")
-	dataSelect := parser.ParseDataSelectPathParameter(request)
-	dataSelect.MetricQuery = dataselect.StandardMetrics
-	result, err := resourceService.GetServicePods(k8sClient, apiHandler.iManager.Metric().Client(), namespace, name, dataSelect)
+	result, err := istio.GetServiceMeshRouting(dynamicClient, namespace, name)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 	response.WriteHeaderAndEntity(http.StatusOK, result)
 }
 
-func (apiHandler *APIHandler) handleGetNodeList(request *restful.Request, response *restful.Response) {
-	k8sClient, err := apiHandler.cManager.Client(request)
+func (apiHandler *APIHandler) handleGetKnativeServiceList(request *restful.Request, response *restful.Response) {
+	cfg, err := apiHandler.cManager.Config(request)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	discoveryClient, dynamicClient, err := newDiscoveryAndDynamicClients(cfg)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
 	dataSelect := parser.ParseDataSelectPathParameter(request)
-	dataSelect.MetricQuery = dataselect.StandardMetrics
-	result, err := node.GetNodeList(k8sClient, dataSelect, apiHandler.iManager.Metric().Client())
+	namespace := parseNamespacePathParameter(request)
+	result, err := knative.GetServiceList(discoveryClient, dynamicClient, namespace, dataSelect)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 	response.WriteHeaderAndEntity(http.StatusOK, result)
 }
 
-func (apiHandler *APIHandler) handleGetNodeDetail(request *restful.Request, response *restful.Response) {
-	k8sClient, err := apiHandler.cManager.Client(request)
+func (apiHandler *APIHandler) handleGetKnativeServiceDetail(request *restful.Request, response *restful.Response) {
+	cfg, err := apiHandler.cManager.Config(request)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	discoveryClient, dynamicClient, err := newDiscoveryAndDynamicClients(cfg)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	namespace := request.PathParameter("namespace")
+	name := request.PathParameter("name")
+	result, err := knative.GetServiceDetail(discoveryClient, dynamicClient, namespace, name)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetRevisionList(request *restful.Request, response *restful.Response) {
+	cfg, err := apiHandler.cManager.Config(request)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	discoveryClient, dynamicClient, err := newDiscoveryAndDynamicClients(cfg)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	dataSelect := parser.ParseDataSelectPathParameter(request)
+	namespace := parseNamespacePathParameter(request)
+	result, err := knative.GetRevisionList(discoveryClient, dynamicClient, namespace, dataSelect)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetRevisionDetail(request *restful.Request, response *restful.Response) {
+	cfg, err := apiHandler.cManager.Config(request)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	discoveryClient, dynamicClient, err := newDiscoveryAndDynamicClients(cfg)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	namespace := request.PathParameter("namespace")
+	name := request.PathParameter("name")
+	result, err := knative.GetRevisionDetail(discoveryClient, dynamicClient, namespace, name)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+// newDiscoveryAndDynamicClients creates clients for resources of API groups that are optional, i.e. Knative
+// Serving. Discovery is used to check whether the group is installed and in which version.
+func newDiscoveryAndDynamicClients(cfg *rest.Config) (discovery.DiscoveryInterface, dynamic.Interface, error) {
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	dynamicClient, err := dynamic.NewForConfig(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return discoveryClient, dynamicClient, nil
+}
+
+// dynamicClient creates dynamic client for the user of the request. It is used for resources, that Dashboard
+// has no typed client for, i.e. Istio networking objects.
+func (apiHandler *APIHandler) dynamicClient(request *restful.Request) (dynamic.Interface, error) {
+	cfg, err := apiHandler.cManager.Config(request)
+	if err != nil {
+		return nil, err
+	}
+	return dynamic.NewForConfig(cfg)
+}
+
+// getPolicyViolations returns Gatekeeper audit violations of the resource. Kind is the Kubernetes kind of the
+// resource. Failures are appended to non-critical errors, so that the rest of the detail is still displayed.
+func (apiHandler *APIHandler) getPolicyViolations(request *restful.Request, kind, namespace, name string,
+	nonCriticalErrors []error) ([]gatekeeper.Violation, []error) {
+	cfg, err := apiHandler.cManager.Config(request)
+	if err != nil {
+		return []gatekeeper.Violation{}, errors.AppendOptionalError(err, nonCriticalErrors)
+	}
+
+	discoveryClient, dynamicClient, err := newDiscoveryAndDynamicClients(cfg)
+	if err != nil {
+		return []gatekeeper.Violation{}, errors.AppendOptionalError(err, nonCriticalErrors)
+	}
+
+	violations, err := gatekeeper.GetResourceViolations(discoveryClient, dynamicClient, kind, namespace, name)
+	return violations, errors.AppendOptionalError(err, nonCriticalErrors)
+}
+
+// getResourceRecommendations returns recommendations of the vertical pod autoscaler targeting the workload. Kind
+// is the Kubernetes kind of the workload. Failures are appended to non-critical errors, so that the rest of the
+// detail is still displayed.
+func (apiHandler *APIHandler) getResourceRecommendations(request *restful.Request, k8sClient kubernetes.Interface,
+	kind, namespace, name string, nonCriticalErrors []error) (*verticalpodautoscaler.Recommendations, []error) {
+	cfg, err := apiHandler.cManager.Config(request)
+	if err != nil {
+		return nil, errors.AppendOptionalError(err, nonCriticalErrors)
+	}
+
+	discoveryClient, dynamicClient, err := newDiscoveryAndDynamicClients(cfg)
+	if err != nil {
+		return nil, errors.AppendOptionalError(err, nonCriticalErrors)
+	}
+
+	recommendations, err := verticalpodautoscaler.GetWorkloadRecommendations(k8sClient, discoveryClient,
+		dynamicClient, kind, namespace, name)
+	return recommendations, errors.AppendOptionalError(err, nonCriticalErrors)
+}
+
+func (apiHandler *APIHandler) handleGetServicePods(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	namespace := request.PathParameter("namespace")
+	name := request.PathParameter("service")
+	dataSelect := parser.ParseDataSelectPathParameter(request)
+	dataSelect.MetricQuery = dataselect.StandardMetrics
+	result, err := resourceService.GetServicePods(k8sClient, apiHandler.iManager.Metric().Client(), namespace, name, dataSelect)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetNodeList(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	dataSelect := parser.ParseDataSelectPathParameter(request)
+	dataSelect.MetricQuery = dataselect.StandardMetrics
+	result, err := node.GetNodeList(k8sClient, dataSelect, apiHandler.iManager.Metric().Client())
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetNodeGroupOverview(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	result, err := node.GetNodeGroupOverview(k8sClient)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetNodeHeatmap(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	result, err := node.GetNodeHeatmap(k8sClient, apiHandler.iManager.Metric().Client())
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetKubeBenchRunList(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	dataSelect := parser.ParseDataSelectPathParameter(request)
+	result, err := kubebench.GetRunList(k8sClient, request.PathParameter("namespace"), dataSelect)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleCreateKubeBenchRun(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	spec := new(kubebench.RunSpec)
+	if err := request.ReadEntity(spec); err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	result, err := kubebench.CreateRun(k8sClient, request.PathParameter("namespace"), args.Holder.GetKubeBenchImage(),
+		spec)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusCreated, result)
+}
+
+func (apiHandler *APIHandler) handleGetCostReport(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	pricing := cost.Pricing{
+		CPUCoreMonth:   args.Holder.GetCostCPUCoreMonth(),
+		MemoryGiBMonth: args.Holder.GetCostMemoryGiBMonth(),
+		Currency:       args.Holder.GetCostCurrency(),
+	}
+	result, err := cost.GetCostReport(k8sClient, apiHandler.iManager.Metric().Client(), pricing,
+		request.PathParameter("namespace"))
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetWorkloadStatusSummary(request *restful.Request,
+	response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	s := apiHandler.sManager.GetGlobalSettings(apiHandler.cManager.InsecureClient())
+	result, err := workload.GetStatusSummary(k8sClient, parseNamespacePathParameter(request), s.IsResourceKindHidden)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetControlPlaneHealth(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	result, err := controlplane.GetHealth(k8sClient)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetNodeDetail(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	name := request.PathParameter("name")
+	dataSelect := parser.ParseDataSelectPathParameter(request)
+	dataSelect.MetricQuery = dataselect.StandardMetrics
+	nsQuery := restrictNamespaceQuery(request, common.NewNamespaceQuery(nil))
+	result, err := node.GetNodeDetail(k8sClient, apiHandler.iManager.Metric().Client(), name, nsQuery, dataSelect)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetNodeEvents(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	name := request.PathParameter("name")
+	dataSelect := parser.ParseDataSelectPathParameter(request)
+	dataSelect.MetricQuery = dataselect.StandardMetrics
+	result, err := event.GetNodeEvents(k8sClient, dataSelect, name)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetNodePods(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	name := request.PathParameter("name")
+	dataSelect := parser.ParseDataSelectPathParameter(request)
+	dataSelect.MetricQuery = dataselect.StandardMetrics
+	nsQuery := restrictNamespaceQuery(request, common.NewNamespaceQuery(nil))
+	result, err := node.GetNodePods(k8sClient, apiHandler.iManager.Metric().Client(), nsQuery, dataSelect, name)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleDeploy(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	appDeploymentSpec := new(deployment.AppDeploymentSpec)
+	if err := request.ReadEntity(appDeploymentSpec); err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+	if err := checkNamespace(request, appDeploymentSpec.Namespace); err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+	if err := validation.ValidateAppDeploymentImages(appDeploymentSpec); err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+	if err := validation.ValidateAppDeploymentPorts(appDeploymentSpec); err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+	if err := validation.ValidateAppDeploymentQuotas(appDeploymentSpec, k8sClient); err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+	if err := validation.EnsureAppDeploymentNamespace(appDeploymentSpec, k8sClient); err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+	if err := deployment.DeployApp(appDeploymentSpec, k8sClient); err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusCreated, appDeploymentSpec)
+}
+
+func (apiHandler *APIHandler) handlePreviewDeploy(request *restful.Request, response *restful.Response) {
+	appDeploymentSpec := new(deployment.AppDeploymentSpec)
+	if err := request.ReadEntity(appDeploymentSpec); err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+	if err := checkNamespace(request, appDeploymentSpec.Namespace); err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	result, err := deployment.PreviewApp(appDeploymentSpec)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleDeployWithPullSecret(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	spec := new(deployment.AppDeploymentWithPullSecretSpec)
+	if err := request.ReadEntity(spec); err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+	if err := checkNamespace(request, spec.Deployment.Namespace); err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+	if err := validation.ValidateAppDeploymentImages(&spec.Deployment); err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+	if err := validation.ValidateAppDeploymentPorts(&spec.Deployment); err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+	if err := validation.ValidateAppDeploymentQuotas(&spec.Deployment, k8sClient); err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+	if err := validation.EnsureAppDeploymentNamespace(&spec.Deployment, k8sClient); err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+	if err := deployment.DeployAppWithPullSecret(spec, k8sClient); err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	// Credentials are never sent back to the client.
+	spec.PullSecret.Password = ""
+	response.WriteHeaderAndEntity(http.StatusCreated, spec)
+}
+
+func (apiHandler *APIHandler) handleScaleResource(request *restful.Request, response *restful.Response) {
+	cfg, err := apiHandler.cManager.Config(request)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	namespace := request.PathParameter("namespace")
+	kind := request.PathParameter("kind")
+	name := request.PathParameter("name")
+	count := request.QueryParameter("scaleBy")
+	dryRun := request.QueryParameter("dryRun") == "true"
+	replicaCountSpec, err := scaling.ScaleResource(cfg, kind, namespace, name, count, dryRun)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, replicaCountSpec)
+}
+
+func (apiHandler *APIHandler) handleGetReplicaCount(request *restful.Request, response *restful.Response) {
+	cfg, err := apiHandler.cManager.Config(request)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	namespace := request.PathParameter("namespace")
+	kind := request.PathParameter("kind")
+	name := request.PathParameter("name")
+	replicaCounts, err := scaling.GetReplicaCounts(cfg, kind, namespace, name)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, replicaCounts)
+}
+
+func (apiHandler *APIHandler) handleBulkAction(request *restful.Request, response *restful.Response) {
+	spec := new(bulk.BulkActionSpec)
+	if err := request.ReadEntity(spec); err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+	s := apiHandler.sManager.GetGlobalSettings(apiHandler.cManager.InsecureClient())
+	for _, target := range spec.Targets {
+		if s.IsResourceKindHidden(target.Kind) {
+			errors.HandleInternalError(request, response, errors.NewForbidden(errors.MsgResourceKindHiddenError))
+			return
+		}
+		if err := checkNamespace(request, target.Namespace); err != nil {
+			errors.HandleInternalError(request, response, err)
+			return
+		}
+	}
+
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	cfg, err := apiHandler.cManager.Config(request)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	verber, err := apiHandler.cManager.VerberClient(request, cfg)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	result, err := bulk.ExecuteBulkAction(k8sClient, verber, cfg, spec)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleDeployFromFile(request *restful.Request, response *restful.Response) {
+	cfg, err := apiHandler.cManager.Config(request)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	deploymentSpec := new(deployment.AppDeploymentFromFileSpec)
+	if err := request.ReadEntity(deploymentSpec); err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	if err := deployment.LoadManifestContent(deploymentSpec, args.Holder.GetManifestURLAllowedHosts()); err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	if deploymentSpec.Validate {
+		validity, err := deployment.ValidateManifests(cfg, deploymentSpec.Content)
+		if err != nil {
+			errors.HandleInternalError(request, response, err)
+			return
+		}
+		if !validity.Valid {
+			response.WriteHeaderAndEntity(http.StatusUnprocessableEntity, validity)
+			return
+		}
+	}
+
+	result, err := deployment.DeployAppFromFile(cfg, deploymentSpec, namespaceCheck(request))
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	response.WriteHeaderAndEntity(http.StatusCreated, result)
+}
+
+func (apiHandler *APIHandler) handleDeployFromKustomization(request *restful.Request, response *restful.Response) {
+	cfg, err := apiHandler.cManager.Config(request)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	spec := new(deployment.KustomizationSpec)
+	if err := request.ReadEntity(spec); err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	result, err := deployment.DeployKustomization(cfg, spec, namespaceCheck(request))
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusCreated, result)
+}
+
+func (apiHandler *APIHandler) handleDeployFromGit(request *restful.Request, response *restful.Response) {
+	cfg, err := apiHandler.cManager.Config(request)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	spec := new(deployment.GitDeploymentSpec)
+	if err := request.ReadEntity(spec); err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	result, err := deployment.DeployFromGit(cfg, spec, namespaceCheck(request))
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusCreated, result)
+}
+
+func (apiHandler *APIHandler) handleRenderKustomization(request *restful.Request, response *restful.Response) {
+	spec := new(deployment.KustomizationSpec)
+	if err := request.ReadEntity(spec); err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	content, err := deployment.RenderKustomization(spec)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, deployment.KustomizationResponse{Content: content})
+}
+
+func (apiHandler *APIHandler) handleNameValidity(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	spec := new(validation.AppNameValiditySpec)
+	if err := request.ReadEntity(spec); err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+	if err := checkNamespace(request, spec.Namespace); err != nil {
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
-	name := request.PathParameter("name")
-	dataSelect := parser.ParseDataSelectPathParameter(request)
-	dataSelect.MetricQuery = dataselect.StandardMetrics
-	result, err := node.GetNodeDetail(k8sClient, apiHandler.iManager.Metric().Client(), name, dataSelect)
+	validity, err := validation.ValidateAppName(spec, k8sClient)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
-	response.WriteHeaderAndEntity(http.StatusOK, result)
+
+	response.WriteHeaderAndEntity(http.StatusOK, validity)
 }
 
-func (apiHandler *APIHandler) handleGetNodeEvents(request *restful.Request, response *restful.Response) {
+func (apiHandler *APIHandler) handleLoadBalancerSupport(request *restful.Request, response *restful.Response) {
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
-	name := request.PathParameter("name")
-	dataSelect := parser.ParseDataSelectPathParameter(request)
-	dataSelect.MetricQuery = dataselect.StandardMetrics
-	result, err := event.GetNodeEvents(k8sClient, dataSelect, name)
+	support, err := validation.CheckLoadBalancerSupport(k8sClient)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
-	response.WriteHeaderAndEntity(http.StatusOK, result)
+
+	response.WriteHeaderAndEntity(http.StatusOK, support)
 }
 
-func (apiHandler *APIHandler) handleGetNodePods(request *restful.Request, response *restful.Response) {
-	k8sClient, err := apiHandler.cManager.Client(request)
-	if err != nil {
-		errors.HandleInternalError(response, err)
+func (apiHandler *APIHandler) handleImageReferenceValidity(request *restful.Request, response *restful.Response) {
+	spec := new(validation.ImageReferenceValiditySpec)
+	if err := request.ReadEntity(spec); err != nil {
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
-	name := request.PathParameter("name")
-	dataSelect := parser.ParseDataSelectPathParameter(request)
-	dataSelect.MetricQuery = dataselect.StandardMetrics
-	result, err := node.GetNodePods(k8sClient, apiHandler.iManager.Metric().Client(), dataSelect, name)
+	validity, err := validation.ValidateImageReference(spec)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
-	response.WriteHeaderAndEntity(http.StatusOK, result)
+	response.WriteHeaderAndEntity(http.StatusOK, validity)
 }
 
-func (apiHandler *APIHandler) handleDeploy(request *restful.Request, response *restful.Response) {
+func (apiHandler *APIHandler) handleImagePullValidity(request *restful.Request, response *restful.Response) {
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
-	appDeploymentSpec := new(deployment.AppDeploymentSpec)
-	if err := request.ReadEntity(appDeploymentSpec); err != nil {
-		errors.HandleInternalError(response, err)
+	spec := new(validation.ImagePullValiditySpec)
+	if err := request.ReadEntity(spec); err != nil {
+		errors.HandleInternalError(request, response, err)
 		return
 	}
-	if err := deployment.DeployApp(appDeploymentSpec, k8sClient); err != nil {
-		errors.HandleInternalError(response, err)
+	if err := checkNamespace(request, spec.Namespace); err != nil {
+		errors.HandleInternalError(request, response, err)
 		return
 	}
-	response.WriteHeaderAndEntity(http.StatusCreated, appDeploymentSpec)
-}
 
-func (apiHandler *APIHandler) handleScaleResource(request *restful.Request, response *restful.Response) {
-	cfg, err := apiHandler.cManager.Config(request)
+	validity, err := validation.ValidateImagePull(spec, k8sClient, &http.Client{Timeout: registryTimeout})
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
+	response.WriteHeaderAndEntity(http.StatusOK, validity)
+}
 
-	namespace := request.PathParameter("namespace")
-	kind := request.PathParameter("kind")
-	name := request.PathParameter("name")
-	count := request.QueryParameter("scaleBy")
-	replicaCountSpec, err := scaling.ScaleResource(cfg, kind, namespace, name, count)
+func (apiHandler *APIHandler) handleGetImageTags(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
-	response.WriteHeaderAndEntity(http.StatusOK, replicaCountSpec)
-}
 
-func (apiHandler *APIHandler) handleGetReplicaCount(request *restful.Request, response *restful.Response) {
-	cfg, err := apiHandler.cManager.Config(request)
-	if err != nil {
-		errors.HandleInternalError(response, err)
+	spec := new(validation.ImageTagsSpec)
+	if err := request.ReadEntity(spec); err != nil {
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
-	namespace := request.PathParameter("namespace")
-	kind := request.PathParameter("kind")
-	name := request.PathParameter("name")
-	replicaCounts, err := scaling.GetReplicaCounts(cfg, kind, namespace, name)
+	tags, err := validation.GetImageTags(spec, k8sClient, &http.Client{Timeout: registryTimeout})
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
-	response.WriteHeaderAndEntity(http.StatusOK, replicaCounts)
+	response.WriteHeaderAndEntity(http.StatusOK, tags)
 }
 
-func (apiHandler *APIHandler) handleDeployFromFile(request *restful.Request, response *restful.Response) {
-	cfg, err := apiHandler.cManager.Config(request)
-	if err != nil {
-		errors.HandleInternalError(response, err)
+func (apiHandler *APIHandler) handleProtocolValidity(request *restful.Request, response *restful.Response) {
+	spec := new(validation.ProtocolValiditySpec)
+	if err := request.ReadEntity(spec); err != nil {
+		errors.HandleInternalError(request, response, err)
 		return
 	}
+	response.WriteHeaderAndEntity(http.StatusOK, validation.ValidateProtocol(spec))
+}
 
-	deploymentSpec := new(deployment.AppDeploymentFromFileSpec)
-	if err := request.ReadEntity(deploymentSpec); err != nil {
-		errors.HandleInternalError(response, err)
+func (apiHandler *APIHandler) handleNamespaceValidity(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
-	isDeployed, err := deployment.DeployAppFromFile(cfg, deploymentSpec)
-	if !isDeployed {
-		errors.HandleInternalError(response, err)
+	spec := new(validation.NamespaceValiditySpec)
+	if err := request.ReadEntity(spec); err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+	if err := checkNamespace(request, spec.Name); err != nil {
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
-	errorMessage := ""
+	validity, err := validation.ValidateNamespace(spec, k8sClient)
 	if err != nil {
-		errorMessage = err.Error()
+		errors.HandleInternalError(request, response, err)
+		return
 	}
-
-	response.WriteHeaderAndEntity(http.StatusCreated, deployment.AppDeploymentFromFileResponse{
-		Name:    deploymentSpec.Name,
-		Content: deploymentSpec.Content,
-		Error:   errorMessage,
-	})
+	response.WriteHeaderAndEntity(http.StatusOK, validity)
 }
 
-func (apiHandler *APIHandler) handleNameValidity(request *restful.Request, response *restful.Response) {
+func (apiHandler *APIHandler) handleAppDeploymentSpecValidity(request *restful.Request, response *restful.Response) {
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
-	spec := new(validation.AppNameValiditySpec)
+	spec := new(deployment.AppDeploymentSpec)
 	if err := request.ReadEntity(spec); err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+	if err := checkNamespace(request, spec.Namespace); err != nil {
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
-	validity, err := validation.ValidateAppName(spec, k8sClient)
+	validity, err := validation.ValidateAppDeploymentSpec(spec, k8sClient)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
-
 	response.WriteHeaderAndEntity(http.StatusOK, validity)
 }
 
-func (apiHandler *APIHandler) handleImageReferenceValidity(request *restful.Request, response *restful.Response) {
-	spec := new(validation.ImageReferenceValiditySpec)
-	if err := request.ReadEntity(spec); err != nil {
-		errors.HandleInternalError(response, err)
+func (apiHandler *APIHandler) handleAppDeploymentQuotaCheck(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
-	validity, err := validation.ValidateImageReference(spec)
-	if err != nil {
-		errors.HandleInternalError(response, err)
+	spec := new(deployment.AppDeploymentSpec)
+	if err := request.ReadEntity(spec); err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+	if err := checkNamespace(request, spec.Namespace); err != nil {
+		errors.HandleInternalError(request, response, err)
 		return
 	}
-	response.WriteHeaderAndEntity(http.StatusOK, validity)
-}
 
-func (apiHandler *APIHandler) handleProtocolValidity(request *restful.Request, response *restful.Response) {
-	spec := new(validation.ProtocolValiditySpec)
-	if err := request.ReadEntity(spec); err != nil {
-		errors.HandleInternalError(response, err)
+	result, err := validation.CheckAppDeploymentQuotas(spec, k8sClient)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
 		return
 	}
-	response.WriteHeaderAndEntity(http.StatusOK, validation.ValidateProtocol(spec))
+	response.WriteHeaderAndEntity(http.StatusOK, result)
 }
 
 func (apiHandler *APIHandler) handleGetAvailableProtocols(request *restful.Request, response *restful.Response) {
@@ -1167,7 +2367,7 @@ func (apiHandler *APIHandler) handleGetAvailableProtocols(request *restful.Reque
 func (apiHandler *APIHandler) handleGetReplicationControllerList(request *restful.Request, response *restful.Response) {
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
@@ -1176,7 +2376,7 @@ func (apiHandler *APIHandler) handleGetReplicationControllerList(request *restfu
 	dataSelect.MetricQuery = dataselect.StandardMetrics
 	result, err := replicationcontroller.GetReplicationControllerList(k8sClient, namespace, dataSelect, apiHandler.iManager.Metric().Client())
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 	response.WriteHeaderAndEntity(http.StatusOK, result)
@@ -1185,7 +2385,7 @@ func (apiHandler *APIHandler) handleGetReplicationControllerList(request *restfu
 func (apiHandler *APIHandler) handleGetReplicaSets(request *restful.Request, response *restful.Response) {
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
@@ -1194,7 +2394,7 @@ func (apiHandler *APIHandler) handleGetReplicaSets(request *restful.Request, res
 	dataSelect.MetricQuery = dataselect.StandardMetrics
 	result, err := replicaset.GetReplicaSetList(k8sClient, namespace, dataSelect, apiHandler.iManager.Metric().Client())
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 	response.WriteHeaderAndEntity(http.StatusOK, result)
@@ -1203,7 +2403,7 @@ func (apiHandler *APIHandler) handleGetReplicaSets(request *restful.Request, res
 func (apiHandler *APIHandler) handleGetReplicaSetDetail(request *restful.Request, response *restful.Response) {
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
@@ -1212,7 +2412,7 @@ func (apiHandler *APIHandler) handleGetReplicaSetDetail(request *restful.Request
 	result, err := replicaset.GetReplicaSetDetail(k8sClient, apiHandler.iManager.Metric().Client(), namespace, replicaSet)
 
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
@@ -1222,7 +2422,7 @@ func (apiHandler *APIHandler) handleGetReplicaSetDetail(request *restful.Request
 func (apiHandler *APIHandler) handleGetReplicaSetPods(request *restful.Request, response *restful.Response) {
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
@@ -1232,7 +2432,7 @@ func (apiHandler *APIHandler) handleGetReplicaSetPods(request *restful.Request,
 	dataSelect.MetricQuery = dataselect.StandardMetrics
 	result, err := replicaset.GetReplicaSetPods(k8sClient, apiHandler.iManager.Metric().Client(), dataSelect, replicaSet, namespace)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
@@ -1242,7 +2442,7 @@ func (apiHandler *APIHandler) handleGetReplicaSetPods(request *restful.Request,
 func (apiHandler *APIHandler) handleGetReplicaSetServices(request *restful.Request, response *restful.Response) {
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
@@ -1252,7 +2452,7 @@ func (apiHandler *APIHandler) handleGetReplicaSetServices(request *restful.Reque
 	dataSelect.MetricQuery = dataselect.StandardMetrics
 	result, err := replicaset.GetReplicaSetServices(k8sClient, dataSelect, namespace, replicaSet)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
@@ -1262,7 +2462,7 @@ func (apiHandler *APIHandler) handleGetReplicaSetServices(request *restful.Reque
 func (apiHandler *APIHandler) handleGetReplicaSetEvents(request *restful.Request, response *restful.Response) {
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
@@ -1272,7 +2472,7 @@ func (apiHandler *APIHandler) handleGetReplicaSetEvents(request *restful.Request
 	dataSelect.MetricQuery = dataselect.StandardMetrics
 	result, err := event.GetResourceEvents(k8sClient, dataSelect, namespace, name)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 	response.WriteHeaderAndEntity(http.StatusOK, result)
@@ -1282,7 +2482,7 @@ func (apiHandler *APIHandler) handleGetReplicaSetEvents(request *restful.Request
 func (apiHandler *APIHandler) handleGetPodEvents(request *restful.Request, response *restful.Response) {
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
@@ -1293,29 +2493,41 @@ func (apiHandler *APIHandler) handleGetPodEvents(request *restful.Request, respo
 	dataSelect.MetricQuery = dataselect.StandardMetrics
 	result, err := pod.GetEventsForPod(k8sClient, dataSelect, namespace, name)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 	response.WriteHeaderAndEntity(http.StatusOK, result)
 }
 
-// Handles execute shell API call
+// Handles execute shell API call. WebSocket upgrade requests are served directly, other requests get ID of a
+// session, that is bound to SockJS connection afterwards.
 func (apiHandler *APIHandler) handleExecShell(request *restful.Request, response *restful.Response) {
+	if websocket.IsWebSocketUpgrade(request.Request) {
+		apiHandler.handleExecShellWebSocket(request, response)
+		return
+	}
+
 	sessionID, err := genTerminalSessionId()
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
 	cfg, err := apiHandler.cManager.Config(request)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	recorder, err := apiHandler.startRecording(request, sessionID)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
@@ -1323,15 +2535,40 @@ func (apiHandler *APIHandler) handleExecShell(request *restful.Request, response
 		id:       sessionID,
 		bound:    make(chan error),
 		sizeChan: make(chan remotecommand.TerminalSize),
+		recorder: recorder,
 	})
 	go WaitForTerminal(k8sClient, cfg, request, sessionID)
 	response.WriteHeaderAndEntity(http.StatusOK, TerminalResponse{ID: sessionID})
 }
 
+// startRecording starts recording of the exec session. Sessions, that can not be recorded while recording is
+// enabled, must not be opened.
+func (apiHandler *APIHandler) startRecording(request *restful.Request, sessionID string) (recordingApi.Recorder,
+	error) {
+	user := ""
+	if apiHandler.rManager.Enabled() {
+		var err error
+		if user, err = apiHandler.cManager.Username(request); err != nil {
+			log.Printf("Cannot get user of exec session %s, refusing the session: %s", sessionID, err)
+			return nil, err
+		}
+	}
+
+	return apiHandler.rManager.Start(recordingApi.Session{
+		ID:        sessionID,
+		User:      user,
+		Namespace: request.PathParameter("namespace"),
+		Pod:       request.PathParameter("pod"),
+		Container: request.PathParameter("container"),
+		Shell:     request.QueryParameter("shell"),
+		StartedAt: time.Now(),
+	})
+}
+
 func (apiHandler *APIHandler) handleGetDeployments(request *restful.Request, response *restful.Response) {
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
@@ -1340,7 +2577,7 @@ func (apiHandler *APIHandler) handleGetDeployments(request *restful.Request, res
 	dataSelect.MetricQuery = dataselect.StandardMetrics
 	result, err := deployment.GetDeploymentList(k8sClient, namespace, dataSelect, apiHandler.iManager.Metric().Client())
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 	response.WriteHeaderAndEntity(http.StatusOK, result)
@@ -1349,7 +2586,7 @@ func (apiHandler *APIHandler) handleGetDeployments(request *restful.Request, res
 func (apiHandler *APIHandler) handleGetDeploymentDetail(request *restful.Request, response *restful.Response) {
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
@@ -1357,9 +2594,12 @@ func (apiHandler *APIHandler) handleGetDeploymentDetail(request *restful.Request
 	name := request.PathParameter("deployment")
 	result, err := deployment.GetDeploymentDetail(k8sClient, namespace, name)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
+	result.PolicyViolations, result.Errors = apiHandler.getPolicyViolations(request, "Deployment", namespace, name, result.Errors)
+	result.ResourceRecommendations, result.Errors = apiHandler.getResourceRecommendations(request, k8sClient,
+		"Deployment", namespace, name, result.Errors)
 
 	response.WriteHeaderAndEntity(http.StatusOK, result)
 }
@@ -1367,7 +2607,7 @@ func (apiHandler *APIHandler) handleGetDeploymentDetail(request *restful.Request
 func (apiHandler *APIHandler) handleGetDeploymentEvents(request *restful.Request, response *restful.Response) {
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
@@ -1376,7 +2616,7 @@ func (apiHandler *APIHandler) handleGetDeploymentEvents(request *restful.Request
 	dataSelect := parser.ParseDataSelectPathParameter(request)
 	result, err := event.GetResourceEvents(k8sClient, dataSelect, namespace, name)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 	response.WriteHeaderAndEntity(http.StatusOK, result)
@@ -1385,7 +2625,7 @@ func (apiHandler *APIHandler) handleGetDeploymentEvents(request *restful.Request
 func (apiHandler *APIHandler) handleGetDeploymentOldReplicaSets(request *restful.Request, response *restful.Response) {
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
@@ -1395,7 +2635,7 @@ func (apiHandler *APIHandler) handleGetDeploymentOldReplicaSets(request *restful
 	dataSelect.MetricQuery = dataselect.StandardMetrics
 	result, err := deployment.GetDeploymentOldReplicaSets(k8sClient, dataSelect, namespace, name)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 	response.WriteHeaderAndEntity(http.StatusOK, result)
@@ -1404,7 +2644,7 @@ func (apiHandler *APIHandler) handleGetDeploymentOldReplicaSets(request *restful
 func (apiHandler *APIHandler) handleGetDeploymentNewReplicaSet(request *restful.Request, response *restful.Response) {
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
@@ -1414,7 +2654,7 @@ func (apiHandler *APIHandler) handleGetDeploymentNewReplicaSet(request *restful.
 	dataSelect.MetricQuery = dataselect.StandardMetrics
 	result, err := deployment.GetDeploymentNewReplicaSet(k8sClient, dataSelect, namespace, name)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 	response.WriteHeaderAndEntity(http.StatusOK, result)
@@ -1423,7 +2663,7 @@ func (apiHandler *APIHandler) handleGetDeploymentNewReplicaSet(request *restful.
 func (apiHandler *APIHandler) handleGetPods(request *restful.Request, response *restful.Response) {
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
@@ -1432,7 +2672,7 @@ func (apiHandler *APIHandler) handleGetPods(request *restful.Request, response *
 	dataSelect.MetricQuery = dataselect.StandardMetrics // download standard metrics - cpu, and memory - by default
 	result, err := pod.GetPodList(k8sClient, apiHandler.iManager.Metric().Client(), namespace, dataSelect)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 	response.WriteHeaderAndEntity(http.StatusOK, result)
@@ -1441,7 +2681,7 @@ func (apiHandler *APIHandler) handleGetPods(request *restful.Request, response *
 func (apiHandler *APIHandler) handleGetPodDetail(request *restful.Request, response *restful.Response) {
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
@@ -1449,16 +2689,20 @@ func (apiHandler *APIHandler) handleGetPodDetail(request *restful.Request, respo
 	name := request.PathParameter("pod")
 	result, err := pod.GetPodDetail(k8sClient, apiHandler.iManager.Metric().Client(), namespace, name)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
+	result.PolicyViolations, result.Errors = apiHandler.getPolicyViolations(request, "Pod", namespace, name, result.Errors)
+
+	s := apiHandler.sManager.GetGlobalSettings(apiHandler.cManager.InsecureClient())
+	pod.HideEnvValues(result, s.IsResourceKindHidden)
 	response.WriteHeaderAndEntity(http.StatusOK, result)
 }
 
 func (apiHandler *APIHandler) handleGetReplicationControllerDetail(request *restful.Request, response *restful.Response) {
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
@@ -1466,7 +2710,7 @@ func (apiHandler *APIHandler) handleGetReplicationControllerDetail(request *rest
 	name := request.PathParameter("replicationController")
 	result, err := replicationcontroller.GetReplicationControllerDetail(k8sClient, namespace, name)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 	response.WriteHeaderAndEntity(http.StatusOK, result)
@@ -1475,7 +2719,7 @@ func (apiHandler *APIHandler) handleGetReplicationControllerDetail(request *rest
 func (apiHandler *APIHandler) handleUpdateReplicasCount(request *restful.Request, response *restful.Response) {
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
@@ -1483,12 +2727,12 @@ func (apiHandler *APIHandler) handleUpdateReplicasCount(request *restful.Request
 	name := request.PathParameter("replicationController")
 	spec := new(replicationcontroller.ReplicationControllerSpec)
 	if err := request.ReadEntity(spec); err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
 	if err := replicationcontroller.UpdateReplicasCount(k8sClient, namespace, name, spec); err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
@@ -1498,13 +2742,13 @@ func (apiHandler *APIHandler) handleUpdateReplicasCount(request *restful.Request
 func (apiHandler *APIHandler) handleGetResource(request *restful.Request, response *restful.Response) {
 	config, err := apiHandler.cManager.Config(request)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
 	verber, err := apiHandler.cManager.VerberClient(request, config)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
@@ -1513,10 +2757,102 @@ func (apiHandler *APIHandler) handleGetResource(request *restful.Request, respon
 	name := request.PathParameter("name")
 	result, err := verber.Get(kind, ok, namespace, name)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	if err := writeRawObject(request, response, result); err != nil {
+		errors.HandleInternalError(request, response, err)
+	}
+}
+
+func (apiHandler *APIHandler) handleGetGitOpsStatus(request *restful.Request, response *restful.Response) {
+	config, err := apiHandler.cManager.Config(request)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	verber, err := apiHandler.cManager.VerberClient(request, config)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	discoveryClient, dynamicClient, err := newDiscoveryAndDynamicClients(config)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
+	kind := request.PathParameter("kind")
+	namespace, ok := request.PathParameters()["namespace"]
+	name := request.PathParameter("name")
+	object, err := verber.Get(kind, ok, namespace, name)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	accessor, err := meta.Accessor(object)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	result, err := gitops.GetStatus(discoveryClient, dynamicClient, accessor)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+// handleGetResourceDiff compares the live object with its last applied configuration. Manifest to compare with
+// can be posted instead.
+func (apiHandler *APIHandler) handleGetResourceDiff(request *restful.Request, response *restful.Response) {
+	config, err := apiHandler.cManager.Config(request)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	verber, err := apiHandler.cManager.VerberClient(request, config)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	var manifest []byte
+	if request.Request.Method == http.MethodPost {
+		object, err := readRawObject(request)
+		if err != nil {
+			errors.HandleInternalError(request, response, err)
+			return
+		}
+		manifest = object.Raw
+	}
+
+	kind := request.PathParameter("kind")
+	namespace, ok := request.PathParameters()["namespace"]
+	name := request.PathParameter("name")
+	object, err := verber.Get(kind, ok, namespace, name)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	live, ok := object.(*runtime.Unknown)
+	if !ok {
+		errors.HandleInternalError(request, response, errors.NewUnexpectedObject(object))
+		return
+	}
+
+	result, err := diff.GetDiff(live.Raw, manifest)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
 	response.WriteHeaderAndEntity(http.StatusOK, result)
 }
 
@@ -1524,44 +2860,99 @@ func (apiHandler *APIHandler) handlePutResource(
 	request *restful.Request, response *restful.Response) {
 	config, err := apiHandler.cManager.Config(request)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
 	verber, err := apiHandler.cManager.VerberClient(request, config)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
 	kind := request.PathParameter("kind")
 	namespace, ok := request.PathParameters()["namespace"]
 	name := request.PathParameter("name")
-	putSpec := &runtime.Unknown{}
-	if err := request.ReadEntity(putSpec); err != nil {
-		errors.HandleInternalError(response, err)
+	putSpec, err := readRawObject(request)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	if err := requireResourceVersion(putSpec); err != nil {
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
 	if err := verber.Put(kind, ok, namespace, name, putSpec); err != nil {
-		errors.HandleInternalError(response, err)
+		if !errors.IsConflictError(err) {
+			errors.HandleInternalError(request, response, err)
+			return
+		}
+
+		// Object was changed after it was read into the editor, so the difference is sent back instead.
+		current, getErr := verber.Get(kind, ok, namespace, name)
+		if getErr != nil {
+			errors.HandleInternalError(request, response, err)
+			return
+		}
+		conflict, diffErr := newResourceConflict(err.Error(), putSpec, current)
+		if diffErr != nil {
+			errors.HandleInternalError(request, response, err)
+			return
+		}
+		response.WriteHeaderAndEntity(http.StatusConflict, conflict)
 		return
 	}
 
 	response.WriteHeader(http.StatusCreated)
 }
 
+func (apiHandler *APIHandler) handlePatchResource(
+	request *restful.Request, response *restful.Response) {
+	config, err := apiHandler.cManager.Config(request)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	verber, err := apiHandler.cManager.VerberClient(request, config)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	kind := request.PathParameter("kind")
+	namespace, ok := request.PathParameters()["namespace"]
+	name := request.PathParameter("name")
+	patchType, patch, err := readRawPatch(request)
+	if err != nil {
+		errors.HandleInternalError(request, response, errors.NewBadRequest(err.Error()))
+		return
+	}
+
+	result, err := verber.Patch(kind, ok, namespace, name, patchType, patch)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	if err := writeRawObject(request, response, result); err != nil {
+		errors.HandleInternalError(request, response, err)
+	}
+}
+
 func (apiHandler *APIHandler) handleDeleteResource(
 	request *restful.Request, response *restful.Response) {
 	config, err := apiHandler.cManager.Config(request)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
 	verber, err := apiHandler.cManager.VerberClient(request, config)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
@@ -1570,7 +2961,7 @@ func (apiHandler *APIHandler) handleDeleteResource(
 	name := request.PathParameter("name")
 
 	if err := verber.Delete(kind, ok, namespace, name); err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
@@ -1583,7 +2974,7 @@ func (apiHandler *APIHandler) handleDeleteResource(
 
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 	if err = apiHandler.sManager.DeletePinnedResource(k8sClient, pinnedResource); err != nil {
@@ -1598,7 +2989,7 @@ func (apiHandler *APIHandler) handleDeleteResource(
 func (apiHandler *APIHandler) handleGetReplicationControllerPods(request *restful.Request, response *restful.Response) {
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
@@ -1608,7 +2999,7 @@ func (apiHandler *APIHandler) handleGetReplicationControllerPods(request *restfu
 	dataSelect.MetricQuery = dataselect.StandardMetrics
 	result, err := replicationcontroller.GetReplicationControllerPods(k8sClient, apiHandler.iManager.Metric().Client(), dataSelect, rc, namespace)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 	response.WriteHeaderAndEntity(http.StatusOK, result)
@@ -1617,17 +3008,17 @@ func (apiHandler *APIHandler) handleGetReplicationControllerPods(request *restfu
 func (apiHandler *APIHandler) handleCreateNamespace(request *restful.Request, response *restful.Response) {
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
 	namespaceSpec := new(ns.NamespaceSpec)
 	if err := request.ReadEntity(namespaceSpec); err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 	if err := ns.CreateNamespace(namespaceSpec, k8sClient); err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 	response.WriteHeaderAndEntity(http.StatusCreated, namespaceSpec)
@@ -1636,30 +3027,55 @@ func (apiHandler *APIHandler) handleCreateNamespace(request *restful.Request, re
 func (apiHandler *APIHandler) handleGetNamespaces(request *restful.Request, response *restful.Response) {
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
 	dataSelect := parser.ParseDataSelectPathParameter(request)
-	result, err := ns.GetNamespaceList(k8sClient, dataSelect)
+	nsQuery := restrictNamespaceQuery(request, common.NewNamespaceQuery(nil))
+	result, err := ns.GetNamespaceList(k8sClient, nsQuery, dataSelect)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	s, err := settings.GetEffectiveSettings(apiHandler.sManager, apiHandler.cManager, request)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
+	ns.MarkPreferredNamespaces(result, s.DefaultNamespace, s.PinnedNamespaces)
 	response.WriteHeaderAndEntity(http.StatusOK, result)
 }
 
 func (apiHandler *APIHandler) handleGetNamespaceDetail(request *restful.Request, response *restful.Response) {
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
 	name := request.PathParameter("name")
 	result, err := ns.GetNamespaceDetail(k8sClient, name)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+	result.PolicyViolations, result.Errors = apiHandler.getPolicyViolations(request, "Namespace", "", name, result.Errors)
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetNamespaceOverview(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	s := apiHandler.sManager.GetGlobalSettings(apiHandler.cManager.InsecureClient())
+	result, err := ns.GetNamespaceOverview(k8sClient, request.PathParameter("name"), s.IsResourceKindHidden)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 	response.WriteHeaderAndEntity(http.StatusOK, result)
@@ -1668,7 +3084,7 @@ func (apiHandler *APIHandler) handleGetNamespaceDetail(request *restful.Request,
 func (apiHandler *APIHandler) handleGetNamespaceEvents(request *restful.Request, response *restful.Response) {
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
@@ -1676,27 +3092,66 @@ func (apiHandler *APIHandler) handleGetNamespaceEvents(request *restful.Request,
 	dataSelect := parser.ParseDataSelectPathParameter(request)
 	result, err := event.GetNamespaceEvents(k8sClient, dataSelect, name)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 	response.WriteHeaderAndEntity(http.StatusOK, result)
 }
 
+// handleWatchEvents streams events of a namespace, or of all namespaces if none is given, as newline
+// delimited JSON until the client disconnects or Dashboard shuts down. Query parameter 'type' narrows the
+// stream to given event type, i.e. 'Warning'.
+func (apiHandler *APIHandler) handleWatchEvents(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	namespace := request.PathParameter("namespace")
+	eventType := request.QueryParameter("type")
+	watcher, err := event.WatchEvents(k8sClient, namespace)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	response.AddHeader(restful.HEADER_ContentType, "application/x-ndjson")
+	response.WriteHeader(http.StatusOK)
+	response.Flush()
+
+	nsQuery := restrictNamespaceQuery(request, common.NewNamespaceQuery(nil))
+	encoder := json.NewEncoder(response)
+	err = event.StreamEvents(watcher, eventType, streamDone(request.Request.Context()), func(e common.Event) error {
+		if !nsQuery.Matches(e.ObjectMeta.Namespace) {
+			return nil
+		}
+		if err := encoder.Encode(e); err != nil {
+			return err
+		}
+		response.Flush()
+		return nil
+	})
+	if err != nil {
+		log.Printf("Event stream for namespace %q closed with error: %s", namespace, err.Error())
+	}
+}
+
 func (apiHandler *APIHandler) handleCreateImagePullSecret(request *restful.Request, response *restful.Response) {
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
 	spec := new(secret.ImagePullSecretSpec)
 	if err := request.ReadEntity(spec); err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 	result, err := secret.CreateSecret(k8sClient, spec)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 	response.WriteHeaderAndEntity(http.StatusCreated, result)
@@ -1705,7 +3160,7 @@ func (apiHandler *APIHandler) handleCreateImagePullSecret(request *restful.Reque
 func (apiHandler *APIHandler) handleGetSecretDetail(request *restful.Request, response *restful.Response) {
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
@@ -1713,7 +3168,7 @@ func (apiHandler *APIHandler) handleGetSecretDetail(request *restful.Request, re
 	name := request.PathParameter("name")
 	result, err := secret.GetSecretDetail(k8sClient, namespace, name)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 	response.WriteHeaderAndEntity(http.StatusOK, result)
@@ -1722,7 +3177,7 @@ func (apiHandler *APIHandler) handleGetSecretDetail(request *restful.Request, re
 func (apiHandler *APIHandler) handleGetSecretList(request *restful.Request, response *restful.Response) {
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
@@ -1730,7 +3185,7 @@ func (apiHandler *APIHandler) handleGetSecretList(request *restful.Request, resp
 	namespace := parseNamespacePathParameter(request)
 	result, err := secret.GetSecretList(k8sClient, namespace, dataSelect)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 	response.WriteHeaderAndEntity(http.StatusOK, result)
@@ -1739,7 +3194,7 @@ func (apiHandler *APIHandler) handleGetSecretList(request *restful.Request, resp
 func (apiHandler *APIHandler) handleGetConfigMapList(request *restful.Request, response *restful.Response) {
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
@@ -1747,7 +3202,7 @@ func (apiHandler *APIHandler) handleGetConfigMapList(request *restful.Request, r
 	dataSelect := parser.ParseDataSelectPathParameter(request)
 	result, err := configmap.GetConfigMapList(k8sClient, namespace, dataSelect)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 	response.WriteHeaderAndEntity(http.StatusOK, result)
@@ -1756,7 +3211,7 @@ func (apiHandler *APIHandler) handleGetConfigMapList(request *restful.Request, r
 func (apiHandler *APIHandler) handleGetConfigMapDetail(request *restful.Request, response *restful.Response) {
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
@@ -1764,7 +3219,7 @@ func (apiHandler *APIHandler) handleGetConfigMapDetail(request *restful.Request,
 	name := request.PathParameter("configmap")
 	result, err := configmap.GetConfigMapDetail(k8sClient, namespace, name)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 	response.WriteHeaderAndEntity(http.StatusOK, result)
@@ -1773,14 +3228,14 @@ func (apiHandler *APIHandler) handleGetConfigMapDetail(request *restful.Request,
 func (apiHandler *APIHandler) handleGetPersistentVolumeList(request *restful.Request, response *restful.Response) {
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
 	dataSelect := parser.ParseDataSelectPathParameter(request)
 	result, err := persistentvolume.GetPersistentVolumeList(k8sClient, dataSelect)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 	response.WriteHeaderAndEntity(http.StatusOK, result)
@@ -1789,14 +3244,14 @@ func (apiHandler *APIHandler) handleGetPersistentVolumeList(request *restful.Req
 func (apiHandler *APIHandler) handleGetPersistentVolumeDetail(request *restful.Request, response *restful.Response) {
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
 	name := request.PathParameter("persistentvolume")
 	result, err := persistentvolume.GetPersistentVolumeDetail(k8sClient, name)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 	response.WriteHeaderAndEntity(http.StatusOK, result)
@@ -1805,7 +3260,7 @@ func (apiHandler *APIHandler) handleGetPersistentVolumeDetail(request *restful.R
 func (apiHandler *APIHandler) handleGetPersistentVolumeClaimList(request *restful.Request, response *restful.Response) {
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
@@ -1813,7 +3268,7 @@ func (apiHandler *APIHandler) handleGetPersistentVolumeClaimList(request *restfu
 	dataSelect := parser.ParseDataSelectPathParameter(request)
 	result, err := persistentvolumeclaim.GetPersistentVolumeClaimList(k8sClient, namespace, dataSelect)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 	response.WriteHeaderAndEntity(http.StatusOK, result)
@@ -1822,7 +3277,7 @@ func (apiHandler *APIHandler) handleGetPersistentVolumeClaimList(request *restfu
 func (apiHandler *APIHandler) handleGetPersistentVolumeClaimDetail(request *restful.Request, response *restful.Response) {
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
@@ -1830,7 +3285,7 @@ func (apiHandler *APIHandler) handleGetPersistentVolumeClaimDetail(request *rest
 	name := request.PathParameter("name")
 	result, err := persistentvolumeclaim.GetPersistentVolumeClaimDetail(k8sClient, namespace, name)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 	response.WriteHeaderAndEntity(http.StatusOK, result)
@@ -1839,7 +3294,7 @@ func (apiHandler *APIHandler) handleGetPersistentVolumeClaimDetail(request *rest
 func (apiHandler *APIHandler) handleGetPodContainers(request *restful.Request, response *restful.Response) {
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
@@ -1847,7 +3302,7 @@ func (apiHandler *APIHandler) handleGetPodContainers(request *restful.Request, r
 	name := request.PathParameter("pod")
 	result, err := container.GetPodContainers(k8sClient, namespace, name)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 	response.WriteHeaderAndEntity(http.StatusOK, result)
@@ -1856,7 +3311,7 @@ func (apiHandler *APIHandler) handleGetPodContainers(request *restful.Request, r
 func (apiHandler *APIHandler) handleGetReplicationControllerEvents(request *restful.Request, response *restful.Response) {
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
@@ -1865,7 +3320,7 @@ func (apiHandler *APIHandler) handleGetReplicationControllerEvents(request *rest
 	dataSelect := parser.ParseDataSelectPathParameter(request)
 	result, err := event.GetResourceEvents(k8sClient, dataSelect, namespace, name)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 	response.WriteHeaderAndEntity(http.StatusOK, result)
@@ -1875,7 +3330,7 @@ func (apiHandler *APIHandler) handleGetReplicationControllerServices(request *re
 	response *restful.Response) {
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
@@ -1884,7 +3339,7 @@ func (apiHandler *APIHandler) handleGetReplicationControllerServices(request *re
 	dataSelect := parser.ParseDataSelectPathParameter(request)
 	result, err := replicationcontroller.GetReplicationControllerServices(k8sClient, dataSelect, namespace, name)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 	response.WriteHeaderAndEntity(http.StatusOK, result)
@@ -1893,7 +3348,7 @@ func (apiHandler *APIHandler) handleGetReplicationControllerServices(request *re
 func (apiHandler *APIHandler) handleGetDaemonSetList(request *restful.Request, response *restful.Response) {
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
@@ -1902,7 +3357,7 @@ func (apiHandler *APIHandler) handleGetDaemonSetList(request *restful.Request, r
 	dataSelect.MetricQuery = dataselect.StandardMetrics
 	result, err := daemonset.GetDaemonSetList(k8sClient, namespace, dataSelect, apiHandler.iManager.Metric().Client())
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 	response.WriteHeaderAndEntity(http.StatusOK, result)
@@ -1912,7 +3367,7 @@ func (apiHandler *APIHandler) handleGetDaemonSetDetail(
 	request *restful.Request, response *restful.Response) {
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
@@ -1920,16 +3375,19 @@ func (apiHandler *APIHandler) handleGetDaemonSetDetail(
 	name := request.PathParameter("daemonSet")
 	result, err := daemonset.GetDaemonSetDetail(k8sClient, apiHandler.iManager.Metric().Client(), namespace, name)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
+	result.PolicyViolations, result.Errors = apiHandler.getPolicyViolations(request, "DaemonSet", namespace, name, result.Errors)
+	result.ResourceRecommendations, result.Errors = apiHandler.getResourceRecommendations(request, k8sClient,
+		"DaemonSet", namespace, name, result.Errors)
 	response.WriteHeaderAndEntity(http.StatusOK, result)
 }
 
 func (apiHandler *APIHandler) handleGetDaemonSetPods(request *restful.Request, response *restful.Response) {
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
@@ -1939,7 +3397,7 @@ func (apiHandler *APIHandler) handleGetDaemonSetPods(request *restful.Request, r
 	dataSelect.MetricQuery = dataselect.StandardMetrics
 	result, err := daemonset.GetDaemonSetPods(k8sClient, apiHandler.iManager.Metric().Client(), dataSelect, name, namespace)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 	response.WriteHeaderAndEntity(http.StatusOK, result)
@@ -1948,7 +3406,7 @@ func (apiHandler *APIHandler) handleGetDaemonSetPods(request *restful.Request, r
 func (apiHandler *APIHandler) handleGetDaemonSetServices(request *restful.Request, response *restful.Response) {
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
@@ -1957,7 +3415,7 @@ func (apiHandler *APIHandler) handleGetDaemonSetServices(request *restful.Reques
 	dataSelect := parser.ParseDataSelectPathParameter(request)
 	result, err := daemonset.GetDaemonSetServices(k8sClient, dataSelect, namespace, daemonSet)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 	response.WriteHeaderAndEntity(http.StatusOK, result)
@@ -1966,7 +3424,7 @@ func (apiHandler *APIHandler) handleGetDaemonSetServices(request *restful.Reques
 func (apiHandler *APIHandler) handleGetDaemonSetEvents(request *restful.Request, response *restful.Response) {
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
@@ -1975,7 +3433,7 @@ func (apiHandler *APIHandler) handleGetDaemonSetEvents(request *restful.Request,
 	dataSelect := parser.ParseDataSelectPathParameter(request)
 	result, err := event.GetResourceEvents(k8sClient, dataSelect, namespace, name)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 	response.WriteHeaderAndEntity(http.StatusOK, result)
@@ -1985,7 +3443,7 @@ func (apiHandler *APIHandler) handleGetHorizontalPodAutoscalerList(request *rest
 	response *restful.Response) {
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
@@ -1993,7 +3451,7 @@ func (apiHandler *APIHandler) handleGetHorizontalPodAutoscalerList(request *rest
 	dataSelect := parser.ParseDataSelectPathParameter(request)
 	result, err := horizontalpodautoscaler.GetHorizontalPodAutoscalerList(k8sClient, namespace, dataSelect)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 	response.WriteHeaderAndEntity(http.StatusOK, result)
@@ -2003,7 +3461,7 @@ func (apiHandler *APIHandler) handleGetHorizontalPodAutoscalerListForResource(re
 	response *restful.Response) {
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
@@ -2012,7 +3470,25 @@ func (apiHandler *APIHandler) handleGetHorizontalPodAutoscalerListForResource(re
 	kind := request.PathParameter("kind")
 	result, err := horizontalpodautoscaler.GetHorizontalPodAutoscalerListForResource(k8sClient, namespace, kind, name)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetPodPlacement(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	namespace := request.PathParameter("namespace")
+	name := request.PathParameter("name")
+	kind := request.PathParameter("kind")
+	result, err := topology.GetPodPlacement(k8sClient, kind, namespace, name)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 	response.WriteHeaderAndEntity(http.StatusOK, result)
@@ -2021,7 +3497,7 @@ func (apiHandler *APIHandler) handleGetHorizontalPodAutoscalerListForResource(re
 func (apiHandler *APIHandler) handleGetHorizontalPodAutoscalerDetail(request *restful.Request, response *restful.Response) {
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
@@ -2029,7 +3505,7 @@ func (apiHandler *APIHandler) handleGetHorizontalPodAutoscalerDetail(request *re
 	name := request.PathParameter("horizontalpodautoscaler")
 	result, err := horizontalpodautoscaler.GetHorizontalPodAutoscalerDetail(k8sClient, namespace, name)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 	response.WriteHeaderAndEntity(http.StatusOK, result)
@@ -2038,7 +3514,7 @@ func (apiHandler *APIHandler) handleGetHorizontalPodAutoscalerDetail(request *re
 func (apiHandler *APIHandler) handleGetJobList(request *restful.Request, response *restful.Response) {
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
@@ -2047,7 +3523,7 @@ func (apiHandler *APIHandler) handleGetJobList(request *restful.Request, respons
 	dataSelect.MetricQuery = dataselect.StandardMetrics
 	result, err := job.GetJobList(k8sClient, namespace, dataSelect, apiHandler.iManager.Metric().Client())
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 	response.WriteHeaderAndEntity(http.StatusOK, result)
@@ -2056,7 +3532,7 @@ func (apiHandler *APIHandler) handleGetJobList(request *restful.Request, respons
 func (apiHandler *APIHandler) handleGetJobDetail(request *restful.Request, response *restful.Response) {
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
@@ -2064,7 +3540,7 @@ func (apiHandler *APIHandler) handleGetJobDetail(request *restful.Request, respo
 	name := request.PathParameter("name")
 	result, err := job.GetJobDetail(k8sClient, namespace, name)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 	response.WriteHeaderAndEntity(http.StatusOK, result)
@@ -2073,7 +3549,7 @@ func (apiHandler *APIHandler) handleGetJobDetail(request *restful.Request, respo
 func (apiHandler *APIHandler) handleGetJobPods(request *restful.Request, response *restful.Response) {
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
@@ -2083,7 +3559,7 @@ func (apiHandler *APIHandler) handleGetJobPods(request *restful.Request, respons
 	dataSelect.MetricQuery = dataselect.StandardMetrics
 	result, err := job.GetJobPods(k8sClient, apiHandler.iManager.Metric().Client(), dataSelect, namespace, name)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 	response.WriteHeaderAndEntity(http.StatusOK, result)
@@ -2092,7 +3568,7 @@ func (apiHandler *APIHandler) handleGetJobPods(request *restful.Request, respons
 func (apiHandler *APIHandler) handleGetJobEvents(request *restful.Request, response *restful.Response) {
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
@@ -2101,7 +3577,7 @@ func (apiHandler *APIHandler) handleGetJobEvents(request *restful.Request, respo
 	dataSelect := parser.ParseDataSelectPathParameter(request)
 	result, err := job.GetJobEvents(k8sClient, dataSelect, namespace, name)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 	response.WriteHeaderAndEntity(http.StatusOK, result)
@@ -2110,7 +3586,7 @@ func (apiHandler *APIHandler) handleGetJobEvents(request *restful.Request, respo
 func (apiHandler *APIHandler) handleGetCronJobList(request *restful.Request, response *restful.Response) {
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
@@ -2119,7 +3595,7 @@ func (apiHandler *APIHandler) handleGetCronJobList(request *restful.Request, res
 	dataSelect.MetricQuery = dataselect.StandardMetrics
 	result, err := cronjob.GetCronJobList(k8sClient, namespace, dataSelect, apiHandler.iManager.Metric().Client())
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 	response.WriteHeaderAndEntity(http.StatusOK, result)
@@ -2128,7 +3604,7 @@ func (apiHandler *APIHandler) handleGetCronJobList(request *restful.Request, res
 func (apiHandler *APIHandler) handleGetCronJobDetail(request *restful.Request, response *restful.Response) {
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
@@ -2136,7 +3612,7 @@ func (apiHandler *APIHandler) handleGetCronJobDetail(request *restful.Request, r
 	name := request.PathParameter("name")
 	result, err := cronjob.GetCronJobDetail(k8sClient, namespace, name)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 	response.WriteHeaderAndEntity(http.StatusOK, result)
@@ -2145,7 +3621,7 @@ func (apiHandler *APIHandler) handleGetCronJobDetail(request *restful.Request, r
 func (apiHandler *APIHandler) handleGetCronJobJobs(request *restful.Request, response *restful.Response) {
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
@@ -2159,7 +3635,7 @@ func (apiHandler *APIHandler) handleGetCronJobJobs(request *restful.Request, res
 	dataSelect := parser.ParseDataSelectPathParameter(request)
 	result, err := cronjob.GetCronJobJobs(k8sClient, apiHandler.iManager.Metric().Client(), dataSelect, namespace, name, active)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 	response.WriteHeaderAndEntity(http.StatusOK, result)
@@ -2168,7 +3644,7 @@ func (apiHandler *APIHandler) handleGetCronJobJobs(request *restful.Request, res
 func (apiHandler *APIHandler) handleGetCronJobEvents(request *restful.Request, response *restful.Response) {
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
@@ -2177,7 +3653,7 @@ func (apiHandler *APIHandler) handleGetCronJobEvents(request *restful.Request, r
 	dataSelect := parser.ParseDataSelectPathParameter(request)
 	result, err := cronjob.GetCronJobEvents(k8sClient, dataSelect, namespace, name)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 	response.WriteHeaderAndEntity(http.StatusOK, result)
@@ -2186,7 +3662,7 @@ func (apiHandler *APIHandler) handleGetCronJobEvents(request *restful.Request, r
 func (apiHandler *APIHandler) handleTriggerCronJob(request *restful.Request, response *restful.Response) {
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
@@ -2194,7 +3670,7 @@ func (apiHandler *APIHandler) handleTriggerCronJob(request *restful.Request, res
 	name := request.PathParameter("name")
 	err = cronjob.TriggerCronJob(k8sClient, namespace, name)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 	response.WriteHeader(http.StatusOK)
@@ -2203,14 +3679,14 @@ func (apiHandler *APIHandler) handleTriggerCronJob(request *restful.Request, res
 func (apiHandler *APIHandler) handleGetStorageClassList(request *restful.Request, response *restful.Response) {
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
 	dataSelect := parser.ParseDataSelectPathParameter(request)
 	result, err := storageclass.GetStorageClassList(k8sClient, dataSelect)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 	response.WriteHeaderAndEntity(http.StatusOK, result)
@@ -2219,14 +3695,14 @@ func (apiHandler *APIHandler) handleGetStorageClassList(request *restful.Request
 func (apiHandler *APIHandler) handleGetStorageClass(request *restful.Request, response *restful.Response) {
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
 	name := request.PathParameter("storageclass")
 	result, err := storageclass.GetStorageClass(k8sClient, name)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 	response.WriteHeaderAndEntity(http.StatusOK, result)
@@ -2236,7 +3712,7 @@ func (apiHandler *APIHandler) handleGetStorageClassPersistentVolumes(request *re
 	response *restful.Response) {
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
@@ -2245,7 +3721,7 @@ func (apiHandler *APIHandler) handleGetStorageClassPersistentVolumes(request *re
 	result, err := persistentvolume.GetStorageClassPersistentVolumes(k8sClient,
 		name, dataSelect)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 	response.WriteHeaderAndEntity(http.StatusOK, result)
@@ -2255,7 +3731,7 @@ func (apiHandler *APIHandler) handleGetPodPersistentVolumeClaims(request *restfu
 	response *restful.Response) {
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
@@ -2265,7 +3741,7 @@ func (apiHandler *APIHandler) handleGetPodPersistentVolumeClaims(request *restfu
 	result, err := persistentvolumeclaim.GetPodPersistentVolumeClaims(k8sClient,
 		namespace, name, dataSelect)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 	response.WriteHeaderAndEntity(http.StatusOK, result)
@@ -2274,14 +3750,14 @@ func (apiHandler *APIHandler) handleGetPodPersistentVolumeClaims(request *restfu
 func (apiHandler *APIHandler) handleGetCustomResourceDefinitionList(request *restful.Request, response *restful.Response) {
 	apiextensionsclient, err := apiHandler.cManager.APIExtensionsClient(request)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
 	dataSelect := parser.ParseDataSelectPathParameter(request)
 	result, err := customresourcedefinition.GetCustomResourceDefinitionList(apiextensionsclient, dataSelect)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
@@ -2291,20 +3767,20 @@ func (apiHandler *APIHandler) handleGetCustomResourceDefinitionList(request *res
 func (apiHandler *APIHandler) handleGetCustomResourceDefinitionDetail(request *restful.Request, response *restful.Response) {
 	config, err := apiHandler.cManager.Config(request)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
 	apiextensionsclient, err := apiHandler.cManager.APIExtensionsClient(request)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
 	name := request.PathParameter("crd")
 	result, err := customresourcedefinition.GetCustomResourceDefinitionDetail(apiextensionsclient, config, name)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
@@ -2314,13 +3790,13 @@ func (apiHandler *APIHandler) handleGetCustomResourceDefinitionDetail(request *r
 func (apiHandler *APIHandler) handleGetCustomResourceObjectList(request *restful.Request, response *restful.Response) {
 	config, err := apiHandler.cManager.Config(request)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
 	apiextensionsclient, err := apiHandler.cManager.APIExtensionsClient(request)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
@@ -2329,7 +3805,7 @@ func (apiHandler *APIHandler) handleGetCustomResourceObjectList(request *restful
 	dataSelect := parser.ParseDataSelectPathParameter(request)
 	result, err := customresourcedefinition.GetCustomResourceObjectList(apiextensionsclient, config, namespace, dataSelect, crdName)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
@@ -2339,13 +3815,13 @@ func (apiHandler *APIHandler) handleGetCustomResourceObjectList(request *restful
 func (apiHandler *APIHandler) handleGetCustomResourceObjectDetail(request *restful.Request, response *restful.Response) {
 	config, err := apiHandler.cManager.Config(request)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
 	apiextensionsclient, err := apiHandler.cManager.APIExtensionsClient(request)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
@@ -2354,7 +3830,7 @@ func (apiHandler *APIHandler) handleGetCustomResourceObjectDetail(request *restf
 	namespace := parseNamespacePathParameter(request)
 	result, err := customresourcedefinition.GetCustomResourceObjectDetail(apiextensionsclient, namespace, config, crdName, name)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
@@ -2366,7 +3842,7 @@ func (apiHandler *APIHandler) handleGetCustomResourceObjectEvents(request *restf
 
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
@@ -2376,7 +3852,7 @@ func (apiHandler *APIHandler) handleGetCustomResourceObjectEvents(request *restf
 	dataSelect.MetricQuery = dataselect.StandardMetrics
 	result, err := customresourcedefinition.GetEventsForCustomResourceObject(k8sClient, dataSelect, namespace, name)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
@@ -2386,7 +3862,7 @@ func (apiHandler *APIHandler) handleGetCustomResourceObjectEvents(request *restf
 func (apiHandler *APIHandler) handleLogSource(request *restful.Request, response *restful.Response) {
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
@@ -2395,7 +3871,7 @@ func (apiHandler *APIHandler) handleLogSource(request *restful.Request, response
 	namespace := request.PathParameter("namespace")
 	logSources, err := logs.GetLogSources(k8sClient, namespace, resourceName, resourceType)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 	response.WriteHeaderAndEntity(http.StatusOK, logSources)
@@ -2404,7 +3880,7 @@ func (apiHandler *APIHandler) handleLogSource(request *restful.Request, response
 func (apiHandler *APIHandler) handleLogs(request *restful.Request, response *restful.Response) {
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
@@ -2441,7 +3917,7 @@ func (apiHandler *APIHandler) handleLogs(request *restful.Request, response *res
 
 	result, err := container.GetLogDetails(k8sClient, namespace, podID, containerID, logSelector, usePreviousLogs)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 	response.WriteHeaderAndEntity(http.StatusOK, result)
@@ -2450,7 +3926,7 @@ func (apiHandler *APIHandler) handleLogs(request *restful.Request, response *res
 func (apiHandler *APIHandler) handleLogFile(request *restful.Request, response *restful.Response) {
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 	namespace := request.PathParameter("namespace")
@@ -2460,10 +3936,10 @@ func (apiHandler *APIHandler) handleLogFile(request *restful.Request, response *
 
 	logStream, err := container.GetLogFile(k8sClient, namespace, podID, containerID, usePreviousLogs)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
-	handleDownload(response, logStream)
+	handleDownload(request, response, logStream)
 }
 
 // parseNamespacePathParameter parses namespace selector for list pages in path parameter.
@@ -2479,5 +3955,22 @@ func parseNamespacePathParameter(request *restful.Request) *common.NamespaceQuer
 			nonEmptyNamespaces = append(nonEmptyNamespaces, n)
 		}
 	}
-	return common.NewNamespaceQuery(nonEmptyNamespaces)
+	return restrictNamespaceQuery(request, common.NewNamespaceQuery(nonEmptyNamespaces))
+}
+
+// restrictNamespaceQuery hides namespaces restricted by settings stored in the request by
+// namespaceRestrictionFilter. Requests made from a virtual dashboard see only the namespace of the tenant.
+func restrictNamespaceQuery(request *restful.Request, query *common.NamespaceQuery) *common.NamespaceQuery {
+	s, restricted := request.Attribute(namespaceRestrictionAttribute).(settingsApi.Settings)
+	tenant := TenantNamespace(request.Request)
+	switch {
+	case len(tenant) > 0:
+		return common.NewSameNamespaceQuery(tenant).Restrict(func(namespace string) bool {
+			return namespace == tenant && (!restricted || s.IsNamespaceAllowed(namespace))
+		})
+	case restricted:
+		return query.Restrict(s.IsNamespaceAllowed)
+	}
+
+	return query
 }
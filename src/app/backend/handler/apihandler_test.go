@@ -17,6 +17,7 @@ package handler
 import (
 	"encoding/json"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"bytes"
@@ -29,9 +30,12 @@ import (
 	authApi "github.com/kubernetes/dashboard/src/app/backend/auth/api"
 	"github.com/kubernetes/dashboard/src/app/backend/auth/jwe"
 	"github.com/kubernetes/dashboard/src/app/backend/client"
+	"github.com/kubernetes/dashboard/src/app/backend/features"
 	"github.com/kubernetes/dashboard/src/app/backend/settings"
+	settingsApi "github.com/kubernetes/dashboard/src/app/backend/settings/api"
 	"github.com/kubernetes/dashboard/src/app/backend/sync"
 	"github.com/kubernetes/dashboard/src/app/backend/systembanner"
+	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/fake"
 )
 
@@ -47,7 +51,8 @@ func TestCreateHTTPAPIHandler(t *testing.T) {
 	authManager := auth.NewAuthManager(cManager, getTokenManager(), authApi.AuthenticationModes{}, true)
 	sManager := settings.NewSettingsManager()
 	sbManager := systembanner.NewSystemBannerManager("Hello world!", "INFO")
-	_, err := CreateHTTPAPIHandler(nil, cManager, authManager, sManager, sbManager)
+	fManager, _ := features.NewFeatureManager("")
+	_, err := CreateHTTPAPIHandler(nil, cManager, authManager, sManager, sbManager, fManager)
 	if err != nil {
 		t.Fatal("CreateHTTPAPIHandler() cannot create HTTP API handler")
 	}
@@ -83,6 +88,121 @@ func TestShouldDoCsrfValidation(t *testing.T) {
 	}
 }
 
+type fakeSettingsManager struct {
+	settingsApi.SettingsManager
+	settings settingsApi.Settings
+}
+
+func (sm *fakeSettingsManager) GetGlobalSettings(client kubernetes.Interface) settingsApi.Settings {
+	return sm.settings
+}
+
+func TestNamespaceRestrictionFilter(t *testing.T) {
+	cManager := client.NewClientManager("", "http://localhost:8080")
+	sManager := &fakeSettingsManager{settings: settingsApi.Settings{DeniedNamespaces: []string{"kube-system"}}}
+
+	ws := new(restful.WebService)
+	ws.Path("/api/v1").Produces(restful.MIME_JSON)
+	ws.Filter(namespaceRestrictionFilter(sManager, cManager))
+	handle := func(request *restful.Request, response *restful.Response) {
+		response.WriteHeaderAndEntity(http.StatusOK, parseNamespacePathParameter(request).Matches("kube-system"))
+	}
+	ws.Route(ws.GET("/pod").To(handle))
+	ws.Route(ws.GET("/pod/{namespace}").To(handle))
+	ws.Route(ws.GET("/namespace/{name}").To(handle))
+	container := restful.NewContainer()
+	container.Add(ws)
+
+	cases := []struct {
+		url          string
+		expectedCode int
+		expectedBody string
+	}{
+		{"/api/v1/pod", http.StatusOK, "false"},
+		{"/api/v1/pod/default", http.StatusOK, "false"},
+		{"/api/v1/pod/default,kube-system", http.StatusForbidden, "MSG_NAMESPACE_RESTRICTED_ERROR"},
+		{"/api/v1/namespace/kube-system", http.StatusForbidden, "MSG_NAMESPACE_RESTRICTED_ERROR"},
+		{"/api/v1/namespace/default", http.StatusOK, "false"},
+	}
+
+	for _, c := range cases {
+		recorder := httptest.NewRecorder()
+		container.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, c.url, nil))
+		if recorder.Code != c.expectedCode || !strings.Contains(recorder.Body.String(), c.expectedBody) {
+			t.Errorf("GET %s returned %d %s, expected %d %s", c.url, recorder.Code, recorder.Body.String(),
+				c.expectedCode, c.expectedBody)
+		}
+	}
+}
+
+func TestHiddenResourceKindsFilter(t *testing.T) {
+	cManager := client.NewClientManager("", "http://localhost:8080")
+	sManager := &fakeSettingsManager{settings: settingsApi.Settings{HiddenResourceKinds: []string{"secret"}}}
+
+	ws := new(restful.WebService)
+	ws.Path("/api/v1").Produces(restful.MIME_JSON)
+	ws.Filter(hiddenResourceKindsFilter(sManager, cManager))
+	handle := func(request *restful.Request, response *restful.Response) {
+		response.WriteHeader(http.StatusOK)
+	}
+	ws.Route(ws.GET("/secret/{namespace}").To(handle))
+	ws.Route(ws.GET("/configmap/{namespace}").To(handle))
+	ws.Route(ws.GET("/_raw/{kind}/namespace/{namespace}/name/{name}").To(handle))
+	ws.Route(ws.GET("/scale/{kind}/{namespace}/{name}").To(handle))
+	ws.Route(ws.GET("/diff/{kind}/namespace/{namespace}/name/{name}").To(handle))
+	ws.Route(ws.GET("/{kind}/{namespace}/{name}/horizontalpodautoscaler").To(handle))
+	container := restful.NewContainer()
+	container.Add(ws)
+
+	cases := []struct {
+		url          string
+		expectedCode int
+	}{
+		{"/api/v1/secret/default", http.StatusForbidden},
+		{"/api/v1/configmap/default", http.StatusOK},
+		{"/api/v1/_raw/secret/namespace/default/name/foo", http.StatusForbidden},
+		{"/api/v1/_raw/configmap/namespace/default/name/foo", http.StatusOK},
+		{"/api/v1/scale/secret/default/foo", http.StatusForbidden},
+		{"/api/v1/scale/deployment/default/foo", http.StatusOK},
+		{"/api/v1/diff/secret/namespace/default/name/foo", http.StatusForbidden},
+		{"/api/v1/diff/configmap/namespace/default/name/foo", http.StatusOK},
+		{"/api/v1/secret/default/foo/horizontalpodautoscaler", http.StatusForbidden},
+	}
+
+	for _, c := range cases {
+		recorder := httptest.NewRecorder()
+		container.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, c.url, nil))
+		if recorder.Code != c.expectedCode {
+			t.Errorf("GET %s returned %d, expected %d", c.url, recorder.Code, c.expectedCode)
+		}
+	}
+}
+
+func TestHandleBulkActionHiddenResourceKind(t *testing.T) {
+	apiHandler := &APIHandler{
+		cManager: client.NewClientManager("", "http://localhost:8080"),
+		sManager: &fakeSettingsManager{settings: settingsApi.Settings{HiddenResourceKinds: []string{"secret"}}},
+	}
+
+	ws := new(restful.WebService)
+	ws.Path("/api/v1").Consumes(restful.MIME_JSON).Produces(restful.MIME_JSON)
+	ws.Route(ws.POST("/bulk").To(apiHandler.handleBulkAction))
+	container := restful.NewContainer()
+	container.Add(ws)
+
+	body := `{"action":"delete","targets":[{"kind":"configmap","namespace":"default","name":"a"},` +
+		`{"kind":"Secret","namespace":"default","name":"b"}]}`
+	request := httptest.NewRequest(http.MethodPost, "/api/v1/bulk", strings.NewReader(body))
+	request.Header.Set("Content-Type", restful.MIME_JSON)
+	recorder := httptest.NewRecorder()
+	container.ServeHTTP(recorder, request)
+
+	if recorder.Code != http.StatusForbidden {
+		t.Errorf("POST /bulk with a hidden kind returned %d %s, expected %d", recorder.Code, recorder.Body,
+			http.StatusForbidden)
+	}
+}
+
 func TestMapUrlToResource(t *testing.T) {
 	cases := []struct {
 		url, expected string
@@ -0,0 +1,101 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/gorilla/websocket"
+)
+
+// concurrencyLimiter counts requests in flight of every client.
+type concurrencyLimiter struct {
+	mux      sync.Mutex
+	inFlight map[string]int
+}
+
+// acquire reserves a slot for a request of given client. Returns false if the client reached the limit.
+func (self *concurrencyLimiter) acquire(clientID string, limit int) bool {
+	self.mux.Lock()
+	defer self.mux.Unlock()
+
+	if self.inFlight[clientID] >= limit {
+		return false
+	}
+	self.inFlight[clientID]++
+	return true
+}
+
+// release frees a slot reserved by acquire.
+func (self *concurrencyLimiter) release(clientID string) {
+	self.mux.Lock()
+	defer self.mux.Unlock()
+
+	self.inFlight[clientID]--
+	if self.inFlight[clientID] <= 0 {
+		delete(self.inFlight, clientID)
+	}
+}
+
+// CreateConcurrencyLimitHandler wraps given API handler, so that every client can have at most limit requests
+// in flight, and a single client cannot use up whole apiserver budget of the backend. Requests above the limit
+// are rejected with 429 status. Long-running requests, i.e. watches, log streams and exec sessions, are not
+// counted. Limit is disabled when it is 0. It is read for every request, so that it can be changed without restart.
+// Clients are identified by the verified user returned by user function, or by their IP address.
+func CreateConcurrencyLimitHandler(handler http.Handler, limit func() int,
+	user func(*http.Request) string) http.Handler {
+	limiter := &concurrencyLimiter{inFlight: make(map[string]int)}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		currentLimit := limit()
+		if currentLimit <= 0 || isLongRunningRequest(r) {
+			handler.ServeHTTP(w, r)
+			return
+		}
+
+		clientID := requestClientID(r, user)
+		if !limiter.acquire(clientID, currentLimit) {
+			throttledRequestCounter.Inc()
+			w.Header().Set("Retry-After", "1")
+			http.Error(w, "Too many concurrent requests, try again later", http.StatusTooManyRequests)
+			return
+		}
+		defer limiter.release(clientID)
+
+		handler.ServeHTTP(w, r)
+	})
+}
+
+// isLongRunningRequest returns true for requests, that are held open for the whole time the user watches the
+// page, i.e. event watches, log streams and WebSocket connections of exec sessions.
+func isLongRunningRequest(r *http.Request) bool {
+	return strings.HasSuffix(r.URL.Path, "/watch") || strings.HasPrefix(r.URL.Path, "/api/v1/log/stream/") ||
+		websocket.IsWebSocketUpgrade(r)
+}
+
+// requestClientID identifies the client, that sent the request, without contacting the apiserver. Verified user
+// is preferred, so that every logged in user has own limit. Headers with unverified credentials are not used,
+// as they can be changed with every request. Other requests are identified by the IP address of the client.
+func requestClientID(r *http.Request, user func(*http.Request) string) string {
+	if name := user(r); len(name) > 0 {
+		return "user:" + name
+	}
+
+	if ip := remoteIP(r); ip != nil {
+		return "ip:" + ip.String()
+	}
+	return "ip:" + r.RemoteAddr
+}
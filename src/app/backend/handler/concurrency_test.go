@@ -0,0 +1,128 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+func TestCreateConcurrencyLimitHandler(t *testing.T) {
+	started := make(chan struct{})
+	unblock := make(chan struct{})
+	blocking := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/pod" {
+			started <- struct{}{}
+			<-unblock
+		}
+	})
+	// Only the first token is verified, other requests are identified by their IP address.
+	user := func(r *http.Request) string {
+		if r.Header.Get("Authorization") == "Bearer first" {
+			return "jane"
+		}
+		return ""
+	}
+	handler := CreateConcurrencyLimitHandler(blocking, func() int { return 1 }, user)
+
+	newRequest := func(path, token string) *http.Request {
+		request := httptest.NewRequest(http.MethodGet, path, nil)
+		request.RemoteAddr = "10.0.0.1:51234"
+		if len(token) > 0 {
+			request.Header.Set("Authorization", "Bearer "+token)
+		}
+		return request
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		handler.ServeHTTP(httptest.NewRecorder(), newRequest("/api/v1/pod", "first"))
+	}()
+	<-started
+
+	cases := []struct {
+		path     string
+		token    string
+		expected int
+	}{
+		{"/api/v1/node", "first", http.StatusTooManyRequests},
+		{"/api/v1/node", "second", http.StatusOK},
+		{"/api/v1/node", "", http.StatusOK},
+		{"/api/v1/event/watch", "first", http.StatusOK},
+		{"/api/v1/log/stream/default/pod", "first", http.StatusOK},
+	}
+	for _, c := range cases {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, newRequest(c.path, c.token))
+		if recorder.Code != c.expected {
+			t.Errorf("request to %s with token %q code == %d, expected %d", c.path, c.token, recorder.Code, c.expected)
+		}
+	}
+
+	close(unblock)
+	wg.Wait()
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, newRequest("/api/v1/node", "first"))
+	if recorder.Code != http.StatusOK {
+		t.Errorf("request after the first one finished code == %d, expected %d", recorder.Code, http.StatusOK)
+	}
+}
+
+func TestRequestClientID(t *testing.T) {
+	user := func(r *http.Request) string { return r.Header.Get("X-Verified-User") }
+
+	verified := httptest.NewRequest(http.MethodGet, "/api/v1/pod", nil)
+	verified.Header.Set("X-Verified-User", "jane")
+	if id := requestClientID(verified, user); id != "user:jane" {
+		t.Errorf("requestClientID() == %s, expected user:jane", id)
+	}
+
+	for _, header := range []string{"Authorization", "Impersonate-User"} {
+		unverified := httptest.NewRequest(http.MethodGet, "/api/v1/pod", nil)
+		unverified.RemoteAddr = "10.0.0.1:51234"
+		unverified.Header.Set(header, "random")
+		if id := requestClientID(unverified, user); id != "ip:10.0.0.1" {
+			t.Errorf("requestClientID() with unverified %s header == %s, expected ip:10.0.0.1", header, id)
+		}
+	}
+}
+
+func TestIsLongRunningRequest(t *testing.T) {
+	upgrade := httptest.NewRequest(http.MethodGet, "/api/v1/pod/default/web/shell/app", nil)
+	upgrade.Header.Set("Connection", "Upgrade")
+	upgrade.Header.Set("Upgrade", "websocket")
+
+	cases := []struct {
+		request  *http.Request
+		expected bool
+	}{
+		{httptest.NewRequest(http.MethodGet, "/api/v1/event/default/watch", nil), true},
+		{httptest.NewRequest(http.MethodGet, "/api/v1/log/stream/default/web/app?follow=true", nil), true},
+		{upgrade, true},
+		{httptest.NewRequest(http.MethodGet, "/api/v1/pod/default/web/shell/app", nil), false},
+		{httptest.NewRequest(http.MethodGet, "/api/v1/pod", nil), false},
+	}
+
+	for _, c := range cases {
+		if actual := isLongRunningRequest(c.request); actual != c.expected {
+			t.Errorf("isLongRunningRequest(%s) == %t, expected %t", c.request.URL, actual, c.expected)
+		}
+	}
+}
@@ -0,0 +1,190 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+)
+
+// ignoredConflictFields are fields that always differ between the edited and the current object and do not
+// help to resolve the conflict.
+var ignoredConflictFields = map[string]bool{
+	"metadata.resourceVersion": true,
+	"metadata.managedFields":   true,
+	"metadata.generation":      true,
+}
+
+// missingField stands for a field that does not exist in one of the compared objects, so that it can be told
+// apart from a field set to null.
+var missingField = &struct{}{}
+
+// FieldDiff describes a single field that differs between the edited and the current object. Values are raw
+// JSON, so that zero values, i.e. 0, false or "", are kept and only missing fields are omitted.
+type FieldDiff struct {
+	// JSON path of the field, i.e. spec.replicas.
+	Path string `json:"path"`
+
+	// Value of the field in the edited object. Missing when the field was removed.
+	Edited json.RawMessage `json:"edited,omitempty"`
+
+	// Value of the field in the current object. Missing when the field does not exist anymore.
+	Current json.RawMessage `json:"current,omitempty"`
+}
+
+// ResourceConflict is returned when the edited object was changed by someone else after it was read into
+// the editor.
+type ResourceConflict struct {
+	// Message of the conflict error returned by the apiserver.
+	Message string `json:"message"`
+
+	// Resource version of the edited object, as read into the editor.
+	EditedResourceVersion string `json:"editedResourceVersion"`
+
+	// Resource version of the current object.
+	CurrentResourceVersion string `json:"currentResourceVersion"`
+
+	// Fields that differ between the edited and the current object.
+	Diff []FieldDiff `json:"diff"`
+
+	// Current object, so the editor can be reloaded.
+	Current *runtime.Unknown `json:"current"`
+}
+
+// getResourceVersion returns metadata.resourceVersion of the raw JSON object.
+func getResourceVersion(object *runtime.Unknown) (string, error) {
+	meta := struct {
+		Metadata struct {
+			ResourceVersion string `json:"resourceVersion"`
+		} `json:"metadata"`
+	}{}
+	if err := json.Unmarshal(object.Raw, &meta); err != nil {
+		return "", errors.NewBadRequest(err.Error())
+	}
+
+	return meta.Metadata.ResourceVersion, nil
+}
+
+// requireResourceVersion returns bad request error when the edited object does not carry resource version of
+// the read that populated the editor. Without it the update could silently overwrite concurrent changes.
+func requireResourceVersion(object *runtime.Unknown) error {
+	resourceVersion, err := getResourceVersion(object)
+	if err != nil {
+		return err
+	}
+	if len(resourceVersion) == 0 {
+		return errors.NewBadRequest("metadata.resourceVersion is required, reload the resource and edit it again")
+	}
+
+	return nil
+}
+
+// newResourceConflict compares the edited object with the current one, as returned by the verber.
+func newResourceConflict(message string, edited *runtime.Unknown, currentObject runtime.Object) (
+	*ResourceConflict, error) {
+	current, ok := currentObject.(*runtime.Unknown)
+	if !ok {
+		return nil, errors.NewUnexpectedObject(currentObject)
+	}
+
+	var editedObj, currentObj interface{}
+	if err := json.Unmarshal(edited.Raw, &editedObj); err != nil {
+		return nil, errors.NewBadRequest(err.Error())
+	}
+	if err := json.Unmarshal(current.Raw, &currentObj); err != nil {
+		return nil, err
+	}
+
+	editedVersion, _ := getResourceVersion(edited)
+	currentVersion, _ := getResourceVersion(current)
+	return &ResourceConflict{
+		Message:                message,
+		EditedResourceVersion:  editedVersion,
+		CurrentResourceVersion: currentVersion,
+		Diff:                   diffFields("", editedObj, currentObj, make([]FieldDiff, 0)),
+		Current:                current,
+	}, nil
+}
+
+// diffFields appends differences between two decoded JSON values. Maps are compared key by key, arrays of the
+// same length item by item, other values as a whole. Keys missing in one of the maps are compared as missingField.
+func diffFields(path string, edited, current interface{}, result []FieldDiff) []FieldDiff {
+	if ignoredConflictFields[path] || reflect.DeepEqual(edited, current) {
+		return result
+	}
+
+	editedMap, editedIsMap := edited.(map[string]interface{})
+	currentMap, currentIsMap := current.(map[string]interface{})
+	if editedIsMap && currentIsMap {
+		keys := make(map[string]bool)
+		for key := range editedMap {
+			keys[key] = true
+		}
+		for key := range currentMap {
+			keys[key] = true
+		}
+		sorted := make([]string, 0, len(keys))
+		for key := range keys {
+			sorted = append(sorted, key)
+		}
+		sort.Strings(sorted)
+
+		for _, key := range sorted {
+			childPath := key
+			if len(path) > 0 {
+				childPath = path + "." + key
+			}
+			result = diffFields(childPath, mapField(editedMap, key), mapField(currentMap, key), result)
+		}
+		return result
+	}
+
+	editedList, editedIsList := edited.([]interface{})
+	currentList, currentIsList := current.([]interface{})
+	if editedIsList && currentIsList && len(editedList) == len(currentList) {
+		for i := range editedList {
+			result = diffFields(fmt.Sprintf("%s[%d]", path, i), editedList[i], currentList[i], result)
+		}
+		return result
+	}
+
+	return append(result, FieldDiff{Path: path, Edited: rawField(edited), Current: rawField(current)})
+}
+
+// mapField returns value of the key or missingField, if the map does not have the key.
+func mapField(fields map[string]interface{}, key string) interface{} {
+	if value, ok := fields[key]; ok {
+		return value
+	}
+	return missingField
+}
+
+// rawField encodes decoded JSON value back to JSON. Missing field is encoded as empty message, so that it is
+// omitted from the diff.
+func rawField(value interface{}) json.RawMessage {
+	if value == missingField {
+		return nil
+	}
+
+	// Value was decoded from JSON, so it can always be encoded again.
+	raw, _ := json.Marshal(value)
+	return raw
+}
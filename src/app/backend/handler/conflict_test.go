@@ -0,0 +1,84 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestRequireResourceVersion(t *testing.T) {
+	cases := []struct {
+		raw      string
+		expected bool
+	}{
+		{`{"metadata":{"name":"foo","resourceVersion":"12"}}`, true},
+		{`{"metadata":{"name":"foo"}}`, false},
+		{`{"metadata":{"name":"foo","resourceVersion":""}}`, false},
+		{`{"metadata":`, false},
+	}
+
+	for _, c := range cases {
+		err := requireResourceVersion(&runtime.Unknown{Raw: []byte(c.raw)})
+		if (err == nil) != c.expected {
+			t.Errorf("requireResourceVersion(%s) returned %v", c.raw, err)
+		}
+		if err != nil && !errors.IsBadRequest(err) {
+			t.Errorf("Expected bad request for %s, but got %#v", c.raw, err)
+		}
+	}
+}
+
+func TestNewResourceConflict(t *testing.T) {
+	edited := &runtime.Unknown{Raw: []byte(`{"metadata":{"name":"foo","resourceVersion":"12",` +
+		`"labels":{"app":"foo","tier":"web"}},"spec":{"replicas":3,"paused":false,"minReadySeconds":0,` +
+		`"ports":[{"port":80},{"port":443}]}}`)}
+	current := &runtime.Unknown{Raw: []byte(`{"metadata":{"name":"foo","resourceVersion":"15",` +
+		`"labels":{"app":"foo"},"generation":4},"spec":{"replicas":2,"paused":null,"strategy":"",` +
+		`"ports":[{"port":80},{"port":8443}]}}`)}
+
+	conflict, err := newResourceConflict("conflict", edited, current)
+	if err != nil {
+		t.Fatalf("newResourceConflict() returned unexpected error: %s", err)
+	}
+
+	expected := []FieldDiff{
+		{Path: "metadata.labels.tier", Edited: json.RawMessage(`"web"`)},
+		{Path: "spec.minReadySeconds", Edited: json.RawMessage(`0`)},
+		{Path: "spec.paused", Edited: json.RawMessage(`false`), Current: json.RawMessage(`null`)},
+		{Path: "spec.ports[1].port", Edited: json.RawMessage(`443`), Current: json.RawMessage(`8443`)},
+		{Path: "spec.replicas", Edited: json.RawMessage(`3`), Current: json.RawMessage(`2`)},
+		{Path: "spec.strategy", Current: json.RawMessage(`""`)},
+	}
+	if !reflect.DeepEqual(conflict.Diff, expected) {
+		t.Errorf("Expected diff %#v, but got %#v", expected, conflict.Diff)
+	}
+	if conflict.EditedResourceVersion != "12" || conflict.CurrentResourceVersion != "15" {
+		t.Errorf("Expected resource versions 12 and 15, but got %s and %s", conflict.EditedResourceVersion,
+			conflict.CurrentResourceVersion)
+	}
+	if conflict.Current != current {
+		t.Errorf("Expected current object to be returned")
+	}
+
+	if _, err := newResourceConflict("conflict", &runtime.Unknown{Raw: []byte(`{"spec":`)}, current); err == nil ||
+		!errors.IsBadRequest(err) {
+		t.Errorf("Expected bad request for malformed edited object, but got %#v", err)
+	}
+}
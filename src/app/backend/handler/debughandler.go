@@ -0,0 +1,137 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"fmt"
+	"html/template"
+	"log"
+	"net/http"
+	"runtime"
+	"runtime/pprof"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// DebugPath is a path prefix of profiling and runtime debug endpoints.
+	DebugPath = "/debug/"
+
+	// Maximum duration of CPU profile in seconds.
+	maxCPUProfileSeconds = 300
+)
+
+var debugIndexTemplate = template.Must(template.New("index").Parse(`<html>
+<head><title>Dashboard profiles</title></head>
+<body>
+<h1>Profiles</h1>
+<ul>
+<li><a href="profile?seconds=30">profile</a> - CPU profile, duration is set by seconds parameter</li>
+{{range .}}<li><a href="{{.Name}}?debug=1">{{.Name}}</a> ({{.Count}})</li>
+{{end}}</ul>
+<h1>Dumps</h1>
+<ul>
+<li><a href="../dump/goroutines">goroutines</a> - stack traces of all goroutines</li>
+<li><a href="../dump/heap">heap</a> - heap profile collected after garbage collection</li>
+</ul>
+</body>
+</html>
+`))
+
+// CreateDebugHandler creates handler serving pprof compatible profiles under /debug/pprof/ and goroutine and
+// heap dumps under /debug/dump/. Handler should be served only on a local address, because profiles expose
+// internals of the running process. Profiles are served by runtime/pprof directly, so that net/http/pprof
+// does not register its handlers on the default serve mux used by the main server.
+func CreateDebugHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(DebugPath+"pprof/", handleProfile)
+	mux.HandleFunc(DebugPath+"dump/goroutines", handleGoroutineDump)
+	mux.HandleFunc(DebugPath+"dump/heap", handleHeapDump)
+	return mux
+}
+
+// handleProfile serves index of available profiles, CPU profile or named profile, i.e. heap or goroutine.
+// Debug parameter works the same way as in net/http/pprof, 0 returns binary protobuf format.
+func handleProfile(w http.ResponseWriter, r *http.Request) {
+	name := strings.TrimPrefix(r.URL.Path, DebugPath+"pprof/")
+	switch name {
+	case "":
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		if err := debugIndexTemplate.Execute(w, pprof.Profiles()); err != nil {
+			log.Printf("Error while rendering profiles index: %s", err)
+		}
+	case "profile":
+		handleCPUProfile(w, r)
+	default:
+		profile := pprof.Lookup(name)
+		if profile == nil {
+			http.Error(w, fmt.Sprintf("Unknown profile %s", name), http.StatusNotFound)
+			return
+		}
+
+		debug, _ := strconv.Atoi(r.URL.Query().Get("debug"))
+		writeProfile(w, profile, debug)
+	}
+}
+
+// handleCPUProfile collects CPU profile for the number of seconds given by seconds parameter, 30 by default.
+func handleCPUProfile(w http.ResponseWriter, r *http.Request) {
+	seconds, err := strconv.Atoi(r.URL.Query().Get("seconds"))
+	if err != nil || seconds <= 0 {
+		seconds = 30
+	}
+	if seconds > maxCPUProfileSeconds {
+		seconds = maxCPUProfileSeconds
+	}
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("Content-Disposition", `attachment; filename="profile"`)
+	if err := pprof.StartCPUProfile(w); err != nil {
+		w.Header().Del("Content-Disposition")
+		http.Error(w, fmt.Sprintf("Could not enable CPU profiling: %s", err), http.StatusInternalServerError)
+		return
+	}
+
+	select {
+	case <-time.After(time.Duration(seconds) * time.Second):
+	case <-r.Context().Done():
+	}
+	pprof.StopCPUProfile()
+}
+
+// handleGoroutineDump writes stack traces of all goroutines in the same format as unrecovered panic.
+func handleGoroutineDump(w http.ResponseWriter, r *http.Request) {
+	writeProfile(w, pprof.Lookup("goroutine"), 2)
+}
+
+// handleHeapDump runs garbage collection and writes heap profile, so that it contains only live objects.
+func handleHeapDump(w http.ResponseWriter, r *http.Request) {
+	runtime.GC()
+	writeProfile(w, pprof.Lookup("heap"), 0)
+}
+
+func writeProfile(w http.ResponseWriter, profile *pprof.Profile, debug int) {
+	if debug > 0 {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	} else {
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, profile.Name()))
+	}
+
+	if err := profile.WriteTo(w, debug); err != nil {
+		log.Printf("Error while writing %s profile: %s", profile.Name(), err)
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestDebugHandler(t *testing.T) {
+	cases := []struct {
+		path                string
+		expectedCode        int
+		expectedContentType string
+		expectedContent     string
+	}{
+		{"/debug/pprof/", http.StatusOK, "text/html; charset=utf-8", "goroutine"},
+		{"/debug/pprof/goroutine?debug=1", http.StatusOK, "text/plain; charset=utf-8", "goroutine profile"},
+		{"/debug/pprof/heap", http.StatusOK, "application/octet-stream", ""},
+		{"/debug/pprof/unknown", http.StatusNotFound, "text/plain; charset=utf-8", "Unknown profile unknown"},
+		{"/debug/dump/goroutines", http.StatusOK, "text/plain; charset=utf-8", "TestDebugHandler"},
+		{"/debug/dump/heap", http.StatusOK, "application/octet-stream", ""},
+		{"/api/v1/pod", http.StatusNotFound, "text/plain; charset=utf-8", ""},
+	}
+
+	handler := CreateDebugHandler()
+	for _, c := range cases {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, c.path, nil))
+
+		if recorder.Code != c.expectedCode {
+			t.Errorf("Expected status code %d for %s, got %d", c.expectedCode, c.path, recorder.Code)
+		}
+
+		if contentType := recorder.Header().Get("Content-Type"); contentType != c.expectedContentType {
+			t.Errorf("Expected content type %s for %s, got %s", c.expectedContentType, c.path, contentType)
+		}
+
+		if !strings.Contains(recorder.Body.String(), c.expectedContent) {
+			t.Errorf("Expected response for %s to contain %q, got %q", c.path, c.expectedContent,
+				recorder.Body.String())
+		}
+	}
+}
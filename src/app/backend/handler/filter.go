@@ -20,6 +20,7 @@ import (
 	"io/ioutil"
 	"log"
 	"net/http"
+	"net/url"
 	"strings"
 	"time"
 
@@ -31,11 +32,20 @@ import (
 	authApi "github.com/kubernetes/dashboard/src/app/backend/auth/api"
 	clientapi "github.com/kubernetes/dashboard/src/app/backend/client/api"
 	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/falco"
+	"github.com/kubernetes/dashboard/src/app/backend/features"
+	featuresApi "github.com/kubernetes/dashboard/src/app/backend/features/api"
+	"github.com/kubernetes/dashboard/src/app/backend/logging"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/deployment"
+	settingsApi "github.com/kubernetes/dashboard/src/app/backend/settings/api"
 )
 
+// namespaceRestrictionAttribute is a name of request attribute that holds settings used to restrict namespaces.
+const namespaceRestrictionAttribute = "namespaceRestriction"
+
 // InstallFilters installs defined filter for given web service
 func InstallFilters(ws *restful.WebService, manager clientapi.ClientManager) {
-	ws.Filter(requestAndResponseLogger)
+	ws.Filter(requestAndResponseLogger(manager))
 	ws.Filter(metricsFilter)
 	ws.Filter(validateXSRFFilter(manager.CSRFKey()))
 	ws.Filter(restrictedResourcesFilter)
@@ -52,29 +62,210 @@ func restrictedResourcesFilter(request *restful.Request, response *restful.Respo
 	response.WriteHeaderAndEntity(int(err.ErrStatus.Code), err.Error())
 }
 
-// web-service filter function used for request and response logging.
-func requestAndResponseLogger(request *restful.Request, response *restful.Response,
-	chain *restful.FilterChain) {
-	if args.Holder.GetAPILogLevel() != "NONE" {
-		log.Printf(formatRequestLog(request))
+// namespaceRestrictionFilter rejects requests targeting namespaces that are hidden by allowed and denied
+// namespaces from global settings. Settings are stored in the request, so that list endpoints can hide
+// restricted namespaces as well.
+func namespaceRestrictionFilter(sManager settingsApi.SettingsManager,
+	cManager clientapi.ClientManager) restful.FilterFunction {
+	return func(request *restful.Request, response *restful.Response, chain *restful.FilterChain) {
+		s := sManager.GetGlobalSettings(cManager.InsecureClient())
+		if !s.HasNamespaceRestrictions() {
+			chain.ProcessFilter(request, response)
+			return
+		}
+
+		for _, namespace := range requestedNamespaces(request) {
+			if !s.IsNamespaceAllowed(namespace) {
+				err := errors.NewForbidden(errors.MsgNamespaceRestrictedError)
+				response.WriteHeaderAndEntity(int(err.ErrStatus.Code), err.Error())
+				return
+			}
+		}
+
+		request.SetAttribute(namespaceRestrictionAttribute, s)
+		chain.ProcessFilter(request, response)
+	}
+}
+
+// checkNamespace returns forbidden error when given namespace, that was not part of the request path, i.e. was
+// taken from the request body, is hidden by settings stored in the request by namespaceRestrictionFilter, or is
+// not the namespace of the virtual dashboard the request was made from. Empty namespace of cluster-scoped objects
+// is always allowed.
+func checkNamespace(request *restful.Request, namespace string) error {
+	if len(namespace) == 0 {
+		return nil
+	}
+
+	s, ok := request.Attribute(namespaceRestrictionAttribute).(settingsApi.Settings)
+	if ok && !s.IsNamespaceAllowed(namespace) {
+		return errors.NewForbidden(errors.MsgNamespaceRestrictedError)
+	}
+
+	if tenant := TenantNamespace(request.Request); len(tenant) > 0 && namespace != tenant {
+		return errors.NewForbidden(errors.MsgTenantNamespaceError)
+	}
+
+	return nil
+}
+
+// nonDeployingRoutes lists write routes, that do not create or update cluster objects, i.e. validation,
+// rendering and configuration of Dashboard itself. Proxy checks features of proxied requests on its own.
+var nonDeployingRoutes = map[string]bool{
+	"/api/v1/login":                                         true,
+	"/api/v1/token/refresh":                                 true,
+	"/api/v1/login/notice/acknowledgment":                   true,
+	"/api/v1/settings/global":                               true,
+	"/api/v1/settings/user":                                 true,
+	"/api/v1/settings/user/favorites":                       true,
+	"/api/v1/settings/user/recent":                          true,
+	"/api/v1/settings/pinner":                               true,
+	"/api/v1/systembanner":                                  true,
+	"/api/v1/groupmapping":                                  true,
+	"/api/v1" + falco.EventPath:                             true,
+	"/api/v1/appdeployment/preview":                         true,
+	"/api/v1/appdeployment/validate/name":                   true,
+	"/api/v1/appdeployment/validate/imagereference":         true,
+	"/api/v1/appdeployment/validate/imagepull":              true,
+	"/api/v1/appdeployment/validate/protocol":               true,
+	"/api/v1/appdeployment/validate/namespace":              true,
+	"/api/v1/appdeployment/validate/spec":                   true,
+	"/api/v1/appdeployment/validate/quota":                  true,
+	"/api/v1/appdeployment/imagetags":                       true,
+	"/api/v1/appdeploymentfromkustomization/render":         true,
+	"/api/v1/chart/render":                                  true,
+	"/api/v1/diff/{kind}/namespace/{namespace}/name/{name}": true,
+	"/api/v1/diff/{kind}/name/{name}":                       true,
+	"/api/v1/proxy/{subpath:*}":                             true,
+}
+
+// appDeploymentFilter rejects POST, PUT and PATCH requests when app deployment feature is disabled, unless
+// the route is known not to create or update cluster objects. New write routes are gated by default.
+func appDeploymentFilter(fManager featuresApi.FeatureManager) restful.FilterFunction {
+	requireAppDeployment := features.RequireFeature(fManager, featuresApi.AppDeployment)
+	return func(request *restful.Request, response *restful.Response, chain *restful.FilterChain) {
+		switch request.Request.Method {
+		case http.MethodPost, http.MethodPut, http.MethodPatch:
+			if !nonDeployingRoutes[request.SelectedRoutePath()] {
+				requireAppDeployment(request, response, chain)
+				return
+			}
+		}
+
+		chain.ProcessFilter(request, response)
+	}
+}
+
+// namespaceCheck returns checkNamespace bound to given request, that can be applied to namespaces of objects
+// deployed from files.
+func namespaceCheck(request *restful.Request) deployment.NamespaceCheck {
+	return func(namespace string) error {
+		return checkNamespace(request, namespace)
+	}
+}
+
+// hiddenResourceKindsFilter rejects requests targeting resource kinds that are hidden in global settings. Both the
+// kind parameter of the route, i.e. of raw, scale and diff routes, and the first segment of the route path are
+// checked. Targets of bulk actions are checked by the handler, as they are sent in the body.
+func hiddenResourceKindsFilter(sManager settingsApi.SettingsManager,
+	cManager clientapi.ClientManager) restful.FilterFunction {
+	return func(request *restful.Request, response *restful.Response, chain *restful.FilterChain) {
+		s := sManager.GetGlobalSettings(cManager.InsecureClient())
+		if len(s.HiddenResourceKinds) == 0 {
+			chain.ProcessFilter(request, response)
+			return
+		}
+
+		hidden := s.IsResourceKindHidden(request.PathParameter("kind"))
+		if resource := mapUrlToResource(request.SelectedRoutePath()); resource != nil {
+			hidden = hidden || s.IsResourceKindHidden(*resource)
+		}
+
+		if hidden {
+			err := errors.NewForbidden(errors.MsgResourceKindHiddenError)
+			response.WriteHeaderAndEntity(int(err.ErrStatus.Code), err.Error())
+			return
+		}
+
+		chain.ProcessFilter(request, response)
+	}
+}
+
+// requestedNamespaces returns namespaces selected by path parameters of the request.
+func requestedNamespaces(request *restful.Request) []string {
+	result := make([]string, 0)
+	for _, namespace := range strings.Split(request.PathParameter("namespace"), ",") {
+		if namespace = strings.TrimSpace(namespace); len(namespace) > 0 {
+			result = append(result, namespace)
+		}
 	}
 
-	chain.ProcessFilter(request, response)
+	if strings.HasPrefix(request.Request.URL.Path, "/api/v1/namespace/") && len(request.PathParameter("name")) > 0 {
+		result = append(result, request.PathParameter("name"))
+	}
+
+	return result
+}
 
-	if args.Holder.GetAPILogLevel() != "NONE" {
+// requestAndResponseLogger returns web-service filter function used for request and response logging. When
+// structured logging is enabled, single entry is written for every request once response is sent.
+func requestAndResponseLogger(manager clientapi.ClientManager) restful.FilterFunction {
+	return func(request *restful.Request, response *restful.Response, chain *restful.FilterChain) {
+		if args.Holder.GetAPILogLevel() == "NONE" {
+			chain.ProcessFilter(request, response)
+			return
+		}
+
+		if logging.IsStructured() {
+			start := time.Now()
+			chain.ProcessFilter(request, response)
+			logRequest(manager, request, response, time.Since(start))
+			return
+		}
+
+		log.Printf(formatRequestLog(request))
+		chain.ProcessFilter(request, response)
 		log.Printf(formatResponseLog(response, request))
 	}
 }
 
+// logRequest writes structured log entry describing handled request. Level of the entry depends on response
+// status, which for failed calls to the API server is the status returned by the API server.
+func logRequest(manager clientapi.ClientManager, request *restful.Request, response *restful.Response,
+	latency time.Duration) {
+	uri := redactedRequestURI(request.Request.URL)
+
+	fields := logging.Fields{
+		"method":     request.Request.Method,
+		"route":      request.SelectedRoutePath(),
+		"uri":        uri,
+		"remoteAddr": request.Request.RemoteAddr,
+		"requestID":  RequestID(request.Request),
+		"status":     response.StatusCode(),
+		"latencyMs":  float64(latency) / float64(time.Millisecond),
+	}
+
+	// User is logged only when it was verified while handling the request, so that logging does not cost an
+	// additional token review.
+	if user := manager.KnownUsername(request); len(user) > 0 {
+		fields["user"] = user
+	}
+
+	level := logging.LevelInfo
+	switch {
+	case response.StatusCode() >= http.StatusInternalServerError:
+		level = logging.LevelError
+	case response.StatusCode() >= http.StatusBadRequest:
+		level = logging.LevelWarning
+	}
+
+	logging.Log(level, fmt.Sprintf("%s %s", request.Request.Method, uri), fields)
+}
+
 // formatRequestLog formats request log string.
 func formatRequestLog(request *restful.Request) string {
-	uri := ""
+	uri := redactedRequestURI(request.Request.URL)
 	content := "{}"
 
-	if request.Request.URL != nil {
-		uri = request.Request.URL.RequestURI()
-	}
-
 	byteArr, err := ioutil.ReadAll(request.Request.Body)
 	if err == nil {
 		content = string(byteArr)
@@ -99,6 +290,24 @@ func formatResponseLog(response *restful.Response, request *restful.Request) str
 		request.Request.RemoteAddr, response.StatusCode())
 }
 
+// redactedRequestURI returns request URI with values of the token query parameter hidden, so that tokens passed
+// in the query, i.e. by Falco, are not logged.
+func redactedRequestURI(u *url.URL) string {
+	if u == nil {
+		return ""
+	}
+
+	query := u.Query()
+	if _, exists := query["token"]; !exists {
+		return u.RequestURI()
+	}
+
+	query.Set("token", "redacted")
+	redacted := *u
+	redacted.RawQuery = query.Encode()
+	return redacted.RequestURI()
+}
+
 // checkSensitiveUrl checks if a string matches against a sensitive URL
 // true if sensitive. false if not.
 func checkSensitiveURL(url *string) bool {
@@ -119,6 +328,7 @@ func metricsFilter(req *restful.Request, resp *restful.Response,
 	chain *restful.FilterChain) {
 	resource := mapUrlToResource(req.SelectedRoutePath())
 	httpClient := utilnet.GetHTTPClient(req.Request)
+	reqStart := time.Now()
 
 	chain.ProcessFilter(req, resp)
 
@@ -128,9 +338,10 @@ func metricsFilter(req *restful.Request, resp *restful.Response,
 			*resource, httpClient,
 			resp.Header().Get("Content-Type"),
 			resp.StatusCode(),
-			time.Now(),
+			reqStart,
 		)
 	}
+	monitorRoute(req.Request.Method, req.SelectedRoutePath(), resp.StatusCode(), reqStart)
 }
 
 func validateXSRFFilter(csrfKey string) restful.FilterFunction {
@@ -165,6 +376,11 @@ func shouldDoCsrfValidation(req *restful.Request) bool {
 		return false
 	}
 
+	// Falco alerts are posted by Falco itself, that is authorized by the configured token instead.
+	if req.SelectedRoutePath() == "/api/v1"+falco.EventPath {
+		return false
+	}
+
 	return true
 }
 
@@ -0,0 +1,114 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/emicklei/go-restful"
+
+	"github.com/kubernetes/dashboard/src/app/backend/features"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+	settingsApi "github.com/kubernetes/dashboard/src/app/backend/settings/api"
+)
+
+func TestCheckNamespace(t *testing.T) {
+	restricted := restful.NewRequest(httptest.NewRequest("POST", "/api/v1/appdeployment", nil))
+	restricted.SetAttribute(namespaceRestrictionAttribute, settingsApi.Settings{
+		AllowedNamespaces: []string{"default", "apps"},
+		DeniedNamespaces:  []string{"apps"},
+	})
+	unrestricted := restful.NewRequest(httptest.NewRequest("POST", "/api/v1/appdeployment", nil))
+	tenantRequest := httptest.NewRequest("POST", "/api/v1/appdeployment", nil)
+	tenant := restful.NewRequest(tenantRequest.WithContext(context.WithValue(tenantRequest.Context(), tenantKey{},
+		"team-a")))
+
+	cases := []struct {
+		request   *restful.Request
+		namespace string
+		forbidden bool
+	}{
+		{restricted, "default", false},
+		{restricted, "apps", true},
+		{restricted, "kube-system", true},
+		{restricted, "", false},
+		{unrestricted, "kube-system", false},
+		{tenant, "team-a", false},
+		{tenant, "team-b", true},
+		{tenant, "", false},
+	}
+
+	for _, c := range cases {
+		err := checkNamespace(c.request, c.namespace)
+		if (err != nil) != c.forbidden {
+			t.Errorf("checkNamespace(%q) == %v, expected forbidden: %t", c.namespace, err, c.forbidden)
+		}
+		if err := namespaceCheck(c.request)(c.namespace); (err != nil) != c.forbidden {
+			t.Errorf("namespaceCheck()(%q) == %v, expected forbidden: %t", c.namespace, err, c.forbidden)
+		}
+	}
+
+	query := restrictNamespaceQuery(tenant, common.NewNamespaceQuery(nil))
+	if query.ToRequestParam() != "team-a" || !query.Matches("team-a") || query.Matches("team-b") {
+		t.Errorf("restrictNamespaceQuery() of tenant request should match only team-a namespace, got %#v", query)
+	}
+}
+
+func TestAppDeploymentFilter(t *testing.T) {
+	manager, err := features.NewFeatureManager("appDeployment=false")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ws := new(restful.WebService)
+	ws.Path("/api/v1").Filter(appDeploymentFilter(manager))
+	handle := func(request *restful.Request, response *restful.Response) {
+		response.WriteHeader(http.StatusOK)
+	}
+	ws.Route(ws.GET("/pod").To(handle))
+	ws.Route(ws.POST("/bulk").To(handle))
+	ws.Route(ws.POST("/chart/release").To(handle))
+	ws.Route(ws.PUT("/chart/release/{namespace}/{release}").To(handle))
+	ws.Route(ws.PATCH("/_raw/{kind}/name/{name}").To(handle))
+	ws.Route(ws.POST("/appdeployment/validate/name").To(handle))
+	ws.Route(ws.PUT("/settings/global").To(handle))
+	container := restful.NewContainer()
+	container.Add(ws)
+
+	cases := []struct {
+		method   string
+		url      string
+		expected int
+	}{
+		{http.MethodGet, "/api/v1/pod", http.StatusOK},
+		{http.MethodPost, "/api/v1/bulk", http.StatusForbidden},
+		{http.MethodPost, "/api/v1/chart/release", http.StatusForbidden},
+		{http.MethodPut, "/api/v1/chart/release/default/app", http.StatusForbidden},
+		{http.MethodPatch, "/api/v1/_raw/node/name/node-1", http.StatusForbidden},
+		{http.MethodPost, "/api/v1/appdeployment/validate/name", http.StatusOK},
+		{http.MethodPut, "/api/v1/settings/global", http.StatusOK},
+	}
+
+	for _, c := range cases {
+		recorder := httptest.NewRecorder()
+		container.ServeHTTP(recorder, httptest.NewRequest(c.method, c.url, nil))
+		if recorder.Code != c.expected {
+			t.Errorf("%s %s returned %d, expected %d", c.method, c.url, recorder.Code, c.expected)
+		}
+	}
+}
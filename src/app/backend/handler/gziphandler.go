@@ -16,35 +16,90 @@ package handler
 
 import (
 	"compress/gzip"
-	"io"
 	"net/http"
+	"strconv"
 	"strings"
 )
 
+// gzipResponseWriter compresses the response, unless the handler already set its encoding, i.e. when serving
+// a precompressed asset. Decision is made when the header is written.
 type gzipResponseWriter struct {
-	io.Writer
 	http.ResponseWriter
+	gz          *gzip.Writer
+	wroteHeader bool
 }
 
-// Use the Writer part of gzipResponseWriter to write the output.
-func (w gzipResponseWriter) Write(b []byte) (int, error) {
-	return w.Writer.Write(b)
+// WriteHeader enables compression of the response body, if it is not encoded yet and it has a body.
+func (w *gzipResponseWriter) WriteHeader(code int) {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+
+	header := w.Header()
+	header.Add("Vary", "Accept-Encoding")
+	if header.Get("Content-Encoding") == "" && code != http.StatusNoContent && code != http.StatusNotModified {
+		header.Set("Content-Encoding", "gzip")
+		header.Del("Content-Length")
+		w.gz = gzip.NewWriter(w.ResponseWriter)
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+// Write writes to the gzip writer if compression is enabled. Content type is detected from uncompressed data.
+func (w *gzipResponseWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		if w.Header().Get("Content-Type") == "" {
+			w.Header().Set("Content-Type", http.DetectContentType(b))
+		}
+		w.WriteHeader(http.StatusOK)
+	}
+	if w.gz != nil {
+		return w.gz.Write(b)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// close flushes the remaining compressed data.
+func (w *gzipResponseWriter) close() {
+	if w.gz != nil {
+		w.gz.Close()
+	}
 }
 
 // MakeGzipHandler adds support for gzip compression for given handler
 func MakeGzipHandler(handler http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// Check if the client can accept the gzip encoding.
-		if !strings.Contains(r.Header.Get("Accept-Encoding"), "gzip") {
+		if !acceptsEncoding(r.Header.Get("Accept-Encoding"), "gzip") {
 			handler.ServeHTTP(w, r)
 			return
 		}
 
-		// Set the HTTP header indicating encoding.
-		w.Header().Set("Content-Encoding", "gzip")
-		gz := gzip.NewWriter(w)
-		defer gz.Close()
-		gzw := gzipResponseWriter{Writer: gz, ResponseWriter: w}
+		gzw := &gzipResponseWriter{ResponseWriter: w}
+		defer gzw.close()
 		handler.ServeHTTP(gzw, r)
 	})
 }
+
+// acceptsEncoding returns true if given Accept-Encoding header value allows the encoding, i.e. 'br'.
+// Encodings with zero quality are not accepted.
+func acceptsEncoding(acceptEncoding, encoding string) bool {
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		params := strings.Split(part, ";")
+		if !strings.EqualFold(strings.TrimSpace(params[0]), encoding) {
+			continue
+		}
+
+		for _, param := range params[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				if quality, err := strconv.ParseFloat(strings.TrimPrefix(param, "q="), 64); err == nil && quality == 0 {
+					return false
+				}
+			}
+		}
+		return true
+	}
+	return false
+}
@@ -0,0 +1,80 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"compress/gzip"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMakeGzipHandler(t *testing.T) {
+	plain := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("<html></html>"))
+	})
+	encoded := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "br")
+		w.Write([]byte("brotli"))
+	})
+
+	request := httptest.NewRequest(http.MethodGet, "/", nil)
+	request.Header.Set("Accept-Encoding", "gzip")
+	recorder := httptest.NewRecorder()
+	MakeGzipHandler(plain).ServeHTTP(recorder, request)
+
+	if recorder.Header().Get("Content-Encoding") != "gzip" {
+		t.Fatalf("expected gzip encoded response, got %q", recorder.Header().Get("Content-Encoding"))
+	}
+	if contentType := recorder.Header().Get("Content-Type"); contentType != "text/html; charset=utf-8" {
+		t.Errorf("expected content type of uncompressed body, got %q", contentType)
+	}
+	reader, err := gzip.NewReader(recorder.Body)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	body, _ := ioutil.ReadAll(reader)
+	if string(body) != "<html></html>" {
+		t.Errorf("expected decompressed body to be %q, got %q", "<html></html>", string(body))
+	}
+
+	recorder = httptest.NewRecorder()
+	MakeGzipHandler(encoded).ServeHTTP(recorder, request)
+	if recorder.Header().Get("Content-Encoding") != "br" || recorder.Body.String() != "brotli" {
+		t.Errorf("expected already encoded response to be passed through, got %q encoded %q",
+			recorder.Header().Get("Content-Encoding"), recorder.Body.String())
+	}
+}
+
+func TestAcceptsEncoding(t *testing.T) {
+	cases := []struct {
+		acceptEncoding string
+		encoding       string
+		expected       bool
+	}{
+		{"gzip, deflate, br", "br", true},
+		{"gzip, deflate", "br", false},
+		{"gzip;q=0.5, br;q=0", "br", false},
+		{"GZIP", "gzip", true},
+		{"", "gzip", false},
+	}
+
+	for _, c := range cases {
+		if actual := acceptsEncoding(c.acceptEncoding, c.encoding); actual != c.expected {
+			t.Errorf("acceptsEncoding(%q, %q) == %t, expected %t", c.acceptEncoding, c.encoding, actual, c.expected)
+		}
+	}
+}
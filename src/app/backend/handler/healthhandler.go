@@ -0,0 +1,132 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/kubernetes/dashboard/src/app/backend/args"
+	clientapi "github.com/kubernetes/dashboard/src/app/backend/client/api"
+	"github.com/kubernetes/dashboard/src/app/backend/integration"
+)
+
+// apiserverCheckTimeout is a maximum time that the readiness probe waits for the apiserver to respond.
+const apiserverCheckTimeout = 5 * time.Second
+
+// HealthCheck is a single named check of a backend dependency. Check returns nil if the dependency is
+// healthy.
+type HealthCheck struct {
+	Name  string
+	Check func() error
+}
+
+// HealthCheckResult is an outcome of a single health check.
+type HealthCheckResult struct {
+	Name    string `json:"name"`
+	Healthy bool   `json:"healthy"`
+	Error   string `json:"error,omitempty"`
+}
+
+// HealthStatus is a response of the liveness and readiness endpoints.
+type HealthStatus struct {
+	// True when all of the checks passed.
+	Healthy bool                `json:"healthy"`
+	Checks  []HealthCheckResult `json:"checks"`
+}
+
+// HealthHandler serves liveness (/healthz) and readiness (/readyz) probes of the backend.
+type HealthHandler struct {
+	liveness  []HealthCheck
+	readiness []HealthCheck
+}
+
+// CreateHealthHandler creates health handler. Liveness probe checks only the things that cannot be fixed
+// without a restart, i.e. availability of static assets. Readiness probe additionally fails during shutdown and
+// checks connection to the apiserver and, if enabled, reachability of the metrics backend.
+func CreateHealthHandler(cManager clientapi.ClientManager, iManager integration.IntegrationManager) *HealthHandler {
+	assetsCheck := HealthCheck{Name: "assets", Check: checkAssets}
+	apiserverCheck := HealthCheck{Name: "apiserver", Check: func() error {
+		ctx, cancel := context.WithTimeout(context.Background(), apiserverCheckTimeout)
+		defer cancel()
+
+		_, err := cManager.InsecureClient().Discovery().RESTClient().Get().AbsPath("/healthz").Context(ctx).
+			Do().Raw()
+		return err
+	}}
+
+	readiness := []HealthCheck{assetsCheck, {Name: "shutdown", Check: checkShutdown}, apiserverCheck}
+	if args.Holder.GetEnableMetricsReadinessCheck() {
+		readiness = append(readiness, HealthCheck{Name: "metrics", Check: func() error {
+			metricClient := iManager.Metric().Client()
+			if metricClient == nil {
+				return errors.New("no metric client is active")
+			}
+			return metricClient.HealthCheck()
+		}})
+	}
+
+	return &HealthHandler{
+		liveness:  []HealthCheck{assetsCheck},
+		readiness: readiness,
+	}
+}
+
+// ServeLiveness serves liveness probe.
+func (handler *HealthHandler) ServeLiveness(w http.ResponseWriter, r *http.Request) {
+	writeHealthStatus(w, runHealthChecks(handler.liveness))
+}
+
+// ServeReadiness serves readiness probe.
+func (handler *HealthHandler) ServeReadiness(w http.ResponseWriter, r *http.Request) {
+	writeHealthStatus(w, runHealthChecks(handler.readiness))
+}
+
+func runHealthChecks(checks []HealthCheck) *HealthStatus {
+	status := &HealthStatus{Healthy: true, Checks: make([]HealthCheckResult, 0, len(checks))}
+	for _, check := range checks {
+		result := HealthCheckResult{Name: check.Name, Healthy: true}
+		if err := check.Check(); err != nil {
+			result.Healthy = false
+			result.Error = err.Error()
+			status.Healthy = false
+		}
+		status.Checks = append(status.Checks, result)
+	}
+	return status
+}
+
+func writeHealthStatus(w http.ResponseWriter, status *HealthStatus) {
+	code := http.StatusOK
+	if !status.Healthy {
+		code = http.StatusServiceUnavailable
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(status)
+}
+
+// checkAssets verifies that index.html of the default locale can be served.
+func checkAssets() error {
+	_, err := os.Stat(filepath.Join(getAssetsDir(), defaultLocaleDir, "index.html"))
+	return err
+}
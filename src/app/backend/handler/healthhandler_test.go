@@ -0,0 +1,70 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestHealthHandler(t *testing.T) {
+	healthy := HealthCheck{Name: "healthy", Check: func() error { return nil }}
+	unhealthy := HealthCheck{Name: "unhealthy", Check: func() error { return errors.New("connection refused") }}
+
+	cases := []struct {
+		checks       []HealthCheck
+		expectedCode int
+		expected     HealthStatus
+	}{
+		{
+			[]HealthCheck{healthy},
+			http.StatusOK,
+			HealthStatus{Healthy: true, Checks: []HealthCheckResult{{Name: "healthy", Healthy: true}}},
+		},
+		{
+			[]HealthCheck{healthy, unhealthy},
+			http.StatusServiceUnavailable,
+			HealthStatus{Healthy: false, Checks: []HealthCheckResult{
+				{Name: "healthy", Healthy: true},
+				{Name: "unhealthy", Healthy: false, Error: "connection refused"},
+			}},
+		},
+	}
+
+	for _, c := range cases {
+		handler := &HealthHandler{liveness: c.checks, readiness: c.checks}
+		for _, serve := range []http.HandlerFunc{handler.ServeLiveness, handler.ServeReadiness} {
+			recorder := httptest.NewRecorder()
+			serve(recorder, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+
+			if recorder.Code != c.expectedCode {
+				t.Errorf("Expected status code %d, got %d", c.expectedCode, recorder.Code)
+			}
+
+			actual := HealthStatus{}
+			if err := json.Unmarshal(recorder.Body.Bytes(), &actual); err != nil {
+				t.Fatalf("Cannot unmarshal health status: %s", err)
+			}
+
+			if !reflect.DeepEqual(actual, c.expected) {
+				t.Errorf("Expected health status %#v, got %#v", c.expected, actual)
+			}
+		}
+	}
+}
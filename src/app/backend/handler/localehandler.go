@@ -17,9 +17,13 @@ package handler
 import (
 	"encoding/json"
 	"io/ioutil"
+	"mime"
 	"net/http"
+	"net/url"
 	"os"
+	"path"
 	"path/filepath"
+	"regexp"
 	"strings"
 
 	"github.com/golang/glog"
@@ -30,6 +34,20 @@ import (
 const defaultLocaleDir = "en"
 const assetsDir = "public"
 
+// fingerprintedAssetRegexp matches names of assets, that contain hash of their content, i.e.
+// main.2b1c3d4e5f60718293a4.js. Such names are generated by the frontend build with output hashing enabled.
+var fingerprintedAssetRegexp = regexp.MustCompile(`\.[0-9a-f]{16,}\.[0-9a-z]+$`)
+
+// precompressedVariants are encodings of precompressed assets generated by the build script, in the order
+// of preference.
+var precompressedVariants = []struct {
+	encoding  string
+	extension string
+}{
+	{encoding: "br", extension: ".br"},
+	{encoding: "gzip", extension: ".gz"},
+}
+
 // Localization is a spec for the localization configuration of dashboard.
 type Localization struct {
 	Translations []string `json:"translations"`
@@ -96,19 +114,125 @@ func dirExists(name string) bool {
 	return true
 }
 
-// LocaleHandler serves different html versions based on the Accept-Language header.
+// LocaleHandler serves different html versions based on the Accept-Language header. Unknown paths, that are
+// not assets, are served index.html, so that deep links can be routed by the frontend application.
 func (handler *LocaleHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	if r.URL.EscapedPath() == "/" || r.URL.EscapedPath() == "/index.html" {
-		// Do not store the html page in the cache. If the user is to click on 'switch language',
-		// we want a different index.html (for the right locale) to be served when the page refreshes.
-		w.Header().Add("Cache-Control", "no-store")
-	}
 	acceptLanguage := os.Getenv("ACCEPT_LANGUAGE")
 	if acceptLanguage == "" {
 		acceptLanguage = r.Header.Get("Accept-Language")
 	}
 	dirName := handler.determineLocalizedDir(acceptLanguage)
-	http.FileServer(http.Dir(dirName)).ServeHTTP(w, r)
+
+	if assetPath, ok := resolveAsset(dirName, r.URL.Path); ok {
+		w.Header().Set("Cache-Control", getCacheControl(assetPath))
+		if servePrecompressed(w, r, dirName, assetPath) {
+			return
+		}
+		if assetPath != r.URL.Path {
+			r = withPath(r, assetPath)
+		}
+		http.FileServer(http.Dir(dirName)).ServeHTTP(w, r)
+		return
+	}
+
+	// Missing files are never cached, so that a fingerprinted asset, that is requested before a new version
+	// of the frontend is deployed, is not cached as not found for a year.
+	w.Header().Set("Cache-Control", "no-store")
+	if isNavigation(r) {
+		// Deep links, i.e. /pod/default/nginx, are routed by the frontend application.
+		http.ServeFile(w, r, filepath.Join(dirName, "index.html"))
+		return
+	}
+	http.NotFound(w, r)
+}
+
+// resolveAsset returns URL path of the asset, that should be served for given URL path, and whether it exists.
+// Relative references of index.html served for a deep link, i.e. /pod/default/main.js, are resolved against
+// the root of the assets directory by stripping leading path segments.
+func resolveAsset(dir, urlPath string) (string, bool) {
+	if _, err := os.Stat(filepath.Join(dir, filepath.FromSlash(path.Clean("/"+urlPath)))); err == nil {
+		return urlPath, true
+	}
+
+	segments := strings.Split(strings.Trim(path.Clean("/"+urlPath), "/"), "/")
+	for i := 1; i < len(segments); i++ {
+		assetPath := "/" + strings.Join(segments[i:], "/")
+		if info, err := os.Stat(filepath.Join(dir, filepath.FromSlash(assetPath))); err == nil && !info.IsDir() {
+			return assetPath, true
+		}
+	}
+	return "", false
+}
+
+// servePrecompressed serves precompressed variant of the asset, i.e. main.js.br for main.js, if it exists and
+// the client accepts its encoding. Brotli is preferred over gzip. Returns false, if there is no such variant.
+func servePrecompressed(w http.ResponseWriter, r *http.Request, dir, assetPath string) bool {
+	assetFile := filepath.Join(dir, filepath.FromSlash(path.Clean("/"+assetPath)))
+	for _, variant := range precompressedVariants {
+		if !acceptsEncoding(r.Header.Get("Accept-Encoding"), variant.encoding) {
+			continue
+		}
+
+		file, err := os.Open(assetFile + variant.extension)
+		if err != nil {
+			continue
+		}
+		defer file.Close()
+
+		info, err := file.Stat()
+		if err != nil || info.IsDir() {
+			continue
+		}
+
+		contentType := mime.TypeByExtension(path.Ext(assetPath))
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+		w.Header().Set("Content-Type", contentType)
+		w.Header().Set("Content-Encoding", variant.encoding)
+		w.Header().Add("Vary", "Accept-Encoding")
+		http.ServeContent(w, r, assetPath, info.ModTime(), file)
+		return true
+	}
+	return false
+}
+
+// isNavigation returns true if the request is a page load of a path, that is not an API call or an asset.
+// Paths with an extension are considered assets, unless the browser asks for html, because resource names,
+// i.e. names of pods, can contain dots.
+func isNavigation(r *http.Request) bool {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		return false
+	}
+	if strings.HasPrefix(r.URL.Path, "/api/") {
+		return false
+	}
+	return path.Ext(r.URL.Path) == "" || strings.Contains(r.Header.Get("Accept"), "text/html")
+}
+
+// withPath returns a shallow copy of the request with given URL path.
+func withPath(r *http.Request, urlPath string) *http.Request {
+	result := new(http.Request)
+	*result = *r
+	result.URL = new(url.URL)
+	*result.URL = *r.URL
+	result.URL.Path = urlPath
+	result.URL.RawPath = ""
+	return result
+}
+
+// getCacheControl returns Cache-Control header for the asset with given path. Fingerprinted assets never change,
+// so they are cached for a year. The html page is not stored in the cache. If the user is to click on
+// 'switch language', we want a different index.html (for the right locale) to be served when the page refreshes.
+// Remaining assets, i.e. icons or configuration, have to be revalidated.
+func getCacheControl(urlPath string) string {
+	if urlPath == "/" || strings.HasSuffix(urlPath, ".html") {
+		return "no-store"
+	}
+	if fingerprintedAssetRegexp.MatchString(path.Base(urlPath)) {
+		return "public, max-age=31536000, immutable"
+	}
+	return "no-cache"
 }
 
 func (handler *LocaleHandler) determineLocalizedDir(locale string) string {
@@ -17,6 +17,8 @@ package handler
 import (
 	"encoding/json"
 	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"reflect"
@@ -203,3 +205,163 @@ func TestDetermineLocale(t *testing.T) {
 		}()
 	}
 }
+
+func TestGetCacheControl(t *testing.T) {
+	cases := []struct {
+		path     string
+		expected string
+	}{
+		{"/", "no-store"},
+		{"/index.html", "no-store"},
+		{"/main.2b1c3d4e5f60718293a4.js", "public, max-age=31536000, immutable"},
+		{"/styles.0a1b2c3d4e5f60718293.css", "public, max-age=31536000, immutable"},
+		{"/assets/MaterialIcons-Regular.0a1b2c3d4e5f6071.woff2", "public, max-age=31536000, immutable"},
+		{"/main.js", "no-cache"},
+		{"/assets/images/kubernetes-logo.svg", "no-cache"},
+		{"/main.2b1c3d4e.js", "no-cache"},
+	}
+
+	for _, c := range cases {
+		actual := getCacheControl(c.path)
+		if actual != c.expected {
+			t.Errorf("getCacheControl(%s) == %s, expected %s", c.path, actual, c.expected)
+		}
+	}
+}
+
+func TestLocaleHandlerCacheControl(t *testing.T) {
+	localeDir := filepath.Join(getAssetsDir(), defaultLocaleDir)
+	if err := os.MkdirAll(localeDir, 0777); err != nil {
+		t.Fatalf("%s", err)
+	}
+	defer os.RemoveAll(getAssetsDir())
+
+	for _, name := range []string{"index.html", "main.2b1c3d4e5f60718293a4.js"} {
+		if err := ioutil.WriteFile(filepath.Join(localeDir, name), []byte(name), 0666); err != nil {
+			t.Fatalf("%s", err)
+		}
+	}
+
+	cases := []struct {
+		path         string
+		code         int
+		cacheControl string
+	}{
+		{"/", http.StatusOK, "no-store"},
+		{"/main.2b1c3d4e5f60718293a4.js", http.StatusOK, "public, max-age=31536000, immutable"},
+		{"/main.0000000000000000000.js", http.StatusNotFound, "no-store"},
+	}
+
+	handler := &LocaleHandler{SupportedLocales: languageMake([]string{"en"})}
+	for _, c := range cases {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, c.path, nil))
+
+		if recorder.Code != c.code {
+			t.Errorf("ServeHTTP(%s) code == %d, expected %d", c.path, recorder.Code, c.code)
+		}
+		if actual := recorder.Header().Get("Cache-Control"); actual != c.cacheControl {
+			t.Errorf("ServeHTTP(%s) Cache-Control == %s, expected %s", c.path, actual, c.cacheControl)
+		}
+	}
+}
+
+func TestLocaleHandlerHistoryFallback(t *testing.T) {
+	localeDir := filepath.Join(getAssetsDir(), defaultLocaleDir)
+	if err := os.MkdirAll(filepath.Join(localeDir, "assets"), 0777); err != nil {
+		t.Fatalf("%s", err)
+	}
+	defer os.RemoveAll(getAssetsDir())
+
+	for _, name := range []string{"index.html", "main.2b1c3d4e5f60718293a4.js", "assets/logo.svg"} {
+		if err := ioutil.WriteFile(filepath.Join(localeDir, name), []byte(name), 0666); err != nil {
+			t.Fatalf("%s", err)
+		}
+	}
+
+	cases := []struct {
+		method string
+		path   string
+		accept string
+		code   int
+		body   string
+	}{
+		{http.MethodGet, "/pod/default/nginx", "", http.StatusOK, "index.html"},
+		{http.MethodGet, "/pod/default/nginx-1.2", "text/html,application/xhtml+xml", http.StatusOK, "index.html"},
+		{http.MethodGet, "/pod/default/main.2b1c3d4e5f60718293a4.js", "*/*", http.StatusOK,
+			"main.2b1c3d4e5f60718293a4.js"},
+		{http.MethodGet, "/pod/default/assets/logo.svg", "*/*", http.StatusOK, "assets/logo.svg"},
+		{http.MethodGet, "/pod/default/missing.js", "*/*", http.StatusNotFound, ""},
+		{http.MethodGet, "/api/v1/unknown", "", http.StatusNotFound, ""},
+		{http.MethodPost, "/pod/default/nginx", "", http.StatusNotFound, ""},
+	}
+
+	handler := &LocaleHandler{SupportedLocales: languageMake([]string{"en"})}
+	for _, c := range cases {
+		request := httptest.NewRequest(c.method, c.path, nil)
+		request.Header.Set("Accept", c.accept)
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, request)
+
+		if recorder.Code != c.code {
+			t.Errorf("ServeHTTP(%s %s) code == %d, expected %d", c.method, c.path, recorder.Code, c.code)
+		}
+		if len(c.body) > 0 && recorder.Body.String() != c.body {
+			t.Errorf("ServeHTTP(%s %s) body == %s, expected %s", c.method, c.path, recorder.Body.String(), c.body)
+		}
+	}
+}
+
+func TestLocaleHandlerPrecompressed(t *testing.T) {
+	localeDir := filepath.Join(getAssetsDir(), defaultLocaleDir)
+	if err := os.MkdirAll(localeDir, 0777); err != nil {
+		t.Fatalf("%s", err)
+	}
+	defer os.RemoveAll(getAssetsDir())
+
+	files := map[string]string{
+		"main.js":       "main",
+		"main.js.br":    "brotli",
+		"main.js.gz":    "gzip",
+		"styles.css":    "styles",
+		"styles.css.gz": "gzip",
+	}
+	for name, content := range files {
+		if err := ioutil.WriteFile(filepath.Join(localeDir, name), []byte(content), 0666); err != nil {
+			t.Fatalf("%s", err)
+		}
+	}
+
+	cases := []struct {
+		path           string
+		acceptEncoding string
+		encoding       string
+		body           string
+	}{
+		{"/main.js", "gzip, deflate, br", "br", "brotli"},
+		{"/main.js", "gzip, br;q=0", "gzip", "gzip"},
+		{"/main.js", "", "", "main"},
+		{"/styles.css", "br", "", "styles"},
+		{"/styles.css", "br, gzip", "gzip", "gzip"},
+	}
+
+	handler := &LocaleHandler{SupportedLocales: languageMake([]string{"en"})}
+	for _, c := range cases {
+		request := httptest.NewRequest(http.MethodGet, c.path, nil)
+		request.Header.Set("Accept-Encoding", c.acceptEncoding)
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, request)
+
+		if actual := recorder.Header().Get("Content-Encoding"); actual != c.encoding {
+			t.Errorf("ServeHTTP(%s, %s) Content-Encoding == %s, expected %s", c.path, c.acceptEncoding, actual,
+				c.encoding)
+		}
+		if recorder.Body.String() != c.body {
+			t.Errorf("ServeHTTP(%s, %s) body == %s, expected %s", c.path, c.acceptEncoding, recorder.Body.String(),
+				c.body)
+		}
+		if len(c.encoding) > 0 && recorder.Header().Get("Content-Type") == "" {
+			t.Errorf("ServeHTTP(%s, %s) expected Content-Type of the uncompressed asset", c.path, c.acceptEncoding)
+		}
+	}
+}
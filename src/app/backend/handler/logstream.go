@@ -0,0 +1,176 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+
+	restful "github.com/emicklei/go-restful"
+	"github.com/gorilla/websocket"
+
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/container"
+)
+
+// LogWebSocketProtocol is a WebSocket subprotocol of log streams. Every text message is a single log line.
+const LogWebSocketProtocol = "v1.log.dashboard.k8s.io"
+
+// logUpgrader upgrades log stream requests to WebSocket connections. Cross-origin requests are rejected.
+var logUpgrader = websocket.Upgrader{Subprotocols: []string{LogWebSocketProtocol}}
+
+// handleLogStream streams logs of a pod container. WebSocket upgrade requests get every line as a text message,
+// other requests get chunked plain text response. With 'follow=true' the stream is kept open until the
+// container stops, the client disconnects or Dashboard shuts down.
+func (apiHandler *APIHandler) handleLogStream(request *restful.Request, response *restful.Response) {
+	isWebSocket := websocket.IsWebSocketUpgrade(request.Request)
+	if isWebSocket {
+		useWebSocketCredentials(request.Request)
+	}
+
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	options, err := parseLogStreamOptions(request)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	namespace := request.PathParameter("namespace")
+	podID := request.PathParameter("pod")
+	stream, err := container.OpenLogStream(k8sClient, namespace, podID, request.PathParameter("container"), options)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	if isWebSocket {
+		err = streamLogsWebSocket(request, response, stream)
+	} else {
+		err = streamLogsChunked(request, response, stream)
+	}
+	if err != nil {
+		log.Printf("Log stream of pod %s/%s closed with error: %s", namespace, podID, err.Error())
+	}
+}
+
+// streamLogsChunked writes log lines to the response, flushing after every line.
+func streamLogsChunked(request *restful.Request, response *restful.Response, stream io.ReadCloser) error {
+	response.AddHeader(restful.HEADER_ContentType, "text/plain")
+	response.WriteHeader(http.StatusOK)
+	response.Flush()
+
+	return container.StreamLogs(stream, streamDone(request.Request.Context()), func(line string) error {
+		if _, err := io.WriteString(response, line+"\n"); err != nil {
+			return err
+		}
+		response.Flush()
+		return nil
+	})
+}
+
+// streamLogsWebSocket sends log lines as WebSocket text messages. Connection is closed with normal closure once
+// the stream ends.
+func streamLogsWebSocket(request *restful.Request, response *restful.Response, stream io.ReadCloser) error {
+	// Upgrade replies with an error itself when it fails.
+	streamClosed := trackHijackedStream()
+	defer streamClosed()
+	conn, err := logUpgrader.Upgrade(response.ResponseWriter, request.Request, nil)
+	if err != nil {
+		stream.Close()
+		return err
+	}
+	defer conn.Close()
+
+	// Hijacked connections are not tracked by the request context, so client disconnects are detected by
+	// reading from the connection. Messages sent by the client are ignored.
+	disconnected := make(chan struct{})
+	go func() {
+		defer close(disconnected)
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+
+	stopCh := make(chan struct{})
+	go func() {
+		defer close(stopCh)
+		select {
+		case <-disconnected:
+		case <-shutdown:
+		}
+	}()
+
+	err = container.StreamLogs(stream, stopCh, func(line string) error {
+		return conn.WriteMessage(websocket.TextMessage, []byte(line))
+	})
+
+	code, reason := websocket.CloseNormalClosure, "Log stream ended"
+	select {
+	case <-shutdown:
+		code, reason = websocket.CloseGoingAway, "Dashboard is shutting down"
+	default:
+		if err != nil {
+			code, reason = websocket.CloseInternalServerErr, err.Error()
+		}
+	}
+	if len(reason) > maxCloseReasonLength {
+		reason = reason[:maxCloseReasonLength]
+	}
+	conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(code, reason))
+	return err
+}
+
+// parseLogStreamOptions parses 'follow', 'previous', 'timestamps', 'sinceSeconds' and 'tailLines' query
+// parameters.
+func parseLogStreamOptions(request *restful.Request) (*container.LogStreamOptions, error) {
+	options := &container.LogStreamOptions{
+		Follow:     request.QueryParameter("follow") == "true",
+		Previous:   request.QueryParameter("previous") == "true",
+		Timestamps: request.QueryParameter("timestamps") == "true",
+	}
+
+	var err error
+	if options.SinceSeconds, err = parseOptionalInt64(request, "sinceSeconds"); err != nil {
+		return nil, err
+	}
+	if options.TailLines, err = parseOptionalInt64(request, "tailLines"); err != nil {
+		return nil, err
+	}
+
+	return options, nil
+}
+
+func parseOptionalInt64(request *restful.Request, name string) (*int64, error) {
+	param := request.QueryParameter(name)
+	if len(param) == 0 {
+		return nil, nil
+	}
+
+	value, err := strconv.ParseInt(param, 10, 64)
+	if err != nil {
+		return nil, errors.NewBadRequest(fmt.Sprintf("invalid %s query parameter: %s", name, param))
+	}
+	return &value, nil
+}
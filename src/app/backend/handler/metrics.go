@@ -47,6 +47,28 @@ var (
 		},
 		[]string{"verb", "resource"},
 	)
+	routeRequestCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "dashboard_api_requests_total",
+			Help: "Counter of requests to the dashboard API broken out for each method, route and HTTP response code.",
+		},
+		[]string{"method", "route", "code"},
+	)
+	routeRequestDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name: "dashboard_api_request_duration_seconds",
+			Help: "Response latency distribution in seconds for each method and route of the dashboard API.",
+			// Use buckets ranging from 5 ms to ~20 seconds.
+			Buckets: prometheus.ExponentialBuckets(0.005, 2.0, 13),
+		},
+		[]string{"method", "route"},
+	)
+	throttledRequestCounter = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "dashboard_api_throttled_requests_total",
+			Help: "Counter of requests to the dashboard API rejected, because the client has too many requests in flight.",
+		},
+	)
 )
 
 // Initialize all metrics in prometheus
@@ -54,6 +76,9 @@ func init() {
 	prometheus.MustRegister(requestCounter)
 	prometheus.MustRegister(requestLatencies)
 	prometheus.MustRegister(requestLatenciesSummary)
+	prometheus.MustRegister(routeRequestCounter)
+	prometheus.MustRegister(routeRequestDuration)
+	prometheus.MustRegister(throttledRequestCounter)
 }
 
 // Track API call in prometheus
@@ -63,3 +88,10 @@ func monitor(verb, resource string, client, contentType string, httpCode int, re
 	requestLatencies.WithLabelValues(verb, resource).Observe(elapsed)
 	requestLatenciesSummary.WithLabelValues(verb, resource).Observe(elapsed)
 }
+
+// Track API call per route in prometheus. Route is a path template, i.e. /api/v1/pod/{namespace}/{pod}, so
+// the number of label values is bounded by the number of registered routes.
+func monitorRoute(method, route string, httpCode int, reqStart time.Time) {
+	routeRequestCounter.WithLabelValues(method, route, strconv.Itoa(httpCode)).Inc()
+	routeRequestDuration.WithLabelValues(method, route).Observe(time.Since(reqStart).Seconds())
+}
@@ -0,0 +1,242 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httputil"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/emicklei/go-restful"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/rest"
+
+	"github.com/kubernetes/dashboard/src/app/backend/client"
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	featuresApi "github.com/kubernetes/dashboard/src/app/backend/features/api"
+	settingsApi "github.com/kubernetes/dashboard/src/app/backend/settings/api"
+)
+
+// proxyFlushInterval is how often proxied responses are flushed to the client, so watch requests are not
+// buffered.
+const proxyFlushInterval = 100 * time.Millisecond
+
+// Headers used to authenticate against Dashboard. They must not be forwarded to the apiserver, because user
+// credentials are already part of the rest config transport.
+var proxyStrippedHeaders = []string{"Authorization", client.JWETokenHeader, "Cookie", "X-CSRF-TOKEN"}
+
+// apiServerRequest describes the resource targeted by a request proxied to the apiserver.
+type apiServerRequest struct {
+	// isResource is false for requests of paths that do not point to resources, i.e. /version or /apis.
+	isResource  bool
+	group       string
+	version     string
+	namespace   string
+	resource    string
+	name        string
+	subresource string
+}
+
+// parseAPIServerPath parses given apiserver path, i.e. /apis/apps/v1/namespaces/default/deployments/app/scale.
+// Namespace of requests targeting namespace object itself is set to its name.
+func parseAPIServerPath(subpath string) apiServerRequest {
+	parts := strings.Split(strings.Trim(path.Clean("/"+subpath), "/"), "/")
+	result := apiServerRequest{}
+	switch {
+	case len(parts) >= 3 && parts[0] == "api":
+		result.version, parts = parts[1], parts[2:]
+	case len(parts) >= 4 && parts[0] == "apis":
+		result.group, result.version, parts = parts[1], parts[2], parts[3:]
+	default:
+		return result
+	}
+
+	// Deprecated watch paths have the same structure with additional prefix.
+	if parts[0] == "watch" {
+		parts = parts[1:]
+	}
+
+	if len(parts) >= 3 && parts[0] == "namespaces" {
+		result.namespace, parts = parts[1], parts[2:]
+	}
+
+	if len(parts) == 0 {
+		return result
+	}
+
+	result.isResource = true
+	result.resource = parts[0]
+	if len(parts) > 1 {
+		result.name = parts[1]
+	}
+	if len(parts) > 2 {
+		result.subresource = parts[2]
+	}
+	if result.group == "" && result.resource == "namespaces" {
+		result.namespace = result.name
+	}
+
+	return result
+}
+
+// proxyFeature returns feature that has to be enabled to proxy request with given method to the apiserver.
+// Empty string is returned when request is not gated.
+func proxyFeature(method string, target apiServerRequest) featuresApi.Feature {
+	switch {
+	case target.subresource == "exec" || target.subresource == "attach" || target.subresource == "portforward":
+		return featuresApi.Exec
+	case target.subresource == "log":
+		return featuresApi.LogsDownload
+	case method == http.MethodPost || method == http.MethodPut || method == http.MethodPatch:
+		return featuresApi.AppDeployment
+	}
+
+	return ""
+}
+
+// checkProxyRequest applies feature gates, hidden resource kinds, namespace restrictions and tenant namespace to
+// the request proxied to the apiserver, the same way as the filters do for other routes. Resource kind and
+// scope are looked up in the discovery only when restrictions are configured.
+func checkProxyRequest(fManager featuresApi.FeatureManager, s settingsApi.Settings,
+	client discovery.DiscoveryInterface, tenant, method string, target apiServerRequest) error {
+	if feature := proxyFeature(method, target); len(feature) > 0 && !fManager.IsEnabled(feature) {
+		return errors.NewForbidden(errors.MsgFeatureDisabledError)
+	}
+
+	if !target.isResource || (len(s.HiddenResourceKinds) == 0 && !s.HasNamespaceRestrictions() && len(tenant) == 0) {
+		return nil
+	}
+
+	kind, namespaced, err := lookupAPIResource(client, target)
+	if err != nil {
+		return err
+	}
+
+	if s.IsResourceKindHidden(kind) {
+		return errors.NewForbidden(errors.MsgResourceKindHiddenError)
+	}
+
+	if len(target.namespace) == 0 {
+		// Namespaced resources requested without a namespace are listed or watched in all namespaces. The same
+		// applies to the list of namespaces itself.
+		allNamespaces := namespaced || (target.group == "" && target.resource == "namespaces")
+		if allNamespaces && len(tenant) > 0 {
+			return errors.NewForbidden(errors.MsgTenantNamespaceError)
+		}
+		if allNamespaces && s.HasNamespaceRestrictions() {
+			return errors.NewForbidden(errors.MsgNamespaceRestrictedError)
+		}
+		return nil
+	}
+
+	if len(tenant) > 0 && target.namespace != tenant {
+		return errors.NewForbidden(errors.MsgTenantNamespaceError)
+	}
+	if !s.IsNamespaceAllowed(target.namespace) {
+		return errors.NewForbidden(errors.MsgNamespaceRestrictedError)
+	}
+
+	return nil
+}
+
+// lookupAPIResource returns kind of the resource targeted by the request and whether it is namespaced.
+func lookupAPIResource(client discovery.DiscoveryInterface, target apiServerRequest) (string, bool, error) {
+	groupVersion := schema.GroupVersion{Group: target.group, Version: target.version}.String()
+	resources, err := client.ServerResourcesForGroupVersion(groupVersion)
+	if err != nil {
+		return "", false, err
+	}
+
+	for _, resource := range resources.APIResources {
+		if resource.Name == target.resource {
+			return resource.Kind, resource.Namespaced, nil
+		}
+	}
+
+	return "", false, errors.NewNotFound(fmt.Sprintf("resource %s not found in %s", target.resource, groupVersion))
+}
+
+func (apiHandler *APIHandler) handleProxy(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	subpath := request.PathParameter("subpath")
+	s := apiHandler.sManager.GetGlobalSettings(apiHandler.cManager.InsecureClient())
+	err = checkProxyRequest(apiHandler.fManager, s, k8sClient.Discovery(), TenantNamespace(request.Request),
+		request.Request.Method, parseAPIServerPath(subpath))
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	cfg, err := apiHandler.cManager.Config(request)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	proxy, err := newAPIServerProxy(cfg, subpath)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	proxy.ServeHTTP(response, request.Request)
+}
+
+// newAPIServerProxy creates reverse proxy that forwards request to given path of the apiserver using
+// credentials and TLS settings of given config.
+func newAPIServerProxy(cfg *rest.Config, subpath string) (*httputil.ReverseProxy, error) {
+	target, _, err := rest.DefaultServerURL(cfg.Host, "", schema.GroupVersion{}, rest.IsConfigTransportTLS(*cfg))
+	if err != nil {
+		return nil, err
+	}
+
+	transport, err := rest.TransportFor(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	director := func(req *http.Request) {
+		req.URL.Scheme = target.Scheme
+		req.URL.Host = target.Host
+		req.URL.Path = path.Join("/", target.Path, subpath)
+		req.Host = target.Host
+
+		for _, header := range proxyStrippedHeaders {
+			req.Header.Del(header)
+		}
+
+		// Impersonation is already configured in the transport based on the original request.
+		for header := range req.Header {
+			if strings.HasPrefix(header, "Impersonate-") {
+				req.Header.Del(header)
+			}
+		}
+	}
+
+	return &httputil.ReverseProxy{
+		Director:      director,
+		Transport:     transport,
+		FlushInterval: proxyFlushInterval,
+	}, nil
+}
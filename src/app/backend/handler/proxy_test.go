@@ -0,0 +1,162 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+
+	"github.com/kubernetes/dashboard/src/app/backend/client"
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/features"
+	settingsApi "github.com/kubernetes/dashboard/src/app/backend/settings/api"
+)
+
+func TestAPIServerProxy(t *testing.T) {
+	var upstream *http.Request
+	apiserver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		upstream = r
+		w.WriteHeader(http.StatusTeapot)
+	}))
+	defer apiserver.Close()
+
+	proxy, err := newAPIServerProxy(&rest.Config{Host: apiserver.URL, BearerToken: "user-token"},
+		"apis/example.com/v1/widgets")
+	if err != nil {
+		t.Fatalf("newAPIServerProxy returned unexpected error: %s", err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/proxy/apis/example.com/v1/widgets?watch=true", nil)
+	req.Header.Set("Authorization", "Bearer dashboard-token")
+	req.Header.Set(client.JWETokenHeader, "jwe")
+	req.Header.Set("Impersonate-User", "admin")
+	recorder := httptest.NewRecorder()
+	proxy.ServeHTTP(recorder, req)
+
+	if recorder.Code != http.StatusTeapot {
+		t.Errorf("Expected status code %d, got %d", http.StatusTeapot, recorder.Code)
+	}
+
+	if upstream == nil {
+		t.Fatal("Expected request to be forwarded to the apiserver")
+	}
+
+	if upstream.URL.Path != "/apis/example.com/v1/widgets" || upstream.URL.RawQuery != "watch=true" {
+		t.Errorf("Unexpected forwarded URL: %s", upstream.URL)
+	}
+
+	if auth := upstream.Header.Get("Authorization"); auth != "Bearer user-token" {
+		t.Errorf("Expected Authorization header 'Bearer user-token', got %q", auth)
+	}
+
+	for _, header := range []string{client.JWETokenHeader, "Impersonate-User"} {
+		if len(upstream.Header.Get(header)) > 0 {
+			t.Errorf("Expected %s header to be stripped", header)
+		}
+	}
+}
+
+func TestParseAPIServerPath(t *testing.T) {
+	cases := []struct {
+		subpath  string
+		expected apiServerRequest
+	}{
+		{"version", apiServerRequest{}},
+		{"apis/apps/v1", apiServerRequest{}},
+		{"api/v1/pods", apiServerRequest{isResource: true, version: "v1", resource: "pods"}},
+		{"api/v1/namespaces/default/pods/foo/exec", apiServerRequest{isResource: true, version: "v1",
+			namespace: "default", resource: "pods", name: "foo", subresource: "exec"}},
+		{"api/v1/namespaces/kube-system", apiServerRequest{isResource: true, version: "v1",
+			namespace: "kube-system", resource: "namespaces", name: "kube-system"}},
+		{"apis/apps/v1/watch/namespaces/default/deployments", apiServerRequest{isResource: true, group: "apps",
+			version: "v1", namespace: "default", resource: "deployments"}},
+		{"api/v1/namespaces/default/../kube-system/secrets", apiServerRequest{isResource: true, version: "v1",
+			namespace: "kube-system", resource: "secrets"}},
+	}
+
+	for _, c := range cases {
+		if actual := parseAPIServerPath(c.subpath); !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("parseAPIServerPath(%q) == %+v, expected %+v", c.subpath, actual, c.expected)
+		}
+	}
+}
+
+func TestCheckProxyRequest(t *testing.T) {
+	k8sClient := fake.NewSimpleClientset()
+	k8sClient.Resources = []*metav1.APIResourceList{{
+		GroupVersion: "v1",
+		APIResources: []metav1.APIResource{
+			{Name: "pods", Kind: "Pod", Namespaced: true},
+			{Name: "secrets", Kind: "Secret", Namespaced: true},
+			{Name: "namespaces", Kind: "Namespace"},
+			{Name: "nodes", Kind: "Node"},
+		},
+	}}
+	restricted := settingsApi.Settings{DeniedNamespaces: []string{"kube-system"}, HiddenResourceKinds: []string{"secret"}}
+	enabled, _ := features.NewFeatureManager("")
+	disabled, _ := features.NewFeatureManager("exec=false,appDeployment=false")
+
+	cases := []struct {
+		settings settingsApi.Settings
+		disabled bool
+		tenant   string
+		method   string
+		subpath  string
+		expected string
+	}{
+		{restricted, false, "", http.MethodGet, "api/v1/namespaces/default/pods", ""},
+		{restricted, false, "", http.MethodGet, "api/v1/namespaces/kube-system/pods",
+			errors.MsgNamespaceRestrictedError},
+		{restricted, false, "", http.MethodGet, "api/v1/namespaces/kube-system", errors.MsgNamespaceRestrictedError},
+		{restricted, false, "", http.MethodGet, "api/v1/pods", errors.MsgNamespaceRestrictedError},
+		{restricted, false, "", http.MethodGet, "api/v1/namespaces", errors.MsgNamespaceRestrictedError},
+		{restricted, false, "", http.MethodGet, "api/v1/nodes", ""},
+		{restricted, false, "", http.MethodGet, "api/v1/namespaces/default/secrets/foo",
+			errors.MsgResourceKindHiddenError},
+		{settingsApi.Settings{}, false, "team-a", http.MethodGet, "api/v1/namespaces/team-a/pods", ""},
+		{settingsApi.Settings{}, false, "team-a", http.MethodGet, "api/v1/namespaces/team-b/pods",
+			errors.MsgTenantNamespaceError},
+		{settingsApi.Settings{}, false, "team-a", http.MethodGet, "api/v1/pods", errors.MsgTenantNamespaceError},
+		{settingsApi.Settings{}, false, "", http.MethodGet, "api/v1/pods", ""},
+		{settingsApi.Settings{}, true, "", http.MethodGet, "api/v1/pods", ""},
+		{settingsApi.Settings{}, true, "", http.MethodPost, "api/v1/namespaces/default/pods",
+			errors.MsgFeatureDisabledError},
+		{settingsApi.Settings{}, true, "", http.MethodGet, "api/v1/namespaces/default/pods/foo/exec",
+			errors.MsgFeatureDisabledError},
+	}
+
+	for _, c := range cases {
+		fManager := enabled
+		if c.disabled {
+			fManager = disabled
+		}
+
+		err := checkProxyRequest(fManager, c.settings, k8sClient.Discovery(), c.tenant, c.method,
+			parseAPIServerPath(c.subpath))
+		actual := ""
+		if err != nil {
+			actual = err.Error()
+		}
+		if actual != c.expected {
+			t.Errorf("checkProxyRequest(%s %s) returned %q, expected %q", c.method, c.subpath, actual, c.expected)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/emicklei/go-restful"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+)
+
+// MIMEYAML is a content type used to exchange raw resources in YAML format.
+const MIMEYAML = "application/yaml"
+
+// patchMIMETypes lists content types accepted by the raw resource patch endpoint. Plain JSON and YAML bodies are
+// applied as strategic merge patches.
+var patchMIMETypes = []string{
+	string(types.StrategicMergePatchType),
+	string(types.MergePatchType),
+	string(types.JSONPatchType),
+	restful.MIME_JSON,
+	MIMEYAML,
+}
+
+// isYAML returns true if given Accept or Content-Type header value asks for YAML.
+func isYAML(header string) bool {
+	return strings.Contains(header, MIMEYAML)
+}
+
+// writeRawObject writes object returned by the apiserver in format requested by the Accept header. JSON is
+// used by default.
+func writeRawObject(request *restful.Request, response *restful.Response, object runtime.Object) error {
+	if !isYAML(request.HeaderParameter("Accept")) {
+		return response.WriteHeaderAndEntity(http.StatusOK, object)
+	}
+
+	data, err := yaml.Marshal(object)
+	if err != nil {
+		return err
+	}
+
+	response.AddHeader("Content-Type", MIMEYAML)
+	response.WriteHeader(http.StatusOK)
+	_, err = response.Write(data)
+	return err
+}
+
+// readRawObject reads raw object from the request body. Body is converted to JSON if Content-Type header
+// indicates YAML.
+func readRawObject(request *restful.Request) (*runtime.Unknown, error) {
+	object := &runtime.Unknown{}
+	if !isYAML(request.HeaderParameter("Content-Type")) {
+		if err := request.ReadEntity(object); err != nil {
+			return nil, errors.NewBadRequest(err.Error())
+		}
+		return object, nil
+	}
+
+	data, err := ioutil.ReadAll(request.Request.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	object.Raw, err = yaml.YAMLToJSON(data)
+	if err != nil {
+		return nil, errors.NewBadRequest(err.Error())
+	}
+	object.ContentType = runtime.ContentTypeJSON
+	return object, nil
+}
+
+// readRawPatch reads patch from the request body and returns it together with its type deduced from the
+// Content-Type header. YAML body is converted to JSON and, same as plain JSON, treated as a strategic merge patch.
+func readRawPatch(request *restful.Request) (types.PatchType, []byte, error) {
+	data, err := ioutil.ReadAll(request.Request.Body)
+	if err != nil {
+		return "", nil, err
+	}
+
+	contentType := request.HeaderParameter("Content-Type")
+	if isYAML(contentType) {
+		if data, err = yaml.YAMLToJSON(data); err != nil {
+			return "", nil, errors.NewBadRequest(err.Error())
+		}
+		return types.StrategicMergePatchType, data, nil
+	}
+
+	for _, patchType := range []types.PatchType{types.StrategicMergePatchType, types.MergePatchType,
+		types.JSONPatchType} {
+		if strings.HasPrefix(contentType, string(patchType)) {
+			return patchType, data, nil
+		}
+	}
+
+	return types.StrategicMergePatchType, data, nil
+}
@@ -0,0 +1,107 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/emicklei/go-restful"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestWriteRawObjectYAML(t *testing.T) {
+	httpReq, _ := http.NewRequest(http.MethodGet, "/api/v1/_raw/pod/default/foo", nil)
+	httpReq.Header.Set("Accept", MIMEYAML)
+	recorder := httptest.NewRecorder()
+
+	object := &runtime.Unknown{Raw: []byte(`{"kind":"Pod","metadata":{"name":"foo"}}`)}
+	if err := writeRawObject(restful.NewRequest(httpReq), restful.NewResponse(recorder), object); err != nil {
+		t.Fatalf("writeRawObject returned unexpected error: %s", err)
+	}
+
+	expected := "kind: Pod\nmetadata:\n  name: foo\n"
+	if recorder.Body.String() != expected {
+		t.Errorf("Expected body %q, got %q", expected, recorder.Body.String())
+	}
+
+	if contentType := recorder.Header().Get("Content-Type"); contentType != MIMEYAML {
+		t.Errorf("Expected content type %s, got %s", MIMEYAML, contentType)
+	}
+}
+
+func TestReadRawObject(t *testing.T) {
+	cases := []struct {
+		contentType string
+		body        string
+		expected    string
+	}{
+		{restful.MIME_JSON, `{"kind":"Pod"}`, `{"kind":"Pod"}`},
+		{MIMEYAML, "kind: Pod\nmetadata:\n  name: foo\n", `{"kind":"Pod","metadata":{"name":"foo"}}`},
+	}
+
+	for _, c := range cases {
+		httpReq, _ := http.NewRequest(http.MethodPut, "/api/v1/_raw/pod/default/foo", strings.NewReader(c.body))
+		httpReq.Header.Set("Content-Type", c.contentType)
+
+		actual, err := readRawObject(restful.NewRequest(httpReq))
+		if err != nil {
+			t.Errorf("readRawObject(%s) returned unexpected error: %s", c.contentType, err)
+			continue
+		}
+
+		if string(actual.Raw) != c.expected {
+			t.Errorf("readRawObject(%s) == %s, expected %s", c.contentType, actual.Raw, c.expected)
+		}
+	}
+}
+
+func TestReadRawPatch(t *testing.T) {
+	cases := []struct {
+		contentType       string
+		body              string
+		expectedPatchType types.PatchType
+		expected          string
+	}{
+		{restful.MIME_JSON, `{"spec":{"replicas":2}}`, types.StrategicMergePatchType, `{"spec":{"replicas":2}}`},
+		{MIMEYAML, "spec:\n  replicas: 2\n", types.StrategicMergePatchType, `{"spec":{"replicas":2}}`},
+		{string(types.MergePatchType), `{"spec":{"replicas":2}}`, types.MergePatchType, `{"spec":{"replicas":2}}`},
+		{string(types.JSONPatchType), `[{"op":"remove","path":"/spec/replicas"}]`, types.JSONPatchType,
+			`[{"op":"remove","path":"/spec/replicas"}]`},
+	}
+
+	for _, c := range cases {
+		httpReq, _ := http.NewRequest(http.MethodPatch, "/api/v1/_raw/deployment/namespace/default/name/foo",
+			strings.NewReader(c.body))
+		httpReq.Header.Set("Content-Type", c.contentType)
+
+		patchType, actual, err := readRawPatch(restful.NewRequest(httpReq))
+		if err != nil {
+			t.Errorf("readRawPatch(%s) returned unexpected error: %s", c.contentType, err)
+			continue
+		}
+
+		if patchType != c.expectedPatchType {
+			t.Errorf("readRawPatch(%s) patch type == %s, expected %s", c.contentType, patchType, c.expectedPatchType)
+		}
+
+		if string(actual) != c.expected {
+			t.Errorf("readRawPatch(%s) == %s, expected %s", c.contentType, actual, c.expected)
+		}
+	}
+}
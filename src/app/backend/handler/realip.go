@@ -0,0 +1,118 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/kubernetes/dashboard/src/app/backend/client"
+)
+
+// ParseTrustedProxies parses CIDRs of trusted proxies, i.e. 10.0.0.0/8. Single IP addresses are accepted as well.
+func ParseTrustedProxies(proxies []string) ([]*net.IPNet, error) {
+	result := make([]*net.IPNet, 0, len(proxies))
+	for _, proxy := range proxies {
+		proxy = strings.TrimSpace(proxy)
+		if ip := net.ParseIP(proxy); ip != nil {
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 8*net.IPv4len
+			}
+			result = append(result, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+
+		_, cidr, err := net.ParseCIDR(proxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy %s: %s", proxy, err)
+		}
+		result = append(result, cidr)
+	}
+	return result, nil
+}
+
+// CreateRealIPHandler replaces remote address of requests sent by trusted proxies, i.e. an ingress controller,
+// with the address of the client, that the proxy received the request from. X-Forwarded-For is walked from
+// the right, so that addresses prepended by the client are ignored, then X-Real-IP is used. Forwarding headers
+// of requests sent directly by clients are removed, because they can be spoofed. Requests, whose connection comes
+// from one of impersonating proxies, are marked, so that impersonation headers set by them can be used, see
+// --enable-proxy-impersonation. Forwarding headers are never used for that.
+func CreateRealIPHandler(handler http.Handler, trustedProxies, impersonatingProxies []*net.IPNet) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		peer := remoteIP(r)
+		if isTrustedProxy(peer, impersonatingProxies) {
+			r = client.WithImpersonatingProxy(r)
+		}
+
+		if !isTrustedProxy(peer, trustedProxies) {
+			r.Header.Del("X-Forwarded-For")
+			r.Header.Del("X-Real-IP")
+			handler.ServeHTTP(w, r)
+			return
+		}
+
+		if ip := forwardedClientIP(r, trustedProxies); ip != nil {
+			r.RemoteAddr = net.JoinHostPort(ip.String(), "0")
+		}
+		handler.ServeHTTP(w, r)
+	})
+}
+
+// forwardedClientIP returns the rightmost address from X-Forwarded-For, that is not a trusted proxy, or
+// X-Real-IP if there is no such address. Returns nil if neither of the headers is set.
+func forwardedClientIP(r *http.Request, trustedProxies []*net.IPNet) net.IP {
+	addresses := strings.Split(strings.Join(r.Header["X-Forwarded-For"], ","), ",")
+	var leftmost net.IP
+	for i := len(addresses) - 1; i >= 0; i-- {
+		ip := net.ParseIP(strings.TrimSpace(addresses[i]))
+		if ip == nil {
+			// Chain can not be trusted beyond a malformed entry.
+			break
+		}
+		if !isTrustedProxy(ip, trustedProxies) {
+			return ip
+		}
+		leftmost = ip
+	}
+
+	if ip := net.ParseIP(strings.TrimSpace(r.Header.Get("X-Real-IP"))); ip != nil {
+		return ip
+	}
+	return leftmost
+}
+
+func isTrustedProxy(ip net.IP, trustedProxies []*net.IPNet) bool {
+	if ip == nil {
+		return false
+	}
+	for _, proxy := range trustedProxies {
+		if proxy.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// remoteIP returns IP address of the remote address of the request, or nil if it can not be parsed.
+func remoteIP(r *http.Request) net.IP {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	return net.ParseIP(host)
+}
@@ -0,0 +1,128 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	restful "github.com/emicklei/go-restful"
+
+	"github.com/kubernetes/dashboard/src/app/backend/args"
+	"github.com/kubernetes/dashboard/src/app/backend/client"
+)
+
+func TestParseTrustedProxies(t *testing.T) {
+	proxies, err := ParseTrustedProxies([]string{"10.0.0.0/8", "192.168.1.1", "fd00::/8"})
+	if err != nil {
+		t.Fatalf("ParseTrustedProxies() returned error: %s", err)
+	}
+	if len(proxies) != 3 || proxies[1].String() != "192.168.1.1/32" {
+		t.Errorf("ParseTrustedProxies() == %v, expected single address as /32 network", proxies)
+	}
+
+	if _, err := ParseTrustedProxies([]string{"10.0.0.0/33"}); err == nil {
+		t.Error("ParseTrustedProxies() expected error for invalid CIDR")
+	}
+}
+
+func TestCreateRealIPHandler(t *testing.T) {
+	trustedProxies, _ := ParseTrustedProxies([]string{"10.0.0.0/8"})
+	handler := CreateRealIPHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(remoteIP(r).String() + " " + r.Header.Get("X-Forwarded-For")))
+	}), trustedProxies, nil)
+
+	cases := []struct {
+		remoteAddr   string
+		forwardedFor string
+		realIP       string
+		expected     string
+	}{
+		// Headers of clients, that are not trusted proxies, are ignored and removed.
+		{"203.0.113.7:1234", "198.51.100.1", "", "203.0.113.7 "},
+		{"10.0.0.1:1234", "198.51.100.1", "", "198.51.100.1 198.51.100.1"},
+		// Addresses prepended by the client itself are not trusted.
+		{"10.0.0.1:1234", "192.0.2.1, 198.51.100.1, 10.0.0.2", "", "198.51.100.1 192.0.2.1, 198.51.100.1, 10.0.0.2"},
+		{"10.0.0.1:1234", "", "198.51.100.1", "198.51.100.1 "},
+		{"10.0.0.1:1234", "10.0.0.3", "", "10.0.0.3 10.0.0.3"},
+		{"10.0.0.1:1234", "", "", "10.0.0.1 "},
+	}
+
+	for _, c := range cases {
+		request := httptest.NewRequest(http.MethodGet, "/api/v1/pod", nil)
+		request.RemoteAddr = c.remoteAddr
+		if len(c.forwardedFor) > 0 {
+			request.Header.Set("X-Forwarded-For", c.forwardedFor)
+		}
+		if len(c.realIP) > 0 {
+			request.Header.Set("X-Real-IP", c.realIP)
+		}
+
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, request)
+		if recorder.Body.String() != c.expected {
+			t.Errorf("Request from %s with X-Forwarded-For %q and X-Real-IP %q was handled as %q, expected %q",
+				c.remoteAddr, c.forwardedFor, c.realIP, recorder.Body.String(), c.expected)
+		}
+	}
+}
+
+func TestCreateRealIPHandlerImpersonation(t *testing.T) {
+	defer args.GetHolderBuilder().SetEnableProxyImpersonation(false).SetEnableSkipLogin(false)
+	args.GetHolderBuilder().SetEnableProxyImpersonation(true).SetEnableSkipLogin(true)
+
+	trustedProxies, _ := ParseTrustedProxies([]string{"10.0.0.0/8"})
+	impersonatingProxies, _ := ParseTrustedProxies([]string{"10.1.0.1"})
+	manager := client.NewClientManager("", "https://localhost:8080")
+	handler := CreateRealIPHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.TLS = &tls.ConnectionState{}
+		cfg, err := manager.Config(restful.NewRequest(r))
+		if err != nil {
+			t.Fatalf("Config() returned error: %s", err)
+		}
+		w.Write([]byte(cfg.Impersonate.UserName))
+	}), trustedProxies, impersonatingProxies)
+
+	cases := []struct {
+		info         string
+		remoteAddr   string
+		forwardedFor string
+		expected     string
+	}{
+		{"authenticating proxy", "10.1.0.1:1234", "198.51.100.1", "jane"},
+		{"client", "203.0.113.7:1234", "", ""},
+		{"client spoofing address of the proxy", "203.0.113.7:1234", "10.1.0.1", ""},
+		{"trusted proxy", "10.0.0.1:1234", "198.51.100.1", ""},
+		{"trusted proxy forwarding address of the proxy", "10.0.0.1:1234", "10.1.0.1", ""},
+	}
+
+	for _, c := range cases {
+		request := httptest.NewRequest(http.MethodGet, "/api/v1/pod", nil)
+		request.RemoteAddr = c.remoteAddr
+		request.Header.Set("Impersonate-User", "jane")
+		if len(c.forwardedFor) > 0 {
+			request.Header.Set("X-Forwarded-For", c.forwardedFor)
+		}
+
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, request)
+		if recorder.Body.String() != c.expected {
+			t.Errorf("%s: request from %s was impersonating %q, expected %q", c.info, c.remoteAddr,
+				recorder.Body.String(), c.expected)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"runtime/debug"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/logging"
+)
+
+// RecoveredError is a body of the response sent when handler panics.
+type RecoveredError struct {
+	metav1.Status
+
+	// ID of the request that can be used to find the stack trace in logs.
+	RequestID string `json:"requestID"`
+}
+
+// CreateRecoveryHandler wraps given handler, so that panics are logged with stack trace and request ID and
+// answered with JSON error instead of closing the connection. Request ID is set by request ID handler.
+func CreateRecoveryHandler(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer func() {
+			recovered := recover()
+			if recovered == nil {
+				return
+			}
+
+			// Aborted handlers are expected to close the connection.
+			if recovered == http.ErrAbortHandler {
+				panic(recovered)
+			}
+
+			logPanic(r, RequestID(r), recovered, debug.Stack())
+			writeRecoveredError(w, RequestID(r))
+		}()
+
+		handler.ServeHTTP(w, r)
+	})
+}
+
+func logPanic(r *http.Request, requestID string, recovered interface{}, stack []byte) {
+	if logging.IsStructured() {
+		logging.Log(logging.LevelError, fmt.Sprintf("Recovered from panic: %v", recovered), logging.Fields{
+			"requestID": requestID,
+			"method":    r.Method,
+			"uri":       r.URL.RequestURI(),
+			"stack":     string(stack),
+		})
+		return
+	}
+
+	log.Printf("Recovered from panic while handling %s %s, request ID %s: %v\n%s", r.Method, r.URL.RequestURI(),
+		requestID, recovered, stack)
+}
+
+func writeRecoveredError(w http.ResponseWriter, requestID string) {
+	err := errors.NewInternal(errors.MsgUnexpectedServerError)
+	body, _ := json.Marshal(&RecoveredError{Status: err.ErrStatus, RequestID: requestID})
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusInternalServerError)
+	w.Write(body)
+}
@@ -0,0 +1,84 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kubernetes/dashboard/src/app/backend/client"
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+)
+
+func TestRecoveryHandler(t *testing.T) {
+	cases := []struct {
+		handler           http.HandlerFunc
+		requestID         string
+		expectedCode      int
+		expectedRecovered bool
+	}{
+		{func(w http.ResponseWriter, r *http.Request) { w.Write([]byte("ok")) }, "", http.StatusOK, false},
+		{func(w http.ResponseWriter, r *http.Request) { panic("nil map") }, "", http.StatusInternalServerError,
+			true},
+		{func(w http.ResponseWriter, r *http.Request) { panic("nil map") }, "abc", http.StatusInternalServerError,
+			true},
+	}
+
+	for _, c := range cases {
+		request := httptest.NewRequest(http.MethodGet, "/api/v1/pod", nil)
+		if len(c.requestID) > 0 {
+			request.Header.Set(client.RequestIDHeader, c.requestID)
+		}
+		recorder := httptest.NewRecorder()
+		CreateRequestIDHandler(CreateRecoveryHandler(c.handler)).ServeHTTP(recorder, request)
+
+		if recorder.Code != c.expectedCode {
+			t.Errorf("Expected status code %d, got %d", c.expectedCode, recorder.Code)
+		}
+
+		requestID := recorder.Header().Get(client.RequestIDHeader)
+		if len(requestID) == 0 || (len(c.requestID) > 0 && requestID != c.requestID) {
+			t.Errorf("Expected request ID header %q, got %q", c.requestID, requestID)
+		}
+
+		if !c.expectedRecovered {
+			continue
+		}
+
+		body := RecoveredError{}
+		if err := json.Unmarshal(recorder.Body.Bytes(), &body); err != nil {
+			t.Errorf("Expected JSON error body, got %s: %s", recorder.Body.String(), err)
+			continue
+		}
+
+		if body.RequestID != requestID || body.Details.Causes[0].Message != errors.MsgUnexpectedServerError {
+			t.Errorf("Unexpected error body %#v for request ID %s", body, requestID)
+		}
+	}
+}
+
+func TestRecoveryHandlerAbort(t *testing.T) {
+	defer func() {
+		if recovered := recover(); recovered != http.ErrAbortHandler {
+			t.Errorf("Expected http.ErrAbortHandler to be passed through, got %v", recovered)
+		}
+	}()
+
+	CreateRecoveryHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic(http.ErrAbortHandler)
+	})).ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+}
@@ -0,0 +1,59 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// CreateHTTPSRedirectHandler creates handler, that redirects plain HTTP requests to the same host, path and
+// query on given HTTPS port, so that users who type the plain URL are not met with a connection error.
+func CreateHTTPSRedirectHandler(port int) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host := r.Host
+		if h, _, err := net.SplitHostPort(r.Host); err == nil {
+			host = h
+		} else {
+			// Bare IPv6 host without port, i.e. [::1], keeps its brackets.
+			host = strings.TrimSuffix(strings.TrimPrefix(host, "["), "]")
+		}
+
+		target := url.URL{
+			Scheme:   "https",
+			Host:     httpsHost(host, port),
+			Path:     r.URL.Path,
+			RawPath:  r.URL.RawPath,
+			RawQuery: r.URL.RawQuery,
+		}
+
+		// Permanent redirect is not used, so that browsers do not remember it when HTTPS is disabled later.
+		http.Redirect(w, r, target.String(), http.StatusFound)
+	})
+}
+
+// httpsHost returns host of the redirect target. Default HTTPS port is omitted.
+func httpsHost(host string, port int) string {
+	if port == 443 {
+		if ip := net.ParseIP(host); ip != nil && ip.To4() == nil {
+			return "[" + host + "]"
+		}
+		return host
+	}
+	return net.JoinHostPort(host, strconv.Itoa(port))
+}
@@ -0,0 +1,51 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCreateHTTPSRedirectHandler(t *testing.T) {
+	cases := []struct {
+		port     int
+		host     string
+		target   string
+		expected string
+	}{
+		{8443, "dashboard.example.com:9090", "/api/v1/pod?filterBy=name,nginx", "https://dashboard.example.com:8443/api/v1/pod?filterBy=name,nginx"},
+		{443, "dashboard.example.com", "/", "https://dashboard.example.com/"},
+		{443, "[::1]:9090", "/config", "https://[::1]/config"},
+		{8443, "[::1]:9090", "/config", "https://[::1]:8443/config"},
+		{8443, "[::1]", "/config", "https://[::1]:8443/config"},
+		{443, "[fd00::10]", "/", "https://[fd00::10]/"},
+	}
+
+	for _, c := range cases {
+		request := httptest.NewRequest(http.MethodGet, c.target, nil)
+		request.Host = c.host
+		recorder := httptest.NewRecorder()
+		CreateHTTPSRedirectHandler(c.port).ServeHTTP(recorder, request)
+
+		if recorder.Code != http.StatusFound {
+			t.Errorf("redirect of %s%s code == %d, expected %d", c.host, c.target, recorder.Code, http.StatusFound)
+		}
+		if location := recorder.Header().Get("Location"); location != c.expected {
+			t.Errorf("redirect of %s%s location == %s, expected %s", c.host, c.target, location, c.expected)
+		}
+	}
+}
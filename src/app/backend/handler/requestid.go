@@ -0,0 +1,58 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"regexp"
+
+	"github.com/kubernetes/dashboard/src/app/backend/client"
+)
+
+// validRequestID matches request IDs sent by callers that are safe to reuse in logs and headers.
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._-]{1,128}$`)
+
+// CreateRequestIDHandler wraps given handler, so that every request has an ID. Valid ID sent by the caller is
+// reused, otherwise a random one is generated. ID is stored in the request header, so that it can be logged and
+// forwarded to the apiserver, and is returned in the response header.
+func CreateRequestIDHandler(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID := r.Header.Get(client.RequestIDHeader)
+		if !validRequestID.MatchString(requestID) {
+			requestID = newRequestID()
+			r.Header.Set(client.RequestIDHeader, requestID)
+		}
+
+		w.Header().Set(client.RequestIDHeader, requestID)
+		handler.ServeHTTP(w, r)
+	})
+}
+
+// RequestID returns ID of the request set by request ID handler.
+func RequestID(r *http.Request) string {
+	return r.Header.Get(client.RequestIDHeader)
+}
+
+// newRequestID returns random 16 characters long hex string.
+func newRequestID() string {
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return "unknown"
+	}
+
+	return hex.EncodeToString(id)
+}
@@ -0,0 +1,65 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/kubernetes/dashboard/src/app/backend/client"
+)
+
+func TestRequestIDHandler(t *testing.T) {
+	cases := []struct {
+		header string
+		reused bool
+	}{
+		{"", false},
+		{"abc", true},
+		{"req-1.2_3", true},
+		{"abc\ndef", false},
+		{"a b", false},
+		{"abc\"}", false},
+		{strings.Repeat("a", 129), false},
+	}
+
+	for _, c := range cases {
+		var seen string
+		handler := CreateRequestIDHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			seen = RequestID(r)
+		}))
+
+		request := httptest.NewRequest(http.MethodGet, "/api/v1/pod", nil)
+		if len(c.header) > 0 {
+			request.Header.Set(client.RequestIDHeader, c.header)
+		}
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, request)
+
+		returned := recorder.Header().Get(client.RequestIDHeader)
+		if len(seen) == 0 || seen != returned {
+			t.Errorf("Expected handler to see returned request ID %q, got %q", returned, seen)
+		}
+
+		if c.reused && returned != c.header {
+			t.Errorf("Expected request ID %q to be reused, got %q", c.header, returned)
+		}
+		if !c.reused && returned == c.header {
+			t.Errorf("Expected request ID %q to be replaced", c.header)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"fmt"
+	"mime"
+	"net/http"
+	"strings"
+
+	restful "github.com/emicklei/go-restful"
+)
+
+// proxyPathPrefix is a path prefix of the API, that proxies requests of any content type to services and pods.
+const proxyPathPrefix = "/api/v1/proxy/"
+
+// rawPathPrefix is a path prefix of the raw resource API, that accepts patch content types in PATCH requests.
+const rawPathPrefix = "/api/v1/_raw/"
+
+// allowedContentTypes are content types accepted in bodies of create and update requests of the API.
+var allowedContentTypes = []string{restful.MIME_JSON, MIMEYAML}
+
+// CreateRequestLimitHandler wraps given API handler, so that create and update requests with a body larger than
+// maxBodySize bytes, or with a content type that the API does not accept, are rejected before any decoding
+// happens. Size of the body is not limited when maxBodySize is 0. It is read for every request, so that it can be
+// changed without restart.
+func CreateRequestLimitHandler(handler http.Handler, maxBodySize func() int64) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !hasBody(r) {
+			handler.ServeHTTP(w, r)
+			return
+		}
+
+		maxBodySize := maxBodySize()
+		if maxBodySize > 0 {
+			if r.ContentLength > maxBodySize {
+				http.Error(w, fmt.Sprintf("Request body is larger than %d bytes", maxBodySize),
+					http.StatusRequestEntityTooLarge)
+				return
+			}
+
+			// Length of chunked bodies is not known up front, so reading is stopped once the limit is reached.
+			r.Body = http.MaxBytesReader(w, r.Body, maxBodySize)
+		}
+
+		allowed := requestContentTypes(r)
+		if !strings.HasPrefix(r.URL.Path, proxyPathPrefix) &&
+			!isAllowedContentType(r.Header.Get("Content-Type"), allowed) {
+			http.Error(w, fmt.Sprintf("Content type has to be one of: %s", strings.Join(allowed, ", ")),
+				http.StatusUnsupportedMediaType)
+			return
+		}
+
+		handler.ServeHTTP(w, r)
+	})
+}
+
+// hasBody returns true if the request creates or updates a resource and it is not empty. Length of chunked
+// bodies is unknown.
+func hasBody(r *http.Request) bool {
+	switch r.Method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch:
+		return r.ContentLength != 0
+	}
+	return false
+}
+
+// requestContentTypes returns content types accepted in the body of given request. Raw resources can be
+// patched with any of the patch content types.
+func requestContentTypes(r *http.Request) []string {
+	if r.Method == http.MethodPatch && strings.HasPrefix(r.URL.Path, rawPathPrefix) {
+		return patchMIMETypes
+	}
+	return allowedContentTypes
+}
+
+// isAllowedContentType returns true if given Content-Type header value, ignoring its parameters, i.e. charset,
+// is one of the allowed content types.
+func isAllowedContentType(contentType string, accepted []string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+
+	for _, allowed := range accepted {
+		if mediaType == allowed {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,84 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCreateRequestLimitHandler(t *testing.T) {
+	echo := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		w.Write(body)
+	})
+
+	cases := []struct {
+		method      string
+		path        string
+		contentType string
+		body        string
+		chunked     bool
+		expected    int
+	}{
+		{http.MethodGet, "/api/v1/pod", "", "", false, http.StatusOK},
+		{http.MethodPost, "/api/v1/appdeployment", "application/json", `{"name":"nginx"}`, false, http.StatusOK},
+		{http.MethodPut, "/api/v1/_raw/pod/namespace/default/name/nginx", "application/yaml; charset=utf-8",
+			"kind: Pod", false, http.StatusOK},
+		{http.MethodPost, "/api/v1/login/notice/acknowledgment", "", "", false, http.StatusOK},
+		{http.MethodPatch, "/api/v1/_raw/deployment/namespace/default/name/nginx",
+			"application/strategic-merge-patch+json", `{"spec":{"replicas":2}}`, false, http.StatusOK},
+		{http.MethodPatch, "/api/v1/_raw/namespace/name/default", "application/merge-patch+json",
+			`{"metadata":{"labels":null}}`, false, http.StatusOK},
+		{http.MethodPatch, "/api/v1/_raw/pod/namespace/default/name/nginx", "application/json-patch+json",
+			`[]`, false, http.StatusOK},
+		{http.MethodPut, "/api/v1/_raw/pod/namespace/default/name/nginx", "application/merge-patch+json",
+			`{"kind":"Pod"}`, false, http.StatusUnsupportedMediaType},
+		{http.MethodPost, "/api/v1/appdeployment", "text/plain", `{"name":"nginx"}`, false,
+			http.StatusUnsupportedMediaType},
+		{http.MethodPost, "/api/v1/appdeployment", "", `{"name":"nginx"}`, false, http.StatusUnsupportedMediaType},
+		{http.MethodPost, "/api/v1/proxy/namespace/default/service/web/", "text/plain", "hello", false,
+			http.StatusOK},
+		{http.MethodPost, "/api/v1/appdeployment", "application/json", strings.Repeat("a", 33), false,
+			http.StatusRequestEntityTooLarge},
+		{http.MethodPost, "/api/v1/appdeployment", "application/json", strings.Repeat("a", 33), true,
+			http.StatusBadRequest},
+	}
+
+	handler := CreateRequestLimitHandler(echo, func() int64 { return 32 })
+	for _, c := range cases {
+		request := httptest.NewRequest(c.method, c.path, strings.NewReader(c.body))
+		if c.chunked {
+			request.ContentLength = -1
+		}
+		if len(c.contentType) > 0 {
+			request.Header.Set("Content-Type", c.contentType)
+		}
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, request)
+
+		if recorder.Code != c.expected {
+			t.Errorf("%s %s with %q content type and %d bytes long body code == %d, expected %d", c.method, c.path,
+				c.contentType, len(c.body), recorder.Code, c.expected)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+var (
+	// draining is closed when the backend starts shutting down and readiness probe starts failing.
+	draining     = make(chan struct{})
+	drainingOnce sync.Once
+
+	// shutdown is closed when long-lived streams are closed, after endpoints had time to be updated.
+	shutdown     = make(chan struct{})
+	shutdownOnce sync.Once
+
+	// hijackedStreams counts WebSocket streams, whose connections are hijacked and thus not waited for by
+	// graceful shutdown of the server.
+	hijackedStreams sync.WaitGroup
+)
+
+// StartShutdown marks the backend as shutting down. Readiness probe starts failing, so that no new traffic is
+// routed to this replica. Requests, including new streams, are still served until CloseStreams is called.
+func StartShutdown() {
+	drainingOnce.Do(func() {
+		close(draining)
+	})
+}
+
+// CloseStreams closes long-lived streams, i.e. terminal sessions and event watches, so that they do not hold
+// graceful shutdown of the server until its grace period expires. WebSocket streams send close frames.
+func CloseStreams() {
+	StartShutdown()
+	shutdownOnce.Do(func() {
+		close(shutdown)
+		terminalSessions.CloseAll(1, "Dashboard is shutting down")
+	})
+}
+
+// trackHijackedStream has to be called before a connection is upgraded to WebSocket, while the server still
+// tracks it, and the returned function once the stream is closed.
+func trackHijackedStream() func() {
+	hijackedStreams.Add(1)
+	return hijackedStreams.Done
+}
+
+// WaitForStreams blocks until all WebSocket streams are closed or the context is done. It has to be called after
+// graceful shutdown of the server returned, so that no new streams are started.
+func WaitForStreams(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		hijackedStreams.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// checkShutdown is a readiness check, that fails once the backend started shutting down.
+func checkShutdown() error {
+	select {
+	case <-draining:
+		return errors.New("backend is shutting down")
+	default:
+		return nil
+	}
+}
+
+// streamDone returns a channel, that is closed when either the request is finished or long-lived streams are
+// closed on shutdown.
+func streamDone(ctx context.Context) <-chan struct{} {
+	done := make(chan struct{})
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-shutdown:
+		}
+		close(done)
+	}()
+	return done
+}
@@ -0,0 +1,113 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+func resetShutdown() {
+	draining = make(chan struct{})
+	drainingOnce = sync.Once{}
+	shutdown = make(chan struct{})
+	shutdownOnce = sync.Once{}
+}
+
+func TestStartShutdown(t *testing.T) {
+	defer resetShutdown()
+
+	terminalSessions.Set("unbound", TerminalSession{id: "unbound"})
+	defer terminalSessions.Close("unbound", 1, "")
+	done := streamDone(context.Background())
+
+	if err := checkShutdown(); err != nil {
+		t.Fatalf("checkShutdown() == %v before shutdown, expected nil", err)
+	}
+
+	StartShutdown()
+	StartShutdown()
+
+	if err := checkShutdown(); err == nil {
+		t.Error("checkShutdown() == nil after shutdown, expected error")
+	}
+
+	select {
+	case <-done:
+		t.Error("streamDone() was closed during drain delay")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	if session := terminalSessions.Get("unbound"); session.id != "unbound" {
+		t.Error("terminal session was removed during drain delay")
+	}
+}
+
+func TestCloseStreams(t *testing.T) {
+	defer resetShutdown()
+
+	terminalSessions.Set("unbound", TerminalSession{id: "unbound"})
+	done := streamDone(context.Background())
+
+	CloseStreams()
+	CloseStreams()
+
+	if err := checkShutdown(); err == nil {
+		t.Error("checkShutdown() == nil after streams were closed, expected error")
+	}
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Error("streamDone() was not closed after streams were closed")
+	}
+
+	if session := terminalSessions.Get("unbound"); session.id != "" {
+		t.Errorf("terminal session %q was not removed on shutdown", session.id)
+	}
+}
+
+func TestWaitForStreams(t *testing.T) {
+	streamClosed := trackHijackedStream()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := WaitForStreams(ctx); err != context.DeadlineExceeded {
+		t.Errorf("WaitForStreams() == %v with open stream, expected %v", err, context.DeadlineExceeded)
+	}
+
+	streamClosed()
+	if err := WaitForStreams(context.Background()); err != nil {
+		t.Errorf("WaitForStreams() == %v after stream was closed, expected nil", err)
+	}
+}
+
+func TestStreamDoneOnRequestEnd(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := streamDone(ctx)
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Error("streamDone() was not closed after request context was canceled")
+	}
+
+	if err := checkShutdown(); err != nil {
+		t.Errorf("checkShutdown() == %v, expected nil", err)
+	}
+}
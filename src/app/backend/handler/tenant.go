@@ -0,0 +1,119 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/emicklei/go-restful"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+)
+
+// TenantPathPrefix is a prefix of URL paths of virtual dashboards scoped to a single namespace, i.e.
+// /t/team-a/. Frontend application and API served under this prefix are pinned to the namespace.
+const TenantPathPrefix = "/t/"
+
+type tenantKey struct{}
+
+// CreateTenantHandler strips tenant prefix from the URL path, i.e. /t/team-a/api/v1/pod/team-a is handled as
+// /api/v1/pod/team-a, and stores the namespace of the tenant in the request context. Frontend application uses
+// relative URLs, so assets, config and API calls of the page served under the prefix keep the prefix as well.
+func CreateTenantHandler(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.URL.Path, TenantPathPrefix) {
+			handler.ServeHTTP(w, r)
+			return
+		}
+
+		namespace, rest := splitTenantPath(r.URL.Path)
+		if len(validation.IsDNS1123Label(namespace)) > 0 {
+			http.NotFound(w, r)
+			return
+		}
+
+		if len(rest) == 0 {
+			// Relative URLs have to be resolved against the tenant root, not its parent.
+			target := TenantPathPrefix + namespace + "/"
+			if len(r.URL.RawQuery) > 0 {
+				target += "?" + r.URL.RawQuery
+			}
+			http.Redirect(w, r, target, http.StatusMovedPermanently)
+			return
+		}
+
+		r = withPath(r, rest)
+		handler.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), tenantKey{}, namespace)))
+	})
+}
+
+// splitTenantPath splits URL path with tenant prefix into namespace of the tenant and remaining path, that
+// starts with a slash. Remaining path is empty if there is no slash after the namespace.
+func splitTenantPath(urlPath string) (string, string) {
+	tenantPath := strings.TrimPrefix(urlPath, TenantPathPrefix)
+	i := strings.Index(tenantPath, "/")
+	if i < 0 {
+		return tenantPath, ""
+	}
+	return tenantPath[:i], tenantPath[i:]
+}
+
+// TenantNamespace returns namespace of the virtual dashboard the request was made from, or empty string if
+// the request was not made under the tenant prefix.
+func TenantNamespace(r *http.Request) string {
+	namespace, _ := r.Context().Value(tenantKey{}).(string)
+	return namespace
+}
+
+// tenantFilter rejects API requests made from a virtual dashboard, that target other namespaces than the one
+// of the tenant, or all namespaces at once. Requests of cluster-scoped resources are not affected.
+func tenantFilter(ws *restful.WebService) restful.FilterFunction {
+	return func(request *restful.Request, response *restful.Response, chain *restful.FilterChain) {
+		tenant := TenantNamespace(request.Request)
+		if len(tenant) == 0 {
+			chain.ProcessFilter(request, response)
+			return
+		}
+
+		namespaces := requestedNamespaces(request)
+		allowed := len(namespaces) > 0 || !hasNamespacedRoute(ws, request.SelectedRoutePath())
+		for _, namespace := range namespaces {
+			allowed = allowed && namespace == tenant
+		}
+
+		if !allowed {
+			err := errors.NewForbidden(errors.MsgTenantNamespaceError)
+			response.WriteHeaderAndEntity(int(err.ErrStatus.Code), err.Error())
+			return
+		}
+
+		chain.ProcessFilter(request, response)
+	}
+}
+
+// hasNamespacedRoute returns true if route with given path has a namespaced variant, i.e. /api/v1/pod or
+// /api/v1/pod/ has /api/v1/pod/{namespace}, which means that it lists resources from all namespaces.
+func hasNamespacedRoute(ws *restful.WebService, routePath string) bool {
+	namespacedPath := strings.TrimSuffix(routePath, "/") + "/{namespace}"
+	for _, route := range ws.Routes() {
+		if route.Path == namespacedPath {
+			return true
+		}
+	}
+	return false
+}
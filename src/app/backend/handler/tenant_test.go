@@ -0,0 +1,100 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/emicklei/go-restful"
+)
+
+func TestCreateTenantHandler(t *testing.T) {
+	handler := CreateTenantHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.URL.Path + " " + TenantNamespace(r)))
+	}))
+
+	cases := []struct {
+		url              string
+		expectedCode     int
+		expectedBody     string
+		expectedLocation string
+	}{
+		{"/api/v1/pod/default", http.StatusOK, "/api/v1/pod/default ", ""},
+		{"/t/team-a/api/v1/pod/team-a", http.StatusOK, "/api/v1/pod/team-a team-a", ""},
+		{"/t/team-a/", http.StatusOK, "/ team-a", ""},
+		{"/t/team-a?foo=bar", http.StatusMovedPermanently, "", "/t/team-a/?foo=bar"},
+		{"/t/Team_A/", http.StatusNotFound, "", ""},
+		{"/t/", http.StatusNotFound, "", ""},
+	}
+
+	for _, c := range cases {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, c.url, nil))
+		if recorder.Code != c.expectedCode {
+			t.Errorf("GET %s returned %d, expected %d", c.url, recorder.Code, c.expectedCode)
+		}
+		if c.expectedCode == http.StatusOK && recorder.Body.String() != c.expectedBody {
+			t.Errorf("GET %s was handled as %q, expected %q", c.url, recorder.Body.String(), c.expectedBody)
+		}
+		if location := recorder.Header().Get("Location"); location != c.expectedLocation {
+			t.Errorf("GET %s redirected to %q, expected %q", c.url, location, c.expectedLocation)
+		}
+	}
+}
+
+func TestTenantFilter(t *testing.T) {
+	ws := new(restful.WebService)
+	ws.Path("/api/v1").Produces(restful.MIME_JSON)
+	ws.Filter(tenantFilter(ws))
+	handle := func(request *restful.Request, response *restful.Response) {
+		response.WriteHeader(http.StatusOK)
+	}
+	ws.Route(ws.GET("/pod").To(handle))
+	ws.Route(ws.GET("/pod/{namespace}").To(handle))
+	ws.Route(ws.GET("/node").To(handle))
+	ws.Route(ws.GET("/namespace/{name}").To(handle))
+	ws.Route(ws.GET("/persistentvolumeclaim/").To(handle))
+	ws.Route(ws.GET("/persistentvolumeclaim/{namespace}").To(handle))
+	container := restful.NewContainer()
+	container.Add(ws)
+	handler := CreateTenantHandler(container)
+
+	cases := []struct {
+		url          string
+		expectedCode int
+	}{
+		{"/api/v1/pod", http.StatusOK},
+		{"/api/v1/pod/kube-system", http.StatusOK},
+		{"/t/team-a/api/v1/pod/team-a", http.StatusOK},
+		{"/t/team-a/api/v1/pod/team-b", http.StatusForbidden},
+		{"/t/team-a/api/v1/pod/team-a,team-b", http.StatusForbidden},
+		{"/t/team-a/api/v1/pod", http.StatusForbidden},
+		{"/t/team-a/api/v1/node", http.StatusOK},
+		{"/t/team-a/api/v1/namespace/team-a", http.StatusOK},
+		{"/t/team-a/api/v1/namespace/team-b", http.StatusForbidden},
+		{"/t/team-a/api/v1/persistentvolumeclaim/", http.StatusForbidden},
+		{"/t/team-a/api/v1/persistentvolumeclaim/team-a", http.StatusOK},
+	}
+
+	for _, c := range cases {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, c.url, nil))
+		if recorder.Code != c.expectedCode {
+			t.Errorf("GET %s returned %d, expected %d", c.url, recorder.Code, c.expectedCode)
+		}
+	}
+}
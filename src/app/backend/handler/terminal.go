@@ -31,6 +31,8 @@ import (
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/remotecommand"
+
+	recordingapi "github.com/kubernetes/dashboard/src/app/backend/recording/api"
 )
 
 const END_OF_TRANSMISSION = "\u0004"
@@ -49,6 +51,7 @@ type TerminalSession struct {
 	sockJSSession sockjs.Session
 	sizeChan      chan remotecommand.TerminalSize
 	doneChan      chan struct{}
+	recorder      recordingapi.Recorder
 }
 
 // TerminalMessage is the messaging protocol between ShellController and TerminalSession.
@@ -85,16 +88,25 @@ func (t TerminalSession) Read(p []byte) (int, error) {
 		return copy(p, END_OF_TRANSMISSION), err
 	}
 
+	return readTerminalMessage([]byte(m), p, t.sizeChan, t.recorder)
+}
+
+// readTerminalMessage handles a single message received from the terminal. Keystrokes are copied to p and
+// resize events are sent to the size channel. Both are recorded if the recorder is set.
+func readTerminalMessage(m []byte, p []byte, sizeChan chan<- remotecommand.TerminalSize,
+	recorder recordingapi.Recorder) (int, error) {
 	var msg TerminalMessage
-	if err := json.Unmarshal([]byte(m), &msg); err != nil {
+	if err := json.Unmarshal(m, &msg); err != nil {
 		return copy(p, END_OF_TRANSMISSION), err
 	}
 
 	switch msg.Op {
 	case "stdin":
+		record(recorder, recordingapi.EventInput, msg.Data)
 		return copy(p, msg.Data), nil
 	case "resize":
-		t.sizeChan <- remotecommand.TerminalSize{Width: msg.Cols, Height: msg.Rows}
+		record(recorder, recordingapi.EventResize, fmt.Sprintf("%dx%d", msg.Cols, msg.Rows))
+		sizeChan <- remotecommand.TerminalSize{Width: msg.Cols, Height: msg.Rows}
 		return 0, nil
 	default:
 		return copy(p, END_OF_TRANSMISSION), fmt.Errorf("unknown message type '%s'", msg.Op)
@@ -104,6 +116,7 @@ func (t TerminalSession) Read(p []byte) (int, error) {
 // Write handles process->pty stdout
 // Called from remotecommand whenever there is any output
 func (t TerminalSession) Write(p []byte) (int, error) {
+	record(t.recorder, recordingapi.EventOutput, string(p))
 	msg, err := json.Marshal(TerminalMessage{
 		Op:   "stdout",
 		Data: string(p),
@@ -118,6 +131,13 @@ func (t TerminalSession) Write(p []byte) (int, error) {
 	return len(p), nil
 }
 
+// record records the event if the session is recorded.
+func record(recorder recordingapi.Recorder, eventType recordingapi.EventType, data string) {
+	if recorder != nil {
+		recorder.Record(eventType, data)
+	}
+}
+
 // Toast can be used to send the user any OOB messages
 // hterm puts these in the center of the terminal
 func (t TerminalSession) Toast(p string) error {
@@ -161,9 +181,31 @@ func (sm *SessionMap) Set(sessionId string, session TerminalSession) {
 func (sm *SessionMap) Close(sessionId string, status uint32, reason string) {
 	sm.Lock.Lock()
 	defer sm.Lock.Unlock()
-	err := sm.Sessions[sessionId].sockJSSession.Close(status, reason)
-	if err != nil {
-		log.Println(err)
+	sm.close(sessionId, status, reason)
+}
+
+// CloseAll shuts down all SockJS connections, i.e. when Dashboard is shutting down.
+func (sm *SessionMap) CloseAll(status uint32, reason string) {
+	sm.Lock.Lock()
+	defer sm.Lock.Unlock()
+	for sessionId := range sm.Sessions {
+		sm.close(sessionId, status, reason)
+	}
+}
+
+// close has to be called with the lock held. Sessions, that were already closed or were not bound to a SockJS
+// connection yet, are only removed.
+func (sm *SessionMap) close(sessionId string, status uint32, reason string) {
+	session, ok := sm.Sessions[sessionId]
+	if ok && session.sockJSSession != nil {
+		if err := session.sockJSSession.Close(status, reason); err != nil {
+			log.Println(err)
+		}
+	}
+	if ok && session.recorder != nil {
+		if err := session.recorder.Close(); err != nil {
+			log.Printf("Cannot close recording of exec session %s: %s", sessionId, err)
+		}
 	}
 
 	delete(sm.Sessions, sessionId)
@@ -275,6 +317,26 @@ func isValidShell(validShells []string, shell string) bool {
 	return false
 }
 
+// startShell executes the shell in the container specified in request and connects it up with the ptyHandler. The
+// first shell, that can be started, is used when no valid shell is given.
+func startShell(k8sClient kubernetes.Interface, cfg *rest.Config, request *restful.Request, shell string,
+	ptyHandler PtyHandler) error {
+	validShells := []string{"bash", "sh", "powershell", "cmd"}
+	if isValidShell(validShells, shell) {
+		return startProcess(k8sClient, cfg, request, []string{shell}, ptyHandler)
+	}
+
+	// No shell given or it was not valid: try some shells until one succeeds or all fail
+	// FIXME: if the first shell fails then the first keyboard event is lost
+	var err error
+	for _, testShell := range validShells {
+		if err = startProcess(k8sClient, cfg, request, []string{testShell}, ptyHandler); err == nil {
+			break
+		}
+	}
+	return err
+}
+
 // WaitForTerminal is called from apihandler.handleAttach as a goroutine
 // Waits for the SockJS connection to be opened by the client the session to be bound in handleTerminalSession
 func WaitForTerminal(k8sClient kubernetes.Interface, cfg *rest.Config, request *restful.Request, sessionId string) {
@@ -284,23 +346,7 @@ func WaitForTerminal(k8sClient kubernetes.Interface, cfg *rest.Config, request *
 	case <-terminalSessions.Get(sessionId).bound:
 		close(terminalSessions.Get(sessionId).bound)
 
-		var err error
-		validShells := []string{"bash", "sh", "powershell", "cmd"}
-
-		if isValidShell(validShells, shell) {
-			cmd := []string{shell}
-			err = startProcess(k8sClient, cfg, request, cmd, terminalSessions.Get(sessionId))
-		} else {
-			// No shell given or it was not valid: try some shells until one succeeds or all fail
-			// FIXME: if the first shell fails then the first keyboard event is lost
-			for _, testShell := range validShells {
-				cmd := []string{testShell}
-				if err = startProcess(k8sClient, cfg, request, cmd, terminalSessions.Get(sessionId)); err == nil {
-					break
-				}
-			}
-		}
-
+		err := startShell(k8sClient, cfg, request, shell, terminalSessions.Get(sessionId))
 		if err != nil {
 			terminalSessions.Close(sessionId, 2, err.Error())
 			return
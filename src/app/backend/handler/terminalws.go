@@ -0,0 +1,212 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+
+	restful "github.com/emicklei/go-restful"
+	"github.com/gorilla/websocket"
+	"k8s.io/client-go/tools/remotecommand"
+
+	"github.com/kubernetes/dashboard/src/app/backend/client"
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	recordingapi "github.com/kubernetes/dashboard/src/app/backend/recording/api"
+)
+
+const (
+	// TerminalWebSocketProtocol is a WebSocket subprotocol of terminal sessions. Messages are TerminalMessage
+	// objects encoded as JSON.
+	TerminalWebSocketProtocol = "v1.terminal.dashboard.k8s.io"
+
+	// Browsers can not set headers of WebSocket connections, so tokens can be passed as subprotocols with these
+	// prefixes. Tokens are encoded with unpadded base64url encoding. The bearer token format is the same as the
+	// one accepted by the Kubernetes API server.
+	bearerTokenProtocolPrefix = "base64url.bearer.authorization.k8s.io."
+	jweTokenProtocolPrefix    = "base64url.jwe.dashboard.k8s.io."
+
+	// maxCloseReasonLength is the maximum length of the reason of WebSocket close frame.
+	maxCloseReasonLength = 123
+)
+
+// terminalUpgrader upgrades terminal requests to WebSocket connections. Cross-origin requests are rejected.
+var terminalUpgrader = websocket.Upgrader{Subprotocols: []string{TerminalWebSocketProtocol}}
+
+// webSocketTerminal implements PtyHandler using a WebSocket connection.
+type webSocketTerminal struct {
+	conn     *websocket.Conn
+	sizeChan chan remotecommand.TerminalSize
+	doneChan chan struct{}
+	recorder recordingapi.Recorder
+
+	// writeMux serializes writes, as stdout and stderr are written from different goroutines.
+	writeMux  sync.Mutex
+	closeOnce sync.Once
+}
+
+// Next implements remotecommand.TerminalSizeQueue interface.
+func (t *webSocketTerminal) Next() *remotecommand.TerminalSize {
+	select {
+	case size := <-t.sizeChan:
+		return &size
+	case <-t.doneChan:
+		return nil
+	}
+}
+
+// Read handles pty->process messages (stdin, resize).
+func (t *webSocketTerminal) Read(p []byte) (int, error) {
+	_, m, err := t.conn.ReadMessage()
+	if err != nil {
+		// Send terminated signal to process to avoid resource leak
+		return copy(p, END_OF_TRANSMISSION), err
+	}
+
+	return readTerminalMessage(m, p, t.sizeChan, t.recorder)
+}
+
+// Write handles process->pty stdout.
+func (t *webSocketTerminal) Write(p []byte) (int, error) {
+	record(t.recorder, recordingapi.EventOutput, string(p))
+	msg, err := json.Marshal(TerminalMessage{
+		Op:   "stdout",
+		Data: string(p),
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	t.writeMux.Lock()
+	defer t.writeMux.Unlock()
+	if err := t.conn.WriteMessage(websocket.TextMessage, msg); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Close sends close frame with the status code and reason to the client and closes the connection.
+func (t *webSocketTerminal) Close(code int, reason string) {
+	t.closeOnce.Do(func() {
+		close(t.doneChan)
+		if len(reason) > maxCloseReasonLength {
+			reason = reason[:maxCloseReasonLength]
+		}
+
+		t.writeMux.Lock()
+		err := t.conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(code, reason))
+		t.writeMux.Unlock()
+		if err != nil && err != websocket.ErrCloseSent {
+			log.Printf("Cannot close terminal WebSocket: %s", err)
+		}
+
+		t.conn.Close()
+		if err := t.recorder.Close(); err != nil {
+			log.Printf("Cannot close recording of exec session: %s", err)
+		}
+	})
+}
+
+// handleExecShellWebSocket executes the shell in the container and streams it over the WebSocket connection.
+// Unlike SockJS sessions, the session is bound to the connection, so no session ID is exchanged.
+func (apiHandler *APIHandler) handleExecShellWebSocket(request *restful.Request, response *restful.Response) {
+	useWebSocketCredentials(request.Request)
+
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	cfg, err := apiHandler.cManager.Config(request)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	sessionID, err := genTerminalSessionId()
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	recorder, err := apiHandler.startRecording(request, sessionID)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	// Upgrade replies with an error itself when it fails.
+	streamClosed := trackHijackedStream()
+	defer streamClosed()
+	conn, err := terminalUpgrader.Upgrade(response.ResponseWriter, request.Request, nil)
+	if err != nil {
+		log.Printf("Cannot upgrade terminal connection: %s", err)
+		recorder.Close()
+		return
+	}
+
+	terminal := &webSocketTerminal{
+		conn:     conn,
+		sizeChan: make(chan remotecommand.TerminalSize),
+		doneChan: make(chan struct{}),
+		recorder: recorder,
+	}
+
+	// Hijacked connections are not closed by graceful shutdown of the server.
+	go func() {
+		select {
+		case <-shutdown:
+			terminal.Close(websocket.CloseGoingAway, "Dashboard is shutting down")
+		case <-terminal.doneChan:
+		}
+	}()
+
+	if err := startShell(k8sClient, cfg, request, request.QueryParameter("shell"), terminal); err != nil {
+		terminal.Close(websocket.CloseInternalServerErr, err.Error())
+		return
+	}
+	terminal.Close(websocket.CloseNormalClosure, "Process exited")
+}
+
+// useWebSocketCredentials copies token passed as WebSocket subprotocol to the header, that the client manager
+// reads it from. Headers sent by the client take precedence.
+func useWebSocketCredentials(request *http.Request) {
+	for _, protocol := range websocket.Subprotocols(request) {
+		header, prefix := "", ""
+		switch {
+		case strings.HasPrefix(protocol, bearerTokenProtocolPrefix):
+			header, prefix = "Authorization", bearerTokenProtocolPrefix
+		case strings.HasPrefix(protocol, jweTokenProtocolPrefix):
+			header, prefix = client.JWETokenHeader, jweTokenProtocolPrefix
+		default:
+			continue
+		}
+
+		token, err := base64.RawURLEncoding.DecodeString(strings.TrimPrefix(protocol, prefix))
+		if err != nil || len(request.Header.Get(header)) > 0 {
+			continue
+		}
+		if header == "Authorization" {
+			request.Header.Set(header, "Bearer "+string(token))
+		} else {
+			request.Header.Set(header, string(token))
+		}
+	}
+}
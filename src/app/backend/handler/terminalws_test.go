@@ -0,0 +1,151 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"k8s.io/client-go/tools/remotecommand"
+
+	"github.com/kubernetes/dashboard/src/app/backend/client"
+	recordingapi "github.com/kubernetes/dashboard/src/app/backend/recording/api"
+)
+
+type fakeRecorder struct {
+	events []recordingapi.EventType
+	closed bool
+}
+
+func (self *fakeRecorder) Record(eventType recordingapi.EventType, data string) {
+	self.events = append(self.events, eventType)
+}
+
+func (self *fakeRecorder) Close() error {
+	self.closed = true
+	return nil
+}
+
+func TestWebSocketTerminal(t *testing.T) {
+	recorder := &fakeRecorder{}
+	terminals := make(chan *webSocketTerminal)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := terminalUpgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("cannot upgrade connection: %v", err)
+			return
+		}
+		terminals <- &webSocketTerminal{conn: conn, sizeChan: make(chan remotecommand.TerminalSize, 1),
+			doneChan: make(chan struct{}), recorder: recorder}
+	}))
+	defer server.Close()
+
+	dialer := websocket.Dialer{Subprotocols: []string{TerminalWebSocketProtocol}}
+	conn, response, err := dialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	if err != nil {
+		t.Fatalf("cannot dial terminal: %v", err)
+	}
+	defer conn.Close()
+	if protocol := response.Header.Get("Sec-WebSocket-Protocol"); protocol != TerminalWebSocketProtocol {
+		t.Errorf("expected %s subprotocol, got %q", TerminalWebSocketProtocol, protocol)
+	}
+	terminal := <-terminals
+
+	conn.WriteJSON(TerminalMessage{Op: "resize", Rows: 40, Cols: 120})
+	conn.WriteJSON(TerminalMessage{Op: "stdin", Data: "ls\r"})
+
+	p := make([]byte, 32)
+	if n, err := terminal.Read(p); n != 0 || err != nil {
+		t.Errorf("expected resize to be read without data, got %d, %v", n, err)
+	}
+	if size := terminal.Next(); size == nil || size.Width != 120 || size.Height != 40 {
+		t.Errorf("expected 120x40 terminal size, got %v", size)
+	}
+	if n, err := terminal.Read(p); string(p[:n]) != "ls\r" || err != nil {
+		t.Errorf("expected stdin to be read, got %q, %v", p[:n], err)
+	}
+
+	if _, err := terminal.Write([]byte("bin\r\n")); err != nil {
+		t.Fatalf("cannot write to terminal: %v", err)
+	}
+	msg := TerminalMessage{}
+	if err := conn.ReadJSON(&msg); err != nil || msg.Op != "stdout" || msg.Data != "bin\r\n" {
+		t.Errorf("expected stdout message, got %v, %v", msg, err)
+	}
+
+	terminal.Close(websocket.CloseNormalClosure, "Process exited")
+	_, _, err = conn.ReadMessage()
+	if closeErr, ok := err.(*websocket.CloseError); !ok || closeErr.Code != websocket.CloseNormalClosure ||
+		closeErr.Text != "Process exited" {
+		t.Errorf("expected normal closure, got %v", err)
+	}
+	if terminal.Next() != nil {
+		t.Error("expected no more resize events after the terminal is closed")
+	}
+
+	expected := []recordingapi.EventType{recordingapi.EventResize, recordingapi.EventInput, recordingapi.EventOutput}
+	if len(recorder.events) != len(expected) || !recorder.closed {
+		t.Errorf("expected %v events to be recorded and recorder closed, got %v, %v", expected, recorder.events,
+			recorder.closed)
+	}
+}
+
+func TestUseWebSocketCredentials(t *testing.T) {
+	encode := base64.RawURLEncoding.EncodeToString
+	cases := []struct {
+		info          string
+		protocols     string
+		authorization string
+		expectedAuth  string
+		expectedJWE   string
+	}{
+		{"no token", TerminalWebSocketProtocol, "", "", ""},
+		{
+			"bearer token",
+			TerminalWebSocketProtocol + ", " + bearerTokenProtocolPrefix + encode([]byte("abc.def")),
+			"", "Bearer abc.def", "",
+		},
+		{
+			"jwe token",
+			TerminalWebSocketProtocol + ", " + jweTokenProtocolPrefix + encode([]byte(`{"protected":"x"}`)),
+			"", "", `{"protected":"x"}`,
+		},
+		{
+			"header takes precedence",
+			bearerTokenProtocolPrefix + encode([]byte("abc.def")),
+			"Bearer header", "Bearer header", "",
+		},
+		{"invalid encoding", bearerTokenProtocolPrefix + "!!!", "", "", ""},
+	}
+
+	for _, c := range cases {
+		request := httptest.NewRequest(http.MethodGet, "/api/v1/pod/default/web/shell/nginx", nil)
+		request.Header.Set("Sec-WebSocket-Protocol", c.protocols)
+		if len(c.authorization) > 0 {
+			request.Header.Set("Authorization", c.authorization)
+		}
+
+		useWebSocketCredentials(request)
+		if auth, jwe := request.Header.Get("Authorization"), request.Header.Get(client.JWETokenHeader); auth !=
+			c.expectedAuth || jwe != c.expectedJWE {
+			t.Errorf("%s: expected %q authorization and %q jwe token, got %q and %q", c.info, c.expectedAuth,
+				c.expectedJWE, auth, jwe)
+		}
+	}
+}
@@ -0,0 +1,183 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package imagescan
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/kubernetes/dashboard/src/app/backend/imagescan/api"
+)
+
+const (
+	// adapterTimeout is the timeout of a single request to the scanner adapter.
+	adapterTimeout = 10 * time.Second
+
+	scanRequestMimeType = "application/vnd.scanner.adapter.scan.request+json; version=1.0"
+	scanReportMimeType  = "application/vnd.scanner.adapter.vuln.report.harbor+json; version=1.0"
+	manifestMimeType    = "application/vnd.docker.distribution.manifest.v2+json"
+)
+
+// adapterScanner is a client of scanners implementing Harbor pluggable scanner API, i.e. harbor-scanner-trivy
+// or harbor-scanner-clair.
+type adapterScanner struct {
+	url    string
+	client *http.Client
+}
+
+type scanRequest struct {
+	Registry struct {
+		URL string `json:"url"`
+	} `json:"registry"`
+	Artifact struct {
+		Repository string `json:"repository"`
+		Digest     string `json:"digest"`
+		MimeType   string `json:"mime_type"`
+	} `json:"artifact"`
+}
+
+type scanResponse struct {
+	ID string `json:"id"`
+}
+
+type scanReport struct {
+	Vulnerabilities []struct {
+		ID         string `json:"id"`
+		Package    string `json:"pkg"`
+		Version    string `json:"version"`
+		FixVersion string `json:"fix_version"`
+		Severity   string `json:"severity"`
+	} `json:"vulnerabilities"`
+}
+
+// Submit implements Scanner interface. See Scanner for more information.
+func (self *adapterScanner) Submit(image api.Image) (string, error) {
+	request := scanRequest{}
+	request.Registry.URL = registryURL(image.Registry)
+	request.Artifact.Repository = image.Repository
+	request.Artifact.Digest = image.Digest
+	request.Artifact.MimeType = manifestMimeType
+
+	body, err := json.Marshal(request)
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, self.url+"/api/v1/scan", bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", scanRequestMimeType)
+
+	resp, err := self.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusAccepted {
+		return "", adapterError(resp)
+	}
+
+	response := scanResponse{}
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return "", err
+	}
+	return response.ID, nil
+}
+
+// Report implements Scanner interface. See Scanner for more information.
+func (self *adapterScanner) Report(scanID string) ([]api.Vulnerability, bool, error) {
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/api/v1/scan/%s/report", self.url, scanID), nil)
+	if err != nil {
+		return nil, false, err
+	}
+	req.Header.Set("Accept", scanReportMimeType)
+
+	resp, err := self.client.Do(req)
+	if err != nil {
+		return nil, false, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusFound:
+		// Adapter redirects to the same URL until the report is ready.
+		return nil, false, nil
+	default:
+		return nil, false, adapterError(resp)
+	}
+
+	report := scanReport{}
+	if err := json.NewDecoder(resp.Body).Decode(&report); err != nil {
+		return nil, false, err
+	}
+
+	vulnerabilities := make([]api.Vulnerability, 0, len(report.Vulnerabilities))
+	for _, v := range report.Vulnerabilities {
+		vulnerabilities = append(vulnerabilities, api.Vulnerability{
+			ID:         v.ID,
+			Package:    v.Package,
+			Version:    v.Version,
+			FixVersion: v.FixVersion,
+			Severity:   toSeverity(v.Severity),
+		})
+	}
+	return vulnerabilities, true, nil
+}
+
+// registryURL returns URL of the registry API. Docker Hub API is served from a different host than its images
+// are named after.
+func registryURL(registry string) string {
+	if registry == "docker.io" {
+		return "https://registry-1.docker.io"
+	}
+	return "https://" + registry
+}
+
+func toSeverity(severity string) api.Severity {
+	for _, known := range []api.Severity{api.SeverityCritical, api.SeverityHigh, api.SeverityMedium,
+		api.SeverityLow} {
+		if strings.EqualFold(severity, string(known)) {
+			return known
+		}
+	}
+	return api.SeverityUnknown
+}
+
+func adapterError(resp *http.Response) error {
+	body, _ := ioutil.ReadAll(resp.Body)
+	return fmt.Errorf("scanner responded with %s: %s", resp.Status, strings.TrimSpace(string(body)))
+}
+
+// newAdapterScanner creates client of the scanner adapter at given URL.
+func newAdapterScanner(url string) api.Scanner {
+	return &adapterScanner{
+		url: strings.TrimSuffix(url, "/"),
+		client: &http.Client{
+			Timeout: adapterTimeout,
+			// Redirect to the same report URL means that the report is not ready, so it is not followed.
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"time"
+)
+
+// Severity of a vulnerability as reported by the scanner.
+type Severity string
+
+// List of vulnerability severities, from the most severe.
+const (
+	SeverityCritical Severity = "Critical"
+	SeverityHigh     Severity = "High"
+	SeverityMedium   Severity = "Medium"
+	SeverityLow      Severity = "Low"
+	SeverityUnknown  Severity = "Unknown"
+)
+
+// Image identifies the scanned image. Scans are made and cached per digest, because tags can be moved.
+type Image struct {
+	// Registry the image is stored in, i.e. docker.io.
+	Registry string
+
+	// Repository of the image within the registry, i.e. library/nginx.
+	Repository string
+
+	// Digest of the image manifest, i.e. sha256:...
+	Digest string
+}
+
+// Vulnerability is a single vulnerability found in the image.
+type Vulnerability struct {
+	ID         string
+	Package    string
+	Version    string
+	FixVersion string
+	Severity   Severity
+}
+
+// Scanner scans images for vulnerabilities. Scans are asynchronous: image is submitted first and report is read
+// when the scan is finished. Different scanners, i.e. Trivy or Clair, can be plugged in by implementing it.
+type Scanner interface {
+	// Submit requests scan of the image and returns ID of the scan.
+	Submit(image Image) (string, error)
+
+	// Report returns vulnerabilities found by the scan. Second return value is false when the scan is not
+	// finished yet.
+	Report(scanID string) ([]Vulnerability, bool, error)
+}
+
+// ScanStatus is the status of vulnerability scan of an image.
+type ScanStatus string
+
+// List of scan statuses.
+const (
+	// ScanStatusCompleted means that the image was scanned and counts of vulnerabilities are known.
+	ScanStatusCompleted ScanStatus = "Completed"
+
+	// ScanStatusPending means that the image is being scanned.
+	ScanStatusPending ScanStatus = "Pending"
+
+	// ScanStatusFailed means that the image could not be scanned.
+	ScanStatusFailed ScanStatus = "Failed"
+
+	// ScanStatusUnavailable means that the image cannot be scanned, because its digest is not known yet.
+	ScanStatusUnavailable ScanStatus = "Unavailable"
+)
+
+// VulnerabilitySummary summarizes vulnerabilities of a single image.
+type VulnerabilitySummary struct {
+	// Image as referred to by the container spec.
+	Image string `json:"image"`
+
+	// Digest of the image that was scanned.
+	Digest string `json:"digest,omitempty"`
+
+	// Status of the scan.
+	Status ScanStatus `json:"status"`
+
+	// Human readable description of the failure.
+	Message string `json:"message,omitempty"`
+
+	// Number of vulnerabilities per severity.
+	Counts map[Severity]int `json:"counts"`
+
+	// Number of vulnerabilities that are fixed in a newer version of the package.
+	Fixable int `json:"fixable"`
+
+	// Time when the scan was completed.
+	ScannedAt *time.Time `json:"scannedAt,omitempty"`
+}
+
+// VulnerabilityReport contains summaries of all distinct images of a pod or of all pods of a resource.
+type VulnerabilityReport struct {
+	// False when no scanner is configured.
+	Enabled bool `json:"enabled"`
+
+	// Summaries of the images.
+	Images []VulnerabilitySummary `json:"images"`
+}
+
+// ScanManager provides cached vulnerability summaries of images.
+type ScanManager interface {
+	// Enabled returns true when a scanner is configured.
+	Enabled() bool
+
+	// Summarize returns vulnerability summary of the image. Image ID is the one reported by the container
+	// runtime in the container status, that contains digest of the image. Scan is submitted when the image was
+	// not scanned yet.
+	Summarize(image, imageID string) VulnerabilitySummary
+}
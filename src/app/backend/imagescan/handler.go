@@ -0,0 +1,83 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package imagescan
+
+import (
+	"net/http"
+
+	restful "github.com/emicklei/go-restful"
+
+	clientapi "github.com/kubernetes/dashboard/src/app/backend/client/api"
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/imagescan/api"
+)
+
+// ScanHandler manages all endpoints related to image vulnerability scans.
+type ScanHandler struct {
+	manager       api.ScanManager
+	clientManager clientapi.ClientManager
+}
+
+// Install creates new endpoints for image vulnerability scans. Reports are returned with enabled set to false
+// when no scanner is configured.
+func (self *ScanHandler) Install(ws *restful.WebService) {
+	ws.Route(
+		ws.GET("/pod/{namespace}/{pod}/vulnerability").
+			To(self.handleGetPodReport).
+			Writes(api.VulnerabilityReport{}))
+	ws.Route(
+		ws.GET("/deployment/{namespace}/{deployment}/vulnerability").
+			To(self.handleGetDeploymentReport).
+			Writes(api.VulnerabilityReport{}))
+}
+
+func (self *ScanHandler) handleGetPodReport(request *restful.Request, response *restful.Response) {
+	k8sClient, err := self.clientManager.Client(request)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	namespace := request.PathParameter("namespace")
+	name := request.PathParameter("pod")
+	result, err := GetPodVulnerabilityReport(self.manager, k8sClient, namespace, name)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (self *ScanHandler) handleGetDeploymentReport(request *restful.Request, response *restful.Response) {
+	k8sClient, err := self.clientManager.Client(request)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	namespace := request.PathParameter("namespace")
+	name := request.PathParameter("deployment")
+	result, err := GetDeploymentVulnerabilityReport(self.manager, k8sClient, namespace, name)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+// NewScanHandler creates ScanHandler.
+func NewScanHandler(manager api.ScanManager, clientManager clientapi.ClientManager) ScanHandler {
+	return ScanHandler{manager: manager, clientManager: clientManager}
+}
@@ -0,0 +1,96 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package imagescan
+
+import (
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	client "k8s.io/client-go/kubernetes"
+
+	"github.com/kubernetes/dashboard/src/app/backend/imagescan/api"
+)
+
+// GetPodVulnerabilityReport returns vulnerability summaries of all distinct images run by containers of the pod.
+func GetPodVulnerabilityReport(manager api.ScanManager, client client.Interface, namespace,
+	name string) (*api.VulnerabilityReport, error) {
+	pod, err := client.CoreV1().Pods(namespace).Get(name, metaV1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return toVulnerabilityReport(manager, []v1.Pod{*pod}, pod.Spec), nil
+}
+
+// GetDeploymentVulnerabilityReport returns vulnerability summaries of all distinct images run by pods of the
+// deployment.
+func GetDeploymentVulnerabilityReport(manager api.ScanManager, client client.Interface, namespace,
+	name string) (*api.VulnerabilityReport, error) {
+	deployment, err := client.AppsV1().Deployments(namespace).Get(name, metaV1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	selector, err := metaV1.LabelSelectorAsSelector(deployment.Spec.Selector)
+	if err != nil {
+		return nil, err
+	}
+
+	pods, err := client.CoreV1().Pods(namespace).List(metaV1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, err
+	}
+
+	return toVulnerabilityReport(manager, pods.Items, deployment.Spec.Template.Spec), nil
+}
+
+// toVulnerabilityReport summarizes images of the pods. Images are identified by image IDs from container
+// statuses, so that the image that is actually running is scanned. Containers of the spec that are not running
+// in any of the pods, i.e. because they are being pulled, are reported as unavailable.
+func toVulnerabilityReport(manager api.ScanManager, pods []v1.Pod, spec v1.PodSpec) *api.VulnerabilityReport {
+	report := &api.VulnerabilityReport{Enabled: manager.Enabled(), Images: make([]api.VulnerabilitySummary, 0)}
+	if !report.Enabled {
+		return report
+	}
+
+	imageIDs := make(map[string]bool)
+	running := make(map[string]bool)
+	for _, pod := range pods {
+		statuses := append(append([]v1.ContainerStatus{}, pod.Status.InitContainerStatuses...),
+			pod.Status.ContainerStatuses...)
+		for _, status := range statuses {
+			if len(status.ImageID) == 0 {
+				continue
+			}
+			running[status.Name] = true
+			if imageIDs[status.ImageID] {
+				continue
+			}
+			imageIDs[status.ImageID] = true
+			report.Images = append(report.Images, manager.Summarize(status.Image, status.ImageID))
+		}
+	}
+
+	images := make(map[string]bool)
+	containers := append(append([]v1.Container{}, spec.InitContainers...), spec.Containers...)
+	for _, container := range containers {
+		if running[container.Name] || images[container.Image] {
+			continue
+		}
+		images[container.Image] = true
+		report.Images = append(report.Images, manager.Summarize(container.Image, ""))
+	}
+
+	return report
+}
@@ -0,0 +1,235 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package imagescan
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/docker/distribution/reference"
+
+	"github.com/kubernetes/dashboard/src/app/backend/args"
+	"github.com/kubernetes/dashboard/src/app/backend/imagescan/api"
+)
+
+const (
+	// resultTTL is the time completed scan results are cached for. Images are rescanned afterwards, because
+	// vulnerability databases are updated.
+	resultTTL = 6 * time.Hour
+
+	// retryAfter is the time failed scans are cached for, so that broken scanner is not called on every request.
+	retryAfter = time.Minute
+
+	// pendingTimeout is the time after which scans that are still not finished are submitted again.
+	pendingTimeout = 30 * time.Minute
+
+	// maxCacheEntries is the maximum number of cached digests. Least recently used digests are dropped first.
+	maxCacheEntries = 1000
+)
+
+// cacheEntry is the state of the scan of a single image digest.
+type cacheEntry struct {
+	scanID    string
+	summary   api.VulnerabilitySummary
+	updatedAt time.Time
+}
+
+// cacheSlot holds the scan state of a single digest. Its lock is held while the scanner is called, so that
+// concurrent requests for the same digest submit a single scan.
+type cacheSlot struct {
+	mux   sync.Mutex
+	entry *cacheEntry
+
+	// lastUsed is guarded by the lock of the scan manager.
+	lastUsed time.Time
+}
+
+// scanManager caches vulnerability summaries per image digest. Scans are submitted on the first request for a
+// digest and their reports are polled on subsequent requests.
+type scanManager struct {
+	scanner api.Scanner
+	now     func() time.Time
+
+	mux   sync.Mutex
+	cache map[string]*cacheSlot
+}
+
+// Enabled implements ScanManager interface. See ScanManager for more information.
+func (self *scanManager) Enabled() bool {
+	return self.scanner != nil
+}
+
+// Summarize implements ScanManager interface. See ScanManager for more information.
+func (self *scanManager) Summarize(image, imageID string) api.VulnerabilitySummary {
+	scanned, err := parseImageID(imageID)
+	if err != nil {
+		return api.VulnerabilitySummary{Image: image, Status: api.ScanStatusUnavailable, Message: err.Error(),
+			Counts: map[api.Severity]int{}}
+	}
+
+	slot := self.getSlot(scanned.Digest)
+	slot.mux.Lock()
+	defer slot.mux.Unlock()
+
+	var current cacheEntry
+	switch {
+	case slot.entry == nil || self.expired(*slot.entry):
+		current = self.submit(scanned)
+	case slot.entry.summary.Status == api.ScanStatusPending:
+		current = self.poll(*slot.entry)
+	default:
+		current = *slot.entry
+	}
+	slot.entry = &current
+
+	summary := current.summary
+	summary.Image = image
+	return summary
+}
+
+// getSlot returns cache slot of the digest and creates it when it is not cached yet. Least recently used slots
+// are dropped, so that the cache does not grow with every image ever seen in the cluster.
+func (self *scanManager) getSlot(digest string) *cacheSlot {
+	self.mux.Lock()
+	defer self.mux.Unlock()
+
+	now := self.now()
+	slot, ok := self.cache[digest]
+	if !ok {
+		self.prune(now)
+		slot = &cacheSlot{}
+		self.cache[digest] = slot
+	}
+	slot.lastUsed = now
+	return slot
+}
+
+// prune drops slots that were not used for longer than results are cached for. When the cache is still full,
+// least recently used slot is dropped as well. Caller has to hold the lock of the scan manager.
+func (self *scanManager) prune(now time.Time) {
+	var oldestDigest string
+	var oldest time.Time
+	for digest, slot := range self.cache {
+		if now.Sub(slot.lastUsed) > resultTTL {
+			delete(self.cache, digest)
+			continue
+		}
+		if len(oldestDigest) == 0 || slot.lastUsed.Before(oldest) {
+			oldestDigest, oldest = digest, slot.lastUsed
+		}
+	}
+
+	if len(self.cache) >= maxCacheEntries {
+		delete(self.cache, oldestDigest)
+	}
+}
+
+func (self *scanManager) expired(entry cacheEntry) bool {
+	switch entry.summary.Status {
+	case api.ScanStatusCompleted:
+		return self.now().Sub(entry.updatedAt) > resultTTL
+	case api.ScanStatusFailed:
+		return self.now().Sub(entry.updatedAt) > retryAfter
+	case api.ScanStatusPending:
+		return self.now().Sub(entry.updatedAt) > pendingTimeout
+	}
+	return false
+}
+
+func (self *scanManager) submit(image api.Image) cacheEntry {
+	log.Printf("Submitting scan of %s/%s@%s image", image.Registry, image.Repository, image.Digest)
+	scanID, err := self.scanner.Submit(image)
+	if err != nil {
+		log.Printf("Cannot submit image scan: %s", err)
+		return cacheEntry{summary: failedSummary(image, err), updatedAt: self.now()}
+	}
+
+	return self.poll(cacheEntry{scanID: scanID, summary: api.VulnerabilitySummary{Digest: image.Digest,
+		Status: api.ScanStatusPending, Counts: map[api.Severity]int{}}, updatedAt: self.now()})
+}
+
+func (self *scanManager) poll(entry cacheEntry) cacheEntry {
+	vulnerabilities, finished, err := self.scanner.Report(entry.scanID)
+	if err != nil {
+		log.Printf("Cannot get report of image scan %s: %s", entry.scanID, err)
+		return cacheEntry{summary: failedSummary(api.Image{Digest: entry.summary.Digest}, err), updatedAt: self.now()}
+	}
+	if !finished {
+		return entry
+	}
+
+	scannedAt := self.now()
+	entry.updatedAt = scannedAt
+	entry.summary = api.VulnerabilitySummary{
+		Digest:    entry.summary.Digest,
+		Status:    api.ScanStatusCompleted,
+		Counts:    map[api.Severity]int{},
+		ScannedAt: &scannedAt,
+	}
+	for _, vulnerability := range vulnerabilities {
+		entry.summary.Counts[vulnerability.Severity]++
+		if len(vulnerability.FixVersion) > 0 {
+			entry.summary.Fixable++
+		}
+	}
+	return entry
+}
+
+func failedSummary(image api.Image, err error) api.VulnerabilitySummary {
+	return api.VulnerabilitySummary{Digest: image.Digest, Status: api.ScanStatusFailed, Message: err.Error(),
+		Counts: map[api.Severity]int{}}
+}
+
+// parseImageID extracts image repository and digest from the image ID reported by the container runtime, i.e.
+// docker-pullable://nginx@sha256:... Image IDs of locally built images, that have no repository digest, cannot
+// be scanned.
+func parseImageID(imageID string) (api.Image, error) {
+	if i := strings.Index(imageID, "://"); i >= 0 {
+		imageID = imageID[i+3:]
+	}
+
+	named, err := reference.ParseNormalizedNamed(imageID)
+	if err != nil {
+		return api.Image{}, fmt.Errorf("image digest is not known: %s", err)
+	}
+
+	digested, ok := named.(reference.Digested)
+	if !ok {
+		return api.Image{}, fmt.Errorf("image digest is not known")
+	}
+
+	return api.Image{
+		Registry:   reference.Domain(named),
+		Repository: reference.Path(named),
+		Digest:     digested.Digest().String(),
+	}, nil
+}
+
+// NewScanManager creates scan manager for the scanner configured by arguments. Scanning is disabled when no
+// scanner is configured.
+func NewScanManager() api.ScanManager {
+	var scanner api.Scanner
+	if url := args.Holder.GetImageScannerURL(); len(url) > 0 {
+		scanner = newAdapterScanner(url)
+	}
+	return newScanManager(scanner)
+}
+
+func newScanManager(scanner api.Scanner) *scanManager {
+	return &scanManager{scanner: scanner, now: time.Now, cache: make(map[string]*cacheSlot)}
+}
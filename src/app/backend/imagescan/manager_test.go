@@ -0,0 +1,202 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package imagescan
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/kubernetes/dashboard/src/app/backend/imagescan/api"
+)
+
+const testDigest = "sha256:0d17b565c37bcbd895e9d92315a05c1c3c9a29f762b011a10c54a66cd53c9b31"
+
+type fakeScanner struct {
+	mux       sync.Mutex
+	submitted []api.Image
+	finished  bool
+	err       error
+}
+
+func (self *fakeScanner) Submit(image api.Image) (string, error) {
+	if self.err != nil {
+		return "", self.err
+	}
+	self.mux.Lock()
+	defer self.mux.Unlock()
+	self.submitted = append(self.submitted, image)
+	return "scan-id", nil
+}
+
+func (self *fakeScanner) Report(scanID string) ([]api.Vulnerability, bool, error) {
+	if !self.finished {
+		return nil, false, nil
+	}
+	return []api.Vulnerability{
+		{ID: "CVE-1", Severity: api.SeverityCritical, FixVersion: "1.1"},
+		{ID: "CVE-2", Severity: api.SeverityCritical},
+		{ID: "CVE-3", Severity: api.SeverityLow},
+	}, true, nil
+}
+
+func TestScanManagerSummarize(t *testing.T) {
+	scanner := &fakeScanner{}
+	manager := newScanManager(scanner)
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	manager.now = func() time.Time { return now }
+	imageID := "docker-pullable://nginx@" + testDigest
+
+	summary := manager.Summarize("nginx:1.17", imageID)
+	if summary.Status != api.ScanStatusPending {
+		t.Fatalf("Expected pending scan, got %s", summary.Status)
+	}
+	expected := api.Image{Registry: "docker.io", Repository: "library/nginx", Digest: testDigest}
+	if len(scanner.submitted) != 1 || scanner.submitted[0] != expected {
+		t.Fatalf("Expected %#v to be submitted, got %#v", expected, scanner.submitted)
+	}
+
+	scanner.finished = true
+	summary = manager.Summarize("nginx:latest", imageID)
+	if summary.Status != api.ScanStatusCompleted || summary.Image != "nginx:latest" ||
+		summary.Counts[api.SeverityCritical] != 2 || summary.Counts[api.SeverityLow] != 1 || summary.Fixable != 1 {
+		t.Fatalf("Unexpected summary %#v", summary)
+	}
+
+	manager.Summarize("nginx:1.17", imageID)
+	if len(scanner.submitted) != 1 {
+		t.Fatalf("Expected cached result to be used, got %d submissions", len(scanner.submitted))
+	}
+
+	now = now.Add(resultTTL + time.Second)
+	manager.Summarize("nginx:1.17", imageID)
+	if len(scanner.submitted) != 2 {
+		t.Fatalf("Expected expired result to be rescanned, got %d submissions", len(scanner.submitted))
+	}
+}
+
+func TestScanManagerSummarizeConcurrently(t *testing.T) {
+	scanner := &fakeScanner{}
+	manager := newScanManager(scanner)
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	manager.now = func() time.Time { return now }
+	imageID := "docker-pullable://nginx@" + testDigest
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			manager.Summarize("nginx", imageID)
+		}()
+	}
+	wg.Wait()
+	if len(scanner.submitted) != 1 {
+		t.Fatalf("Expected concurrent requests to submit single scan, got %d submissions", len(scanner.submitted))
+	}
+
+	now = now.Add(pendingTimeout + time.Second)
+	if summary := manager.Summarize("nginx", imageID); summary.Status != api.ScanStatusPending ||
+		len(scanner.submitted) != 2 {
+		t.Fatalf("Expected stale pending scan to be submitted again, got %d submissions", len(scanner.submitted))
+	}
+}
+
+func TestScanManagerPrune(t *testing.T) {
+	manager := newScanManager(&fakeScanner{finished: true})
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	manager.now = func() time.Time { return now }
+
+	for i := 0; i < maxCacheEntries+1; i++ {
+		now = now.Add(time.Second)
+		manager.Summarize("nginx", fmt.Sprintf("nginx@sha256:%064x", i))
+	}
+	if len(manager.cache) != maxCacheEntries {
+		t.Fatalf("Expected cache to be limited to %d digests, got %d", maxCacheEntries, len(manager.cache))
+	}
+	if _, ok := manager.cache[fmt.Sprintf("sha256:%064x", 0)]; ok {
+		t.Errorf("Expected least recently used digest to be dropped")
+	}
+
+	now = now.Add(resultTTL + time.Second)
+	manager.Summarize("nginx", "nginx@"+testDigest)
+	if len(manager.cache) != 1 {
+		t.Errorf("Expected expired digests to be dropped, got %d", len(manager.cache))
+	}
+}
+
+func TestScanManagerSummarizeErrors(t *testing.T) {
+	scanner := &fakeScanner{err: errors.New("scanner is down")}
+	manager := newScanManager(scanner)
+
+	if summary := manager.Summarize("nginx", ""); summary.Status != api.ScanStatusUnavailable {
+		t.Errorf("Expected image without digest to be unavailable, got %s", summary.Status)
+	}
+
+	if summary := manager.Summarize("nginx", "nginx@"+testDigest); summary.Status != api.ScanStatusFailed ||
+		summary.Message != "scanner is down" {
+		t.Errorf("Expected failed scan, got %#v", summary)
+	}
+}
+
+func TestGetPodVulnerabilityReport(t *testing.T) {
+	pod := &v1.Pod{
+		ObjectMeta: metaV1.ObjectMeta{Name: "pod", Namespace: "default"},
+		Spec: v1.PodSpec{
+			InitContainers: []v1.Container{{Name: "init", Image: "nginx:1.17"}},
+			Containers: []v1.Container{
+				{Name: "app", Image: "nginx:1.17"},
+				{Name: "sidecar", Image: "busybox"},
+			},
+		},
+		Status: v1.PodStatus{
+			InitContainerStatuses: []v1.ContainerStatus{
+				{Name: "init", Image: "docker.io/library/nginx:1.17", ImageID: "docker-pullable://nginx@" + testDigest},
+			},
+			ContainerStatuses: []v1.ContainerStatus{
+				{Name: "app", Image: "docker.io/library/nginx:1.17", ImageID: "docker-pullable://nginx@" + testDigest},
+				{Name: "sidecar", Image: "busybox"},
+			},
+		},
+	}
+
+	report, err := GetPodVulnerabilityReport(newScanManager(&fakeScanner{finished: true}),
+		fake.NewSimpleClientset(pod), "default", "pod")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !report.Enabled || len(report.Images) != 2 {
+		t.Fatalf("Expected 2 distinct images, got %#v", report)
+	}
+	if report.Images[0].Status != api.ScanStatusCompleted || report.Images[1].Image != "busybox" ||
+		report.Images[1].Status != api.ScanStatusUnavailable {
+		t.Errorf("Unexpected report %#v", report.Images)
+	}
+
+	report, err = GetPodVulnerabilityReport(newScanManager(nil), fake.NewSimpleClientset(pod), "default", "pod")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Enabled || len(report.Images) != 0 {
+		t.Errorf("Expected disabled report, got %#v", report)
+	}
+}
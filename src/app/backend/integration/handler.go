@@ -18,6 +18,8 @@ import (
 	"net/http"
 
 	restful "github.com/emicklei/go-restful"
+
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
 	"github.com/kubernetes/dashboard/src/app/backend/integration/api"
 )
 
@@ -43,8 +45,7 @@ func (self IntegrationHandler) handleGetState(request *restful.Request, response
 	integrationName := request.PathParameter("name")
 	state, err := self.manager.GetState(api.IntegrationID(integrationName))
 	if err != nil {
-		response.AddHeader("Content-Type", "text/plain")
-		response.WriteErrorString(http.StatusInternalServerError, err.Error()+"\n")
+		errors.WriteError(request, response, err)
 		return
 	}
 	response.WriteHeaderAndEntity(http.StatusOK, state)
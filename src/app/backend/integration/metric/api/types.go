@@ -45,6 +45,34 @@ type MetricClient interface {
 	integrationapi.Integration
 }
 
+// TrafficMetricClient is an interface that exposes request metrics of services collected by a service mesh.
+type TrafficMetricClient interface {
+	// ServiceTraffic returns traffic metrics of the service. Nil is returned when there are no metrics of the
+	// service, i.e. when it is not a part of the mesh.
+	ServiceTraffic(namespace, name string) (*ServiceTraffic, error)
+
+	// Implements IntegrationApp interface
+	integrationapi.Integration
+}
+
+// ServiceTraffic contains request metrics of a single service over a recent time window.
+type ServiceTraffic struct {
+	// Service mesh that reported the metrics, i.e. istio or linkerd.
+	Mesh string `json:"mesh"`
+
+	// Time window the metrics are calculated over, i.e. 5m.
+	Window string `json:"window"`
+
+	// Number of requests per second.
+	RequestRate float64 `json:"requestRate"`
+
+	// Fraction of requests, that failed, between 0 and 1. Not set when there were no requests.
+	ErrorRate *float64 `json:"errorRate,omitempty"`
+
+	// 99th percentile of request latency in milliseconds. Not set when there were no requests.
+	P99Latency *float64 `json:"p99Latency,omitempty"`
+}
+
 // CachedResources contains all resources that may be required by DataSelect functions for metric
 // gathering. Depending on the need you may have to provide DataSelect with resources it
 // requires, for example resource like deployment will need Pods in order to calculate its metrics.
@@ -0,0 +1,90 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/kubernetes/dashboard/src/app/backend/args"
+	integrationapi "github.com/kubernetes/dashboard/src/app/backend/integration/api"
+	"github.com/kubernetes/dashboard/src/app/backend/logging"
+)
+
+const (
+	requestResultSuccess = "success"
+	requestResultError   = "error"
+)
+
+var (
+	metricClientRequestCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "dashboard_metric_client_requests_total",
+			Help: "Counter of requests made to the metrics backend broken out for each integration, resource " +
+				"and result.",
+		},
+		[]string{"integration", "resource", "result"},
+	)
+	metricClientRequestDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name: "dashboard_metric_client_request_duration_seconds",
+			Help: "Latency distribution in seconds of requests made to the metrics backend, broken out for each " +
+				"integration and resource.",
+			// Use buckets ranging from 5 ms to ~20 seconds.
+			Buckets: prometheus.ExponentialBuckets(0.005, 2.0, 13),
+		},
+		[]string{"integration", "resource"},
+	)
+)
+
+// Initialize metric client metrics in prometheus.
+func init() {
+	prometheus.MustRegister(metricClientRequestCounter)
+	prometheus.MustRegister(metricClientRequestDuration)
+}
+
+// TrackRequest records outcome and duration of a single request made by the metric client with given
+// integration id for metrics of given resource kind, i.e. pod. Error rate can be computed as the ratio of
+// requests with 'error' result to all requests.
+func TrackRequest(id integrationapi.IntegrationID, resource string, start time.Time, err error) {
+	result := requestResultSuccess
+	if err != nil {
+		result = requestResultError
+	}
+
+	metricClientRequestCounter.WithLabelValues(string(id), resource, result).Inc()
+	metricClientRequestDuration.WithLabelValues(string(id), resource).Observe(time.Since(start).Seconds())
+}
+
+// LogSlowRequest logs request made by the metric client when it took longer than configured threshold. Path
+// selects resources of given kind, items is the number of requested resources.
+func LogSlowRequest(id integrationapi.IntegrationID, resource, path string, items int, start time.Time) {
+	threshold := time.Duration(args.Holder.GetSlowRequestThreshold()) * time.Millisecond
+	duration := time.Since(start)
+	if threshold <= 0 || duration <= threshold {
+		return
+	}
+
+	logging.Log(logging.LevelWarning, fmt.Sprintf("Slow %s request %s", id, path), logging.Fields{
+		"upstream":   string(id),
+		"verb":       "GET",
+		"resource":   resource,
+		"path":       path,
+		"items":      items,
+		"durationMs": float64(duration) / float64(time.Millisecond),
+	})
+}
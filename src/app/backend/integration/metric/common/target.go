@@ -0,0 +1,79 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	metricapi "github.com/kubernetes/dashboard/src/app/backend/integration/metric/api"
+)
+
+// Target lists nodes, or pods of a single namespace, whose metrics are summed into the metric of selected
+// resource. It is used by metric clients, that download metrics of native resources only.
+type Target struct {
+	Kind      api.ResourceKind
+	Namespace string
+	Names     []string
+	UIDs      []types.UID
+}
+
+// GetTarget converts the selector to native resources. Derived resources, i.e. deployments, are converted to
+// their pods found in cached resources.
+func GetTarget(selector metricapi.ResourceSelector, cachedResources *metricapi.CachedResources) (Target, error) {
+	switch selector.ResourceType {
+	case api.ResourceKindNode:
+		return Target{Kind: api.ResourceKindNode, Names: []string{selector.ResourceName},
+			UIDs: []types.UID{selector.UID}}, nil
+	case api.ResourceKindPod:
+		return Target{Kind: api.ResourceKindPod, Namespace: selector.Namespace,
+			Names: []string{selector.ResourceName}, UIDs: []types.UID{selector.UID}}, nil
+	}
+
+	if metricapi.DerivedResources[selector.ResourceType] != api.ResourceKindPod {
+		return Target{}, fmt.Errorf(`Resource "%s" is not supported`, selector.ResourceType)
+	}
+	if cachedResources == nil || cachedResources.Pods == nil {
+		return Target{}, fmt.Errorf(`Pods were not available in cache. Required for resource type: "%s"`,
+			selector.ResourceType)
+	}
+
+	result := Target{Kind: api.ResourceKindPod, Namespace: selector.Namespace}
+	for _, pod := range cachedResources.Pods {
+		if pod.Namespace == selector.Namespace && isOwnedBy(pod, selector) {
+			result.Names = append(result.Names, pod.Name)
+			result.UIDs = append(result.UIDs, pod.UID)
+		}
+	}
+	return result, nil
+}
+
+// isOwnedBy checks if the pod belongs to the derived resource. Pods of deployments are matched by the selector,
+// as they are owned by replica sets.
+func isOwnedBy(pod v1.Pod, selector metricapi.ResourceSelector) bool {
+	if selector.ResourceType == api.ResourceKindDeployment {
+		return api.IsSelectorMatching(selector.Selector, pod.Labels)
+	}
+
+	for _, ownerRef := range pod.OwnerReferences {
+		if ownerRef.Controller != nil && *ownerRef.Controller && ownerRef.UID == selector.UID {
+			return true
+		}
+	}
+	return false
+}
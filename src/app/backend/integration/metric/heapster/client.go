@@ -15,21 +15,25 @@
 package heapster
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
 	"strings"
+	"time"
 
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	heapster "k8s.io/heapster/metrics/api/v1/types"
 
+	"github.com/kubernetes/dashboard/src/app/backend/api"
 	"github.com/kubernetes/dashboard/src/app/backend/client"
 	"github.com/kubernetes/dashboard/src/app/backend/errors"
 	integrationapi "github.com/kubernetes/dashboard/src/app/backend/integration/api"
 	metricapi "github.com/kubernetes/dashboard/src/app/backend/integration/metric/api"
 	"github.com/kubernetes/dashboard/src/app/backend/integration/metric/common"
+	"github.com/kubernetes/dashboard/src/app/backend/tracing"
 )
 
 // Heapster client implements MetricClient and Integration interfaces.
@@ -155,7 +159,7 @@ func (self heapsterClient) ithResourceDownload(selector heapsterSelector, metric
 	result := metricapi.NewMetricPromise()
 	go func() {
 		rawResult := heapster.MetricResult{}
-		err := self.unmarshalType(selector.Path+selector.Resources[i]+"/metrics/"+metricName, &rawResult)
+		err := self.unmarshalType(selector.TargetResourceType, 1, selector.Path+selector.Resources[i]+"/metrics/"+metricName, &rawResult)
 		if err != nil {
 			result.Metric <- nil
 			result.Error <- err
@@ -188,7 +192,7 @@ func (self heapsterClient) allInOneDownload(selector heapsterSelector, metricNam
 			return
 		}
 		rawResults := heapster.MetricResultList{}
-		err := self.unmarshalType(selector.Path+strings.Join(selector.Resources, ",")+"/metrics/"+metricName, &rawResults)
+		err := self.unmarshalType(selector.TargetResourceType, len(selector.Resources), selector.Path+strings.Join(selector.Resources, ",")+"/metrics/"+metricName, &rawResults)
 		if err != nil {
 			result.PutMetrics(nil, err)
 			return
@@ -220,9 +224,13 @@ func (self heapsterClient) allInOneDownload(selector heapsterSelector, metricNam
 }
 
 // unmarshalType performs heapster GET request to the specifies path and transfers
-// the data to the interface provided.
-func (self heapsterClient) unmarshalType(path string, v interface{}) error {
+// the data to the interface provided. Request is tracked for given resource kind and number of
+// requested items.
+func (self heapsterClient) unmarshalType(resource api.ResourceKind, items int, path string, v interface{}) error {
+	start := time.Now()
 	rawData, err := self.client.Get("/model/" + path).DoRaw()
+	common.TrackRequest(integrationapi.HeapsterIntegrationID, string(resource), start, err)
+	common.LogSlowRequest(integrationapi.HeapsterIntegrationID, string(resource), path, items, start)
 	if err != nil {
 		return err
 	}
@@ -242,7 +250,8 @@ func CreateHeapsterClient(host string, k8sClient kubernetes.Interface) (
 		return heapsterClient{client: c}, nil
 	}
 
-	cfg := &rest.Config{Host: host, QPS: client.DefaultQPS, Burst: client.DefaultBurst}
+	cfg := &rest.Config{Host: host, QPS: client.DefaultQPS, Burst: client.DefaultBurst,
+		WrapTransport: tracing.WrapTransport(context.Background())}
 	restClient, err := kubernetes.NewForConfig(cfg)
 	if err != nil {
 		return heapsterClient{}, err
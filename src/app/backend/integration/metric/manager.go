@@ -23,6 +23,8 @@ import (
 	integrationapi "github.com/kubernetes/dashboard/src/app/backend/integration/api"
 	metricapi "github.com/kubernetes/dashboard/src/app/backend/integration/metric/api"
 	"github.com/kubernetes/dashboard/src/app/backend/integration/metric/heapster"
+	"github.com/kubernetes/dashboard/src/app/backend/integration/metric/metricsserver"
+	"github.com/kubernetes/dashboard/src/app/backend/integration/metric/prometheus"
 	"github.com/kubernetes/dashboard/src/app/backend/integration/metric/sidecar"
 	"k8s.io/apimachinery/pkg/util/wait"
 )
@@ -45,6 +47,18 @@ type MetricManager interface {
 	ConfigureSidecar(host string) MetricManager
 	// ConfigureHeapster configures and adds sidecar to clients list.
 	ConfigureHeapster(host string) MetricManager
+	// ConfigureMetricsServer configures and adds metrics-server client to clients list.
+	ConfigureMetricsServer() MetricManager
+	// ConfigurePrometheus configures client of Prometheus, that collects service mesh traffic metrics.
+	ConfigurePrometheus(host string) MetricManager
+	// ConfigurePrometheusMetrics configures and adds client of Prometheus, that collects container metrics, to
+	// clients list.
+	ConfigurePrometheusMetrics(host string) MetricManager
+	// TrafficClient returns traffic metric client. It is nil when Prometheus is not configured or not reachable.
+	TrafficClient() metricapi.TrafficMetricClient
+	// EnableTrafficWithRetry runs health check of traffic metric client in a separate thread every 'period'
+	// seconds and enables the client while it is healthy.
+	EnableTrafficWithRetry(period time.Duration)
 }
 
 // Implements MetricManager interface.
@@ -52,6 +66,11 @@ type metricManager struct {
 	manager clientapi.ClientManager
 	clients map[integrationapi.IntegrationID]metricapi.MetricClient
 	active  metricapi.MetricClient
+	// checked is true once health of the metric client was checked at least once.
+	checked bool
+
+	traffic        metricapi.TrafficMetricClient
+	trafficHealthy bool
 }
 
 // AddClient implements metric manager interface. See MetricManager for more information.
@@ -94,9 +113,15 @@ func (self *metricManager) EnableWithRetry(id integrationapi.IntegrationID, peri
 		}
 
 		err := metricClient.HealthCheck()
+		firstCheck := !self.checked
+		self.checked = true
 		if err != nil {
+			// Report only when metrics become unavailable, so that the log is not flooded by periodic checks.
+			if firstCheck || self.active != nil {
+				log.Printf("Metric client health check failed: %s. Metrics will not be available. "+
+					"Retrying every %d seconds.", err, period)
+			}
 			self.active = nil
-			log.Printf("Metric client health check failed: %s. Retrying in %d seconds.", err, period)
 			return
 		}
 
@@ -114,6 +139,11 @@ func (self *metricManager) List() []integrationapi.Integration {
 		result = append(result, c.(integrationapi.Integration))
 	}
 
+	// Prometheus can be both the metric and the traffic metric client.
+	if _, listed := self.clients[integrationapi.PrometheusIntegrationID]; self.traffic != nil && !listed {
+		result = append(result, self.traffic)
+	}
+
 	return result
 }
 
@@ -143,6 +173,79 @@ func (self *metricManager) ConfigureHeapster(host string) MetricManager {
 	return self
 }
 
+// ConfigureMetricsServer implements metric manager interface. See MetricManager for more information.
+func (self *metricManager) ConfigureMetricsServer() MetricManager {
+	kubeClient := self.manager.InsecureClient()
+	metricClient, err := metricsserver.CreateMetricsServerClient(kubeClient)
+	if err != nil {
+		log.Printf("There was an error during metrics-server client creation: %s", err.Error())
+		return self
+	}
+
+	self.clients[metricClient.ID()] = metricClient
+	return self
+}
+
+// ConfigurePrometheus implements metric manager interface. See MetricManager for more information.
+func (self *metricManager) ConfigurePrometheus(host string) MetricManager {
+	trafficClient, err := prometheus.CreatePrometheusClient(host)
+	if err != nil {
+		log.Printf("There was an error during prometheus client creation: %s", err.Error())
+		return self
+	}
+
+	self.traffic = trafficClient
+	return self
+}
+
+// ConfigurePrometheusMetrics implements metric manager interface. See MetricManager for more information.
+func (self *metricManager) ConfigurePrometheusMetrics(host string) MetricManager {
+	metricClient, err := prometheus.CreatePrometheusMetricClient(host)
+	if err != nil {
+		log.Printf("There was an error during prometheus client creation: %s", err.Error())
+		return self
+	}
+
+	self.clients[metricClient.ID()] = metricClient
+	return self
+}
+
+// TrafficClient implements metric manager interface. See MetricManager for more information.
+func (self *metricManager) TrafficClient() metricapi.TrafficMetricClient {
+	if !self.trafficHealthy {
+		return nil
+	}
+
+	return self.traffic
+}
+
+// EnableTrafficWithRetry implements metric manager interface. See MetricManager for more information.
+func (self *metricManager) EnableTrafficWithRetry(period time.Duration) {
+	if self.traffic == nil {
+		return
+	}
+
+	checked := false
+	go wait.Forever(func() {
+		err := self.traffic.HealthCheck()
+		firstCheck := !checked
+		checked = true
+		if err != nil {
+			if firstCheck || self.trafficHealthy {
+				log.Printf("Traffic metric client health check failed: %s. Traffic metrics will not be available. "+
+					"Retrying every %d seconds.", err, period)
+			}
+			self.trafficHealthy = false
+			return
+		}
+
+		if !self.trafficHealthy {
+			log.Printf("Successful request to %s", self.traffic.ID())
+			self.trafficHealthy = true
+		}
+	}, period*time.Second)
+}
+
 // NewMetricManager creates metric manager.
 func NewMetricManager(manager clientapi.ClientManager) MetricManager {
 	return &metricManager{
@@ -0,0 +1,214 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metricsserver
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	integrationapi "github.com/kubernetes/dashboard/src/app/backend/integration/api"
+	metricapi "github.com/kubernetes/dashboard/src/app/backend/integration/metric/api"
+	"github.com/kubernetes/dashboard/src/app/backend/integration/metric/common"
+)
+
+// metricsServerClient implements MetricClient and Integration interfaces. Metrics-server keeps only the latest
+// usage of nodes and pods, so every downloaded metric has a single data point, taken at the time of download.
+type metricsServerClient struct {
+	client metricsAPIClient
+	now    func() time.Time
+}
+
+// Implement Integration interface.
+
+// HealthCheck implements integration app interface. See Integration interface for more information.
+func (self metricsServerClient) HealthCheck() error {
+	if self.client == nil {
+		return errors.New("Metrics-server not configured")
+	}
+
+	_, err := self.client.Get("")
+	return err
+}
+
+// ID implements integration app interface. See Integration interface for more information.
+func (self metricsServerClient) ID() integrationapi.IntegrationID {
+	return integrationapi.MetricsServerIntegrationID
+}
+
+// Implement MetricClient interface
+
+// DownloadMetrics implements metric client interface. See MetricClient for more information.
+func (self metricsServerClient) DownloadMetrics(selectors []metricapi.ResourceSelector,
+	metricNames []string, cachedResources *metricapi.CachedResources) metricapi.MetricPromises {
+	result := metricapi.MetricPromises{}
+	for _, metricName := range metricNames {
+		collectedMetrics := self.DownloadMetric(selectors, metricName, cachedResources)
+		result = append(result, collectedMetrics...)
+	}
+	return result
+}
+
+// DownloadMetric implements metric client interface. See MetricClient for more information. Usage of all
+// resources of a kind in a namespace is downloaded with a single request.
+func (self metricsServerClient) DownloadMetric(selectors []metricapi.ResourceSelector,
+	metricName string, cachedResources *metricapi.CachedResources) metricapi.MetricPromises {
+	result := metricapi.NewMetricPromises(len(selectors))
+	go func() {
+		resourceName, err := toResourceName(metricName)
+		if err != nil {
+			result.PutMetrics(nil, err)
+			return
+		}
+
+		timestamp := self.now()
+		usages := map[string]map[string]v1.ResourceList{}
+		for i, selector := range selectors {
+			target, err := common.GetTarget(selector, cachedResources)
+			if err != nil {
+				result[i].Metric <- nil
+				result[i].Error <- err
+				continue
+			}
+
+			key := string(target.Kind) + "/" + target.Namespace
+			usage, downloaded := usages[key]
+			if !downloaded {
+				usage, err = self.downloadUsage(target.Kind, target.Namespace)
+				if err != nil {
+					log.Printf("Cannot download %s metrics from metrics-server: %s", target.Kind, err)
+				}
+				usages[key] = usage
+			}
+			if usage == nil {
+				result[i].Metric <- nil
+				result[i].Error <- fmt.Errorf("can not get metrics of %s %s", target.Kind, selector.ResourceName)
+				continue
+			}
+
+			metric := toMetric(target, usage, resourceName, metricName, timestamp)
+			result[i].Metric <- &metric
+			result[i].Error <- nil
+		}
+	}()
+	return result
+}
+
+// AggregateMetrics implements metric client interface. See MetricClient for more information.
+func (self metricsServerClient) AggregateMetrics(metrics metricapi.MetricPromises, metricName string,
+	aggregations metricapi.AggregationModes) metricapi.MetricPromises {
+	return common.AggregateMetricPromises(metrics, metricName, aggregations, nil)
+}
+
+// downloadUsage returns current usage of all nodes, or all pods in the namespace, mapped by their names.
+func (self metricsServerClient) downloadUsage(kind api.ResourceKind, namespace string) (
+	map[string]v1.ResourceList, error) {
+	start := time.Now()
+	result := map[string]v1.ResourceList{}
+	var err error
+	path := "/namespaces/" + namespace + "/pods"
+	if kind == api.ResourceKindNode {
+		path = "/nodes"
+		list := nodeMetricsList{}
+		if err = self.unmarshal(path, &list); err == nil {
+			for _, item := range list.Items {
+				result[item.ObjectMeta.Name] = item.Usage
+			}
+		}
+	} else {
+		list := podMetricsList{}
+		if err = self.unmarshal(path, &list); err == nil {
+			for _, item := range list.Items {
+				result[item.ObjectMeta.Name] = item.usage()
+			}
+		}
+	}
+
+	common.TrackRequest(integrationapi.MetricsServerIntegrationID, string(kind), start, err)
+	common.LogSlowRequest(integrationapi.MetricsServerIntegrationID, string(kind), path, len(result), start)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (self metricsServerClient) unmarshal(path string, v interface{}) error {
+	rawData, err := self.client.Get(path)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(rawData, v)
+}
+
+// toResourceName maps metric name to the name of the resource reported by metrics-server.
+func toResourceName(metricName string) (v1.ResourceName, error) {
+	switch metricName {
+	case metricapi.CpuUsage:
+		return v1.ResourceCPU, nil
+	case metricapi.MemoryUsage:
+		return v1.ResourceMemory, nil
+	default:
+		return "", fmt.Errorf(`Metric "%s" is not supported by metrics-server`, metricName)
+	}
+}
+
+// toMetric sums usage of target resources. Resources without metrics, i.e. pods that just started, are skipped.
+// CPU usage is in millicores and memory usage in bytes, the same as in metrics of other providers.
+func toMetric(target common.Target, usage map[string]v1.ResourceList, resourceName v1.ResourceName, metricName string,
+	timestamp time.Time) metricapi.Metric {
+	metrics := make([]metricapi.Metric, 0)
+	for i, name := range target.Names {
+		resourceUsage, exists := usage[name]
+		if !exists {
+			continue
+		}
+
+		quantity := resourceUsage[resourceName]
+		value := quantity.Value()
+		if resourceName == v1.ResourceCPU {
+			value = quantity.MilliValue()
+		}
+
+		metrics = append(metrics, metricapi.Metric{
+			DataPoints:   metricapi.DataPoints{{X: timestamp.Unix(), Y: value}},
+			MetricPoints: []metricapi.MetricPoint{{Timestamp: timestamp, Value: uint64(value)}},
+			MetricName:   metricName,
+			Label:        metricapi.Label{target.Kind: []types.UID{target.UIDs[i]}},
+		})
+	}
+
+	return common.AggregateData(metrics, metricName, metricapi.SumAggregation)
+}
+
+// CreateMetricsServerClient creates new metrics-server client. Metrics are read from metrics.k8s.io API,
+// that metrics-server registers in the API server, so no address has to be configured.
+func CreateMetricsServerClient(k8sClient kubernetes.Interface) (metricapi.MetricClient, error) {
+	if k8sClient == nil {
+		return metricsServerClient{}, errors.New("Kubernetes client is required to reach metrics-server")
+	}
+
+	log.Print("Creating metrics-server client")
+	return metricsServerClient{
+		client: apiServerMetricsClient{client: k8sClient.CoreV1().RESTClient()},
+		now:    time.Now,
+	}, nil
+}
@@ -0,0 +1,164 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metricsserver
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	metricapi "github.com/kubernetes/dashboard/src/app/backend/integration/metric/api"
+)
+
+const podMetricsJSON = `{"kind":"PodMetricsList","items":[
+{"metadata":{"name":"web-1","namespace":"default"},"timestamp":"2020-01-01T00:00:00Z","window":"30s",
+ "containers":[{"name":"app","usage":{"cpu":"100m","memory":"64Mi"}},{"name":"proxy","usage":{"cpu":"5000000n","memory":"16Mi"}}]},
+{"metadata":{"name":"web-2","namespace":"default"},"timestamp":"2020-01-01T00:00:00Z","window":"30s",
+ "containers":[{"name":"app","usage":{"cpu":"200m","memory":"128Mi"}}]}]}`
+
+const nodeMetricsJSON = `{"kind":"NodeMetricsList","items":[
+{"metadata":{"name":"node-1"},"timestamp":"2020-01-01T00:00:00Z","window":"30s","usage":{"cpu":"1500m","memory":"2Gi"}}]}`
+
+type fakeMetricsAPIClient struct {
+	responses map[string]string
+	requests  []string
+}
+
+func (self *fakeMetricsAPIClient) Get(path string) ([]byte, error) {
+	self.requests = append(self.requests, path)
+	response, exists := self.responses[path]
+	if !exists {
+		return nil, errors.New("the server could not find the requested resource")
+	}
+	return []byte(response), nil
+}
+
+func TestDownloadMetric(t *testing.T) {
+	timestamp := time.Unix(1577836800, 0)
+	controller := true
+	pods := []v1.Pod{
+		{ObjectMeta: metaV1.ObjectMeta{Name: "web-1", Namespace: "default", UID: "P1",
+			OwnerReferences: []metaV1.OwnerReference{{UID: "RS1", Controller: &controller}}}},
+		{ObjectMeta: metaV1.ObjectMeta{Name: "web-2", Namespace: "default", UID: "P2",
+			OwnerReferences: []metaV1.OwnerReference{{UID: "RS1", Controller: &controller}}}},
+		{ObjectMeta: metaV1.ObjectMeta{Name: "web-3", Namespace: "default", UID: "P3",
+			OwnerReferences: []metaV1.OwnerReference{{UID: "RS1", Controller: &controller}}}},
+	}
+
+	cases := []struct {
+		info       string
+		selector   metricapi.ResourceSelector
+		metricName string
+		expected   int64
+	}{
+		{
+			"pod cpu usage is sum of its containers",
+			metricapi.ResourceSelector{Namespace: "default", ResourceType: api.ResourceKindPod, ResourceName: "web-1",
+				UID: "P1"},
+			metricapi.CpuUsage,
+			105,
+		},
+		{
+			"replica set memory usage is sum of its pods, pods without metrics are skipped",
+			metricapi.ResourceSelector{Namespace: "default", ResourceType: api.ResourceKindReplicaSet,
+				ResourceName: "web", UID: "RS1"},
+			metricapi.MemoryUsage,
+			208 * 1024 * 1024,
+		},
+		{
+			"node cpu usage",
+			metricapi.ResourceSelector{ResourceType: api.ResourceKindNode, ResourceName: "node-1", UID: "N1"},
+			metricapi.CpuUsage,
+			1500,
+		},
+	}
+
+	for _, c := range cases {
+		apiClient := &fakeMetricsAPIClient{responses: map[string]string{
+			"/namespaces/default/pods": podMetricsJSON,
+			"/nodes":                   nodeMetricsJSON,
+		}}
+		client := metricsServerClient{client: apiClient, now: func() time.Time { return timestamp }}
+
+		metrics, err := client.DownloadMetric([]metricapi.ResourceSelector{c.selector}, c.metricName,
+			&metricapi.CachedResources{Pods: pods}).GetMetrics()
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", c.info, err)
+			continue
+		}
+
+		expected := metricapi.DataPoints{{X: timestamp.Unix(), Y: c.expected}}
+		if len(metrics) != 1 || !reflect.DeepEqual(metrics[0].DataPoints, expected) {
+			t.Errorf("%s: expected data points %v, got %v", c.info, expected, metrics)
+		}
+		if len(apiClient.requests) != 1 {
+			t.Errorf("%s: expected single request, got %v", c.info, apiClient.requests)
+		}
+	}
+}
+
+func TestDownloadMetricSingleRequestPerNamespace(t *testing.T) {
+	apiClient := &fakeMetricsAPIClient{responses: map[string]string{"/namespaces/default/pods": podMetricsJSON}}
+	client := metricsServerClient{client: apiClient, now: time.Now}
+	selectors := []metricapi.ResourceSelector{
+		{Namespace: "default", ResourceType: api.ResourceKindPod, ResourceName: "web-1", UID: "P1"},
+		{Namespace: "default", ResourceType: api.ResourceKindPod, ResourceName: "web-2", UID: "P2"},
+		{Namespace: "other", ResourceType: api.ResourceKindPod, ResourceName: "web-1", UID: "P4"},
+	}
+
+	promises := client.DownloadMetric(selectors, metricapi.MemoryUsage, metricapi.NoResourceCache)
+	for i, promise := range promises {
+		metric, err := promise.GetMetric()
+		if i < 2 && (err != nil || metric.Label[api.ResourceKindPod][0] != selectors[i].UID) {
+			t.Errorf("expected metric of %s, got %v, %v", selectors[i].ResourceName, metric, err)
+		}
+		if i == 2 && err == nil {
+			t.Errorf("expected error for namespace without metrics, got %v", metric)
+		}
+	}
+
+	expectedRequests := []string{"/namespaces/default/pods", "/namespaces/other/pods"}
+	if !reflect.DeepEqual(apiClient.requests, expectedRequests) {
+		t.Errorf("expected requests %v, got %v", expectedRequests, apiClient.requests)
+	}
+}
+
+func TestDownloadMetricUnsupported(t *testing.T) {
+	client := metricsServerClient{client: &fakeMetricsAPIClient{}, now: time.Now}
+	selector := metricapi.ResourceSelector{Namespace: "default", ResourceType: api.ResourceKindPod,
+		ResourceName: "web-1", UID: types.UID("P1")}
+
+	promises := client.DownloadMetric([]metricapi.ResourceSelector{selector}, "cpu/limit", metricapi.NoResourceCache)
+	if _, err := promises[0].GetMetric(); err == nil {
+		t.Error("expected error for metric not reported by metrics-server")
+	}
+}
+
+func TestHealthCheck(t *testing.T) {
+	if err := (metricsServerClient{}).HealthCheck(); err == nil {
+		t.Error("expected health check of not configured client to fail")
+	}
+
+	client := metricsServerClient{client: &fakeMetricsAPIClient{responses: map[string]string{"": "{}"}}}
+	if err := client.HealthCheck(); err != nil {
+		t.Errorf("expected health check to pass, got %v", err)
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metricsserver
+
+import (
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Following types mirror the metrics.k8s.io/v1beta1 API served by metrics-server. Only fields used by Dashboard
+// are decoded.
+
+// nodeMetricsList is a list of current resource usage of nodes.
+type nodeMetricsList struct {
+	Items []nodeMetrics `json:"items"`
+}
+
+// nodeMetrics is current resource usage of a single node.
+type nodeMetrics struct {
+	ObjectMeta metaV1.ObjectMeta `json:"metadata"`
+	Timestamp  metaV1.Time       `json:"timestamp"`
+	Usage      v1.ResourceList   `json:"usage"`
+}
+
+// podMetricsList is a list of current resource usage of pods.
+type podMetricsList struct {
+	Items []podMetrics `json:"items"`
+}
+
+// podMetrics is current resource usage of containers of a single pod.
+type podMetrics struct {
+	ObjectMeta metaV1.ObjectMeta  `json:"metadata"`
+	Timestamp  metaV1.Time        `json:"timestamp"`
+	Containers []containerMetrics `json:"containers"`
+}
+
+// containerMetrics is current resource usage of a single container.
+type containerMetrics struct {
+	Name  string          `json:"name"`
+	Usage v1.ResourceList `json:"usage"`
+}
+
+// usage returns sum of usage of all containers of the pod.
+func (self podMetrics) usage() v1.ResourceList {
+	result := v1.ResourceList{}
+	for _, container := range self.Containers {
+		for name, quantity := range container.Usage {
+			sum := result[name]
+			sum.Add(quantity)
+			result[name] = sum
+		}
+	}
+	return result
+}
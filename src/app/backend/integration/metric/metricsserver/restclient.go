@@ -0,0 +1,38 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metricsserver
+
+import (
+	"k8s.io/client-go/rest"
+)
+
+// metricsAPIPath is the path of the metrics API registered by metrics-server in the API server aggregation layer.
+const metricsAPIPath = "/apis/metrics.k8s.io/v1beta1"
+
+// metricsAPIClient is used to make raw requests to the metrics API. Separation is done to allow testing.
+type metricsAPIClient interface {
+	// Get sends GET request to the path relative to the metrics API, i.e. /nodes.
+	Get(path string) ([]byte, error)
+}
+
+// apiServerMetricsClient talks to metrics-server through the API server.
+type apiServerMetricsClient struct {
+	client rest.Interface
+}
+
+// Get implements metricsAPIClient interface.
+func (self apiServerMetricsClient) Get(path string) ([]byte, error) {
+	return self.client.Get().AbsPath(metricsAPIPath + path).DoRaw()
+}
@@ -0,0 +1,227 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheus
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	integrationapi "github.com/kubernetes/dashboard/src/app/backend/integration/api"
+	metricapi "github.com/kubernetes/dashboard/src/app/backend/integration/metric/api"
+	"github.com/kubernetes/dashboard/src/app/backend/tracing"
+)
+
+const (
+	// window is the time range that rates and latency are calculated over.
+	window = "5m"
+
+	requestTimeout = 10 * time.Second
+)
+
+// meshQueries contains PromQL queries of a single service mesh. Selector placeholders are replaced with the
+// namespace and name of the service.
+type meshQueries struct {
+	mesh        string
+	requestRate string
+	errorRate   string
+	p99Latency  string
+}
+
+// Queries are tried in order. Metrics of the first mesh, that reports any requests of the service, are used.
+var queries = []meshQueries{
+	{
+		mesh: "istio",
+		requestRate: `sum(rate(istio_requests_total{reporter="destination",destination_service_namespace="%[1]s",` +
+			`destination_service_name="%[2]s"}[%[3]s]))`,
+		errorRate: `sum(rate(istio_requests_total{reporter="destination",destination_service_namespace="%[1]s",` +
+			`destination_service_name="%[2]s",response_code=~"5.."}[%[3]s])) / ` +
+			`sum(rate(istio_requests_total{reporter="destination",destination_service_namespace="%[1]s",` +
+			`destination_service_name="%[2]s"}[%[3]s]))`,
+		p99Latency: `histogram_quantile(0.99, sum(rate(istio_request_duration_milliseconds_bucket{` +
+			`reporter="destination",destination_service_namespace="%[1]s",destination_service_name="%[2]s"}` +
+			`[%[3]s])) by (le))`,
+	},
+	{
+		mesh: "linkerd",
+		requestRate: `sum(rate(response_total{direction="outbound",dst_namespace="%[1]s",dst_service="%[2]s"}` +
+			`[%[3]s]))`,
+		errorRate: `sum(rate(response_total{direction="outbound",dst_namespace="%[1]s",dst_service="%[2]s",` +
+			`classification="failure"}[%[3]s])) / ` +
+			`sum(rate(response_total{direction="outbound",dst_namespace="%[1]s",dst_service="%[2]s"}[%[3]s]))`,
+		p99Latency: `histogram_quantile(0.99, sum(rate(response_latency_ms_bucket{direction="outbound",` +
+			`dst_namespace="%[1]s",dst_service="%[2]s"}[%[3]s])) by (le))`,
+	},
+}
+
+// queryResponse is a response of the Prometheus instant query API.
+type queryResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+	Data   struct {
+		ResultType string `json:"resultType"`
+		Result     []struct {
+			// Value is a pair of a timestamp and a string value.
+			Value []interface{} `json:"value"`
+		} `json:"result"`
+	} `json:"data"`
+}
+
+// Prometheus client implements TrafficMetricClient, MetricClient and Integration interfaces.
+type prometheusClient struct {
+	host   string
+	client *http.Client
+}
+
+// HealthCheck implements integration app interface. See Integration interface for more information.
+func (self prometheusClient) HealthCheck() error {
+	response, err := self.client.Get(self.host + "/-/ready")
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("Prometheus is not ready: %s", response.Status)
+	}
+	return nil
+}
+
+// ID implements integration app interface. See Integration interface for more information.
+func (self prometheusClient) ID() integrationapi.IntegrationID {
+	return integrationapi.PrometheusIntegrationID
+}
+
+// ServiceTraffic implements traffic metric client interface. See TrafficMetricClient for more information.
+func (self prometheusClient) ServiceTraffic(namespace, name string) (*metricapi.ServiceTraffic, error) {
+	for _, q := range queries {
+		requestRate, err := self.query(fmt.Sprintf(q.requestRate, namespace, name, window))
+		if err != nil {
+			return nil, err
+		}
+		if requestRate == nil {
+			continue
+		}
+
+		result := &metricapi.ServiceTraffic{Mesh: q.mesh, Window: window, RequestRate: *requestRate}
+		if *requestRate == 0 {
+			return result, nil
+		}
+
+		if result.ErrorRate, err = self.query(fmt.Sprintf(q.errorRate, namespace, name, window)); err != nil {
+			return nil, err
+		}
+		if result.ErrorRate == nil {
+			// Series of failed requests do not exist until the first request fails.
+			errorRate := 0.0
+			result.ErrorRate = &errorRate
+		}
+
+		if result.P99Latency, err = self.query(fmt.Sprintf(q.p99Latency, namespace, name, window)); err != nil {
+			return nil, err
+		}
+		return result, nil
+	}
+
+	return nil, nil
+}
+
+// query runs an instant query, that is expected to return a single sample. Nil is returned when the query
+// returns no samples or the value is not a number.
+func (self prometheusClient) query(query string) (*float64, error) {
+	response, err := self.client.Get(self.host + "/api/v1/query?" + url.Values{"query": {query}}.Encode())
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	body, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	result := queryResponse{}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("invalid response of Prometheus query: %s", err)
+	}
+	if result.Status != "success" {
+		return nil, fmt.Errorf("Prometheus query failed: %s", result.Error)
+	}
+
+	if result.Data.ResultType != "vector" || len(result.Data.Result) == 0 ||
+		len(result.Data.Result[0].Value) != 2 {
+		return nil, nil
+	}
+
+	raw, ok := result.Data.Result[0].Value[1].(string)
+	if !ok {
+		return nil, nil
+	}
+
+	value, err := strconv.ParseFloat(raw, 64)
+	if err != nil || math.IsNaN(value) || math.IsInf(value, 0) {
+		return nil, nil
+	}
+	return &value, nil
+}
+
+// CreatePrometheusClient creates a client of the Prometheus server at the given address, that collects service
+// mesh metrics.
+func CreatePrometheusClient(host string) (metricapi.TrafficMetricClient, error) {
+	client, err := newPrometheusClient(host)
+	if err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+// CreatePrometheusMetricClient creates a client of the Prometheus server at the given address, that provides CPU
+// and memory usage of pods and nodes collected from cAdvisor of kubelets.
+func CreatePrometheusMetricClient(host string) (metricapi.MetricClient, error) {
+	client, err := newPrometheusClient(host)
+	if err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+func newPrometheusClient(host string) (*prometheusClient, error) {
+	if len(host) == 0 {
+		return nil, errors.New("Prometheus host is not set")
+	}
+
+	parsed, err := url.Parse(host)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || len(parsed.Host) == 0 {
+		return nil, fmt.Errorf("invalid Prometheus host %q, expected protocol://address:port", host)
+	}
+
+	log.Printf("Creating Prometheus client for %s", host)
+	return &prometheusClient{
+		host: strings.TrimSuffix(host, "/"),
+		client: &http.Client{
+			Timeout:   requestTimeout,
+			Transport: tracing.WrapTransport(context.Background())(http.DefaultTransport),
+		},
+	}, nil
+}
@@ -0,0 +1,128 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheus
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// newPrometheus returns a server, that responds to queries containing the first matching key with its value. Empty
+// value or no matching key results in an empty vector.
+func newPrometheus(values [][2]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/-/ready" {
+			return
+		}
+
+		query := r.URL.Query().Get("query")
+		for _, v := range values {
+			if key, value := v[0], v[1]; strings.Contains(query, key) {
+				if len(value) > 0 {
+					fmt.Fprintf(w, `{"status":"success","data":{"resultType":"vector","result":[{"metric":{},`+
+						`"value":[1580000000,"%s"]}]}}`, value)
+					return
+				}
+				break
+			}
+		}
+		fmt.Fprint(w, `{"status":"success","data":{"resultType":"vector","result":[]}}`)
+	}))
+}
+
+func TestServiceTraffic(t *testing.T) {
+	cases := []struct {
+		name                  string
+		values                [][2]string
+		mesh                  string
+		requestRate           float64
+		errorRate, p99Latency string
+	}{
+		{
+			"istio",
+			[][2]string{
+				{`response_code=~"5.."`, "0.05"},
+				{"istio_request_duration_milliseconds", "250"},
+				{`destination_service_name="reviews"`, "12.5"},
+			},
+			"istio", 12.5, "0.05", "250",
+		},
+		{
+			"linkerd without failed requests",
+			[][2]string{
+				{"response_latency_ms_bucket", "NaN"},
+				{`classification="failure"`, ""},
+				{`dst_service="reviews"`, "3"},
+			},
+			"linkerd", 3, "0", "<nil>",
+		},
+		{
+			"service outside of mesh",
+			nil,
+			"", 0, "", "",
+		},
+	}
+
+	for _, c := range cases {
+		server := newPrometheus(c.values)
+		client, err := CreatePrometheusClient(server.URL)
+		if err != nil {
+			t.Fatalf("%s: CreatePrometheusClient() returned error: %s", c.name, err)
+		}
+
+		actual, err := client.ServiceTraffic("default", "reviews")
+		server.Close()
+		if err != nil {
+			t.Errorf("%s: ServiceTraffic() returned error: %s", c.name, err)
+			continue
+		}
+
+		if len(c.mesh) == 0 {
+			if actual != nil {
+				t.Errorf("%s: expected no traffic, got %#v", c.name, actual)
+			}
+			continue
+		}
+
+		if actual == nil || actual.Mesh != c.mesh || actual.RequestRate != c.requestRate ||
+			format(actual.ErrorRate) != c.errorRate || format(actual.P99Latency) != c.p99Latency {
+			t.Errorf("%s: unexpected traffic %#v", c.name, actual)
+		}
+	}
+}
+
+func TestHealthCheck(t *testing.T) {
+	server := newPrometheus(nil)
+	defer server.Close()
+
+	client, _ := CreatePrometheusClient(server.URL + "/")
+	if err := client.HealthCheck(); err != nil {
+		t.Errorf("HealthCheck() returned error: %s", err)
+	}
+
+	if _, err := CreatePrometheusClient("prometheus:9090"); err == nil {
+		t.Error("Expected error when host is not an URL")
+	}
+}
+
+func format(value *float64) string {
+	if value == nil {
+		return "<nil>"
+	}
+	return fmt.Sprint(*value)
+}
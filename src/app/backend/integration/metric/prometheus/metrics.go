@@ -0,0 +1,270 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheus
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"math"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	integrationapi "github.com/kubernetes/dashboard/src/app/backend/integration/api"
+	metricapi "github.com/kubernetes/dashboard/src/app/backend/integration/metric/api"
+	"github.com/kubernetes/dashboard/src/app/backend/integration/metric/common"
+)
+
+const (
+	// metricRange and metricStep define time range and resolution of metrics shown on graphs and sparklines. They
+	// are the same as the ones of metrics provided by Sidecar.
+	metricRange = 15 * time.Minute
+	metricStep  = time.Minute
+)
+
+// resourceQueries contains PromQL queries of container metrics exported by cAdvisor of kubelets. Placeholders are
+// replaced with the namespace, regular expression matching names of the resources and the rate window. Results
+// are grouped by the pod or node label. CPU usage is in millicores and memory usage in bytes. Node metrics are
+// read from the root cgroup and require the node label, that is added by Prometheus Operator and kube-prometheus.
+var resourceQueries = map[string]map[api.ResourceKind]string{
+	metricapi.CpuUsage: {
+		api.ResourceKindPod: `sum by (pod) (rate(container_cpu_usage_seconds_total{container!="",container!="POD",` +
+			`namespace="%[1]s",pod=~"%[2]s"}[%[3]s])) * 1000`,
+		api.ResourceKindNode: `sum by (node) (rate(container_cpu_usage_seconds_total{id="/",node=~"%[2]s"}` +
+			`[%[3]s])) * 1000`,
+	},
+	metricapi.MemoryUsage: {
+		api.ResourceKindPod: `sum by (pod) (container_memory_working_set_bytes{container!="",container!="POD",` +
+			`namespace="%[1]s",pod=~"%[2]s"})`,
+		api.ResourceKindNode: `sum by (node) (container_memory_working_set_bytes{id="/",node=~"%[2]s"})`,
+	},
+}
+
+// resourceLabels are labels of query results identifying the resource.
+var resourceLabels = map[api.ResourceKind]string{
+	api.ResourceKindPod:  "pod",
+	api.ResourceKindNode: "node",
+}
+
+// rangeQueryResponse is a response of the Prometheus range query API.
+type rangeQueryResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+	Data   struct {
+		ResultType string `json:"resultType"`
+		Result     []struct {
+			Metric map[string]string `json:"metric"`
+			// Values are pairs of a timestamp and a string value.
+			Values [][]interface{} `json:"values"`
+		} `json:"result"`
+	} `json:"data"`
+}
+
+// DownloadMetrics implements metric client interface. See MetricClient for more information.
+func (self prometheusClient) DownloadMetrics(selectors []metricapi.ResourceSelector,
+	metricNames []string, cachedResources *metricapi.CachedResources) metricapi.MetricPromises {
+	result := metricapi.MetricPromises{}
+	for _, metricName := range metricNames {
+		collectedMetrics := self.DownloadMetric(selectors, metricName, cachedResources)
+		result = append(result, collectedMetrics...)
+	}
+	return result
+}
+
+// DownloadMetric implements metric client interface. See MetricClient for more information. Metrics of all
+// selected resources of a kind in a namespace are downloaded with a single query.
+func (self prometheusClient) DownloadMetric(selectors []metricapi.ResourceSelector,
+	metricName string, cachedResources *metricapi.CachedResources) metricapi.MetricPromises {
+	result := metricapi.NewMetricPromises(len(selectors))
+	go func() {
+		if _, supported := resourceQueries[metricName]; !supported {
+			result.PutMetrics(nil, fmt.Errorf(`Metric "%s" is not supported by Prometheus client`, metricName))
+			return
+		}
+
+		targets := make([]common.Target, len(selectors))
+		targetErrors := make([]error, len(selectors))
+		names := map[string][]string{}
+		for i, selector := range selectors {
+			targets[i], targetErrors[i] = common.GetTarget(selector, cachedResources)
+			if targetErrors[i] == nil {
+				key := targetKey(targets[i])
+				names[key] = append(names[key], targets[i].Names...)
+			}
+		}
+
+		end := time.Now().Truncate(metricStep)
+		downloaded := map[string]map[string][]metricapi.MetricPoint{}
+		downloadErrors := map[string]error{}
+		for i, target := range targets {
+			if targetErrors[i] != nil {
+				result[i].Metric <- nil
+				result[i].Error <- targetErrors[i]
+				continue
+			}
+
+			key := targetKey(target)
+			if _, exists := downloaded[key]; !exists && downloadErrors[key] == nil {
+				downloaded[key], downloadErrors[key] = self.downloadSeries(metricName, target.Kind,
+					target.Namespace, names[key], end)
+				if downloadErrors[key] != nil {
+					log.Printf("Cannot download %s metrics from Prometheus: %s", target.Kind, downloadErrors[key])
+				}
+			}
+			if downloadErrors[key] != nil {
+				result[i].Metric <- nil
+				result[i].Error <- downloadErrors[key]
+				continue
+			}
+
+			metric := toMetric(target, downloaded[key], metricName)
+			result[i].Metric <- &metric
+			result[i].Error <- nil
+		}
+	}()
+	return result
+}
+
+// AggregateMetrics implements metric client interface. See MetricClient for more information.
+func (self prometheusClient) AggregateMetrics(metrics metricapi.MetricPromises, metricName string,
+	aggregations metricapi.AggregationModes) metricapi.MetricPromises {
+	return common.AggregateMetricPromises(metrics, metricName, aggregations, nil)
+}
+
+// downloadSeries returns metric points of the resources of given kind mapped by their names.
+func (self prometheusClient) downloadSeries(metricName string, kind api.ResourceKind, namespace string,
+	names []string, end time.Time) (map[string][]metricapi.MetricPoint, error) {
+	quoted := make([]string, 0, len(names))
+	unique := map[string]bool{}
+	for _, name := range names {
+		if !unique[name] {
+			unique[name] = true
+			quoted = append(quoted, quoteRegexp(name))
+		}
+	}
+
+	query := fmt.Sprintf(resourceQueries[metricName][kind], namespace, strings.Join(quoted, "|"), window)
+	start := time.Now()
+	series, err := self.queryRange(query, resourceLabels[kind], end.Add(-metricRange), end)
+	common.TrackRequest(integrationapi.PrometheusIntegrationID, string(kind), start, err)
+	common.LogSlowRequest(integrationapi.PrometheusIntegrationID, string(kind), query, len(quoted), start)
+	return series, err
+}
+
+// quoteRegexp returns regular expression matching exactly the given name, that can be placed inside PromQL double
+// quoted string. Backslashes of escaped characters, e.g. dots of "ip-10-0-0-1.ec2.internal", are doubled, as
+// PromQL strings process escape sequences before the regular expression is compiled.
+func quoteRegexp(name string) string {
+	return strings.Replace(regexp.QuoteMeta(name), `\`, `\\`, -1)
+}
+
+// queryRange runs a range query and returns metric points of the result series mapped by the value of given
+// label. Query is sent in the request body, as lists of resources can exceed the maximum URL length.
+func (self prometheusClient) queryRange(query, label string, start, end time.Time) (
+	map[string][]metricapi.MetricPoint, error) {
+	response, err := self.client.PostForm(self.host+"/api/v1/query_range", url.Values{
+		"query": {query},
+		"start": {strconv.FormatInt(start.Unix(), 10)},
+		"end":   {strconv.FormatInt(end.Unix(), 10)},
+		"step":  {strconv.FormatInt(int64(metricStep/time.Second), 10)},
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	body, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	rangeResult := rangeQueryResponse{}
+	if err := json.Unmarshal(body, &rangeResult); err != nil {
+		return nil, fmt.Errorf("invalid response of Prometheus query: %s", err)
+	}
+	if rangeResult.Status != "success" {
+		return nil, fmt.Errorf("Prometheus query failed: %s", rangeResult.Error)
+	}
+
+	result := map[string][]metricapi.MetricPoint{}
+	if rangeResult.Data.ResultType != "matrix" {
+		return result, nil
+	}
+
+	for _, series := range rangeResult.Data.Result {
+		points := make([]metricapi.MetricPoint, 0, len(series.Values))
+		for _, sample := range series.Values {
+			if point, ok := toMetricPoint(sample); ok {
+				points = append(points, point)
+			}
+		}
+		result[series.Metric[label]] = points
+	}
+	return result, nil
+}
+
+// toMetricPoint converts a pair of a timestamp and a string value. Negative and not a number values are skipped.
+func toMetricPoint(sample []interface{}) (metricapi.MetricPoint, bool) {
+	if len(sample) != 2 {
+		return metricapi.MetricPoint{}, false
+	}
+
+	timestamp, ok := sample[0].(float64)
+	raw, isString := sample[1].(string)
+	if !ok || !isString {
+		return metricapi.MetricPoint{}, false
+	}
+
+	value, err := strconv.ParseFloat(raw, 64)
+	if err != nil || math.IsNaN(value) || math.IsInf(value, 0) || value < 0 {
+		return metricapi.MetricPoint{}, false
+	}
+	return metricapi.MetricPoint{Timestamp: time.Unix(int64(timestamp), 0), Value: uint64(value)}, true
+}
+
+// toMetric sums series of target resources. Resources without series, i.e. pods that just started, are skipped.
+func toMetric(target common.Target, series map[string][]metricapi.MetricPoint, metricName string) metricapi.Metric {
+	metrics := make([]metricapi.Metric, 0)
+	for i, name := range target.Names {
+		points, exists := series[name]
+		if !exists {
+			continue
+		}
+
+		dataPoints := make(metricapi.DataPoints, 0, len(points))
+		for _, point := range points {
+			dataPoints = append(dataPoints, metricapi.DataPoint{X: point.Timestamp.Unix(), Y: int64(point.Value)})
+		}
+		metrics = append(metrics, metricapi.Metric{
+			DataPoints:   dataPoints,
+			MetricPoints: points,
+			MetricName:   metricName,
+			Label:        metricapi.Label{target.Kind: []types.UID{target.UIDs[i]}},
+		})
+	}
+
+	return common.AggregateData(metrics, metricName, metricapi.SumAggregation)
+}
+
+func targetKey(target common.Target) string {
+	return string(target.Kind) + "/" + target.Namespace
+}
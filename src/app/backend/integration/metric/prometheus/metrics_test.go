@@ -0,0 +1,139 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheus
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	metricapi "github.com/kubernetes/dashboard/src/app/backend/integration/metric/api"
+)
+
+// newRangePrometheus returns a server, that responds to range queries with a series of every pod or node, whose
+// name is in the query. Values of a series are 1000 multiples of its position.
+func newRangePrometheus(series map[string][]string, queries *[]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/query_range" || r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		query := r.PostFormValue("query")
+		*queries = append(*queries, query)
+		label := "pod"
+		if strings.Contains(query, "by (node)") {
+			label = "node"
+		}
+
+		result := make([]string, 0)
+		for name, values := range series {
+			if strings.Contains(query, name) {
+				samples := make([]string, 0)
+				for i, value := range values {
+					samples = append(samples, fmt.Sprintf(`[%d,"%s"]`, 1580000000+60*i, value))
+				}
+				result = append(result, fmt.Sprintf(`{"metric":{"%s":"%s"},"values":[%s]}`, label, name,
+					strings.Join(samples, ",")))
+			}
+		}
+		fmt.Fprintf(w, `{"status":"success","data":{"resultType":"matrix","result":[%s]}}`,
+			strings.Join(result, ","))
+	}))
+}
+
+func TestDownloadMetric(t *testing.T) {
+	queries := make([]string, 0)
+	server := newRangePrometheus(map[string][]string{
+		"web-1":  {"100", "150"},
+		"web-2":  {"50", "NaN"},
+		"node-1": {"2000"},
+	}, &queries)
+	defer server.Close()
+
+	client, err := CreatePrometheusMetricClient(server.URL)
+	if err != nil {
+		t.Fatalf("CreatePrometheusMetricClient() returned error: %s", err)
+	}
+
+	controller := true
+	pods := []v1.Pod{
+		{ObjectMeta: metaV1.ObjectMeta{Name: "web-1", Namespace: "default", UID: "P1",
+			OwnerReferences: []metaV1.OwnerReference{{UID: "RS1", Controller: &controller}}}},
+		{ObjectMeta: metaV1.ObjectMeta{Name: "web-2", Namespace: "default", UID: "P2",
+			OwnerReferences: []metaV1.OwnerReference{{UID: "RS1", Controller: &controller}}}},
+	}
+	selectors := []metricapi.ResourceSelector{
+		{Namespace: "default", ResourceType: api.ResourceKindReplicaSet, ResourceName: "web", UID: "RS1"},
+		{Namespace: "default", ResourceType: api.ResourceKindPod, ResourceName: "web-1", UID: "P1"},
+		{ResourceType: api.ResourceKindNode, ResourceName: "node-1", UID: "N1"},
+	}
+	expected := []metricapi.DataPoints{
+		{{X: 1580000000, Y: 150}, {X: 1580000060, Y: 150}},
+		{{X: 1580000000, Y: 100}, {X: 1580000060, Y: 150}},
+		{{X: 1580000000, Y: 2000}},
+	}
+
+	promises := client.DownloadMetric(selectors, metricapi.CpuUsage, &metricapi.CachedResources{Pods: pods})
+	for i, promise := range promises {
+		metric, err := promise.GetMetric()
+		if err != nil || !reflect.DeepEqual(metric.DataPoints, expected[i]) {
+			t.Errorf("Expected data points %v of %s, got %v, %v", expected[i], selectors[i].ResourceName, metric, err)
+		}
+	}
+
+	if len(queries) != 2 {
+		t.Fatalf("Expected single query for pods and nodes, got %v", queries)
+	}
+	if !strings.Contains(queries[0], `namespace="default",pod=~"web-1|web-2"`) ||
+		!strings.Contains(queries[1], `node=~"node-1"`) {
+		t.Errorf("Unexpected queries %v", queries)
+	}
+}
+
+func TestDownloadMetricDottedNodeName(t *testing.T) {
+	queries := make([]string, 0)
+	server := newRangePrometheus(map[string][]string{}, &queries)
+	defer server.Close()
+
+	client, _ := CreatePrometheusMetricClient(server.URL)
+	selector := metricapi.ResourceSelector{ResourceType: api.ResourceKindNode,
+		ResourceName: "ip-10-0-0-1.ec2.internal", UID: "N1"}
+
+	promises := client.DownloadMetric([]metricapi.ResourceSelector{selector}, metricapi.MemoryUsage,
+		metricapi.NoResourceCache)
+	promises[0].GetMetric()
+	if len(queries) != 1 || !strings.Contains(queries[0], `node=~"ip-10-0-0-1\\.ec2\\.internal"`) {
+		t.Errorf("Expected node name escaped for PromQL string, got %v", queries)
+	}
+}
+
+func TestDownloadMetricUnsupported(t *testing.T) {
+	client, _ := CreatePrometheusMetricClient("http://prometheus:9090")
+	selector := metricapi.ResourceSelector{Namespace: "default", ResourceType: api.ResourceKindPod,
+		ResourceName: "web-1", UID: "P1"}
+
+	promises := client.DownloadMetric([]metricapi.ResourceSelector{selector}, "cpu/limit", metricapi.NoResourceCache)
+	if _, err := promises[0].GetMetric(); err == nil {
+		t.Error("Expected error for metric not collected from cAdvisor")
+	}
+}
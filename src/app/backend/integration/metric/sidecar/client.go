@@ -15,16 +15,20 @@
 package sidecar
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"log"
 	"strings"
+	"time"
 
+	"github.com/kubernetes/dashboard/src/app/backend/api"
 	"github.com/kubernetes/dashboard/src/app/backend/client"
 	integrationapi "github.com/kubernetes/dashboard/src/app/backend/integration/api"
 	metricapi "github.com/kubernetes/dashboard/src/app/backend/integration/metric/api"
 	"github.com/kubernetes/dashboard/src/app/backend/integration/metric/common"
+	"github.com/kubernetes/dashboard/src/app/backend/tracing"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
@@ -153,7 +157,7 @@ func (self sidecarClient) ithResourceDownload(selector sidecarSelector, metricNa
 	result := metricapi.NewMetricPromise()
 	go func() {
 		rawResult := metricapi.SidecarMetricResultList{}
-		err := self.unmarshalType(selector.Path+selector.Resources[i]+"/metrics/"+metricName, &rawResult)
+		err := self.unmarshalType(selector.TargetResourceType, 1, selector.Path+selector.Resources[i]+"/metrics/"+metricName, &rawResult)
 		if err != nil {
 			result.Metric <- nil
 			result.Error <- err
@@ -194,7 +198,7 @@ func (self sidecarClient) allInOneDownload(selector sidecarSelector, metricName
 		}
 		rawResults := metricapi.SidecarMetricResultList{}
 
-		err := self.unmarshalType(selector.Path+strings.Join(selector.Resources, ",")+"/metrics/"+metricName, &rawResults)
+		err := self.unmarshalType(selector.TargetResourceType, len(selector.Resources), selector.Path+strings.Join(selector.Resources, ",")+"/metrics/"+metricName, &rawResults)
 
 		if err != nil {
 			result.PutMetrics(nil, err)
@@ -247,9 +251,13 @@ func (self sidecarClient) allInOneDownload(selector sidecarSelector, metricName
 }
 
 // unmarshalType performs sidecar GET request to the specifies path and transfers
-// the data to the interface provided.
-func (self sidecarClient) unmarshalType(path string, v interface{}) error {
+// the data to the interface provided. Request is tracked for given resource kind and number of
+// requested items.
+func (self sidecarClient) unmarshalType(resource api.ResourceKind, items int, path string, v interface{}) error {
+	start := time.Now()
 	rawData, err := self.client.Get("/api/v1/dashboard/" + path).DoRaw()
+	common.TrackRequest(integrationapi.SidecarIntegrationID, string(resource), start, err)
+	common.LogSlowRequest(integrationapi.SidecarIntegrationID, string(resource), path, items, start)
 	if err != nil {
 		return err
 	}
@@ -269,7 +277,8 @@ func CreateSidecarClient(host string, k8sClient kubernetes.Interface) (
 		return sidecarClient{client: c}, nil
 	}
 
-	cfg := &rest.Config{Host: host, QPS: client.DefaultQPS, Burst: client.DefaultBurst}
+	cfg := &rest.Config{Host: host, QPS: client.DefaultQPS, Burst: client.DefaultBurst,
+		WrapTransport: tracing.WrapTransport(context.Background())}
 	restClient, err := kubernetes.NewForConfig(cfg)
 	if err != nil {
 		return sidecarClient{}, err
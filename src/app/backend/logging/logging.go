@@ -0,0 +1,150 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package logging configures format of Dashboard logs. Plain text output of standard log package is used by
+// default. JSON format wraps every line into an entry with time, level and message and allows to write
+// structured entries with additional fields, so logs can be consumed by centralized log pipelines.
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Format is a format of log output.
+type Format string
+
+const (
+	// TextFormat writes plain text lines of standard log package.
+	TextFormat Format = "text"
+	// JSONFormat writes one JSON object per line.
+	JSONFormat Format = "json"
+)
+
+// Level is a severity of log entry.
+type Level string
+
+const (
+	// LevelInfo is used for regular entries.
+	LevelInfo Level = "info"
+	// LevelWarning is used for entries describing client errors.
+	LevelWarning Level = "warning"
+	// LevelError is used for entries describing server errors.
+	LevelError Level = "error"
+)
+
+// Fields are additional key-value pairs of structured log entry.
+type Fields map[string]interface{}
+
+var (
+	mux    sync.Mutex
+	format = TextFormat
+	output io.Writer
+)
+
+// Init configures standard log package to write logs to given output in given format. Unknown format
+// results in an error.
+func Init(f string, out io.Writer) error {
+	mux.Lock()
+	defer mux.Unlock()
+
+	switch Format(strings.ToLower(f)) {
+	case "", TextFormat:
+		format, output = TextFormat, out
+		log.SetFlags(log.LstdFlags)
+		log.SetOutput(out)
+	case JSONFormat:
+		format, output = JSONFormat, out
+		log.SetFlags(0)
+		log.SetOutput(&jsonWriter{})
+	default:
+		return fmt.Errorf("unknown log format %q, should be one of 'text|json'", f)
+	}
+
+	return nil
+}
+
+// IsStructured returns true when logs are written as JSON entries.
+func IsStructured() bool {
+	mux.Lock()
+	defer mux.Unlock()
+	return format == JSONFormat
+}
+
+// Log writes entry with given level, message and fields. In text format fields are appended to the message
+// as key=value pairs.
+func Log(level Level, msg string, fields Fields) {
+	if !IsStructured() {
+		log.Printf("%s%s", msg, formatFields(fields))
+		return
+	}
+
+	mux.Lock()
+	defer mux.Unlock()
+	writeEntry(level, msg, fields)
+}
+
+// writeEntry encodes single entry and writes it to the output. Caller has to hold the lock.
+func writeEntry(level Level, msg string, fields Fields) {
+	entry := make(map[string]interface{}, len(fields)+3)
+	for key, value := range fields {
+		entry[key] = value
+	}
+	entry["time"] = time.Now().Format(time.RFC3339)
+	entry["level"] = level
+	entry["msg"] = msg
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		line, _ = json.Marshal(map[string]interface{}{"time": entry["time"], "level": LevelError,
+			"msg": fmt.Sprintf("Could not encode log entry %q: %s", msg, err)})
+	}
+	output.Write(append(line, '\n'))
+}
+
+func formatFields(fields Fields) string {
+	if len(fields) == 0 {
+		return ""
+	}
+
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	buffer := &bytes.Buffer{}
+	for _, key := range keys {
+		fmt.Fprintf(buffer, " %s=%v", key, fields[key])
+	}
+	return buffer.String()
+}
+
+// jsonWriter wraps lines written by standard log package into JSON entries.
+type jsonWriter struct{}
+
+// Write implements io.Writer. Standard log package calls it once per line.
+func (self *jsonWriter) Write(p []byte) (int, error) {
+	mux.Lock()
+	defer mux.Unlock()
+	writeEntry(LevelInfo, strings.TrimSuffix(string(p), "\n"), nil)
+	return len(p), nil
+}
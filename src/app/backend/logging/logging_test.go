@@ -0,0 +1,112 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestInit(t *testing.T) {
+	defer Init(string(TextFormat), os.Stdout)
+
+	cases := []struct {
+		format      string
+		structured  bool
+		expectedErr bool
+	}{
+		{"", false, false},
+		{"text", false, false},
+		{"JSON", true, false},
+		{"xml", false, true},
+	}
+
+	for _, c := range cases {
+		Init(string(TextFormat), os.Stdout)
+		err := Init(c.format, &bytes.Buffer{})
+		if (err != nil) != c.expectedErr {
+			t.Errorf("Init(%s) returned error %v, expected error: %t", c.format, err, c.expectedErr)
+		}
+
+		if IsStructured() != c.structured {
+			t.Errorf("Init(%s) resulted in structured logging %t, expected %t", c.format, IsStructured(),
+				c.structured)
+		}
+	}
+}
+
+func TestLog(t *testing.T) {
+	defer Init(string(TextFormat), os.Stdout)
+
+	buffer := &bytes.Buffer{}
+	if err := Init(string(JSONFormat), buffer); err != nil {
+		t.Fatal(err)
+	}
+
+	log.Printf("plain %s", "message")
+	Log(LevelWarning, "GET /api/v1/pod", Fields{"status": 403, "user": "admin"})
+
+	lines := strings.Split(strings.TrimSpace(buffer.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 log lines, but got %#v", lines)
+	}
+
+	cases := []struct {
+		line     string
+		expected map[string]interface{}
+	}{
+		{lines[0], map[string]interface{}{"level": "info", "msg": "plain message"}},
+		{lines[1], map[string]interface{}{"level": "warning", "msg": "GET /api/v1/pod", "status": float64(403),
+			"user": "admin"}},
+	}
+
+	for _, c := range cases {
+		entry := make(map[string]interface{})
+		if err := json.Unmarshal([]byte(c.line), &entry); err != nil {
+			t.Errorf("Log line %s is not valid JSON: %s", c.line, err)
+			continue
+		}
+
+		if _, ok := entry["time"]; !ok {
+			t.Errorf("Log entry %#v is missing time", entry)
+		}
+
+		for key, value := range c.expected {
+			if entry[key] != value {
+				t.Errorf("Expected %s of log entry %#v to be %#v", key, entry, value)
+			}
+		}
+	}
+}
+
+func TestFormatFields(t *testing.T) {
+	cases := []struct {
+		fields   Fields
+		expected string
+	}{
+		{nil, ""},
+		{Fields{"user": "admin", "status": 200}, " status=200 user=admin"},
+	}
+
+	for _, c := range cases {
+		if actual := formatFields(c.fields); actual != c.expected {
+			t.Errorf("formatFields(%#v) == %q, expected %q", c.fields, actual, c.expected)
+		}
+	}
+}
@@ -114,6 +114,10 @@ func (cm *fakeClientManager) ClientCmdConfig(req *restful.Request) (clientcmd.Cl
 	panic("implement me")
 }
 
+func (cm *fakeClientManager) ConfigMode() clientapi.ConfigMode {
+	return clientapi.InClusterConfigMode
+}
+
 func (cm *fakeClientManager) CSRFKey() string {
 	panic("implement me")
 }
@@ -129,3 +133,11 @@ func (cm *fakeClientManager) VerberClient(req *restful.Request, config *rest.Con
 func (cm *fakeClientManager) SetTokenManager(manager authApi.TokenManager) {
 	panic("implement me")
 }
+
+func (cm *fakeClientManager) Username(req *restful.Request) (string, error) {
+	panic("implement me")
+}
+
+func (cm *fakeClientManager) KnownUsername(req *restful.Request) string {
+	panic("implement me")
+}
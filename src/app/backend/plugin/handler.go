@@ -66,7 +66,7 @@ func NewPluginHandler(cManager clientapi.ClientManager) *Handler {
 func (h *Handler) handlePluginList(request *restful.Request, response *restful.Response) {
 	pluginClient, err := h.cManager.PluginClient(request)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 	namespace := request.PathParameter("namespace")
@@ -74,7 +74,7 @@ func (h *Handler) handlePluginList(request *restful.Request, response *restful.R
 
 	result, err := GetPluginList(pluginClient, namespace, dataSelect)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 	response.WriteHeaderAndEntity(http.StatusOK, result)
@@ -92,7 +92,7 @@ func (h *Handler) servePluginSource(request *restful.Request, response *restful.
 
 	result, err := GetPluginSource(pluginClient, k8sClient, namespace, name)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 	response.AddHeader(contentTypeHeader, jsContentType)
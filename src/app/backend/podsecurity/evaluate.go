@@ -0,0 +1,294 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package podsecurity evaluates pod specs against the Pod Security Standards profiles. See
+// https://kubernetes.io/docs/concepts/security/pod-security-standards/ for description of the profiles.
+package podsecurity
+
+import (
+	"fmt"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// Level is a Pod Security Standards profile.
+type Level string
+
+// List of profiles, from the least to the most restrictive.
+const (
+	// LevelPrivileged is unrestricted. Every pod satisfies it.
+	LevelPrivileged Level = "privileged"
+
+	// LevelBaseline prevents known privilege escalations.
+	LevelBaseline Level = "baseline"
+
+	// LevelRestricted follows pod hardening best practices.
+	LevelRestricted Level = "restricted"
+)
+
+const (
+	appArmorAnnotationPrefix         = "container.apparmor.security.beta.kubernetes.io/"
+	seccompPodAnnotation             = "seccomp.security.alpha.kubernetes.io/pod"
+	seccompContainerAnnotationPrefix = "container.seccomp.security.alpha.kubernetes.io/"
+)
+
+// Capabilities that container runtimes grant by default. Baseline profile allows adding only these.
+var baselineCapabilities = map[v1.Capability]bool{
+	"AUDIT_WRITE": true, "CHOWN": true, "DAC_OVERRIDE": true, "FOWNER": true, "FSETID": true, "KILL": true,
+	"MKNOD": true, "NET_BIND_SERVICE": true, "SETFCAP": true, "SETGID": true, "SETPCAP": true, "SETUID": true,
+	"SYS_CHROOT": true,
+}
+
+// Sysctls that are namespaced and cannot affect other pods on the node.
+var safeSysctls = map[string]bool{
+	"kernel.shm_rmid_forced":              true,
+	"net.ipv4.ip_local_port_range":        true,
+	"net.ipv4.ip_unprivileged_port_start": true,
+	"net.ipv4.tcp_syncookies":             true,
+	"net.ipv4.ping_group_range":           true,
+}
+
+// Violation is a single field of the pod spec that does not satisfy the profile.
+type Violation struct {
+	// Least restrictive profile that is violated.
+	Level Level `json:"level"`
+
+	// Name of the check, i.e. "Host namespaces".
+	Check string `json:"check"`
+
+	// Path of the violating field, i.e. spec.containers[0].securityContext.privileged.
+	Field string `json:"field"`
+
+	// Human readable description of the violation.
+	Message string `json:"message"`
+}
+
+// Evaluation is the result of evaluation of a pod spec.
+type Evaluation struct {
+	// Most restrictive profile that the pod satisfies.
+	Level Level `json:"level"`
+
+	// List of violations of the baseline and restricted profiles.
+	Violations []Violation `json:"violations"`
+}
+
+type evaluator struct {
+	annotations map[string]string
+	spec        *v1.PodSpec
+	path        *field.Path
+	violations  []Violation
+}
+
+// Evaluate checks the pod spec against baseline and restricted profiles. Path is the path of the spec within
+// the evaluated object, i.e. spec.template.spec for deployments. Annotations are the annotations of the pod,
+// that configure AppArmor and seccomp profiles.
+func Evaluate(annotations map[string]string, spec *v1.PodSpec, path *field.Path) Evaluation {
+	e := &evaluator{annotations: annotations, spec: spec, path: path, violations: make([]Violation, 0)}
+	e.checkPod()
+	e.forEachContainer(e.checkContainer)
+
+	level := LevelRestricted
+	for _, violation := range e.violations {
+		if violation.Level == LevelBaseline {
+			level = LevelPrivileged
+			break
+		}
+		level = LevelBaseline
+	}
+
+	return Evaluation{Level: level, Violations: e.violations}
+}
+
+func (e *evaluator) violate(level Level, check string, path *field.Path, format string, args ...interface{}) {
+	e.violations = append(e.violations, Violation{
+		Level:   level,
+		Check:   check,
+		Field:   path.String(),
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+func (e *evaluator) forEachContainer(check func(container *v1.Container, path *field.Path)) {
+	for i := range e.spec.InitContainers {
+		check(&e.spec.InitContainers[i], e.path.Child("initContainers").Index(i))
+	}
+	for i := range e.spec.Containers {
+		check(&e.spec.Containers[i], e.path.Child("containers").Index(i))
+	}
+}
+
+func (e *evaluator) checkPod() {
+	if e.spec.HostNetwork {
+		e.violate(LevelBaseline, "Host namespaces", e.path.Child("hostNetwork"), "host network must not be used")
+	}
+	if e.spec.HostPID {
+		e.violate(LevelBaseline, "Host namespaces", e.path.Child("hostPID"), "host PID namespace must not be used")
+	}
+	if e.spec.HostIPC {
+		e.violate(LevelBaseline, "Host namespaces", e.path.Child("hostIPC"), "host IPC namespace must not be used")
+	}
+
+	for i, volume := range e.spec.Volumes {
+		path := e.path.Child("volumes").Index(i)
+		if volume.HostPath != nil {
+			e.violate(LevelBaseline, "HostPath volumes", path.Child("hostPath"),
+				"volume %q mounts host path %s", volume.Name, volume.HostPath.Path)
+		} else if !isRestrictedVolume(volume.VolumeSource) {
+			e.violate(LevelRestricted, "Volume types", path,
+				"volume %q is not of an allowed type (configMap, csi, downwardAPI, emptyDir, persistentVolumeClaim, "+
+					"projected, secret)", volume.Name)
+		}
+	}
+
+	securityContext := e.spec.SecurityContext
+	if securityContext == nil {
+		securityContext = &v1.PodSecurityContext{}
+	}
+	path := e.path.Child("securityContext")
+	e.checkSELinux(securityContext.SELinuxOptions, path.Child("seLinuxOptions"))
+	for i, sysctl := range securityContext.Sysctls {
+		if !safeSysctls[sysctl.Name] {
+			e.violate(LevelBaseline, "Sysctls", path.Child("sysctls").Index(i).Child("name"),
+				"sysctl %s is not allowed", sysctl.Name)
+		}
+	}
+	if securityContext.RunAsUser != nil && *securityContext.RunAsUser == 0 {
+		e.violate(LevelRestricted, "Running as non-root", path.Child("runAsUser"), "pod must not run as root (UID 0)")
+	}
+
+	if profile, ok := e.annotations[seccompPodAnnotation]; ok && !isAllowedSeccompProfile(profile) {
+		e.violate(LevelBaseline, "Seccomp", field.NewPath("metadata", "annotations").Key(seccompPodAnnotation),
+			"seccomp profile %s is not allowed", profile)
+	}
+}
+
+func (e *evaluator) checkContainer(container *v1.Container, path *field.Path) {
+	for i, port := range container.Ports {
+		if port.HostPort != 0 {
+			e.violate(LevelBaseline, "Host ports", path.Child("ports").Index(i).Child("hostPort"),
+				"container %q uses host port %d", container.Name, port.HostPort)
+		}
+	}
+
+	annotations := field.NewPath("metadata", "annotations")
+	if profile, ok := e.annotations[appArmorAnnotationPrefix+container.Name]; ok && profile != "runtime/default" &&
+		!strings.HasPrefix(profile, "localhost/") {
+		e.violate(LevelBaseline, "AppArmor", annotations.Key(appArmorAnnotationPrefix+container.Name),
+			"container %q uses AppArmor profile %s", container.Name, profile)
+	}
+	seccompKey := seccompContainerAnnotationPrefix + container.Name
+	if profile, ok := e.annotations[seccompKey]; ok && !isAllowedSeccompProfile(profile) {
+		e.violate(LevelBaseline, "Seccomp", annotations.Key(seccompKey), "container %q uses seccomp profile %s",
+			container.Name, profile)
+	} else if !e.hasSeccompProfile(container.Name) {
+		e.violate(LevelRestricted, "Seccomp", annotations.Key(seccompKey),
+			"container %q must use runtime/default or a localhost seccomp profile", container.Name)
+	}
+
+	securityContext := container.SecurityContext
+	if securityContext == nil {
+		securityContext = &v1.SecurityContext{}
+	}
+	path = path.Child("securityContext")
+
+	if securityContext.Privileged != nil && *securityContext.Privileged {
+		e.violate(LevelBaseline, "Privileged containers", path.Child("privileged"),
+			"container %q must not be privileged", container.Name)
+	}
+	if securityContext.ProcMount != nil && *securityContext.ProcMount != v1.DefaultProcMount {
+		e.violate(LevelBaseline, "/proc mount type", path.Child("procMount"),
+			"container %q must use the default /proc mount", container.Name)
+	}
+	e.checkSELinux(securityContext.SELinuxOptions, path.Child("seLinuxOptions"))
+	e.checkCapabilities(container, securityContext.Capabilities, path.Child("capabilities"))
+
+	if securityContext.AllowPrivilegeEscalation == nil || *securityContext.AllowPrivilegeEscalation {
+		e.violate(LevelRestricted, "Privilege escalation", path.Child("allowPrivilegeEscalation"),
+			"container %q must set allowPrivilegeEscalation to false", container.Name)
+	}
+	if !e.runsAsNonRoot(securityContext) {
+		e.violate(LevelRestricted, "Running as non-root", path.Child("runAsNonRoot"),
+			"container %q must set runAsNonRoot to true in the pod or container security context", container.Name)
+	}
+	if securityContext.RunAsUser != nil && *securityContext.RunAsUser == 0 {
+		e.violate(LevelRestricted, "Running as non-root", path.Child("runAsUser"),
+			"container %q must not run as root (UID 0)", container.Name)
+	}
+}
+
+func (e *evaluator) checkSELinux(options *v1.SELinuxOptions, path *field.Path) {
+	if options == nil {
+		return
+	}
+
+	if len(options.User) > 0 || len(options.Role) > 0 {
+		e.violate(LevelBaseline, "SELinux", path, "custom SELinux user or role must not be set")
+	}
+	switch options.Type {
+	case "", "container_t", "container_init_t", "container_kvm_t":
+	default:
+		e.violate(LevelBaseline, "SELinux", path.Child("type"), "SELinux type %s is not allowed", options.Type)
+	}
+}
+
+func (e *evaluator) checkCapabilities(container *v1.Container, capabilities *v1.Capabilities, path *field.Path) {
+	if capabilities == nil {
+		capabilities = &v1.Capabilities{}
+	}
+
+	for i, capability := range capabilities.Add {
+		if !baselineCapabilities[capability] {
+			e.violate(LevelBaseline, "Capabilities", path.Child("add").Index(i),
+				"container %q must not add capability %s", container.Name, capability)
+		} else if capability != "NET_BIND_SERVICE" {
+			e.violate(LevelRestricted, "Capabilities", path.Child("add").Index(i),
+				"container %q may add only NET_BIND_SERVICE capability", container.Name)
+		}
+	}
+
+	for _, capability := range capabilities.Drop {
+		if capability == "ALL" {
+			return
+		}
+	}
+	e.violate(LevelRestricted, "Capabilities", path.Child("drop"), "container %q must drop ALL capabilities",
+		container.Name)
+}
+
+func (e *evaluator) runsAsNonRoot(securityContext *v1.SecurityContext) bool {
+	if securityContext.RunAsNonRoot != nil {
+		return *securityContext.RunAsNonRoot
+	}
+	return e.spec.SecurityContext != nil && e.spec.SecurityContext.RunAsNonRoot != nil &&
+		*e.spec.SecurityContext.RunAsNonRoot
+}
+
+func (e *evaluator) hasSeccompProfile(container string) bool {
+	if profile, ok := e.annotations[seccompContainerAnnotationPrefix+container]; ok {
+		return isAllowedSeccompProfile(profile)
+	}
+	profile, ok := e.annotations[seccompPodAnnotation]
+	return ok && isAllowedSeccompProfile(profile)
+}
+
+func isAllowedSeccompProfile(profile string) bool {
+	return profile == "runtime/default" || profile == "docker/default" || strings.HasPrefix(profile, "localhost/")
+}
+
+func isRestrictedVolume(source v1.VolumeSource) bool {
+	return source.ConfigMap != nil || source.CSI != nil || source.DownwardAPI != nil || source.EmptyDir != nil ||
+		source.PersistentVolumeClaim != nil || source.Projected != nil || source.Secret != nil
+}
@@ -0,0 +1,109 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package podsecurity
+
+import (
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func restrictedContainer(name string) v1.Container {
+	escalation := false
+	nonRoot := true
+	return v1.Container{
+		Name: name,
+		SecurityContext: &v1.SecurityContext{
+			AllowPrivilegeEscalation: &escalation,
+			RunAsNonRoot:             &nonRoot,
+			Capabilities:             &v1.Capabilities{Drop: []v1.Capability{"ALL"}},
+		},
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	privileged := true
+	root := int64(0)
+	seccomp := map[string]string{seccompPodAnnotation: "runtime/default"}
+
+	cases := []struct {
+		name        string
+		annotations map[string]string
+		spec        v1.PodSpec
+		level       Level
+		fields      []string
+	}{
+		{
+			name:        "restricted",
+			annotations: seccomp,
+			spec: v1.PodSpec{
+				Containers: []v1.Container{restrictedContainer("app")},
+				Volumes:    []v1.Volume{{Name: "data", VolumeSource: v1.VolumeSource{EmptyDir: &v1.EmptyDirVolumeSource{}}}},
+			},
+			level:  LevelRestricted,
+			fields: []string{},
+		},
+		{
+			name:  "default container",
+			spec:  v1.PodSpec{Containers: []v1.Container{{Name: "app"}}},
+			level: LevelBaseline,
+			fields: []string{
+				"metadata.annotations[container.seccomp.security.alpha.kubernetes.io/app]",
+				"spec.containers[0].securityContext.capabilities.drop",
+				"spec.containers[0].securityContext.allowPrivilegeEscalation",
+				"spec.containers[0].securityContext.runAsNonRoot",
+			},
+		},
+		{
+			name:        "privileged",
+			annotations: seccomp,
+			spec: v1.PodSpec{
+				HostNetwork: true,
+				Containers: []v1.Container{func() v1.Container {
+					container := restrictedContainer("app")
+					container.SecurityContext.Privileged = &privileged
+					container.SecurityContext.RunAsUser = &root
+					return container
+				}()},
+				Volumes: []v1.Volume{{Name: "docker", VolumeSource: v1.VolumeSource{
+					HostPath: &v1.HostPathVolumeSource{Path: "/var/run/docker.sock"}}}},
+			},
+			level: LevelPrivileged,
+			fields: []string{
+				"spec.hostNetwork",
+				"spec.volumes[0].hostPath",
+				"spec.containers[0].securityContext.privileged",
+				"spec.containers[0].securityContext.runAsUser",
+			},
+		},
+	}
+
+	for _, c := range cases {
+		evaluation := Evaluate(c.annotations, &c.spec, field.NewPath("spec"))
+		fields := make([]string, 0)
+		for _, violation := range evaluation.Violations {
+			fields = append(fields, violation.Field)
+		}
+
+		if evaluation.Level != c.level {
+			t.Errorf("%s: expected level %s, got %s", c.name, c.level, evaluation.Level)
+		}
+		if !reflect.DeepEqual(fields, c.fields) {
+			t.Errorf("%s: expected violations of %v, got %v", c.name, c.fields, fields)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"time"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+)
+
+// EventType is a type of a recorded terminal event. Values match event types of asciicast v2 format.
+type EventType string
+
+// List of recorded terminal events.
+const (
+	// EventInput is data typed by the user.
+	EventInput EventType = "i"
+	// EventOutput is data printed by the process.
+	EventOutput EventType = "o"
+	// EventResize is a change of the terminal size. Data is in COLSxROWS format.
+	EventResize EventType = "r"
+)
+
+// Session describes a recorded exec session.
+type Session struct {
+	ID string `json:"id"`
+
+	// User that opened the terminal.
+	User string `json:"user"`
+
+	Namespace string `json:"namespace"`
+	Pod       string `json:"pod"`
+	Container string `json:"container"`
+
+	// Shell requested by the user. Empty when the first available shell was used.
+	Shell string `json:"shell,omitempty"`
+
+	StartedAt time.Time `json:"startedAt"`
+
+	// Not set while the session is still open.
+	EndedAt *time.Time `json:"endedAt,omitempty"`
+}
+
+// Event is a single recorded terminal event.
+type Event struct {
+	// Time of the event in seconds since the start of the session.
+	Time float64   `json:"time"`
+	Type EventType `json:"type"`
+	Data string    `json:"data"`
+}
+
+// Default and maximum number of events returned at once. Long sessions are replayed page by page, so that the
+// whole recording is never loaded into memory.
+const (
+	DefaultEventLimit = 1000
+	MaxEventLimit     = 10000
+)
+
+// Recording is a recorded exec session together with a page of its events, so it can be replayed.
+type Recording struct {
+	Session `json:",inline"`
+	Events  []Event `json:"events"`
+
+	// Number of all recorded events of the session.
+	TotalEvents int `json:"totalEvents"`
+}
+
+// SessionList contains recorded exec sessions ordered from the newest one.
+type SessionList struct {
+	ListMeta api.ListMeta `json:"listMeta"`
+	Sessions []Session    `json:"sessions"`
+
+	// False when recording of exec sessions is not configured.
+	Enabled bool `json:"enabled"`
+}
+
+// Recorder records events of a single exec session. It has to be safe for concurrent use, as input and output
+// are recorded from different goroutines.
+type Recorder interface {
+	Record(eventType EventType, data string)
+
+	// Close marks the session as ended. Events recorded afterwards are dropped.
+	Close() error
+}
+
+// Sink stores recorded exec sessions.
+type Sink interface {
+	// Create starts recording of a new session.
+	Create(session Session) (Recorder, error)
+
+	// List returns all recorded sessions.
+	List() ([]Session, error)
+
+	// Get returns recorded session with at most limit of its events, starting from the offset.
+	Get(id string, offset, limit int) (*Recording, error)
+}
+
+// RecordingManager records exec sessions to the configured sink.
+type RecordingManager interface {
+	// Enabled returns true when recording of exec sessions is configured.
+	Enabled() bool
+
+	// Start starts recording of a new session. Recorder, that drops all events, is returned when recording is
+	// disabled. Error is returned when recording is enabled, but can not be started, in which case the session
+	// must not be opened.
+	Start(session Session) (Recorder, error)
+
+	// List returns recorded sessions ordered from the newest one.
+	List() ([]Session, error)
+
+	// Get returns recorded session with at most limit of its events, starting from the offset.
+	Get(id string, offset, limit int) (*Recording, error)
+}
@@ -0,0 +1,273 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recording
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/recording/api"
+)
+
+const (
+	metadataExtension = ".json"
+	castExtension     = ".cast"
+
+	// Terminal size written to the asciicast header. Actual size is recorded by the first resize event.
+	defaultWidth  = 80
+	defaultHeight = 24
+)
+
+// sessionIDPattern matches valid session IDs. IDs are used as file names, so they must not contain path separators.
+var sessionIDPattern = regexp.MustCompile(`^[a-zA-Z0-9-]+$`)
+
+// castHeader is the first line of asciicast v2 file.
+type castHeader struct {
+	Version   int    `json:"version"`
+	Width     int    `json:"width"`
+	Height    int    `json:"height"`
+	Timestamp int64  `json:"timestamp"`
+	Title     string `json:"title"`
+}
+
+// fileSink stores every session in the directory as two files. The first one contains session metadata, the
+// second one its events in asciicast v2 format, so that recordings can be replayed by asciinema player as well.
+type fileSink struct {
+	dir string
+	now func() time.Time
+}
+
+// Create implements Sink interface. See Sink for more information.
+func (self *fileSink) Create(session api.Session) (api.Recorder, error) {
+	if !sessionIDPattern.MatchString(session.ID) {
+		return nil, fmt.Errorf("invalid session id %q", session.ID)
+	}
+	if err := os.MkdirAll(self.dir, 0700); err != nil {
+		return nil, err
+	}
+
+	file, err := os.OpenFile(self.path(session.ID, castExtension), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return nil, err
+	}
+
+	header, err := json.Marshal(castHeader{
+		Version:   2,
+		Width:     defaultWidth,
+		Height:    defaultHeight,
+		Timestamp: session.StartedAt.Unix(),
+		Title:     fmt.Sprintf("%s@%s/%s/%s", session.User, session.Namespace, session.Pod, session.Container),
+	})
+	if err == nil {
+		_, err = file.Write(append(header, '\n'))
+	}
+	if err == nil {
+		err = self.writeMetadata(session)
+	}
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	return &fileRecorder{sink: self, session: session, file: file}, nil
+}
+
+// List implements Sink interface. See Sink for more information.
+func (self *fileSink) List() ([]api.Session, error) {
+	files, err := ioutil.ReadDir(self.dir)
+	if os.IsNotExist(err) {
+		return []api.Session{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]api.Session, 0)
+	for _, file := range files {
+		if file.IsDir() || !strings.HasSuffix(file.Name(), metadataExtension) {
+			continue
+		}
+
+		session, err := self.readMetadata(strings.TrimSuffix(file.Name(), metadataExtension))
+		if err != nil {
+			log.Printf("Skipping exec session recording %s: %s", file.Name(), err)
+			continue
+		}
+		result = append(result, *session)
+	}
+	return result, nil
+}
+
+// Get implements Sink interface. See Sink for more information.
+func (self *fileSink) Get(id string, offset, limit int) (*api.Recording, error) {
+	if !sessionIDPattern.MatchString(id) {
+		return nil, errors.NewNotFound(fmt.Sprintf("exec session %s not found", id))
+	}
+
+	session, err := self.readMetadata(id)
+	if os.IsNotExist(err) {
+		return nil, errors.NewNotFound(fmt.Sprintf("exec session %s not found", id))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(self.path(id, castExtension))
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	events, total, err := readEvents(file, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return &api.Recording{Session: *session, Events: events, TotalEvents: total}, nil
+}
+
+func (self *fileSink) path(id, extension string) string {
+	return filepath.Join(self.dir, id+extension)
+}
+
+func (self *fileSink) readMetadata(id string) (*api.Session, error) {
+	data, err := ioutil.ReadFile(self.path(id, metadataExtension))
+	if err != nil {
+		return nil, err
+	}
+
+	session := &api.Session{}
+	err = json.Unmarshal(data, session)
+	return session, err
+}
+
+// writeMetadata replaces metadata file atomically, so that sessions are never listed with partial metadata.
+func (self *fileSink) writeMetadata(session api.Session) error {
+	data, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+
+	tmp := self.path(session.ID, metadataExtension+".tmp")
+	if err := ioutil.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, self.path(session.ID, metadataExtension))
+}
+
+// readEvents reads at most limit events of asciicast v2 file starting from the offset, together with the number
+// of all events in the file. The header line is skipped. Events outside of the page are only counted, so that
+// long recordings are not loaded into memory.
+func readEvents(reader io.Reader, offset, limit int) ([]api.Event, int, error) {
+	result := make([]api.Event, 0)
+	total := 0
+	buffered := bufio.NewReader(reader)
+	for header := true; ; header = false {
+		line, err := buffered.ReadBytes('\n')
+		if len(line) > 0 && !header {
+			var fields []interface{}
+			if err := json.Unmarshal(line, &fields); err != nil {
+				return nil, 0, err
+			}
+			if event, ok := toEvent(fields); ok {
+				if total >= offset && len(result) < limit {
+					result = append(result, event)
+				}
+				total++
+			}
+		}
+		if err == io.EOF {
+			return result, total, nil
+		}
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+}
+
+func toEvent(fields []interface{}) (api.Event, bool) {
+	if len(fields) != 3 {
+		return api.Event{}, false
+	}
+
+	eventTime, timeOK := fields[0].(float64)
+	eventType, typeOK := fields[1].(string)
+	data, dataOK := fields[2].(string)
+	return api.Event{Time: eventTime, Type: api.EventType(eventType), Data: data}, timeOK && typeOK && dataOK
+}
+
+// fileRecorder appends events of a single session to its asciicast file.
+type fileRecorder struct {
+	sink    *fileSink
+	session api.Session
+
+	mux    sync.Mutex
+	file   *os.File
+	closed bool
+	// failed is set after the first write error, so that broken sink does not flood the log.
+	failed bool
+}
+
+// Record implements Recorder interface. See Recorder for more information.
+func (self *fileRecorder) Record(eventType api.EventType, data string) {
+	self.mux.Lock()
+	defer self.mux.Unlock()
+	if self.closed || self.failed {
+		return
+	}
+
+	elapsed := self.sink.now().Sub(self.session.StartedAt).Seconds()
+	line, err := json.Marshal([]interface{}{float64(int64(elapsed*1e6)) / 1e6, eventType, data})
+	if err == nil {
+		_, err = self.file.Write(append(line, '\n'))
+	}
+	if err != nil {
+		log.Printf("Cannot record event of exec session %s: %s", self.session.ID, err)
+		self.failed = true
+	}
+}
+
+// Close implements Recorder interface. See Recorder for more information.
+func (self *fileRecorder) Close() error {
+	self.mux.Lock()
+	defer self.mux.Unlock()
+	if self.closed {
+		return nil
+	}
+	self.closed = true
+
+	err := self.file.Close()
+	endedAt := self.sink.now()
+	self.session.EndedAt = &endedAt
+	if metadataErr := self.sink.writeMetadata(self.session); err == nil {
+		err = metadataErr
+	}
+	return err
+}
+
+// NewFileSink creates Sink, that stores recordings in the directory.
+func NewFileSink(dir string) api.Sink {
+	return &fileSink{dir: dir, now: time.Now}
+}
@@ -0,0 +1,138 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recording
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/kubernetes/dashboard/src/app/backend/recording/api"
+)
+
+func TestFileSink(t *testing.T) {
+	dir, err := ioutil.TempDir("", "recording")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	startedAt := time.Date(2020, 1, 1, 10, 0, 0, 0, time.UTC)
+	now := startedAt
+	sink := &fileSink{dir: filepath.Join(dir, "sessions"), now: func() time.Time { return now }}
+	session := api.Session{ID: "abc123", User: "alice", Namespace: "default", Pod: "web", Container: "nginx",
+		StartedAt: startedAt}
+
+	recorder, err := sink.Create(session)
+	if err != nil {
+		t.Fatalf("Create() returned error: %v", err)
+	}
+	if _, err := sink.Create(session); err == nil {
+		t.Error("expected error when the session is recorded twice")
+	}
+
+	now = startedAt.Add(500 * time.Millisecond)
+	recorder.Record(api.EventResize, "120x40")
+	recorder.Record(api.EventInput, "ls\r")
+	now = startedAt.Add(1500 * time.Millisecond)
+	recorder.Record(api.EventOutput, "bin  etc\r\n")
+
+	sessions, err := sink.List()
+	if err != nil || len(sessions) != 1 || sessions[0].EndedAt != nil {
+		t.Errorf("expected a single open session, got %#v, %v", sessions, err)
+	}
+
+	now = startedAt.Add(2 * time.Second)
+	if err := recorder.Close(); err != nil {
+		t.Fatalf("Close() returned error: %v", err)
+	}
+	recorder.Record(api.EventOutput, "dropped")
+
+	actual, err := sink.Get(session.ID, 0, api.DefaultEventLimit)
+	if err != nil {
+		t.Fatalf("Get() returned error: %v", err)
+	}
+
+	endedAt := startedAt.Add(2 * time.Second)
+	session.EndedAt = &endedAt
+	expected := &api.Recording{Session: session, Events: []api.Event{
+		{Time: 0.5, Type: api.EventResize, Data: "120x40"},
+		{Time: 0.5, Type: api.EventInput, Data: "ls\r"},
+		{Time: 1.5, Type: api.EventOutput, Data: "bin  etc\r\n"},
+	}, TotalEvents: 3}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Get() == %#v, expected %#v", actual, expected)
+	}
+
+	page, err := sink.Get(session.ID, 1, 1)
+	if err != nil || page.TotalEvents != 3 || !reflect.DeepEqual(page.Events, expected.Events[1:2]) {
+		t.Errorf("Get() of the second event == %#v, %v, expected %#v", page, err, expected.Events[1:2])
+	}
+
+	cast, err := ioutil.ReadFile(sink.path(session.ID, castExtension))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if header := strings.SplitN(string(cast), "\n", 2)[0]; header !=
+		`{"version":2,"width":80,"height":24,"timestamp":1577872800,"title":"alice@default/web/nginx"}` {
+		t.Errorf("unexpected asciicast header: %s", header)
+	}
+
+	for _, id := range []string{"missing", "../sessions/abc123"} {
+		if _, err := sink.Get(id, 0, api.DefaultEventLimit); !errors.IsNotFound(err) {
+			t.Errorf("Get(%q) expected not found error, got %v", id, err)
+		}
+	}
+}
+
+func TestRecordingManager(t *testing.T) {
+	disabled := NewRecordingManager("")
+	recorder, err := disabled.Start(api.Session{ID: "abc123"})
+	if disabled.Enabled() || err != nil || recorder == nil {
+		t.Errorf("expected disabled manager to return no-op recorder, got %v, %v", recorder, err)
+	}
+
+	dir, err := ioutil.TempDir("", "recording")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	manager := NewRecordingManager(dir)
+	startedAt := time.Date(2020, 1, 1, 10, 0, 0, 0, time.UTC)
+	for i, id := range []string{"first", "second", "third"} {
+		if _, err := manager.Start(api.Session{ID: id, StartedAt: startedAt.Add(time.Duration(i) * time.Minute)}); err != nil {
+			t.Fatalf("Start() returned error: %v", err)
+		}
+	}
+
+	sessions, err := manager.List()
+	if err != nil {
+		t.Fatalf("List() returned error: %v", err)
+	}
+	ids := make([]string, 0)
+	for _, session := range sessions {
+		ids = append(ids, session.ID)
+	}
+	if !reflect.DeepEqual(ids, []string{"third", "second", "first"}) {
+		t.Errorf("expected sessions ordered from the newest one, got %v", ids)
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recording
+
+import (
+	"net/http"
+	"strconv"
+
+	restful "github.com/emicklei/go-restful"
+
+	clientapi "github.com/kubernetes/dashboard/src/app/backend/client/api"
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/recording/api"
+)
+
+// RecordingHandler manages all endpoints related to recorded exec sessions.
+type RecordingHandler struct {
+	manager       api.RecordingManager
+	clientManager clientapi.ClientManager
+}
+
+// Install creates new endpoints for recorded exec sessions. Recordings contain everything typed and printed in
+// terminals of all users, so they are available only to cluster administrators, i.e. users allowed to perform
+// any action on any resource.
+func (self *RecordingHandler) Install(ws *restful.WebService) {
+	ws.Route(
+		ws.GET("/execrecording").
+			To(self.handleGetSessions).
+			Writes(api.SessionList{}))
+	ws.Route(
+		ws.GET("/execrecording/{id}").
+			To(self.handleGetRecording).
+			Param(ws.QueryParameter("offset", "index of the first returned event")).
+			Param(ws.QueryParameter("limit", "maximum number of returned events")).
+			Writes(api.Recording{}))
+}
+
+func (self *RecordingHandler) handleGetSessions(request *restful.Request, response *restful.Response) {
+	if !self.isAdmin(request) {
+		errors.HandleInternalError(request, response, errors.NewForbidden("cluster administrator access is required"))
+		return
+	}
+
+	sessions, err := self.manager.List()
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	result := api.SessionList{Sessions: sessions, Enabled: self.manager.Enabled()}
+	result.ListMeta.TotalItems = len(sessions)
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (self *RecordingHandler) handleGetRecording(request *restful.Request, response *restful.Response) {
+	if !self.isAdmin(request) {
+		errors.HandleInternalError(request, response, errors.NewForbidden("cluster administrator access is required"))
+		return
+	}
+
+	offset, limit, err := parseEventPage(request)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	result, err := self.manager.Get(request.PathParameter("id"), offset, limit)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+// parseEventPage returns offset and limit of the requested page of events. Limit is capped at MaxEventLimit.
+func parseEventPage(request *restful.Request) (int, int, error) {
+	offset, limit := 0, api.DefaultEventLimit
+	var err error
+	if value := request.QueryParameter("offset"); len(value) > 0 {
+		if offset, err = strconv.Atoi(value); err != nil || offset < 0 {
+			return 0, 0, errors.NewBadRequest("offset has to be a non-negative number")
+		}
+	}
+	if value := request.QueryParameter("limit"); len(value) > 0 {
+		if limit, err = strconv.Atoi(value); err != nil || limit <= 0 {
+			return 0, 0, errors.NewBadRequest("limit has to be a positive number")
+		}
+	}
+	if limit > api.MaxEventLimit {
+		limit = api.MaxEventLimit
+	}
+	return offset, limit, nil
+}
+
+func (self *RecordingHandler) isAdmin(request *restful.Request) bool {
+	return self.clientManager.CanI(request, clientapi.ToSelfSubjectAccessReview("", "", "*", "*"))
+}
+
+// NewRecordingHandler creates RecordingHandler.
+func NewRecordingHandler(manager api.RecordingManager, clientManager clientapi.ClientManager) RecordingHandler {
+	return RecordingHandler{manager: manager, clientManager: clientManager}
+}
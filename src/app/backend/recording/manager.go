@@ -0,0 +1,86 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recording
+
+import (
+	"sort"
+
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+
+	"github.com/kubernetes/dashboard/src/app/backend/recording/api"
+)
+
+// recordingManager implements RecordingManager interface.
+type recordingManager struct {
+	sink api.Sink
+}
+
+// Enabled implements RecordingManager interface. See RecordingManager for more information.
+func (self *recordingManager) Enabled() bool {
+	return self.sink != nil
+}
+
+// Start implements RecordingManager interface. See RecordingManager for more information.
+func (self *recordingManager) Start(session api.Session) (api.Recorder, error) {
+	if !self.Enabled() {
+		return noopRecorder{}, nil
+	}
+	return self.sink.Create(session)
+}
+
+// List implements RecordingManager interface. See RecordingManager for more information.
+func (self *recordingManager) List() ([]api.Session, error) {
+	if !self.Enabled() {
+		return []api.Session{}, nil
+	}
+
+	sessions, err := self.sink.List()
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(sessions, func(i, j int) bool {
+		return sessions[i].StartedAt.After(sessions[j].StartedAt)
+	})
+	return sessions, nil
+}
+
+// Get implements RecordingManager interface. See RecordingManager for more information.
+func (self *recordingManager) Get(id string, offset, limit int) (*api.Recording, error) {
+	if !self.Enabled() {
+		return nil, errors.NewNotFound("recording of exec sessions is not enabled")
+	}
+	return self.sink.Get(id, offset, limit)
+}
+
+// noopRecorder drops all events. It is used when recording is disabled.
+type noopRecorder struct{}
+
+// Record implements Recorder interface. See Recorder for more information.
+func (noopRecorder) Record(api.EventType, string) {}
+
+// Close implements Recorder interface. See Recorder for more information.
+func (noopRecorder) Close() error {
+	return nil
+}
+
+// NewRecordingManager creates RecordingManager. Sessions are recorded to the directory, recording is disabled
+// when it is empty.
+func NewRecordingManager(dir string) api.RecordingManager {
+	if len(dir) == 0 {
+		return &recordingManager{}
+	}
+	return &recordingManager{sink: NewFileSink(dir)}
+}
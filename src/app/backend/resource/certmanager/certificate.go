@@ -0,0 +1,279 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package certmanager
+
+import (
+	"log"
+	"time"
+
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+)
+
+// certificateGroups are API groups of cert-manager certificates. Group was renamed in cert-manager 0.11, older
+// one is used as a fallback.
+var certificateGroups = []string{"cert-manager.io", "certmanager.k8s.io"}
+
+// expiringThreshold is the time before expiry when certificate that was not renewed yet is reported as expiring.
+const expiringThreshold = 7 * 24 * time.Hour
+
+// CertificateStatus summarizes issuance and renewal state of a certificate.
+type CertificateStatus string
+
+// List of certificate statuses.
+const (
+	// CertificateStatusReady means that the certificate is issued and valid.
+	CertificateStatusReady CertificateStatus = "Ready"
+
+	// CertificateStatusPending means that the certificate is being issued for the first time.
+	CertificateStatusPending CertificateStatus = "Pending"
+
+	// CertificateStatusFailing means that issuance or renewal of the certificate failed.
+	CertificateStatusFailing CertificateStatus = "Failing"
+
+	// CertificateStatusExpiring means that the certificate expires soon and was not renewed.
+	CertificateStatusExpiring CertificateStatus = "Expiring"
+
+	// CertificateStatusExpired means that the certificate is not valid anymore.
+	CertificateStatusExpired CertificateStatus = "Expired"
+)
+
+// Certificate is a single cert-manager certificate.
+type Certificate struct {
+	api.ObjectMeta `json:"objectMeta"`
+	api.TypeMeta   `json:"typeMeta"`
+
+	// Name of the secret the certificate is stored in.
+	SecretName string `json:"secretName"`
+
+	// DNS names the certificate is issued for.
+	DNSNames []string `json:"dnsNames"`
+
+	// Kind and name of the issuer of the certificate, i.e. ClusterIssuer/letsencrypt.
+	IssuerKind string `json:"issuerKind"`
+	IssuerName string `json:"issuerName"`
+
+	// Summary of issuance and renewal state of the certificate.
+	Status CertificateStatus `json:"status"`
+
+	// Reason and message of the Ready condition, that explain why the certificate is not ready.
+	Reason  string `json:"reason,omitempty"`
+	Message string `json:"message,omitempty"`
+
+	// Expiry time of the issued certificate.
+	NotAfter *metaV1.Time `json:"notAfter,omitempty"`
+
+	// Time when cert-manager starts renewal of the certificate.
+	RenewalTime *metaV1.Time `json:"renewalTime,omitempty"`
+}
+
+// CertificateList contains a list of cert-manager certificates.
+type CertificateList struct {
+	api.ListMeta `json:"listMeta"`
+
+	// Unordered list of certificates.
+	Items []Certificate `json:"items"`
+
+	// False when cert-manager is not installed in the cluster.
+	Installed bool `json:"installed"`
+
+	// List of non-critical errors, that occurred during resource retrieval.
+	Errors []error `json:"errors"`
+}
+
+// GetCertificateList returns all cert-manager certificates in the given namespace. Empty list is returned when
+// cert-manager is not installed.
+func GetCertificateList(discoveryClient discovery.DiscoveryInterface, client dynamic.Interface,
+	namespace *common.NamespaceQuery, dsQuery *dataselect.DataSelectQuery) (*CertificateList, error) {
+	log.Printf("Getting list of certificates in %s namespace", namespace.ToRequestParam())
+
+	result := &CertificateList{Items: make([]Certificate, 0), Errors: make([]error, 0)}
+	certificates, installed, err := listCertificates(discoveryClient, client, namespace)
+	nonCriticalErrors, criticalError := errors.HandleError(err)
+	if criticalError != nil {
+		return nil, criticalError
+	}
+	result.Installed = installed
+	result.Errors = nonCriticalErrors
+
+	cells, filteredTotal := dataselect.GenericDataSelectWithFilter(toCells(certificates), dsQuery)
+	certificates = fromCells(cells)
+	result.ListMeta = api.ListMeta{TotalItems: filteredTotal}
+	for i := range certificates {
+		result.Items = append(result.Items, toCertificate(&certificates[i], time.Now()))
+	}
+
+	return result, nil
+}
+
+// GetSecretCertificates returns certificates stored in any of the given secrets, i.e. secrets referred to by
+// TLS section of an ingress. Empty list is returned when cert-manager is not installed.
+func GetSecretCertificates(discoveryClient discovery.DiscoveryInterface, client dynamic.Interface,
+	namespace string, secretNames []string) ([]Certificate, error) {
+	result := make([]Certificate, 0)
+	if len(secretNames) == 0 {
+		return result, nil
+	}
+
+	certificates, _, err := listCertificates(discoveryClient, client, common.NewSameNamespaceQuery(namespace))
+	if err != nil {
+		return result, err
+	}
+
+	secrets := make(map[string]bool)
+	for _, name := range secretNames {
+		secrets[name] = true
+	}
+
+	now := time.Now()
+	for i := range certificates {
+		certificate := toCertificate(&certificates[i], now)
+		if secrets[certificate.SecretName] {
+			result = append(result, certificate)
+		}
+	}
+	return result, nil
+}
+
+func listCertificates(discoveryClient discovery.DiscoveryInterface, client dynamic.Interface,
+	namespace *common.NamespaceQuery) ([]unstructured.Unstructured, bool, error) {
+	resource, installed, err := common.FindServedResource(discoveryClient, "certificates", certificateGroups...)
+	if err != nil || !installed {
+		return []unstructured.Unstructured{}, installed, err
+	}
+
+	list, err := client.Resource(resource).Namespace(namespace.ToRequestParam()).List(api.ListEverything)
+	if err != nil {
+		return []unstructured.Unstructured{}, true, err
+	}
+
+	certificates := make([]unstructured.Unstructured, 0)
+	for _, item := range list.Items {
+		if namespace.Matches(item.GetNamespace()) {
+			certificates = append(certificates, item)
+		}
+	}
+	return certificates, true, nil
+}
+
+func toCertificate(obj *unstructured.Unstructured, now time.Time) Certificate {
+	certificate := Certificate{
+		ObjectMeta: common.NewUnstructuredObjectMeta(obj),
+		TypeMeta:   api.NewTypeMeta(api.ResourceKindCertificate),
+		NotAfter:   getTime(obj, "status", "notAfter"),
+		// Older versions of cert-manager do not report renewal time.
+		RenewalTime: getTime(obj, "status", "renewalTime"),
+	}
+	certificate.SecretName, _, _ = unstructured.NestedString(obj.Object, "spec", "secretName")
+	certificate.DNSNames, _, _ = unstructured.NestedStringSlice(obj.Object, "spec", "dnsNames")
+	certificate.IssuerKind, _, _ = unstructured.NestedString(obj.Object, "spec", "issuerRef", "kind")
+	certificate.IssuerName, _, _ = unstructured.NestedString(obj.Object, "spec", "issuerRef", "name")
+	if len(certificate.IssuerKind) == 0 {
+		certificate.IssuerKind = "Issuer"
+	}
+
+	ready, reason, message := getReadyCondition(obj)
+	certificate.Reason = reason
+	certificate.Message = message
+	certificate.Status = getCertificateStatus(ready, certificate.NotAfter, now)
+	return certificate
+}
+
+// getCertificateStatus derives the status from the Ready condition and expiry time. Expiry takes precedence, as
+// it is the most urgent problem.
+func getCertificateStatus(ready string, notAfter *metaV1.Time, now time.Time) CertificateStatus {
+	switch {
+	case notAfter != nil && !now.Before(notAfter.Time):
+		return CertificateStatusExpired
+	case ready == string(metaV1.ConditionFalse) && notAfter == nil:
+		return CertificateStatusPending
+	case ready == string(metaV1.ConditionFalse):
+		return CertificateStatusFailing
+	case notAfter != nil && notAfter.Time.Sub(now) < expiringThreshold:
+		return CertificateStatusExpiring
+	case ready == string(metaV1.ConditionTrue):
+		return CertificateStatusReady
+	}
+	return CertificateStatusPending
+}
+
+func getReadyCondition(obj *unstructured.Unstructured) (string, string, string) {
+	conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	for _, condition := range conditions {
+		condition, ok := condition.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		if t, _, _ := unstructured.NestedString(condition, "type"); t == "Ready" {
+			status, _, _ := unstructured.NestedString(condition, "status")
+			reason, _, _ := unstructured.NestedString(condition, "reason")
+			message, _, _ := unstructured.NestedString(condition, "message")
+			return status, reason, message
+		}
+	}
+	return string(metaV1.ConditionUnknown), "", ""
+}
+
+func getTime(obj *unstructured.Unstructured, fields ...string) *metaV1.Time {
+	value, _, _ := unstructured.NestedString(obj.Object, fields...)
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil
+	}
+	return &metaV1.Time{Time: parsed}
+}
+
+// The code below allows to perform complex data section on []unstructured.Unstructured
+
+type CertificateCell unstructured.Unstructured
+
+func (self CertificateCell) GetProperty(name dataselect.PropertyName) dataselect.ComparableValue {
+	obj := unstructured.Unstructured(self)
+	switch name {
+	case dataselect.NameProperty:
+		return dataselect.StdComparableString(obj.GetName())
+	case dataselect.CreationTimestampProperty:
+		return dataselect.StdComparableTime(obj.GetCreationTimestamp().Time)
+	case dataselect.NamespaceProperty:
+		return dataselect.StdComparableString(obj.GetNamespace())
+	default:
+		// if name is not supported then just return a constant dummy value, sort will have no effect.
+		return nil
+	}
+}
+
+func toCells(std []unstructured.Unstructured) []dataselect.DataCell {
+	cells := make([]dataselect.DataCell, len(std))
+	for i := range std {
+		cells[i] = CertificateCell(std[i])
+	}
+	return cells
+}
+
+func fromCells(cells []dataselect.DataCell) []unstructured.Unstructured {
+	std := make([]unstructured.Unstructured, len(cells))
+	for i := range std {
+		std[i] = unstructured.Unstructured(cells[i].(CertificateCell))
+	}
+	return std
+}
@@ -0,0 +1,97 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package certmanager
+
+import (
+	"testing"
+	"time"
+
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	fakedynamic "k8s.io/client-go/dynamic/fake"
+	clienttesting "k8s.io/client-go/testing"
+)
+
+func TestGetCertificateStatus(t *testing.T) {
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	expiresAt := func(d time.Duration) *metaV1.Time { return &metaV1.Time{Time: now.Add(d)} }
+
+	cases := []struct {
+		ready    string
+		notAfter *metaV1.Time
+		expected CertificateStatus
+	}{
+		{"True", expiresAt(30 * 24 * time.Hour), CertificateStatusReady},
+		{"True", expiresAt(24 * time.Hour), CertificateStatusExpiring},
+		{"True", expiresAt(-time.Hour), CertificateStatusExpired},
+		{"False", expiresAt(-time.Hour), CertificateStatusExpired},
+		{"False", expiresAt(24 * time.Hour), CertificateStatusFailing},
+		{"False", nil, CertificateStatusPending},
+		{"Unknown", nil, CertificateStatusPending},
+	}
+
+	for _, c := range cases {
+		if actual := getCertificateStatus(c.ready, c.notAfter, now); actual != c.expected {
+			t.Errorf("getCertificateStatus(%s, %v) == %s, expected %s", c.ready, c.notAfter, actual, c.expected)
+		}
+	}
+}
+
+func TestGetSecretCertificates(t *testing.T) {
+	newCertificate := func(name, secretName string) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "cert-manager.io/v1alpha2",
+			"kind":       "Certificate",
+			"metadata":   map[string]interface{}{"name": name, "namespace": "default"},
+			"spec": map[string]interface{}{
+				"secretName": secretName,
+				"issuerRef":  map[string]interface{}{"kind": "ClusterIssuer", "name": "letsencrypt"},
+			},
+			"status": map[string]interface{}{
+				"conditions": []interface{}{map[string]interface{}{"type": "Ready", "status": "False",
+					"reason": "Failed", "message": "challenge failed"}},
+			},
+		}}
+	}
+
+	discoveryClient := &fakediscovery.FakeDiscovery{Fake: &clienttesting.Fake{
+		Resources: []*metaV1.APIResourceList{{GroupVersion: "cert-manager.io/v1alpha2"}},
+	}}
+	client := fakedynamic.NewSimpleDynamicClient(runtime.NewScheme(), newCertificate("web", "web-tls"),
+		newCertificate("other", "other-tls"))
+
+	certificates, err := GetSecretCertificates(discoveryClient, client, "default", []string{"web-tls"})
+	if err != nil {
+		t.Fatalf("GetSecretCertificates() returned unexpected error: %s", err)
+	}
+	if len(certificates) != 1 {
+		t.Fatalf("GetSecretCertificates() returned %d certificates, expected 1", len(certificates))
+	}
+
+	certificate := certificates[0]
+	if certificate.Name != "web" || certificate.Status != CertificateStatusPending ||
+		certificate.Message != "challenge failed" || certificate.IssuerKind != "ClusterIssuer" {
+		t.Errorf("GetSecretCertificates() == %#v, expected pending web certificate", certificate)
+	}
+
+	certificates, err = GetSecretCertificates(&fakediscovery.FakeDiscovery{Fake: &clienttesting.Fake{}}, client,
+		"default", []string{"web-tls"})
+	if err != nil || len(certificates) != 0 {
+		t.Errorf("GetSecretCertificates() == %v, %v, expected no certificates when cert-manager is not installed",
+			certificates, err)
+	}
+}
@@ -24,16 +24,24 @@ import api "k8s.io/api/core/v1"
 //    filtered here.
 type NamespaceQuery struct {
 	namespaces []string
+
+	// isAllowed hides namespaces restricted by Dashboard settings. All namespaces are allowed when nil.
+	isAllowed func(namespace string) bool
+}
+
+// Restrict returns copy of the query that matches only namespaces accepted by isAllowed.
+func (n *NamespaceQuery) Restrict(isAllowed func(namespace string) bool) *NamespaceQuery {
+	return &NamespaceQuery{namespaces: n.namespaces, isAllowed: isAllowed}
 }
 
 // NewSameNamespaceQuery creates new namespace query that queries single namespace.
 func NewSameNamespaceQuery(namespace string) *NamespaceQuery {
-	return &NamespaceQuery{[]string{namespace}}
+	return &NamespaceQuery{namespaces: []string{namespace}}
 }
 
 // NewNamespaceQuery creates new query for given namespaces.
 func NewNamespaceQuery(namespaces []string) *NamespaceQuery {
-	return &NamespaceQuery{namespaces}
+	return &NamespaceQuery{namespaces: namespaces}
 }
 
 // ToRequestParam returns K8s API namespace query for list of objects from this namespaces.
@@ -48,6 +56,10 @@ func (n *NamespaceQuery) ToRequestParam() string {
 
 // Matches returns true when the given namespace matches this query.
 func (n *NamespaceQuery) Matches(namespace string) bool {
+	if n.isAllowed != nil && !n.isAllowed(namespace) {
+		return false
+	}
+
 	if len(n.namespaces) == 0 {
 		return true
 	}
@@ -69,3 +69,29 @@ func TestMatches(t *testing.T) {
 		t.Error("Expected kube-system not to match")
 	}
 }
+
+func TestRestrict(t *testing.T) {
+	isAllowed := func(namespace string) bool { return namespace != "kube-system" }
+
+	nsQ := NewNamespaceQuery(nil).Restrict(isAllowed)
+	if !nsQ.Matches("foo") {
+		t.Error("Expected foo to match")
+	}
+	if nsQ.Matches("kube-system") {
+		t.Error("Expected kube-system not to match")
+	}
+
+	nsQ = NewNamespaceQuery([]string{"foo", "kube-system"}).Restrict(isAllowed)
+	if nsQ.ToRequestParam() != "" {
+		t.Errorf("Expected %s to be ''", nsQ.ToRequestParam())
+	}
+	if !nsQ.Matches("foo") {
+		t.Error("Expected foo to match")
+	}
+	if nsQ.Matches("bar") {
+		t.Error("Expected bar not to match")
+	}
+	if nsQ.Matches("kube-system") {
+		t.Error("Expected kube-system not to match")
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+)
+
+// FindServedResource returns the resource of the first of the given API groups that is served by the cluster, in
+// the version preferred by the cluster. It is used for resources of optional add-ons, that are read with the
+// dynamic client. Second return value is false when none of the groups is served.
+func FindServedResource(discoveryClient discovery.DiscoveryInterface, resource string, groups ...string) (
+	schema.GroupVersionResource, bool, error) {
+	groupList, err := discoveryClient.ServerGroups()
+	if err != nil {
+		return schema.GroupVersionResource{}, false, err
+	}
+
+	for _, name := range groups {
+		for _, group := range groupList.Groups {
+			if group.Name != name || len(group.Versions) == 0 {
+				continue
+			}
+
+			version := group.PreferredVersion.Version
+			if len(version) == 0 {
+				version = group.Versions[0].Version
+			}
+			return schema.GroupVersionResource{Group: name, Version: version, Resource: resource}, true, nil
+		}
+	}
+
+	return schema.GroupVersionResource{}, false, nil
+}
+
+// NewUnstructuredObjectMeta creates object meta of the object read with the dynamic client.
+func NewUnstructuredObjectMeta(obj *unstructured.Unstructured) api.ObjectMeta {
+	return api.NewObjectMeta(metaV1.ObjectMeta{
+		Name:              obj.GetName(),
+		Namespace:         obj.GetNamespace(),
+		Labels:            obj.GetLabels(),
+		Annotations:       obj.GetAnnotations(),
+		CreationTimestamp: obj.GetCreationTimestamp(),
+		UID:               obj.GetUID(),
+	})
+}
@@ -0,0 +1,153 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"sync"
+
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+)
+
+// LogStreamOptions selects which logs of a container are streamed.
+type LogStreamOptions struct {
+	// Follow keeps the stream open and forwards new lines as the container writes them.
+	Follow bool
+	// Previous streams logs of the previous, terminated, instance of the container.
+	Previous bool
+	// Timestamps prefixes every line with RFC3339 timestamp.
+	Timestamps bool
+	// SinceSeconds streams only lines written in given number of seconds.
+	SinceSeconds *int64
+	// TailLines streams only given number of the newest lines. It is limited to lineReadLimit lines, which are
+	// also streamed when it is not set.
+	TailLines *int64
+}
+
+// maxLogLineLength is the number of bytes of a single log line, that are streamed. Rest of longer lines is
+// dropped, so that a container writing no newlines can not make the backend buffer its whole output.
+const maxLogLineLength = 64 * 1024
+
+// LogLineSink is called for every streamed log line. The line does not contain the trailing newline.
+type LogLineSink func(line string) error
+
+// Validate checks that options can be passed to the API server.
+func (self *LogStreamOptions) Validate() error {
+	if self.SinceSeconds != nil && *self.SinceSeconds < 1 {
+		return errors.NewBadRequest("sinceSeconds must be greater than 0")
+	}
+
+	if self.TailLines != nil && *self.TailLines < 0 {
+		return errors.NewBadRequest("tailLines must not be negative")
+	}
+
+	return nil
+}
+
+// OpenLogStream opens a log stream of given pod container. When container is empty, logs of the first one are
+// streamed.
+func OpenLogStream(client kubernetes.Interface, namespace, podID, container string, options *LogStreamOptions) (
+	io.ReadCloser, error) {
+	if err := options.Validate(); err != nil {
+		return nil, err
+	}
+
+	if len(container) == 0 {
+		pod, err := client.CoreV1().Pods(namespace).Get(podID, metaV1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		container = pod.Spec.Containers[0].Name
+	}
+
+	return openStream(client, namespace, podID, mapToStreamLogOptions(container, options))
+}
+
+// Maps the stream options to the corresponding api object. Stream starts with at most lineReadLimit lines, so that
+// logs of long running containers are not sent in full, same as by the log viewer.
+func mapToStreamLogOptions(container string, options *LogStreamOptions) *v1.PodLogOptions {
+	logOptions := &v1.PodLogOptions{
+		Container:    container,
+		Follow:       options.Follow,
+		Previous:     options.Previous,
+		Timestamps:   options.Timestamps,
+		SinceSeconds: options.SinceSeconds,
+		TailLines:    options.TailLines,
+	}
+
+	if options.TailLines == nil || *options.TailLines > lineReadLimit {
+		logOptions.TailLines = &lineReadLimit
+	}
+
+	return logOptions
+}
+
+// StreamLogs forwards lines read from the log stream to the sink until the stream ends, stop channel is closed
+// or the sink returns an error. Lines are truncated to maxLogLineLength bytes. The stream is always closed.
+func StreamLogs(stream io.ReadCloser, stopCh <-chan struct{}, sink LogLineSink) error {
+	closeOnce := sync.Once{}
+	closeStream := func() { closeOnce.Do(func() { stream.Close() }) }
+	defer closeStream()
+
+	finished := make(chan struct{})
+	defer close(finished)
+	go func() {
+		select {
+		case <-stopCh:
+			// Unblocks the pending read of a followed stream.
+			closeStream()
+		case <-finished:
+		}
+	}()
+
+	reader := bufio.NewReaderSize(stream, maxLogLineLength)
+	for {
+		line, err := readLogLine(reader)
+		if len(line) > 0 {
+			if sinkErr := sink(line); sinkErr != nil {
+				return sinkErr
+			}
+		}
+
+		if err != nil {
+			select {
+			case <-stopCh:
+				return nil
+			default:
+			}
+
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+	}
+}
+
+// readLogLine reads a line without the trailing newline. Bytes over the size of the reader buffer are discarded.
+func readLogLine(reader *bufio.Reader) (string, error) {
+	data, err := reader.ReadSlice('\n')
+	line := string(bytes.TrimSuffix(data, []byte{'\n'}))
+	for err == bufio.ErrBufferFull {
+		_, err = reader.ReadSlice('\n')
+	}
+	return line, err
+}
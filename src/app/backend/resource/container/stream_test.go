@@ -0,0 +1,143 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"io"
+	"io/ioutil"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+func TestMapToStreamLogOptions(t *testing.T) {
+	since, tail, overLimit := int64(60), int64(10), lineReadLimit+1
+	cases := []struct {
+		options  *LogStreamOptions
+		expected *v1.PodLogOptions
+	}{
+		{
+			&LogStreamOptions{Follow: true},
+			&v1.PodLogOptions{Container: "app", Follow: true, TailLines: &lineReadLimit},
+		},
+		{
+			&LogStreamOptions{Previous: true, Timestamps: true, SinceSeconds: &since},
+			&v1.PodLogOptions{Container: "app", Previous: true, Timestamps: true, SinceSeconds: &since,
+				TailLines: &lineReadLimit},
+		},
+		{
+			&LogStreamOptions{Follow: true, TailLines: &tail},
+			&v1.PodLogOptions{Container: "app", Follow: true, TailLines: &tail},
+		},
+		{
+			&LogStreamOptions{TailLines: &overLimit},
+			&v1.PodLogOptions{Container: "app", TailLines: &lineReadLimit},
+		},
+	}
+
+	for _, c := range cases {
+		actual := mapToStreamLogOptions("app", c.options)
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("mapToStreamLogOptions(%#v) == %#v, expected %#v", c.options, actual, c.expected)
+		}
+	}
+}
+
+func TestLogStreamOptionsValidate(t *testing.T) {
+	zero, negative, positive := int64(0), int64(-1), int64(5)
+	cases := []struct {
+		options *LogStreamOptions
+		valid   bool
+	}{
+		{&LogStreamOptions{}, true},
+		{&LogStreamOptions{SinceSeconds: &positive, TailLines: &zero}, true},
+		{&LogStreamOptions{SinceSeconds: &zero}, false},
+		{&LogStreamOptions{TailLines: &negative}, false},
+	}
+
+	for _, c := range cases {
+		if err := c.options.Validate(); (err == nil) != c.valid {
+			t.Errorf("expected %#v to be valid: %t, got %v", c.options, c.valid, err)
+		}
+	}
+}
+
+func TestStreamLogs(t *testing.T) {
+	var lines []string
+	stream := ioutil.NopCloser(strings.NewReader("first\nsecond\nlast without newline"))
+	err := StreamLogs(stream, make(chan struct{}), func(line string) error {
+		lines = append(lines, line)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []string{"first", "second", "last without newline"}
+	if !reflect.DeepEqual(lines, expected) {
+		t.Errorf("expected lines %v, got %v", expected, lines)
+	}
+}
+
+func TestStreamLogsLongLine(t *testing.T) {
+	var lines []string
+	long := strings.Repeat("x", 3*maxLogLineLength+10)
+	stream := ioutil.NopCloser(strings.NewReader(long + "\nnext\n" + long))
+	err := StreamLogs(stream, make(chan struct{}), func(line string) error {
+		lines = append(lines, line)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	truncated := long[:maxLogLineLength]
+	expected := []string{truncated, "next", truncated}
+	if !reflect.DeepEqual(lines, expected) {
+		t.Errorf("expected %d lines truncated to %d bytes, got %d lines", len(expected), maxLogLineLength,
+			len(lines))
+	}
+}
+
+func TestStreamLogsStop(t *testing.T) {
+	reader, writer := io.Pipe()
+	stopCh := make(chan struct{})
+	received := make(chan string)
+	result := make(chan error)
+	go func() {
+		result <- StreamLogs(reader, stopCh, func(line string) error {
+			received <- line
+			return nil
+		})
+	}()
+
+	go writer.Write([]byte("followed\n"))
+	if line := <-received; line != "followed" {
+		t.Errorf("expected followed line, got %q", line)
+	}
+
+	close(stopCh)
+	select {
+	case err := <-result:
+		if err != nil {
+			t.Errorf("expected stopped stream to end without error, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("stream was not closed after stop")
+	}
+}
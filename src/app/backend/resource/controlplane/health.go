@@ -0,0 +1,166 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controlplane
+
+import (
+	"log"
+	"sort"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+)
+
+// healthPaths are health endpoints of the apiserver, that are checked. Etcd check is served under /healthz only.
+var healthPaths = []string{"/healthz/etcd", "/livez", "/readyz"}
+
+// controlPlaneSelector selects static pods of control plane components created by kubeadm.
+var controlPlaneSelector = labels.SelectorFromSet(labels.Set{"tier": "control-plane"})
+
+// ComponentStatus is a status of a control plane component reported by the apiserver, i.e. scheduler or etcd-0.
+type ComponentStatus struct {
+	Name    string `json:"name"`
+	Healthy bool   `json:"healthy"`
+	Message string `json:"message,omitempty"`
+}
+
+// HealthCheck is a result of a health endpoint of the apiserver.
+type HealthCheck struct {
+	Path    string `json:"path"`
+	Healthy bool   `json:"healthy"`
+	Message string `json:"message,omitempty"`
+}
+
+// Pod is a pod of a control plane component running in kube-system namespace.
+type Pod struct {
+	ObjectMeta   api.ObjectMeta `json:"objectMeta"`
+	Component    string         `json:"component"`
+	NodeName     string         `json:"nodeName"`
+	Phase        v1.PodPhase    `json:"phase"`
+	Ready        bool           `json:"ready"`
+	RestartCount int32          `json:"restartCount"`
+}
+
+// Health aggregates health of the control plane from all available sources.
+type Health struct {
+	// True when all components, health checks and pods are healthy.
+	Healthy bool `json:"healthy"`
+
+	Components   []ComponentStatus `json:"components"`
+	HealthChecks []HealthCheck     `json:"healthChecks"`
+	Pods         []Pod             `json:"pods"`
+
+	// False when control plane pods are not visible, i.e. on managed clusters, where control plane is not a part
+	// of the cluster.
+	PodsVisible bool `json:"podsVisible"`
+
+	// List of non-critical errors, that occurred during resource retrieval.
+	Errors []error `json:"errors"`
+}
+
+// GetHealth returns health of the control plane based on component statuses, health endpoints of the apiserver
+// and control plane pods in kube-system namespace. Sources, that are not accessible, are skipped.
+func GetHealth(client kubernetes.Interface) (*Health, error) {
+	log.Print("Getting health of the control plane")
+
+	result := &Health{
+		Healthy:      true,
+		Components:   make([]ComponentStatus, 0),
+		HealthChecks: make([]HealthCheck, 0),
+		Pods:         make([]Pod, 0),
+		Errors:       make([]error, 0),
+	}
+
+	statuses, err := client.CoreV1().ComponentStatuses().List(api.ListEverything)
+	result.Errors = errors.AppendOptionalError(err, result.Errors)
+	if err == nil {
+		for _, status := range statuses.Items {
+			component := toComponentStatus(status)
+			result.Healthy = result.Healthy && component.Healthy
+			result.Components = append(result.Components, component)
+		}
+		sort.Slice(result.Components, func(i, j int) bool {
+			return result.Components[i].Name < result.Components[j].Name
+		})
+	}
+
+	if restClient := client.Discovery().RESTClient(); restClient != nil {
+		for _, path := range healthPaths {
+			body, err := restClient.Get().AbsPath(path).DoRaw()
+			check := HealthCheck{Path: path, Healthy: err == nil, Message: strings.TrimSpace(string(body))}
+			if err != nil && len(check.Message) == 0 {
+				check.Message = err.Error()
+			}
+			result.Healthy = result.Healthy && check.Healthy
+			result.HealthChecks = append(result.HealthChecks, check)
+		}
+	}
+
+	pods, err := client.CoreV1().Pods(metaV1.NamespaceSystem).List(metaV1.ListOptions{
+		LabelSelector: controlPlaneSelector.String(),
+	})
+	result.Errors = errors.AppendOptionalError(err, result.Errors)
+	if err == nil {
+		for _, pod := range pods.Items {
+			controlPlanePod := toPod(pod)
+			result.Healthy = result.Healthy && controlPlanePod.Ready
+			result.Pods = append(result.Pods, controlPlanePod)
+		}
+		sort.Slice(result.Pods, func(i, j int) bool { return result.Pods[i].ObjectMeta.Name < result.Pods[j].ObjectMeta.Name })
+		result.PodsVisible = len(result.Pods) > 0
+	}
+
+	return result, nil
+}
+
+func toComponentStatus(status v1.ComponentStatus) ComponentStatus {
+	result := ComponentStatus{Name: status.Name}
+	for _, condition := range status.Conditions {
+		if condition.Type != v1.ComponentHealthy {
+			continue
+		}
+
+		result.Healthy = condition.Status == v1.ConditionTrue
+		result.Message = condition.Message
+		if len(condition.Error) > 0 {
+			result.Message = condition.Error
+		}
+	}
+	return result
+}
+
+func toPod(pod v1.Pod) Pod {
+	result := Pod{
+		ObjectMeta: api.NewObjectMeta(pod.ObjectMeta),
+		Component:  pod.Labels["component"],
+		NodeName:   pod.Spec.NodeName,
+		Phase:      pod.Status.Phase,
+	}
+
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == v1.PodReady {
+			result.Ready = condition.Status == v1.ConditionTrue
+		}
+	}
+	for _, status := range pod.Status.ContainerStatuses {
+		result.RestartCount += status.RestartCount
+	}
+	return result
+}
@@ -0,0 +1,89 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controlplane
+
+import (
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestGetHealth(t *testing.T) {
+	client := fake.NewSimpleClientset(
+		&v1.ComponentStatus{
+			ObjectMeta: metaV1.ObjectMeta{Name: "scheduler"},
+			Conditions: []v1.ComponentCondition{{Type: v1.ComponentHealthy, Status: v1.ConditionTrue, Message: "ok"}},
+		},
+		&v1.ComponentStatus{
+			ObjectMeta: metaV1.ObjectMeta{Name: "etcd-0"},
+			Conditions: []v1.ComponentCondition{{Type: v1.ComponentHealthy, Status: v1.ConditionFalse,
+				Error: "connection refused"}},
+		},
+		&v1.Pod{
+			ObjectMeta: metaV1.ObjectMeta{Name: "kube-apiserver-master", Namespace: metaV1.NamespaceSystem,
+				Labels: map[string]string{"tier": "control-plane", "component": "kube-apiserver"}},
+			Spec: v1.PodSpec{NodeName: "master"},
+			Status: v1.PodStatus{
+				Phase:             v1.PodRunning,
+				Conditions:        []v1.PodCondition{{Type: v1.PodReady, Status: v1.ConditionTrue}},
+				ContainerStatuses: []v1.ContainerStatus{{RestartCount: 2}},
+			},
+		},
+		&v1.Pod{
+			ObjectMeta: metaV1.ObjectMeta{Name: "coredns", Namespace: metaV1.NamespaceSystem,
+				Labels: map[string]string{"k8s-app": "kube-dns"}},
+		},
+	)
+
+	health, err := GetHealth(client)
+	if err != nil {
+		t.Fatalf("GetHealth() returned error: %v", err)
+	}
+
+	expectedComponents := []ComponentStatus{
+		{Name: "etcd-0", Healthy: false, Message: "connection refused"},
+		{Name: "scheduler", Healthy: true, Message: "ok"},
+	}
+	if !reflect.DeepEqual(health.Components, expectedComponents) {
+		t.Errorf("GetHealth() components == %#v, expected %#v", health.Components, expectedComponents)
+	}
+
+	if !health.PodsVisible || len(health.Pods) != 1 {
+		t.Fatalf("GetHealth() pods == %#v, expected only kube-apiserver pod", health.Pods)
+	}
+
+	pod := health.Pods[0]
+	if pod.Component != "kube-apiserver" || pod.NodeName != "master" || !pod.Ready || pod.RestartCount != 2 {
+		t.Errorf("GetHealth() pod == %#v, expected ready kube-apiserver pod on master with 2 restarts", pod)
+	}
+
+	if health.Healthy {
+		t.Error("GetHealth() expected control plane to be unhealthy when etcd is unhealthy")
+	}
+}
+
+func TestGetHealthManagedCluster(t *testing.T) {
+	health, err := GetHealth(fake.NewSimpleClientset())
+	if err != nil {
+		t.Fatalf("GetHealth() returned error: %v", err)
+	}
+
+	if !health.Healthy || health.PodsVisible {
+		t.Errorf("GetHealth() == %#v, expected healthy control plane without visible pods", health)
+	}
+}
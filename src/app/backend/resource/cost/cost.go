@@ -0,0 +1,370 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cost
+
+import (
+	"log"
+	"sort"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	metricapi "github.com/kubernetes/dashboard/src/app/backend/integration/metric/api"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/node"
+)
+
+const (
+	bytesInGiB       = 1 << 30
+	millicoresInCore = 1000
+	defaultCurrency  = "USD"
+	replicaSetKind   = "ReplicaSet"
+	jobKind          = "Job"
+	deploymentKind   = "Deployment"
+	cronJobKind      = "CronJob"
+)
+
+// Pricing contains monthly prices of resources used to estimate cost.
+type Pricing struct {
+	// Price of one CPU core per month.
+	CPUCoreMonth float64 `json:"cpuCoreMonth"`
+
+	// Price of one GiB of memory per month.
+	MemoryGiBMonth float64 `json:"memoryGiBMonth"`
+
+	Currency string `json:"currency"`
+}
+
+// Estimate is an estimated monthly cost of a group of pods. Cost of every pod is based on its requests or on its
+// average usage when the pod uses more than it requests.
+type Estimate struct {
+	// Number of pods included in the estimate.
+	Pods int `json:"pods"`
+
+	// Sum of CPU requests in cores and memory requests in GiB.
+	CPURequests    float64 `json:"cpuRequests"`
+	MemoryRequests float64 `json:"memoryRequests"`
+
+	// Sum of average CPU usage in cores and memory usage in GiB. Set only when metrics are available.
+	CPUUsage    *float64 `json:"cpuUsage,omitempty"`
+	MemoryUsage *float64 `json:"memoryUsage,omitempty"`
+
+	// Monthly cost of requested resources.
+	RequestsCost float64 `json:"requestsCost"`
+
+	// Monthly cost of used resources. Set only when metrics are available.
+	UsageCost *float64 `json:"usageCost,omitempty"`
+
+	// Estimated monthly cost.
+	Cost float64 `json:"cost"`
+
+	// Sum of the greater of requests and usage for every pod.
+	cpuEffective    float64
+	memoryEffective float64
+}
+
+// WorkloadCost is an estimated cost of pods of a single workload, i.e. deployment.
+type WorkloadCost struct {
+	Kind     api.ResourceKind `json:"kind"`
+	Name     string           `json:"name"`
+	Estimate `json:",inline"`
+}
+
+// NamespaceCost is an estimated cost of pods of a single namespace.
+type NamespaceCost struct {
+	Name     string `json:"name"`
+	Estimate `json:",inline"`
+
+	// Costs of workloads of the namespace ordered from the most expensive one. Returned only for a single
+	// namespace.
+	Workloads []WorkloadCost `json:"workloads,omitempty"`
+}
+
+// CostReport contains estimated costs of namespaces ordered from the most expensive one.
+type CostReport struct {
+	Pricing Pricing `json:"pricing"`
+
+	// False when there is no metrics backend and estimates are based only on requests.
+	MetricsAvailable bool `json:"metricsAvailable"`
+
+	Total      Estimate        `json:"total"`
+	Namespaces []NamespaceCost `json:"namespaces"`
+
+	// List of non-critical errors, that occurred during resource retrieval.
+	Errors []error `json:"errors"`
+}
+
+// usage is an average usage of a single pod in cores and GiB.
+type usage struct {
+	cpu    *float64
+	memory *float64
+}
+
+// GetCostReport returns estimated monthly costs of all namespaces. When the namespace is not empty, only the
+// namespace is included together with costs of its workloads.
+func GetCostReport(client kubernetes.Interface, metricClient metricapi.MetricClient, pricing Pricing,
+	namespace string) (*CostReport, error) {
+	log.Printf("Getting cost report of %s namespace", namespace)
+
+	if len(pricing.Currency) == 0 {
+		pricing.Currency = defaultCurrency
+	}
+
+	pods, err := client.CoreV1().Pods(namespace).List(api.ListEverything)
+	nonCriticalErrors, criticalError := errors.HandleError(err)
+	if criticalError != nil {
+		return nil, criticalError
+	}
+
+	report := &CostReport{
+		Pricing:          pricing,
+		MetricsAvailable: metricClient != nil,
+		Namespaces:       make([]NamespaceCost, 0),
+		Errors:           nonCriticalErrors,
+	}
+	if pods == nil {
+		return report, nil
+	}
+
+	active := make([]v1.Pod, 0, len(pods.Items))
+	for _, pod := range pods.Items {
+		if pod.Status.Phase != v1.PodSucceeded && pod.Status.Phase != v1.PodFailed {
+			active = append(active, pod)
+		}
+	}
+
+	usages := getUsagePerPod(active, metricClient)
+
+	var owners map[string]ownerReference
+	if len(namespace) > 0 {
+		owners, report.Errors = getOwners(client, namespace, report.Errors)
+	}
+
+	namespaces := make(map[string]*NamespaceCost)
+	workloads := make(map[string]map[ownerReference]*WorkloadCost)
+	for i := range active {
+		pod := &active[i]
+		requests, _, err := node.PodRequestsAndLimits(pod)
+		if err != nil {
+			report.Errors = errors.AppendOptionalError(err, report.Errors)
+			continue
+		}
+		podUsage := usages[pod.UID]
+
+		ns, exists := namespaces[pod.Namespace]
+		if !exists {
+			ns = &NamespaceCost{Name: pod.Namespace}
+			namespaces[pod.Namespace] = ns
+			workloads[pod.Namespace] = make(map[ownerReference]*WorkloadCost)
+		}
+		ns.add(requests, podUsage)
+		report.Total.add(requests, podUsage)
+
+		if owners == nil {
+			continue
+		}
+
+		owner := getOwner(pod, owners)
+		workload, exists := workloads[pod.Namespace][owner]
+		if !exists {
+			workload = &WorkloadCost{Kind: api.ResourceKind(strings.ToLower(owner.kind)), Name: owner.name}
+			workloads[pod.Namespace][owner] = workload
+		}
+		workload.add(requests, podUsage)
+	}
+
+	report.Total.calculate(pricing)
+	for name, ns := range namespaces {
+		ns.calculate(pricing)
+		if owners != nil {
+			ns.Workloads = make([]WorkloadCost, 0, len(workloads[name]))
+			for _, workload := range workloads[name] {
+				workload.calculate(pricing)
+				ns.Workloads = append(ns.Workloads, *workload)
+			}
+			sort.SliceStable(ns.Workloads, func(i, j int) bool {
+				return byCost(ns.Workloads[i].Estimate, ns.Workloads[j].Estimate,
+					ns.Workloads[i].Name, ns.Workloads[j].Name)
+			})
+		}
+		report.Namespaces = append(report.Namespaces, *ns)
+	}
+
+	sort.SliceStable(report.Namespaces, func(i, j int) bool {
+		return byCost(report.Namespaces[i].Estimate, report.Namespaces[j].Estimate,
+			report.Namespaces[i].Name, report.Namespaces[j].Name)
+	})
+	return report, nil
+}
+
+// byCost orders estimates from the most expensive one. Estimates with equal cost are ordered by name.
+func byCost(a, b Estimate, aName, bName string) bool {
+	if a.Cost != b.Cost {
+		return a.Cost > b.Cost
+	}
+	return aName < bName
+}
+
+func (self *Estimate) add(requests v1.ResourceList, podUsage usage) {
+	self.Pods++
+
+	cpuRequest := float64(requests.Cpu().MilliValue()) / millicoresInCore
+	memoryRequest := float64(requests.Memory().Value()) / bytesInGiB
+	self.CPURequests += cpuRequest
+	self.MemoryRequests += memoryRequest
+
+	cpuEffective, memoryEffective := cpuRequest, memoryRequest
+	if podUsage.cpu != nil {
+		self.CPUUsage = addValue(self.CPUUsage, *podUsage.cpu)
+		if *podUsage.cpu > cpuEffective {
+			cpuEffective = *podUsage.cpu
+		}
+	}
+	if podUsage.memory != nil {
+		self.MemoryUsage = addValue(self.MemoryUsage, *podUsage.memory)
+		if *podUsage.memory > memoryEffective {
+			memoryEffective = *podUsage.memory
+		}
+	}
+
+	self.cpuEffective += cpuEffective
+	self.memoryEffective += memoryEffective
+}
+
+func (self *Estimate) calculate(pricing Pricing) {
+	self.RequestsCost = self.CPURequests*pricing.CPUCoreMonth + self.MemoryRequests*pricing.MemoryGiBMonth
+	self.Cost = self.cpuEffective*pricing.CPUCoreMonth + self.memoryEffective*pricing.MemoryGiBMonth
+
+	if self.CPUUsage != nil || self.MemoryUsage != nil {
+		usageCost := 0.0
+		if self.CPUUsage != nil {
+			usageCost += *self.CPUUsage * pricing.CPUCoreMonth
+		}
+		if self.MemoryUsage != nil {
+			usageCost += *self.MemoryUsage * pricing.MemoryGiBMonth
+		}
+		self.UsageCost = &usageCost
+	}
+}
+
+func addValue(sum *float64, value float64) *float64 {
+	if sum == nil {
+		return &value
+	}
+	result := *sum + value
+	return &result
+}
+
+// getUsagePerPod returns average CPU and memory usage of pods over the time range provided by the metric client.
+func getUsagePerPod(pods []v1.Pod, metricClient metricapi.MetricClient) map[types.UID]usage {
+	result := make(map[types.UID]usage)
+	if metricClient == nil || len(pods) == 0 {
+		return result
+	}
+
+	selectors := make([]metricapi.ResourceSelector, 0, len(pods))
+	for _, pod := range pods {
+		selectors = append(selectors, metricapi.ResourceSelector{
+			Namespace:    pod.Namespace,
+			ResourceType: api.ResourceKindPod,
+			ResourceName: pod.Name,
+			UID:          pod.UID,
+		})
+	}
+
+	metrics, err := metricClient.DownloadMetrics(selectors, []string{metricapi.CpuUsage, metricapi.MemoryUsage},
+		metricapi.NoResourceCache).GetMetrics()
+	if err != nil {
+		log.Printf("Skipping metrics because of error: %s\n", err)
+		return result
+	}
+
+	for _, metric := range metrics {
+		uids := metric.Label[api.ResourceKindPod]
+		if len(uids) != 1 || len(metric.MetricPoints) == 0 {
+			continue
+		}
+
+		var sum float64
+		for _, point := range metric.MetricPoints {
+			sum += float64(point.Value)
+		}
+		average := sum / float64(len(metric.MetricPoints))
+
+		podUsage := result[uids[0]]
+		switch metric.MetricName {
+		case metricapi.CpuUsage:
+			average /= millicoresInCore
+			podUsage.cpu = &average
+		case metricapi.MemoryUsage:
+			average /= bytesInGiB
+			podUsage.memory = &average
+		}
+		result[uids[0]] = podUsage
+	}
+
+	return result
+}
+
+// ownerReference identifies a workload that pods are grouped by.
+type ownerReference struct {
+	kind string
+	name string
+}
+
+// getOwners returns owners of replica sets and jobs of the namespace, so that pods can be grouped by deployments
+// and cron jobs instead.
+func getOwners(client kubernetes.Interface, namespace string, nonCriticalErrors []error) (
+	map[string]ownerReference, []error) {
+	owners := make(map[string]ownerReference)
+
+	replicaSets, err := client.AppsV1().ReplicaSets(namespace).List(api.ListEverything)
+	nonCriticalErrors = errors.AppendOptionalError(err, nonCriticalErrors)
+	if err == nil {
+		for _, rs := range replicaSets.Items {
+			if ref := metaV1.GetControllerOf(&rs); ref != nil && ref.Kind == deploymentKind {
+				owners[replicaSetKind+"/"+rs.Name] = ownerReference{kind: ref.Kind, name: ref.Name}
+			}
+		}
+	}
+
+	jobs, err := client.BatchV1().Jobs(namespace).List(api.ListEverything)
+	nonCriticalErrors = errors.AppendOptionalError(err, nonCriticalErrors)
+	if err == nil {
+		for _, job := range jobs.Items {
+			if ref := metaV1.GetControllerOf(&job); ref != nil && ref.Kind == cronJobKind {
+				owners[jobKind+"/"+job.Name] = ownerReference{kind: ref.Kind, name: ref.Name}
+			}
+		}
+	}
+
+	return owners, nonCriticalErrors
+}
+
+func getOwner(pod *v1.Pod, owners map[string]ownerReference) ownerReference {
+	ref := metaV1.GetControllerOf(pod)
+	if ref == nil {
+		return ownerReference{kind: string(api.ResourceKindPod), name: pod.Name}
+	}
+
+	if owner, exists := owners[ref.Kind+"/"+ref.Name]; exists {
+		return owner
+	}
+	return ownerReference{kind: ref.Kind, name: ref.Name}
+}
@@ -0,0 +1,154 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cost
+
+import (
+	"testing"
+
+	apps "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	integrationapi "github.com/kubernetes/dashboard/src/app/backend/integration/api"
+	metricapi "github.com/kubernetes/dashboard/src/app/backend/integration/metric/api"
+)
+
+var pricing = Pricing{CPUCoreMonth: 20, MemoryGiBMonth: 2, Currency: "EUR"}
+
+type fakeMetricClient struct {
+	metrics []metricapi.Metric
+}
+
+func (fakeMetricClient) ID() integrationapi.IntegrationID {
+	return "fake"
+}
+
+func (fakeMetricClient) HealthCheck() error {
+	return nil
+}
+
+func (fakeMetricClient) DownloadMetric(selectors []metricapi.ResourceSelector, metricName string,
+	cachedResources *metricapi.CachedResources) metricapi.MetricPromises {
+	return nil
+}
+
+func (self fakeMetricClient) DownloadMetrics(selectors []metricapi.ResourceSelector, metricNames []string,
+	cachedResources *metricapi.CachedResources) metricapi.MetricPromises {
+	promises := metricapi.NewMetricPromises(len(self.metrics))
+	promises.PutMetrics(self.metrics, nil)
+	return promises
+}
+
+func (fakeMetricClient) AggregateMetrics(metrics metricapi.MetricPromises, metricName string,
+	aggregations metricapi.AggregationModes) metricapi.MetricPromises {
+	return metrics
+}
+
+func newPod(namespace, name, cpu, memory string, owner *metaV1.OwnerReference) *v1.Pod {
+	pod := &v1.Pod{
+		ObjectMeta: metaV1.ObjectMeta{Name: name, Namespace: namespace, UID: types.UID(name)},
+		Spec: v1.PodSpec{Containers: []v1.Container{{
+			Name: "app",
+			Resources: v1.ResourceRequirements{Requests: v1.ResourceList{
+				v1.ResourceCPU:    resource.MustParse(cpu),
+				v1.ResourceMemory: resource.MustParse(memory),
+			}},
+		}}},
+		Status: v1.PodStatus{Phase: v1.PodRunning},
+	}
+	if owner != nil {
+		pod.OwnerReferences = []metaV1.OwnerReference{*owner}
+	}
+	return pod
+}
+
+func controller(kind, name string) *metaV1.OwnerReference {
+	isController := true
+	return &metaV1.OwnerReference{Kind: kind, Name: name, Controller: &isController}
+}
+
+func newMetric(name string, uid types.UID, values ...uint64) metricapi.Metric {
+	metric := metricapi.Metric{MetricName: name, Label: metricapi.Label{api.ResourceKindPod: []types.UID{uid}}}
+	for _, value := range values {
+		metric.MetricPoints = append(metric.MetricPoints, metricapi.MetricPoint{Value: value})
+	}
+	return metric
+}
+
+func TestGetCostReport(t *testing.T) {
+	rs := &apps.ReplicaSet{ObjectMeta: metaV1.ObjectMeta{Name: "web-5d4f8", Namespace: "shop",
+		OwnerReferences: []metaV1.OwnerReference{*controller("Deployment", "web")}}}
+	completed := newPod("shop", "migrate", "4", "4Gi", nil)
+	completed.Status.Phase = v1.PodSucceeded
+
+	client := fake.NewSimpleClientset(rs, completed,
+		newPod("shop", "web-5d4f8-a", "500m", "1Gi", controller("ReplicaSet", "web-5d4f8")),
+		newPod("shop", "web-5d4f8-b", "500m", "1Gi", controller("ReplicaSet", "web-5d4f8")),
+		newPod("shop", "db-0", "1", "2Gi", controller("StatefulSet", "db")),
+		newPod("tools", "debug", "100m", "128Mi", nil),
+	)
+	metricClient := fakeMetricClient{metrics: []metricapi.Metric{
+		// Pod web-5d4f8-a uses more CPU than it requests, on average 1.5 cores.
+		newMetric(metricapi.CpuUsage, "web-5d4f8-a", 1000, 2000),
+		newMetric(metricapi.MemoryUsage, "web-5d4f8-a", 512<<20),
+	}}
+
+	report, err := GetCostReport(client, metricClient, pricing, "")
+	if err != nil {
+		t.Fatalf("GetCostReport() returned error: %s", err)
+	}
+
+	if !report.MetricsAvailable || len(report.Namespaces) != 2 || report.Namespaces[0].Name != "shop" {
+		t.Fatalf("Expected shop and tools namespaces ordered by cost, got %#v", report.Namespaces)
+	}
+
+	shop := report.Namespaces[0]
+	if shop.Pods != 3 || shop.CPURequests != 2 || shop.MemoryRequests != 4 || shop.Workloads != nil {
+		t.Errorf("Unexpected requests of shop namespace: %#v", shop)
+	}
+	// 2 cores and 4 GiB requested, web-5d4f8-a uses 1 more core than it requests.
+	if shop.RequestsCost != 48 || shop.Cost != 68 {
+		t.Errorf("Expected requests cost 48 and cost 68 of shop namespace, got %v and %v", shop.RequestsCost,
+			shop.Cost)
+	}
+	if shop.UsageCost == nil || *shop.UsageCost != 31 {
+		t.Errorf("Expected usage cost 31 of shop namespace, got %v", shop.UsageCost)
+	}
+	if report.Total.Pods != 4 || report.Pricing.Currency != "EUR" {
+		t.Errorf("Unexpected total of the report: %#v", report.Total)
+	}
+
+	report, err = GetCostReport(client, nil, pricing, "shop")
+	if err != nil {
+		t.Fatalf("GetCostReport() returned error: %s", err)
+	}
+
+	workloads := report.Namespaces[0].Workloads
+	if len(report.Namespaces) != 1 || len(workloads) != 2 {
+		t.Fatalf("Expected two workloads of shop namespace, got %#v", report.Namespaces)
+	}
+	// Equal costs are ordered by name.
+	if workloads[0].Kind != api.ResourceKindStatefulSet || workloads[0].Name != "db" || workloads[0].Cost != 24 {
+		t.Errorf("Expected stateful set db with cost 24, got %#v", workloads[0])
+	}
+	if workloads[1].Kind != api.ResourceKindDeployment || workloads[1].Name != "web" || workloads[1].Cost != 24 ||
+		workloads[1].Pods != 2 || workloads[1].UsageCost != nil {
+		t.Errorf("Expected deployment web with two pods and cost 24 without usage, got %#v", workloads[1])
+	}
+}
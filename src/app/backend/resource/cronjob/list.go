@@ -33,6 +33,9 @@ type CronJobList struct {
 	CumulativeMetrics []metricapi.Metric `json:"cumulativeMetrics"`
 	Items             []CronJob          `json:"items"`
 
+	// MetricsAvailable is false when there is no metrics backend and CumulativeMetrics is always empty.
+	MetricsAvailable bool `json:"metricsAvailable"`
+
 	// Basic information about resources status on the list.
 	Status common.ResourceStatus `json:"status"`
 
@@ -99,6 +102,7 @@ func toCronJobList(cronJobs []v1beta1.CronJob, nonCriticalErrors []error, dsQuer
 		list.Items = append(list.Items, toCronJob(&cronJob))
 	}
 
+	list.MetricsAvailable = metricClient != nil
 	cumulativeMetrics, err := metricPromises.GetMetrics()
 	if err != nil {
 		list.CumulativeMetrics = make([]metricapi.Metric, 0)
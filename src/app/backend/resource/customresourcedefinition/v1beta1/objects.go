@@ -64,6 +64,16 @@ func GetCustomResourceObjectList(client apiextensionsclientset.Interface, config
 	}
 	list.Errors = nonCriticalErrors
 
+	if customResourceDefinition.Spec.Scope == apiextensions.NamespaceScoped {
+		items := make([]types.CustomResourceObject, 0)
+		for _, item := range list.Items {
+			if namespace.Matches(item.ObjectMeta.Namespace) {
+				items = append(items, item)
+			}
+		}
+		list.Items = items
+	}
+
 	// Return only slice of data, pagination is done here.
 	crdObjectCells, filteredTotal := dataselect.GenericDataSelectWithFilter(toObjectCells(list.Items), dsQuery)
 	list.Items = fromObjectCells(crdObjectCells)
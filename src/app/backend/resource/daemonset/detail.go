@@ -17,8 +17,12 @@ package daemonset
 import (
 	"log"
 
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
 	metricapi "github.com/kubernetes/dashboard/src/app/backend/integration/metric/api"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/gatekeeper"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/verticalpodautoscaler"
+	api "k8s.io/api/core/v1"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8sClient "k8s.io/client-go/kubernetes"
@@ -31,6 +35,12 @@ type DaemonSetDetail struct {
 
 	LabelSelector *v1.LabelSelector `json:"labelSelector,omitempty"`
 
+	// Gatekeeper audit violations of the resource. Empty when Gatekeeper is not installed.
+	PolicyViolations []gatekeeper.Violation `json:"policyViolations"`
+
+	// Recommendations of the vertical pod autoscaler targeting the workload. Nil when there is none.
+	ResourceRecommendations *verticalpodautoscaler.Recommendations `json:"resourceRecommendations,omitempty"`
+
 	// List of non-critical errors, that occurred during resource retrieval.
 	Errors []error `json:"errors"`
 }
@@ -51,18 +61,22 @@ func GetDaemonSetDetail(client k8sClient.Interface, metricClient metricapi.Metri
 	}
 
 	eventList := <-channels.EventList.List
-	if err := <-channels.EventList.Error; err != nil {
-		return nil, err
+	err = <-channels.EventList.Error
+	nonCriticalErrors := errors.AppendOptionalError(err, make([]error, 0))
+	if err != nil {
+		eventList = &api.EventList{}
 	}
 
 	podList := <-channels.PodList.List
-	if err := <-channels.PodList.Error; err != nil {
-		return nil, err
+	err = <-channels.PodList.Error
+	nonCriticalErrors = errors.AppendOptionalError(err, nonCriticalErrors)
+	if err != nil {
+		podList = &api.PodList{}
 	}
 
 	return &DaemonSetDetail{
 		DaemonSet:     toDaemonSet(*daemonSet, podList.Items, eventList.Items),
 		LabelSelector: daemonSet.Spec.Selector,
-		Errors:        []error{},
+		Errors:        nonCriticalErrors,
 	}, nil
 }
@@ -33,6 +33,9 @@ type DaemonSetList struct {
 	CumulativeMetrics []metricapi.Metric    `json:"cumulativeMetrics"`
 	Status            common.ResourceStatus `json:"status"`
 
+	// MetricsAvailable is false when there is no metrics backend and CumulativeMetrics is always empty.
+	MetricsAvailable bool `json:"metricsAvailable"`
+
 	// List of non-critical errors, that occurred during resource retrieval.
 	Errors []error `json:"errors"`
 }
@@ -112,6 +115,7 @@ func toDaemonSetList(daemonSets []apps.DaemonSet, pods []v1.Pod, events []v1.Eve
 		daemonSetList.DaemonSets = append(daemonSetList.DaemonSets, toDaemonSet(daemonSet, pods, events))
 	}
 
+	daemonSetList.MetricsAvailable = metricClient != nil
 	cumulativeMetrics, err := metricPromises.GetMetrics()
 	daemonSetList.CumulativeMetrics = cumulativeMetrics
 	if err != nil {
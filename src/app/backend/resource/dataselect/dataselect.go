@@ -97,8 +97,10 @@ func (self DataSelector) Less(i, j int) bool {
 }
 
 // Sort sorts the data inside as instructed by DataSelectQuery and returns itself to allow method chaining.
+// Sort is stable, so items with equal sort properties, or all items when no sort is requested, keep the order
+// they were listed in.
 func (self *DataSelector) Sort() *DataSelector {
-	sort.Sort(*self)
+	sort.Stable(*self)
 	return self
 }
 
@@ -160,6 +162,12 @@ func (self *DataSelector) getMetrics(metricClient metricapi.MetricClient) (
 // GetMetrics downloads metrics for data cells currently present in self.GenericDataList as instructed
 // by MetricQuery and inserts resulting MetricPromises to self.MetricsPromises.
 func (self *DataSelector) GetMetrics(metricClient metricapi.MetricClient) *DataSelector {
+	// Lack of metrics backend is detected and reported by the metric manager, there is no need to repeat it on
+	// every request.
+	if metricClient == nil {
+		return self
+	}
+
 	metricPromisesList, err := self.getMetrics(metricClient)
 	if err != nil {
 		log.Print(err)
@@ -178,6 +186,10 @@ func (self *DataSelector) GetMetrics(metricClient metricapi.MetricClient) *DataS
 // GetCumulativeMetrics downloads and aggregates metrics for data cells currently present in self.GenericDataList as instructed
 // by MetricQuery and inserts resulting MetricPromises to self.CumulativeMetricsPromises.
 func (self *DataSelector) GetCumulativeMetrics(metricClient metricapi.MetricClient) *DataSelector {
+	if metricClient == nil {
+		return self
+	}
+
 	metricPromisesList, err := self.getMetrics(metricClient)
 	if err != nil {
 		log.Print(err)
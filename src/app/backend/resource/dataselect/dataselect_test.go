@@ -108,6 +108,11 @@ func TestSort(t *testing.T) {
 			NewSortQuery([]string{"a", "name", "d", "creationTimestamp"}),
 			[]int{10, 3, 2, 1, 5, 4, 6, 7, 8, 9},
 		},
+		{
+			"sort by property with equal values - items with equal values keep the original order",
+			NewSortQuery([]string{"d", "name"}),
+			[]int{9, 8, 7, 6, 4, 5, 1, 2, 3, 10},
+		},
 		{
 			"empty sort list - no sort",
 			NewSortQuery([]string{}),
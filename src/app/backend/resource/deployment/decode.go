@@ -0,0 +1,198 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deployment
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	k8sjson "k8s.io/apimachinery/pkg/runtime/serializer/json"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+)
+
+// strictDecoder decodes json case-sensitively and rejects unknown fields.
+var strictDecoder = k8sjson.StrictCaseSensitiveJsonIterator()
+
+// manifestObject is a single object of the uploaded content together with the resource it is created as.
+type manifestObject struct {
+	document manifestDocument
+	object   *unstructured.Unstructured
+	resource schema.GroupVersionResource
+
+	// namespaced is true when the resource is namespaced, i.e. it is not a Namespace or a ClusterRole.
+	namespaced bool
+}
+
+// String returns description of the object used in error messages, i.e. document 2 (Deployment "web", line 14).
+func (self manifestObject) String() string {
+	return fmt.Sprintf("document %d (%s %q, line %d)", self.document.index, self.object.GetKind(),
+		self.object.GetName(), self.document.startLine)
+}
+
+// decodeManifests strictly decodes all documents of the given yaml or json content. Duplicate keys are rejected
+// and objects of kinds built into Kubernetes are checked for unknown fields and fields of wrong type. Items of
+// lists are returned as separate objects. Problems of all documents are reported together.
+func decodeManifests(content string) ([]*manifestObject, error) {
+	result := make([]*manifestObject, 0)
+	problems := make([]string, 0)
+	for _, document := range splitManifestDocuments(content) {
+		objects, err := decodeManifestDocument(document)
+		if err != nil {
+			problems = append(problems, err.Error())
+			continue
+		}
+		result = append(result, objects...)
+	}
+
+	if len(problems) > 0 {
+		return nil, errors.NewBadRequest(strings.Join(problems, "\n"))
+	}
+
+	return result, nil
+}
+
+func decodeManifestDocument(document manifestDocument) ([]*manifestObject, error) {
+	data, err := yaml.YAMLToJSONStrict([]byte(strings.Join(document.lines, "\n")))
+	if err != nil {
+		return nil, fmt.Errorf("document %d (line %d): %s", document.index, document.startLine, err)
+	}
+
+	var obj interface{}
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return nil, fmt.Errorf("document %d (line %d): %s", document.index, document.startLine, err)
+	}
+
+	gvk, err := getManifestObjectKind(obj)
+	if err != nil {
+		return nil, fmt.Errorf("document %d (line %d): %s", document.index, document.startLine, err)
+	}
+
+	fields := obj.(map[string]interface{})
+	if gvk.Kind != "List" {
+		object := &manifestObject{document: document, object: &unstructured.Unstructured{Object: fields}}
+		return []*manifestObject{object}, checkUnknownFields(object, data, gvk)
+	}
+
+	items, _ := fields["items"].([]interface{})
+	result := make([]*manifestObject, 0, len(items))
+	for i, item := range items {
+		itemGVK, err := getManifestObjectKind(item)
+		if err != nil {
+			return nil, fmt.Errorf("document %d (line %d): items[%d]: %s", document.index,
+				document.fieldLine(fmt.Sprintf("items[%d]", i)), i, err)
+		}
+
+		itemData, err := json.Marshal(item)
+		if err != nil {
+			return nil, err
+		}
+
+		object := &manifestObject{document: document,
+			object: &unstructured.Unstructured{Object: item.(map[string]interface{})}}
+		if err := checkUnknownFields(object, itemData, itemGVK); err != nil {
+			return nil, err
+		}
+		result = append(result, object)
+	}
+
+	return result, nil
+}
+
+// checkUnknownFields decodes given data into the Go type of given kind and rejects unknown fields. Field names are
+// matched case-sensitively, the same as the apiserver does, so i.e. "Spec" is an unknown field. Kinds that are
+// not built into Kubernetes, i.e. custom resources, are not checked.
+func checkUnknownFields(object *manifestObject, data []byte, gvk schema.GroupVersionKind) error {
+	typed, err := scheme.Scheme.New(gvk)
+	if err != nil {
+		return nil
+	}
+
+	if err := strictDecoder.Unmarshal(data, typed); err != nil {
+		// Drop position of the error in the data and the Go types leading to the unknown field.
+		message := err.Error()
+		if i := strings.Index(message, ", error found in"); i >= 0 {
+			message = message[:i]
+		}
+		if i := strings.Index(message, "found unknown field"); i >= 0 {
+			message = message[i:]
+		}
+		return fmt.Errorf("%s: %s", object, message)
+	}
+
+	return nil
+}
+
+// resolveManifestResources finds resource of every object using discovery and rejects objects whose kind and
+// apiVersion are not served by the cluster. Problems of all objects are reported together.
+func resolveManifestResources(discoveryClient discovery.DiscoveryInterface, objects []*manifestObject) error {
+	resourceLists := make(map[string]*metaV1.APIResourceList)
+	problems := make([]string, 0)
+	for _, object := range objects {
+		apiVersion := object.object.GetAPIVersion()
+		resourceList, cached := resourceLists[apiVersion]
+		if !cached {
+			var err error
+			resourceList, err = discoveryClient.ServerResourcesForGroupVersion(apiVersion)
+			if err != nil && !k8serrors.IsNotFound(err) {
+				return err
+			}
+			resourceLists[apiVersion] = resourceList
+		}
+
+		if resourceList == nil {
+			problems = append(problems, fmt.Sprintf("%s: apiVersion %s is not served by the cluster", object,
+				apiVersion))
+			continue
+		}
+
+		resource := findServedResource(resourceList, object.object.GetKind())
+		if resource == nil {
+			problems = append(problems, fmt.Sprintf("%s: kind %s is not served by the cluster in apiVersion %s",
+				object, object.object.GetKind(), apiVersion))
+			continue
+		}
+
+		gv, _ := schema.ParseGroupVersion(apiVersion)
+		object.resource = gv.WithResource(resource.Name)
+		object.namespaced = resource.Namespaced
+	}
+
+	if len(problems) > 0 {
+		return errors.NewBadRequest(strings.Join(problems, "\n"))
+	}
+
+	return nil
+}
+
+// findServedResource returns resource of given kind. Subresources are skipped.
+func findServedResource(resourceList *metaV1.APIResourceList, kind string) *metaV1.APIResource {
+	for i := range resourceList.APIResources {
+		resource := &resourceList.APIResources[i]
+		if resource.Kind == kind && !strings.Contains(resource.Name, "/") {
+			return resource
+		}
+	}
+
+	return nil
+}
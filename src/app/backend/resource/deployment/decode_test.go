@@ -0,0 +1,168 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deployment
+
+import (
+	"strings"
+	"testing"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	clienttesting "k8s.io/client-go/testing"
+)
+
+// servedDiscovery returns not found error for group versions that are not served, the same as the apiserver does.
+type servedDiscovery struct {
+	*fakediscovery.FakeDiscovery
+}
+
+func (self servedDiscovery) ServerResourcesForGroupVersion(groupVersion string) (*metaV1.APIResourceList, error) {
+	for _, resourceList := range self.Resources {
+		if resourceList.GroupVersion == groupVersion {
+			return resourceList, nil
+		}
+	}
+	return nil, k8serrors.NewNotFound(schema.GroupResource{}, groupVersion)
+}
+
+func TestDecodeManifests(t *testing.T) {
+	cases := []struct {
+		content  string
+		objects  int
+		problems []string
+	}{
+		{
+			"apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: web\nspec:\n  replicas: 2\n",
+			1,
+			nil,
+		},
+		{
+			"apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: a\n---\napiVersion: apps/v1\nkind: Deployment\n" +
+				"metadata:\n  name: web\nspec:\n  replica: 2\n",
+			0,
+			[]string{`document 2 (Deployment "web", line 6): found unknown field: replica`},
+		},
+		{
+			"apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: web\nSpec:\n  replicas: 2\n",
+			0,
+			[]string{`document 1 (Deployment "web", line 1): found unknown field: Spec`},
+		},
+		{
+			`{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "a"}}` + "\n" +
+				`{"apiVersion": "v1", "kind": "Secret", "metadata": {"name": "b"}}{"apiVersion": "v1",` + "\n" +
+				`"kind": "Service", "metadata": {"name": "c"}}`,
+			3,
+			nil,
+		},
+		{
+			`{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "a"}}` + "\n" +
+				`{"apiVersion": "v1", "kind": "Secret", "metadata": {"name": "b", "Namespace": "c"}}`,
+			0,
+			[]string{`document 2 (Secret "b", line 2): found unknown field: Namespace`},
+		},
+		{
+			"{apiVersion: v1, kind: ConfigMap, metadata: {name: a}}\n",
+			1,
+			nil,
+		},
+		{
+			"apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: a\n  name: b\n",
+			0,
+			[]string{"document 1 (line 1):", `key "name" already set`},
+		},
+		{
+			"metadata:\n  name: a\n",
+			0,
+			[]string{"document 1 (line 1):"},
+		},
+		{
+			"apiVersion: example.com/v1\nkind: Widget\nmetadata:\n  name: a\nspec:\n  anything: 1\n",
+			1,
+			nil,
+		},
+		{
+			"apiVersion: v1\nkind: List\nitems:\n- apiVersion: v1\n  kind: ConfigMap\n  metadata:\n    name: a\n" +
+				"- apiVersion: v1\n  kind: Secret\n  metadata:\n    name: b\n",
+			2,
+			nil,
+		},
+	}
+
+	for _, c := range cases {
+		objects, err := decodeManifests(c.content)
+		if len(c.problems) == 0 {
+			if err != nil {
+				t.Errorf("decodeManifests(%q) returned unexpected error: %s", c.content, err)
+			} else if len(objects) != c.objects {
+				t.Errorf("decodeManifests(%q) returned %d objects, expected %d", c.content, len(objects), c.objects)
+			}
+			continue
+		}
+
+		if err == nil {
+			t.Errorf("decodeManifests(%q) expected error", c.content)
+			continue
+		}
+		for _, problem := range c.problems {
+			if !strings.Contains(err.Error(), problem) {
+				t.Errorf("decodeManifests(%q) error %q should contain %q", c.content, err, problem)
+			}
+		}
+	}
+}
+
+func TestResolveManifestResources(t *testing.T) {
+	discoveryClient := servedDiscovery{&fakediscovery.FakeDiscovery{Fake: &clienttesting.Fake{
+		Resources: []*metaV1.APIResourceList{{
+			GroupVersion: "apps/v1",
+			APIResources: []metaV1.APIResource{
+				{Name: "deployments/scale", Kind: "Scale"},
+				{Name: "deployments", Kind: "Deployment"},
+			},
+		}},
+	}}}
+
+	objects, err := decodeManifests("apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: web\n")
+	if err != nil {
+		t.Fatalf("decodeManifests() returned unexpected error: %s", err)
+	}
+	if err := resolveManifestResources(discoveryClient, objects); err != nil {
+		t.Fatalf("resolveManifestResources() returned unexpected error: %s", err)
+	}
+	expected := schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+	if objects[0].resource != expected {
+		t.Errorf("resolveManifestResources() resolved %v, expected %v", objects[0].resource, expected)
+	}
+
+	objects, err = decodeManifests("apiVersion: apps/v2\nkind: Deployment\nmetadata:\n  name: web\n---\n" +
+		"apiVersion: apps/v1\nkind: Widget\nmetadata:\n  name: w\n")
+	if err != nil {
+		t.Fatalf("decodeManifests() returned unexpected error: %s", err)
+	}
+	err = resolveManifestResources(discoveryClient, objects)
+	if !k8serrors.IsBadRequest(err) {
+		t.Fatalf("resolveManifestResources() expected bad request error, got %v", err)
+	}
+	for _, problem := range []string{
+		`document 1 (Deployment "web", line 1): apiVersion apps/v2 is not served by the cluster`,
+		`document 2 (Widget "w", line 6): kind Widget is not served by the cluster in apiVersion apps/v1`,
+	} {
+		if !strings.Contains(err.Error(), problem) {
+			t.Errorf("resolveManifestResources() error %q should contain %q", err, problem)
+		}
+	}
+}
@@ -16,7 +16,6 @@ package deployment
 
 import (
 	"fmt"
-	"io"
 	"log"
 	"strings"
 
@@ -25,11 +24,8 @@ import (
 	api "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
-	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/rand"
-	"k8s.io/apimachinery/pkg/util/yaml"
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/dynamic"
 	client "k8s.io/client-go/kubernetes"
@@ -59,6 +55,17 @@ type AppDeploymentSpec struct {
 	// specified here).
 	ContainerCommandArgs *string `json:"containerCommandArgs"`
 
+	// Command that is executed instead of container entrypoint, split into separate arguments. Takes
+	// precedence over ContainerCommand.
+	Command []string `json:"command"`
+
+	// Arguments for the container command or container entrypoint, split into separate arguments. Takes
+	// precedence over ContainerCommandArgs.
+	Args []string `json:"args"`
+
+	// Containers that are run to completion, one after another, before the app container is started.
+	InitContainers []InitContainer `json:"initContainers"`
+
 	// Number of replicas of the image to maintain.
 	Replicas int32 `json:"replicas"`
 
@@ -78,12 +85,21 @@ type AppDeploymentSpec struct {
 	// Target namespace of the application.
 	Namespace string `json:"namespace"`
 
+	// Whether to create the target namespace when it does not exist.
+	CreateNamespace bool `json:"createNamespace"`
+
 	// Optional memory requirement for the container.
 	MemoryRequirement *resource.Quantity `json:"memoryRequirement"`
 
 	// Optional CPU requirement for the container.
 	CpuRequirement *resource.Quantity `json:"cpuRequirement"`
 
+	// Optional memory limit for the container.
+	MemoryLimit *resource.Quantity `json:"memoryLimit"`
+
+	// Optional CPU limit for the container.
+	CpuLimit *resource.Quantity `json:"cpuLimit"`
+
 	// Labels that will be defined on Pods/RCs/Services
 	Labels []Label `json:"labels"`
 
@@ -102,7 +118,10 @@ type AppDeploymentFromFileSpec struct {
 	// File content
 	Content string `json:"content"`
 
-	// Whether validate content before creation or not
+	// HTTP(S) URL that the file content is fetched from. Only one of content and url can be set.
+	URL string `json:"url,omitempty"`
+
+	// Whether to validate content against the OpenAPI schema of the apiserver before creation or not
 	Validate bool `json:"validate"`
 }
 
@@ -116,6 +135,40 @@ type AppDeploymentFromFileResponse struct {
 
 	// Error after create resource
 	Error string `json:"error"`
+
+	// Results of all objects of the file in the order in which they were created.
+	Objects []ObjectDeployment `json:"objects"`
+}
+
+// ObjectDeployment is a deployment result of a single object of a file.
+type ObjectDeployment struct {
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
+
+	// Whether the object was created.
+	Success bool `json:"success"`
+
+	// Error after create resource
+	Error string `json:"error,omitempty"`
+}
+
+// InitContainer is a specification of an init container of an application deployment.
+type InitContainer struct {
+	// Name of the init container. Must be unique within the app.
+	Name string `json:"name"`
+
+	// Docker image path of the init container.
+	ContainerImage string `json:"containerImage"`
+
+	// Command that is executed instead of container entrypoint, if specified.
+	Command []string `json:"command"`
+
+	// Arguments for the container command or container entrypoint.
+	Args []string `json:"args"`
+
+	// List of user-defined environment variables.
+	Variables []EnvironmentVariable `json:"variables"`
 }
 
 // PortMapping is a specification of port mapping for an application deployment.
@@ -128,6 +181,10 @@ type PortMapping struct {
 
 	// IP protocol for the mapping, e.g., "TCP" or "UDP".
 	Protocol api.Protocol `json:"protocol"`
+
+	// Optional port exposed on every node of the cluster. Can be set only for external services and has to be
+	// in service node port range of the cluster. Allocated automatically when empty.
+	NodePort int32 `json:"nodePort,omitempty"`
 }
 
 // EnvironmentVariable represents a named variable accessible for containers.
@@ -135,8 +192,30 @@ type EnvironmentVariable struct {
 	// Name of the variable. Must be a C_IDENTIFIER.
 	Name string `json:"name"`
 
-	// Value of the variable, as defined in Kubernetes core API.
+	// Value of the variable, as defined in Kubernetes core API. Ignored when ValueFrom is specified.
 	Value string `json:"value"`
+
+	// Optional source of the variable value.
+	ValueFrom *EnvironmentVariableSource `json:"valueFrom,omitempty"`
+}
+
+// EnvironmentVariableSource represents a source of the environment variable value. Only one of its fields
+// may be set.
+type EnvironmentVariableSource struct {
+	// Selects a key of a secret in the app namespace.
+	SecretKeyRef *KeySelector `json:"secretKeyRef,omitempty"`
+
+	// Selects a key of a config map in the app namespace.
+	ConfigMapKeyRef *KeySelector `json:"configMapKeyRef,omitempty"`
+}
+
+// KeySelector selects a key of a secret or config map.
+type KeySelector struct {
+	// Name of the secret or config map.
+	Name string `json:"name"`
+
+	// Key to select.
+	Key string `json:"key"`
 }
 
 // Label is a structure representing label assignable to Pod/RC/Service
@@ -158,8 +237,32 @@ type Protocols struct {
 // client. App deployment consists of a deployment and an optional service. Both of them
 // share common labels.
 func DeployApp(spec *AppDeploymentSpec, client client.Interface) error {
+	_, err := createAppObjects(spec, client)
+	return err
+}
+
+// createAppObjects creates objects of the app and returns the deployment, if it has been created, so that
+// callers can remove it on failure without touching objects that existed before.
+func createAppObjects(spec *AppDeploymentSpec, client client.Interface) (*apps.Deployment, error) {
 	log.Printf("Deploying %s application into %s namespace", spec.Name, spec.Namespace)
 
+	deployment, service := generateAppObjects(spec)
+	created, err := client.AppsV1().Deployments(spec.Namespace).Create(deployment)
+	if err != nil {
+		return nil, err
+	}
+
+	if service != nil {
+		_, err = client.CoreV1().Services(spec.Namespace).Create(service)
+		return created, err
+	}
+
+	return created, nil
+}
+
+// generateAppObjects generates the deployment and the optional service of the app. Service is nil when there
+// are no port mappings.
+func generateAppObjects(spec *AppDeploymentSpec) (*apps.Deployment, *api.Service) {
 	annotations := map[string]string{}
 	if spec.Description != nil {
 		annotations[DescriptionAnnotationKey] = *spec.Description
@@ -189,6 +292,12 @@ func DeployApp(spec *AppDeploymentSpec, client client.Interface) error {
 	if spec.ContainerCommandArgs != nil {
 		containerSpec.Args = []string{*spec.ContainerCommandArgs}
 	}
+	if len(spec.Command) > 0 {
+		containerSpec.Command = spec.Command
+	}
+	if len(spec.Args) > 0 {
+		containerSpec.Args = spec.Args
+	}
 
 	if spec.CpuRequirement != nil {
 		containerSpec.Resources.Requests[api.ResourceCPU] = *spec.CpuRequirement
@@ -196,8 +305,18 @@ func DeployApp(spec *AppDeploymentSpec, client client.Interface) error {
 	if spec.MemoryRequirement != nil {
 		containerSpec.Resources.Requests[api.ResourceMemory] = *spec.MemoryRequirement
 	}
+	if spec.CpuLimit != nil || spec.MemoryLimit != nil {
+		containerSpec.Resources.Limits = make(map[api.ResourceName]resource.Quantity)
+	}
+	if spec.CpuLimit != nil {
+		containerSpec.Resources.Limits[api.ResourceCPU] = *spec.CpuLimit
+	}
+	if spec.MemoryLimit != nil {
+		containerSpec.Resources.Limits[api.ResourceMemory] = *spec.MemoryLimit
+	}
 	podSpec := api.PodSpec{
-		Containers: []api.Container{containerSpec},
+		InitContainers: convertInitContainersSpec(spec.InitContainers),
+		Containers:     []api.Container{containerSpec},
 	}
 	if spec.ImagePullSecret != nil {
 		podSpec.ImagePullSecrets = []api.LocalObjectReference{{Name: *spec.ImagePullSecret}}
@@ -218,45 +337,40 @@ func DeployApp(spec *AppDeploymentSpec, client client.Interface) error {
 			},
 		},
 	}
-	_, err := client.AppsV1().Deployments(spec.Namespace).Create(deployment)
 
-	if err != nil {
-		return err
+	if len(spec.PortMappings) == 0 {
+		return deployment, nil
 	}
 
-	if len(spec.PortMappings) > 0 {
-		service := &api.Service{
-			ObjectMeta: objectMeta,
-			Spec: api.ServiceSpec{
-				Selector: labels,
-			},
-		}
-
-		if spec.IsExternal {
-			service.Spec.Type = api.ServiceTypeLoadBalancer
-		} else {
-			service.Spec.Type = api.ServiceTypeClusterIP
-		}
+	service := &api.Service{
+		ObjectMeta: objectMeta,
+		Spec: api.ServiceSpec{
+			Selector: labels,
+		},
+	}
 
-		for _, portMapping := range spec.PortMappings {
-			servicePort :=
-				api.ServicePort{
-					Protocol: portMapping.Protocol,
-					Port:     portMapping.Port,
-					Name:     generatePortMappingName(portMapping),
-					TargetPort: intstr.IntOrString{
-						Type:   intstr.Int,
-						IntVal: portMapping.TargetPort,
-					},
-				}
-			service.Spec.Ports = append(service.Spec.Ports, servicePort)
-		}
+	if spec.IsExternal {
+		service.Spec.Type = api.ServiceTypeLoadBalancer
+	} else {
+		service.Spec.Type = api.ServiceTypeClusterIP
+	}
 
-		_, err = client.CoreV1().Services(spec.Namespace).Create(service)
-		return err
+	for _, portMapping := range spec.PortMappings {
+		servicePort :=
+			api.ServicePort{
+				Protocol: portMapping.Protocol,
+				Port:     portMapping.Port,
+				Name:     generatePortMappingName(portMapping),
+				NodePort: portMapping.NodePort,
+				TargetPort: intstr.IntOrString{
+					Type:   intstr.Int,
+					IntVal: portMapping.TargetPort,
+				},
+			}
+		service.Spec.Ports = append(service.Spec.Ports, servicePort)
 	}
 
-	return nil
+	return deployment, service
 }
 
 // GetAvailableProtocols returns list of available protocols. Currently it is TCP and UDP.
@@ -264,14 +378,49 @@ func GetAvailableProtocols() *Protocols {
 	return &Protocols{Protocols: []api.Protocol{api.ProtocolTCP, api.ProtocolUDP}}
 }
 
+func convertInitContainersSpec(initContainers []InitContainer) []api.Container {
+	var result []api.Container
+	for _, initContainer := range initContainers {
+		result = append(result, api.Container{
+			Name:    initContainer.Name,
+			Image:   initContainer.ContainerImage,
+			Command: initContainer.Command,
+			Args:    initContainer.Args,
+			Env:     convertEnvVarsSpec(initContainer.Variables),
+		})
+	}
+	return result
+}
+
 func convertEnvVarsSpec(variables []EnvironmentVariable) []api.EnvVar {
 	var result []api.EnvVar
 	for _, variable := range variables {
-		result = append(result, api.EnvVar{Name: variable.Name, Value: variable.Value})
+		result = append(result, convertEnvVarSpec(variable))
 	}
 	return result
 }
 
+func convertEnvVarSpec(variable EnvironmentVariable) api.EnvVar {
+	if variable.ValueFrom == nil {
+		return api.EnvVar{Name: variable.Name, Value: variable.Value}
+	}
+
+	source := &api.EnvVarSource{}
+	if ref := variable.ValueFrom.SecretKeyRef; ref != nil {
+		source.SecretKeyRef = &api.SecretKeySelector{
+			LocalObjectReference: api.LocalObjectReference{Name: ref.Name},
+			Key:                  ref.Key,
+		}
+	}
+	if ref := variable.ValueFrom.ConfigMapKeyRef; ref != nil {
+		source.ConfigMapKeyRef = &api.ConfigMapKeySelector{
+			LocalObjectReference: api.LocalObjectReference{Name: ref.Name},
+			Key:                  ref.Key,
+		}
+	}
+	return api.EnvVar{Name: variable.Name, ValueFrom: source}
+}
+
 func generatePortMappingName(portMapping PortMapping) string {
 	return generateName(fmt.Sprintf("%s-%d-%d-", strings.ToLower(string(portMapping.Protocol)),
 		portMapping.Port, portMapping.TargetPort))
@@ -298,64 +447,116 @@ func getLabelsMap(labels []Label) map[string]string {
 	return result
 }
 
-// DeployAppFromFile deploys an app based on the given yaml or json file.
-func DeployAppFromFile(cfg *rest.Config, spec *AppDeploymentFromFileSpec) (bool, error) {
-	reader := strings.NewReader(spec.Content)
+// NamespaceCheck returns error when objects must not be created in given namespace. Namespaces of objects are
+// known only once the manifests are decoded, so the check is applied to every object separately.
+type NamespaceCheck func(namespace string) error
+
+// DeployAppFromFile deploys an app based on the given yaml or json file. All documents are decoded and checked
+// against resources served by the cluster before any object is created, so that an invalid document does not
+// leave the app deployed partially. Failure of one object does not stop creation of the others. Error is
+// returned only when the file is invalid or none of its objects could be created. Optional check is applied
+// to the namespace of every object and to namespaces created by the file.
+func DeployAppFromFile(cfg *rest.Config, spec *AppDeploymentFromFileSpec,
+	check NamespaceCheck) (*AppDeploymentFromFileResponse, error) {
 	log.Printf("Namespace for deploy from file: %s\n", spec.Namespace)
-	d := yaml.NewYAMLOrJSONDecoder(reader, 4096)
-	for {
-		data := unstructured.Unstructured{}
-		if err := d.Decode(&data); err != nil {
-			if err == io.EOF {
-				return true, nil
-			}
-			return false, err
-		}
+	objects, err := decodeManifests(spec.Content)
+	if err != nil {
+		return nil, err
+	}
 
-		version := data.GetAPIVersion()
-		kind := data.GetKind()
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
 
-		gv, err := schema.ParseGroupVersion(version)
-		if err != nil {
-			gv = schema.GroupVersion{Version: version}
-		}
+	if err := resolveManifestResources(discoveryClient, objects); err != nil {
+		return nil, err
+	}
 
-		discoveryClient, err := discovery.NewDiscoveryClientForConfig(cfg)
-		if err != nil {
-			return false, err
-		}
+	dynamicClient, err := dynamic.NewForConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
 
-		apiResourceList, err := discoveryClient.ServerResourcesForGroupVersion(version)
-		if err != nil {
-			return false, err
-		}
-		apiResources := apiResourceList.APIResources
-		var resource *metaV1.APIResource
-		for _, apiResource := range apiResources {
-			if apiResource.Kind == kind && !strings.Contains(apiResource.Name, "/") {
-				resource = &apiResource
-				break
-			}
-		}
-		if resource == nil {
-			return false, fmt.Errorf("unknown resource kind: %s", kind)
-		}
+	response := &AppDeploymentFromFileResponse{Name: spec.Name, Content: spec.Content}
+	response.Objects, err = createManifestObjects(dynamicClient, objects, spec.Namespace, check)
+	if err != nil {
+		return nil, err
+	}
 
-		dynamicClient, err := dynamic.NewForConfig(cfg)
-		if err != nil {
-			return false, err
+	problems := make([]string, 0)
+	for _, object := range response.Objects {
+		if !object.Success {
+			problems = append(problems, object.Error)
 		}
+	}
+	response.Error = strings.Join(problems, "\n")
 
-		groupVersionResource := schema.GroupVersionResource{Group: gv.Group, Version: gv.Version, Resource: resource.Name}
+	return response, nil
+}
 
-		if strings.Compare(spec.Namespace, "_all") == 0 {
-			_, err = dynamicClient.Resource(groupVersionResource).Namespace(data.GetNamespace()).Create(&data, metaV1.CreateOptions{})
-		} else {
-			_, err = dynamicClient.Resource(groupVersionResource).Namespace(spec.Namespace).Create(&data, metaV1.CreateOptions{})
+// checkObjectNamespace applies given check to the namespace that object will be created in or, for namespace
+// objects, to the created namespace.
+func checkObjectNamespace(object *manifestObject, namespace string, check NamespaceCheck) error {
+	if check == nil {
+		return nil
+	}
+
+	if object.resource.Group == "" && object.resource.Resource == "namespaces" {
+		return check(object.object.GetName())
+	}
+
+	return check(namespace)
+}
+
+// createManifestObjects creates all given objects one after another and returns result of every object. Objects
+// of namespaced resources are created in the given namespace, or in their own one when it is '_all'. Error of the
+// first object is returned when none of the objects could be created.
+func createManifestObjects(dynamicClient dynamic.Interface, objects []*manifestObject, namespace string,
+	check NamespaceCheck) ([]ObjectDeployment, error) {
+	result := make([]ObjectDeployment, 0, len(objects))
+	created := 0
+	var firstErr error
+	for _, object := range objects {
+		objectNamespace := ""
+		if object.namespaced {
+			objectNamespace = namespace
+			if strings.Compare(namespace, "_all") == 0 {
+				objectNamespace = object.object.GetNamespace()
+			}
+			if len(objectNamespace) == 0 {
+				objectNamespace = api.NamespaceDefault
+			}
 		}
 
+		deployment := ObjectDeployment{
+			Kind:      object.object.GetKind(),
+			Name:      object.object.GetName(),
+			Namespace: objectNamespace,
+			Success:   true,
+		}
+		err := checkObjectNamespace(object, objectNamespace, check)
+		if err == nil {
+			_, err = dynamicClient.Resource(object.resource).Namespace(objectNamespace).Create(object.object,
+				metaV1.CreateOptions{})
+		}
 		if err != nil {
-			return false, errors.LocalizeError(err)
+			err = errors.LocalizeError(err)
+			if firstErr == nil {
+				firstErr = err
+			}
+			deployment.Success = false
+			deployment.Error = fmt.Sprintf("%s: %s", object, err.Error())
+		} else {
+			created++
 		}
+
+		result = append(result, deployment)
 	}
+
+	if firstErr != nil && created == 0 {
+		return nil, firstErr
+	}
+
+	return result, nil
 }
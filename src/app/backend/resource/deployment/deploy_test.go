@@ -23,8 +23,14 @@ import (
 	api "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	fakedynamic "k8s.io/client-go/dynamic/fake"
 	"k8s.io/client-go/kubernetes/fake"
 	core "k8s.io/client-go/testing"
+
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
 )
 
 func TestDeployApp(t *testing.T) {
@@ -115,11 +121,64 @@ func TestDeployAppContainerCommands(t *testing.T) {
 	}
 }
 
+func TestDeployWithCommandListAndInitContainers(t *testing.T) {
+	command := "foo-command"
+	spec := &AppDeploymentSpec{
+		Namespace:        "foo-namespace",
+		Name:             "foo-name",
+		ContainerCommand: &command,
+		Command:          []string{"sh", "-c"},
+		Args:             []string{"echo foo"},
+		InitContainers: []InitContainer{{
+			Name:           "init",
+			ContainerImage: "busybox",
+			Command:        []string{"sleep"},
+			Args:           []string{"5"},
+			Variables:      []EnvironmentVariable{{Name: "foo", Value: "bar"}},
+		}},
+	}
+	expectedInitContainers := []api.Container{{
+		Name:    "init",
+		Image:   "busybox",
+		Command: []string{"sleep"},
+		Args:    []string{"5"},
+		Env:     []api.EnvVar{{Name: "foo", Value: "bar"}},
+	}}
+	testClient := fake.NewSimpleClientset()
+
+	DeployApp(spec, testClient)
+
+	createAction := testClient.Actions()[0].(core.CreateActionImpl)
+
+	rc := createAction.GetObject().(*apps.Deployment)
+	container := rc.Spec.Template.Spec.Containers[0]
+	if !reflect.DeepEqual(container.Command, spec.Command) || !reflect.DeepEqual(container.Args, spec.Args) {
+		t.Errorf("Expected command and args to be %#v %#v but got %#v %#v",
+			spec.Command, spec.Args, container.Command, container.Args)
+	}
+
+	if !reflect.DeepEqual(rc.Spec.Template.Spec.InitContainers, expectedInitContainers) {
+		t.Errorf("Expected init containers to be %#v but got %#v",
+			expectedInitContainers, rc.Spec.Template.Spec.InitContainers)
+	}
+}
+
 func TestDeployShouldPopulateEnvVars(t *testing.T) {
 	spec := &AppDeploymentSpec{
 		Namespace: "foo-namespace",
 		Name:      "foo-name",
-		Variables: []EnvironmentVariable{{"foo", "bar"}},
+		Variables: []EnvironmentVariable{
+			{Name: "foo", Value: "bar"},
+			{Name: "pass", ValueFrom: &EnvironmentVariableSource{SecretKeyRef: &KeySelector{Name: "db", Key: "password"}}},
+			{Name: "mode", ValueFrom: &EnvironmentVariableSource{ConfigMapKeyRef: &KeySelector{Name: "app", Key: "mode"}}},
+		},
+	}
+	expected := []api.EnvVar{
+		{Name: "foo", Value: "bar"},
+		{Name: "pass", ValueFrom: &api.EnvVarSource{SecretKeyRef: &api.SecretKeySelector{
+			LocalObjectReference: api.LocalObjectReference{Name: "db"}, Key: "password"}}},
+		{Name: "mode", ValueFrom: &api.EnvVarSource{ConfigMapKeyRef: &api.ConfigMapKeySelector{
+			LocalObjectReference: api.LocalObjectReference{Name: "app"}, Key: "mode"}}},
 	}
 	testClient := fake.NewSimpleClientset()
 
@@ -129,9 +188,8 @@ func TestDeployShouldPopulateEnvVars(t *testing.T) {
 
 	rc := createAction.GetObject().(*apps.Deployment)
 	container := rc.Spec.Template.Spec.Containers[0]
-	if !reflect.DeepEqual(container.Env, []api.EnvVar{{Name: "foo", Value: "bar"}}) {
-		t.Errorf("Expected environment variables to be %#v but got %#v",
-			[]api.EnvVar{{Name: "foo", Value: "bar"}}, container.Env)
+	if !reflect.DeepEqual(container.Env, expected) {
+		t.Errorf("Expected environment variables to be %#v but got %#v", expected, container.Env)
 	}
 }
 
@@ -177,6 +235,40 @@ func TestDeployWithResourceRequirements(t *testing.T) {
 	}
 }
 
+func TestDeployWithResourceLimits(t *testing.T) {
+	cpuRequirement := resource.MustParse("100m")
+	cpuLimit := resource.MustParse("500m")
+	memoryLimit := resource.MustParse("256Mi")
+	spec := &AppDeploymentSpec{
+		Namespace:      "foo-namespace",
+		Name:           "foo-name",
+		CpuRequirement: &cpuRequirement,
+		CpuLimit:       &cpuLimit,
+		MemoryLimit:    &memoryLimit,
+	}
+	expectedResources := api.ResourceRequirements{
+		Requests: map[api.ResourceName]resource.Quantity{
+			api.ResourceCPU: cpuRequirement,
+		},
+		Limits: map[api.ResourceName]resource.Quantity{
+			api.ResourceMemory: memoryLimit,
+			api.ResourceCPU:    cpuLimit,
+		},
+	}
+	testClient := fake.NewSimpleClientset()
+
+	DeployApp(spec, testClient)
+
+	createAction := testClient.Actions()[0].(core.CreateActionImpl)
+
+	rc := createAction.GetObject().(*apps.Deployment)
+	container := rc.Spec.Template.Spec.Containers[0]
+	if !reflect.DeepEqual(container.Resources, expectedResources) {
+		t.Errorf("Expected resource requirements to be %#v but got %#v",
+			expectedResources, container.Resources)
+	}
+}
+
 func TestGetAvailableProtocols(t *testing.T) {
 	expected := &Protocols{Protocols: []api.Protocol{"TCP", "UDP"}}
 
@@ -186,3 +278,75 @@ func TestGetAvailableProtocols(t *testing.T) {
 			expected, actual)
 	}
 }
+
+func TestCreateManifestObjects(t *testing.T) {
+	discoveryClient := servedDiscovery{&fakediscovery.FakeDiscovery{Fake: &core.Fake{
+		Resources: []*metaV1.APIResourceList{{
+			GroupVersion: "v1",
+			APIResources: []metaV1.APIResource{
+				{Name: "namespaces", Kind: "Namespace"},
+				{Name: "configmaps", Kind: "ConfigMap", Namespaced: true},
+			},
+		}},
+	}}}
+
+	objects, err := decodeManifests("apiVersion: v1\nkind: Namespace\nmetadata:\n  name: other\n---\n" +
+		"apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: settings\n---\n" +
+		"apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: settings\n  namespace: other\n---\n" +
+		"apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: existing\n  namespace: other\n")
+	if err != nil {
+		t.Fatalf("decodeManifests() returned unexpected error: %s", err)
+	}
+	if err := resolveManifestResources(discoveryClient, objects); err != nil {
+		t.Fatalf("resolveManifestResources() returned unexpected error: %s", err)
+	}
+
+	dynamicClient := fakedynamic.NewSimpleDynamicClient(runtime.NewScheme(),
+		&unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata":   map[string]interface{}{"name": "existing", "namespace": "other"},
+		}})
+
+	actual, err := createManifestObjects(dynamicClient, objects, "_all", nil)
+	if err != nil {
+		t.Fatalf("createManifestObjects() returned unexpected error: %s", err)
+	}
+
+	expected := []ObjectDeployment{
+		{Kind: "Namespace", Name: "other", Success: true},
+		{Kind: "ConfigMap", Name: "settings", Namespace: "default", Success: true},
+		{Kind: "ConfigMap", Name: "settings", Namespace: "other", Success: true},
+		{Kind: "ConfigMap", Name: "existing", Namespace: "other"},
+	}
+	for i := range actual {
+		actual[i].Error = ""
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("createManifestObjects() == %#v, expected %#v", actual, expected)
+	}
+
+	_, err = createManifestObjects(dynamicClient, objects[3:], "_all", nil)
+	if err == nil {
+		t.Error("createManifestObjects() expected error when no object could be created")
+	}
+
+	denyOther := func(namespace string) error {
+		if namespace == "other" {
+			return errors.NewForbidden(errors.MsgNamespaceRestrictedError)
+		}
+		return nil
+	}
+	actual, err = createManifestObjects(fakedynamic.NewSimpleDynamicClient(runtime.NewScheme()), objects, "_all",
+		denyOther)
+	if err != nil {
+		t.Fatalf("createManifestObjects() returned unexpected error: %s", err)
+	}
+
+	for i, object := range actual {
+		if object.Success != (i == 1) {
+			t.Errorf("createManifestObjects() created %s %s/%s: %t, expected only objects outside of restricted "+
+				"namespace to be created", object.Kind, object.Namespace, object.Name, object.Success)
+		}
+	}
+}
@@ -19,7 +19,10 @@ import (
 
 	"github.com/kubernetes/dashboard/src/app/backend/errors"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/gatekeeper"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/verticalpodautoscaler"
 	apps "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	client "k8s.io/client-go/kubernetes"
@@ -74,6 +77,12 @@ type DeploymentDetail struct {
 	// Optional field that specifies the number of old Replica Sets to retain to allow rollback.
 	RevisionHistoryLimit *int32 `json:"revisionHistoryLimit"`
 
+	// Gatekeeper audit violations of the resource. Empty when Gatekeeper is not installed.
+	PolicyViolations []gatekeeper.Violation `json:"policyViolations"`
+
+	// Recommendations of the vertical pod autoscaler targeting the workload. Nil when there is none.
+	ResourceRecommendations *verticalpodautoscaler.Recommendations `json:"resourceRecommendations,omitempty"`
+
 	// List of non-critical errors, that occurred during resource retrieval.
 	Errors []error `json:"errors"`
 }
@@ -105,23 +114,23 @@ func GetDeploymentDetail(client client.Interface, namespace string, deploymentNa
 
 	rawRs := <-channels.ReplicaSetList.List
 	err = <-channels.ReplicaSetList.Error
-	nonCriticalErrors, criticalError := errors.HandleError(err)
-	if criticalError != nil {
-		return nil, criticalError
+	nonCriticalErrors := errors.AppendOptionalError(err, make([]error, 0))
+	if err != nil {
+		rawRs = &apps.ReplicaSetList{}
 	}
 
 	rawPods := <-channels.PodList.List
 	err = <-channels.PodList.Error
-	nonCriticalErrors, criticalError = errors.AppendError(err, nonCriticalErrors)
-	if criticalError != nil {
-		return nil, criticalError
+	nonCriticalErrors = errors.AppendOptionalError(err, nonCriticalErrors)
+	if err != nil {
+		rawPods = &v1.PodList{}
 	}
 
 	rawEvents := <-channels.EventList.List
 	err = <-channels.EventList.Error
-	nonCriticalErrors, criticalError = errors.AppendError(err, nonCriticalErrors)
-	if criticalError != nil {
-		return nil, criticalError
+	nonCriticalErrors = errors.AppendOptionalError(err, nonCriticalErrors)
+	if err != nil {
+		rawEvents = &v1.EventList{}
 	}
 
 	// Extra Info
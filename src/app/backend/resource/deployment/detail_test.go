@@ -15,6 +15,7 @@
 package deployment
 
 import (
+	"fmt"
 	"reflect"
 	"testing"
 
@@ -24,8 +25,10 @@ import (
 	apps "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/kubernetes/fake"
+	core "k8s.io/client-go/testing"
 )
 
 func createDeployment(name, namespace, podTemplateName string, replicas int32, podLabel,
@@ -167,3 +170,25 @@ func TestGetDeploymentDetail(t *testing.T) {
 		}
 	}
 }
+
+func TestGetDeploymentDetailWithFailingSubqueries(t *testing.T) {
+	deployment := createDeployment("dp-1", "ns-1", "pod-1", 4,
+		map[string]string{"track": "beta"}, map[string]string{"foo": "bar"})
+	fakeClient := fake.NewSimpleClientset(deployment)
+	fakeClient.PrependReactor("list", "events", func(action core.Action) (bool, runtime.Object, error) {
+		return true, &v1.EventList{}, fmt.Errorf("events are unavailable")
+	})
+
+	actual, err := GetDeploymentDetail(fakeClient, "ns-1", "dp-1")
+	if err != nil {
+		t.Fatalf("GetDeploymentDetail(client, namespace, name) returned unexpected error: %v", err)
+	}
+
+	if actual.ObjectMeta.Name != "dp-1" {
+		t.Errorf("Expected deployment dp-1 to be returned, got %s", actual.ObjectMeta.Name)
+	}
+
+	if len(actual.Errors) != 1 || actual.Errors[0].Error() != "events are unavailable" {
+		t.Errorf("Expected failing events to be reported as non-critical error, got %v", actual.Errors)
+	}
+}
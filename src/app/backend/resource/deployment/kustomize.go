@@ -0,0 +1,189 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deployment
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path"
+	"strings"
+
+	"k8s.io/cli-runtime/pkg/kustomize"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/kustomize/pkg/fs"
+
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+)
+
+const (
+	// kustomizationRoot is a directory of in-memory file system that uploaded archives are extracted to.
+	kustomizationRoot = "/kustomization"
+	// maxArchiveSize is a maximum total size in bytes of files extracted from uploaded archive.
+	maxArchiveSize = 10 << 20
+	// maxArchiveFiles is a maximum number of files extracted from uploaded archive.
+	maxArchiveFiles = 1000
+)
+
+// remoteKustomizationPrefixes lists prefixes of kustomization urls that are cloned over https. Other urls, i.e.
+// local paths or ssh urls, would be resolved with the file system and credentials of Dashboard.
+var remoteKustomizationPrefixes = []string{"https://", "git::https://", "github.com/"}
+
+// KustomizationSpec is a specification of kustomization that should be rendered and optionally deployed.
+// Exactly one of Archive and URL has to be set.
+type KustomizationSpec struct {
+	// Gzipped tar archive with the kustomization. Encoded with base64 in JSON.
+	Archive []byte `json:"archive,omitempty"`
+
+	// Git URL of the kustomization in the format supported by kustomize, i.e.
+	// github.com/kubernetes-sigs/kustomize//examples/helloWorld?ref=v1.0.6.
+	URL string `json:"url,omitempty"`
+
+	// Directory of the kustomization inside of the archive. Root of the archive is used when empty.
+	Path string `json:"path,omitempty"`
+
+	// Namespace that objects should be deployed in. Use '_all' to keep namespaces of rendered objects.
+	Namespace string `json:"namespace"`
+
+	// Whether validate content before creation or not
+	Validate bool `json:"validate"`
+}
+
+// KustomizationResponse is a result of rendering and deploying kustomization.
+type KustomizationResponse struct {
+	// Resolved resources as a multi-document YAML.
+	Content string `json:"content"`
+
+	// Error after create resource
+	Error string `json:"error"`
+}
+
+// RenderKustomization builds given kustomization and returns resolved resources as a multi-document YAML.
+func RenderKustomization(spec *KustomizationSpec) (string, error) {
+	var fSys fs.FileSystem
+	var root string
+
+	switch {
+	case len(spec.Archive) > 0 && len(spec.URL) > 0:
+		return "", errors.NewBadRequest("only one of kustomization archive and url can be set")
+	case len(spec.Archive) > 0:
+		fakeFS := fs.MakeFakeFS()
+		if err := extractArchive(fakeFS, spec.Archive); err != nil {
+			return "", errors.NewBadRequest("invalid kustomization archive: " + err.Error())
+		}
+		fSys = fakeFS
+		root = path.Join(kustomizationRoot, spec.Path)
+	case len(spec.URL) > 0:
+		if !isRemoteKustomization(spec.URL) {
+			return "", errors.NewBadRequest("kustomization url has to point to a git repository served over https")
+		}
+		// Remote kustomizations are cloned by kustomize to a temporary directory.
+		fSys = fs.MakeRealFS()
+		root = spec.URL
+	default:
+		return "", errors.NewBadRequest("kustomization archive or url is required")
+	}
+
+	out := &bytes.Buffer{}
+	if err := kustomize.RunKustomizeBuild(out, fSys, root); err != nil {
+		return "", err
+	}
+
+	return out.String(), nil
+}
+
+// DeployKustomization builds given kustomization and creates all of the resolved resources. Optional check is
+// applied to namespaces of all created objects.
+func DeployKustomization(cfg *rest.Config, spec *KustomizationSpec, check NamespaceCheck) (*KustomizationResponse,
+	error) {
+	content, err := RenderKustomization(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &KustomizationResponse{Content: content}
+	deployed, err := DeployAppFromFile(cfg, &AppDeploymentFromFileSpec{
+		Name:      "kustomization",
+		Namespace: spec.Namespace,
+		Content:   content,
+		Validate:  spec.Validate,
+	}, check)
+	if err != nil {
+		response.Error = err.Error()
+	} else {
+		response.Error = deployed.Error
+	}
+
+	return response, nil
+}
+
+// isRemoteKustomization returns true when given kustomization url points to a git repository served over https.
+func isRemoteKustomization(url string) bool {
+	for _, prefix := range remoteKustomizationPrefixes {
+		if strings.HasPrefix(url, prefix) && len(url) > len(prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// extractArchive extracts regular files of gzipped tar archive to the kustomization root of given file system.
+// Archives with more than maxArchiveFiles files or maxArchiveSize bytes of content are rejected.
+func extractArchive(fSys fs.FileSystem, archive []byte) error {
+	gzipReader, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		return err
+	}
+	defer gzipReader.Close()
+
+	tarReader := tar.NewReader(gzipReader)
+	files, remaining := 0, int64(maxArchiveSize)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		if files++; files > maxArchiveFiles {
+			return fmt.Errorf("archive has more than %d files", maxArchiveFiles)
+		}
+
+		// Cleaning the path as an absolute one makes sure that files cannot escape the kustomization root.
+		name := path.Join(kustomizationRoot, path.Clean("/"+strings.TrimPrefix(header.Name, "./")))
+		data, err := ioutil.ReadAll(io.LimitReader(tarReader, remaining+1))
+		if err != nil {
+			return err
+		}
+
+		if remaining -= int64(len(data)); remaining < 0 {
+			return fmt.Errorf("archive content is larger than %d bytes", maxArchiveSize)
+		}
+
+		if err := fSys.WriteFile(name, data); err != nil {
+			return err
+		}
+	}
+}
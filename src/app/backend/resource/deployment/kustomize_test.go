@@ -0,0 +1,110 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deployment
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"strings"
+	"testing"
+
+	"sigs.k8s.io/kustomize/pkg/fs"
+)
+
+func newKustomizationArchive(t *testing.T, files map[string]string) []byte {
+	buffer := &bytes.Buffer{}
+	gzipWriter := gzip.NewWriter(buffer)
+	tarWriter := tar.NewWriter(gzipWriter)
+	for name, content := range files {
+		header := &tar.Header{Name: name, Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg}
+		if err := tarWriter.WriteHeader(header); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tarWriter.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tarWriter.Close()
+	gzipWriter.Close()
+	return buffer.Bytes()
+}
+
+func TestRenderKustomization(t *testing.T) {
+	archive := newKustomizationArchive(t, map[string]string{
+		"./base/kustomization.yaml":    "resources:\n- configmap.yaml\n",
+		"./base/configmap.yaml":        "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: config\ndata:\n  key: value\n",
+		"./overlay/kustomization.yaml": "namePrefix: prod-\nbases:\n- ../base\n",
+	})
+
+	cases := []struct {
+		spec        *KustomizationSpec
+		expected    string
+		expectedErr bool
+	}{
+		{&KustomizationSpec{Archive: archive, Path: "overlay"}, "name: prod-config", false},
+		{&KustomizationSpec{Archive: archive, Path: "base"}, "name: config", false},
+		{&KustomizationSpec{}, "", true},
+		{&KustomizationSpec{Archive: archive, URL: "github.com/example/repo"}, "", true},
+		{&KustomizationSpec{Archive: []byte("not an archive")}, "", true},
+	}
+
+	for _, c := range cases {
+		actual, err := RenderKustomization(c.spec)
+		if (err != nil) != c.expectedErr {
+			t.Errorf("RenderKustomization(%s) returned error %v, expected error: %t", c.spec.Path, err, c.expectedErr)
+			continue
+		}
+
+		if !strings.Contains(actual, c.expected) {
+			t.Errorf("RenderKustomization(%s) == %q, expected it to contain %q", c.spec.Path, actual, c.expected)
+		}
+	}
+}
+
+func TestRenderKustomizationRejectsLocalURL(t *testing.T) {
+	for _, url := range []string{"/etc", "../kustomization", "file:///etc", "git@github.com:example/repo.git",
+		"ssh://git@example.com/repo", "http://example.com/repo"} {
+		if _, err := RenderKustomization(&KustomizationSpec{URL: url}); err == nil ||
+			!strings.Contains(err.Error(), "https") {
+			t.Errorf("RenderKustomization(%q) returned error %v, expected url to be rejected", url, err)
+		}
+	}
+}
+
+func TestExtractArchiveLimits(t *testing.T) {
+	files := map[string]string{}
+	for i := 0; i <= maxArchiveFiles; i++ {
+		files[fmt.Sprintf("file-%d.yaml", i)] = ""
+	}
+
+	cases := []struct {
+		files    map[string]string
+		expected string
+	}{
+		{map[string]string{"kustomization.yaml": "resources: []\n"}, ""},
+		{files, "files"},
+		{map[string]string{"big.yaml": strings.Repeat("a", maxArchiveSize+1)}, "bytes"},
+	}
+
+	for _, c := range cases {
+		err := extractArchive(fs.MakeFakeFS(), newKustomizationArchive(t, c.files))
+		if (err == nil) != (c.expected == "") || (err != nil && !strings.Contains(err.Error(), c.expected)) {
+			t.Errorf("extractArchive() returned error %v, expected %q", err, c.expected)
+		}
+	}
+}
@@ -33,6 +33,9 @@ type DeploymentList struct {
 	ListMeta          api.ListMeta       `json:"listMeta"`
 	CumulativeMetrics []metricapi.Metric `json:"cumulativeMetrics"`
 
+	// MetricsAvailable is false when there is no metrics backend and CumulativeMetrics is always empty.
+	MetricsAvailable bool `json:"metricsAvailable"`
+
 	// Basic information about resources status on the list.
 	Status common.ResourceStatus `json:"status"`
 
@@ -135,6 +138,7 @@ func toDeploymentList(deployments []apps.Deployment, pods []v1.Pod, events []v1.
 		deploymentList.Deployments = append(deploymentList.Deployments, toDeployment(&deployment, rs, pods, events))
 	}
 
+	deploymentList.MetricsAvailable = metricClient != nil
 	cumulativeMetrics, err := metricPromises.GetMetrics()
 	deploymentList.CumulativeMetrics = cumulativeMetrics
 	if err != nil {
@@ -0,0 +1,184 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deployment
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+)
+
+const (
+	// manifestFetchTimeout is a maximum time that fetching of a manifest from url can take.
+	manifestFetchTimeout = 30 * time.Second
+
+	// maxManifestSize is a maximum size in bytes of a manifest fetched from url.
+	maxManifestSize = 10 << 20
+
+	// maxManifestRedirects is a maximum number of redirects followed when a manifest is fetched.
+	maxManifestRedirects = 5
+)
+
+// internalNetworks are address ranges that manifests are never fetched from, so that URLs can not be used to
+// reach services of the cluster or its private network. Loopback, link-local and multicast addresses are
+// checked separately.
+var internalNetworks = parseCIDRs("10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16", "100.64.0.0/10", "fc00::/7")
+
+func parseCIDRs(cidrs ...string) []*net.IPNet {
+	result := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		result = append(result, network)
+	}
+	return result
+}
+
+// LoadManifestContent fetches content of the given spec from its url, if set. Name of the spec defaults to the
+// name of the fetched file. Manifests are fetched only over https from the allowed hosts. Loading from urls is
+// disabled when no host is allowed.
+func LoadManifestContent(spec *AppDeploymentFromFileSpec, allowedHosts []string) error {
+	return newManifestFetcher(allowedHosts).load(spec)
+}
+
+// manifestFetcher fetches manifests from allowed hosts and refuses to connect to internal addresses, even when
+// an allowed host resolves to them or redirects to them.
+type manifestFetcher struct {
+	client       *http.Client
+	allowedHosts []string
+}
+
+func newManifestFetcher(allowedHosts []string) *manifestFetcher {
+	dialer := &net.Dialer{Timeout: manifestFetchTimeout, Control: checkManifestAddress}
+	fetcher := &manifestFetcher{allowedHosts: allowedHosts}
+	fetcher.client = &http.Client{
+		Timeout: manifestFetchTimeout,
+		// Proxy is not used, as addresses of proxied connections could not be checked.
+		Transport:     &http.Transport{DialContext: dialer.DialContext, TLSHandshakeTimeout: 10 * time.Second},
+		CheckRedirect: fetcher.checkRedirect,
+	}
+	return fetcher
+}
+
+func (self *manifestFetcher) load(spec *AppDeploymentFromFileSpec) error {
+	if len(spec.URL) == 0 {
+		return nil
+	}
+
+	if len(spec.Content) > 0 {
+		return errors.NewBadRequest("only one of content and url can be set")
+	}
+
+	if len(self.allowedHosts) == 0 {
+		return errors.NewForbidden("deploying manifests from url is disabled")
+	}
+
+	manifestURL, err := url.Parse(spec.URL)
+	if err != nil {
+		return errors.NewBadRequest("manifest url has to be a valid https url")
+	}
+	if err := self.checkURL(manifestURL); err != nil {
+		return err
+	}
+
+	resp, err := self.client.Get(manifestURL.String())
+	if err != nil {
+		return errors.NewBadRequest(fmt.Sprintf("could not fetch manifest: %s", err.Error()))
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return errors.NewBadRequest(fmt.Sprintf("could not fetch manifest: %s returned %s", spec.URL, resp.Status))
+	}
+
+	data, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxManifestSize+1))
+	if err != nil {
+		return errors.NewBadRequest(fmt.Sprintf("could not fetch manifest: %s", err.Error()))
+	}
+
+	if len(data) > maxManifestSize {
+		return errors.NewBadRequest(fmt.Sprintf("manifest is larger than %d bytes", maxManifestSize))
+	}
+
+	spec.Content = string(data)
+	if len(spec.Name) == 0 {
+		spec.Name = path.Base(manifestURL.Path)
+	}
+
+	return nil
+}
+
+// checkURL returns an error unless the url is an https url of one of the allowed hosts.
+func (self *manifestFetcher) checkURL(manifestURL *url.URL) error {
+	if manifestURL.Scheme != "https" || len(manifestURL.Hostname()) == 0 {
+		return errors.NewBadRequest("manifest url has to be a valid https url")
+	}
+
+	for _, host := range self.allowedHosts {
+		if strings.EqualFold(host, manifestURL.Hostname()) {
+			return nil
+		}
+	}
+	return errors.NewForbidden(fmt.Sprintf("deploying manifests from %s is not allowed", manifestURL.Hostname()))
+}
+
+// checkRedirect applies the same checks to redirect targets as to the requested url.
+func (self *manifestFetcher) checkRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) >= maxManifestRedirects {
+		return fmt.Errorf("stopped after %d redirects", maxManifestRedirects)
+	}
+	return self.checkURL(req.URL)
+}
+
+// checkManifestAddress is called before a connection is made, after the host has been resolved, so that hosts
+// resolving to internal addresses are refused.
+func checkManifestAddress(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil || isInternalIP(ip) {
+		return fmt.Errorf("address %s is not allowed", host)
+	}
+	return nil
+}
+
+// isInternalIP returns true if the address is not publicly routable.
+func isInternalIP(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsUnspecified() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() {
+		return true
+	}
+
+	for _, network := range internalNetworks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,124 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deployment
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+func TestLoadManifestContent(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/manifests/app.yaml":
+			w.Write([]byte("apiVersion: v1\nkind: Namespace\nmetadata:\n  name: app\n"))
+		case "/redirect/insecure":
+			http.Redirect(w, r, "http://127.0.0.1/manifests/app.yaml", http.StatusFound)
+		case "/redirect/metadata":
+			http.Redirect(w, r, "https://169.254.169.254/latest/meta-data", http.StatusFound)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	// Test server listens on loopback, so it is reached without the address check of the fetcher.
+	fetcher := newManifestFetcher([]string{"127.0.0.1"})
+	fetcher.client.Transport = server.Client().Transport
+
+	spec := &AppDeploymentFromFileSpec{URL: server.URL + "/manifests/app.yaml"}
+	if err := fetcher.load(spec); err != nil {
+		t.Fatalf("load() returned unexpected error: %s", err)
+	}
+	if spec.Content != "apiVersion: v1\nkind: Namespace\nmetadata:\n  name: app\n" {
+		t.Errorf("load() loaded content %q", spec.Content)
+	}
+	if spec.Name != "app.yaml" {
+		t.Errorf("load() set name %q, expected app.yaml", spec.Name)
+	}
+
+	for _, spec := range []*AppDeploymentFromFileSpec{
+		{URL: server.URL + "/missing.yaml"},
+		{URL: server.URL + "/redirect/insecure"},
+		{URL: server.URL + "/redirect/metadata"},
+		{URL: "http://127.0.0.1/manifests/app.yaml"},
+		{URL: "file:///etc/passwd"},
+		{URL: server.URL + "/manifests/app.yaml", Content: "kind: Namespace"},
+	} {
+		if err := fetcher.load(spec); !k8serrors.IsBadRequest(err) {
+			t.Errorf("load(%s) expected bad request error, got %v", spec.URL, err)
+		}
+	}
+
+	for _, spec := range []*AppDeploymentFromFileSpec{
+		{URL: "https://kubernetes.default.svc/api"},
+		{URL: "https://169.254.169.254/latest/meta-data"},
+	} {
+		if err := fetcher.load(spec); !k8serrors.IsForbidden(err) {
+			t.Errorf("load(%s) expected forbidden error for host that is not allowed, got %v", spec.URL, err)
+		}
+	}
+}
+
+func TestLoadManifestContentDisabled(t *testing.T) {
+	spec := &AppDeploymentFromFileSpec{URL: "https://raw.githubusercontent.com/app/app.yaml"}
+	if err := LoadManifestContent(spec, nil); !k8serrors.IsForbidden(err) {
+		t.Errorf("LoadManifestContent() expected forbidden error without allowed hosts, got %v", err)
+	}
+
+	if err := LoadManifestContent(&AppDeploymentFromFileSpec{Content: "kind: Namespace"}, nil); err != nil {
+		t.Errorf("LoadManifestContent() of spec without url returned unexpected error: %s", err)
+	}
+}
+
+func TestLoadManifestContentInternalAddress(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("kind: Namespace"))
+	}))
+	defer server.Close()
+
+	spec := &AppDeploymentFromFileSpec{URL: server.URL + "/app.yaml"}
+	if err := LoadManifestContent(spec, []string{"127.0.0.1"}); !k8serrors.IsBadRequest(err) {
+		t.Errorf("LoadManifestContent() expected allowed host on loopback address to be refused, got %v", err)
+	}
+}
+
+func TestIsInternalIP(t *testing.T) {
+	cases := map[string]bool{
+		"127.0.0.1":       true,
+		"::1":             true,
+		"10.96.0.1":       true,
+		"172.20.0.10":     true,
+		"192.168.1.1":     true,
+		"169.254.169.254": true,
+		"100.100.100.200": true,
+		"fd00::10":        true,
+		"fe80::1":         true,
+		"0.0.0.0":         true,
+		"::ffff:10.0.0.1": true,
+		"140.82.112.3":    false,
+		"2606:4700::1":    false,
+	}
+
+	for address, expected := range cases {
+		if actual := isInternalIP(net.ParseIP(address)); actual != expected {
+			t.Errorf("isInternalIP(%s) == %t, expected %t", address, actual, expected)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deployment
+
+import (
+	"strings"
+
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kubernetes/dashboard/src/app/backend/podsecurity"
+)
+
+// AppDeploymentPreview contains manifests of the objects that would be created by the app deployment.
+type AppDeploymentPreview struct {
+	// Multi-document YAML with the deployment and the optional service.
+	Content string `json:"content"`
+
+	// Evaluation of the pod template against Pod Security Standards profiles.
+	Security podsecurity.Evaluation `json:"security"`
+}
+
+// PreviewApp generates manifests of the objects that DeployApp would create for the given spec, without
+// creating anything. Names of service ports contain a random suffix, which is generated again on deploy.
+func PreviewApp(spec *AppDeploymentSpec) (*AppDeploymentPreview, error) {
+	deployment, service := generateAppObjects(spec)
+	deployment.TypeMeta = metaV1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"}
+	deployment.Namespace = spec.Namespace
+
+	documents := make([]string, 0)
+	content, err := yaml.Marshal(deployment)
+	if err != nil {
+		return nil, err
+	}
+	documents = append(documents, string(content))
+
+	if service != nil {
+		service.TypeMeta = metaV1.TypeMeta{APIVersion: "v1", Kind: "Service"}
+		service.Namespace = spec.Namespace
+		content, err = yaml.Marshal(service)
+		if err != nil {
+			return nil, err
+		}
+		documents = append(documents, string(content))
+	}
+
+	return &AppDeploymentPreview{
+		Content: strings.Join(documents, "---\n"),
+		Security: podsecurity.Evaluate(deployment.Spec.Template.Annotations, &deployment.Spec.Template.Spec,
+			field.NewPath("spec", "template", "spec")),
+	}, nil
+}
@@ -0,0 +1,60 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deployment
+
+import (
+	"strings"
+	"testing"
+
+	api "k8s.io/api/core/v1"
+)
+
+func TestPreviewApp(t *testing.T) {
+	cases := []struct {
+		spec      *AppDeploymentSpec
+		documents int
+		expected  []string
+	}{
+		{
+			&AppDeploymentSpec{Name: "foo", Namespace: "bar", ContainerImage: "nginx", Replicas: 2},
+			1,
+			[]string{"kind: Deployment", "namespace: bar", "image: nginx", "replicas: 2"},
+		},
+		{
+			&AppDeploymentSpec{Name: "foo", Namespace: "bar", ContainerImage: "nginx", IsExternal: true,
+				PortMappings: []PortMapping{{Port: 80, TargetPort: 8080, Protocol: api.ProtocolTCP}}},
+			2,
+			[]string{"kind: Deployment", "kind: Service", "type: LoadBalancer", "targetPort: 8080"},
+		},
+	}
+
+	for _, c := range cases {
+		preview, err := PreviewApp(c.spec)
+		if err != nil {
+			t.Errorf("PreviewApp(%#v) returned unexpected error: %s", c.spec, err)
+			continue
+		}
+
+		if documents := len(strings.Split(preview.Content, "---\n")); documents != c.documents {
+			t.Errorf("Expected %d documents in preview, got %d: %s", c.documents, documents, preview.Content)
+		}
+
+		for _, expected := range c.expected {
+			if !strings.Contains(preview.Content, expected) {
+				t.Errorf("Expected preview to contain %q, got %s", expected, preview.Content)
+			}
+		}
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deployment
+
+import (
+	"log"
+
+	apps "k8s.io/api/apps/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	client "k8s.io/client-go/kubernetes"
+
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/secret"
+)
+
+// AppDeploymentWithPullSecretSpec is a specification for an app deployment from a private registry, for which
+// the image pull secret is created together with the app.
+type AppDeploymentWithPullSecretSpec struct {
+	// Specification of the app. Its image pull secret is overridden by the created secret.
+	Deployment AppDeploymentSpec `json:"deployment"`
+
+	// Registry credentials of the created secret. Name is generated from the app name when empty and the
+	// namespace is always the one of the app.
+	PullSecret secret.DockerConfigJSONSecretSpec `json:"pullSecret"`
+}
+
+// DeployAppWithPullSecret creates a dockerconfigjson secret from the given registry credentials and deploys
+// the app referencing it in image pull secrets. When any of the steps fails, objects created by this call are
+// removed, so either everything or nothing is created. Objects that existed before, i.e. a deployment with the
+// same name, are never removed.
+func DeployAppWithPullSecret(spec *AppDeploymentWithPullSecretSpec, client client.Interface) error {
+	appSpec := &spec.Deployment
+	secretSpec := spec.PullSecret
+	secretSpec.Namespace = appSpec.Namespace
+	if len(secretSpec.Name) == 0 {
+		secretSpec.Name = generateName(appSpec.Name + "-registry-")
+	}
+
+	log.Printf("Creating %s image pull secret for %s application", secretSpec.Name, appSpec.Name)
+	if _, err := secret.CreateSecret(client, &secretSpec); err != nil {
+		return err
+	}
+
+	appSpec.ImagePullSecret = &secretSpec.Name
+	deployment, err := createAppObjects(appSpec, client)
+	if err != nil {
+		rollbackDeployAppWithPullSecret(appSpec, deployment, secretSpec.Name, client)
+		return err
+	}
+
+	return nil
+}
+
+// rollbackDeployAppWithPullSecret removes the created secret and the deployment, if it has been created. UID of
+// the deployment is required to match, so that a deployment recreated by someone else in the meantime is kept.
+func rollbackDeployAppWithPullSecret(spec *AppDeploymentSpec, deployment *apps.Deployment, secretName string,
+	client client.Interface) {
+	log.Printf("Rolling back deployment of %s application", spec.Name)
+	if deployment != nil {
+		options := &metaV1.DeleteOptions{}
+		if len(deployment.UID) > 0 {
+			options.Preconditions = metaV1.NewUIDPreconditions(string(deployment.UID))
+		}
+		err := client.AppsV1().Deployments(spec.Namespace).Delete(deployment.Name, options)
+		if err != nil && !errors.IsNotFoundError(err) {
+			log.Printf("Failed to remove %s deployment: %s", deployment.Name, err.Error())
+		}
+	}
+
+	err := client.CoreV1().Secrets(spec.Namespace).Delete(secretName, &metaV1.DeleteOptions{})
+	if err != nil && !errors.IsNotFoundError(err) {
+		log.Printf("Failed to remove %s secret: %s", secretName, err.Error())
+	}
+}
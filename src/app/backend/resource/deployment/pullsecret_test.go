@@ -0,0 +1,110 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deployment
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	apps "k8s.io/api/apps/v1"
+	api "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	core "k8s.io/client-go/testing"
+
+	"github.com/kubernetes/dashboard/src/app/backend/resource/secret"
+)
+
+func TestDeployAppWithPullSecret(t *testing.T) {
+	cases := []struct {
+		failServices   bool
+		expectedErr    bool
+		expectedExists bool
+	}{
+		{false, false, true},
+		{true, true, false},
+	}
+
+	for _, c := range cases {
+		spec := &AppDeploymentWithPullSecretSpec{
+			Deployment: AppDeploymentSpec{Name: "app", Namespace: "foo", ContainerImage: "registry.example.com/app",
+				PortMappings: []PortMapping{{Port: 80, TargetPort: 80, Protocol: api.ProtocolTCP}}},
+			PullSecret: secret.DockerConfigJSONSecretSpec{Name: "creds", Namespace: "bar",
+				Registry: "registry.example.com", Username: "user", Password: "pass"},
+		}
+
+		testClient := fake.NewSimpleClientset()
+		if c.failServices {
+			testClient.PrependReactor("create", "services",
+				func(action core.Action) (bool, runtime.Object, error) {
+					return true, nil, fmt.Errorf("service creation failed")
+				})
+		}
+
+		err := DeployAppWithPullSecret(spec, testClient)
+		if (err != nil) != c.expectedErr {
+			t.Errorf("DeployAppWithPullSecret() returned error %v, expected error: %t", err, c.expectedErr)
+		}
+
+		created, err := testClient.CoreV1().Secrets("foo").Get("creds", metaV1.GetOptions{})
+		if (err == nil) != c.expectedExists {
+			t.Errorf("Expected secret existence to be %t, but got error %v", c.expectedExists, err)
+		}
+
+		_, err = testClient.AppsV1().Deployments("foo").Get("app", metaV1.GetOptions{})
+		if (err == nil) != c.expectedExists {
+			t.Errorf("Expected deployment existence to be %t, but got error %v", c.expectedExists, err)
+		}
+
+		if !c.expectedExists {
+			continue
+		}
+
+		if created.Type != api.SecretTypeDockerConfigJson ||
+			!strings.Contains(string(created.Data[api.DockerConfigJsonKey]), `"registry.example.com"`) {
+			t.Errorf("Unexpected secret created: %#v", created)
+		}
+
+		if spec.Deployment.ImagePullSecret == nil || *spec.Deployment.ImagePullSecret != "creds" {
+			t.Errorf("Expected deployment to reference creds image pull secret, got %v",
+				spec.Deployment.ImagePullSecret)
+		}
+	}
+}
+
+func TestDeployAppWithPullSecretExistingDeployment(t *testing.T) {
+	spec := &AppDeploymentWithPullSecretSpec{
+		Deployment: AppDeploymentSpec{Name: "app", Namespace: "foo", ContainerImage: "registry.example.com/app"},
+		PullSecret: secret.DockerConfigJSONSecretSpec{Name: "creds", Registry: "registry.example.com",
+			Username: "user", Password: "pass"},
+	}
+	existing := &apps.Deployment{ObjectMeta: metaV1.ObjectMeta{Name: "app", Namespace: "foo", UID: "existing"}}
+	testClient := fake.NewSimpleClientset(existing)
+
+	if err := DeployAppWithPullSecret(spec, testClient); err == nil {
+		t.Fatal("DeployAppWithPullSecret() expected to fail for a deployment that already exists")
+	}
+
+	if deployment, err := testClient.AppsV1().Deployments("foo").Get("app", metaV1.GetOptions{}); err != nil ||
+		deployment.UID != "existing" {
+		t.Errorf("Expected existing deployment to be kept, got %v, %v", deployment, err)
+	}
+
+	if _, err := testClient.CoreV1().Secrets("foo").Get("creds", metaV1.GetOptions{}); err == nil {
+		t.Error("Expected created secret to be removed")
+	}
+}
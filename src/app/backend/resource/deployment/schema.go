@@ -0,0 +1,476 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deployment
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/rest"
+	"k8s.io/kube-openapi/pkg/util/proto/validation"
+	"k8s.io/kubectl/pkg/util/openapi"
+)
+
+// schemaCacheTTL is how long a parsed OpenAPI schema is reused at most, even if discovery of the apiserver does
+// not change. Schemas of custom resources can change without any change of the served group versions.
+const schemaCacheTTL = 10 * time.Minute
+
+var (
+	documentSeparator = regexp.MustCompile(`^---(\s.*)?$`)
+	pathSegment       = regexp.MustCompile(`[^.\[\]]+|\[\d+\]`)
+
+	// schemas caches parsed OpenAPI schemas by apiserver host.
+	schemas = &schemaCache{entries: make(map[string]schemaCacheEntry)}
+)
+
+// schemaCacheEntry is a parsed OpenAPI schema of a single apiserver.
+type schemaCacheEntry struct {
+	// discoveryKey is a hash of the apiserver version and of the served group versions at the time the schema
+	// was downloaded.
+	discoveryKey string
+	resources    openapi.Resources
+	expires      time.Time
+}
+
+// schemaCache caches parsed OpenAPI schemas, so that the full schema is not downloaded and parsed on every
+// validation. Cached schema is dropped when discovery of the apiserver changes or when it expires.
+type schemaCache struct {
+	mu      sync.Mutex
+	entries map[string]schemaCacheEntry
+}
+
+func (self *schemaCache) get(host, discoveryKey string) openapi.Resources {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+	entry, ok := self.entries[host]
+	if !ok || entry.discoveryKey != discoveryKey || time.Now().After(entry.expires) {
+		delete(self.entries, host)
+		return nil
+	}
+	return entry.resources
+}
+
+func (self *schemaCache) set(host, discoveryKey string, resources openapi.Resources) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+	self.entries[host] = schemaCacheEntry{discoveryKey: discoveryKey, resources: resources,
+		expires: time.Now().Add(schemaCacheTTL)}
+}
+
+// ManifestFieldError describes a single field of the uploaded content that does not match the OpenAPI
+// schema published by the apiserver.
+type ManifestFieldError struct {
+	// Index of the document in the content, starting from 1.
+	Document int `json:"document"`
+
+	// Line of the field in the content, starting from 1. When the field itself can not be found, i.e. it is
+	// missing, line of its closest parent is used.
+	Line int `json:"line"`
+
+	// Kind of the invalid object.
+	Kind string `json:"kind"`
+
+	// JSON path of the field within the object, i.e. spec.template.spec.containers[0].image.
+	Field string `json:"field"`
+
+	// Human readable description of the error.
+	Detail string `json:"detail"`
+}
+
+// ManifestValidity describes validity of the uploaded content.
+type ManifestValidity struct {
+	// True when all of the documents match the schema.
+	Valid bool `json:"valid"`
+
+	// List of all invalid fields.
+	Errors []ManifestFieldError `json:"errors"`
+}
+
+// manifestDocument is a single document of the uploaded content.
+type manifestDocument struct {
+	// Index of the document, starting from 1.
+	index int
+
+	// Line of the content the document starts at, starting from 1.
+	startLine int
+
+	lines []string
+}
+
+// ValidateManifests validates all documents of the given yaml or json content against the OpenAPI schema of
+// the apiserver, i.e. reports unknown fields and fields of wrong type. Objects of kinds that do not have
+// published schema are not validated.
+func ValidateManifests(cfg *rest.Config, content string) (*ManifestValidity, error) {
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	resources, err := getOpenAPIResources(discoveryClient, cfg.Host)
+	if err != nil {
+		return nil, err
+	}
+
+	return validateManifests(resources, content), nil
+}
+
+// getOpenAPIResources returns parsed OpenAPI schema of the apiserver. Schema is downloaded only when it is not
+// cached yet, or when the apiserver version or served group versions changed since it was cached.
+func getOpenAPIResources(discoveryClient discovery.DiscoveryInterface, host string) (openapi.Resources, error) {
+	discoveryKey, err := getDiscoveryKey(discoveryClient)
+	if err != nil {
+		return nil, err
+	}
+
+	if resources := schemas.get(host, discoveryKey); resources != nil {
+		return resources, nil
+	}
+
+	document, err := discoveryClient.OpenAPISchema()
+	if err != nil {
+		return nil, err
+	}
+
+	resources, err := openapi.NewOpenAPIData(document)
+	if err != nil {
+		return nil, err
+	}
+
+	schemas.set(host, discoveryKey, resources)
+	return resources, nil
+}
+
+// getDiscoveryKey returns hash of the apiserver version and of all group versions served by the apiserver.
+func getDiscoveryKey(discoveryClient discovery.DiscoveryInterface) (string, error) {
+	version, err := discoveryClient.ServerVersion()
+	if err != nil {
+		return "", err
+	}
+
+	groups, err := discoveryClient.ServerGroups()
+	if err != nil {
+		return "", err
+	}
+
+	groupVersions := []string{version.GitVersion}
+	for _, group := range groups.Groups {
+		for _, groupVersion := range group.Versions {
+			groupVersions = append(groupVersions, groupVersion.GroupVersion)
+		}
+	}
+	sort.Strings(groupVersions[1:])
+
+	hash := sha256.Sum256([]byte(strings.Join(groupVersions, "\n")))
+	return hex.EncodeToString(hash[:]), nil
+}
+
+func validateManifests(resources openapi.Resources, content string) *ManifestValidity {
+	result := &ManifestValidity{Valid: true, Errors: make([]ManifestFieldError, 0)}
+	for _, document := range splitManifestDocuments(content) {
+		result.Errors = append(result.Errors, validateManifestDocument(resources, document)...)
+	}
+
+	result.Valid = len(result.Errors) == 0
+	return result
+}
+
+func validateManifestDocument(resources openapi.Resources, document manifestDocument) []ManifestFieldError {
+	documentError := func(kind string, err error) []ManifestFieldError {
+		return []ManifestFieldError{{Document: document.index, Line: document.startLine, Kind: kind,
+			Detail: err.Error()}}
+	}
+
+	data, err := yaml.ToJSON([]byte(strings.Join(document.lines, "\n")))
+	if err != nil {
+		return documentError("", err)
+	}
+
+	var obj interface{}
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return documentError("", err)
+	}
+
+	gvk, err := getManifestObjectKind(obj)
+	if err != nil {
+		return documentError("", err)
+	}
+
+	if gvk.Kind != "List" {
+		return validateManifestObject(resources, document, obj, gvk, "")
+	}
+
+	items, _ := obj.(map[string]interface{})["items"].([]interface{})
+	result := make([]ManifestFieldError, 0)
+	for i, item := range items {
+		prefix := fmt.Sprintf("items[%d]", i)
+		itemGVK, err := getManifestObjectKind(item)
+		if err != nil {
+			result = append(result, ManifestFieldError{Document: document.index,
+				Line: document.fieldLine(prefix), Field: prefix, Detail: err.Error()})
+			continue
+		}
+		result = append(result, validateManifestObject(resources, document, item, itemGVK, prefix)...)
+	}
+	return result
+}
+
+// validateManifestObject validates single object of the document. Prefix is the path of the object within the
+// document, it is empty unless the object is an item of a list.
+func validateManifestObject(resources openapi.Resources, document manifestDocument, obj interface{},
+	gvk schema.GroupVersionKind, prefix string) []ManifestFieldError {
+	resource := resources.LookupResource(gvk)
+	if resource == nil {
+		return nil
+	}
+
+	result := make([]ManifestFieldError, 0)
+	for _, err := range validation.ValidateModel(obj, resource, gvk.Kind) {
+		field, detail := gvk.Kind, err.Error()
+		if validationErr, ok := err.(validation.ValidationError); ok {
+			field, detail = validationErr.Path, validationErr.Err.Error()
+			switch e := validationErr.Err.(type) {
+			case validation.UnknownFieldError:
+				field += "." + e.Field
+			case validation.MissingRequiredFieldError:
+				field += "." + e.Field
+			case validation.InvalidObjectTypeError:
+				field = e.Path
+			}
+		}
+
+		// Paths of the validation errors start with the kind of the validated object.
+		field = strings.TrimPrefix(strings.TrimPrefix(field, gvk.Kind), ".")
+		if len(prefix) > 0 {
+			field = strings.TrimSuffix(prefix+"."+field, ".")
+		}
+
+		result = append(result, ManifestFieldError{Document: document.index, Line: document.fieldLine(field),
+			Kind: gvk.Kind, Field: field, Detail: detail})
+	}
+	return result
+}
+
+func getManifestObjectKind(obj interface{}) (schema.GroupVersionKind, error) {
+	fields, ok := obj.(map[string]interface{})
+	if !ok {
+		return schema.GroupVersionKind{}, fmt.Errorf("document has to be an object")
+	}
+
+	apiVersion, ok := fields["apiVersion"].(string)
+	if !ok || len(apiVersion) == 0 {
+		return schema.GroupVersionKind{}, fmt.Errorf("apiVersion has to be set")
+	}
+
+	kind, ok := fields["kind"].(string)
+	if !ok || len(kind) == 0 {
+		return schema.GroupVersionKind{}, fmt.Errorf("kind has to be set")
+	}
+
+	gv, err := schema.ParseGroupVersion(apiVersion)
+	if err != nil {
+		return schema.GroupVersionKind{}, err
+	}
+	return gv.WithKind(kind), nil
+}
+
+// splitManifestDocuments splits yaml or json content into documents. Documents that contain only comments or
+// whitespace are skipped the same way they are skipped during deploy. Json objects that follow each other
+// without "---" are split into separate documents.
+func splitManifestDocuments(content string) []manifestDocument {
+	result := make([]manifestDocument, 0)
+	current := manifestDocument{startLine: 1}
+	add := func() {
+		if !isEmptyManifestDocument(current.lines) {
+			current.index = len(result) + 1
+			result = append(result, current)
+		}
+	}
+
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	scanner.Buffer(make([]byte, 0, 64*1024), len(content)+1)
+	for line := 1; scanner.Scan(); line++ {
+		if documentSeparator.MatchString(scanner.Text()) {
+			add()
+			current = manifestDocument{startLine: line + 1}
+			continue
+		}
+		current.lines = append(current.lines, scanner.Text())
+	}
+	add()
+
+	return splitJSONStreams(result)
+}
+
+// splitJSONStreams splits documents that are streams of json objects, i.e. objects not separated by "---", so
+// that every object is a separate document. Documents that are not valid json streams are kept as they are.
+func splitJSONStreams(documents []manifestDocument) []manifestDocument {
+	result := make([]manifestDocument, 0, len(documents))
+	for _, document := range documents {
+		for _, part := range splitJSONStream(document) {
+			part.index = len(result) + 1
+			result = append(result, part)
+		}
+	}
+	return result
+}
+
+func splitJSONStream(document manifestDocument) []manifestDocument {
+	content := strings.Join(document.lines, "\n")
+	if !strings.HasPrefix(strings.TrimSpace(content), "{") {
+		return []manifestDocument{document}
+	}
+
+	result := make([]manifestDocument, 0)
+	decoder := json.NewDecoder(strings.NewReader(content))
+	for decoder.More() {
+		start := int(decoder.InputOffset())
+		var value json.RawMessage
+		if err := decoder.Decode(&value); err != nil {
+			return []manifestDocument{document}
+		}
+
+		// Offset before decoding points at the end of the previous value, skip whitespace in between.
+		start += len(content[start:]) - len(strings.TrimLeft(content[start:], " \t\r\n"))
+		end := int(decoder.InputOffset())
+		result = append(result, manifestDocument{
+			startLine: document.startLine + strings.Count(content[:start], "\n"),
+			lines:     strings.Split(content[start:end], "\n"),
+		})
+	}
+
+	if len(result) < 2 {
+		return []manifestDocument{document}
+	}
+	return result
+}
+
+func isEmptyManifestDocument(lines []string) bool {
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if len(trimmed) > 0 && !strings.HasPrefix(trimmed, "#") {
+			return false
+		}
+	}
+	return true
+}
+
+// fieldLine returns the line of the field with given JSON path, i.e. spec.containers[0].name. Fields are looked
+// up in block style yaml by their indentation. Line of the closest parent that could be found is returned
+// otherwise, which is the start of the document for flow style yaml and json.
+func (self manifestDocument) fieldLine(path string) int {
+	found, indent := -1, -1
+	for _, segment := range pathSegment.FindAllString(path, -1) {
+		if strings.HasPrefix(segment, "[") {
+			index, _ := strconv.Atoi(strings.Trim(segment, "[]"))
+			line, itemIndent := self.findItem(found+1, indent, index)
+			if line < 0 {
+				break
+			}
+			// The first key of the item is on the same line as the dash.
+			found, indent = line-1, itemIndent
+			continue
+		}
+
+		line, keyIndent := self.findKey(found+1, indent, segment)
+		if line < 0 {
+			break
+		}
+		found, indent = line, keyIndent
+	}
+
+	if found < 0 {
+		return self.startLine
+	}
+	return self.startLine + found
+}
+
+// findKey returns index and indentation of the line with given key, that is a direct child of the parent
+// with given indentation. Search stops at the end of the parent block, which is also the start of the next sequence item
+// when the parent is an item.
+func (self manifestDocument) findKey(from, parentIndent int, key string) (int, int) {
+	childIndent := -1
+	for i := from; i < len(self.lines); i++ {
+		indent, text := splitManifestLine(self.lines[i])
+		if len(text) == 0 || strings.HasPrefix(text, "#") {
+			continue
+		}
+		trimmed := strings.TrimLeft(self.lines[i], " ")
+		if indent <= parentIndent ||
+			(i > from && strings.HasPrefix(trimmed, "-") && len(self.lines[i])-len(trimmed) <= parentIndent) {
+			return -1, -1
+		}
+		if childIndent < 0 {
+			childIndent = indent
+		}
+		if indent == childIndent && (strings.HasPrefix(text, key+":") || strings.HasPrefix(text, `"`+key+`":`)) {
+			return i, indent
+		}
+	}
+	return -1, -1
+}
+
+// findItem returns index and indentation of the first key of n-th item of the sequence that starts after
+// given line. Items are allowed to have the same indentation as their parent key.
+func (self manifestDocument) findItem(from, parentIndent, n int) (int, int) {
+	count, dashIndent := 0, -1
+	for i := from; i < len(self.lines); i++ {
+		trimmed := strings.TrimLeft(self.lines[i], " ")
+		if len(trimmed) == 0 || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		indent := len(self.lines[i]) - len(trimmed)
+		if indent < parentIndent || (indent == parentIndent && !strings.HasPrefix(trimmed, "-")) {
+			return -1, -1
+		}
+		if !strings.HasPrefix(trimmed, "- ") && trimmed != "-" {
+			continue
+		}
+		if dashIndent < 0 {
+			dashIndent = indent
+		}
+		if indent != dashIndent {
+			continue
+		}
+		if count == n {
+			keyIndent, _ := splitManifestLine(self.lines[i])
+			return i, keyIndent - 1
+		}
+		count++
+	}
+	return -1, -1
+}
+
+// splitManifestLine returns indentation of the key on the given line and the rest of the line. Dashes of
+// sequence items count as indentation.
+func splitManifestLine(line string) (int, string) {
+	indent := 0
+	for indent < len(line) && (line[indent] == ' ' || (line[indent] == '-' &&
+		(indent+1 == len(line) || line[indent+1] == ' '))) {
+		indent++
+	}
+	return indent, line[indent:]
+}
@@ -0,0 +1,231 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deployment
+
+import (
+	"reflect"
+	"testing"
+
+	openapi_v2 "github.com/googleapis/gnostic/OpenAPIv2"
+	"github.com/googleapis/gnostic/compiler"
+	"gopkg.in/yaml.v2"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/version"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	core "k8s.io/client-go/testing"
+	"k8s.io/kubectl/pkg/util/openapi"
+)
+
+const testSwagger = `{
+  "swagger": "2.0",
+  "info": {"title": "Kubernetes", "version": "v1.17.0"},
+  "paths": {},
+  "definitions": {
+    "io.k8s.api.apps.v1.Deployment": {
+      "type": "object",
+      "properties": {
+        "apiVersion": {"type": "string"},
+        "kind": {"type": "string"},
+        "metadata": {"$ref": "#/definitions/io.k8s.apimachinery.pkg.apis.meta.v1.ObjectMeta"},
+        "spec": {"$ref": "#/definitions/io.k8s.api.apps.v1.DeploymentSpec"}
+      },
+      "x-kubernetes-group-version-kind": [{"group": "apps", "kind": "Deployment", "version": "v1"}]
+    },
+    "io.k8s.api.apps.v1.DeploymentSpec": {
+      "type": "object",
+      "properties": {
+        "replicas": {"type": "integer", "format": "int32"},
+        "template": {"$ref": "#/definitions/io.k8s.api.core.v1.PodTemplateSpec"}
+      }
+    },
+    "io.k8s.api.core.v1.PodTemplateSpec": {
+      "type": "object",
+      "properties": {
+        "spec": {"$ref": "#/definitions/io.k8s.api.core.v1.PodSpec"}
+      }
+    },
+    "io.k8s.api.core.v1.PodSpec": {
+      "type": "object",
+      "required": ["containers"],
+      "properties": {
+        "containers": {"type": "array", "items": {"$ref": "#/definitions/io.k8s.api.core.v1.Container"}}
+      }
+    },
+    "io.k8s.api.core.v1.Container": {
+      "type": "object",
+      "required": ["name"],
+      "properties": {
+        "name": {"type": "string"},
+        "image": {"type": "string"}
+      }
+    },
+    "io.k8s.apimachinery.pkg.apis.meta.v1.ObjectMeta": {
+      "type": "object",
+      "properties": {
+        "name": {"type": "string"}
+      }
+    }
+  }
+}`
+
+func getTestResources(t *testing.T) openapi.Resources {
+	var info yaml.MapSlice
+	if err := yaml.Unmarshal([]byte(testSwagger), &info); err != nil {
+		t.Fatalf("Cannot unmarshal test swagger: %s", err)
+	}
+
+	document, err := openapi_v2.NewDocument(info, compiler.NewContext("$root", nil))
+	if err != nil {
+		t.Fatalf("Cannot parse test swagger: %s", err)
+	}
+
+	resources, err := openapi.NewOpenAPIData(document)
+	if err != nil {
+		t.Fatalf("Cannot read test swagger: %s", err)
+	}
+	return resources
+}
+
+func TestValidateManifests(t *testing.T) {
+	cases := []struct {
+		content  string
+		expected []ManifestFieldError
+	}{
+		{
+			`apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+spec:
+  template:
+    spec:
+      containers:
+      - name: app
+        image: nginx`,
+			[]ManifestFieldError{},
+		},
+		{
+			`apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+spec:
+  replicas: two
+  template:
+    spec:
+      containers:
+      - name: first
+        image: nginx
+      - name: second
+        imagee: nginx
+---
+# Empty document.
+---
+apiVersion: v1
+kind: Unknown
+foo: bar
+---
+apiVersion: apps/v1
+kind: Deployment
+spec:
+  template:
+    spec: {}
+---
+kind: Deployment`,
+			[]ManifestFieldError{
+				{Document: 1, Line: 6, Kind: "Deployment", Field: "spec.replicas",
+					Detail: `invalid type for io.k8s.api.apps.v1.DeploymentSpec.replicas: got "string", expected "integer"`},
+				{Document: 1, Line: 13, Kind: "Deployment", Field: "spec.template.spec.containers[1].imagee",
+					Detail: `unknown field "imagee" in io.k8s.api.core.v1.Container`},
+				{Document: 3, Line: 25, Kind: "Deployment", Field: "spec.template.spec.containers",
+					Detail: `missing required field "containers" in io.k8s.api.core.v1.PodSpec`},
+				{Document: 4, Line: 27, Detail: "apiVersion has to be set"},
+			},
+		},
+		{
+			`apiVersion: v1
+kind: List
+items:
+- apiVersion: apps/v1
+  kind: Deployment
+  metadata:
+    nam: app`,
+			[]ManifestFieldError{
+				{Document: 1, Line: 7, Kind: "Deployment", Field: "items[0].metadata.nam",
+					Detail: `unknown field "nam" in io.k8s.apimachinery.pkg.apis.meta.v1.ObjectMeta`},
+			},
+		},
+		{
+			`{"apiVersion": "apps/v1", "kind": "Deployment", "spec": {"replicas": "two"}}`,
+			[]ManifestFieldError{
+				{Document: 1, Line: 1, Kind: "Deployment", Field: "spec.replicas",
+					Detail: `invalid type for io.k8s.api.apps.v1.DeploymentSpec.replicas: got "string", expected "integer"`},
+			},
+		},
+	}
+
+	resources := getTestResources(t)
+	for _, c := range cases {
+		actual := validateManifests(resources, c.content)
+		if !reflect.DeepEqual(actual.Errors, c.expected) || actual.Valid != (len(c.expected) == 0) {
+			t.Errorf("validateManifests(%q) == %#v, expected %#v", c.content, actual, c.expected)
+		}
+	}
+}
+
+// countingDiscovery counts downloads of the OpenAPI schema.
+type countingDiscovery struct {
+	*fakediscovery.FakeDiscovery
+	downloads int
+}
+
+func (self *countingDiscovery) OpenAPISchema() (*openapi_v2.Document, error) {
+	self.downloads++
+	return self.FakeDiscovery.OpenAPISchema()
+}
+
+func TestGetOpenAPIResources(t *testing.T) {
+	discoveryClient := &countingDiscovery{FakeDiscovery: &fakediscovery.FakeDiscovery{Fake: &core.Fake{
+		Resources: []*metaV1.APIResourceList{{GroupVersion: "apps/v1"}},
+	}, FakedServerVersion: &version.Info{GitVersion: "v1.17.0"}}}
+
+	cases := []struct {
+		info              string
+		change            func()
+		expectedDownloads int
+	}{
+		{"first validation", func() {}, 1},
+		{"unchanged discovery", func() {}, 1},
+		{"new group version", func() {
+			discoveryClient.Resources = append(discoveryClient.Resources,
+				&metaV1.APIResourceList{GroupVersion: "example.com/v1"})
+		}, 2},
+		{"upgraded apiserver", func() {
+			discoveryClient.FakedServerVersion = &version.Info{GitVersion: "v1.18.0"}
+		}, 3},
+		{"unchanged discovery after upgrade", func() {}, 3},
+	}
+
+	for _, c := range cases {
+		c.change()
+		if _, err := getOpenAPIResources(discoveryClient, "https://schema-test"); err != nil {
+			t.Fatalf("getOpenAPIResources() returned unexpected error on %s: %s", c.info, err)
+		}
+		if discoveryClient.downloads != c.expectedDownloads {
+			t.Errorf("getOpenAPIResources() downloaded schema %d times after %s, expected %d",
+				discoveryClient.downloads, c.info, c.expectedDownloads)
+		}
+	}
+}
@@ -0,0 +1,314 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+)
+
+// Source is the origin of the desired state of an object.
+type Source string
+
+// List of supported sources of the desired state.
+const (
+	// SourceLastApplied is the configuration stored by kubectl apply in the object annotation.
+	SourceLastApplied Source = "last-applied"
+	// SourceManifest is a manifest supplied by the user.
+	SourceManifest Source = "manifest"
+)
+
+// Operation describes what applying the desired state does with a field of the live object.
+type Operation string
+
+// List of operations of a change.
+const (
+	OperationAdd     Operation = "add"
+	OperationRemove  Operation = "remove"
+	OperationReplace Operation = "replace"
+)
+
+// Change is a single field, that differs between the live object and its desired state.
+type Change struct {
+	// Path of the field, i.e. spec.template.spec.containers[0].image.
+	Path      string    `json:"path"`
+	Operation Operation `json:"operation"`
+
+	// Live value of the field, not set when the field is added.
+	Live interface{} `json:"live,omitempty"`
+
+	// Desired value of the field, not set when the field is removed.
+	Desired interface{} `json:"desired,omitempty"`
+}
+
+// Diff is a difference between the live state of an object and its desired state. Only fields managed by the
+// desired state are compared, fields set by the server or controllers are ignored.
+type Diff struct {
+	Source Source `json:"source"`
+
+	// True when applying the desired state would not change the object.
+	Identical bool `json:"identical"`
+
+	Changes []Change `json:"changes"`
+
+	// Unified diff of YAML representations of the live object and its desired state.
+	Unified string `json:"unified"`
+}
+
+// GetDiff compares the live object with given manifest. When manifest is empty, the object is compared with
+// its last-applied-configuration annotation. Both objects are expected to be in JSON format.
+func GetDiff(live, manifest []byte) (*Diff, error) {
+	liveObject := make(map[string]interface{})
+	if err := json.Unmarshal(live, &liveObject); err != nil {
+		return nil, err
+	}
+
+	lastApplied, err := getLastApplied(liveObject)
+	if err != nil {
+		return nil, err
+	}
+
+	source, desired := SourceLastApplied, lastApplied
+	if len(manifest) > 0 {
+		source, desired = SourceManifest, make(map[string]interface{})
+		if err := json.Unmarshal(manifest, &desired); err != nil {
+			return nil, errors.NewBadRequest(fmt.Sprintf("invalid manifest: %s", err))
+		}
+		if name := getName(desired); name != "" && name != getName(liveObject) {
+			return nil, errors.NewBadRequest(fmt.Sprintf("manifest describes %s object, expected %s", name,
+				getName(liveObject)))
+		}
+
+		// Name and namespace default to those of the live object as the manifest is applied to it.
+		if metadata, ok := desired["metadata"].(map[string]interface{}); ok {
+			for _, key := range []string{"name", "namespace"} {
+				if _, ok := metadata[key]; !ok && getMetadata(liveObject)[key] != nil {
+					metadata[key] = getMetadata(liveObject)[key]
+				}
+			}
+		}
+	} else if lastApplied == nil {
+		return nil, errors.NewBadRequest(fmt.Sprintf("object has no %s annotation, a manifest to compare with "+
+			"is required", v1.LastAppliedConfigAnnotation))
+	}
+
+	removeLastApplied(liveObject)
+	removeLastApplied(desired)
+
+	// Fields, that were applied last time and are missing in the manifest, are removed by the next apply, so
+	// they are compared too.
+	managed := []map[string]interface{}{desired}
+	if lastApplied != nil && source == SourceManifest {
+		removeLastApplied(lastApplied)
+		managed = append(managed, lastApplied)
+	}
+	liveObject = prune(liveObject, managed...)
+
+	unified, err := unifiedYAMLDiff(liveObject, desired, "live", string(source))
+	if err != nil {
+		return nil, err
+	}
+
+	changes := compare("", liveObject, desired, make([]Change, 0))
+	return &Diff{
+		Source:    source,
+		Identical: len(changes) == 0,
+		Changes:   changes,
+		Unified:   unified,
+	}, nil
+}
+
+// getLastApplied returns the configuration stored in the last-applied-configuration annotation of the object or
+// nil if it is not set.
+func getLastApplied(object map[string]interface{}) (map[string]interface{}, error) {
+	annotations, _ := getMetadata(object)["annotations"].(map[string]interface{})
+	value, ok := annotations[v1.LastAppliedConfigAnnotation].(string)
+	if !ok || value == "" {
+		return nil, nil
+	}
+
+	result := make(map[string]interface{})
+	if err := json.Unmarshal([]byte(value), &result); err != nil {
+		return nil, errors.NewBadRequest(fmt.Sprintf("invalid %s annotation: %s", v1.LastAppliedConfigAnnotation,
+			err))
+	}
+	return result, nil
+}
+
+// removeLastApplied removes the last-applied-configuration annotation, so that it is not compared. Annotations
+// are removed completely when there are no other annotations.
+func removeLastApplied(object map[string]interface{}) {
+	metadata := getMetadata(object)
+	annotations, ok := metadata["annotations"].(map[string]interface{})
+	if !ok {
+		return
+	}
+
+	delete(annotations, v1.LastAppliedConfigAnnotation)
+	if len(annotations) == 0 {
+		delete(metadata, "annotations")
+	}
+}
+
+func getMetadata(object map[string]interface{}) map[string]interface{} {
+	metadata, _ := object["metadata"].(map[string]interface{})
+	return metadata
+}
+
+func getName(object map[string]interface{}) string {
+	name, _ := getMetadata(object)["name"].(string)
+	return name
+}
+
+// prune returns a copy of the live object with fields, that are not set in any of managed objects, removed.
+// Elements of lists are pruned by their position.
+func prune(live map[string]interface{}, managed ...map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{})
+	for key, value := range live {
+		found, managedValues := false, make([]interface{}, 0)
+		for _, object := range managed {
+			if managedValue, ok := object[key]; ok {
+				found = true
+				managedValues = append(managedValues, managedValue)
+			}
+		}
+		if found {
+			result[key] = pruneValue(value, managedValues)
+		}
+	}
+	return result
+}
+
+func pruneValue(live interface{}, managed []interface{}) interface{} {
+	switch value := live.(type) {
+	case map[string]interface{}:
+		children := make([]map[string]interface{}, 0)
+		for _, managedValue := range managed {
+			if child, ok := managedValue.(map[string]interface{}); ok {
+				children = append(children, child)
+			}
+		}
+		if len(children) > 0 {
+			return prune(value, children...)
+		}
+	case []interface{}:
+		result := make([]interface{}, len(value))
+		for i, item := range value {
+			items := make([]interface{}, 0)
+			for _, managedValue := range managed {
+				if list, ok := managedValue.([]interface{}); ok && i < len(list) {
+					items = append(items, list[i])
+				}
+			}
+			result[i] = pruneValue(item, items)
+		}
+		return result
+	}
+	return live
+}
+
+// compare appends changes needed to turn the live value into the desired one to given list.
+func compare(path string, live, desired interface{}, changes []Change) []Change {
+	liveMap, liveIsMap := live.(map[string]interface{})
+	desiredMap, desiredIsMap := desired.(map[string]interface{})
+	if liveIsMap && desiredIsMap {
+		for _, key := range sortedKeys(liveMap, desiredMap) {
+			liveValue, inLive := liveMap[key]
+			desiredValue, inDesired := desiredMap[key]
+			switch {
+			case !inLive:
+				changes = append(changes, Change{Path: childPath(path, key), Operation: OperationAdd,
+					Desired: desiredValue})
+			case !inDesired:
+				changes = append(changes, Change{Path: childPath(path, key), Operation: OperationRemove,
+					Live: liveValue})
+			default:
+				changes = compare(childPath(path, key), liveValue, desiredValue, changes)
+			}
+		}
+		return changes
+	}
+
+	liveList, liveIsList := live.([]interface{})
+	desiredList, desiredIsList := desired.([]interface{})
+	if liveIsList && desiredIsList && len(liveList) == len(desiredList) {
+		for i := range liveList {
+			changes = compare(fmt.Sprintf("%s[%d]", path, i), liveList[i], desiredList[i], changes)
+		}
+		return changes
+	}
+
+	if !reflect.DeepEqual(live, desired) {
+		changes = append(changes, Change{Path: path, Operation: OperationReplace, Live: live, Desired: desired})
+	}
+	return changes
+}
+
+func sortedKeys(maps ...map[string]interface{}) []string {
+	keys := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, m := range maps {
+		for key := range m {
+			if !seen[key] {
+				seen[key] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// childPath returns path of the field with given key. Keys, that are not plain identifiers, i.e. label keys, are
+// quoted.
+func childPath(path, key string) string {
+	if strings.ContainsAny(key, "./[] ") {
+		return fmt.Sprintf("%s[%q]", path, key)
+	}
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// unifiedYAMLDiff returns unified diff of YAML representations of given objects.
+func unifiedYAMLDiff(from, to interface{}, fromName, toName string) (string, error) {
+	fromYAML, err := yaml.Marshal(from)
+	if err != nil {
+		return "", err
+	}
+	toYAML, err := yaml.Marshal(to)
+	if err != nil {
+		return "", err
+	}
+
+	return unifiedDiff(splitLines(string(fromYAML)), splitLines(string(toYAML)), fromName, toName), nil
+}
+
+func splitLines(text string) []string {
+	text = strings.TrimSuffix(text, "\n")
+	if text == "" {
+		return []string{}
+	}
+	return strings.Split(text, "\n")
+}
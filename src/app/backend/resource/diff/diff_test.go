@@ -0,0 +1,122 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diff
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+)
+
+const testLiveDeployment = `{
+  "apiVersion": "apps/v1",
+  "kind": "Deployment",
+  "metadata": {
+    "name": "web",
+    "namespace": "default",
+    "uid": "3f1d",
+    "resourceVersion": "1234",
+    "labels": {"app": "web", "pod-template-hash": "5d4f8"},
+    "annotations": {
+      "deployment.kubernetes.io/revision": "2",
+      "kubectl.kubernetes.io/last-applied-configuration": "{\"apiVersion\":\"apps/v1\",\"kind\":\"Deployment\",\"metadata\":{\"annotations\":{},\"labels\":{\"app\":\"web\"},\"name\":\"web\",\"namespace\":\"default\"},\"spec\":{\"replicas\":2,\"template\":{\"spec\":{\"containers\":[{\"image\":\"nginx:1.17\",\"name\":\"web\"}]}}}}"
+    }
+  },
+  "spec": {
+    "replicas": 5,
+    "progressDeadlineSeconds": 600,
+    "template": {"spec": {"containers": [{"image": "nginx:1.17", "name": "web", "imagePullPolicy": "IfNotPresent"}]}}
+  },
+  "status": {"replicas": 5}
+}`
+
+func TestGetDiff(t *testing.T) {
+	cases := []struct {
+		info     string
+		live     string
+		manifest string
+		expected *Diff
+	}{
+		{
+			"last applied configuration",
+			testLiveDeployment,
+			"",
+			&Diff{
+				Source: SourceLastApplied,
+				Changes: []Change{
+					{Path: "spec.replicas", Operation: OperationReplace, Live: float64(5), Desired: float64(2)},
+				},
+				Unified: "--- live\n+++ last-applied\n@@ -6,7 +6,7 @@\n   name: web\n   namespace: default\n" +
+					" spec:\n-  replicas: 5\n+  replicas: 2\n   template:\n     spec:\n       containers:\n",
+			},
+		},
+		{
+			"manifest",
+			testLiveDeployment,
+			`{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"name":"web","labels":{"app":"web",` +
+				`"app.kubernetes.io/part-of":"shop"}},"spec":{"template":{"spec":{"containers":[{"image":"nginx:1.18",` +
+				`"name":"web"}]}}}}`,
+			&Diff{
+				Source: SourceManifest,
+				Changes: []Change{
+					{Path: `metadata.labels["app.kubernetes.io/part-of"]`, Operation: OperationAdd, Desired: "shop"},
+					{Path: "spec.replicas", Operation: OperationRemove, Live: float64(5)},
+					{Path: "spec.template.spec.containers[0].image", Operation: OperationReplace,
+						Live: "nginx:1.17", Desired: "nginx:1.18"},
+				},
+			},
+		},
+		{
+			"identical manifest",
+			`{"metadata":{"name":"web","namespace":"default","uid":"3f1d"},"spec":{"replicas":5}}`,
+			`{"metadata":{"name":"web"},"spec":{"replicas":5}}`,
+			&Diff{Source: SourceManifest, Identical: true, Changes: []Change{}},
+		},
+	}
+
+	for _, c := range cases {
+		actual, err := GetDiff([]byte(c.live), []byte(c.manifest))
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", c.info, err)
+		}
+		if c.expected.Unified == "" && !c.expected.Identical {
+			// Unified diff is checked only for the first case.
+			actual.Unified = ""
+		}
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("%s: GetDiff() == %#v, expected %#v", c.info, actual, c.expected)
+		}
+	}
+}
+
+func TestGetDiffErrors(t *testing.T) {
+	cases := []struct {
+		info     string
+		live     string
+		manifest string
+	}{
+		{"no last applied configuration", `{"metadata":{"name":"web"}}`, ""},
+		{"invalid manifest", `{"metadata":{"name":"web"}}`, `{"metadata":`},
+		{"other object", `{"metadata":{"name":"web"}}`, `{"metadata":{"name":"db"}}`},
+	}
+
+	for _, c := range cases {
+		_, err := GetDiff([]byte(c.live), []byte(c.manifest))
+		if !errors.IsBadRequest(err) {
+			t.Errorf("%s: expected bad request, got %v", c.info, err)
+		}
+	}
+}
@@ -0,0 +1,174 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diff
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// contextLines is the number of unchanged lines shown around every change.
+	contextLines = 3
+
+	// maxEditCells limits the size of the table used to find the longest common subsequence of lines. Larger
+	// inputs are diffed as a single replacement.
+	maxEditCells = 1 << 22
+)
+
+// edit is a single line of an edit script. Operation is ' ' for unchanged line, '-' for removed and '+' for added
+// one.
+type edit struct {
+	operation byte
+	line      string
+}
+
+// unifiedDiff returns unified diff of given lines. Empty string is returned when there are no differences.
+func unifiedDiff(from, to []string, fromName, toName string) string {
+	edits := editScript(from, to)
+
+	changed := make([]int, 0)
+	for i, e := range edits {
+		if e.operation != ' ' {
+			changed = append(changed, i)
+		}
+	}
+	if len(changed) == 0 {
+		return ""
+	}
+
+	// fromLine and toLine hold the number of lines of both inputs preceding every edit.
+	fromLine, toLine := make([]int, len(edits)+1), make([]int, len(edits)+1)
+	for i, e := range edits {
+		fromLine[i+1], toLine[i+1] = fromLine[i], toLine[i]
+		if e.operation != '+' {
+			fromLine[i+1]++
+		}
+		if e.operation != '-' {
+			toLine[i+1]++
+		}
+	}
+
+	var result strings.Builder
+	fmt.Fprintf(&result, "--- %s\n+++ %s\n", fromName, toName)
+	for first := 0; first < len(changed); {
+		last := first
+		for last+1 < len(changed) && changed[last+1]-changed[last] <= 2*contextLines {
+			last++
+		}
+
+		start, end := changed[first]-contextLines, changed[last]+contextLines+1
+		if start < 0 {
+			start = 0
+		}
+		if end > len(edits) {
+			end = len(edits)
+		}
+
+		fmt.Fprintf(&result, "@@ -%s +%s @@\n", hunkRange(fromLine[start], fromLine[end]-fromLine[start]),
+			hunkRange(toLine[start], toLine[end]-toLine[start]))
+		for _, e := range edits[start:end] {
+			result.WriteByte(e.operation)
+			result.WriteString(e.line)
+			result.WriteByte('\n')
+		}
+		first = last + 1
+	}
+
+	return result.String()
+}
+
+// hunkRange formats range of a hunk header. Empty ranges point to the line preceding the hunk.
+func hunkRange(preceding, length int) string {
+	if length == 0 {
+		return fmt.Sprintf("%d,0", preceding)
+	}
+	if length == 1 {
+		return fmt.Sprintf("%d", preceding+1)
+	}
+	return fmt.Sprintf("%d,%d", preceding+1, length)
+}
+
+// editScript returns the shortest list of edits turning from lines into to lines.
+func editScript(from, to []string) []edit {
+	prefix := 0
+	for prefix < len(from) && prefix < len(to) && from[prefix] == to[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(from)-prefix && suffix < len(to)-prefix &&
+		from[len(from)-1-suffix] == to[len(to)-1-suffix] {
+		suffix++
+	}
+
+	result := make([]edit, 0, len(from)+len(to))
+	for _, line := range from[:prefix] {
+		result = append(result, edit{' ', line})
+	}
+	result = append(result, editMiddle(from[prefix:len(from)-suffix], to[prefix:len(to)-suffix])...)
+	for _, line := range from[len(from)-suffix:] {
+		result = append(result, edit{' ', line})
+	}
+	return result
+}
+
+// editMiddle finds edits between given lines using the longest common subsequence.
+func editMiddle(from, to []string) []edit {
+	n, m := len(from), len(to)
+	result := make([]edit, 0, n+m)
+	if (n+1)*(m+1) > maxEditCells {
+		for _, line := range from {
+			result = append(result, edit{'-', line})
+		}
+		for _, line := range to {
+			result = append(result, edit{'+', line})
+		}
+		return result
+	}
+
+	// common[i][j] is the length of the longest common subsequence of from[i:] and to[j:].
+	common := make([][]int, n+1)
+	for i := range common {
+		common[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			switch {
+			case from[i] == to[j]:
+				common[i][j] = common[i+1][j+1] + 1
+			case common[i+1][j] >= common[i][j+1]:
+				common[i][j] = common[i+1][j]
+			default:
+				common[i][j] = common[i][j+1]
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < n || j < m {
+		switch {
+		case i < n && j < m && from[i] == to[j]:
+			result = append(result, edit{' ', from[i]})
+			i, j = i+1, j+1
+		case i < n && (j == m || common[i+1][j] >= common[i][j+1]):
+			result = append(result, edit{'-', from[i]})
+			i++
+		default:
+			result = append(result, edit{'+', to[j]})
+			j++
+		}
+	}
+	return result
+}
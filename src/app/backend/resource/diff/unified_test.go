@@ -0,0 +1,59 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diff
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestUnifiedDiff(t *testing.T) {
+	cases := []struct {
+		info     string
+		from, to string
+		expected string
+	}{
+		{"identical", "a\nb", "a\nb", ""},
+		{"added to empty", "", "a", "--- from\n+++ to\n@@ -0,0 +1 @@\n+a\n"},
+		{
+			"separate hunks",
+			"1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11",
+			"0\n1\n2\n3\n4\n5\n6\n7\n8\n9\nten\n11",
+			"--- from\n+++ to\n@@ -1,3 +1,4 @@\n+0\n 1\n 2\n 3\n@@ -7,5 +8,5 @@\n 7\n 8\n 9\n-10\n+ten\n 11\n",
+		},
+		{
+			"merged hunks",
+			"1\n2\n3\n4\n5",
+			"1\ntwo\n3\n4\nfive",
+			"--- from\n+++ to\n@@ -1,5 +1,5 @@\n 1\n-2\n+two\n 3\n 4\n-5\n+five\n",
+		},
+	}
+
+	for _, c := range cases {
+		if actual := unifiedDiff(splitLines(c.from), splitLines(c.to), "from", "to"); actual != c.expected {
+			t.Errorf("%s: unifiedDiff() ==\n%s\nexpected\n%s", c.info, actual, c.expected)
+		}
+	}
+}
+
+func TestEditScriptLimit(t *testing.T) {
+	from := strings.Split(strings.Repeat("a\n", 3000), "\n")
+	to := strings.Split(strings.Repeat("b\n", 3000), "\n")
+
+	edits := editScript(from, to)
+	if len(edits) != 6001 {
+		t.Errorf("expected 6001 edits, got %d", len(edits))
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package event
+
+import (
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+)
+
+// EventSink is called for every event received by StreamEvents. Returning an error stops the stream.
+type EventSink func(event common.Event) error
+
+// WatchEvents opens a watch on events in the given namespace. Empty namespace means all namespaces.
+func WatchEvents(client kubernetes.Interface, namespace string) (watch.Interface, error) {
+	return client.CoreV1().Events(namespace).Watch(api.ListEverything)
+}
+
+// StreamEvents forwards added and modified events from the watcher to the sink until stop channel is
+// closed, the watch ends or the sink returns an error. When eventType is not empty only events of given
+// type, i.e. "Warning", are forwarded.
+func StreamEvents(watcher watch.Interface, eventType string, stopCh <-chan struct{}, sink EventSink) error {
+	defer watcher.Stop()
+
+	for {
+		select {
+		case <-stopCh:
+			return nil
+		case ev, ok := <-watcher.ResultChan():
+			if !ok {
+				return nil
+			}
+
+			switch ev.Type {
+			case watch.Added, watch.Modified:
+				apiEvent, ok := ev.Object.(*v1.Event)
+				if !ok {
+					continue
+				}
+
+				events := FillEventsType([]v1.Event{*apiEvent})
+				if len(eventType) > 0 && events[0].Type != eventType {
+					continue
+				}
+
+				if err := sink(ToEvent(events[0])); err != nil {
+					return err
+				}
+			case watch.Error:
+				return errors.NewUnexpectedObject(ev.Object)
+			}
+		}
+	}
+}
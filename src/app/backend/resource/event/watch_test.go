@@ -0,0 +1,91 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package event
+
+import (
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+)
+
+func TestStreamEvents(t *testing.T) {
+	cases := []struct {
+		eventType string
+		events    []watch.Event
+		expected  []string
+	}{
+		{
+			"",
+			[]watch.Event{
+				{Type: watch.Added, Object: &v1.Event{ObjectMeta: metaV1.ObjectMeta{Name: "ev-1"}, Reason: "Started"}},
+				{Type: watch.Modified, Object: &v1.Event{ObjectMeta: metaV1.ObjectMeta{Name: "ev-2"}, Reason: "BackOff"}},
+				{Type: watch.Deleted, Object: &v1.Event{ObjectMeta: metaV1.ObjectMeta{Name: "ev-3"}}},
+			},
+			[]string{"ev-1", "ev-2"},
+		},
+		{
+			v1.EventTypeWarning,
+			[]watch.Event{
+				{Type: watch.Added, Object: &v1.Event{ObjectMeta: metaV1.ObjectMeta{Name: "ev-1"}, Reason: "Started"}},
+				{Type: watch.Added, Object: &v1.Event{ObjectMeta: metaV1.ObjectMeta{Name: "ev-2"}, Reason: "BackOff"}},
+				{Type: watch.Added, Object: &v1.Event{ObjectMeta: metaV1.ObjectMeta{Name: "ev-3"},
+					Type: v1.EventTypeWarning}},
+			},
+			[]string{"ev-2", "ev-3"},
+		},
+	}
+
+	for _, c := range cases {
+		watcher := watch.NewFakeWithChanSize(len(c.events), false)
+		for _, ev := range c.events {
+			watcher.Action(ev.Type, ev.Object)
+		}
+		watcher.Stop()
+
+		actual := make([]string, 0)
+		err := StreamEvents(watcher, c.eventType, make(chan struct{}), func(event common.Event) error {
+			actual = append(actual, event.ObjectMeta.Name)
+			return nil
+		})
+
+		if err != nil {
+			t.Errorf("StreamEvents(%#v) returned unexpected error: %s", c.eventType, err)
+		}
+
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("StreamEvents(%#v) == %#v, expected %#v", c.eventType, actual, c.expected)
+		}
+	}
+}
+
+func TestStreamEventsStop(t *testing.T) {
+	watcher := watch.NewFake()
+	stopCh := make(chan struct{})
+	close(stopCh)
+
+	err := StreamEvents(watcher, "", stopCh, func(event common.Event) error {
+		t.Errorf("Unexpected event: %#v", event)
+		return nil
+	})
+
+	if err != nil {
+		t.Errorf("StreamEvents returned unexpected error: %s", err)
+	}
+}
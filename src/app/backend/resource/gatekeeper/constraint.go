@@ -0,0 +1,238 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gatekeeper
+
+import (
+	"log"
+	"strings"
+	"time"
+
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+)
+
+// constraintsGroup is the API group of Gatekeeper constraints. Every constraint template creates a separate
+// constraint kind in this group.
+const constraintsGroup = "constraints.gatekeeper.sh"
+
+// Violation is a single violation of a constraint found by the Gatekeeper audit.
+type Violation struct {
+	// Kind and name of the violated constraint.
+	ConstraintKind string `json:"constraintKind"`
+	ConstraintName string `json:"constraintName"`
+
+	// Action taken on the violation, i.e. deny or dryrun.
+	EnforcementAction string `json:"enforcementAction"`
+
+	// Kind, namespace and name of the violating resource.
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+
+	// Message explaining the violation.
+	Message string `json:"message"`
+}
+
+// Constraint is a single Gatekeeper constraint.
+type Constraint struct {
+	api.ObjectMeta `json:"objectMeta"`
+	api.TypeMeta   `json:"typeMeta"`
+
+	// Kind of the constraint, that is defined by its constraint template, i.e. K8sRequiredLabels.
+	Kind string `json:"kind"`
+
+	// Action taken on violations, deny by default.
+	EnforcementAction string `json:"enforcementAction"`
+
+	// Number of violations found by the last audit. Gatekeeper reports only a limited number of violations, so it
+	// can be greater than the length of Violations.
+	TotalViolations int64 `json:"totalViolations"`
+
+	// Time of the last audit.
+	AuditTimestamp *metaV1.Time `json:"auditTimestamp,omitempty"`
+
+	// Violations found by the last audit.
+	Violations []Violation `json:"violations"`
+}
+
+// ConstraintList contains a list of Gatekeeper constraints of all kinds.
+type ConstraintList struct {
+	api.ListMeta `json:"listMeta"`
+
+	// Unordered list of constraints.
+	Items []Constraint `json:"items"`
+
+	// False when Gatekeeper is not installed in the cluster.
+	Installed bool `json:"installed"`
+
+	// List of non-critical errors, that occurred during resource retrieval.
+	Errors []error `json:"errors"`
+}
+
+// GetConstraintList returns constraints of all kinds together with their audit violations. Empty list is returned
+// when Gatekeeper is not installed.
+func GetConstraintList(discoveryClient discovery.DiscoveryInterface, client dynamic.Interface,
+	dsQuery *dataselect.DataSelectQuery) (*ConstraintList, error) {
+	log.Print("Getting list of Gatekeeper constraints")
+
+	result := &ConstraintList{Items: make([]Constraint, 0), Errors: make([]error, 0)}
+	constraints, installed, err := listConstraints(discoveryClient, client)
+	nonCriticalErrors, criticalError := errors.HandleError(err)
+	if criticalError != nil {
+		return nil, criticalError
+	}
+	result.Installed = installed
+	result.Errors = nonCriticalErrors
+
+	cells, filteredTotal := dataselect.GenericDataSelectWithFilter(toCells(constraints), dsQuery)
+	constraints = fromCells(cells)
+	result.ListMeta = api.ListMeta{TotalItems: filteredTotal}
+	for i := range constraints {
+		result.Items = append(result.Items, toConstraint(&constraints[i]))
+	}
+
+	return result, nil
+}
+
+// GetResourceViolations returns audit violations of the given resource. Kind is the Kubernetes kind of the
+// resource, i.e. Deployment, and namespace is empty for cluster scoped resources. Empty list is returned when
+// Gatekeeper is not installed.
+func GetResourceViolations(discoveryClient discovery.DiscoveryInterface, client dynamic.Interface, kind,
+	namespace, name string) ([]Violation, error) {
+	result := make([]Violation, 0)
+	constraints, _, err := listConstraints(discoveryClient, client)
+	if err != nil {
+		return result, err
+	}
+
+	for i := range constraints {
+		for _, violation := range toConstraint(&constraints[i]).Violations {
+			if violation.Kind == kind && violation.Namespace == namespace && violation.Name == name {
+				result = append(result, violation)
+			}
+		}
+	}
+	return result, nil
+}
+
+// listConstraints lists constraints of every kind served in the constraints group.
+func listConstraints(discoveryClient discovery.DiscoveryInterface, client dynamic.Interface) (
+	[]unstructured.Unstructured, bool, error) {
+	group, installed, err := common.FindServedResource(discoveryClient, "", constraintsGroup)
+	if err != nil || !installed {
+		return []unstructured.Unstructured{}, installed, err
+	}
+
+	groupVersion := group.GroupVersion()
+	resources, err := discoveryClient.ServerResourcesForGroupVersion(groupVersion.String())
+	if err != nil {
+		return []unstructured.Unstructured{}, true, err
+	}
+
+	constraints := make([]unstructured.Unstructured, 0)
+	for _, resource := range resources.APIResources {
+		// Skip subresources, i.e. k8srequiredlabels/status.
+		if strings.Contains(resource.Name, "/") {
+			continue
+		}
+
+		list, err := client.Resource(groupVersion.WithResource(resource.Name)).List(api.ListEverything)
+		if err != nil {
+			return constraints, true, err
+		}
+		constraints = append(constraints, list.Items...)
+	}
+	return constraints, true, nil
+}
+
+func toConstraint(obj *unstructured.Unstructured) Constraint {
+	constraint := Constraint{
+		ObjectMeta:        common.NewUnstructuredObjectMeta(obj),
+		TypeMeta:          api.NewTypeMeta(api.ResourceKindConstraint),
+		Kind:              obj.GetKind(),
+		EnforcementAction: "deny",
+		Violations:        make([]Violation, 0),
+	}
+	if action, _, _ := unstructured.NestedString(obj.Object, "spec", "enforcementAction"); len(action) > 0 {
+		constraint.EnforcementAction = action
+	}
+	constraint.TotalViolations, _, _ = unstructured.NestedInt64(obj.Object, "status", "totalViolations")
+
+	timestamp, _, _ := unstructured.NestedString(obj.Object, "status", "auditTimestamp")
+	if parsed, err := time.Parse(time.RFC3339, timestamp); err == nil {
+		constraint.AuditTimestamp = &metaV1.Time{Time: parsed}
+	}
+
+	violations, _, _ := unstructured.NestedSlice(obj.Object, "status", "violations")
+	for _, violation := range violations {
+		violation, ok := violation.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		result := Violation{ConstraintKind: constraint.Kind, ConstraintName: constraint.Name,
+			EnforcementAction: constraint.EnforcementAction}
+		if action, _, _ := unstructured.NestedString(violation, "enforcementAction"); len(action) > 0 {
+			result.EnforcementAction = action
+		}
+		result.Kind, _, _ = unstructured.NestedString(violation, "kind")
+		result.Namespace, _, _ = unstructured.NestedString(violation, "namespace")
+		result.Name, _, _ = unstructured.NestedString(violation, "name")
+		result.Message, _, _ = unstructured.NestedString(violation, "message")
+		constraint.Violations = append(constraint.Violations, result)
+	}
+
+	return constraint
+}
+
+// The code below allows to perform complex data section on []unstructured.Unstructured
+
+type ConstraintCell unstructured.Unstructured
+
+func (self ConstraintCell) GetProperty(name dataselect.PropertyName) dataselect.ComparableValue {
+	obj := unstructured.Unstructured(self)
+	switch name {
+	case dataselect.NameProperty:
+		return dataselect.StdComparableString(obj.GetName())
+	case dataselect.CreationTimestampProperty:
+		return dataselect.StdComparableTime(obj.GetCreationTimestamp().Time)
+	default:
+		// if name is not supported then just return a constant dummy value, sort will have no effect.
+		return nil
+	}
+}
+
+func toCells(std []unstructured.Unstructured) []dataselect.DataCell {
+	cells := make([]dataselect.DataCell, len(std))
+	for i := range std {
+		cells[i] = ConstraintCell(std[i])
+	}
+	return cells
+}
+
+func fromCells(cells []dataselect.DataCell) []unstructured.Unstructured {
+	std := make([]unstructured.Unstructured, len(cells))
+	for i := range std {
+		std[i] = unstructured.Unstructured(cells[i].(ConstraintCell))
+	}
+	return std
+}
@@ -0,0 +1,129 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gatekeeper
+
+import (
+	"reflect"
+	"testing"
+
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	fakedynamic "k8s.io/client-go/dynamic/fake"
+	clienttesting "k8s.io/client-go/testing"
+
+	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+)
+
+func newConstraint(kind, name, action string, violations ...interface{}) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": constraintsGroup + "/v1beta1",
+		"kind":       kind,
+		"metadata":   map[string]interface{}{"name": name},
+		"spec":       map[string]interface{}{},
+		"status": map[string]interface{}{
+			"auditTimestamp":  "2020-01-01T00:00:00Z",
+			"totalViolations": int64(len(violations)),
+			"violations":      violations,
+		},
+	}}
+	if len(action) > 0 {
+		obj.Object["spec"] = map[string]interface{}{"enforcementAction": action}
+	}
+	return obj
+}
+
+func newViolation(kind, namespace, name, message string) map[string]interface{} {
+	return map[string]interface{}{"kind": kind, "namespace": namespace, "name": name, "message": message}
+}
+
+func TestConstraints(t *testing.T) {
+	discoveryClient := &fakediscovery.FakeDiscovery{Fake: &clienttesting.Fake{Resources: []*metaV1.APIResourceList{{
+		GroupVersion: constraintsGroup + "/v1beta1",
+		APIResources: []metaV1.APIResource{
+			{Name: "k8srequiredlabels", Kind: "K8sRequiredLabels"},
+			{Name: "k8srequiredlabels/status", Kind: "K8sRequiredLabels"},
+			{Name: "k8sallowedrepos", Kind: "K8sAllowedRepos"},
+		},
+	}}}}
+
+	constraints := map[string][]*unstructured.Unstructured{
+		"k8srequiredlabels": {
+			newConstraint("K8sRequiredLabels", "must-have-owner", "",
+				newViolation("Deployment", "default", "web", "missing label owner"),
+				newViolation("Namespace", "", "default", "missing label owner")),
+		},
+		"k8sallowedrepos": {
+			newConstraint("K8sAllowedRepos", "trusted-repos", "dryrun",
+				newViolation("Deployment", "default", "web", "image nginx is not allowed"),
+				newViolation("Deployment", "other", "web", "image nginx is not allowed")),
+		},
+	}
+
+	// Objects are created through resource clients, because the fake client cannot guess resources of
+	// constraints from their kinds.
+	client := fakedynamic.NewSimpleDynamicClient(runtime.NewScheme())
+	for resource, objects := range constraints {
+		gvr := schema.GroupVersionResource{Group: constraintsGroup, Version: "v1beta1", Resource: resource}
+		for _, obj := range objects {
+			if _, err := client.Resource(gvr).Create(obj, metaV1.CreateOptions{}); err != nil {
+				t.Fatalf("Cannot create %s: %s", obj.GetName(), err)
+			}
+		}
+	}
+
+	list, err := GetConstraintList(discoveryClient, client, dataselect.NewDataSelectQuery(
+		dataselect.NoPagination, dataselect.NewSortQuery([]string{"a", "name"}), dataselect.NoFilter,
+		dataselect.NoMetrics))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !list.Installed || len(list.Items) != 2 {
+		t.Fatalf("Expected 2 constraints, got %#v", list)
+	}
+	if list.Items[0].Name != "must-have-owner" || list.Items[0].EnforcementAction != "deny" ||
+		list.Items[0].TotalViolations != 2 || list.Items[0].AuditTimestamp == nil {
+		t.Errorf("Unexpected constraint %#v", list.Items[0])
+	}
+
+	violations, err := GetResourceViolations(discoveryClient, client, "Deployment", "default", "web")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []Violation{
+		{ConstraintKind: "K8sRequiredLabels", ConstraintName: "must-have-owner", EnforcementAction: "deny",
+			Kind: "Deployment", Namespace: "default", Name: "web", Message: "missing label owner"},
+		{ConstraintKind: "K8sAllowedRepos", ConstraintName: "trusted-repos", EnforcementAction: "dryrun",
+			Kind: "Deployment", Namespace: "default", Name: "web", Message: "image nginx is not allowed"},
+	}
+	if !reflect.DeepEqual(violations, expected) {
+		t.Errorf("Expected violations %#v, got %#v", expected, violations)
+	}
+}
+
+func TestConstraintsNotInstalled(t *testing.T) {
+	discoveryClient := &fakediscovery.FakeDiscovery{Fake: &clienttesting.Fake{}}
+	client := fakedynamic.NewSimpleDynamicClient(runtime.NewScheme())
+
+	list, err := GetConstraintList(discoveryClient, client, dataselect.NoDataSelect)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if list.Installed || len(list.Items) != 0 {
+		t.Errorf("Expected empty list, got %#v", list)
+	}
+}
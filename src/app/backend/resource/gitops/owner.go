@@ -0,0 +1,240 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitops
+
+import (
+	"log"
+	"strings"
+
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+)
+
+// Tool is a GitOps tool, that manages resources.
+type Tool string
+
+// List of supported GitOps tools.
+const (
+	ToolArgoCD Tool = "argocd"
+	ToolFlux   Tool = "flux"
+)
+
+// Labels and annotations set by GitOps tools on managed resources.
+const (
+	argoTrackingIDAnnotation = "argocd.argoproj.io/tracking-id"
+	argoInstanceLabel        = "argocd.argoproj.io/instance"
+	// Argo CD tracks resources by this label by default. It is set by many Helm charts as well, so the application
+	// has to exist for the resource to be considered managed by Argo CD.
+	appInstanceLabel = "app.kubernetes.io/instance"
+
+	fluxKustomizeNameLabel      = "kustomize.toolkit.fluxcd.io/name"
+	fluxKustomizeNamespaceLabel = "kustomize.toolkit.fluxcd.io/namespace"
+	fluxHelmNameLabel           = "helm.toolkit.fluxcd.io/name"
+	fluxHelmNamespaceLabel      = "helm.toolkit.fluxcd.io/namespace"
+	// Flux does not revert changes of resources with reconciliation disabled by this annotation.
+	fluxReconcileAnnotation = "kustomize.toolkit.fluxcd.io/reconcile"
+)
+
+// Owner is a GitOps object, i.e. Argo CD application or Flux kustomization, that manages a resource.
+type Owner struct {
+	Tool      Tool   `json:"tool"`
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+
+	// False when the owner is referenced by the resource, but could not be found.
+	Found bool `json:"found"`
+
+	// Sync status, i.e. Synced or OutOfSync for Argo CD and Ready or NotReady for Flux.
+	SyncStatus string `json:"syncStatus,omitempty"`
+
+	// Health status, i.e. Healthy, Progressing or Degraded. Known only when the tool checks health.
+	HealthStatus string `json:"healthStatus,omitempty"`
+
+	// Revision of the source, that was applied last.
+	Revision string `json:"revision,omitempty"`
+
+	Message string `json:"message,omitempty"`
+
+	// True when manual changes of the resource are reverted automatically by the tool.
+	RevertsChanges bool `json:"revertsChanges"`
+}
+
+// Status describes whether a resource is managed by a GitOps tool.
+type Status struct {
+	Managed bool   `json:"managed"`
+	Owner   *Owner `json:"owner,omitempty"`
+
+	// List of non-critical errors, that occurred during resource retrieval.
+	Errors []error `json:"errors"`
+}
+
+// GetStatus returns GitOps tool, that manages the resource, based on labels and annotations set on it, together
+// with sync and health status of the owning object.
+func GetStatus(discoveryClient discovery.DiscoveryInterface, client dynamic.Interface, object metaV1.Object) (
+	*Status, error) {
+	log.Printf("Getting GitOps owner of %s %s", object.GetNamespace(), object.GetName())
+
+	result := &Status{Errors: make([]error, 0)}
+	owner, err := getFluxOwner(discoveryClient, client, object)
+	if owner == nil && err == nil {
+		owner, err = getArgoOwner(discoveryClient, client, object)
+	}
+
+	nonCriticalErrors, criticalError := errors.AppendError(err, result.Errors)
+	if criticalError != nil {
+		return nil, criticalError
+	}
+
+	result.Errors = nonCriticalErrors
+	result.Owner = owner
+	result.Managed = owner != nil
+	return result, nil
+}
+
+func getArgoOwner(discoveryClient discovery.DiscoveryInterface, client dynamic.Interface,
+	object metaV1.Object) (*Owner, error) {
+	name, verified := getArgoApplicationName(object)
+	if len(name) == 0 {
+		return nil, nil
+	}
+
+	// Applications can be created in any namespace since Argo CD 2.5. Their names are prefixed with the
+	// namespace in tracking IDs then.
+	namespace := ""
+	if parts := strings.SplitN(name, "_", 2); len(parts) == 2 {
+		namespace, name = parts[0], parts[1]
+	}
+
+	resource, installed, err := common.FindServedResource(discoveryClient, "applications", "argoproj.io")
+	if err != nil || !installed {
+		return nil, err
+	}
+
+	list, err := client.Resource(resource).Namespace(namespace).List(metaV1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range list.Items {
+		if list.Items[i].GetName() == name {
+			return toArgoOwner(&list.Items[i]), nil
+		}
+	}
+
+	if !verified {
+		return nil, nil
+	}
+	return &Owner{Tool: ToolArgoCD, Kind: "Application", Namespace: namespace, Name: name}, nil
+}
+
+// getArgoApplicationName returns name of the Argo CD application, that tracks the object. Second return value is
+// false when the name is taken from the common instance label and may not refer to an application.
+func getArgoApplicationName(object metaV1.Object) (string, bool) {
+	if id := object.GetAnnotations()[argoTrackingIDAnnotation]; len(id) > 0 {
+		// Tracking ID has format <application>:<group>/<kind>:<namespace>/<name>.
+		return strings.SplitN(id, ":", 2)[0], true
+	}
+
+	if name := object.GetLabels()[argoInstanceLabel]; len(name) > 0 {
+		return name, true
+	}
+
+	return object.GetLabels()[appInstanceLabel], false
+}
+
+func toArgoOwner(app *unstructured.Unstructured) *Owner {
+	owner := &Owner{
+		Tool:      ToolArgoCD,
+		Kind:      "Application",
+		Namespace: app.GetNamespace(),
+		Name:      app.GetName(),
+		Found:     true,
+	}
+
+	owner.SyncStatus, _, _ = unstructured.NestedString(app.Object, "status", "sync", "status")
+	owner.HealthStatus, _, _ = unstructured.NestedString(app.Object, "status", "health", "status")
+	owner.Revision, _, _ = unstructured.NestedString(app.Object, "status", "sync", "revision")
+	owner.Message, _, _ = unstructured.NestedString(app.Object, "status", "operationState", "message")
+	owner.RevertsChanges, _, _ = unstructured.NestedBool(app.Object, "spec", "syncPolicy", "automated", "selfHeal")
+	return owner
+}
+
+func getFluxOwner(discoveryClient discovery.DiscoveryInterface, client dynamic.Interface,
+	object metaV1.Object) (*Owner, error) {
+	labels := object.GetLabels()
+	owner := &Owner{Tool: ToolFlux}
+	var resource, group string
+	switch {
+	case len(labels[fluxKustomizeNameLabel]) > 0:
+		owner.Kind, owner.Name, owner.Namespace = "Kustomization", labels[fluxKustomizeNameLabel],
+			labels[fluxKustomizeNamespaceLabel]
+		resource, group = "kustomizations", "kustomize.toolkit.fluxcd.io"
+	case len(labels[fluxHelmNameLabel]) > 0:
+		owner.Kind, owner.Name, owner.Namespace = "HelmRelease", labels[fluxHelmNameLabel],
+			labels[fluxHelmNamespaceLabel]
+		resource, group = "helmreleases", "helm.toolkit.fluxcd.io"
+	default:
+		return nil, nil
+	}
+
+	gvr, installed, err := common.FindServedResource(discoveryClient, resource, group)
+	if err != nil || !installed {
+		return owner, err
+	}
+
+	obj, err := client.Resource(gvr).Namespace(owner.Namespace).Get(owner.Name, metaV1.GetOptions{})
+	if errors.IsNotFoundError(err) {
+		return owner, nil
+	}
+	if err != nil {
+		return owner, err
+	}
+
+	owner.Found = true
+	suspended, _, _ := unstructured.NestedBool(obj.Object, "spec", "suspend")
+	owner.RevertsChanges = !suspended && object.GetAnnotations()[fluxReconcileAnnotation] != "disabled"
+	owner.Revision, _, _ = unstructured.NestedString(obj.Object, "status", "lastAppliedRevision")
+
+	conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	for _, c := range conditions {
+		condition, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		status, _ := condition["status"].(string)
+		switch condition["type"] {
+		case "Ready":
+			owner.SyncStatus = map[string]string{"True": "Ready", "False": "NotReady"}[status]
+			if len(owner.SyncStatus) == 0 {
+				owner.SyncStatus = "Reconciling"
+			}
+			owner.Message, _ = condition["message"].(string)
+		case "Healthy":
+			owner.HealthStatus = map[string]string{"True": "Healthy", "False": "Degraded"}[status]
+			if len(owner.HealthStatus) == 0 {
+				owner.HealthStatus = "Progressing"
+			}
+		}
+	}
+
+	return owner, nil
+}
@@ -0,0 +1,126 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitops
+
+import (
+	"reflect"
+	"testing"
+
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	fakedynamic "k8s.io/client-go/dynamic/fake"
+	clienttesting "k8s.io/client-go/testing"
+)
+
+func TestGetStatus(t *testing.T) {
+	discoveryClient := &fakediscovery.FakeDiscovery{Fake: &clienttesting.Fake{
+		Resources: []*metaV1.APIResourceList{
+			{GroupVersion: "argoproj.io/v1alpha1"},
+			{GroupVersion: "kustomize.toolkit.fluxcd.io/v1beta1"},
+		},
+	}}
+	client := fakedynamic.NewSimpleDynamicClient(runtime.NewScheme(),
+		&unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "argoproj.io/v1alpha1",
+			"kind":       "Application",
+			"metadata":   map[string]interface{}{"name": "shop", "namespace": "argocd"},
+			"spec": map[string]interface{}{
+				"syncPolicy": map[string]interface{}{"automated": map[string]interface{}{"selfHeal": true}},
+			},
+			"status": map[string]interface{}{
+				"sync":   map[string]interface{}{"status": "OutOfSync", "revision": "4f2a9c1"},
+				"health": map[string]interface{}{"status": "Healthy"},
+			},
+		}},
+		&unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "kustomize.toolkit.fluxcd.io/v1beta1",
+			"kind":       "Kustomization",
+			"metadata":   map[string]interface{}{"name": "apps", "namespace": "flux-system"},
+			"spec":       map[string]interface{}{"suspend": false},
+			"status": map[string]interface{}{
+				"lastAppliedRevision": "main/8d1e2f3",
+				"conditions": []interface{}{
+					map[string]interface{}{"type": "Ready", "status": "False", "message": "health check failed"},
+					map[string]interface{}{"type": "Healthy", "status": "False"},
+				},
+			},
+		}},
+	)
+
+	cases := []struct {
+		name        string
+		labels      map[string]string
+		annotations map[string]string
+		expected    *Owner
+	}{
+		{
+			"argo cd tracking id",
+			nil,
+			map[string]string{argoTrackingIDAnnotation: "shop:apps/Deployment:shop/web"},
+			&Owner{Tool: ToolArgoCD, Kind: "Application", Namespace: "argocd", Name: "shop", Found: true,
+				SyncStatus: "OutOfSync", HealthStatus: "Healthy", Revision: "4f2a9c1", RevertsChanges: true},
+		},
+		{
+			"argo cd application, that does not exist",
+			map[string]string{argoInstanceLabel: "removed"},
+			nil,
+			&Owner{Tool: ToolArgoCD, Kind: "Application", Name: "removed"},
+		},
+		{
+			"instance label of a helm chart",
+			map[string]string{appInstanceLabel: "my-release"},
+			nil,
+			nil,
+		},
+		{
+			"flux kustomization",
+			map[string]string{fluxKustomizeNameLabel: "apps", fluxKustomizeNamespaceLabel: "flux-system",
+				appInstanceLabel: "shop"},
+			nil,
+			&Owner{Tool: ToolFlux, Kind: "Kustomization", Namespace: "flux-system", Name: "apps", Found: true,
+				SyncStatus: "NotReady", HealthStatus: "Degraded", Revision: "main/8d1e2f3",
+				Message: "health check failed", RevertsChanges: true},
+		},
+		{
+			"flux kustomization with reconciliation disabled",
+			map[string]string{fluxKustomizeNameLabel: "apps", fluxKustomizeNamespaceLabel: "flux-system"},
+			map[string]string{fluxReconcileAnnotation: "disabled"},
+			&Owner{Tool: ToolFlux, Kind: "Kustomization", Namespace: "flux-system", Name: "apps", Found: true,
+				SyncStatus: "NotReady", HealthStatus: "Degraded", Revision: "main/8d1e2f3",
+				Message: "health check failed"},
+		},
+		{
+			"flux helm release without flux installed",
+			map[string]string{fluxHelmNameLabel: "redis", fluxHelmNamespaceLabel: "cache"},
+			nil,
+			&Owner{Tool: ToolFlux, Kind: "HelmRelease", Namespace: "cache", Name: "redis"},
+		},
+	}
+
+	for _, c := range cases {
+		object := &metaV1.ObjectMeta{Name: "web", Namespace: "shop", Labels: c.labels, Annotations: c.annotations}
+		actual, err := GetStatus(discoveryClient, client, object)
+		if err != nil {
+			t.Errorf("%s: GetStatus() returned error: %s", c.name, err)
+			continue
+		}
+
+		if actual.Managed != (c.expected != nil) || !reflect.DeepEqual(actual.Owner, c.expected) {
+			t.Errorf("%s: GetStatus() returned owner %#v, expected %#v", c.name, actual.Owner, c.expected)
+		}
+	}
+}
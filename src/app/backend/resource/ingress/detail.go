@@ -19,7 +19,13 @@ import (
 
 	extensions "k8s.io/api/extensions/v1beta1"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
 	client "k8s.io/client-go/kubernetes"
+
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/certmanager"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/gatekeeper"
 )
 
 // IngressDetail API resource provides mechanisms to inject containers with configuration data while keeping
@@ -34,12 +40,23 @@ type IngressDetail struct {
 	// Status is the current state of the Ingress.
 	Status extensions.IngressStatus `json:"status"`
 
+	// Cert-manager certificates stored in TLS secrets of the Ingress. Empty when cert-manager is not installed.
+	Certificates []certmanager.Certificate `json:"certificates"`
+
+	// Results of DNS check of the hostnames of the Ingress.
+	HostResolutions []HostResolution `json:"hostResolutions"`
+
+	// Gatekeeper audit violations of the resource. Empty when Gatekeeper is not installed.
+	PolicyViolations []gatekeeper.Violation `json:"policyViolations"`
+
 	// List of non-critical errors, that occurred during resource retrieval.
 	Errors []error `json:"errors"`
 }
 
-// GetIngressDetail returns detailed information about an ingress
-func GetIngressDetail(client client.Interface, namespace, name string) (*IngressDetail, error) {
+// GetIngressDetail returns detailed information about an ingress together with status of its cert-manager
+// certificates and DNS records.
+func GetIngressDetail(client client.Interface, discoveryClient discovery.DiscoveryInterface,
+	dynamicClient dynamic.Interface, namespace, name string) (*IngressDetail, error) {
 	log.Printf("Getting details of %s ingress in %s namespace", name, namespace)
 
 	rawIngress, err := client.ExtensionsV1beta1().Ingresses(namespace).Get(name, metaV1.GetOptions{})
@@ -48,13 +65,26 @@ func GetIngressDetail(client client.Interface, namespace, name string) (*Ingress
 		return nil, err
 	}
 
-	return getIngressDetail(rawIngress), nil
+	secretNames := make([]string, 0)
+	for _, tls := range rawIngress.Spec.TLS {
+		if len(tls.SecretName) > 0 {
+			secretNames = append(secretNames, tls.SecretName)
+		}
+	}
+	certificates, err := certmanager.GetSecretCertificates(discoveryClient, dynamicClient, namespace, secretNames)
+	nonCriticalErrors := errors.AppendOptionalError(err, make([]error, 0))
+
+	return getIngressDetail(rawIngress, certificates, nonCriticalErrors), nil
 }
 
-func getIngressDetail(i *extensions.Ingress) *IngressDetail {
+func getIngressDetail(i *extensions.Ingress, certificates []certmanager.Certificate,
+	nonCriticalErrors []error) *IngressDetail {
 	return &IngressDetail{
-		Ingress: toIngress(i),
-		Spec:    i.Spec,
-		Status:  i.Status,
+		Ingress:         toIngress(i),
+		Spec:            i.Spec,
+		Status:          i.Status,
+		Certificates:    certificates,
+		HostResolutions: checkHostResolution(i),
+		Errors:          nonCriticalErrors,
 	}
 }
@@ -0,0 +1,180 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ingress
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	extensions "k8s.io/api/extensions/v1beta1"
+)
+
+const (
+	// dnsLookupTimeout is the timeout of resolution of a single hostname.
+	dnsLookupTimeout = 3 * time.Second
+
+	// maxConcurrentLookups is the number of hostnames of a single ingress resolved at the same time.
+	maxConcurrentLookups = 8
+
+	// externalDNSHostnameAnnotation lists additional hostnames that External-DNS creates records for.
+	externalDNSHostnameAnnotation = "external-dns.alpha.kubernetes.io/hostname"
+)
+
+// DNSStatus is the result of comparison of the addresses a hostname resolves to with the addresses of the ingress.
+type DNSStatus string
+
+// List of DNS statuses.
+const (
+	// DNSStatusMatching means that the hostname resolves to an address of the ingress.
+	DNSStatusMatching DNSStatus = "Matching"
+
+	// DNSStatusMismatch means that the hostname resolves to addresses not related to the ingress.
+	DNSStatusMismatch DNSStatus = "Mismatch"
+
+	// DNSStatusUnresolved means that the hostname cannot be resolved.
+	DNSStatusUnresolved DNSStatus = "Unresolved"
+
+	// DNSStatusPending means that the ingress has no address assigned yet, so the hostname cannot be checked.
+	DNSStatusPending DNSStatus = "Pending"
+)
+
+// HostResolution is the result of DNS check of a single hostname of the ingress.
+type HostResolution struct {
+	// Hostname from the ingress rules or from the External-DNS hostname annotation.
+	Host string `json:"host"`
+
+	// Addresses the hostname resolves to from Dashboard.
+	Addresses []string `json:"addresses"`
+
+	// Result of the check.
+	Status DNSStatus `json:"status"`
+
+	// Human readable description of the problem.
+	Message string `json:"message,omitempty"`
+}
+
+// hostResolver resolves hostnames to addresses. It is replaced in tests.
+type hostResolver interface {
+	LookupHost(ctx context.Context, host string) ([]string, error)
+}
+
+var resolver hostResolver = net.DefaultResolver
+
+// checkHostResolution resolves hostnames of the ingress and compares them with addresses of its load balancer.
+// Hostnames of load balancers, i.e. on AWS, are resolved as well and match when they resolve to the same address.
+// Wildcard hostnames cannot be resolved and are skipped. Resolution happens from Dashboard pod, so results may
+// differ from what the clients see when split-horizon DNS is used.
+func checkHostResolution(ingress *extensions.Ingress) []HostResolution {
+	hosts := getIngressHosts(ingress)
+	result := make([]HostResolution, len(hosts))
+	if len(hosts) == 0 {
+		return result
+	}
+
+	expected := make(map[string]bool)
+	for _, address := range ingress.Status.LoadBalancer.Ingress {
+		if len(address.IP) > 0 {
+			expected[address.IP] = true
+		}
+		if len(address.Hostname) > 0 {
+			addresses, _ := lookupHost(address.Hostname)
+			for _, resolved := range addresses {
+				expected[resolved] = true
+			}
+		}
+	}
+
+	workers := maxConcurrentLookups
+	if len(hosts) < workers {
+		workers = len(hosts)
+	}
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				result[i] = resolveHost(hosts[i], expected)
+			}
+		}()
+	}
+	for i := range hosts {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	return result
+}
+
+func resolveHost(host string, expected map[string]bool) HostResolution {
+	addresses, err := lookupHost(host)
+	if err != nil {
+		return HostResolution{Host: host, Addresses: []string{}, Status: DNSStatusUnresolved, Message: err.Error()}
+	}
+
+	resolution := HostResolution{Host: host, Addresses: addresses}
+	if len(expected) == 0 {
+		resolution.Status = DNSStatusPending
+		resolution.Message = "ingress has no load balancer address yet"
+		return resolution
+	}
+
+	for _, address := range addresses {
+		if expected[address] {
+			resolution.Status = DNSStatusMatching
+			return resolution
+		}
+	}
+
+	resolution.Status = DNSStatusMismatch
+	resolution.Message = fmt.Sprintf("%s resolves to %s, that is not an address of the ingress load balancer", host,
+		strings.Join(addresses, ", "))
+	return resolution
+}
+
+func lookupHost(host string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dnsLookupTimeout)
+	defer cancel()
+	return resolver.LookupHost(ctx, host)
+}
+
+// getIngressHosts returns distinct hostnames of the ingress rules and of the External-DNS annotation.
+func getIngressHosts(ingress *extensions.Ingress) []string {
+	hosts := make([]string, 0)
+	seen := make(map[string]bool)
+	add := func(host string) {
+		host = strings.TrimSuffix(strings.TrimSpace(host), ".")
+		if len(host) == 0 || strings.HasPrefix(host, "*") || seen[host] {
+			return
+		}
+		seen[host] = true
+		hosts = append(hosts, host)
+	}
+
+	for _, rule := range ingress.Spec.Rules {
+		add(rule.Host)
+	}
+	for _, host := range strings.Split(ingress.Annotations[externalDNSHostnameAnnotation], ",") {
+		add(host)
+	}
+	return hosts
+}
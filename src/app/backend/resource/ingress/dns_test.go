@@ -0,0 +1,137 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ingress
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	extensions "k8s.io/api/extensions/v1beta1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type fakeResolver map[string][]string
+
+func (self fakeResolver) LookupHost(ctx context.Context, host string) ([]string, error) {
+	addresses, ok := self[host]
+	if !ok {
+		return nil, fmt.Errorf("lookup %s: no such host", host)
+	}
+	return addresses, nil
+}
+
+// concurrencyResolver records the maximum number of lookups running at the same time.
+type concurrencyResolver struct {
+	mux     sync.Mutex
+	running int
+	max     int
+}
+
+func (self *concurrencyResolver) LookupHost(ctx context.Context, host string) ([]string, error) {
+	self.mux.Lock()
+	self.running++
+	if self.running > self.max {
+		self.max = self.running
+	}
+	self.mux.Unlock()
+
+	time.Sleep(5 * time.Millisecond)
+
+	self.mux.Lock()
+	self.running--
+	self.mux.Unlock()
+	return []string{"10.0.0.1"}, nil
+}
+
+func newDNSIngress(hosts []string, annotation string, addresses ...v1.LoadBalancerIngress) *extensions.Ingress {
+	ingress := &extensions.Ingress{
+		ObjectMeta: metaV1.ObjectMeta{Annotations: map[string]string{externalDNSHostnameAnnotation: annotation}},
+		Status:     extensions.IngressStatus{LoadBalancer: v1.LoadBalancerStatus{Ingress: addresses}},
+	}
+	for _, host := range hosts {
+		ingress.Spec.Rules = append(ingress.Spec.Rules, extensions.IngressRule{Host: host})
+	}
+	return ingress
+}
+
+func TestCheckHostResolution(t *testing.T) {
+	defer func(original hostResolver) { resolver = original }(resolver)
+	resolver = fakeResolver{
+		"shop.example.com":     {"10.0.0.1"},
+		"old.example.com":      {"10.0.0.9"},
+		"api.example.com":      {"10.0.0.2"},
+		"lb.elb.amazonaws.com": {"10.0.0.2"},
+		"www.example.com":      {"10.0.0.1", "10.0.0.3"},
+	}
+
+	cases := []struct {
+		ingress  *extensions.Ingress
+		expected []DNSStatus
+	}{
+		{
+			newDNSIngress([]string{"shop.example.com", "old.example.com", "missing.example.com", "*.example.com"},
+				"www.example.com, shop.example.com", v1.LoadBalancerIngress{IP: "10.0.0.1"}),
+			[]DNSStatus{DNSStatusMatching, DNSStatusMismatch, DNSStatusUnresolved, DNSStatusMatching},
+		},
+		{
+			newDNSIngress([]string{"api.example.com"}, "", v1.LoadBalancerIngress{Hostname: "lb.elb.amazonaws.com"}),
+			[]DNSStatus{DNSStatusMatching},
+		},
+		{
+			newDNSIngress([]string{"shop.example.com", ""}, ""),
+			[]DNSStatus{DNSStatusPending},
+		},
+	}
+
+	for _, c := range cases {
+		statuses := make([]DNSStatus, 0)
+		for _, resolution := range checkHostResolution(c.ingress) {
+			statuses = append(statuses, resolution.Status)
+		}
+
+		if !reflect.DeepEqual(statuses, c.expected) {
+			t.Errorf("Expected statuses %v, got %v", c.expected, statuses)
+		}
+	}
+}
+
+func TestCheckHostResolutionConcurrency(t *testing.T) {
+	defer func(original hostResolver) { resolver = original }(resolver)
+	counting := &concurrencyResolver{}
+	resolver = counting
+
+	hosts := make([]string, 0)
+	for i := 0; i < 5*maxConcurrentLookups; i++ {
+		hosts = append(hosts, fmt.Sprintf("host-%d.example.com", i))
+	}
+
+	result := checkHostResolution(newDNSIngress(hosts, "", v1.LoadBalancerIngress{IP: "10.0.0.1"}))
+	if len(result) != len(hosts) {
+		t.Fatalf("Expected %d resolutions, got %d", len(hosts), len(result))
+	}
+	for i, resolution := range result {
+		if resolution.Host != hosts[i] || resolution.Status != DNSStatusMatching {
+			t.Errorf("Unexpected resolution %#v of %s", resolution, hosts[i])
+		}
+	}
+	if counting.max > maxConcurrentLookups {
+		t.Errorf("Expected at most %d concurrent lookups, got %d", maxConcurrentLookups, counting.max)
+	}
+}
@@ -53,7 +53,14 @@ func GetIngressList(client client.Interface, namespace *common.NamespaceQuery,
 		return nil, criticalError
 	}
 
-	return toIngressList(ingressList.Items, nonCriticalErrors, dsQuery), nil
+	ingresses := make([]extensions.Ingress, 0)
+	for _, ingress := range ingressList.Items {
+		if namespace.Matches(ingress.Namespace) {
+			ingresses = append(ingresses, ingress)
+		}
+	}
+
+	return toIngressList(ingresses, nonCriticalErrors, dsQuery), nil
 }
 
 func getEndpoints(ingress *extensions.Ingress) []common.Endpoint {
@@ -0,0 +1,157 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package istio
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+)
+
+// meshResources maps kinds of supported Istio networking objects to their resources. Objects are read with the
+// dynamic client, so Dashboard does not depend on Istio client libraries and works when Istio is not installed.
+var meshResources = map[api.ResourceKind]schema.GroupVersionResource{
+	api.ResourceKindVirtualService:  {Group: "networking.istio.io", Version: "v1alpha3", Resource: "virtualservices"},
+	api.ResourceKindDestinationRule: {Group: "networking.istio.io", Version: "v1alpha3", Resource: "destinationrules"},
+	api.ResourceKindGateway:         {Group: "networking.istio.io", Version: "v1alpha3", Resource: "gateways"},
+}
+
+// MeshObject is a single Istio networking object, i.e. virtual service, destination rule or gateway.
+type MeshObject struct {
+	api.ObjectMeta `json:"objectMeta"`
+	api.TypeMeta   `json:"typeMeta"`
+
+	// Hosts the object applies to. Hosts of a virtual service, host of a destination rule or hosts of all
+	// servers of a gateway.
+	Hosts []string `json:"hosts"`
+}
+
+func getMeshResource(kind api.ResourceKind) (schema.GroupVersionResource, error) {
+	resource, ok := meshResources[kind]
+	if !ok {
+		return schema.GroupVersionResource{}, fmt.Errorf("unsupported service mesh kind: %s", kind)
+	}
+	return resource, nil
+}
+
+func toMeshObject(kind api.ResourceKind, obj *unstructured.Unstructured) MeshObject {
+	return MeshObject{
+		ObjectMeta: common.NewUnstructuredObjectMeta(obj),
+		TypeMeta:   api.NewTypeMeta(kind),
+		Hosts:      getHosts(kind, obj),
+	}
+}
+
+func getHosts(kind api.ResourceKind, obj *unstructured.Unstructured) []string {
+	hosts := make([]string, 0)
+	switch kind {
+	case api.ResourceKindVirtualService:
+		specHosts, _, _ := unstructured.NestedStringSlice(obj.Object, "spec", "hosts")
+		hosts = append(hosts, specHosts...)
+	case api.ResourceKindDestinationRule:
+		if host, _, _ := unstructured.NestedString(obj.Object, "spec", "host"); len(host) > 0 {
+			hosts = append(hosts, host)
+		}
+	case api.ResourceKindGateway:
+		servers, _, _ := unstructured.NestedSlice(obj.Object, "spec", "servers")
+		for _, server := range servers {
+			if server, ok := server.(map[string]interface{}); ok {
+				serverHosts, _, _ := unstructured.NestedStringSlice(server, "hosts")
+				hosts = append(hosts, serverHosts...)
+			}
+		}
+	}
+	return hosts
+}
+
+// getDestinationHosts returns hosts of all route destinations of the virtual service.
+func getDestinationHosts(obj *unstructured.Unstructured) []string {
+	hosts := make([]string, 0)
+	for _, protocol := range []string{"http", "tcp", "tls"} {
+		routes, _, _ := unstructured.NestedSlice(obj.Object, "spec", protocol)
+		for _, route := range routes {
+			route, ok := route.(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			destinations, _, _ := unstructured.NestedSlice(route, "route")
+			for _, destination := range destinations {
+				if destination, ok := destination.(map[string]interface{}); ok {
+					if host, _, _ := unstructured.NestedString(destination, "destination", "host"); len(host) > 0 {
+						hosts = append(hosts, host)
+					}
+				}
+			}
+		}
+	}
+	return hosts
+}
+
+// matchesService checks whether the host refers to the service. Short names are resolved in the namespace of the
+// object that contains the host. Wildcard hosts, i.e. *.default.svc.cluster.local, are matched by suffix.
+func matchesService(host, objectNamespace, namespace, name string) bool {
+	if host == name {
+		return objectNamespace == namespace
+	}
+
+	fqdn := fmt.Sprintf("%s.%s.svc.cluster.local", name, namespace)
+	if strings.HasPrefix(host, "*") {
+		return strings.HasSuffix(fqdn, strings.TrimPrefix(host, "*"))
+	}
+
+	return strings.HasPrefix(fqdn, host+".") || fqdn == host
+}
+
+// The code below allows to perform complex data section on []unstructured.Unstructured
+
+type MeshObjectCell unstructured.Unstructured
+
+func (self MeshObjectCell) GetProperty(name dataselect.PropertyName) dataselect.ComparableValue {
+	obj := unstructured.Unstructured(self)
+	switch name {
+	case dataselect.NameProperty:
+		return dataselect.StdComparableString(obj.GetName())
+	case dataselect.CreationTimestampProperty:
+		return dataselect.StdComparableTime(obj.GetCreationTimestamp().Time)
+	case dataselect.NamespaceProperty:
+		return dataselect.StdComparableString(obj.GetNamespace())
+	default:
+		// if name is not supported then just return a constant dummy value, sort will have no effect.
+		return nil
+	}
+}
+
+func toCells(std []unstructured.Unstructured) []dataselect.DataCell {
+	cells := make([]dataselect.DataCell, len(std))
+	for i := range std {
+		cells[i] = MeshObjectCell(std[i])
+	}
+	return cells
+}
+
+func fromCells(cells []dataselect.DataCell) []unstructured.Unstructured {
+	std := make([]unstructured.Unstructured, len(cells))
+	for i := range std {
+		std[i] = unstructured.Unstructured(cells[i].(MeshObjectCell))
+	}
+	return std
+}
@@ -0,0 +1,60 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package istio
+
+import (
+	"log"
+
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+)
+
+// MeshObjectDetail contains details of a single Istio networking object.
+type MeshObjectDetail struct {
+	// Extends list item structure.
+	MeshObject `json:",inline"`
+
+	// Spec of the object as stored in the apiserver. It is not interpreted, so that all versions of Istio are
+	// supported.
+	Spec map[string]interface{} `json:"spec"`
+
+	// List of non-critical errors, that occurred during resource retrieval.
+	Errors []error `json:"errors"`
+}
+
+// GetMeshObjectDetail returns details of the Istio networking object of given kind.
+func GetMeshObjectDetail(client dynamic.Interface, kind api.ResourceKind, namespace, name string) (
+	*MeshObjectDetail, error) {
+	log.Printf("Getting details of %s %s in %s namespace", kind, name, namespace)
+
+	resource, err := getMeshResource(kind)
+	if err != nil {
+		return nil, err
+	}
+
+	obj, err := client.Resource(resource).Namespace(namespace).Get(name, metaV1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	spec, _ := obj.Object["spec"].(map[string]interface{})
+	return &MeshObjectDetail{
+		MeshObject: toMeshObject(kind, obj),
+		Spec:       spec,
+		Errors:     []error{},
+	}, nil
+}
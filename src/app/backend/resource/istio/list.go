@@ -0,0 +1,102 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package istio
+
+import (
+	"log"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+)
+
+// MeshObjectList contains a list of Istio networking objects of a single kind.
+type MeshObjectList struct {
+	api.ListMeta `json:"listMeta"`
+
+	// Unordered list of objects.
+	Items []MeshObject `json:"items"`
+
+	// False when Istio resources of the kind are not served by the cluster.
+	Installed bool `json:"installed"`
+
+	// List of non-critical errors, that occurred during resource retrieval.
+	Errors []error `json:"errors"`
+}
+
+// GetMeshObjectList returns all Istio networking objects of given kind in the given namespace. Empty list is
+// returned when Istio is not installed in the cluster.
+func GetMeshObjectList(client dynamic.Interface, kind api.ResourceKind, namespace *common.NamespaceQuery,
+	dsQuery *dataselect.DataSelectQuery) (*MeshObjectList, error) {
+	log.Printf("Getting list of %s objects in %s namespace", kind, namespace.ToRequestParam())
+
+	objects, installed, err := listMeshObjects(client, kind, namespace)
+	nonCriticalErrors, criticalError := errors.HandleError(err)
+	if criticalError != nil {
+		return nil, criticalError
+	}
+
+	return toMeshObjectList(kind, objects, installed, nonCriticalErrors, dsQuery), nil
+}
+
+// listMeshObjects lists objects of given kind matching the namespace query. Second return value is false when
+// the resource of the kind is not served by the cluster.
+func listMeshObjects(client dynamic.Interface, kind api.ResourceKind, namespace *common.NamespaceQuery) (
+	[]unstructured.Unstructured, bool, error) {
+	resource, err := getMeshResource(kind)
+	if err != nil {
+		return nil, false, err
+	}
+
+	list, err := client.Resource(resource).Namespace(namespace.ToRequestParam()).List(api.ListEverything)
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			return []unstructured.Unstructured{}, false, nil
+		}
+		return []unstructured.Unstructured{}, true, err
+	}
+
+	objects := make([]unstructured.Unstructured, 0)
+	for _, item := range list.Items {
+		if namespace.Matches(item.GetNamespace()) {
+			objects = append(objects, item)
+		}
+	}
+	return objects, true, nil
+}
+
+func toMeshObjectList(kind api.ResourceKind, objects []unstructured.Unstructured, installed bool,
+	nonCriticalErrors []error, dsQuery *dataselect.DataSelectQuery) *MeshObjectList {
+	result := &MeshObjectList{
+		ListMeta:  api.ListMeta{TotalItems: len(objects)},
+		Items:     make([]MeshObject, 0),
+		Installed: installed,
+		Errors:    nonCriticalErrors,
+	}
+
+	cells, filteredTotal := dataselect.GenericDataSelectWithFilter(toCells(objects), dsQuery)
+	objects = fromCells(cells)
+	result.ListMeta = api.ListMeta{TotalItems: filteredTotal}
+
+	for i := range objects {
+		result.Items = append(result.Items, toMeshObject(kind, &objects[i]))
+	}
+
+	return result
+}
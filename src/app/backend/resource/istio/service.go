@@ -0,0 +1,140 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package istio
+
+import (
+	"log"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+)
+
+// ServiceMeshRouting contains Istio networking objects that route traffic to a service. It is shown on the
+// service detail page with links to the objects.
+type ServiceMeshRouting struct {
+	// Virtual services that have the service as a host or as a route destination.
+	VirtualServices []MeshObject `json:"virtualServices"`
+
+	// Destination rules that have the service as a host.
+	DestinationRules []MeshObject `json:"destinationRules"`
+
+	// Gateways that the virtual services are bound to.
+	Gateways []MeshObject `json:"gateways"`
+
+	// False when Istio resources are not served by the cluster.
+	Installed bool `json:"installed"`
+
+	// List of non-critical errors, that occurred during resource retrieval.
+	Errors []error `json:"errors"`
+}
+
+// GetServiceMeshRouting returns Istio networking objects from all namespaces that refer to the given service.
+func GetServiceMeshRouting(client dynamic.Interface, namespace, name string) (*ServiceMeshRouting, error) {
+	log.Printf("Getting service mesh routing of %s service in %s namespace", name, namespace)
+
+	allNamespaces := common.NewNamespaceQuery(nil)
+	result := &ServiceMeshRouting{
+		VirtualServices:  make([]MeshObject, 0),
+		DestinationRules: make([]MeshObject, 0),
+		Gateways:         make([]MeshObject, 0),
+	}
+
+	virtualServices, installed, err := listMeshObjects(client, api.ResourceKindVirtualService, allNamespaces)
+	nonCriticalErrors, criticalError := errors.HandleError(err)
+	if criticalError != nil {
+		return nil, criticalError
+	}
+	result.Installed = installed
+	if !installed {
+		result.Errors = nonCriticalErrors
+		return result, nil
+	}
+
+	gatewayNames := make(map[string]bool)
+	for i := range virtualServices {
+		vs := &virtualServices[i]
+		hosts := append(getHosts(api.ResourceKindVirtualService, vs), getDestinationHosts(vs)...)
+		if !matchesAnyHost(hosts, vs.GetNamespace(), namespace, name) {
+			continue
+		}
+
+		result.VirtualServices = append(result.VirtualServices, toMeshObject(api.ResourceKindVirtualService, vs))
+		for _, gateway := range getGatewayNames(vs) {
+			gatewayNames[gateway] = true
+		}
+	}
+
+	destinationRules, _, err := listMeshObjects(client, api.ResourceKindDestinationRule, allNamespaces)
+	nonCriticalErrors, criticalError = errors.AppendError(err, nonCriticalErrors)
+	if criticalError != nil {
+		return nil, criticalError
+	}
+	for i := range destinationRules {
+		dr := &destinationRules[i]
+		if matchesAnyHost(getHosts(api.ResourceKindDestinationRule, dr), dr.GetNamespace(), namespace, name) {
+			result.DestinationRules = append(result.DestinationRules, toMeshObject(api.ResourceKindDestinationRule, dr))
+		}
+	}
+
+	if len(gatewayNames) > 0 {
+		gateways, _, err := listMeshObjects(client, api.ResourceKindGateway, allNamespaces)
+		nonCriticalErrors, criticalError = errors.AppendError(err, nonCriticalErrors)
+		if criticalError != nil {
+			return nil, criticalError
+		}
+		for i := range gateways {
+			gateway := &gateways[i]
+			if gatewayNames[gateway.GetNamespace()+"/"+gateway.GetName()] {
+				result.Gateways = append(result.Gateways, toMeshObject(api.ResourceKindGateway, gateway))
+			}
+		}
+	}
+
+	result.Errors = nonCriticalErrors
+	return result, nil
+}
+
+func matchesAnyHost(hosts []string, objectNamespace, namespace, name string) bool {
+	for _, host := range hosts {
+		if matchesService(host, objectNamespace, namespace, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// getGatewayNames returns gateways of the virtual service in namespace/name form. Gateways referred to by short
+// name are in the namespace of the virtual service. Reserved "mesh" gateway, that stands for all sidecars, is
+// skipped.
+func getGatewayNames(vs *unstructured.Unstructured) []string {
+	gateways, _, _ := unstructured.NestedStringSlice(vs.Object, "spec", "gateways")
+	result := make([]string, 0)
+	for _, gateway := range gateways {
+		switch {
+		case gateway == "mesh":
+			continue
+		case strings.Contains(gateway, "/"):
+			result = append(result, gateway)
+		default:
+			result = append(result, vs.GetNamespace()+"/"+gateway)
+		}
+	}
+	return result
+}
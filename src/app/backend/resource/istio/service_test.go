@@ -0,0 +1,133 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package istio
+
+import (
+	"reflect"
+	"testing"
+
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakedynamic "k8s.io/client-go/dynamic/fake"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+)
+
+func newMeshObject(kind, namespace, name string, spec map[string]interface{}) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "networking.istio.io/v1alpha3",
+		"kind":       kind,
+		"metadata":   map[string]interface{}{"name": name, "namespace": namespace},
+		"spec":       spec,
+	}}
+}
+
+func TestGetServiceMeshRouting(t *testing.T) {
+	virtualServices := []*unstructured.Unstructured{
+		newMeshObject("VirtualService", "default", "by-host", map[string]interface{}{
+			"hosts":    []interface{}{"reviews"},
+			"gateways": []interface{}{"public", "mesh"},
+		}),
+		newMeshObject("VirtualService", "frontend", "by-destination", map[string]interface{}{
+			"hosts": []interface{}{"shop.example.com"},
+			"http": []interface{}{map[string]interface{}{
+				"route": []interface{}{map[string]interface{}{
+					"destination": map[string]interface{}{"host": "reviews.default.svc.cluster.local"},
+				}},
+			}},
+		}),
+		newMeshObject("VirtualService", "frontend", "short-name", map[string]interface{}{
+			"hosts": []interface{}{"reviews"},
+		}),
+	}
+	destinationRules := []*unstructured.Unstructured{
+		newMeshObject("DestinationRule", "default", "reviews", map[string]interface{}{"host": "reviews.default"}),
+		newMeshObject("DestinationRule", "default", "ratings", map[string]interface{}{"host": "ratings"}),
+	}
+	gateways := []*unstructured.Unstructured{
+		newMeshObject("Gateway", "default", "public", map[string]interface{}{
+			"servers": []interface{}{map[string]interface{}{"hosts": []interface{}{"*.example.com"}}},
+		}),
+		newMeshObject("Gateway", "default", "private", map[string]interface{}{}),
+	}
+
+	// Objects are created through resource clients, because the fake client guesses wrong resource of gateways
+	// from their kind.
+	client := fakedynamic.NewSimpleDynamicClient(runtime.NewScheme())
+	for kind, objects := range map[api.ResourceKind][]*unstructured.Unstructured{
+		api.ResourceKindVirtualService:  virtualServices,
+		api.ResourceKindDestinationRule: destinationRules,
+		api.ResourceKindGateway:         gateways,
+	} {
+		for _, obj := range objects {
+			_, err := client.Resource(meshResources[kind]).Namespace(obj.GetNamespace()).Create(obj,
+				metaV1.CreateOptions{})
+			if err != nil {
+				t.Fatalf("Cannot create %s %s: %s", kind, obj.GetName(), err)
+			}
+		}
+	}
+
+	routing, err := GetServiceMeshRouting(client, "default", "reviews")
+	if err != nil {
+		t.Fatalf("GetServiceMeshRouting() returned unexpected error: %s", err)
+	}
+
+	names := func(objects []MeshObject) []string {
+		result := make([]string, 0)
+		for _, object := range objects {
+			result = append(result, object.Namespace+"/"+object.Name)
+		}
+		return result
+	}
+
+	if actual, expected := names(routing.VirtualServices),
+		[]string{"default/by-host", "frontend/by-destination"}; !reflect.DeepEqual(actual, expected) {
+		t.Errorf("GetServiceMeshRouting() virtual services == %v, expected %v", actual, expected)
+	}
+	if actual, expected := names(routing.DestinationRules), []string{"default/reviews"}; !reflect.DeepEqual(actual,
+		expected) {
+		t.Errorf("GetServiceMeshRouting() destination rules == %v, expected %v", actual, expected)
+	}
+	if actual, expected := names(routing.Gateways), []string{"default/public"}; !reflect.DeepEqual(actual, expected) {
+		t.Errorf("GetServiceMeshRouting() gateways == %v, expected %v", actual, expected)
+	}
+	if !reflect.DeepEqual(routing.Gateways[0].Hosts, []string{"*.example.com"}) {
+		t.Errorf("GetServiceMeshRouting() gateway hosts == %v, expected [*.example.com]", routing.Gateways[0].Hosts)
+	}
+}
+
+func TestMatchesService(t *testing.T) {
+	cases := []struct {
+		host, objectNamespace string
+		expected              bool
+	}{
+		{"reviews", "default", true},
+		{"reviews", "other", false},
+		{"reviews.default", "other", true},
+		{"reviews.default.svc", "other", true},
+		{"reviews.default.svc.cluster.local", "other", true},
+		{"*.default.svc.cluster.local", "other", true},
+		{"reviews.other", "default", false},
+		{"rev", "default", false},
+	}
+
+	for _, c := range cases {
+		if actual := matchesService(c.host, c.objectNamespace, "default", "reviews"); actual != c.expected {
+			t.Errorf("matchesService(%q, %q) == %t, expected %t", c.host, c.objectNamespace, actual, c.expected)
+		}
+	}
+}
@@ -42,9 +42,10 @@ func GetJobDetail(client k8sClient.Interface, namespace, name string) (*JobDetai
 	}
 
 	podInfo, err := getJobPodInfo(client, jobData)
-	nonCriticalErrors, criticalError := errors.HandleError(err)
-	if criticalError != nil {
-		return nil, criticalError
+	nonCriticalErrors := errors.AppendOptionalError(err, make([]error, 0))
+	if err != nil {
+		fallback := common.GetPodInfo(jobData.Status.Active, jobData.Spec.Completions, nil)
+		podInfo = &fallback
 	}
 
 	job := toJobDetail(jobData, *podInfo, nonCriticalErrors)
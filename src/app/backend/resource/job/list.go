@@ -33,6 +33,9 @@ type JobList struct {
 	ListMeta          api.ListMeta       `json:"listMeta"`
 	CumulativeMetrics []metricapi.Metric `json:"cumulativeMetrics"`
 
+	// MetricsAvailable is false when there is no metrics backend and CumulativeMetrics is always empty.
+	MetricsAvailable bool `json:"metricsAvailable"`
+
 	// Basic information about resources status on the list.
 	Status common.ResourceStatus `json:"status"`
 
@@ -151,6 +154,7 @@ func ToJobList(jobs []batch.Job, pods []v1.Pod, events []v1.Event, nonCriticalEr
 		jobList.Jobs = append(jobList.Jobs, toJob(&job, &podInfo))
 	}
 
+	jobList.MetricsAvailable = metricClient != nil
 	cumulativeMetrics, err := metricPromises.GetMetrics()
 	jobList.CumulativeMetrics = cumulativeMetrics
 	if err != nil {
@@ -0,0 +1,154 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package knative
+
+import (
+	"strconv"
+
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+)
+
+const (
+	// servingGroup is the API group of Knative Serving.
+	servingGroup = "serving.knative.dev"
+
+	// serviceLabel is set by Knative on revisions to the name of the service they belong to.
+	serviceLabel = "serving.knative.dev/service"
+
+	// minScaleAnnotation is the annotation of revision template that sets minimal number of replicas. Revisions
+	// without it, or with value 0, are scaled to zero when they receive no traffic.
+	minScaleAnnotation = "autoscaling.knative.dev/minScale"
+)
+
+// TrafficTarget describes the part of the service traffic that is routed to a revision.
+type TrafficTarget struct {
+	// Name of the revision. Empty when the target follows the latest ready revision.
+	RevisionName string `json:"revisionName,omitempty"`
+
+	// True when the target follows the latest ready revision.
+	LatestRevision bool `json:"latestRevision"`
+
+	// Tag of the target, that gives the revision its own URL.
+	Tag string `json:"tag,omitempty"`
+
+	// Percentage of the traffic routed to the target.
+	Percent int64 `json:"percent"`
+
+	// URL of the tagged target.
+	URL string `json:"url,omitempty"`
+}
+
+// getServingResource returns the resource of Knative Serving in the version preferred by the cluster. Second
+// return value is false when Knative Serving is not installed.
+func getServingResource(discoveryClient discovery.DiscoveryInterface, resource string) (
+	schema.GroupVersionResource, bool, error) {
+	return common.FindServedResource(discoveryClient, resource, servingGroup)
+}
+
+// getCondition returns status and reason of the condition of given type. Status is Unknown when the object has
+// no such condition.
+func getCondition(obj *unstructured.Unstructured, conditionType string) (string, string) {
+	conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	for _, condition := range conditions {
+		condition, ok := condition.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		if t, _, _ := unstructured.NestedString(condition, "type"); t == conditionType {
+			status, _, _ := unstructured.NestedString(condition, "status")
+			reason, _, _ := unstructured.NestedString(condition, "reason")
+			return status, reason
+		}
+	}
+	return string(metaV1.ConditionUnknown), ""
+}
+
+// getTraffic returns traffic targets from the status of the service, as they are actually applied. Targets from
+// the spec are used when the status is not set yet.
+func getTraffic(obj *unstructured.Unstructured) []TrafficTarget {
+	targets, found, _ := unstructured.NestedSlice(obj.Object, "status", "traffic")
+	if !found {
+		targets, _, _ = unstructured.NestedSlice(obj.Object, "spec", "traffic")
+	}
+
+	result := make([]TrafficTarget, 0)
+	for _, target := range targets {
+		target, ok := target.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		trafficTarget := TrafficTarget{}
+		trafficTarget.RevisionName, _, _ = unstructured.NestedString(target, "revisionName")
+		trafficTarget.LatestRevision, _, _ = unstructured.NestedBool(target, "latestRevision")
+		trafficTarget.Tag, _, _ = unstructured.NestedString(target, "tag")
+		trafficTarget.Percent, _, _ = unstructured.NestedInt64(target, "percent")
+		trafficTarget.URL, _, _ = unstructured.NestedString(target, "url")
+		result = append(result, trafficTarget)
+	}
+	return result
+}
+
+// getMinScale returns minimal number of replicas set by the annotation of the revision. Invalid values are
+// treated as 0, the same as Knative does.
+func getMinScale(annotations map[string]string) int {
+	minScale, err := strconv.Atoi(annotations[minScaleAnnotation])
+	if err != nil || minScale < 0 {
+		return 0
+	}
+	return minScale
+}
+
+// The code below allows to perform complex data section on []unstructured.Unstructured
+
+type ObjectCell unstructured.Unstructured
+
+func (self ObjectCell) GetProperty(name dataselect.PropertyName) dataselect.ComparableValue {
+	obj := unstructured.Unstructured(self)
+	switch name {
+	case dataselect.NameProperty:
+		return dataselect.StdComparableString(obj.GetName())
+	case dataselect.CreationTimestampProperty:
+		return dataselect.StdComparableTime(obj.GetCreationTimestamp().Time)
+	case dataselect.NamespaceProperty:
+		return dataselect.StdComparableString(obj.GetNamespace())
+	default:
+		// if name is not supported then just return a constant dummy value, sort will have no effect.
+		return nil
+	}
+}
+
+func toCells(std []unstructured.Unstructured) []dataselect.DataCell {
+	cells := make([]dataselect.DataCell, len(std))
+	for i := range std {
+		cells[i] = ObjectCell(std[i])
+	}
+	return cells
+}
+
+func fromCells(cells []dataselect.DataCell) []unstructured.Unstructured {
+	std := make([]unstructured.Unstructured, len(cells))
+	for i := range std {
+		std[i] = unstructured.Unstructured(cells[i].(ObjectCell))
+	}
+	return std
+}
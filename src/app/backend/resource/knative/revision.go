@@ -0,0 +1,222 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package knative
+
+import (
+	"log"
+
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+)
+
+// Revision is a single immutable snapshot of the code and configuration of a Knative Service.
+type Revision struct {
+	api.ObjectMeta `json:"objectMeta"`
+	api.TypeMeta   `json:"typeMeta"`
+
+	// Name of the Knative Service the revision belongs to.
+	ServiceName string `json:"serviceName"`
+
+	// Status of the Ready condition of the revision: True, False or Unknown.
+	Ready string `json:"ready"`
+
+	// Image of the first container of the revision.
+	ContainerImage string `json:"containerImage"`
+
+	// Percentage of the service traffic routed to the revision.
+	TrafficPercent int64 `json:"trafficPercent"`
+
+	// Minimal number of replicas. Revisions with 0 are scaled to zero when they receive no traffic.
+	MinScale int `json:"minScale"`
+
+	// True when the revision is currently scaled to zero.
+	ScaledToZero bool `json:"scaledToZero"`
+}
+
+// RevisionList contains a list of Knative revisions.
+type RevisionList struct {
+	api.ListMeta `json:"listMeta"`
+
+	// Unordered list of revisions.
+	Items []Revision `json:"items"`
+
+	// False when Knative Serving is not installed in the cluster.
+	Installed bool `json:"installed"`
+
+	// List of non-critical errors, that occurred during resource retrieval.
+	Errors []error `json:"errors"`
+}
+
+// RevisionDetail contains details of a single Knative revision.
+type RevisionDetail struct {
+	// Extends list item structure.
+	Revision `json:",inline"`
+
+	// Spec of the revision as stored in the apiserver.
+	Spec map[string]interface{} `json:"spec"`
+
+	// List of non-critical errors, that occurred during resource retrieval.
+	Errors []error `json:"errors"`
+}
+
+// GetRevisionList returns all Knative revisions in the given namespace. Empty list is returned when Knative
+// Serving is not installed.
+func GetRevisionList(discoveryClient discovery.DiscoveryInterface, client dynamic.Interface,
+	namespace *common.NamespaceQuery, dsQuery *dataselect.DataSelectQuery) (*RevisionList, error) {
+	log.Printf("Getting list of Knative revisions in %s namespace", namespace.ToRequestParam())
+
+	result := &RevisionList{Items: make([]Revision, 0), Errors: make([]error, 0)}
+	resource, installed, err := getServingResource(discoveryClient, "revisions")
+	if err != nil {
+		return nil, err
+	}
+	if result.Installed = installed; !installed {
+		return result, nil
+	}
+
+	list, err := client.Resource(resource).Namespace(namespace.ToRequestParam()).List(api.ListEverything)
+	nonCriticalErrors, criticalError := errors.HandleError(err)
+	if criticalError != nil {
+		return nil, criticalError
+	}
+	if list == nil {
+		result.Errors = nonCriticalErrors
+		return result, nil
+	}
+
+	// Traffic split is stored in services, so they are needed to get traffic percentage of the revisions.
+	services, err := client.Resource(resource.GroupVersion().WithResource("services")).
+		Namespace(namespace.ToRequestParam()).List(api.ListEverything)
+	nonCriticalErrors = errors.AppendOptionalError(err, nonCriticalErrors)
+	result.Errors = nonCriticalErrors
+
+	knativeServices := make([]Service, 0)
+	if services != nil {
+		for i := range services.Items {
+			knativeServices = append(knativeServices, toService(&services.Items[i]))
+		}
+	}
+
+	revisions := make([]unstructured.Unstructured, 0)
+	for _, item := range list.Items {
+		if namespace.Matches(item.GetNamespace()) {
+			revisions = append(revisions, item)
+		}
+	}
+
+	cells, filteredTotal := dataselect.GenericDataSelectWithFilter(toCells(revisions), dsQuery)
+	revisions = fromCells(cells)
+	result.ListMeta = api.ListMeta{TotalItems: filteredTotal}
+	for i := range revisions {
+		result.Items = append(result.Items, toRevision(&revisions[i], knativeServices))
+	}
+
+	return result, nil
+}
+
+// GetRevisionDetail returns details of the Knative revision.
+func GetRevisionDetail(discoveryClient discovery.DiscoveryInterface, client dynamic.Interface, namespace,
+	name string) (*RevisionDetail, error) {
+	log.Printf("Getting details of %s Knative revision in %s namespace", name, namespace)
+
+	resource, installed, err := getServingResource(discoveryClient, "revisions")
+	if err != nil {
+		return nil, err
+	}
+	if !installed {
+		return nil, errors.NewNotFound("Knative Serving is not installed in the cluster")
+	}
+
+	obj, err := client.Resource(resource).Namespace(namespace).Get(name, metaV1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	nonCriticalErrors := make([]error, 0)
+	knativeServices := make([]Service, 0)
+	if serviceName := obj.GetLabels()[serviceLabel]; len(serviceName) > 0 {
+		service, err := client.Resource(resource.GroupVersion().WithResource("services")).Namespace(namespace).
+			Get(serviceName, metaV1.GetOptions{})
+		nonCriticalErrors = errors.AppendOptionalError(err, nonCriticalErrors)
+		if err == nil {
+			knativeServices = append(knativeServices, toService(service))
+		}
+	}
+
+	spec, _ := obj.Object["spec"].(map[string]interface{})
+	return &RevisionDetail{
+		Revision: toRevision(obj, knativeServices),
+		Spec:     spec,
+		Errors:   nonCriticalErrors,
+	}, nil
+}
+
+// getRevisions lists revisions matching the options. Traffic percentage is taken from the given services.
+func getRevisions(client dynamic.Interface, resource schema.GroupVersionResource, namespace string,
+	options metaV1.ListOptions, services []Service) ([]Revision, error) {
+	list, err := client.Resource(resource).Namespace(namespace).List(options)
+	if err != nil {
+		return make([]Revision, 0), err
+	}
+
+	revisions := make([]Revision, 0, len(list.Items))
+	for i := range list.Items {
+		revisions = append(revisions, toRevision(&list.Items[i], services))
+	}
+	return revisions, nil
+}
+
+func toRevision(obj *unstructured.Unstructured, services []Service) Revision {
+	revision := Revision{
+		ObjectMeta:  common.NewUnstructuredObjectMeta(obj),
+		TypeMeta:    api.NewTypeMeta(api.ResourceKindRevision),
+		ServiceName: obj.GetLabels()[serviceLabel],
+		MinScale:    getMinScale(obj.GetAnnotations()),
+	}
+	revision.Ready, _ = getCondition(obj, "Ready")
+
+	// Revision that receives no traffic is deactivated by the autoscaler, unless it has minimal scale set.
+	active, reason := getCondition(obj, "Active")
+	revision.ScaledToZero = active == string(metaV1.ConditionFalse) && reason == "NoTraffic"
+
+	containers, _, _ := unstructured.NestedSlice(obj.Object, "spec", "containers")
+	if len(containers) > 0 {
+		if container, ok := containers[0].(map[string]interface{}); ok {
+			revision.ContainerImage, _, _ = unstructured.NestedString(container, "image")
+		}
+	}
+
+	for _, service := range services {
+		if service.Namespace != revision.Namespace || service.Name != revision.ServiceName {
+			continue
+		}
+		for _, target := range service.Traffic {
+			if target.RevisionName == revision.Name ||
+				(target.LatestRevision && len(target.RevisionName) == 0 && service.LatestReadyRevisionName == revision.Name) {
+				revision.TrafficPercent += target.Percent
+			}
+		}
+	}
+
+	return revision
+}
@@ -0,0 +1,159 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package knative
+
+import (
+	"log"
+
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+)
+
+// Service is a single Knative Service.
+type Service struct {
+	api.ObjectMeta `json:"objectMeta"`
+	api.TypeMeta   `json:"typeMeta"`
+
+	// URL the service is reachable at.
+	URL string `json:"url"`
+
+	// Status of the Ready condition of the service: True, False or Unknown.
+	Ready string `json:"ready"`
+
+	// Name of the latest revision that is ready to serve traffic.
+	LatestReadyRevisionName string `json:"latestReadyRevisionName"`
+
+	// Name of the latest created revision, that may not be ready yet.
+	LatestCreatedRevisionName string `json:"latestCreatedRevisionName"`
+
+	// Split of the traffic between revisions.
+	Traffic []TrafficTarget `json:"traffic"`
+}
+
+// ServiceList contains a list of Knative Services.
+type ServiceList struct {
+	api.ListMeta `json:"listMeta"`
+
+	// Unordered list of services.
+	Items []Service `json:"items"`
+
+	// False when Knative Serving is not installed in the cluster.
+	Installed bool `json:"installed"`
+
+	// List of non-critical errors, that occurred during resource retrieval.
+	Errors []error `json:"errors"`
+}
+
+// ServiceDetail contains details of a single Knative Service together with its revisions.
+type ServiceDetail struct {
+	// Extends list item structure.
+	Service `json:",inline"`
+
+	// Revisions of the service.
+	Revisions []Revision `json:"revisions"`
+
+	// List of non-critical errors, that occurred during resource retrieval.
+	Errors []error `json:"errors"`
+}
+
+// GetServiceList returns all Knative Services in the given namespace. Empty list is returned when Knative Serving
+// is not installed.
+func GetServiceList(discoveryClient discovery.DiscoveryInterface, client dynamic.Interface,
+	namespace *common.NamespaceQuery, dsQuery *dataselect.DataSelectQuery) (*ServiceList, error) {
+	log.Printf("Getting list of Knative services in %s namespace", namespace.ToRequestParam())
+
+	result := &ServiceList{Items: make([]Service, 0), Errors: make([]error, 0)}
+	resource, installed, err := getServingResource(discoveryClient, "services")
+	if err != nil {
+		return nil, err
+	}
+	if result.Installed = installed; !installed {
+		return result, nil
+	}
+
+	list, err := client.Resource(resource).Namespace(namespace.ToRequestParam()).List(api.ListEverything)
+	nonCriticalErrors, criticalError := errors.HandleError(err)
+	if criticalError != nil {
+		return nil, criticalError
+	}
+	result.Errors = nonCriticalErrors
+	if list == nil {
+		return result, nil
+	}
+
+	services := make([]unstructured.Unstructured, 0)
+	for _, item := range list.Items {
+		if namespace.Matches(item.GetNamespace()) {
+			services = append(services, item)
+		}
+	}
+
+	cells, filteredTotal := dataselect.GenericDataSelectWithFilter(toCells(services), dsQuery)
+	services = fromCells(cells)
+	result.ListMeta = api.ListMeta{TotalItems: filteredTotal}
+	for i := range services {
+		result.Items = append(result.Items, toService(&services[i]))
+	}
+
+	return result, nil
+}
+
+// GetServiceDetail returns details of the Knative Service and its revisions.
+func GetServiceDetail(discoveryClient discovery.DiscoveryInterface, client dynamic.Interface, namespace,
+	name string) (*ServiceDetail, error) {
+	log.Printf("Getting details of %s Knative service in %s namespace", name, namespace)
+
+	resource, installed, err := getServingResource(discoveryClient, "services")
+	if err != nil {
+		return nil, err
+	}
+	if !installed {
+		return nil, errors.NewNotFound("Knative Serving is not installed in the cluster")
+	}
+
+	obj, err := client.Resource(resource).Namespace(namespace).Get(name, metaV1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	service := toService(obj)
+	revisions, err := getRevisions(client, resource.GroupVersion().WithResource("revisions"), namespace,
+		metaV1.ListOptions{LabelSelector: serviceLabel + "=" + name}, []Service{service})
+	nonCriticalErrors := errors.AppendOptionalError(err, make([]error, 0))
+
+	return &ServiceDetail{Service: service, Revisions: revisions, Errors: nonCriticalErrors}, nil
+}
+
+func toService(obj *unstructured.Unstructured) Service {
+	service := Service{
+		ObjectMeta: common.NewUnstructuredObjectMeta(obj),
+		TypeMeta:   api.NewTypeMeta(api.ResourceKindKnativeService),
+		Traffic:    getTraffic(obj),
+	}
+	service.Ready, _ = getCondition(obj, "Ready")
+	service.URL, _, _ = unstructured.NestedString(obj.Object, "status", "url")
+	service.LatestReadyRevisionName, _, _ = unstructured.NestedString(obj.Object, "status",
+		"latestReadyRevisionName")
+	service.LatestCreatedRevisionName, _, _ = unstructured.NestedString(obj.Object, "status",
+		"latestCreatedRevisionName")
+	return service
+}
@@ -0,0 +1,112 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package knative
+
+import (
+	"reflect"
+	"testing"
+
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	fakedynamic "k8s.io/client-go/dynamic/fake"
+	clienttesting "k8s.io/client-go/testing"
+
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+)
+
+func newRevision(name string, annotations map[string]interface{}, active, reason string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "serving.knative.dev/v1",
+		"kind":       "Revision",
+		"metadata": map[string]interface{}{
+			"name":        name,
+			"namespace":   "default",
+			"labels":      map[string]interface{}{serviceLabel: "hello"},
+			"annotations": annotations,
+		},
+		"spec": map[string]interface{}{
+			"containers": []interface{}{map[string]interface{}{"image": "example/hello:" + name}},
+		},
+		"status": map[string]interface{}{
+			"conditions": []interface{}{
+				map[string]interface{}{"type": "Ready", "status": "True"},
+				map[string]interface{}{"type": "Active", "status": active, "reason": reason},
+			},
+		},
+	}}
+}
+
+func TestGetServiceDetail(t *testing.T) {
+	service := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "serving.knative.dev/v1",
+		"kind":       "Service",
+		"metadata":   map[string]interface{}{"name": "hello", "namespace": "default"},
+		"status": map[string]interface{}{
+			"url":                     "http://hello.default.example.com",
+			"latestReadyRevisionName": "hello-2",
+			"conditions":              []interface{}{map[string]interface{}{"type": "Ready", "status": "True"}},
+			"traffic": []interface{}{
+				map[string]interface{}{"revisionName": "hello-1", "percent": int64(20), "tag": "old"},
+				map[string]interface{}{"latestRevision": true, "percent": int64(80)},
+			},
+		},
+	}}
+	discoveryClient := &fakediscovery.FakeDiscovery{Fake: &clienttesting.Fake{
+		Resources: []*metaV1.APIResourceList{{GroupVersion: "serving.knative.dev/v1"}},
+	}}
+	client := fakedynamic.NewSimpleDynamicClient(runtime.NewScheme(), service,
+		newRevision("hello-1", nil, "False", "NoTraffic"),
+		newRevision("hello-2", map[string]interface{}{minScaleAnnotation: "1"}, "True", ""))
+
+	detail, err := GetServiceDetail(discoveryClient, client, "default", "hello")
+	if err != nil {
+		t.Fatalf("GetServiceDetail() returned unexpected error: %s", err)
+	}
+
+	if detail.URL != "http://hello.default.example.com" || detail.Ready != "True" {
+		t.Errorf("GetServiceDetail() == %#v, expected ready service with URL", detail.Service)
+	}
+
+	expected := []Revision{
+		{ServiceName: "hello", Ready: "True", ContainerImage: "example/hello:hello-1", TrafficPercent: 20,
+			ScaledToZero: true},
+		{ServiceName: "hello", Ready: "True", ContainerImage: "example/hello:hello-2", TrafficPercent: 80,
+			MinScale: 1},
+	}
+	for i := range detail.Revisions {
+		detail.Revisions[i].ObjectMeta = expected[i].ObjectMeta
+		detail.Revisions[i].TypeMeta = expected[i].TypeMeta
+	}
+	if !reflect.DeepEqual(detail.Revisions, expected) {
+		t.Errorf("GetServiceDetail() revisions == %#v, expected %#v", detail.Revisions, expected)
+	}
+}
+
+func TestGetServiceListNotInstalled(t *testing.T) {
+	discoveryClient := &fakediscovery.FakeDiscovery{Fake: &clienttesting.Fake{}}
+	client := fakedynamic.NewSimpleDynamicClient(runtime.NewScheme())
+
+	list, err := GetServiceList(discoveryClient, client, common.NewNamespaceQuery(nil),
+		dataselect.NoDataSelect)
+	if err != nil {
+		t.Fatalf("GetServiceList() returned unexpected error: %s", err)
+	}
+	if list.Installed || len(list.Items) != 0 {
+		t.Errorf("GetServiceList() == %#v, expected empty list of not installed Knative Serving", list)
+	}
+}
@@ -0,0 +1,264 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubebench
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"strings"
+)
+
+// CheckStatus is a result of a single CIS benchmark check.
+type CheckStatus string
+
+// List of all statuses reported by kube-bench.
+const (
+	CheckStatusPass CheckStatus = "PASS"
+	CheckStatusFail CheckStatus = "FAIL"
+	CheckStatusWarn CheckStatus = "WARN"
+	CheckStatusInfo CheckStatus = "INFO"
+)
+
+// Check is a single CIS control check, i.e. 1.1.1.
+type Check struct {
+	ID          string      `json:"id"`
+	Description string      `json:"description"`
+	Status      CheckStatus `json:"status"`
+
+	// Steps needed to fix the check. Usually present only for failed and warned checks.
+	Remediation string `json:"remediation,omitempty"`
+
+	// True when the check is counted in the benchmark score.
+	Scored bool `json:"scored"`
+}
+
+// Section groups related checks of a control, i.e. 1.1 Master Node Configuration Files.
+type Section struct {
+	ID          string  `json:"id"`
+	Description string  `json:"description"`
+	Checks      []Check `json:"checks"`
+}
+
+// Totals is a number of checks per status.
+type Totals struct {
+	Pass int `json:"pass"`
+	Fail int `json:"fail"`
+	Warn int `json:"warn"`
+	Info int `json:"info"`
+}
+
+// Control is a top level group of the benchmark, i.e. 1 Master Node Security Configuration.
+type Control struct {
+	ID          string `json:"id"`
+	Description string `json:"description"`
+
+	// Type of nodes the control applies to, i.e. master or node. It is known only for JSON output.
+	NodeType string `json:"nodeType,omitempty"`
+
+	Sections []Section `json:"sections"`
+	Totals   Totals    `json:"totals"`
+}
+
+// jsonControl is a control as printed by kube-bench with the --json flag.
+type jsonControl struct {
+	ID       string `json:"id"`
+	Text     string `json:"text"`
+	NodeType string `json:"node_type"`
+	Tests    []struct {
+		Section string `json:"section"`
+		Desc    string `json:"desc"`
+		Results []struct {
+			TestNumber  string `json:"test_number"`
+			TestDesc    string `json:"test_desc"`
+			Remediation string `json:"remediation"`
+			Status      string `json:"status"`
+			Scored      bool   `json:"scored"`
+		} `json:"results"`
+	} `json:"tests"`
+}
+
+var (
+	checkLine       = regexp.MustCompile(`^\[(PASS|FAIL|WARN|INFO)\] (\d+(?:\.\d+)*) (.*)$`)
+	remediationLine = regexp.MustCompile(`^(\d+(?:\.\d+)+) (.*)$`)
+)
+
+// parseResults parses kube-bench output. Both JSON output, that is printed either as a single document or as one
+// document per control depending on the kube-bench version, and the default text output are supported.
+func parseResults(output []byte) []Control {
+	trimmed := bytes.TrimSpace(output)
+	if len(trimmed) > 0 && (trimmed[0] == '{' || trimmed[0] == '[') {
+		if controls, err := parseJSON(trimmed); err == nil {
+			return controls
+		}
+	}
+
+	return parseText(output)
+}
+
+func parseJSON(output []byte) ([]Control, error) {
+	controls := make([]Control, 0)
+	decoder := json.NewDecoder(bytes.NewReader(output))
+	for decoder.More() {
+		var raw json.RawMessage
+		if err := decoder.Decode(&raw); err != nil {
+			return nil, err
+		}
+
+		var parsed []jsonControl
+		if raw[0] == '[' {
+			if err := json.Unmarshal(raw, &parsed); err != nil {
+				return nil, err
+			}
+		} else {
+			document := struct {
+				Controls []jsonControl `json:"Controls"`
+				jsonControl
+			}{}
+			if err := json.Unmarshal(raw, &document); err != nil {
+				return nil, err
+			}
+
+			parsed = document.Controls
+			if len(document.ID) > 0 {
+				parsed = append(parsed, document.jsonControl)
+			}
+		}
+
+		for _, control := range parsed {
+			controls = append(controls, fromJSON(control))
+		}
+	}
+
+	return controls, nil
+}
+
+func fromJSON(control jsonControl) Control {
+	result := Control{
+		ID:          control.ID,
+		Description: control.Text,
+		NodeType:    control.NodeType,
+		Sections:    make([]Section, 0),
+	}
+
+	for _, test := range control.Tests {
+		section := Section{ID: test.Section, Description: test.Desc, Checks: make([]Check, 0)}
+		for _, r := range test.Results {
+			section.Checks = append(section.Checks, Check{
+				ID:          r.TestNumber,
+				Description: r.TestDesc,
+				Status:      CheckStatus(strings.ToUpper(r.Status)),
+				Remediation: r.Remediation,
+				Scored:      r.Scored,
+			})
+		}
+		result.Sections = append(result.Sections, section)
+	}
+
+	result.Totals = countChecks(result.Sections)
+	return result
+}
+
+// parseText parses the default kube-bench output. INFO lines with one and two level identifiers start controls
+// and sections. Remediations are printed separately after checks of a control and are matched by check ID.
+func parseText(output []byte) []Control {
+	controls := make([]Control, 0)
+	remediations := make(map[string]string)
+	remediationID := ""
+	inRemediations := false
+
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		if strings.HasPrefix(line, "== ") {
+			inRemediations = strings.HasPrefix(line, "== Remediations")
+			remediationID = ""
+			continue
+		}
+
+		if inRemediations {
+			if len(line) == 0 {
+				remediationID = ""
+			} else if match := remediationLine.FindStringSubmatch(line); match != nil {
+				remediationID = match[1]
+				remediations[remediationID] = match[2]
+			} else if len(remediationID) > 0 {
+				remediations[remediationID] += "\n" + line
+			}
+			continue
+		}
+
+		match := checkLine.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+
+		status, id, description := CheckStatus(match[1]), match[2], match[3]
+		depth := strings.Count(id, ".")
+		switch {
+		case status == CheckStatusInfo && depth == 0:
+			controls = append(controls, Control{ID: id, Description: description, Sections: make([]Section, 0)})
+		case len(controls) == 0:
+			continue
+		case status == CheckStatusInfo && depth == 1:
+			control := &controls[len(controls)-1]
+			control.Sections = append(control.Sections, Section{ID: id, Description: description,
+				Checks: make([]Check, 0)})
+		case len(controls[len(controls)-1].Sections) > 0:
+			sections := controls[len(controls)-1].Sections
+			section := &sections[len(sections)-1]
+			section.Checks = append(section.Checks, Check{
+				ID:          id,
+				Description: description,
+				Status:      status,
+				Scored:      strings.HasSuffix(description, "(Scored)") || strings.HasSuffix(description, "(Automated)"),
+			})
+		}
+	}
+
+	for i := range controls {
+		for j := range controls[i].Sections {
+			checks := controls[i].Sections[j].Checks
+			for k := range checks {
+				checks[k].Remediation = remediations[checks[k].ID]
+			}
+		}
+		controls[i].Totals = countChecks(controls[i].Sections)
+	}
+
+	return controls
+}
+
+func countChecks(sections []Section) Totals {
+	totals := Totals{}
+	for _, section := range sections {
+		for _, check := range section.Checks {
+			switch check.Status {
+			case CheckStatusPass:
+				totals.Pass++
+			case CheckStatusFail:
+				totals.Fail++
+			case CheckStatusWarn:
+				totals.Warn++
+			case CheckStatusInfo:
+				totals.Info++
+			}
+		}
+	}
+	return totals
+}
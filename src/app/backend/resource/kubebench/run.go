@@ -0,0 +1,274 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubebench
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/docker/distribution/reference"
+	batch "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/job"
+)
+
+const (
+	// appLabel is set on all kube-bench jobs, including the ones created with the upstream job manifests.
+	appLabel = "kube-bench"
+)
+
+// Run is a single kube-bench job together with the benchmark results printed by it.
+type Run struct {
+	ObjectMeta api.ObjectMeta `json:"objectMeta"`
+	TypeMeta   api.TypeMeta   `json:"typeMeta"`
+
+	// Status of the job. Results are available only for complete jobs.
+	Status job.JobStatus `json:"status"`
+
+	// Name of the node the benchmark was run on.
+	NodeName string `json:"nodeName,omitempty"`
+
+	CompletionTime *metaV1.Time `json:"completionTime,omitempty"`
+
+	Controls []Control `json:"controls"`
+	Totals   Totals    `json:"totals"`
+}
+
+// RunList contains kube-bench runs, by default ordered from the newest one.
+type RunList struct {
+	ListMeta api.ListMeta `json:"listMeta"`
+	Items    []Run        `json:"items"`
+
+	// List of non-critical errors, that occurred during resource retrieval.
+	Errors []error `json:"errors"`
+}
+
+// RunSpec is a specification of a kube-bench job started from Dashboard.
+type RunSpec struct {
+	// Optional list of benchmark targets, i.e. master, node or etcd. Targets are detected by kube-bench when empty.
+	Targets []string `json:"targets"`
+
+	// Optional name of the node to run the benchmark on. Master targets have to be run on a master node.
+	NodeName string `json:"nodeName"`
+}
+
+// readLogs returns logs of the pod. It is a variable, so that it can be replaced in tests, as fake clients do not
+// support reading logs.
+var readLogs = func(client kubernetes.Interface, namespace, name string) ([]byte, error) {
+	return client.CoreV1().Pods(namespace).GetLogs(name, &v1.PodLogOptions{}).Do().Raw()
+}
+
+// GetRunList returns kube-bench jobs labeled with app=kube-bench in the namespace, or in all namespaces when the
+// namespace is empty, together with results parsed from logs of complete jobs. Jobs are ordered from the newest
+// one, unless the data select query sorts them differently. Results are parsed only for jobs on the selected page.
+func GetRunList(client kubernetes.Interface, namespace string, dsQuery *dataselect.DataSelectQuery) (*RunList,
+	error) {
+	log.Printf("Getting list of kube-bench runs in %s namespace", namespace)
+
+	selector := labels.SelectorFromSet(labels.Set{"app": appLabel}).String()
+	jobs, err := client.BatchV1().Jobs(namespace).List(metaV1.ListOptions{LabelSelector: selector})
+	nonCriticalErrors, criticalError := errors.HandleError(err)
+	if criticalError != nil {
+		return nil, criticalError
+	}
+
+	result := &RunList{Items: make([]Run, 0), Errors: nonCriticalErrors}
+	if jobs == nil {
+		return result, nil
+	}
+
+	items := jobs.Items
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[j].ObjectMeta.CreationTimestamp.Before(&items[i].ObjectMeta.CreationTimestamp)
+	})
+
+	jobCells, filteredTotal := dataselect.GenericDataSelectWithFilter(job.ToCells(items), dsQuery)
+	for _, item := range job.FromCells(jobCells) {
+		run, err := toRun(client, item)
+		result.Errors = errors.AppendOptionalError(err, result.Errors)
+		result.Items = append(result.Items, run)
+	}
+
+	result.ListMeta.TotalItems = filteredTotal
+	return result, nil
+}
+
+// ValidateImage checks that the kube-bench image is pinned by digest, so that the image run with access to the host
+// can not be changed by pushing a tag.
+func ValidateImage(image string) error {
+	named, err := reference.ParseNormalizedNamed(image)
+	if err != nil {
+		return err
+	}
+	if _, ok := named.(reference.Digested); !ok {
+		return fmt.Errorf("image %s is not pinned by digest", image)
+	}
+	return nil
+}
+
+// CreateRun starts a kube-bench job with the image configured by the operator in the namespace. The job mounts
+// host directories read by the benchmark the same way as the job manifest provided by kube-bench does.
+func CreateRun(client kubernetes.Interface, namespace, image string, spec *RunSpec) (*Run, error) {
+	log.Printf("Creating kube-bench job in %s namespace", namespace)
+
+	if len(image) == 0 {
+		return nil, errors.NewForbidden("kube-bench image is not configured")
+	}
+	if err := ValidateImage(image); err != nil {
+		return nil, errors.NewForbidden(err.Error())
+	}
+
+	args := []string{"--json"}
+	if len(spec.Targets) > 0 {
+		args = append([]string{"run", "--targets", strings.Join(spec.Targets, ",")}, args...)
+	}
+
+	created, err := client.BatchV1().Jobs(namespace).Create(newJob(namespace, image, spec.NodeName, args))
+	if err != nil {
+		return nil, err
+	}
+
+	run := Run{
+		ObjectMeta: api.NewObjectMeta(created.ObjectMeta),
+		TypeMeta:   api.NewTypeMeta(api.ResourceKindJob),
+		Status:     job.JobStatus{Status: job.JobStatusRunning},
+		NodeName:   spec.NodeName,
+		Controls:   make([]Control, 0),
+	}
+	return &run, nil
+}
+
+func newJob(namespace, image, nodeName string, args []string) *batch.Job {
+	var backoffLimit int32
+	mounts := []struct{ name, hostPath, mountPath string }{
+		{"var-lib-etcd", "/var/lib/etcd", "/var/lib/etcd"},
+		{"var-lib-kubelet", "/var/lib/kubelet", "/var/lib/kubelet"},
+		{"etc-systemd", "/etc/systemd", "/etc/systemd"},
+		{"etc-kubernetes", "/etc/kubernetes", "/etc/kubernetes"},
+		{"usr-bin", "/usr/bin", "/usr/local/mount-from-host/bin"},
+	}
+
+	volumes := make([]v1.Volume, 0, len(mounts))
+	volumeMounts := make([]v1.VolumeMount, 0, len(mounts))
+	for _, mount := range mounts {
+		volumes = append(volumes, v1.Volume{
+			Name:         mount.name,
+			VolumeSource: v1.VolumeSource{HostPath: &v1.HostPathVolumeSource{Path: mount.hostPath}},
+		})
+		volumeMounts = append(volumeMounts, v1.VolumeMount{Name: mount.name, MountPath: mount.mountPath, ReadOnly: true})
+	}
+
+	podSpec := v1.PodSpec{
+		HostPID:       true,
+		RestartPolicy: v1.RestartPolicyNever,
+		NodeName:      nodeName,
+		Containers: []v1.Container{{
+			Name:         appLabel,
+			Image:        image,
+			Command:      []string{"kube-bench"},
+			Args:         args,
+			VolumeMounts: volumeMounts,
+		}},
+		Volumes: volumes,
+	}
+	if len(nodeName) > 0 {
+		// Master nodes are usually tainted, but the benchmark has to be able to run on them.
+		podSpec.Tolerations = []v1.Toleration{{Operator: v1.TolerationOpExists}}
+	}
+
+	labelSet := map[string]string{"app": appLabel}
+	return &batch.Job{
+		ObjectMeta: metaV1.ObjectMeta{GenerateName: appLabel + "-", Namespace: namespace, Labels: labelSet},
+		Spec: batch.JobSpec{
+			BackoffLimit: &backoffLimit,
+			Template: v1.PodTemplateSpec{
+				ObjectMeta: metaV1.ObjectMeta{Labels: labelSet},
+				Spec:       podSpec,
+			},
+		},
+	}
+}
+
+func toRun(client kubernetes.Interface, item batch.Job) (Run, error) {
+	run := Run{
+		ObjectMeta:     api.NewObjectMeta(item.ObjectMeta),
+		TypeMeta:       api.NewTypeMeta(api.ResourceKindJob),
+		Status:         getStatus(&item),
+		CompletionTime: item.Status.CompletionTime,
+		Controls:       make([]Control, 0),
+	}
+
+	selector := labels.SelectorFromSet(labels.Set{"job-name": item.Name}).String()
+	pods, err := client.CoreV1().Pods(item.Namespace).List(metaV1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return run, err
+	}
+
+	var pod *v1.Pod
+	for i := range pods.Items {
+		if len(pods.Items[i].Spec.NodeName) > 0 && len(run.NodeName) == 0 {
+			run.NodeName = pods.Items[i].Spec.NodeName
+		}
+		if pods.Items[i].Status.Phase == v1.PodSucceeded {
+			pod = &pods.Items[i]
+		}
+	}
+	if run.Status.Status != job.JobStatusComplete || pod == nil {
+		return run, nil
+	}
+
+	run.NodeName = pod.Spec.NodeName
+	output, err := readLogs(client, pod.Namespace, pod.Name)
+	if err != nil {
+		return run, err
+	}
+
+	run.Controls = parseResults(output)
+	if len(run.Controls) == 0 {
+		return run, fmt.Errorf("no benchmark results found in logs of pod %s/%s", pod.Namespace, pod.Name)
+	}
+	for _, control := range run.Controls {
+		run.Totals.Pass += control.Totals.Pass
+		run.Totals.Fail += control.Totals.Fail
+		run.Totals.Warn += control.Totals.Warn
+		run.Totals.Info += control.Totals.Info
+	}
+	return run, nil
+}
+
+func getStatus(item *batch.Job) job.JobStatus {
+	status := job.JobStatus{Status: job.JobStatusRunning}
+	for _, condition := range item.Status.Conditions {
+		if condition.Status != v1.ConditionTrue {
+			continue
+		}
+		if condition.Type == batch.JobComplete {
+			status.Status = job.JobStatusComplete
+		} else if condition.Type == batch.JobFailed {
+			status.Status = job.JobStatusFailed
+			status.Message = condition.Message
+		}
+	}
+	return status
+}
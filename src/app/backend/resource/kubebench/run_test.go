@@ -0,0 +1,236 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubebench
+
+import (
+	"reflect"
+	"testing"
+
+	batch "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/job"
+)
+
+const textOutput = `[INFO] 1 Master Node Security Configuration
+[INFO] 1.1 Master Node Configuration Files
+[PASS] 1.1.1 Ensure that the API server pod specification file permissions are set to 644 or more restrictive (Automated)
+[FAIL] 1.1.2 Ensure that the API server pod specification file ownership is set to root:root (Automated)
+[WARN] 1.1.9 Ensure that the Container Network Interface file permissions are set to 644 or more restrictive (Manual)
+
+== Remediations master ==
+1.1.2 Run the below command (based on the file location on your system) on the master node.
+chown root:root /etc/kubernetes/manifests/kube-apiserver.yaml
+
+1.1.9 Run the below command on the master node.
+
+== Summary master ==
+1 checks PASS
+1 checks FAIL
+1 checks WARN
+0 checks INFO
+`
+
+const jsonControl1 = `{"id":"4","version":"1.6","text":"Worker Node Security Configuration","node_type":"node",` +
+	`"tests":[{"section":"4.1","desc":"Worker Node Configuration Files","results":[` +
+	`{"test_number":"4.1.1","test_desc":"Ensure that the kubelet service file permissions are set to 644",` +
+	`"remediation":"chmod 644 /etc/systemd/system/kubelet.service.d/10-kubeadm.conf","status":"FAIL","scored":true},` +
+	`{"test_number":"4.1.2","test_desc":"Ensure that the kubelet service file ownership is set to root:root",` +
+	`"status":"PASS","scored":true}]}]}`
+
+var expectedJSONControl = Control{
+	ID:          "4",
+	Description: "Worker Node Security Configuration",
+	NodeType:    "node",
+	Sections: []Section{{ID: "4.1", Description: "Worker Node Configuration Files", Checks: []Check{
+		{ID: "4.1.1", Description: "Ensure that the kubelet service file permissions are set to 644",
+			Status: CheckStatusFail, Remediation: "chmod 644 /etc/systemd/system/kubelet.service.d/10-kubeadm.conf",
+			Scored: true},
+		{ID: "4.1.2", Description: "Ensure that the kubelet service file ownership is set to root:root",
+			Status: CheckStatusPass, Scored: true},
+	}}},
+	Totals: Totals{Pass: 1, Fail: 1},
+}
+
+func TestParseResults(t *testing.T) {
+	cases := []struct {
+		name     string
+		output   string
+		expected []Control
+	}{
+		{
+			"text output",
+			textOutput,
+			[]Control{{
+				ID:          "1",
+				Description: "Master Node Security Configuration",
+				Sections: []Section{{ID: "1.1", Description: "Master Node Configuration Files", Checks: []Check{
+					{ID: "1.1.1", Description: "Ensure that the API server pod specification file permissions are set " +
+						"to 644 or more restrictive (Automated)", Status: CheckStatusPass, Scored: true},
+					{ID: "1.1.2", Description: "Ensure that the API server pod specification file ownership is set to " +
+						"root:root (Automated)", Status: CheckStatusFail, Scored: true,
+						Remediation: "Run the below command (based on the file location on your system) on the master " +
+							"node.\nchown root:root /etc/kubernetes/manifests/kube-apiserver.yaml"},
+					{ID: "1.1.9", Description: "Ensure that the Container Network Interface file permissions are set " +
+						"to 644 or more restrictive (Manual)", Status: CheckStatusWarn,
+						Remediation: "Run the below command on the master node."},
+				}}},
+				Totals: Totals{Pass: 1, Fail: 1, Warn: 1},
+			}},
+		},
+		{
+			"json document per control",
+			jsonControl1 + "\n" + jsonControl1,
+			[]Control{expectedJSONControl, expectedJSONControl},
+		},
+		{
+			"json document with controls",
+			`{"Controls":[` + jsonControl1 + `],"Totals":{"total_pass":1,"total_fail":1}}`,
+			[]Control{expectedJSONControl},
+		},
+		{
+			"json array",
+			"[" + jsonControl1 + "]",
+			[]Control{expectedJSONControl},
+		},
+		{
+			"no results",
+			"error: unable to determine benchmark version",
+			[]Control{},
+		},
+	}
+
+	for _, c := range cases {
+		actual := parseResults([]byte(c.output))
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("%s: parseResults() ==\n%#v\nexpected\n%#v", c.name, actual, c.expected)
+		}
+	}
+}
+
+func TestGetRunList(t *testing.T) {
+	complete := &batch.Job{
+		ObjectMeta: metaV1.ObjectMeta{Name: "kube-bench-node", Namespace: "default",
+			Labels: map[string]string{"app": "kube-bench"}},
+		Status: batch.JobStatus{Conditions: []batch.JobCondition{
+			{Type: batch.JobComplete, Status: v1.ConditionTrue},
+		}},
+	}
+	running := &batch.Job{
+		ObjectMeta: metaV1.ObjectMeta{Name: "kube-bench-master", Namespace: "default",
+			Labels:            map[string]string{"app": "kube-bench"},
+			CreationTimestamp: metaV1.Unix(10, 0)},
+	}
+	unrelated := &batch.Job{ObjectMeta: metaV1.ObjectMeta{Name: "backup", Namespace: "default"}}
+	pod := &v1.Pod{
+		ObjectMeta: metaV1.ObjectMeta{Name: "kube-bench-node-abcde", Namespace: "default",
+			Labels: map[string]string{"job-name": "kube-bench-node"}},
+		Spec:   v1.PodSpec{NodeName: "node-1"},
+		Status: v1.PodStatus{Phase: v1.PodSucceeded},
+	}
+
+	defer func(original func(kubernetes.Interface, string, string) ([]byte, error)) { readLogs = original }(readLogs)
+	readLogs = func(client kubernetes.Interface, namespace, name string) ([]byte, error) {
+		return []byte(jsonControl1), nil
+	}
+
+	client := fake.NewSimpleClientset(complete, running, unrelated, pod)
+	actual, err := GetRunList(client, "default", dataselect.NoDataSelect)
+	if err != nil {
+		t.Fatalf("GetRunList() returned error: %s", err)
+	}
+
+	if len(actual.Items) != 2 || len(actual.Errors) != 0 {
+		t.Fatalf("GetRunList() returned %d runs and errors %v, expected 2 runs", len(actual.Items), actual.Errors)
+	}
+
+	if name := actual.Items[0].ObjectMeta.Name; name != "kube-bench-master" {
+		t.Errorf("Expected the newest run first, got %s", name)
+	}
+	if status := actual.Items[0].Status.Status; status != job.JobStatusRunning {
+		t.Errorf("Expected running status of kube-bench-master, got %s", status)
+	}
+
+	run := actual.Items[1]
+	if run.Status.Status != job.JobStatusComplete || run.NodeName != "node-1" {
+		t.Errorf("Expected complete run on node-1, got %s run on %q", run.Status.Status, run.NodeName)
+	}
+	if !reflect.DeepEqual(run.Controls, []Control{expectedJSONControl}) || run.Totals != (Totals{Pass: 1, Fail: 1}) {
+		t.Errorf("Unexpected results of kube-bench-node: %#v, totals %#v", run.Controls, run.Totals)
+	}
+
+	logsRead := 0
+	readLogs = func(client kubernetes.Interface, namespace, name string) ([]byte, error) {
+		logsRead++
+		return []byte(jsonControl1), nil
+	}
+	dsQuery := dataselect.NewDataSelectQuery(dataselect.NewPaginationQuery(1, 0),
+		dataselect.NewSortQuery([]string{"a", dataselect.NameProperty}), dataselect.NoFilter, dataselect.NoMetrics)
+	actual, err = GetRunList(client, "default", dsQuery)
+	if err != nil {
+		t.Fatalf("GetRunList() returned error: %s", err)
+	}
+
+	if len(actual.Items) != 1 || actual.ListMeta.TotalItems != 2 || actual.Items[0].ObjectMeta.Name !=
+		"kube-bench-master" {
+		t.Errorf("Expected first of 2 runs sorted by name, got %d runs of %d", len(actual.Items),
+			actual.ListMeta.TotalItems)
+	}
+	if logsRead != 0 {
+		t.Errorf("Expected results to be read only for the selected page, got %d reads", logsRead)
+	}
+}
+
+const testImage = "aquasec/kube-bench@sha256:0d17b565c37bcbd895e9d92315a05c1c3c9a29f762b011a10c54a66cd53c9b31"
+
+func TestCreateRun(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	spec := &RunSpec{Targets: []string{"master", "etcd"}, NodeName: "master-1"}
+	for _, image := range []string{"", "aquasec/kube-bench:latest"} {
+		if _, err := CreateRun(client, "default", image, spec); err == nil {
+			t.Errorf("CreateRun() should refuse image %q that is not pinned by digest", image)
+		}
+	}
+
+	_, err := CreateRun(client, "default", testImage, spec)
+	if err != nil {
+		t.Fatalf("CreateRun() returned error: %s", err)
+	}
+
+	jobs, _ := client.BatchV1().Jobs("default").List(metaV1.ListOptions{})
+	if len(jobs.Items) != 1 {
+		t.Fatalf("Expected one job to be created, got %d", len(jobs.Items))
+	}
+
+	podSpec := jobs.Items[0].Spec.Template.Spec
+	if !podSpec.HostPID || podSpec.NodeName != "master-1" || len(podSpec.Tolerations) != 1 {
+		t.Errorf("Unexpected pod spec of created job: %#v", podSpec)
+	}
+
+	container := podSpec.Containers[0]
+	expectedArgs := []string{"run", "--targets", "master,etcd", "--json"}
+	if container.Image != testImage || !reflect.DeepEqual(container.Args, expectedArgs) {
+		t.Errorf("Expected container %s with args %v, got %s with %v", testImage, expectedArgs, container.Image,
+			container.Args)
+	}
+	if len(container.VolumeMounts) != len(podSpec.Volumes) || len(podSpec.Volumes) != 5 {
+		t.Errorf("Expected 5 host volumes mounted, got %d volumes and %d mounts", len(podSpec.Volumes),
+			len(container.VolumeMounts))
+	}
+}
@@ -19,6 +19,7 @@ import (
 
 	"github.com/kubernetes/dashboard/src/app/backend/api"
 	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/gatekeeper"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/limitrange"
 	rq "github.com/kubernetes/dashboard/src/app/backend/resource/resourcequota"
 	v1 "k8s.io/api/core/v1"
@@ -38,6 +39,9 @@ type NamespaceDetail struct {
 	// ResourceLimits is list of limit ranges associated to the namespace
 	ResourceLimits []limitrange.LimitRangeItem `json:"resourceLimits"`
 
+	// Gatekeeper audit violations of the resource. Empty when Gatekeeper is not installed.
+	PolicyViolations []gatekeeper.Violation `json:"policyViolations"`
+
 	// List of non-critical errors, that occurred during resource retrieval.
 	Errors []error `json:"errors"`
 }
@@ -52,16 +56,10 @@ func GetNamespaceDetail(client k8sClient.Interface, name string) (*NamespaceDeta
 	}
 
 	resourceQuotaList, err := getResourceQuotas(client, *namespace)
-	nonCriticalErrors, criticalError := errors.HandleError(err)
-	if criticalError != nil {
-		return nil, criticalError
-	}
+	nonCriticalErrors := errors.AppendOptionalError(err, make([]error, 0))
 
 	resourceLimits, err := getLimitRanges(client, *namespace)
-	nonCriticalErrors, criticalError = errors.AppendError(err, nonCriticalErrors)
-	if criticalError != nil {
-		return nil, criticalError
-	}
+	nonCriticalErrors = errors.AppendOptionalError(err, nonCriticalErrors)
 
 	namespaceDetails := toNamespaceDetail(*namespace, resourceQuotaList, resourceLimits, nonCriticalErrors)
 	return &namespaceDetails, nil
@@ -82,10 +80,14 @@ func getResourceQuotas(client k8sClient.Interface, namespace v1.Namespace) (*rq.
 	list, err := client.CoreV1().ResourceQuotas(namespace.Name).List(api.ListEverything)
 
 	result := &rq.ResourceQuotaDetailList{
-		Items:    make([]rq.ResourceQuotaDetail, 0),
-		ListMeta: api.ListMeta{TotalItems: len(list.Items)},
+		Items: make([]rq.ResourceQuotaDetail, 0),
+	}
+	if err != nil {
+		return result, err
 	}
 
+	result.ListMeta = api.ListMeta{TotalItems: len(list.Items)}
+
 	for _, item := range list.Items {
 		detail := rq.ToResourceQuotaDetail(&item)
 		result.Items = append(result.Items, *detail)
@@ -95,12 +97,12 @@ func getResourceQuotas(client k8sClient.Interface, namespace v1.Namespace) (*rq.
 }
 
 func getLimitRanges(client k8sClient.Interface, namespace v1.Namespace) ([]limitrange.LimitRangeItem, error) {
+	resourceLimits := make([]limitrange.LimitRangeItem, 0)
 	list, err := client.CoreV1().LimitRanges(namespace.Name).List(api.ListEverything)
 	if err != nil {
-		return nil, err
+		return resourceLimits, err
 	}
 
-	resourceLimits := make([]limitrange.LimitRangeItem, 0)
 	for _, item := range list.Items {
 		list := limitrange.ToLimitRanges(&item)
 		resourceLimits = append(resourceLimits, list...)
@@ -44,6 +44,12 @@ type Namespace struct {
 
 	// Phase is the current lifecycle phase of the namespace.
 	Phase v1.NamespacePhase `json:"phase"`
+
+	// IsDefault is true for the default namespace from settings.
+	IsDefault bool `json:"isDefault"`
+
+	// IsPinned is true for namespaces pinned in settings.
+	IsPinned bool `json:"isPinned"`
 }
 
 // GetNamespaceListFromChannels returns a list of all namespaces in the cluster.
@@ -59,8 +65,9 @@ func GetNamespaceListFromChannels(channels *common.ResourceChannels, dsQuery *da
 	return toNamespaceList(namespaces.Items, nonCriticalErrors, dsQuery), nil
 }
 
-// GetNamespaceList returns a list of all namespaces in the cluster.
-func GetNamespaceList(client kubernetes.Interface, dsQuery *dataselect.DataSelectQuery) (*NamespaceList, error) {
+// GetNamespaceList returns a list of all namespaces in the cluster that match given namespace query.
+func GetNamespaceList(client kubernetes.Interface, nsQuery *common.NamespaceQuery,
+	dsQuery *dataselect.DataSelectQuery) (*NamespaceList, error) {
 	log.Println("Getting list of namespaces")
 	namespaces, err := client.CoreV1().Namespaces().List(api.ListEverything)
 
@@ -69,7 +76,32 @@ func GetNamespaceList(client kubernetes.Interface, dsQuery *dataselect.DataSelec
 		return nil, criticalError
 	}
 
-	return toNamespaceList(namespaces.Items, nonCriticalErrors, dsQuery), nil
+	return toNamespaceList(filterNamespaces(namespaces.Items, nsQuery), nonCriticalErrors, dsQuery), nil
+}
+
+// MarkPreferredNamespaces flags default and pinned namespaces on the list, so that UI can show them first.
+func MarkPreferredNamespaces(list *NamespaceList, defaultNamespace string, pinnedNamespaces []string) {
+	pinned := make(map[string]bool)
+	for _, namespace := range pinnedNamespaces {
+		pinned[namespace] = true
+	}
+
+	for i := range list.Namespaces {
+		name := list.Namespaces[i].ObjectMeta.Name
+		list.Namespaces[i].IsDefault = name == defaultNamespace
+		list.Namespaces[i].IsPinned = pinned[name]
+	}
+}
+
+func filterNamespaces(namespaces []v1.Namespace, nsQuery *common.NamespaceQuery) []v1.Namespace {
+	result := make([]v1.Namespace, 0)
+	for _, namespace := range namespaces {
+		if nsQuery.Matches(namespace.Name) {
+			result = append(result, namespace)
+		}
+	}
+
+	return result
 }
 
 func toNamespaceList(namespaces []v1.Namespace, nonCriticalErrors []error, dsQuery *dataselect.DataSelectQuery) *NamespaceList {
@@ -62,3 +62,22 @@ func TestGetNamespaceList(t *testing.T) {
 		}
 	}
 }
+
+func TestMarkPreferredNamespaces(t *testing.T) {
+	list := &NamespaceList{Namespaces: []Namespace{
+		{ObjectMeta: api.ObjectMeta{Name: "default"}},
+		{ObjectMeta: api.ObjectMeta{Name: "team-a"}},
+		{ObjectMeta: api.ObjectMeta{Name: "kube-system"}},
+	}}
+
+	MarkPreferredNamespaces(list, "default", []string{"team-a", "missing"})
+
+	expected := []Namespace{
+		{ObjectMeta: api.ObjectMeta{Name: "default"}, IsDefault: true},
+		{ObjectMeta: api.ObjectMeta{Name: "team-a"}, IsPinned: true},
+		{ObjectMeta: api.ObjectMeta{Name: "kube-system"}},
+	}
+	if !reflect.DeepEqual(list.Namespaces, expected) {
+		t.Errorf("MarkPreferredNamespaces() == %#v, expected %#v", list.Namespaces, expected)
+	}
+}
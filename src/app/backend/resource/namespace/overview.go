@@ -0,0 +1,155 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package namespace
+
+import (
+	"log"
+	"sort"
+
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sClient "k8s.io/client-go/kubernetes"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/configmap"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/event"
+	rq "github.com/kubernetes/dashboard/src/app/backend/resource/resourcequota"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/secret"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/service"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/workload"
+)
+
+// recentWarningEventLimit is a number of the most recent warning events included in the overview.
+const recentWarningEventLimit = 10
+
+// NamespaceOverview combines everything that the landing page of a namespace displays, so that it can be
+// loaded with a single request. Lists contain the first page of items and total number of items.
+type NamespaceOverview struct {
+	Namespace Namespace `json:"namespace"`
+
+	// Workloads counts pods by their status and workloads by their health.
+	Workloads workload.NamespaceStatus `json:"workloads"`
+
+	ServiceList   service.ServiceList     `json:"serviceList"`
+	ConfigMapList configmap.ConfigMapList `json:"configMapList"`
+	SecretList    secret.SecretList       `json:"secretList"`
+
+	// ResourceQuotaList contains usage of resource quotas of the namespace.
+	ResourceQuotaList *rq.ResourceQuotaDetailList `json:"resourceQuotaList"`
+
+	// WarningEvents are the most recent warning events, newest first.
+	WarningEvents []common.Event `json:"warningEvents"`
+
+	// List of non-critical errors, that occurred during resource retrieval.
+	Errors []error `json:"errors"`
+}
+
+// GetNamespaceOverview gets overview of the namespace. Only failure to get the namespace itself is critical,
+// parts of the overview, that could not be retrieved, are left empty and reported as non-critical errors. Parts
+// showing kinds, for which isHidden returns true, are left empty as well.
+func GetNamespaceOverview(client k8sClient.Interface, name string,
+	isHidden func(kind string) bool) (*NamespaceOverview, error) {
+	log.Printf("Getting overview of %s namespace\n", name)
+
+	namespace, err := client.CoreV1().Namespaces().Get(name, metaV1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	nsQuery := common.NewSameNamespaceQuery(name)
+	result := &NamespaceOverview{
+		Namespace:     toNamespace(*namespace),
+		ServiceList:   service.ServiceList{Services: make([]service.Service, 0), Errors: make([]error, 0)},
+		ConfigMapList: configmap.ConfigMapList{Items: make([]configmap.ConfigMap, 0), Errors: make([]error, 0)},
+		SecretList:    secret.SecretList{Secrets: make([]secret.Secret, 0), Errors: make([]error, 0)},
+		Errors:        make([]error, 0),
+	}
+
+	workloads, err := workload.GetStatusSummary(client, nsQuery, isHidden)
+	result.Errors = errors.AppendOptionalError(err, result.Errors)
+	if err == nil {
+		result.Workloads = workloads.Total
+		result.Workloads.Namespace = name
+		result.Errors = errors.MergeErrors(result.Errors, workloads.Errors)
+	}
+
+	if !isHidden("service") {
+		services, err := service.GetServiceList(client, nsQuery, dataselect.DefaultDataSelect)
+		result.Errors = errors.AppendOptionalError(err, result.Errors)
+		if err == nil {
+			result.ServiceList = *services
+		}
+	}
+
+	if !isHidden("configmap") {
+		configMaps, err := configmap.GetConfigMapList(client, nsQuery, dataselect.DefaultDataSelect)
+		result.Errors = errors.AppendOptionalError(err, result.Errors)
+		if err == nil {
+			result.ConfigMapList = *configMaps
+		}
+	}
+
+	if !isHidden("secret") {
+		secrets, err := secret.GetSecretList(client, nsQuery, dataselect.DefaultDataSelect)
+		result.Errors = errors.AppendOptionalError(err, result.Errors)
+		if err == nil {
+			result.SecretList = *secrets
+		}
+	}
+
+	if !isHidden("resourcequota") {
+		result.ResourceQuotaList, err = getResourceQuotas(client, *namespace)
+		result.Errors = errors.AppendOptionalError(err, result.Errors)
+	}
+
+	result.WarningEvents = make([]common.Event, 0)
+	if !isHidden("event") {
+		result.WarningEvents, err = getRecentWarningEvents(client, name)
+		result.Errors = errors.AppendOptionalError(err, result.Errors)
+	}
+
+	return result, nil
+}
+
+// getRecentWarningEvents returns the most recent warning events of the namespace, newest first.
+func getRecentWarningEvents(client k8sClient.Interface, namespace string) ([]common.Event, error) {
+	result := make([]common.Event, 0)
+	list, err := client.CoreV1().Events(namespace).List(api.ListEverything)
+	if err != nil {
+		return result, err
+	}
+
+	warnings := make([]v1.Event, 0)
+	for _, item := range event.FillEventsType(list.Items) {
+		if item.Type == v1.EventTypeWarning {
+			warnings = append(warnings, item)
+		}
+	}
+
+	sort.SliceStable(warnings, func(i, j int) bool {
+		return warnings[j].LastTimestamp.Before(&warnings[i].LastTimestamp)
+	})
+	if len(warnings) > recentWarningEventLimit {
+		warnings = warnings[:recentWarningEventLimit]
+	}
+
+	for _, warning := range warnings {
+		result = append(result, event.ToEvent(warning))
+	}
+	return result, nil
+}
@@ -0,0 +1,96 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package namespace
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	apps "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestGetNamespaceOverview(t *testing.T) {
+	meta := func(namespace, name string) metaV1.ObjectMeta {
+		return metaV1.ObjectMeta{Namespace: namespace, Name: name}
+	}
+	objects := []runtime.Object{
+		&v1.Namespace{ObjectMeta: metaV1.ObjectMeta{Name: "team-a"}},
+		&v1.Service{ObjectMeta: meta("team-a", "frontend")},
+		&v1.Service{ObjectMeta: meta("team-b", "backend")},
+		&v1.ConfigMap{ObjectMeta: meta("team-a", "settings")},
+		&v1.Secret{ObjectMeta: meta("team-a", "credentials")},
+		&v1.Pod{ObjectMeta: meta("team-a", "frontend-1"), Status: v1.PodStatus{Phase: v1.PodRunning}},
+		&apps.Deployment{ObjectMeta: meta("team-a", "frontend")},
+	}
+
+	now := time.Now()
+	for i := 0; i < recentWarningEventLimit+2; i++ {
+		objects = append(objects, &v1.Event{
+			ObjectMeta:    meta("team-a", fmt.Sprintf("warning-%d", i)),
+			Type:          v1.EventTypeWarning,
+			LastTimestamp: metaV1.NewTime(now.Add(time.Duration(i) * time.Minute)),
+		})
+	}
+	objects = append(objects, &v1.Event{ObjectMeta: meta("team-a", "normal"), Type: v1.EventTypeNormal,
+		LastTimestamp: metaV1.NewTime(now.Add(time.Hour))})
+
+	client := fake.NewSimpleClientset(objects...)
+	overview, err := GetNamespaceOverview(client, "team-a", func(string) bool { return false })
+	if err != nil {
+		t.Fatalf("GetNamespaceOverview() returned error: %s", err)
+	}
+
+	if overview.Namespace.ObjectMeta.Name != "team-a" || overview.ServiceList.ListMeta.TotalItems != 1 ||
+		overview.ConfigMapList.ListMeta.TotalItems != 1 || overview.SecretList.ListMeta.TotalItems != 1 {
+		t.Errorf("GetNamespaceOverview() returned lists of other namespaces or missed items: %#v", overview)
+	}
+	if overview.Workloads.Pods.Running != 1 || overview.Workloads.Deployments.Degraded != 1 {
+		t.Errorf("GetNamespaceOverview() returned invalid workload status: %#v", overview.Workloads)
+	}
+
+	if len(overview.WarningEvents) != recentWarningEventLimit {
+		t.Fatalf("GetNamespaceOverview() returned %d warning events, expected %d", len(overview.WarningEvents),
+			recentWarningEventLimit)
+	}
+	expectedNewest := fmt.Sprintf("warning-%d", recentWarningEventLimit+1)
+	if overview.WarningEvents[0].ObjectMeta.Name != expectedNewest {
+		t.Errorf("GetNamespaceOverview() returned %s as the newest warning, expected %s",
+			overview.WarningEvents[0].ObjectMeta.Name, expectedNewest)
+	}
+
+	if len(overview.Errors) > 0 {
+		t.Errorf("GetNamespaceOverview() returned unexpected errors: %v", overview.Errors)
+	}
+
+	if _, err := GetNamespaceOverview(client, "missing", func(string) bool { return false }); err == nil {
+		t.Error("GetNamespaceOverview() expected error for missing namespace")
+	}
+
+	overview, err = GetNamespaceOverview(client, "team-a", func(kind string) bool {
+		return kind == "secret" || kind == "event"
+	})
+	if err != nil {
+		t.Fatalf("GetNamespaceOverview() returned error: %s", err)
+	}
+	if len(overview.SecretList.Secrets) != 0 || len(overview.WarningEvents) != 0 ||
+		overview.ConfigMapList.ListMeta.TotalItems != 1 {
+		t.Errorf("GetNamespaceOverview() returned hidden kinds or missed visible ones: %#v", overview)
+	}
+}
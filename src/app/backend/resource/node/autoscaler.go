@@ -0,0 +1,322 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package node
+
+import (
+	"bufio"
+	"log"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	client "k8s.io/client-go/kubernetes"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+)
+
+const (
+	// Name, namespace and data key of the config map that cluster autoscaler writes its status to.
+	autoscalerStatusName      = "cluster-autoscaler-status"
+	autoscalerStatusNamespace = "kube-system"
+	autoscalerStatusKey       = "status"
+
+	// Annotation of nodes that cluster autoscaler must not remove.
+	scaleDownDisabledAnnotation = "cluster-autoscaler.kubernetes.io/scale-down-disabled"
+
+	// Format of times in the autoscaler status, that is the format of time.Time.String.
+	autoscalerTimeFormat = "2006-01-02 15:04:05.999999999 -0700 MST"
+)
+
+// nodeGroupLabels are labels that cloud providers put on nodes to identify their node group.
+var nodeGroupLabels = []string{
+	"cloud.google.com/gke-nodepool",
+	"eks.amazonaws.com/nodegroup",
+	"alpha.eksctl.io/nodegroup-name",
+	"kubernetes.azure.com/agentpool",
+	"agentpool",
+	"kops.k8s.io/instancegroup",
+	"node.kubernetes.io/instancegroup",
+}
+
+var autoscalerCountRegexp = regexp.MustCompile(`(\w+)=(\d+)`)
+
+// AutoscalerCondition is the state of a single aspect of cluster autoscaler activity, i.e. scale-up.
+type AutoscalerCondition struct {
+	// Status reported by the autoscaler, i.e. Healthy, InProgress or NoCandidates.
+	Status string `json:"status"`
+
+	// Node counts reported together with the status, i.e. ready=3 or candidates=1.
+	Counts map[string]int `json:"counts"`
+
+	// Time of the last check and of the last status change.
+	LastProbeTime      *metaV1.Time `json:"lastProbeTime,omitempty"`
+	LastTransitionTime *metaV1.Time `json:"lastTransitionTime,omitempty"`
+}
+
+// AutoscalerStatus is the state of cluster autoscaler for the whole cluster or a single node group.
+type AutoscalerStatus struct {
+	Health    *AutoscalerCondition `json:"health,omitempty"`
+	ScaleUp   *AutoscalerCondition `json:"scaleUp,omitempty"`
+	ScaleDown *AutoscalerCondition `json:"scaleDown,omitempty"`
+}
+
+// NodeGroup is a group of nodes with the same configuration, that is scaled by the cloud provider.
+type NodeGroup struct {
+	// Name of the group. It is the name reported by cluster autoscaler, or the value of the node group label of
+	// its nodes when the group is not autoscaled.
+	Name string `json:"name"`
+
+	// Minimal, maximal and target size of the group. Known only for groups managed by cluster autoscaler.
+	MinSize    *int `json:"minSize,omitempty"`
+	MaxSize    *int `json:"maxSize,omitempty"`
+	TargetSize *int `json:"targetSize,omitempty"`
+
+	// Names of the nodes of the group.
+	Nodes []string `json:"nodes"`
+
+	// Number of ready nodes of the group.
+	ReadyNodes int `json:"readyNodes"`
+
+	// Names of the nodes that cluster autoscaler must not remove.
+	ScaleDownDisabledNodes []string `json:"scaleDownDisabledNodes"`
+
+	// Autoscaler status of the group. Nil when the group is not managed by cluster autoscaler.
+	Autoscaler *AutoscalerStatus `json:"autoscaler,omitempty"`
+}
+
+// NodeGroupOverview describes node groups of the cluster and activity of cluster autoscaler.
+type NodeGroupOverview struct {
+	// False when cluster autoscaler does not report its status in the cluster.
+	AutoscalerInstalled bool `json:"autoscalerInstalled"`
+
+	// Time when cluster autoscaler last updated its status.
+	LastUpdated *metaV1.Time `json:"lastUpdated,omitempty"`
+
+	// Autoscaler status of the whole cluster.
+	ClusterWide *AutoscalerStatus `json:"clusterWide,omitempty"`
+
+	// Node groups sorted by name.
+	NodeGroups []NodeGroup `json:"nodeGroups"`
+
+	// Number of nodes that do not belong to any known node group.
+	UngroupedNodes int `json:"ungroupedNodes"`
+
+	// List of non-critical errors, that occurred during resource retrieval.
+	Errors []error `json:"errors"`
+}
+
+// GetNodeGroupOverview returns node groups of the cluster, built from node group labels of the nodes and from the
+// status of cluster autoscaler.
+func GetNodeGroupOverview(client client.Interface) (*NodeGroupOverview, error) {
+	log.Print("Getting node group overview")
+
+	nodes, err := client.CoreV1().Nodes().List(api.ListEverything)
+	nonCriticalErrors, criticalError := errors.HandleError(err)
+	if criticalError != nil {
+		return nil, criticalError
+	}
+
+	result := &NodeGroupOverview{NodeGroups: make([]NodeGroup, 0), Errors: nonCriticalErrors}
+	statusMap, err := client.CoreV1().ConfigMaps(autoscalerStatusNamespace).Get(autoscalerStatusName,
+		metaV1.GetOptions{})
+	if err != nil && !errors.IsNotFoundError(err) {
+		result.Errors = errors.AppendOptionalError(err, result.Errors)
+	}
+
+	groups := make([]NodeGroup, 0)
+	if err == nil {
+		var clusterWide *AutoscalerStatus
+		clusterWide, groups = parseAutoscalerStatus(statusMap.Data[autoscalerStatusKey])
+		result.AutoscalerInstalled = true
+		result.ClusterWide = clusterWide
+		result.LastUpdated = parseAutoscalerTime(statusMap.Annotations["cluster-autoscaler.kubernetes.io/last-updated"])
+	}
+
+	result.NodeGroups, result.UngroupedNodes = groupNodes(nodes.Items, groups)
+	return result, nil
+}
+
+// groupNodes assigns nodes to the node groups by their node group label. Cloud providers do not always name the
+// group the same way in the label and in the autoscaler, i.e. autoscaler on EKS reports name of the autoscaling
+// group, that contains the node group name. Nodes are assigned to such groups when the name matches exactly or
+// is a part of the autoscaler group name. Groups that are not known to the autoscaler are added.
+func groupNodes(nodes []v1.Node, groups []NodeGroup) ([]NodeGroup, int) {
+	ungrouped := 0
+	for _, node := range nodes {
+		label := getNodeGroupLabel(node)
+		if len(label) == 0 {
+			ungrouped++
+			continue
+		}
+
+		group := findNodeGroup(groups, label)
+		if group == nil {
+			groups = append(groups, NodeGroup{Name: label, Nodes: make([]string, 0),
+				ScaleDownDisabledNodes: make([]string, 0)})
+			group = &groups[len(groups)-1]
+		}
+
+		group.Nodes = append(group.Nodes, node.Name)
+		if getNodeConditionStatus(node, v1.NodeReady) == v1.ConditionTrue {
+			group.ReadyNodes++
+		}
+		if node.Annotations[scaleDownDisabledAnnotation] == "true" {
+			group.ScaleDownDisabledNodes = append(group.ScaleDownDisabledNodes, node.Name)
+		}
+	}
+
+	sort.Slice(groups, func(i, j int) bool { return groups[i].Name < groups[j].Name })
+	return groups, ungrouped
+}
+
+func findNodeGroup(groups []NodeGroup, label string) *NodeGroup {
+	for i := range groups {
+		if groups[i].Name == label {
+			return &groups[i]
+		}
+	}
+	for i := range groups {
+		if groups[i].Autoscaler != nil && strings.Contains(groups[i].Name, label) {
+			return &groups[i]
+		}
+	}
+	return nil
+}
+
+func getNodeGroupLabel(node v1.Node) string {
+	for _, label := range nodeGroupLabels {
+		if value := node.Labels[label]; len(value) > 0 {
+			return value
+		}
+	}
+	return ""
+}
+
+// parseAutoscalerStatus parses human readable status written by cluster autoscaler. It consists of Cluster-wide
+// and NodeGroups sections, i.e.:
+//
+//	Cluster-wide:
+//	  Health:      Healthy (ready=3 unready=0 notStarted=0 longNotStarted=0 registered=3 longUnregistered=0)
+//	               LastProbeTime:      2020-01-01 10:00:00.1 +0000 UTC
+//	               LastTransitionTime: 2020-01-01 09:00:00.1 +0000 UTC
+//	  ScaleUp:     NoActivity (ready=3 registered=3)
+//	NodeGroups:
+//	  Name:        pool-1
+//	  Health:      Healthy (ready=3 ... cloudProviderTarget=3 (minSize=1, maxSize=10))
+//
+// Unknown lines are ignored, so that newer versions of the autoscaler are still partially understood.
+func parseAutoscalerStatus(status string) (*AutoscalerStatus, []NodeGroup) {
+	var clusterWide *AutoscalerStatus
+	groups := make([]NodeGroup, 0)
+	var current *AutoscalerStatus
+	var condition *AutoscalerCondition
+
+	scanner := bufio.NewScanner(strings.NewReader(status))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == "Cluster-wide:":
+			clusterWide = &AutoscalerStatus{}
+			current, condition = clusterWide, nil
+			continue
+		case line == "NodeGroups:":
+			current, condition = nil, nil
+			continue
+		}
+
+		i := strings.Index(line, ":")
+		if i < 0 {
+			continue
+		}
+		key, value := line[:i], strings.TrimSpace(line[i+1:])
+
+		switch key {
+		case "Name":
+			groups = append(groups, NodeGroup{Name: value, Nodes: make([]string, 0),
+				ScaleDownDisabledNodes: make([]string, 0), Autoscaler: &AutoscalerStatus{}})
+			current, condition = groups[len(groups)-1].Autoscaler, nil
+		case "Health", "ScaleUp", "ScaleDown":
+			if current == nil {
+				continue
+			}
+			condition = parseAutoscalerCondition(value)
+			switch key {
+			case "Health":
+				current.Health = condition
+			case "ScaleUp":
+				current.ScaleUp = condition
+			case "ScaleDown":
+				current.ScaleDown = condition
+			}
+		case "LastProbeTime":
+			if condition != nil {
+				condition.LastProbeTime = parseAutoscalerTime(value)
+			}
+		case "LastTransitionTime":
+			if condition != nil {
+				condition.LastTransitionTime = parseAutoscalerTime(value)
+			}
+		}
+	}
+
+	for i := range groups {
+		if health := groups[i].Autoscaler.Health; health != nil {
+			groups[i].MinSize = getCount(health.Counts, "minSize")
+			groups[i].MaxSize = getCount(health.Counts, "maxSize")
+			groups[i].TargetSize = getCount(health.Counts, "cloudProviderTarget")
+		}
+	}
+	return clusterWide, groups
+}
+
+// parseAutoscalerCondition parses condition in the "Status (key=value ...)" format.
+func parseAutoscalerCondition(value string) *AutoscalerCondition {
+	condition := &AutoscalerCondition{Status: value, Counts: make(map[string]int)}
+	if i := strings.Index(value, " "); i >= 0 {
+		condition.Status = value[:i]
+		for _, match := range autoscalerCountRegexp.FindAllStringSubmatch(value[i:], -1) {
+			count, err := strconv.Atoi(match[2])
+			if err == nil {
+				condition.Counts[match[1]] = count
+			}
+		}
+	}
+	return condition
+}
+
+func parseAutoscalerTime(value string) *metaV1.Time {
+	// Times written with time.Time.String can contain monotonic clock reading, i.e. "m=+3600.001".
+	if i := strings.Index(value, " m="); i >= 0 {
+		value = value[:i]
+	}
+
+	parsed, err := time.Parse(autoscalerTimeFormat, value)
+	if err != nil {
+		return nil
+	}
+	return &metaV1.Time{Time: parsed}
+}
+
+func getCount(counts map[string]int, key string) *int {
+	if count, ok := counts[key]; ok {
+		return &count
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package node
+
+import (
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+const testAutoscalerStatus = `Cluster-autoscaler status at 2020-01-01 10:00:00.123 +0000 UTC:
+Cluster-wide:
+  Health:      Healthy (ready=2 unready=0 notStarted=0 longNotStarted=0 registered=2 longUnregistered=0)
+               LastProbeTime:      2020-01-01 10:00:00.123 +0000 UTC m=+3600.001
+               LastTransitionTime: 2020-01-01 09:00:00.123 +0000 UTC m=+0.001
+  ScaleUp:     InProgress (ready=2 registered=2)
+               LastProbeTime:      2020-01-01 10:00:00.123 +0000 UTC
+               LastTransitionTime: 2020-01-01 09:55:00 +0000 UTC
+  ScaleDown:   NoCandidates (candidates=0)
+               LastProbeTime:      2020-01-01 10:00:00.123 +0000 UTC
+               LastTransitionTime: 2020-01-01 09:00:00.123 +0000 UTC
+
+NodeGroups:
+  Name:        https://content.googleapis.com/compute/v1/projects/p/zones/z/instanceGroups/gke-c-default-pool-1-grp
+  Health:      Healthy (ready=2 unready=0 notStarted=0 longNotStarted=0 registered=2 longUnregistered=0 cloudProviderTarget=3 (minSize=1, maxSize=5))
+               LastProbeTime:      2020-01-01 10:00:00.123 +0000 UTC
+               LastTransitionTime: 2020-01-01 09:00:00.123 +0000 UTC
+  ScaleUp:     InProgress (ready=2 cloudProviderTarget=3)
+               LastProbeTime:      2020-01-01 10:00:00.123 +0000 UTC
+               LastTransitionTime: 2020-01-01 09:55:00 +0000 UTC
+  ScaleDown:   NoCandidates (candidates=0)
+               LastProbeTime:      2020-01-01 10:00:00.123 +0000 UTC
+               LastTransitionTime: 2020-01-01 09:00:00.123 +0000 UTC
+`
+
+func newGroupNode(name, group string, ready v1.ConditionStatus, annotations map[string]string) *v1.Node {
+	labels := map[string]string{}
+	if len(group) > 0 {
+		labels["cloud.google.com/gke-nodepool"] = group
+	}
+	return &v1.Node{
+		ObjectMeta: metaV1.ObjectMeta{Name: name, Labels: labels, Annotations: annotations},
+		Status:     v1.NodeStatus{Conditions: []v1.NodeCondition{{Type: v1.NodeReady, Status: ready}}},
+	}
+}
+
+func TestGetNodeGroupOverview(t *testing.T) {
+	statusMap := &v1.ConfigMap{
+		ObjectMeta: metaV1.ObjectMeta{Name: autoscalerStatusName, Namespace: autoscalerStatusNamespace},
+		Data:       map[string]string{autoscalerStatusKey: testAutoscalerStatus},
+	}
+	client := fake.NewSimpleClientset(statusMap,
+		newGroupNode("node-1", "default-pool", v1.ConditionTrue, nil),
+		newGroupNode("node-2", "default-pool", v1.ConditionTrue,
+			map[string]string{scaleDownDisabledAnnotation: "true"}),
+		newGroupNode("node-3", "gpu-pool", v1.ConditionFalse, nil),
+		newGroupNode("node-4", "", v1.ConditionTrue, nil))
+
+	overview, err := GetNodeGroupOverview(client)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !overview.AutoscalerInstalled || overview.UngroupedNodes != 1 || len(overview.NodeGroups) != 2 {
+		t.Fatalf("Unexpected overview %#v", overview)
+	}
+	if overview.ClusterWide.ScaleUp.Status != "InProgress" ||
+		overview.ClusterWide.Health.LastProbeTime.Format("15:04:05") != "10:00:00" {
+		t.Errorf("Unexpected cluster wide status %#v", overview.ClusterWide)
+	}
+
+	gpu := overview.NodeGroups[0]
+	if gpu.Name != "gpu-pool" || gpu.Autoscaler != nil || gpu.ReadyNodes != 0 ||
+		!reflect.DeepEqual(gpu.Nodes, []string{"node-3"}) {
+		t.Errorf("Unexpected group without autoscaler %#v", gpu)
+	}
+
+	pool := overview.NodeGroups[1]
+	if *pool.MinSize != 1 || *pool.MaxSize != 5 || *pool.TargetSize != 3 || pool.ReadyNodes != 2 ||
+		!reflect.DeepEqual(pool.Nodes, []string{"node-1", "node-2"}) ||
+		!reflect.DeepEqual(pool.ScaleDownDisabledNodes, []string{"node-2"}) {
+		t.Errorf("Unexpected autoscaled group %#v", pool)
+	}
+	if pool.Autoscaler.ScaleDown.Status != "NoCandidates" || pool.Autoscaler.ScaleDown.Counts["candidates"] != 0 {
+		t.Errorf("Unexpected scale down status %#v", pool.Autoscaler.ScaleDown)
+	}
+}
+
+func TestGetNodeGroupOverviewWithoutAutoscaler(t *testing.T) {
+	overview, err := GetNodeGroupOverview(fake.NewSimpleClientset(newGroupNode("node-1", "pool", v1.ConditionTrue,
+		nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if overview.AutoscalerInstalled || len(overview.Errors) != 0 || len(overview.NodeGroups) != 1 {
+		t.Errorf("Unexpected overview %#v", overview)
+	}
+}
@@ -111,6 +111,9 @@ type NodeDetail struct {
 	// Metrics collected for this resource
 	Metrics []metricapi.Metric `json:"metrics"`
 
+	// MetricsAvailable is false when there is no metrics backend and Metrics is always empty.
+	MetricsAvailable bool `json:"metricsAvailable"`
+
 	// Taints
 	Taints []v1.Taint `json:"taints,omitempty"`
 
@@ -121,9 +124,10 @@ type NodeDetail struct {
 	Errors []error `json:"errors"`
 }
 
-// GetNodeDetail gets node details.
+// GetNodeDetail gets node details. Allocated resources are computed from all pods of the node, but only pods
+// matching given namespace query are listed.
 func GetNodeDetail(client k8sClient.Interface, metricClient metricapi.MetricClient, name string,
-	dsQuery *dataselect.DataSelectQuery) (*NodeDetail, error) {
+	nsQuery *common.NamespaceQuery, dsQuery *dataselect.DataSelectQuery) (*NodeDetail, error) {
 	log.Printf("Getting details of %s node", name)
 
 	node, err := client.CoreV1().Nodes().Get(name, metaV1.GetOptions{})
@@ -138,31 +142,23 @@ func GetNodeDetail(client k8sClient.Interface, metricClient metricapi.MetricClie
 		metricapi.NoResourceCache, metricClient)
 
 	pods, err := getNodePods(client, *node)
-	nonCriticalErrors, criticalError := errors.HandleError(err)
-	if criticalError != nil {
-		return nil, criticalError
+	nonCriticalErrors := errors.AppendOptionalError(err, make([]error, 0))
+	if err != nil {
+		pods = &v1.PodList{}
 	}
 
-	podList, err := GetNodePods(client, metricClient, dsQuery, name)
-	nonCriticalErrors, criticalError = errors.AppendError(err, nonCriticalErrors)
-	if criticalError != nil {
-		return nil, criticalError
-	}
+	podList, err := GetNodePods(client, metricClient, nsQuery, dsQuery, name)
+	nonCriticalErrors = errors.AppendOptionalError(err, nonCriticalErrors)
 
 	eventList, err := event.GetNodeEvents(client, dsQuery, node.Name)
-	nonCriticalErrors, criticalError = errors.AppendError(err, nonCriticalErrors)
-	if criticalError != nil {
-		return nil, criticalError
-	}
+	nonCriticalErrors = errors.AppendOptionalError(err, nonCriticalErrors)
 
 	allocatedResources, err := getNodeAllocatedResources(*node, pods)
-	nonCriticalErrors, criticalError = errors.AppendError(err, nonCriticalErrors)
-	if criticalError != nil {
-		return nil, criticalError
-	}
+	nonCriticalErrors = errors.AppendOptionalError(err, nonCriticalErrors)
 
 	metrics, _ := metricPromises.GetMetrics()
 	nodeDetails := toNodeDetail(*node, podList, eventList, allocatedResources, metrics, nonCriticalErrors)
+	nodeDetails.MetricsAvailable = metricClient != nil
 	return &nodeDetails, nil
 }
 
@@ -287,8 +283,8 @@ func maxResourceList(list, new v1.ResourceList) {
 	}
 }
 
-// GetNodePods return pods list in given named node
-func GetNodePods(client k8sClient.Interface, metricClient metricapi.MetricClient,
+// GetNodePods return pods list in given named node. Pods from namespaces not matching given query are skipped.
+func GetNodePods(client k8sClient.Interface, metricClient metricapi.MetricClient, nsQuery *common.NamespaceQuery,
 	dsQuery *dataselect.DataSelectQuery, name string) (*pod.PodList, error) {
 	podList := pod.PodList{
 		Pods:              []pod.Pod{},
@@ -305,13 +301,20 @@ func GetNodePods(client k8sClient.Interface, metricClient metricapi.MetricClient
 		return &podList, err
 	}
 
-	events, err := event.GetPodsEvents(client, v1.NamespaceAll, pods.Items)
+	items := make([]v1.Pod, 0, len(pods.Items))
+	for _, item := range pods.Items {
+		if nsQuery.Matches(item.Namespace) {
+			items = append(items, item)
+		}
+	}
+
+	events, err := event.GetPodsEvents(client, v1.NamespaceAll, items)
 	nonCriticalErrors, criticalError := errors.HandleError(err)
 	if criticalError != nil {
 		return &podList, criticalError
 	}
 
-	podList = pod.ToPodList(pods.Items, events, nonCriticalErrors, dsQuery, metricClient)
+	podList = pod.ToPodList(items, events, nonCriticalErrors, dsQuery, metricClient)
 	return &podList, nil
 }
 
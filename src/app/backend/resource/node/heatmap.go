@@ -0,0 +1,207 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package node
+
+import (
+	"math"
+
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	client "k8s.io/client-go/kubernetes"
+
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	metricapi "github.com/kubernetes/dashboard/src/app/backend/integration/metric/api"
+)
+
+// HeatmapBucketCount is the number of buckets node percentages are split into. Every bucket is 100/HeatmapBucketCount
+// percent wide, the last one also holds overcommitted nodes.
+const HeatmapBucketCount = 10
+
+// NodeHeatmap contains allocatable CPU and memory requested and used on every node of the cluster.
+type NodeHeatmap struct {
+	Nodes []NodeHeatmapCell `json:"nodes"`
+
+	// BucketCount is the number of buckets the percentages are split into.
+	BucketCount int `json:"bucketCount"`
+
+	// MetricsAvailable is false when there is no metrics backend and used resources are never set.
+	MetricsAvailable bool `json:"metricsAvailable"`
+
+	// List of non-critical errors, that occurred during resource retrieval.
+	Errors []error `json:"errors"`
+}
+
+// NodeHeatmapCell contains requested and used resources of a single node.
+type NodeHeatmapCell struct {
+	Name   string             `json:"name"`
+	Ready  v1.ConditionStatus `json:"ready"`
+	CPU    HeatmapResource    `json:"cpu"`
+	Memory HeatmapResource    `json:"memory"`
+}
+
+// HeatmapResource contains the share of allocatable resource of the node, that is requested by its pods and used.
+type HeatmapResource struct {
+	Requested HeatmapValue `json:"requested"`
+
+	// Used is not set if metrics of the node are not available.
+	Used *HeatmapValue `json:"used,omitempty"`
+}
+
+// HeatmapValue is a percentage of allocatable resource of the node together with its bucket.
+type HeatmapValue struct {
+	// Percentage of allocatable resource, can be over 100%, i.e. overcommitted.
+	Percentage float64 `json:"percentage"`
+
+	// Bucket is a number between 0 and BucketCount-1.
+	Bucket int `json:"bucket"`
+}
+
+// GetNodeHeatmap returns capacity heatmap of all cluster nodes. Nodes are served from the API server cache and pods
+// of all nodes are fetched with a single list call.
+func GetNodeHeatmap(client client.Interface, metricClient metricapi.MetricClient) (*NodeHeatmap, error) {
+	// Resource version "0" allows API server to serve the list from its watch cache instead of etcd.
+	nodes, err := client.CoreV1().Nodes().List(metaV1.ListOptions{ResourceVersion: "0"})
+	nonCriticalErrors, criticalError := errors.HandleError(err)
+	if criticalError != nil {
+		return nil, criticalError
+	}
+
+	selector := fields.AndSelectors(
+		fields.OneTermNotEqualSelector("spec.nodeName", ""),
+		fields.OneTermNotEqualSelector("status.phase", string(v1.PodSucceeded)),
+		fields.OneTermNotEqualSelector("status.phase", string(v1.PodFailed)),
+	)
+	pods, err := client.CoreV1().Pods(v1.NamespaceAll).List(metaV1.ListOptions{FieldSelector: selector.String()})
+	nonCriticalErrors, criticalError = errors.AppendError(err, nonCriticalErrors)
+	if criticalError != nil {
+		return nil, criticalError
+	}
+
+	requests, err := getRequestsPerNode(pods)
+	nonCriticalErrors = errors.AppendOptionalError(err, nonCriticalErrors)
+
+	heatmap := &NodeHeatmap{
+		Nodes:            make([]NodeHeatmapCell, 0, len(nodes.Items)),
+		BucketCount:      HeatmapBucketCount,
+		MetricsAvailable: metricClient != nil,
+	}
+
+	cpuUsage, memoryUsage := getUsagePerNode(nodes.Items, metricClient)
+	for i, node := range nodes.Items {
+		allocatable, requested := node.Status.Allocatable, requests[node.Name]
+		cell := NodeHeatmapCell{
+			Name:  node.Name,
+			Ready: getNodeConditionStatus(node, v1.NodeReady),
+			CPU: HeatmapResource{
+				Requested: toHeatmapValue(requested.Cpu().MilliValue(), allocatable.Cpu().MilliValue()),
+			},
+			Memory: HeatmapResource{
+				Requested: toHeatmapValue(requested.Memory().Value(), allocatable.Memory().Value()),
+			},
+		}
+		if cpuUsage[i] != nil {
+			used := toHeatmapValue(*cpuUsage[i], allocatable.Cpu().MilliValue())
+			cell.CPU.Used = &used
+		}
+		if memoryUsage[i] != nil {
+			used := toHeatmapValue(*memoryUsage[i], allocatable.Memory().Value())
+			cell.Memory.Used = &used
+		}
+		heatmap.Nodes = append(heatmap.Nodes, cell)
+	}
+
+	heatmap.Errors = nonCriticalErrors
+	return heatmap, nil
+}
+
+// getRequestsPerNode sums up resource requests of given pods by the node they are scheduled to.
+func getRequestsPerNode(pods *v1.PodList) (map[string]v1.ResourceList, error) {
+	result := make(map[string]v1.ResourceList)
+	if pods == nil {
+		return result, nil
+	}
+
+	for _, pod := range pods.Items {
+		// Field selector is not supported by all API servers, i.e. fake ones, so pods are filtered again.
+		if pod.Spec.NodeName == "" || pod.Status.Phase == v1.PodSucceeded || pod.Status.Phase == v1.PodFailed {
+			continue
+		}
+
+		reqs, _, err := PodRequestsAndLimits(&pod)
+		if err != nil {
+			return result, err
+		}
+		if _, ok := result[pod.Spec.NodeName]; !ok {
+			result[pod.Spec.NodeName] = v1.ResourceList{}
+		}
+		addResourceList(result[pod.Spec.NodeName], reqs)
+	}
+
+	return result, nil
+}
+
+// getUsagePerNode returns the latest CPU (in millicores) and memory (in bytes) usage of given nodes. Values are
+// nil when the usage of the node is not known.
+func getUsagePerNode(nodes []v1.Node, metricClient metricapi.MetricClient) (cpu, memory []*int64) {
+	cpu, memory = make([]*int64, len(nodes)), make([]*int64, len(nodes))
+	if metricClient == nil || len(nodes) == 0 {
+		return
+	}
+
+	selectors := make([]metricapi.ResourceSelector, len(nodes))
+	for i, node := range nodes {
+		selectors[i] = *NodeCell(node).GetResourceSelector()
+	}
+
+	cpuPromises := metricClient.DownloadMetric(selectors, metricapi.CpuUsage, metricapi.NoResourceCache)
+	memoryPromises := metricClient.DownloadMetric(selectors, metricapi.MemoryUsage, metricapi.NoResourceCache)
+	for i := range nodes {
+		cpu[i] = latestDataPoint(cpuPromises, i)
+		memory[i] = latestDataPoint(memoryPromises, i)
+	}
+	return
+}
+
+func latestDataPoint(promises metricapi.MetricPromises, i int) *int64 {
+	if i >= len(promises) {
+		return nil
+	}
+
+	metric, err := promises[i].GetMetric()
+	if err != nil || metric == nil || len(metric.DataPoints) == 0 {
+		return nil
+	}
+
+	return &metric.DataPoints[len(metric.DataPoints)-1].Y
+}
+
+// toHeatmapValue returns percentage of given allocatable amount and the bucket it falls into.
+func toHeatmapValue(value, allocatable int64) HeatmapValue {
+	if allocatable <= 0 {
+		return HeatmapValue{}
+	}
+
+	percentage := float64(value) / float64(allocatable) * 100
+	bucket := int(math.Floor(percentage * HeatmapBucketCount / 100))
+	if bucket < 0 {
+		bucket = 0
+	}
+	if bucket > HeatmapBucketCount-1 {
+		bucket = HeatmapBucketCount - 1
+	}
+
+	return HeatmapValue{Percentage: percentage, Bucket: bucket}
+}
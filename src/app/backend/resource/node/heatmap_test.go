@@ -0,0 +1,185 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package node
+
+import (
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	integrationapi "github.com/kubernetes/dashboard/src/app/backend/integration/api"
+	metricapi "github.com/kubernetes/dashboard/src/app/backend/integration/metric/api"
+)
+
+// fakeNodeMetricClient returns given usage of the nodes, indexed by node name and metric name.
+type fakeNodeMetricClient struct {
+	usage map[string]map[string]int64
+}
+
+func (fakeNodeMetricClient) ID() integrationapi.IntegrationID {
+	return "fake"
+}
+
+func (fakeNodeMetricClient) HealthCheck() error {
+	return nil
+}
+
+func (self fakeNodeMetricClient) DownloadMetric(selectors []metricapi.ResourceSelector, metricName string,
+	cachedResources *metricapi.CachedResources) metricapi.MetricPromises {
+	promises := metricapi.NewMetricPromises(len(selectors))
+	for i, selector := range selectors {
+		metric := &metricapi.Metric{MetricName: metricName, DataPoints: metricapi.DataPoints{}}
+		if value, ok := self.usage[selector.ResourceName][metricName]; ok {
+			metric.DataPoints = append(metric.DataPoints, metricapi.DataPoint{X: 1, Y: value / 2},
+				metricapi.DataPoint{X: 2, Y: value})
+		}
+		promises[i].Metric <- metric
+		promises[i].Error <- nil
+	}
+	return promises
+}
+
+func (self fakeNodeMetricClient) DownloadMetrics(selectors []metricapi.ResourceSelector, metricNames []string,
+	cachedResources *metricapi.CachedResources) metricapi.MetricPromises {
+	return nil
+}
+
+func (fakeNodeMetricClient) AggregateMetrics(metrics metricapi.MetricPromises, metricName string,
+	aggregations metricapi.AggregationModes) metricapi.MetricPromises {
+	return metrics
+}
+
+func newHeatmapNode(name, cpu, memory string) *v1.Node {
+	return &v1.Node{
+		ObjectMeta: metaV1.ObjectMeta{Name: name},
+		Status: v1.NodeStatus{
+			Allocatable: v1.ResourceList{
+				v1.ResourceCPU:    resource.MustParse(cpu),
+				v1.ResourceMemory: resource.MustParse(memory),
+			},
+			Conditions: []v1.NodeCondition{{Type: v1.NodeReady, Status: v1.ConditionTrue}},
+		},
+	}
+}
+
+func newHeatmapPod(name, node string, phase v1.PodPhase, cpu, memory string) *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: metaV1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec: v1.PodSpec{
+			NodeName: node,
+			Containers: []v1.Container{{
+				Name: "app",
+				Resources: v1.ResourceRequirements{Requests: v1.ResourceList{
+					v1.ResourceCPU:    resource.MustParse(cpu),
+					v1.ResourceMemory: resource.MustParse(memory),
+				}},
+			}},
+		},
+		Status: v1.PodStatus{Phase: phase},
+	}
+}
+
+func TestGetNodeHeatmap(t *testing.T) {
+	client := fake.NewSimpleClientset(
+		newHeatmapNode("node-1", "2", "4Gi"),
+		newHeatmapNode("node-2", "4", "8Gi"),
+		newHeatmapPod("web-1", "node-1", v1.PodRunning, "500m", "1Gi"),
+		newHeatmapPod("web-2", "node-1", v1.PodRunning, "1500m", "1Gi"),
+		// Finished and unscheduled pods do not hold any resources.
+		newHeatmapPod("job-1", "node-2", v1.PodSucceeded, "4", "8Gi"),
+		newHeatmapPod("pending-1", "", v1.PodPending, "4", "8Gi"),
+		newHeatmapPod("db-1", "node-2", v1.PodRunning, "5", "1Gi"),
+	)
+
+	cases := []struct {
+		info         string
+		metricClient metricapi.MetricClient
+		expected     []NodeHeatmapCell
+	}{
+		{
+			"without metrics",
+			nil,
+			[]NodeHeatmapCell{
+				{
+					Name: "node-1", Ready: v1.ConditionTrue,
+					CPU:    HeatmapResource{Requested: HeatmapValue{Percentage: 100, Bucket: 9}},
+					Memory: HeatmapResource{Requested: HeatmapValue{Percentage: 50, Bucket: 5}},
+				},
+				{
+					Name: "node-2", Ready: v1.ConditionTrue,
+					CPU:    HeatmapResource{Requested: HeatmapValue{Percentage: 125, Bucket: 9}},
+					Memory: HeatmapResource{Requested: HeatmapValue{Percentage: 12.5, Bucket: 1}},
+				},
+			},
+		},
+		{
+			"with metrics of a single node",
+			fakeNodeMetricClient{usage: map[string]map[string]int64{
+				"node-1": {metricapi.CpuUsage: 300, metricapi.MemoryUsage: 1 << 30},
+			}},
+			[]NodeHeatmapCell{
+				{
+					Name: "node-1", Ready: v1.ConditionTrue,
+					CPU: HeatmapResource{Requested: HeatmapValue{Percentage: 100, Bucket: 9},
+						Used: &HeatmapValue{Percentage: 15, Bucket: 1}},
+					Memory: HeatmapResource{Requested: HeatmapValue{Percentage: 50, Bucket: 5},
+						Used: &HeatmapValue{Percentage: 25, Bucket: 2}},
+				},
+				{
+					Name: "node-2", Ready: v1.ConditionTrue,
+					CPU:    HeatmapResource{Requested: HeatmapValue{Percentage: 125, Bucket: 9}},
+					Memory: HeatmapResource{Requested: HeatmapValue{Percentage: 12.5, Bucket: 1}},
+				},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		actual, err := GetNodeHeatmap(client, c.metricClient)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", c.info, err)
+		}
+		if actual.BucketCount != HeatmapBucketCount || actual.MetricsAvailable != (c.metricClient != nil) {
+			t.Errorf("%s: unexpected heatmap metadata: %#v", c.info, actual)
+		}
+		if !reflect.DeepEqual(actual.Nodes, c.expected) {
+			t.Errorf("%s: GetNodeHeatmap() == %#v, expected %#v", c.info, actual.Nodes, c.expected)
+		}
+	}
+}
+
+func TestToHeatmapValue(t *testing.T) {
+	cases := []struct {
+		value, allocatable int64
+		expected           HeatmapValue
+	}{
+		{0, 1000, HeatmapValue{Percentage: 0, Bucket: 0}},
+		{99, 1000, HeatmapValue{Percentage: 9.9, Bucket: 0}},
+		{100, 1000, HeatmapValue{Percentage: 10, Bucket: 1}},
+		{999, 1000, HeatmapValue{Percentage: 99.9, Bucket: 9}},
+		{3000, 1000, HeatmapValue{Percentage: 300, Bucket: 9}},
+		{100, 0, HeatmapValue{}},
+	}
+
+	for _, c := range cases {
+		if actual := toHeatmapValue(c.value, c.allocatable); !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("toHeatmapValue(%d, %d) == %#v, expected %#v", c.value, c.allocatable, actual, c.expected)
+		}
+	}
+}
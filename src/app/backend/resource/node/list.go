@@ -32,6 +32,9 @@ type NodeList struct {
 	Nodes             []Node             `json:"nodes"`
 	CumulativeMetrics []metricapi.Metric `json:"cumulativeMetrics"`
 
+	// MetricsAvailable is false when there is no metrics backend and CumulativeMetrics is always empty.
+	MetricsAvailable bool `json:"metricsAvailable"`
+
 	// List of non-critical errors, that occurred during resource retrieval.
 	Errors []error `json:"errors"`
 }
@@ -79,6 +82,7 @@ func toNodeList(client client.Interface, nodes []v1.Node, nonCriticalErrors []er
 		nodeList.Nodes = append(nodeList.Nodes, toNode(node, pods))
 	}
 
+	nodeList.MetricsAvailable = metricClient != nil
 	cumulativeMetrics, err := metricPromises.GetMetrics()
 	nodeList.CumulativeMetrics = cumulativeMetrics
 	if err != nil {
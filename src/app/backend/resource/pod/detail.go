@@ -26,14 +26,17 @@ import (
 	"github.com/kubernetes/dashboard/src/app/backend/api"
 	errorHandler "github.com/kubernetes/dashboard/src/app/backend/errors"
 	metricapi "github.com/kubernetes/dashboard/src/app/backend/integration/metric/api"
+	"github.com/kubernetes/dashboard/src/app/backend/podsecurity"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/controller"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/gatekeeper"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/persistentvolumeclaim"
 	v1 "k8s.io/api/core/v1"
 	res "k8s.io/apimachinery/pkg/api/resource"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/client-go/kubernetes"
 )
 
@@ -54,6 +57,15 @@ type PodDetail struct {
 	EventList                 common.EventList                                `json:"eventList"`
 	PersistentvolumeclaimList persistentvolumeclaim.PersistentVolumeClaimList `json:"persistentVolumeClaimList"`
 
+	// MetricsAvailable is false when there is no metrics backend and Metrics is always empty.
+	MetricsAvailable bool `json:"metricsAvailable"`
+
+	// Evaluation of the pod spec against Pod Security Standards profiles.
+	Security podsecurity.Evaluation `json:"security"`
+
+	// Gatekeeper audit violations of the resource. Empty when Gatekeeper is not installed.
+	PolicyViolations []gatekeeper.Violation `json:"policyViolations"`
+
 	// List of non-critical errors, that occurred during resource retrieval.
 	Errors []error `json:"errors"`
 }
@@ -106,10 +118,7 @@ func GetPodDetail(client kubernetes.Interface, metricClient metricapi.MetricClie
 	}
 
 	controller, err := getPodController(client, common.NewSameNamespaceQuery(namespace), pod)
-	nonCriticalErrors, criticalError := errorHandler.HandleError(err)
-	if criticalError != nil {
-		return nil, criticalError
-	}
+	nonCriticalErrors := errorHandler.AppendOptionalError(err, make([]error, 0))
 
 	_, metricPromises := dataselect.GenericDataSelectWithMetrics(toCells([]v1.Pod{*pod}),
 		dataselect.StdMetricsDataSelect, metricapi.NoResourceCache, metricClient)
@@ -117,33 +126,34 @@ func GetPodDetail(client kubernetes.Interface, metricClient metricapi.MetricClie
 
 	configMapList := <-channels.ConfigMapList.List
 	err = <-channels.ConfigMapList.Error
-	nonCriticalErrors, criticalError = errorHandler.AppendError(err, nonCriticalErrors)
-	if criticalError != nil {
-		return nil, criticalError
+	nonCriticalErrors = errorHandler.AppendOptionalError(err, nonCriticalErrors)
+	if err != nil {
+		configMapList = &v1.ConfigMapList{}
 	}
 
 	secretList := <-channels.SecretList.List
 	err = <-channels.SecretList.Error
-	nonCriticalErrors, criticalError = errorHandler.AppendError(err, nonCriticalErrors)
-	if criticalError != nil {
-		return nil, criticalError
+	nonCriticalErrors = errorHandler.AppendOptionalError(err, nonCriticalErrors)
+	if err != nil {
+		secretList = &v1.SecretList{}
 	}
 
 	eventList, err := GetEventsForPod(client, dataselect.DefaultDataSelect, pod.Namespace, pod.Name)
-	nonCriticalErrors, criticalError = errorHandler.AppendError(err, nonCriticalErrors)
-	if criticalError != nil {
-		return nil, criticalError
-	}
+	nonCriticalErrors = errorHandler.AppendOptionalError(err, nonCriticalErrors)
 
 	persistentVolumeClaimList, err := persistentvolumeclaim.GetPodPersistentVolumeClaims(client,
 		namespace, name, dataselect.DefaultDataSelect)
-	nonCriticalErrors, criticalError = errorHandler.AppendError(err, nonCriticalErrors)
-	if criticalError != nil {
-		return nil, criticalError
+	nonCriticalErrors = errorHandler.AppendOptionalError(err, nonCriticalErrors)
+	if err != nil {
+		persistentVolumeClaimList = &persistentvolumeclaim.PersistentVolumeClaimList{
+			Items:  make([]persistentvolumeclaim.PersistentVolumeClaim, 0),
+			Errors: make([]error, 0),
+		}
 	}
 
 	podDetail := toPodDetail(pod, metrics, configMapList, secretList, controller,
 		eventList, persistentVolumeClaimList, nonCriticalErrors)
+	podDetail.MetricsAvailable = metricClient != nil
 	return &podDetail, nil
 }
 
@@ -224,6 +234,7 @@ func toPodDetail(pod *v1.Pod, metrics []metricapi.Metric, configMaps *v1.ConfigM
 		Conditions:                getPodConditions(*pod),
 		EventList:                 *events,
 		PersistentvolumeclaimList: *persistentVolumeClaimList,
+		Security:                  podsecurity.Evaluate(pod.Annotations, &pod.Spec, field.NewPath("spec")),
 		Errors:                    nonCriticalErrors,
 	}
 }
@@ -283,6 +294,29 @@ func evalEnvFrom(container v1.Container, configMaps *v1.ConfigMapList, secrets *
 	return vars
 }
 
+// HideEnvValues clears values of environment variables that come from config maps or secrets of hidden kinds.
+func HideEnvValues(podDetail *PodDetail, isHidden func(kind string) bool) {
+	hideConfigMaps, hideSecrets := isHidden(api.ResourceKindConfigMap), isHidden(api.ResourceKindSecret)
+	if !hideConfigMaps && !hideSecrets {
+		return
+	}
+
+	for _, containers := range [][]Container{podDetail.InitContainers, podDetail.Containers} {
+		for i := range containers {
+			for j, variable := range containers[i].Env {
+				if variable.ValueFrom == nil {
+					continue
+				}
+
+				if (hideConfigMaps && variable.ValueFrom.ConfigMapKeyRef != nil) ||
+					(hideSecrets && variable.ValueFrom.SecretKeyRef != nil) {
+					containers[i].Env[j].Value = ""
+				}
+			}
+		}
+	}
+}
+
 // evalValueFrom evaluates environment value from given source. For more details check:
 // https://github.com/kubernetes/kubernetes/blob/d82e51edc5f02bff39661203c9b503d054c3493b/pkg/kubectl/describe.go#L1056
 func evalValueFrom(src *v1.EnvVarSource, container *v1.Container, pod *v1.Pod,
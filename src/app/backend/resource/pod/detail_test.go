@@ -21,6 +21,7 @@ import (
 
 	"github.com/kubernetes/dashboard/src/app/backend/api"
 	metricapi "github.com/kubernetes/dashboard/src/app/backend/integration/metric/api"
+	"github.com/kubernetes/dashboard/src/app/backend/podsecurity"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/controller"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
@@ -57,7 +58,11 @@ func TestGetPodDetail(t *testing.T) {
 				},
 				Metrics:                   []metricapi.Metric{},
 				PersistentvolumeclaimList: persistentvolumeclaim.PersistentVolumeClaimList{},
-				Errors:                    []error{},
+				Security: podsecurity.Evaluation{
+					Level:      podsecurity.LevelRestricted,
+					Violations: []podsecurity.Violation{},
+				},
+				Errors: []error{},
 			},
 		},
 	}
@@ -240,3 +245,30 @@ func TestEvalEnvFrom(t *testing.T) {
 		}
 	}
 }
+
+func TestHideEnvValues(t *testing.T) {
+	secretRef := &v1.EnvVarSource{SecretKeyRef: &v1.SecretKeySelector{Key: "password"}}
+	configMapRef := &v1.EnvVarSource{ConfigMapKeyRef: &v1.ConfigMapKeySelector{Key: "mode"}}
+	podDetail := &PodDetail{
+		InitContainers: []Container{{Env: []EnvVar{{Name: "PASSWORD", Value: "c2VjcmV0", ValueFrom: secretRef}}}},
+		Containers: []Container{{Env: []EnvVar{
+			{Name: "PASSWORD", Value: "c2VjcmV0", ValueFrom: secretRef},
+			{Name: "MODE", Value: "debug", ValueFrom: configMapRef},
+			{Name: "PLAIN", Value: "value"},
+		}}},
+	}
+
+	HideEnvValues(podDetail, func(kind string) bool { return kind == api.ResourceKindSecret })
+
+	expected := &PodDetail{
+		InitContainers: []Container{{Env: []EnvVar{{Name: "PASSWORD", ValueFrom: secretRef}}}},
+		Containers: []Container{{Env: []EnvVar{
+			{Name: "PASSWORD", ValueFrom: secretRef},
+			{Name: "MODE", Value: "debug", ValueFrom: configMapRef},
+			{Name: "PLAIN", Value: "value"},
+		}}},
+	}
+	if !reflect.DeepEqual(podDetail, expected) {
+		t.Errorf("HideEnvValues() == %#v, expected %#v", podDetail, expected)
+	}
+}
@@ -33,6 +33,9 @@ type PodList struct {
 	ListMeta          api.ListMeta       `json:"listMeta"`
 	CumulativeMetrics []metricapi.Metric `json:"cumulativeMetrics"`
 
+	// MetricsAvailable is false when there is no metrics backend and CumulativeMetrics is always empty.
+	MetricsAvailable bool `json:"metricsAvailable"`
+
 	// Basic information about resources status on the list.
 	Status common.ResourceStatus `json:"status"`
 
@@ -139,6 +142,7 @@ func ToPodList(pods []v1.Pod, events []v1.Event, nonCriticalErrors []error, dsQu
 		podList.Pods = append(podList.Pods, podDetail)
 	}
 
+	podList.MetricsAvailable = metricClient != nil
 	cumulativeMetrics, err := cumulativeMetricsPromises.GetMetrics()
 	if err != nil {
 		log.Printf("Skipping metrics because of error: %s\n", err)
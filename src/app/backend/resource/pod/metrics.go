@@ -47,9 +47,12 @@ type PodMetrics struct {
 
 func getMetricsPerPod(pods []v1.Pod, metricClient metricapi.MetricClient, dsQuery *dataselect.DataSelectQuery) (
 	*MetricsByPod, error) {
-	log.Println("Getting pod metrics")
-
 	result := &MetricsByPod{MetricsMap: make(map[types.UID]PodMetrics)}
+	if metricClient == nil {
+		return result, nil
+	}
+
+	log.Println("Getting pod metrics")
 
 	metricPromises := dataselect.PodListMetrics(toCells(pods), dsQuery, metricClient)
 	metrics, err := metricPromises.GetMetrics()
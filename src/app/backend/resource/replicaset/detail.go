@@ -54,15 +54,19 @@ func GetReplicaSetDetail(client k8sClient.Interface, metricClient metricapi.Metr
 	}
 
 	podInfo, err := getReplicaSetPodInfo(client, rs)
-	nonCriticalErrors, criticalError := errors.HandleError(err)
-	if criticalError != nil {
-		return nil, criticalError
+	nonCriticalErrors := errors.AppendOptionalError(err, make([]error, 0))
+	if err != nil {
+		fallback := common.GetPodInfo(rs.Status.Replicas, rs.Spec.Replicas, nil)
+		podInfo = &fallback
 	}
 
 	hpas, err := hpa.GetHorizontalPodAutoscalerListForResource(client, namespace, "ReplicaSet", name)
-	nonCriticalErrors, criticalError = errors.AppendError(err, nonCriticalErrors)
-	if criticalError != nil {
-		return nil, criticalError
+	nonCriticalErrors = errors.AppendOptionalError(err, nonCriticalErrors)
+	if err != nil {
+		hpas = &hpa.HorizontalPodAutoscalerList{
+			HorizontalPodAutoscalers: make([]hpa.HorizontalPodAutoscaler, 0),
+			Errors:                   make([]error, 0),
+		}
 	}
 
 	rsDetail := toReplicaSetDetail(rs, *podInfo, *hpas, nonCriticalErrors)
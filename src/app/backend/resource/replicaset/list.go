@@ -33,6 +33,9 @@ type ReplicaSetList struct {
 	ListMeta          api.ListMeta       `json:"listMeta"`
 	CumulativeMetrics []metricapi.Metric `json:"cumulativeMetrics"`
 
+	// MetricsAvailable is false when there is no metrics backend and CumulativeMetrics is always empty.
+	MetricsAvailable bool `json:"metricsAvailable"`
+
 	// Basic information about resources status on the list.
 	Status common.ResourceStatus `json:"status"`
 
@@ -143,6 +146,7 @@ func ToReplicaSetList(replicaSets []apps.ReplicaSet, pods []v1.Pod, events []v1.
 			ToReplicaSet(&replicaSet, &podInfo))
 	}
 
+	replicaSetList.MetricsAvailable = metricClient != nil
 	cumulativeMetrics, err := metricPromises.GetMetrics()
 	replicaSetList.CumulativeMetrics = cumulativeMetrics
 	if err != nil {
@@ -52,9 +52,10 @@ func GetReplicationControllerDetail(client k8sClient.Interface, namespace, name
 	}
 
 	podInfo, err := getReplicationControllerPodInfo(client, replicationController, namespace)
-	nonCriticalErrors, criticalError := errors.HandleError(err)
-	if criticalError != nil {
-		return nil, criticalError
+	nonCriticalErrors := errors.AppendOptionalError(err, make([]error, 0))
+	if err != nil {
+		fallback := common.GetPodInfo(replicationController.Status.Replicas, replicationController.Spec.Replicas, nil)
+		podInfo = &fallback
 	}
 
 	replicationControllerDetail := toReplicationControllerDetail(replicationController, podInfo, nonCriticalErrors)
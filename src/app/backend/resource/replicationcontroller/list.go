@@ -32,6 +32,9 @@ type ReplicationControllerList struct {
 	ListMeta          api.ListMeta       `json:"listMeta"`
 	CumulativeMetrics []metricapi.Metric `json:"cumulativeMetrics"`
 
+	// MetricsAvailable is false when there is no metrics backend and CumulativeMetrics is always empty.
+	MetricsAvailable bool `json:"metricsAvailable"`
+
 	// Basic information about resources status on the list.
 	Status common.ResourceStatus `json:"status"`
 
@@ -130,6 +133,7 @@ func toReplicationControllerList(replicationControllers []v1.ReplicationControll
 		rcList.ReplicationControllers = append(rcList.ReplicationControllers, replicationController)
 	}
 
+	rcList.MetricsAvailable = metricClient != nil
 	cumulativeMetrics, err := metricPromises.GetMetrics()
 	rcList.CumulativeMetrics = cumulativeMetrics
 	if err != nil {
@@ -15,6 +15,8 @@
 package secret
 
 import (
+	"encoding/base64"
+	"encoding/json"
 	"log"
 
 	"github.com/kubernetes/dashboard/src/app/backend/api"
@@ -63,6 +65,53 @@ func (spec *ImagePullSecretSpec) GetData() map[string][]byte {
 	return map[string][]byte{v1.DockerConfigKey: spec.Data}
 }
 
+// DockerConfigJSONSecretSpec is a specification of an image pull secret in the .dockerconfigjson format,
+// which is built from plain registry credentials. It implements SecretSpec.
+type DockerConfigJSONSecretSpec struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+
+	// Address of the registry, i.e. https://index.docker.io/v1/ or registry.example.com:5000.
+	Registry string `json:"registry"`
+	Username string `json:"username"`
+	Password string `json:"password"`
+	Email    string `json:"email"`
+}
+
+// GetName returns the name of the DockerConfigJSONSecretSpec
+func (spec *DockerConfigJSONSecretSpec) GetName() string {
+	return spec.Name
+}
+
+// GetType returns the type of the DockerConfigJSONSecretSpec, which is always api.SecretTypeDockerConfigJson
+func (spec *DockerConfigJSONSecretSpec) GetType() v1.SecretType {
+	return v1.SecretTypeDockerConfigJson
+}
+
+// GetNamespace returns the namespace of the DockerConfigJSONSecretSpec
+func (spec *DockerConfigJSONSecretSpec) GetNamespace() string {
+	return spec.Namespace
+}
+
+// GetData returns the .dockerconfigjson content built from the registry credentials
+func (spec *DockerConfigJSONSecretSpec) GetData() map[string][]byte {
+	auth := base64.StdEncoding.EncodeToString([]byte(spec.Username + ":" + spec.Password))
+	config := map[string]map[string]map[string]string{
+		"auths": {
+			spec.Registry: {
+				"username": spec.Username,
+				"password": spec.Password,
+				"email":    spec.Email,
+				"auth":     auth,
+			},
+		},
+	}
+
+	// Marshalling of nested string maps can not fail.
+	data, _ := json.Marshal(config)
+	return map[string][]byte{v1.DockerConfigJsonKey: data}
+}
+
 // Secret is a single secret returned to the frontend.
 type Secret struct {
 	ObjectMeta api.ObjectMeta `json:"objectMeta"`
@@ -84,7 +133,7 @@ type SecretList struct {
 // GetSecretList returns all secrets in the given namespace.
 func GetSecretList(client kubernetes.Interface, namespace *common.NamespaceQuery,
 	dsQuery *dataselect.DataSelectQuery) (*SecretList, error) {
-	log.Printf("Getting list of secrets in %s namespace\n", namespace)
+	log.Printf("Getting list of secrets in %s namespace\n", namespace.ToRequestParam())
 	secretList, err := client.CoreV1().Secrets(namespace.ToRequestParam()).List(api.ListEverything)
 
 	nonCriticalErrors, criticalError := errors.HandleError(err)
@@ -92,7 +141,14 @@ func GetSecretList(client kubernetes.Interface, namespace *common.NamespaceQuery
 		return nil, criticalError
 	}
 
-	return toSecretList(secretList.Items, nonCriticalErrors, dsQuery), nil
+	secrets := make([]v1.Secret, 0)
+	for _, secret := range secretList.Items {
+		if namespace.Matches(secret.Namespace) {
+			secrets = append(secrets, secret)
+		}
+	}
+
+	return toSecretList(secrets, nonCriticalErrors, dsQuery), nil
 }
 
 // CreateSecret creates a single secret using the cluster API client
@@ -18,7 +18,9 @@ import (
 	"log"
 
 	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	metricapi "github.com/kubernetes/dashboard/src/app/backend/integration/metric/api"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/endpoint"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/gatekeeper"
 	v1 "k8s.io/api/core/v1"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8sClient "k8s.io/client-go/kubernetes"
@@ -35,6 +37,12 @@ type ServiceDetail struct {
 	// Show the value of the SessionAffinity of the Service.
 	SessionAffinity v1.ServiceAffinity `json:"sessionAffinity"`
 
+	// Gatekeeper audit violations of the resource. Empty when Gatekeeper is not installed.
+	PolicyViolations []gatekeeper.Violation `json:"policyViolations"`
+
+	// Request metrics collected by a service mesh. Not set when traffic metrics are not available.
+	Traffic *metricapi.ServiceTraffic `json:"traffic,omitempty"`
+
 	// List of non-critical errors, that occurred during resource retrieval.
 	Errors []error `json:"errors"`
 }
@@ -48,10 +56,7 @@ func GetServiceDetail(client k8sClient.Interface, namespace, name string) (*Serv
 	}
 
 	endpointList, err := endpoint.GetServiceEndpoints(client, namespace, name)
-	nonCriticalErrors, criticalError := errors.HandleError(err)
-	if criticalError != nil {
-		return nil, criticalError
-	}
+	nonCriticalErrors := errors.AppendOptionalError(err, make([]error, 0))
 
 	service := toServiceDetail(serviceData, *endpointList, nonCriticalErrors)
 	return &service, nil
@@ -20,6 +20,8 @@ import (
 	"github.com/kubernetes/dashboard/src/app/backend/errors"
 	metricapi "github.com/kubernetes/dashboard/src/app/backend/integration/metric/api"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/gatekeeper"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/verticalpodautoscaler"
 	apps "k8s.io/api/apps/v1"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
@@ -30,6 +32,12 @@ type StatefulSetDetail struct {
 	// Extends list item structure.
 	StatefulSet `json:",inline"`
 
+	// Gatekeeper audit violations of the resource. Empty when Gatekeeper is not installed.
+	PolicyViolations []gatekeeper.Violation `json:"policyViolations"`
+
+	// Recommendations of the vertical pod autoscaler targeting the workload. Nil when there is none.
+	ResourceRecommendations *verticalpodautoscaler.Recommendations `json:"resourceRecommendations,omitempty"`
+
 	// List of non-critical errors, that occurred during resource retrieval.
 	Errors []error `json:"errors"`
 }
@@ -45,9 +53,10 @@ func GetStatefulSetDetail(client kubernetes.Interface, metricClient metricapi.Me
 	}
 
 	podInfo, err := getStatefulSetPodInfo(client, ss)
-	nonCriticalErrors, criticalError := errors.HandleError(err)
-	if criticalError != nil {
-		return nil, criticalError
+	nonCriticalErrors := errors.AppendOptionalError(err, make([]error, 0))
+	if err != nil {
+		fallback := common.GetPodInfo(ss.Status.Replicas, ss.Spec.Replicas, nil)
+		podInfo = &fallback
 	}
 
 	ssDetail := getStatefulSetDetail(ss, podInfo, nonCriticalErrors)
@@ -36,6 +36,9 @@ type StatefulSetList struct {
 	StatefulSets      []StatefulSet         `json:"statefulSets"`
 	CumulativeMetrics []metricapi.Metric    `json:"cumulativeMetrics"`
 
+	// MetricsAvailable is false when there is no metrics backend and CumulativeMetrics is always empty.
+	MetricsAvailable bool `json:"metricsAvailable"`
+
 	// List of non-critical errors, that occurred during resource retrieval.
 	Errors []error `json:"errors"`
 }
@@ -118,6 +121,7 @@ func toStatefulSetList(statefulSets []apps.StatefulSet, pods []v1.Pod, events []
 		statefulSetList.StatefulSets = append(statefulSetList.StatefulSets, toStatefulSet(&statefulSet, &podInfo))
 	}
 
+	statefulSetList.MetricsAvailable = metricClient != nil
 	cumulativeMetrics, err := metricPromises.GetMetrics()
 	statefulSetList.CumulativeMetrics = cumulativeMetrics
 	if err != nil {
@@ -0,0 +1,228 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package topology
+
+import (
+	"fmt"
+	"log"
+	"sort"
+
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	client "k8s.io/client-go/kubernetes"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+)
+
+// Node labels holding zone of the node. The beta label is used by clusters older than 1.17.
+const (
+	zoneLabel     = "topology.kubernetes.io/zone"
+	zoneLabelBeta = "failure-domain.beta.kubernetes.io/zone"
+)
+
+// PodPlacement maps pods of a workload onto zones and nodes, so that skewed placement can be spotted.
+type PodPlacement struct {
+	// Zones are sorted by name. All zones of the cluster are listed, including those without pods of the workload.
+	// Nodes without zone label are grouped in a zone with empty name.
+	Zones []ZonePlacement `json:"zones"`
+
+	// UnscheduledPods are names of pods, that are not assigned to any node yet.
+	UnscheduledPods []string `json:"unscheduledPods"`
+
+	// MaxSkew is a difference between the highest and the lowest number of pods in a zone.
+	MaxSkew int `json:"maxSkew"`
+
+	// SingleZone is true if the workload has more than one scheduled pod, all of them run in a single zone and
+	// the cluster has more zones. Outage of the zone takes down the whole workload.
+	SingleZone bool `json:"singleZone"`
+
+	// SingleNode is true if the workload has more than one scheduled pod and all of them run on a single node.
+	SingleNode bool `json:"singleNode"`
+
+	// List of non-critical errors, that occurred during resource retrieval.
+	Errors []error `json:"errors"`
+}
+
+// ZonePlacement lists nodes of a zone, that run pods of the workload.
+type ZonePlacement struct {
+	Zone     string          `json:"zone"`
+	PodCount int             `json:"podCount"`
+	Nodes    []NodePlacement `json:"nodes"`
+}
+
+// NodePlacement lists pods of the workload running on a node.
+type NodePlacement struct {
+	Node string   `json:"node"`
+	Pods []string `json:"pods"`
+}
+
+// GetPodPlacement returns placement of pods of the workload with given kind, i.e. deployment, namespace and name.
+// Pods are selected by the label selector of the workload.
+func GetPodPlacement(client client.Interface, kind, namespace, name string) (*PodPlacement, error) {
+	log.Printf("Getting pod placement of %s %s/%s", kind, namespace, name)
+
+	selector, err := getSelector(client, kind, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	pods, err := client.CoreV1().Pods(namespace).List(metaV1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return nil, err
+	}
+
+	nonCriticalErrors := make([]error, 0)
+	nodes, err := client.CoreV1().Nodes().List(api.ListEverything)
+	nonCriticalErrors = errors.AppendOptionalError(err, nonCriticalErrors)
+	if nodes == nil {
+		nodes = &v1.NodeList{}
+	}
+
+	result := toPodPlacement(pods.Items, getZoneNames(nodes.Items))
+	result.Errors = nonCriticalErrors
+	return result, nil
+}
+
+// getSelector returns label selector of the workload in its string form.
+func getSelector(client client.Interface, kind, namespace, name string) (string, error) {
+	var selector *metaV1.LabelSelector
+	switch kind {
+	case api.ResourceKindDeployment:
+		workload, err := client.AppsV1().Deployments(namespace).Get(name, metaV1.GetOptions{})
+		if err != nil {
+			return "", err
+		}
+		selector = workload.Spec.Selector
+	case api.ResourceKindStatefulSet:
+		workload, err := client.AppsV1().StatefulSets(namespace).Get(name, metaV1.GetOptions{})
+		if err != nil {
+			return "", err
+		}
+		selector = workload.Spec.Selector
+	case api.ResourceKindDaemonSet:
+		workload, err := client.AppsV1().DaemonSets(namespace).Get(name, metaV1.GetOptions{})
+		if err != nil {
+			return "", err
+		}
+		selector = workload.Spec.Selector
+	case api.ResourceKindReplicaSet:
+		workload, err := client.AppsV1().ReplicaSets(namespace).Get(name, metaV1.GetOptions{})
+		if err != nil {
+			return "", err
+		}
+		selector = workload.Spec.Selector
+	case api.ResourceKindJob:
+		workload, err := client.BatchV1().Jobs(namespace).Get(name, metaV1.GetOptions{})
+		if err != nil {
+			return "", err
+		}
+		selector = workload.Spec.Selector
+	case api.ResourceKindReplicationController:
+		workload, err := client.CoreV1().ReplicationControllers(namespace).Get(name, metaV1.GetOptions{})
+		if err != nil {
+			return "", err
+		}
+		selector = &metaV1.LabelSelector{MatchLabels: workload.Spec.Selector}
+	default:
+		return "", errors.NewBadRequest(fmt.Sprintf("pod placement is not supported for %s", kind))
+	}
+
+	result, err := metaV1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return "", err
+	}
+	return result.String(), nil
+}
+
+// getZoneNames maps names of the nodes to their zones.
+func getZoneNames(nodes []v1.Node) map[string]string {
+	result := make(map[string]string, len(nodes))
+	for _, node := range nodes {
+		zone, ok := node.Labels[zoneLabel]
+		if !ok {
+			zone = node.Labels[zoneLabelBeta]
+		}
+		result[node.Name] = zone
+	}
+	return result
+}
+
+func toPodPlacement(pods []v1.Pod, zoneNames map[string]string) *PodPlacement {
+	result := &PodPlacement{Zones: make([]ZonePlacement, 0), UnscheduledPods: make([]string, 0)}
+
+	zones := make(map[string]map[string][]string)
+	for _, zone := range zoneNames {
+		zones[zone] = make(map[string][]string)
+	}
+
+	scheduled := 0
+	for _, pod := range pods {
+		if len(pod.Spec.NodeName) == 0 {
+			result.UnscheduledPods = append(result.UnscheduledPods, pod.Name)
+			continue
+		}
+
+		// Zone of a node, that could not be listed, is unknown.
+		zone := zoneNames[pod.Spec.NodeName]
+		if _, ok := zones[zone]; !ok {
+			zones[zone] = make(map[string][]string)
+		}
+		zones[zone][pod.Spec.NodeName] = append(zones[zone][pod.Spec.NodeName], pod.Name)
+		scheduled++
+	}
+
+	for zone, nodes := range zones {
+		placement := ZonePlacement{Zone: zone, Nodes: make([]NodePlacement, 0, len(nodes))}
+		for node, pods := range nodes {
+			sort.Strings(pods)
+			placement.Nodes = append(placement.Nodes, NodePlacement{Node: node, Pods: pods})
+			placement.PodCount += len(pods)
+		}
+		sort.Slice(placement.Nodes, func(i, j int) bool { return placement.Nodes[i].Node < placement.Nodes[j].Node })
+		result.Zones = append(result.Zones, placement)
+	}
+	sort.Slice(result.Zones, func(i, j int) bool { return result.Zones[i].Zone < result.Zones[j].Zone })
+	sort.Strings(result.UnscheduledPods)
+
+	usedZones, usedNodes := 0, 0
+	for _, zone := range result.Zones {
+		if zone.PodCount > 0 {
+			usedZones++
+		}
+		usedNodes += len(zone.Nodes)
+	}
+	result.MaxSkew = maxSkew(result.Zones)
+	result.SingleZone = scheduled > 1 && usedZones == 1 && len(result.Zones) > 1
+	result.SingleNode = scheduled > 1 && usedNodes == 1
+	return result
+}
+
+func maxSkew(zones []ZonePlacement) int {
+	if len(zones) == 0 {
+		return 0
+	}
+
+	min, max := zones[0].PodCount, zones[0].PodCount
+	for _, zone := range zones[1:] {
+		if zone.PodCount < min {
+			min = zone.PodCount
+		}
+		if zone.PodCount > max {
+			max = zone.PodCount
+		}
+	}
+	return max - min
+}
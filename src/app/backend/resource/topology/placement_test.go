@@ -0,0 +1,77 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package topology
+
+import (
+	"reflect"
+	"testing"
+
+	apps "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestGetPodPlacement(t *testing.T) {
+	labels := map[string]string{"app": "frontend"}
+	node := func(name, zone string) *v1.Node {
+		return &v1.Node{ObjectMeta: metaV1.ObjectMeta{Name: name, Labels: map[string]string{zoneLabel: zone}}}
+	}
+	pod := func(name, nodeName string, podLabels map[string]string) *v1.Pod {
+		return &v1.Pod{
+			ObjectMeta: metaV1.ObjectMeta{Namespace: "default", Name: name, Labels: podLabels},
+			Spec:       v1.PodSpec{NodeName: nodeName},
+		}
+	}
+
+	client := fake.NewSimpleClientset(
+		&apps.Deployment{
+			ObjectMeta: metaV1.ObjectMeta{Namespace: "default", Name: "frontend"},
+			Spec:       apps.DeploymentSpec{Selector: &metaV1.LabelSelector{MatchLabels: labels}},
+		},
+		node("node-a1", "zone-a"), node("node-a2", "zone-a"), node("node-b1", "zone-b"),
+		pod("frontend-1", "node-a1", labels),
+		pod("frontend-2", "node-a2", labels),
+		pod("frontend-3", "", labels),
+		pod("other", "node-b1", map[string]string{"app": "other"}),
+	)
+
+	placement, err := GetPodPlacement(client, "deployment", "default", "frontend")
+	if err != nil {
+		t.Fatalf("GetPodPlacement() returned error: %s", err)
+	}
+
+	expected := &PodPlacement{
+		Zones: []ZonePlacement{
+			{Zone: "zone-a", PodCount: 2, Nodes: []NodePlacement{
+				{Node: "node-a1", Pods: []string{"frontend-1"}},
+				{Node: "node-a2", Pods: []string{"frontend-2"}},
+			}},
+			{Zone: "zone-b", PodCount: 0, Nodes: []NodePlacement{}},
+		},
+		UnscheduledPods: []string{"frontend-3"},
+		MaxSkew:         2,
+		SingleZone:      true,
+		SingleNode:      false,
+		Errors:          []error{},
+	}
+	if !reflect.DeepEqual(placement, expected) {
+		t.Errorf("GetPodPlacement() == %#v, expected %#v", placement, expected)
+	}
+
+	if _, err := GetPodPlacement(client, "service", "default", "frontend"); err == nil {
+		t.Error("GetPodPlacement() expected error for kind without pods")
+	}
+}
@@ -0,0 +1,175 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package velero
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+)
+
+// Backup is a single Velero backup.
+type Backup struct {
+	api.ObjectMeta `json:"objectMeta"`
+	api.TypeMeta   `json:"typeMeta"`
+	Status         `json:",inline"`
+
+	// Name of the backup storage location the backup is stored in.
+	StorageLocation string `json:"storageLocation"`
+
+	// Time when the backup is garbage collected.
+	Expiration *metaV1.Time `json:"expiration,omitempty"`
+}
+
+// BackupList contains a list of Velero backups.
+type BackupList struct {
+	api.ListMeta `json:"listMeta"`
+
+	// Unordered list of backups.
+	Items []Backup `json:"items"`
+
+	// False when Velero is not installed in the cluster.
+	Installed bool `json:"installed"`
+
+	// List of non-critical errors, that occurred during resource retrieval.
+	Errors []error `json:"errors"`
+}
+
+// BackupSpec is a specification of a backup of a single namespace triggered from Dashboard.
+type BackupSpec struct {
+	// Namespace to back up.
+	Namespace string `json:"namespace"`
+
+	// Optional time to live of the backup, i.e. 720h. Default of Velero server is used when empty.
+	TTL string `json:"ttl"`
+
+	// Optional name of the backup storage location. Default location is used when empty.
+	StorageLocation string `json:"storageLocation"`
+}
+
+// GetBackupList returns Velero backups that include objects of the given namespace. All backups are returned
+// when the namespace is empty. Empty list is returned when Velero is not installed.
+func GetBackupList(discoveryClient discovery.DiscoveryInterface, client dynamic.Interface, namespace string,
+	dsQuery *dataselect.DataSelectQuery) (*BackupList, error) {
+	log.Printf("Getting list of backups of %s namespace", namespace)
+
+	result := &BackupList{Items: make([]Backup, 0), Errors: make([]error, 0)}
+	backups, installed, err := listCovering(discoveryClient, client, "backups", namespace)
+	nonCriticalErrors, criticalError := errors.HandleError(err)
+	if criticalError != nil {
+		return nil, criticalError
+	}
+	result.Installed = installed
+	result.Errors = nonCriticalErrors
+
+	cells, filteredTotal := dataselect.GenericDataSelectWithFilter(toCells(backups), dsQuery)
+	backups = fromCells(cells)
+	result.ListMeta = api.ListMeta{TotalItems: filteredTotal}
+	for i := range backups {
+		result.Items = append(result.Items, toBackup(&backups[i]))
+	}
+
+	return result, nil
+}
+
+// CreateBackup creates Velero backup of the namespace in the namespace that Velero is installed in. Backup is
+// named after the backed up namespace and the current time.
+func CreateBackup(discoveryClient discovery.DiscoveryInterface, client dynamic.Interface, veleroNamespace string,
+	spec *BackupSpec) (*Backup, error) {
+	log.Printf("Creating backup of %s namespace", spec.Namespace)
+
+	resource, installed, err := getVeleroResource(discoveryClient, "backups")
+	if err != nil {
+		return nil, err
+	}
+	if !installed {
+		return nil, errors.NewNotFound("Velero is not installed in the cluster")
+	}
+
+	backupSpec := map[string]interface{}{
+		"includedNamespaces": []interface{}{spec.Namespace},
+	}
+	if len(spec.TTL) > 0 {
+		if _, err := time.ParseDuration(spec.TTL); err != nil {
+			return nil, errors.NewBadRequest(fmt.Sprintf("invalid backup ttl %q: %s", spec.TTL, err))
+		}
+		backupSpec["ttl"] = spec.TTL
+	}
+	if len(spec.StorageLocation) > 0 {
+		backupSpec["storageLocation"] = spec.StorageLocation
+	}
+
+	backup := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": resource.GroupVersion().String(),
+		"kind":       "Backup",
+		"metadata": map[string]interface{}{
+			"name":      fmt.Sprintf("%s-%s", spec.Namespace, time.Now().UTC().Format("20060102150405")),
+			"namespace": veleroNamespace,
+		},
+		"spec": backupSpec,
+	}}
+
+	created, err := client.Resource(resource).Namespace(veleroNamespace).Create(backup, metaV1.CreateOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	result := toBackup(created)
+	return &result, nil
+}
+
+// listCovering lists Velero objects of the resource, that process objects of the namespace.
+func listCovering(discoveryClient discovery.DiscoveryInterface, client dynamic.Interface, resourceName,
+	namespace string) ([]unstructured.Unstructured, bool, error) {
+	resource, installed, err := getVeleroResource(discoveryClient, resourceName)
+	if err != nil || !installed {
+		return []unstructured.Unstructured{}, installed, err
+	}
+
+	// Velero objects are stored in the namespace of Velero, not in the namespaces they process.
+	list, err := client.Resource(resource).Namespace(metaV1.NamespaceAll).List(api.ListEverything)
+	if err != nil {
+		return []unstructured.Unstructured{}, true, err
+	}
+
+	result := make([]unstructured.Unstructured, 0)
+	for i := range list.Items {
+		if toStatus(&list.Items[i]).covers(namespace) {
+			result = append(result, list.Items[i])
+		}
+	}
+	return result, true, nil
+}
+
+func toBackup(obj *unstructured.Unstructured) Backup {
+	backup := Backup{
+		ObjectMeta: common.NewUnstructuredObjectMeta(obj),
+		TypeMeta:   api.NewTypeMeta(api.ResourceKindBackup),
+		Status:     toStatus(obj),
+		Expiration: getTime(obj, "status", "expiration"),
+	}
+	backup.StorageLocation, _, _ = unstructured.NestedString(obj.Object, "spec", "storageLocation")
+	return backup
+}
@@ -0,0 +1,103 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package velero
+
+import (
+	"reflect"
+	"testing"
+
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	fakedynamic "k8s.io/client-go/dynamic/fake"
+	clienttesting "k8s.io/client-go/testing"
+
+	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+)
+
+func newBackup(name string, spec map[string]interface{}) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "velero.io/v1",
+		"kind":       "Backup",
+		"metadata":   map[string]interface{}{"name": name, "namespace": "velero"},
+		"spec":       spec,
+		"status":     map[string]interface{}{"phase": "PartiallyFailed", "errors": int64(2)},
+	}}
+}
+
+func newVeleroDiscovery() *fakediscovery.FakeDiscovery {
+	return &fakediscovery.FakeDiscovery{Fake: &clienttesting.Fake{
+		Resources: []*metaV1.APIResourceList{{GroupVersion: "velero.io/v1"}},
+	}}
+}
+
+func TestGetBackupList(t *testing.T) {
+	client := fakedynamic.NewSimpleDynamicClient(runtime.NewScheme(),
+		newBackup("all", map[string]interface{}{}),
+		newBackup("wildcard", map[string]interface{}{"includedNamespaces": []interface{}{"*"},
+			"excludedNamespaces": []interface{}{"kube-system"}}),
+		newBackup("web", map[string]interface{}{"includedNamespaces": []interface{}{"web"}}),
+		newBackup("other", map[string]interface{}{"includedNamespaces": []interface{}{"other"}}),
+	)
+
+	cases := []struct {
+		namespace string
+		expected  []string
+	}{
+		{"", []string{"all", "other", "web", "wildcard"}},
+		{"web", []string{"all", "web", "wildcard"}},
+		{"kube-system", []string{"all"}},
+	}
+
+	dsQuery := dataselect.NewDataSelectQuery(dataselect.NoPagination, dataselect.NewSortQuery([]string{"a", "name"}),
+		dataselect.NoFilter, dataselect.NoMetrics)
+	for _, c := range cases {
+		list, err := GetBackupList(newVeleroDiscovery(), client, c.namespace, dsQuery)
+		if err != nil {
+			t.Errorf("GetBackupList(%q) returned unexpected error: %s", c.namespace, err)
+			continue
+		}
+
+		names := make([]string, 0)
+		for _, backup := range list.Items {
+			names = append(names, backup.Name)
+		}
+		if !reflect.DeepEqual(names, c.expected) {
+			t.Errorf("GetBackupList(%q) == %v, expected %v", c.namespace, names, c.expected)
+		}
+		if list.Items[0].Phase != "PartiallyFailed" || list.Items[0].ErrorCount != 2 {
+			t.Errorf("GetBackupList(%q) returned %#v, expected phase and errors of the backup", c.namespace,
+				list.Items[0].Status)
+		}
+	}
+}
+
+func TestCreateBackup(t *testing.T) {
+	client := fakedynamic.NewSimpleDynamicClient(runtime.NewScheme())
+
+	backup, err := CreateBackup(newVeleroDiscovery(), client, "velero", &BackupSpec{Namespace: "web", TTL: "24h"})
+	if err != nil {
+		t.Fatalf("CreateBackup() returned unexpected error: %s", err)
+	}
+	if backup.Namespace != "velero" || !reflect.DeepEqual(backup.IncludedNamespaces, []string{"web"}) {
+		t.Errorf("CreateBackup() == %#v, expected backup of web namespace in velero namespace", backup)
+	}
+
+	if _, err := CreateBackup(newVeleroDiscovery(), client, "velero",
+		&BackupSpec{Namespace: "web", TTL: "month"}); err == nil {
+		t.Errorf("CreateBackup() expected error for invalid ttl")
+	}
+}
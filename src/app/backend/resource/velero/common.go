@@ -0,0 +1,154 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package velero
+
+import (
+	"time"
+
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+)
+
+// veleroGroup is the API group of Velero.
+const veleroGroup = "velero.io"
+
+// Status is the status of a backup or restore shared by both kinds.
+type Status struct {
+	// Phase of the backup or restore, i.e. InProgress, Completed, PartiallyFailed or Failed.
+	Phase string `json:"phase"`
+
+	// Namespaces included in the backup or restore. Empty list or "*" means all namespaces.
+	IncludedNamespaces []string `json:"includedNamespaces"`
+
+	// Namespaces excluded from the backup or restore.
+	ExcludedNamespaces []string `json:"excludedNamespaces"`
+
+	// Number of errors and warnings that occurred while processing individual items.
+	ErrorCount   int64 `json:"errorCount"`
+	WarningCount int64 `json:"warningCount"`
+
+	// Problems with the spec that prevented the backup or restore from running.
+	ValidationErrors []string `json:"validationErrors"`
+
+	// Time when processing started and completed.
+	StartTimestamp      *metaV1.Time `json:"startTimestamp,omitempty"`
+	CompletionTimestamp *metaV1.Time `json:"completionTimestamp,omitempty"`
+}
+
+func getVeleroResource(discoveryClient discovery.DiscoveryInterface, resource string) (
+	schema.GroupVersionResource, bool, error) {
+	return common.FindServedResource(discoveryClient, resource, veleroGroup)
+}
+
+func toStatus(obj *unstructured.Unstructured) Status {
+	status := Status{
+		StartTimestamp:      getTime(obj, "status", "startTimestamp"),
+		CompletionTimestamp: getTime(obj, "status", "completionTimestamp"),
+	}
+	status.Phase, _, _ = unstructured.NestedString(obj.Object, "status", "phase")
+	status.IncludedNamespaces, _, _ = unstructured.NestedStringSlice(obj.Object, "spec", "includedNamespaces")
+	status.ExcludedNamespaces, _, _ = unstructured.NestedStringSlice(obj.Object, "spec", "excludedNamespaces")
+	status.ErrorCount, _, _ = unstructured.NestedInt64(obj.Object, "status", "errors")
+	status.WarningCount, _, _ = unstructured.NestedInt64(obj.Object, "status", "warnings")
+	status.ValidationErrors, _, _ = unstructured.NestedStringSlice(obj.Object, "status", "validationErrors")
+
+	if len(status.Phase) == 0 {
+		// Velero sets the phase when it picks the object up.
+		status.Phase = "New"
+	}
+	if status.IncludedNamespaces == nil {
+		status.IncludedNamespaces = []string{}
+	}
+	if status.ExcludedNamespaces == nil {
+		status.ExcludedNamespaces = []string{}
+	}
+	if status.ValidationErrors == nil {
+		status.ValidationErrors = []string{}
+	}
+	return status
+}
+
+// covers checks whether the backup or restore processes objects of the namespace. All objects are covered when
+// the namespace is empty.
+func (self Status) covers(namespace string) bool {
+	if len(namespace) == 0 {
+		return true
+	}
+
+	for _, excluded := range self.ExcludedNamespaces {
+		if excluded == namespace {
+			return false
+		}
+	}
+
+	if len(self.IncludedNamespaces) == 0 {
+		return true
+	}
+	for _, included := range self.IncludedNamespaces {
+		if included == "*" || included == namespace {
+			return true
+		}
+	}
+	return false
+}
+
+func getTime(obj *unstructured.Unstructured, fields ...string) *metaV1.Time {
+	value, _, _ := unstructured.NestedString(obj.Object, fields...)
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil
+	}
+	return &metaV1.Time{Time: parsed}
+}
+
+// The code below allows to perform complex data section on []unstructured.Unstructured
+
+type ObjectCell unstructured.Unstructured
+
+func (self ObjectCell) GetProperty(name dataselect.PropertyName) dataselect.ComparableValue {
+	obj := unstructured.Unstructured(self)
+	switch name {
+	case dataselect.NameProperty:
+		return dataselect.StdComparableString(obj.GetName())
+	case dataselect.CreationTimestampProperty:
+		return dataselect.StdComparableTime(obj.GetCreationTimestamp().Time)
+	case dataselect.NamespaceProperty:
+		return dataselect.StdComparableString(obj.GetNamespace())
+	default:
+		// if name is not supported then just return a constant dummy value, sort will have no effect.
+		return nil
+	}
+}
+
+func toCells(std []unstructured.Unstructured) []dataselect.DataCell {
+	cells := make([]dataselect.DataCell, len(std))
+	for i := range std {
+		cells[i] = ObjectCell(std[i])
+	}
+	return cells
+}
+
+func fromCells(cells []dataselect.DataCell) []unstructured.Unstructured {
+	std := make([]unstructured.Unstructured, len(cells))
+	for i := range std {
+		std[i] = unstructured.Unstructured(cells[i].(ObjectCell))
+	}
+	return std
+}
@@ -0,0 +1,91 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package velero
+
+import (
+	"log"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+)
+
+// Restore is a single Velero restore.
+type Restore struct {
+	api.ObjectMeta `json:"objectMeta"`
+	api.TypeMeta   `json:"typeMeta"`
+	Status         `json:",inline"`
+
+	// Name of the backup that is restored.
+	BackupName string `json:"backupName"`
+
+	// Reason of the failure of the whole restore.
+	FailureReason string `json:"failureReason,omitempty"`
+}
+
+// RestoreList contains a list of Velero restores.
+type RestoreList struct {
+	api.ListMeta `json:"listMeta"`
+
+	// Unordered list of restores.
+	Items []Restore `json:"items"`
+
+	// False when Velero is not installed in the cluster.
+	Installed bool `json:"installed"`
+
+	// List of non-critical errors, that occurred during resource retrieval.
+	Errors []error `json:"errors"`
+}
+
+// GetRestoreList returns Velero restores that include objects of the given namespace. All restores are returned
+// when the namespace is empty. Empty list is returned when Velero is not installed.
+func GetRestoreList(discoveryClient discovery.DiscoveryInterface, client dynamic.Interface, namespace string,
+	dsQuery *dataselect.DataSelectQuery) (*RestoreList, error) {
+	log.Printf("Getting list of restores of %s namespace", namespace)
+
+	result := &RestoreList{Items: make([]Restore, 0), Errors: make([]error, 0)}
+	restores, installed, err := listCovering(discoveryClient, client, "restores", namespace)
+	nonCriticalErrors, criticalError := errors.HandleError(err)
+	if criticalError != nil {
+		return nil, criticalError
+	}
+	result.Installed = installed
+	result.Errors = nonCriticalErrors
+
+	cells, filteredTotal := dataselect.GenericDataSelectWithFilter(toCells(restores), dsQuery)
+	restores = fromCells(cells)
+	result.ListMeta = api.ListMeta{TotalItems: filteredTotal}
+	for i := range restores {
+		result.Items = append(result.Items, toRestore(&restores[i]))
+	}
+
+	return result, nil
+}
+
+func toRestore(obj *unstructured.Unstructured) Restore {
+	restore := Restore{
+		ObjectMeta: common.NewUnstructuredObjectMeta(obj),
+		TypeMeta:   api.NewTypeMeta(api.ResourceKindRestore),
+		Status:     toStatus(obj),
+	}
+	restore.BackupName, _, _ = unstructured.NestedString(obj.Object, "spec", "backupName")
+	restore.FailureReason, _, _ = unstructured.NestedString(obj.Object, "status", "failureReason")
+	return restore
+}
@@ -0,0 +1,228 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verticalpodautoscaler
+
+import (
+	"fmt"
+	"log"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+	client "k8s.io/client-go/kubernetes"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+)
+
+// vpaGroup is the API group of vertical pod autoscalers.
+const vpaGroup = "autoscaling.k8s.io"
+
+const (
+	// overProvisionedRatio is the ratio of the request to the recommended target above which the container is
+	// reported as over-provisioned.
+	overProvisionedRatio = 2.0
+
+	// underProvisionedRatio is the ratio of the request to the recommended target below which the container is
+	// reported as under-provisioned.
+	underProvisionedRatio = 0.5
+)
+
+// ProvisioningStatus compares the current request of a container with the recommendation.
+type ProvisioningStatus string
+
+// List of provisioning statuses.
+const (
+	// ProvisioningStatusBalanced means that the request is close to the recommended target.
+	ProvisioningStatusBalanced ProvisioningStatus = "Balanced"
+
+	// ProvisioningStatusOverProvisioned means that the container requests much more than it needs.
+	ProvisioningStatusOverProvisioned ProvisioningStatus = "OverProvisioned"
+
+	// ProvisioningStatusUnderProvisioned means that the container requests much less than it needs.
+	ProvisioningStatusUnderProvisioned ProvisioningStatus = "UnderProvisioned"
+
+	// ProvisioningStatusNoRequest means that the container does not request the resource at all.
+	ProvisioningStatusNoRequest ProvisioningStatus = "NoRequest"
+
+	// ProvisioningStatusUnknown means that there is no recommendation for the resource yet.
+	ProvisioningStatusUnknown ProvisioningStatus = "Unknown"
+)
+
+// ResourceRecommendation is the recommendation for a single resource of a container, i.e. CPU.
+type ResourceRecommendation struct {
+	// Current request of the container.
+	Request *resource.Quantity `json:"request,omitempty"`
+
+	// Recommended request and the range in which the request is considered sufficient.
+	Target     *resource.Quantity `json:"target,omitempty"`
+	LowerBound *resource.Quantity `json:"lowerBound,omitempty"`
+	UpperBound *resource.Quantity `json:"upperBound,omitempty"`
+
+	// Comparison of the current request with the recommended target.
+	Status ProvisioningStatus `json:"status"`
+}
+
+// ContainerRecommendation contains recommendations for a single container of the workload.
+type ContainerRecommendation struct {
+	ContainerName string                 `json:"containerName"`
+	CPU           ResourceRecommendation `json:"cpu"`
+	Memory        ResourceRecommendation `json:"memory"`
+}
+
+// Recommendations are recommendations of the vertical pod autoscaler targeting the workload.
+type Recommendations struct {
+	// Name of the vertical pod autoscaler.
+	Name string `json:"name"`
+
+	// Update mode of the autoscaler. Recommendations are applied to pods only in Auto and Recreate modes.
+	UpdateMode string `json:"updateMode"`
+
+	// Recommendations for containers of the workload, in the order of the pod template.
+	Containers []ContainerRecommendation `json:"containers"`
+}
+
+// GetWorkloadRecommendations returns recommendations of the vertical pod autoscaler targeting the workload,
+// together with current requests of its containers. Kind is the Kubernetes kind of the workload, i.e. Deployment.
+// Nil is returned when VPA is not installed or no autoscaler targets the workload.
+func GetWorkloadRecommendations(client client.Interface, discoveryClient discovery.DiscoveryInterface,
+	dynamicClient dynamic.Interface, kind, namespace, name string) (*Recommendations, error) {
+	gvr, installed, err := common.FindServedResource(discoveryClient, "verticalpodautoscalers", vpaGroup)
+	if err != nil || !installed {
+		return nil, err
+	}
+
+	list, err := dynamicClient.Resource(gvr).Namespace(namespace).List(api.ListEverything)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range list.Items {
+		vpa := &list.Items[i]
+		targetKind, _, _ := unstructured.NestedString(vpa.Object, "spec", "targetRef", "kind")
+		targetName, _, _ := unstructured.NestedString(vpa.Object, "spec", "targetRef", "name")
+		if targetKind != kind || targetName != name {
+			continue
+		}
+
+		log.Printf("Getting recommendations of %s vertical pod autoscaler in %s namespace", vpa.GetName(), namespace)
+		spec, err := getPodSpec(client, kind, namespace, name)
+		if err != nil {
+			return nil, err
+		}
+		return toRecommendations(vpa, spec), nil
+	}
+
+	return nil, nil
+}
+
+func getPodSpec(client client.Interface, kind, namespace, name string) (*v1.PodSpec, error) {
+	switch kind {
+	case "Deployment":
+		deployment, err := client.AppsV1().Deployments(namespace).Get(name, metaV1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return &deployment.Spec.Template.Spec, nil
+	case "StatefulSet":
+		statefulSet, err := client.AppsV1().StatefulSets(namespace).Get(name, metaV1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return &statefulSet.Spec.Template.Spec, nil
+	case "DaemonSet":
+		daemonSet, err := client.AppsV1().DaemonSets(namespace).Get(name, metaV1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return &daemonSet.Spec.Template.Spec, nil
+	}
+	return nil, fmt.Errorf("recommendations of %s workloads are not supported", kind)
+}
+
+func toRecommendations(vpa *unstructured.Unstructured, spec *v1.PodSpec) *Recommendations {
+	result := &Recommendations{Name: vpa.GetName(), UpdateMode: "Auto",
+		Containers: make([]ContainerRecommendation, 0)}
+	if mode, _, _ := unstructured.NestedString(vpa.Object, "spec", "updatePolicy", "updateMode"); len(mode) > 0 {
+		result.UpdateMode = mode
+	}
+
+	recommendations := make(map[string]map[string]interface{})
+	containers, _, _ := unstructured.NestedSlice(vpa.Object, "status", "recommendation", "containerRecommendations")
+	for _, container := range containers {
+		if container, ok := container.(map[string]interface{}); ok {
+			name, _, _ := unstructured.NestedString(container, "containerName")
+			recommendations[name] = container
+		}
+	}
+
+	for _, container := range spec.Containers {
+		recommendation := recommendations[container.Name]
+		result.Containers = append(result.Containers, ContainerRecommendation{
+			ContainerName: container.Name,
+			CPU:           toResourceRecommendation(recommendation, container, v1.ResourceCPU),
+			Memory:        toResourceRecommendation(recommendation, container, v1.ResourceMemory),
+		})
+	}
+	return result
+}
+
+func toResourceRecommendation(recommendation map[string]interface{}, container v1.Container,
+	name v1.ResourceName) ResourceRecommendation {
+	result := ResourceRecommendation{
+		Target:     getQuantity(recommendation, "target", name),
+		LowerBound: getQuantity(recommendation, "lowerBound", name),
+		UpperBound: getQuantity(recommendation, "upperBound", name),
+	}
+	if request, ok := container.Resources.Requests[name]; ok {
+		result.Request = &request
+	}
+	result.Status = getProvisioningStatus(result.Request, result.Target)
+	return result
+}
+
+func getProvisioningStatus(request, target *resource.Quantity) ProvisioningStatus {
+	switch {
+	case target == nil || target.IsZero():
+		return ProvisioningStatusUnknown
+	case request == nil || request.IsZero():
+		return ProvisioningStatusNoRequest
+	}
+
+	ratio := float64(request.MilliValue()) / float64(target.MilliValue())
+	switch {
+	case ratio >= overProvisionedRatio:
+		return ProvisioningStatusOverProvisioned
+	case ratio <= underProvisionedRatio:
+		return ProvisioningStatusUnderProvisioned
+	}
+	return ProvisioningStatusBalanced
+}
+
+func getQuantity(recommendation map[string]interface{}, field string, name v1.ResourceName) *resource.Quantity {
+	value, found, _ := unstructured.NestedString(recommendation, field, string(name))
+	if !found {
+		return nil
+	}
+
+	quantity, err := resource.ParseQuantity(value)
+	if err != nil {
+		return nil
+	}
+	return &quantity
+}
@@ -0,0 +1,111 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verticalpodautoscaler
+
+import (
+	"testing"
+
+	apps "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	fakedynamic "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+	clienttesting "k8s.io/client-go/testing"
+)
+
+func newVPA(name, kind, target string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": vpaGroup + "/v1",
+		"kind":       "VerticalPodAutoscaler",
+		"metadata":   map[string]interface{}{"name": name, "namespace": "default"},
+		"spec": map[string]interface{}{
+			"targetRef":    map[string]interface{}{"apiVersion": "apps/v1", "kind": kind, "name": target},
+			"updatePolicy": map[string]interface{}{"updateMode": "Off"},
+		},
+		"status": map[string]interface{}{"recommendation": map[string]interface{}{
+			"containerRecommendations": []interface{}{
+				map[string]interface{}{
+					"containerName": "app",
+					"target":        map[string]interface{}{"cpu": "100m", "memory": "256Mi"},
+					"lowerBound":    map[string]interface{}{"cpu": "50m", "memory": "200Mi"},
+					"upperBound":    map[string]interface{}{"cpu": "200m", "memory": "512Mi"},
+				},
+				map[string]interface{}{
+					"containerName": "sidecar",
+					"target":        map[string]interface{}{"cpu": "10m", "memory": "64Mi"},
+				},
+			},
+		}},
+	}}
+}
+
+func TestGetWorkloadRecommendations(t *testing.T) {
+	deployment := &apps.Deployment{
+		ObjectMeta: metaV1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec: apps.DeploymentSpec{Template: v1.PodTemplateSpec{Spec: v1.PodSpec{Containers: []v1.Container{
+			{Name: "app", Resources: v1.ResourceRequirements{Requests: v1.ResourceList{
+				v1.ResourceCPU:    resource.MustParse("1"),
+				v1.ResourceMemory: resource.MustParse("100Mi"),
+			}}},
+			{Name: "sidecar", Resources: v1.ResourceRequirements{Requests: v1.ResourceList{
+				v1.ResourceCPU: resource.MustParse("10m"),
+			}}},
+			{Name: "new"},
+		}}}},
+	}
+	discoveryClient := &fakediscovery.FakeDiscovery{Fake: &clienttesting.Fake{Resources: []*metaV1.APIResourceList{
+		{GroupVersion: vpaGroup + "/v1"},
+	}}}
+	dynamicClient := fakedynamic.NewSimpleDynamicClient(runtime.NewScheme(), newVPA("other", "Deployment", "api"),
+		newVPA("web", "Deployment", "web"))
+
+	recommendations, err := GetWorkloadRecommendations(fake.NewSimpleClientset(deployment), discoveryClient,
+		dynamicClient, "Deployment", "default", "web")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if recommendations == nil || recommendations.Name != "web" || recommendations.UpdateMode != "Off" ||
+		len(recommendations.Containers) != 3 {
+		t.Fatalf("Unexpected recommendations %#v", recommendations)
+	}
+
+	expected := []struct {
+		container   string
+		cpu, memory ProvisioningStatus
+	}{
+		{"app", ProvisioningStatusOverProvisioned, ProvisioningStatusUnderProvisioned},
+		{"sidecar", ProvisioningStatusBalanced, ProvisioningStatusNoRequest},
+		{"new", ProvisioningStatusUnknown, ProvisioningStatusUnknown},
+	}
+	for i, e := range expected {
+		container := recommendations.Containers[i]
+		if container.ContainerName != e.container || container.CPU.Status != e.cpu ||
+			container.Memory.Status != e.memory {
+			t.Errorf("Expected %s container to be %s/%s, got %s/%s", e.container, e.cpu, e.memory,
+				container.CPU.Status, container.Memory.Status)
+		}
+	}
+
+	recommendations, err = GetWorkloadRecommendations(fake.NewSimpleClientset(deployment), discoveryClient,
+		dynamicClient, "StatefulSet", "default", "web")
+	if err != nil || recommendations != nil {
+		t.Errorf("Expected no recommendations for workload without autoscaler, got %#v, %v", recommendations, err)
+	}
+}
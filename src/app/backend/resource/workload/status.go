@@ -0,0 +1,254 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workload
+
+import (
+	"log"
+	"sort"
+
+	apps "k8s.io/api/apps/v1"
+	batch "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	client "k8s.io/client-go/kubernetes"
+
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+)
+
+// StatusSummary counts pods by their status and workloads by their health in every namespace, so that an
+// overview can be displayed without listing all of the resources.
+type StatusSummary struct {
+	// Namespaces are sorted by name. Namespaces without any pods and workloads are omitted.
+	Namespaces []NamespaceStatus `json:"namespaces"`
+	// Total is a sum of all namespaces.
+	Total NamespaceStatus `json:"total"`
+
+	// List of non-critical errors, that occurred during resource retrieval.
+	Errors []error `json:"errors"`
+}
+
+// NamespaceStatus is a summary of a single namespace. Namespace is empty for the total.
+type NamespaceStatus struct {
+	Namespace   string                `json:"namespace,omitempty"`
+	Pods        common.ResourceStatus `json:"pods"`
+	Deployments HealthStatus          `json:"deployments"`
+	DaemonSets  HealthStatus          `json:"daemonSets"`
+	Jobs        HealthStatus          `json:"jobs"`
+}
+
+// HealthStatus counts workloads by their health.
+type HealthStatus struct {
+	// Number of workloads, that run all of the desired pods in the latest version.
+	Healthy int `json:"healthy"`
+	// Number of workloads, that are rolling out, miss some of the pods or failed.
+	Degraded int `json:"degraded"`
+}
+
+// GetStatusSummary returns status summary of workloads in namespaces selected by the query. Kinds, i.e. secret,
+// for which isHidden returns true are neither listed nor counted.
+func GetStatusSummary(client client.Interface, nsQuery *common.NamespaceQuery,
+	isHidden func(kind string) bool) (*StatusSummary, error) {
+	log.Print("Getting workload status summary")
+
+	channels := &common.ResourceChannels{}
+	if !isHidden("pod") {
+		channels.PodList = common.GetPodListChannel(client, nsQuery, 1)
+	}
+	if !isHidden("deployment") {
+		channels.DeploymentList = common.GetDeploymentListChannel(client, nsQuery, 1)
+	}
+	if !isHidden("daemonset") {
+		channels.DaemonSetList = common.GetDaemonSetListChannel(client, nsQuery, 1)
+	}
+	if !isHidden("job") {
+		channels.JobList = common.GetJobListChannel(client, nsQuery, 1)
+	}
+
+	var pods *v1.PodList
+	var deployments *apps.DeploymentList
+	var daemonSets *apps.DaemonSetList
+	var jobs *batch.JobList
+	nonCriticalErrors := make([]error, 0)
+	var err, criticalError error
+
+	if channels.PodList.List != nil {
+		pods = <-channels.PodList.List
+		err = <-channels.PodList.Error
+		nonCriticalErrors, criticalError = errors.AppendError(err, nonCriticalErrors)
+		if criticalError != nil {
+			return nil, criticalError
+		}
+	}
+
+	if channels.DeploymentList.List != nil {
+		deployments = <-channels.DeploymentList.List
+		err = <-channels.DeploymentList.Error
+		nonCriticalErrors, criticalError = errors.AppendError(err, nonCriticalErrors)
+		if criticalError != nil {
+			return nil, criticalError
+		}
+	}
+
+	if channels.DaemonSetList.List != nil {
+		daemonSets = <-channels.DaemonSetList.List
+		err = <-channels.DaemonSetList.Error
+		nonCriticalErrors, criticalError = errors.AppendError(err, nonCriticalErrors)
+		if criticalError != nil {
+			return nil, criticalError
+		}
+	}
+
+	if channels.JobList.List != nil {
+		jobs = <-channels.JobList.List
+		err = <-channels.JobList.Error
+		nonCriticalErrors, criticalError = errors.AppendError(err, nonCriticalErrors)
+		if criticalError != nil {
+			return nil, criticalError
+		}
+	}
+
+	summary := toStatusSummary(pods, deployments, daemonSets, jobs)
+	summary.Errors = nonCriticalErrors
+	return summary, nil
+}
+
+func toStatusSummary(pods *v1.PodList, deployments *apps.DeploymentList, daemonSets *apps.DaemonSetList,
+	jobs *batch.JobList) *StatusSummary {
+	namespaces := make(map[string]*NamespaceStatus)
+	get := func(namespace string) *NamespaceStatus {
+		if _, ok := namespaces[namespace]; !ok {
+			namespaces[namespace] = &NamespaceStatus{Namespace: namespace}
+		}
+		return namespaces[namespace]
+	}
+
+	if pods != nil {
+		for _, pod := range pods.Items {
+			countPod(&get(pod.Namespace).Pods, pod)
+		}
+	}
+	if deployments != nil {
+		for _, deployment := range deployments.Items {
+			count(&get(deployment.Namespace).Deployments, isDeploymentHealthy(deployment))
+		}
+	}
+	if daemonSets != nil {
+		for _, daemonSet := range daemonSets.Items {
+			count(&get(daemonSet.Namespace).DaemonSets, isDaemonSetHealthy(daemonSet))
+		}
+	}
+	if jobs != nil {
+		for _, job := range jobs.Items {
+			count(&get(job.Namespace).Jobs, !isJobFailed(job))
+		}
+	}
+
+	result := &StatusSummary{Namespaces: make([]NamespaceStatus, 0, len(namespaces))}
+	for _, status := range namespaces {
+		result.Namespaces = append(result.Namespaces, *status)
+		result.Total.add(*status)
+	}
+	sort.Slice(result.Namespaces, func(i, j int) bool {
+		return result.Namespaces[i].Namespace < result.Namespaces[j].Namespace
+	})
+	return result
+}
+
+func (self *NamespaceStatus) add(other NamespaceStatus) {
+	self.Pods.Running += other.Pods.Running
+	self.Pods.Pending += other.Pods.Pending
+	self.Pods.Failed += other.Pods.Failed
+	self.Pods.Succeeded += other.Pods.Succeeded
+	self.Deployments.Healthy += other.Deployments.Healthy
+	self.Deployments.Degraded += other.Deployments.Degraded
+	self.DaemonSets.Healthy += other.DaemonSets.Healthy
+	self.DaemonSets.Degraded += other.DaemonSets.Degraded
+	self.Jobs.Healthy += other.Jobs.Healthy
+	self.Jobs.Degraded += other.Jobs.Degraded
+}
+
+func count(status *HealthStatus, healthy bool) {
+	if healthy {
+		status.Healthy++
+	} else {
+		status.Degraded++
+	}
+}
+
+// countPod counts the pod by its phase. Running pods with containers, that are crash looping or can not pull
+// their image, are counted as failed.
+func countPod(status *common.ResourceStatus, pod v1.Pod) {
+	switch pod.Status.Phase {
+	case v1.PodFailed:
+		status.Failed++
+	case v1.PodSucceeded:
+		status.Succeeded++
+	case v1.PodRunning:
+		if hasFailingContainer(pod) {
+			status.Failed++
+		} else {
+			status.Running++
+		}
+	default:
+		if hasFailingContainer(pod) {
+			status.Failed++
+		} else {
+			status.Pending++
+		}
+	}
+}
+
+func hasFailingContainer(pod v1.Pod) bool {
+	for _, containerStatus := range pod.Status.ContainerStatuses {
+		if waiting := containerStatus.State.Waiting; waiting != nil {
+			switch waiting.Reason {
+			case "CrashLoopBackOff", "ImagePullBackOff", "ErrImagePull", "CreateContainerConfigError":
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isDeploymentHealthy returns true if all of the desired replicas are updated and available.
+func isDeploymentHealthy(deployment apps.Deployment) bool {
+	desired := int32(1)
+	if deployment.Spec.Replicas != nil {
+		desired = *deployment.Spec.Replicas
+	}
+
+	for _, condition := range deployment.Status.Conditions {
+		if condition.Type == apps.DeploymentProgressing && condition.Reason == "ProgressDeadlineExceeded" {
+			return false
+		}
+	}
+
+	return deployment.Status.UpdatedReplicas >= desired && deployment.Status.AvailableReplicas >= desired
+}
+
+// isDaemonSetHealthy returns true if pods on all of the desired nodes are updated and available.
+func isDaemonSetHealthy(daemonSet apps.DaemonSet) bool {
+	desired := daemonSet.Status.DesiredNumberScheduled
+	return daemonSet.Status.UpdatedNumberScheduled >= desired && daemonSet.Status.NumberAvailable >= desired
+}
+
+func isJobFailed(job batch.Job) bool {
+	for _, condition := range job.Status.Conditions {
+		if condition.Type == batch.JobFailed && condition.Status == v1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,99 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workload
+
+import (
+	"reflect"
+	"testing"
+
+	apps "k8s.io/api/apps/v1"
+	batch "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+)
+
+func TestGetStatusSummary(t *testing.T) {
+	replicas := int32(2)
+	meta := func(namespace, name string) metaV1.ObjectMeta {
+		return metaV1.ObjectMeta{Namespace: namespace, Name: name}
+	}
+	crashLooping := v1.ContainerStatus{State: v1.ContainerState{Waiting: &v1.ContainerStateWaiting{
+		Reason: "CrashLoopBackOff"}}}
+
+	client := fake.NewSimpleClientset(
+		&v1.Pod{ObjectMeta: meta("default", "running"), Status: v1.PodStatus{Phase: v1.PodRunning}},
+		&v1.Pod{ObjectMeta: meta("default", "pending"), Status: v1.PodStatus{Phase: v1.PodPending}},
+		&v1.Pod{ObjectMeta: meta("default", "crashing"), Status: v1.PodStatus{Phase: v1.PodRunning,
+			ContainerStatuses: []v1.ContainerStatus{crashLooping}}},
+		&v1.Pod{ObjectMeta: meta("kube-system", "failed"), Status: v1.PodStatus{Phase: v1.PodFailed}},
+		&apps.Deployment{ObjectMeta: meta("default", "healthy"), Spec: apps.DeploymentSpec{Replicas: &replicas},
+			Status: apps.DeploymentStatus{UpdatedReplicas: 2, AvailableReplicas: 2}},
+		&apps.Deployment{ObjectMeta: meta("default", "rolling-out"), Spec: apps.DeploymentSpec{Replicas: &replicas},
+			Status: apps.DeploymentStatus{UpdatedReplicas: 1, AvailableReplicas: 2}},
+		&apps.DaemonSet{ObjectMeta: meta("kube-system", "proxy"), Status: apps.DaemonSetStatus{
+			DesiredNumberScheduled: 3, UpdatedNumberScheduled: 3, NumberAvailable: 3}},
+		&batch.Job{ObjectMeta: meta("kube-system", "backup"), Status: batch.JobStatus{Conditions: []batch.JobCondition{
+			{Type: batch.JobFailed, Status: v1.ConditionTrue}}}},
+	)
+
+	summary, err := GetStatusSummary(client, common.NewNamespaceQuery(nil), func(string) bool { return false })
+	if err != nil {
+		t.Fatalf("GetStatusSummary() returned error: %s", err)
+	}
+
+	expected := []NamespaceStatus{
+		{
+			Namespace:   "default",
+			Pods:        common.ResourceStatus{Running: 1, Pending: 1, Failed: 1},
+			Deployments: HealthStatus{Healthy: 1, Degraded: 1},
+		},
+		{
+			Namespace:  "kube-system",
+			Pods:       common.ResourceStatus{Failed: 1},
+			DaemonSets: HealthStatus{Healthy: 1},
+			Jobs:       HealthStatus{Degraded: 1},
+		},
+	}
+	if !reflect.DeepEqual(summary.Namespaces, expected) {
+		t.Errorf("GetStatusSummary() == %#v, expected %#v", summary.Namespaces, expected)
+	}
+
+	expectedTotal := NamespaceStatus{
+		Pods:        common.ResourceStatus{Running: 1, Pending: 1, Failed: 2},
+		Deployments: HealthStatus{Healthy: 1, Degraded: 1},
+		DaemonSets:  HealthStatus{Healthy: 1},
+		Jobs:        HealthStatus{Degraded: 1},
+	}
+	if !reflect.DeepEqual(summary.Total, expectedTotal) {
+		t.Errorf("GetStatusSummary().Total == %#v, expected %#v", summary.Total, expectedTotal)
+	}
+
+	summary, err = GetStatusSummary(client, common.NewNamespaceQuery(nil), func(kind string) bool {
+		return kind == "pod" || kind == "job"
+	})
+	if err != nil {
+		t.Fatalf("GetStatusSummary() returned error: %s", err)
+	}
+
+	expectedTotal.Pods = common.ResourceStatus{}
+	expectedTotal.Jobs = HealthStatus{}
+	if !reflect.DeepEqual(summary.Total, expectedTotal) {
+		t.Errorf("GetStatusSummary().Total == %#v, expected hidden kinds not to be counted in %#v", summary.Total,
+			expectedTotal)
+	}
+}
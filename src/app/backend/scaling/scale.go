@@ -15,19 +15,35 @@
 package scaling
 
 import (
+	"fmt"
 	"strconv"
 
 	apps "k8s.io/api/apps/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/discovery/cached/memory"
 	"k8s.io/client-go/dynamic"
-	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/restmapper"
-	"k8s.io/client-go/scale"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
 )
 
+// scaleSubresource is the name of the subresource used to read and change replica counts.
+const scaleSubresource = "scale"
+
+// scalableResources maps scalable resource kinds to their group resources. Other kinds are treated as
+// group resources in the "resource.group" format, or as resources of the apps group.
+var scalableResources = map[string]schema.GroupResource{
+	api.ResourceKindDeployment:            apps.Resource("deployments"),
+	api.ResourceKindReplicaSet:            apps.Resource("replicasets"),
+	api.ResourceKindStatefulSet:           apps.Resource("statefulsets"),
+	api.ResourceKindReplicationController: {Resource: "replicationcontrollers"},
+}
+
 // ReplicaCounts provide the desired and actual number of replicas.
 type ReplicaCounts struct {
 	DesiredReplicas int32 `json:"desiredReplicas"`
@@ -36,84 +52,108 @@ type ReplicaCounts struct {
 
 // GetReplicaCounts returns a populated ReplicaCounts object with desired and actual number of replicas.
 func GetReplicaCounts(cfg *rest.Config, kind, namespace, name string) (*ReplicaCounts, error) {
-	sc, err := getScaleGetter(cfg)
+	client, err := getScaleClient(cfg, kind, namespace)
 	if err != nil {
 		return nil, err
 	}
 
-	gr := getGroupResource(kind)
-	res, err := sc.Scales(namespace).Get(gr, name)
+	scale, err := client.Get(name, metaV1.GetOptions{}, scaleSubresource)
 	if err != nil {
 		return nil, err
 	}
 
-	return &ReplicaCounts{
-		ActualReplicas:  res.Status.Replicas,
-		DesiredReplicas: res.Spec.Replicas,
-	}, nil
+	return toReplicaCounts(scale), nil
 }
 
-// ScaleResource scales the provided resource using the client scale method in the case of Deployment,
-// ReplicaSet, Replication Controller. In the case of a job we are using the jobs resource update
-// method since the client scale method does not provide one for the job.
-func ScaleResource(cfg *rest.Config, kind, namespace, name, count string) (*ReplicaCounts, error) {
-	sc, err := getScaleGetter(cfg)
+// ScaleResource changes the desired number of replicas of the provided resource using its scale subresource.
+// When dryRun is set, the change is validated by the apiserver but not persisted.
+func ScaleResource(cfg *rest.Config, kind, namespace, name, count string, dryRun bool) (*ReplicaCounts, error) {
+	replicas, err := parseReplicas(count)
 	if err != nil {
 		return nil, err
 	}
 
-	gr := getGroupResource(kind)
-	res, err := sc.Scales(namespace).Get(gr, name)
+	client, err := getScaleClient(cfg, kind, namespace)
 	if err != nil {
 		return nil, err
 	}
 
-	c, err := strconv.Atoi(count)
+	return scaleResource(client, name, replicas, dryRun)
+}
+
+func scaleResource(client dynamic.ResourceInterface, name string, replicas int64,
+	dryRun bool) (*ReplicaCounts, error) {
+	scale, err := client.Get(name, metaV1.GetOptions{}, scaleSubresource)
 	if err != nil {
 		return nil, err
 	}
 
-	res.Spec.Replicas = int32(c)
+	if err := unstructured.SetNestedField(scale.Object, replicas, "spec", "replicas"); err != nil {
+		return nil, err
+	}
+
+	options := metaV1.UpdateOptions{}
+	if dryRun {
+		options.DryRun = []string{metaV1.DryRunAll}
+	}
 
-	res, err = sc.Scales(namespace).Update(gr, res)
+	scale, err = client.Update(scale, options, scaleSubresource)
 	if err != nil {
 		return nil, err
 	}
 
+	return toReplicaCounts(scale), nil
+}
+
+// parseReplicas returns replica count given as a string. Count has to be a non-negative integer.
+func parseReplicas(count string) (int64, error) {
+	replicas, err := strconv.ParseInt(count, 10, 32)
+	if err != nil || replicas < 0 {
+		return 0, errors.NewBadRequest(fmt.Sprintf("replica count has to be a non-negative integer, got %q",
+			count))
+	}
+
+	return replicas, nil
+}
+
+func toReplicaCounts(scale *unstructured.Unstructured) *ReplicaCounts {
+	desired, _, _ := unstructured.NestedInt64(scale.Object, "spec", "replicas")
+	actual, _, _ := unstructured.NestedInt64(scale.Object, "status", "replicas")
 	return &ReplicaCounts{
-		ActualReplicas:  res.Status.Replicas,
-		DesiredReplicas: res.Spec.Replicas,
-	}, nil
+		ActualReplicas:  int32(actual),
+		DesiredReplicas: int32(desired),
+	}
 }
 
-func getScaleGetter(cfg *rest.Config) (scale.ScalesGetter, error) {
+func getScaleClient(cfg *rest.Config, kind, namespace string) (dynamic.ResourceInterface, error) {
 	discoveryClient, err := discovery.NewDiscoveryClientForConfig(cfg)
 	if err != nil {
 		return nil, err
 	}
 
-	cfg.GroupVersion = &apps.SchemeGroupVersion
-	cfg.NegotiatedSerializer = scheme.Codecs
+	mapper := restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(discoveryClient))
+	// Fixes "unable to get full preferred group-version-resource for <resource>: the cache has not been filled yet".
+	// See more: https://github.com/kubernetes/kubernetes/issues/68735
+	mapper.Reset()
+	gvr, err := mapper.ResourceFor(getGroupResource(kind).WithVersion(""))
+	if err != nil {
+		return nil, errors.NewBadRequest(fmt.Sprintf("resource kind %s can not be scaled: %s", kind, err.Error()))
+	}
 
-	restClient, err := rest.RESTClientFor(cfg)
+	dynamicClient, err := dynamic.NewForConfig(cfg)
 	if err != nil {
 		return nil, err
 	}
 
-	resolver := scale.NewDiscoveryScaleKindResolver(discoveryClient)
-	dc := memory.NewMemCacheClient(discoveryClient)
-	drm := restmapper.NewDeferredDiscoveryRESTMapper(dc)
-
-	// Fixes "unable to get full preferred group-version-resource for <resource>: the cache has not been filled yet".
-	// See more: https://github.com/kubernetes/kubernetes/issues/68735
-	drm.Reset()
-
-	return scale.New(restClient, drm, dynamic.LegacyAPIPathResolverFunc, resolver), nil
+	return dynamicClient.Resource(gvr).Namespace(namespace), nil
 }
 
 func getGroupResource(kind string) schema.GroupResource {
-	gr := schema.ParseGroupResource(kind)
+	if gr, ok := scalableResources[kind]; ok {
+		return gr
+	}
 
+	gr := schema.ParseGroupResource(kind)
 	if gr.Group != "" && gr.Resource != "" {
 		return gr
 	}
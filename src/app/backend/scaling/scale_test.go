@@ -0,0 +1,88 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scaling
+
+import (
+	"reflect"
+	"testing"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakedynamic "k8s.io/client-go/dynamic/fake"
+	clienttesting "k8s.io/client-go/testing"
+)
+
+func TestScaleResource(t *testing.T) {
+	client := fakedynamic.NewSimpleDynamicClient(runtime.NewScheme())
+	client.PrependReactor("get", "deployments", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		return true, &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "autoscaling/v1",
+			"kind":       "Scale",
+			"metadata":   map[string]interface{}{"name": "web", "namespace": "default"},
+			"spec":       map[string]interface{}{"replicas": int64(1)},
+			"status":     map[string]interface{}{"replicas": int64(1)},
+		}}, nil
+	})
+	client.PrependReactor("update", "deployments", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() != scaleSubresource {
+			t.Errorf("Expected update of %s subresource, got %q", scaleSubresource, action.GetSubresource())
+		}
+		return true, action.(clienttesting.UpdateAction).GetObject(), nil
+	})
+
+	gvr := schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+	actual, err := scaleResource(client.Resource(gvr).Namespace("default"), "web", 3, false)
+	if err != nil {
+		t.Fatalf("scaleResource() returned unexpected error: %s", err)
+	}
+
+	expected := &ReplicaCounts{DesiredReplicas: 3, ActualReplicas: 1}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("scaleResource() == %#v, expected %#v", actual, expected)
+	}
+}
+
+func TestParseReplicas(t *testing.T) {
+	if replicas, err := parseReplicas("5"); err != nil || replicas != 5 {
+		t.Errorf("parseReplicas(5) == %d, %v, expected 5", replicas, err)
+	}
+
+	for _, count := range []string{"", "-1", "two", "1.5", "4294967296"} {
+		if _, err := parseReplicas(count); !k8serrors.IsBadRequest(err) {
+			t.Errorf("parseReplicas(%q) expected bad request error, got %v", count, err)
+		}
+	}
+}
+
+func TestGetGroupResource(t *testing.T) {
+	cases := []struct {
+		kind     string
+		expected schema.GroupResource
+	}{
+		{"deployment", schema.GroupResource{Group: "apps", Resource: "deployments"}},
+		{"statefulset", schema.GroupResource{Group: "apps", Resource: "statefulsets"}},
+		{"replicationcontroller", schema.GroupResource{Resource: "replicationcontrollers"}},
+		{"widgets.example.com", schema.GroupResource{Group: "example.com", Resource: "widgets"}},
+		{"daemonsets", schema.GroupResource{Group: "apps", Resource: "daemonsets"}},
+	}
+
+	for _, c := range cases {
+		if actual := getGroupResource(c.kind); actual != c.expected {
+			t.Errorf("getGroupResource(%s) == %v, expected %v", c.kind, actual, c.expected)
+		}
+	}
+}
@@ -16,10 +16,14 @@ package api
 
 import (
 	"encoding/json"
+	"strings"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
+
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
 )
 
 const (
@@ -32,6 +36,13 @@ const (
 	// ConfigMapAPIVersion is a API version of config map.
 	ConfigMapAPIVersion = "v1"
 
+	// UserSettingsConfigMapName contains a name prefix of config maps, that store settings of individual users.
+	// Every user has own config map, so that users do not compete for a single object and its size limit.
+	UserSettingsConfigMapName = "kubernetes-dashboard-user-settings"
+
+	// UserSettingsKey is a user settings config map key which maps to stored data of the user.
+	UserSettingsKey = "settings"
+
 	// GlobalSettingsKey is a settings map key which maps to current global settings.
 	GlobalSettingsKey = "_global"
 
@@ -47,6 +58,29 @@ const (
 
 	// ResourceAlreadyPinnedError occurs while pinning a new resource, if it has been pinned before.
 	ResourceAlreadyPinnedError = "resource already pinned"
+
+	// InvalidRefreshIntervalError occurs during settings save if resource auto-refresh interval is lower than
+	// the minimum allowed one.
+	InvalidRefreshIntervalError = "resource auto-refresh interval has to be 0 or at least %d seconds"
+
+	// FavoriteResourceNotFoundError occurs while removing a resource from favorites, if it wasn't a favorite.
+	FavoriteResourceNotFoundError = "favorite resource not found"
+
+	// ResourceAlreadyFavoriteError occurs while adding a resource to favorites, if it has been added before.
+	ResourceAlreadyFavoriteError = "resource already in favorites"
+
+	// TooManyFavoriteResourcesError occurs while adding a resource to favorites, if the user has reached the limit.
+	TooManyFavoriteResourcesError = "at most %d resources can be added to favorites"
+
+	// MaxFavoriteResources is the maximum number of favorite resources of a single user. Data of every user is
+	// stored in own config map, so it has to stay within the config map size limit.
+	MaxFavoriteResources = 50
+
+	// MaxRecentResources is the number of recently viewed resources kept for every user.
+	MaxRecentResources = 20
+
+	// InvalidSystemBannerScheduleError occurs during settings save if system banner ends before it starts.
+	InvalidSystemBannerScheduleError = "system banner end time has to be after its start time"
 )
 
 // SettingsManager is used for user settings management.
@@ -61,6 +95,25 @@ type SettingsManager interface {
 	SavePinnedResource(client kubernetes.Interface, r *PinnedResource) error
 	// DeletePinnedResource removes a pinned resource from config map.
 	DeletePinnedResource(client kubernetes.Interface, r *PinnedResource) error
+	// GetUserSettings gets overrides of global settings of the given user from user settings config map.
+	GetUserSettings(client kubernetes.Interface, username string) (UserSettings, error)
+	// SaveUserSettings saves overrides of global settings of the given user in user settings config map.
+	SaveUserSettings(client kubernetes.Interface, username string, s *UserSettings) error
+	// DeleteUserSettings removes all overrides of global settings of the given user.
+	DeleteUserSettings(client kubernetes.Interface, username string) error
+	// GetFavoriteResources gets resources added to favorites by the given user.
+	GetFavoriteResources(client kubernetes.Interface, username string) ([]PinnedResource, error)
+	// SaveFavoriteResource adds a resource to favorites of the given user.
+	SaveFavoriteResource(client kubernetes.Interface, username string, r *PinnedResource) error
+	// DeleteFavoriteResource removes a resource from favorites of the given user.
+	DeleteFavoriteResource(client kubernetes.Interface, username string, r *PinnedResource) error
+	// GetRecentResources gets resources recently viewed by the given user, ordered from the latest one.
+	GetRecentResources(client kubernetes.Interface, username string) ([]RecentResource, error)
+	// SaveRecentResource records that the given user has viewed a resource.
+	SaveRecentResource(client kubernetes.Interface, username string, r *PinnedResource) error
+	// Watch keeps settings in sync with config map in the background until stop channel is closed, so they
+	// can be served from memory. Until the first event is received, settings are read on every request.
+	Watch(client kubernetes.Interface, stopCh <-chan struct{})
 }
 
 // PinnedResource represents a pinned resource.
@@ -74,6 +127,20 @@ func (p *PinnedResource) IsEqual(other *PinnedResource) bool {
 	return p.Name == other.Name && p.Namespace == other.Namespace && p.Kind == other.Kind
 }
 
+// Validate returns bad request error if the resource does not have kind or name.
+func (p *PinnedResource) Validate() error {
+	if len(p.Kind) == 0 || len(p.Name) == 0 {
+		return errors.NewBadRequest("kind and name of the resource are required")
+	}
+	return nil
+}
+
+// RecentResource represents a resource recently viewed by the user.
+type RecentResource struct {
+	PinnedResource `json:",inline"`
+	ViewedAt       time.Time `json:"viewedAt"`
+}
+
 // MarshalPinnedResources pinned resource into JSON object.
 func MarshalPinnedResources(p []PinnedResource) string {
 	bytes, _ := json.Marshal(p)
@@ -93,6 +160,120 @@ type Settings struct {
 	ItemsPerPage                    int    `json:"itemsPerPage"`
 	LogsAutoRefreshTimeInterval     int    `json:"logsAutoRefreshTimeInterval"`
 	ResourceAutoRefreshTimeInterval int    `json:"resourceAutoRefreshTimeInterval"`
+	DefaultNamespace                string `json:"defaultNamespace"`
+
+	// Namespaces that are visible in Dashboard. All namespaces are visible when empty.
+	AllowedNamespaces []string `json:"allowedNamespaces"`
+
+	// Namespaces that are hidden and blocked in Dashboard, even if they are allowed.
+	DeniedNamespaces []string `json:"deniedNamespaces"`
+
+	// Namespaces that are flagged as pinned in the namespace list, so that UI can show them first.
+	PinnedNamespaces []string `json:"pinnedNamespaces"`
+
+	// Resource kinds, i.e. secret, that are removed from navigation and blocked in API.
+	HiddenResourceKinds []string `json:"hiddenResourceKinds"`
+
+	// Banner set by admins. It takes precedence over the banner configured with arguments while it is active.
+	SystemBanner *SystemBannerSettings `json:"systemBanner,omitempty"`
+}
+
+// SystemBannerSettings is a system banner message scheduled by admins. Banner without start or end time is
+// active since or until forever.
+type SystemBannerSettings struct {
+	Message  string     `json:"message"`
+	Severity string     `json:"severity"`
+	Start    *time.Time `json:"start,omitempty"`
+	End      *time.Time `json:"end,omitempty"`
+}
+
+// IsActive returns true when banner has a message and given time is within its schedule.
+func (b *SystemBannerSettings) IsActive(now time.Time) bool {
+	if b == nil || len(b.Message) == 0 {
+		return false
+	}
+
+	return (b.Start == nil || !now.Before(*b.Start)) && (b.End == nil || now.Before(*b.End))
+}
+
+// IsResourceKindHidden returns true when given resource kind is hidden.
+func (s Settings) IsResourceKindHidden(kind string) bool {
+	for _, hidden := range s.HiddenResourceKinds {
+		if strings.EqualFold(hidden, kind) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// HasNamespaceRestrictions returns true when any of the namespaces is hidden.
+func (s Settings) HasNamespaceRestrictions() bool {
+	return len(s.AllowedNamespaces) > 0 || len(s.DeniedNamespaces) > 0
+}
+
+// IsNamespaceAllowed returns true when the namespace is not hidden by allowed and denied namespaces.
+func (s Settings) IsNamespaceAllowed(namespace string) bool {
+	for _, denied := range s.DeniedNamespaces {
+		if denied == namespace {
+			return false
+		}
+	}
+
+	if len(s.AllowedNamespaces) == 0 {
+		return true
+	}
+
+	for _, allowed := range s.AllowedNamespaces {
+		if allowed == namespace {
+			return true
+		}
+	}
+
+	return false
+}
+
+// UserSettings contains overrides of global settings of a single user. Only set fields override global ones.
+type UserSettings struct {
+	ItemsPerPage                    *int      `json:"itemsPerPage,omitempty"`
+	LogsAutoRefreshTimeInterval     *int      `json:"logsAutoRefreshTimeInterval,omitempty"`
+	ResourceAutoRefreshTimeInterval *int      `json:"resourceAutoRefreshTimeInterval,omitempty"`
+	DefaultNamespace                *string   `json:"defaultNamespace,omitempty"`
+	PinnedNamespaces                *[]string `json:"pinnedNamespaces,omitempty"`
+	Language                        *string   `json:"language,omitempty"`
+}
+
+// EffectiveSettings are global settings with applied user overrides.
+type EffectiveSettings struct {
+	Settings
+
+	// Language selected by the user. Empty means that the browser language should be used.
+	Language string `json:"language"`
+}
+
+// WithUserSettings returns copy of the settings with applied user overrides.
+func (s Settings) WithUserSettings(u UserSettings) EffectiveSettings {
+	result := EffectiveSettings{Settings: s}
+	if u.ItemsPerPage != nil {
+		result.ItemsPerPage = *u.ItemsPerPage
+	}
+	if u.LogsAutoRefreshTimeInterval != nil {
+		result.LogsAutoRefreshTimeInterval = *u.LogsAutoRefreshTimeInterval
+	}
+	if u.ResourceAutoRefreshTimeInterval != nil {
+		result.ResourceAutoRefreshTimeInterval = *u.ResourceAutoRefreshTimeInterval
+	}
+	if u.DefaultNamespace != nil {
+		result.DefaultNamespace = *u.DefaultNamespace
+	}
+	if u.PinnedNamespaces != nil {
+		result.PinnedNamespaces = *u.PinnedNamespaces
+	}
+	if u.Language != nil {
+		result.Language = *u.Language
+	}
+
+	return result
 }
 
 // Marshal settings into JSON object.
@@ -114,6 +295,7 @@ var defaultSettings = Settings{
 	ItemsPerPage:                    10,
 	LogsAutoRefreshTimeInterval:     5,
 	ResourceAutoRefreshTimeInterval: 5,
+	DefaultNamespace:                "default",
 }
 
 // GetDefaultSettings returns settings structure, that should be used if there are no
@@ -0,0 +1,40 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import "testing"
+
+func TestIsNamespaceAllowed(t *testing.T) {
+	cases := []struct {
+		settings  Settings
+		namespace string
+		expected  bool
+	}{
+		{Settings{}, "kube-system", true},
+		{Settings{DeniedNamespaces: []string{"kube-system"}}, "kube-system", false},
+		{Settings{DeniedNamespaces: []string{"kube-system"}}, "default", true},
+		{Settings{AllowedNamespaces: []string{"team-a", "team-b"}}, "team-b", true},
+		{Settings{AllowedNamespaces: []string{"team-a", "team-b"}}, "default", false},
+		{Settings{AllowedNamespaces: []string{"team-a"}, DeniedNamespaces: []string{"team-a"}}, "team-a", false},
+	}
+
+	for _, c := range cases {
+		actual := c.settings.IsNamespaceAllowed(c.namespace)
+		if actual != c.expected {
+			t.Errorf("IsNamespaceAllowed(%s) == %t, expected %t for settings %#v", c.namespace, actual, c.expected,
+				c.settings)
+		}
+	}
+}
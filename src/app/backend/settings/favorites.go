@@ -0,0 +1,118 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package settings
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/settings/api"
+)
+
+// recentViewUpdatePeriod is the time during which repeated views of the latest viewed resource are not saved, so
+// that reloading a page does not update the config map every time.
+const recentViewUpdatePeriod = time.Minute
+
+// GetFavoriteResources implements SettingsManager interface. Check it for more information.
+func (sm *SettingsManager) GetFavoriteResources(client kubernetes.Interface, username string) (
+	[]api.PinnedResource, error) {
+	entry, err := sm.getUserEntry(client, username)
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]api.PinnedResource{}, entry.Favorites...), nil
+}
+
+// SaveFavoriteResource implements SettingsManager interface. Check it for more information.
+func (sm *SettingsManager) SaveFavoriteResource(client kubernetes.Interface, username string,
+	r *api.PinnedResource) error {
+	if err := r.Validate(); err != nil {
+		return err
+	}
+
+	return sm.updateUserEntry(client, username, func(entry *userSettingsEntry) error {
+		for _, favorite := range entry.Favorites {
+			if favorite.IsEqual(r) {
+				return errors.NewGenericResponse(http.StatusConflict, api.ResourceAlreadyFavoriteError)
+			}
+		}
+		if len(entry.Favorites) >= api.MaxFavoriteResources {
+			return errors.NewBadRequest(fmt.Sprintf(api.TooManyFavoriteResourcesError, api.MaxFavoriteResources))
+		}
+
+		entry.Favorites = append(entry.Favorites, *r)
+		return nil
+	})
+}
+
+// DeleteFavoriteResource implements SettingsManager interface. Check it for more information.
+func (sm *SettingsManager) DeleteFavoriteResource(client kubernetes.Interface, username string,
+	r *api.PinnedResource) error {
+	return sm.updateUserEntry(client, username, func(entry *userSettingsEntry) error {
+		for i, favorite := range entry.Favorites {
+			if favorite.IsEqual(r) {
+				entry.Favorites = append(entry.Favorites[:i], entry.Favorites[i+1:]...)
+				return nil
+			}
+		}
+		return errors.NewNotFound(api.FavoriteResourceNotFoundError)
+	})
+}
+
+// GetRecentResources implements SettingsManager interface. Check it for more information.
+func (sm *SettingsManager) GetRecentResources(client kubernetes.Interface, username string) (
+	[]api.RecentResource, error) {
+	entry, err := sm.getUserEntry(client, username)
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]api.RecentResource{}, entry.Recent...), nil
+}
+
+// SaveRecentResource implements SettingsManager interface. Check it for more information. The resource is moved
+// to the top if it was viewed before and the oldest resources are dropped above the limit.
+func (sm *SettingsManager) SaveRecentResource(client kubernetes.Interface, username string,
+	r *api.PinnedResource) error {
+	if err := r.Validate(); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	entry, err := sm.getUserEntry(client, username)
+	if err != nil {
+		return err
+	}
+	if len(entry.Recent) > 0 && entry.Recent[0].IsEqual(r) && now.Sub(entry.Recent[0].ViewedAt) < recentViewUpdatePeriod {
+		return nil
+	}
+
+	return sm.updateUserEntry(client, username, func(entry *userSettingsEntry) error {
+		recent := []api.RecentResource{{PinnedResource: *r, ViewedAt: now}}
+		for _, resource := range entry.Recent {
+			if !resource.IsEqual(r) && len(recent) < api.MaxRecentResources {
+				recent = append(recent, resource)
+			}
+		}
+
+		entry.Recent = recent
+		return nil
+	})
+}
@@ -0,0 +1,130 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package settings
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/kubernetes/dashboard/src/app/backend/settings/api"
+)
+
+func TestSettingsManager_FavoriteResources(t *testing.T) {
+	sm := NewSettingsManager()
+	client := fake.NewSimpleClientset()
+	itemsPerPage := 50
+	web := &api.PinnedResource{Kind: "deployment", Namespace: "default", Name: "web"}
+	node := &api.PinnedResource{Kind: "node", Name: "node-1"}
+
+	for _, r := range []*api.PinnedResource{web, node} {
+		if err := sm.SaveFavoriteResource(client, "jane", r); err != nil {
+			t.Fatalf("it should save favorite resource, but failed with %s", err.Error())
+		}
+	}
+	if err := sm.SaveFavoriteResource(client, "jane", web); !k8sErrors.IsConflict(err) {
+		t.Errorf("it should not save favorite resource twice, got %v", err)
+	}
+	if err := sm.SaveFavoriteResource(client, "jane", &api.PinnedResource{Kind: "pod"}); !k8sErrors.IsBadRequest(err) {
+		t.Errorf("it should not save favorite resource without name, got %v", err)
+	}
+
+	// Favorites are kept when user settings are saved or deleted.
+	if err := sm.SaveUserSettings(client, "jane", &api.UserSettings{ItemsPerPage: &itemsPerPage}); err != nil {
+		t.Fatalf("it should save user settings, but failed with %s", err.Error())
+	}
+	if err := sm.DeleteUserSettings(client, "jane"); err != nil {
+		t.Fatalf("it should delete user settings, but failed with %s", err.Error())
+	}
+
+	favorites, err := sm.GetFavoriteResources(client, "jane")
+	if err != nil || !reflect.DeepEqual(favorites, []api.PinnedResource{*web, *node}) {
+		t.Errorf("it should return both favorite resources, got %v, %v", favorites, err)
+	}
+
+	if err := sm.DeleteFavoriteResource(client, "jane", web); err != nil {
+		t.Fatalf("it should delete favorite resource, but failed with %s", err.Error())
+	}
+	if err := sm.DeleteFavoriteResource(client, "jane", web); !k8sErrors.IsNotFound(err) {
+		t.Errorf("it should not delete missing favorite resource, got %v", err)
+	}
+
+	favorites, _ = sm.GetFavoriteResources(client, "jane")
+	other, _ := sm.GetFavoriteResources(client, "john")
+	if !reflect.DeepEqual(favorites, []api.PinnedResource{*node}) || len(other) != 0 {
+		t.Errorf("it should keep favorites per user, got %v and %v", favorites, other)
+	}
+
+	for i := 1; i < api.MaxFavoriteResources; i++ {
+		if err := sm.SaveFavoriteResource(client, "jane", &api.PinnedResource{Kind: "pod",
+			Name: fmt.Sprintf("pod-%d", i)}); err != nil {
+			t.Fatalf("it should save favorite resource, but failed with %s", err.Error())
+		}
+	}
+	if err := sm.SaveFavoriteResource(client, "jane", web); !k8sErrors.IsBadRequest(err) {
+		t.Errorf("it should not save favorite resources above the limit, got %v", err)
+	}
+}
+
+func TestSettingsManager_RecentResources(t *testing.T) {
+	sm := NewSettingsManager()
+	client := fake.NewSimpleClientset()
+
+	view := func(name string) {
+		if err := sm.SaveRecentResource(client, "jane", &api.PinnedResource{Kind: "pod", Namespace: "default",
+			Name: name}); err != nil {
+			t.Fatalf("it should save recent resource, but failed with %s", err.Error())
+		}
+	}
+	names := func() []string {
+		recent, err := sm.GetRecentResources(client, "jane")
+		if err != nil {
+			t.Fatalf("it should return recent resources, but failed with %s", err.Error())
+		}
+		result := make([]string, 0)
+		for _, r := range recent {
+			result = append(result, r.Name)
+		}
+		return result
+	}
+
+	view("a")
+	view("b")
+	view("a")
+	if actual := names(); !reflect.DeepEqual(actual, []string{"a", "b"}) {
+		t.Errorf("it should move viewed resource to the top, got %v", actual)
+	}
+
+	recent, _ := sm.GetRecentResources(client, "jane")
+	viewedAt := recent[0].ViewedAt
+	view("a")
+	if recent, _ = sm.GetRecentResources(client, "jane"); !recent[0].ViewedAt.Equal(viewedAt) {
+		t.Errorf("it should not save repeated view within %s", recentViewUpdatePeriod)
+	}
+	if time.Since(viewedAt) > time.Minute {
+		t.Errorf("it should record time of the view, got %s", viewedAt)
+	}
+
+	for i := 0; i < api.MaxRecentResources; i++ {
+		view(fmt.Sprintf("pod-%d", i))
+	}
+	if actual := names(); len(actual) != api.MaxRecentResources || actual[0] != fmt.Sprintf("pod-%d", api.MaxRecentResources-1) {
+		t.Errorf("it should keep only %d most recent resources, got %v", api.MaxRecentResources, actual)
+	}
+}
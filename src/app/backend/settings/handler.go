@@ -46,6 +46,46 @@ func (self *SettingsHandler) Install(ws *restful.WebService) {
 			Reads(api.Settings{}).
 			Writes(api.Settings{}))
 
+	ws.Route(
+		ws.GET("/settings/user").
+			To(self.handleSettingsUserGet).
+			Writes(api.UserSettings{}))
+	ws.Route(
+		ws.PUT("/settings/user").
+			To(self.handleSettingsUserSave).
+			Reads(api.UserSettings{}).
+			Writes(api.UserSettings{}))
+	ws.Route(
+		ws.DELETE("/settings/user").
+			To(self.handleSettingsUserDelete))
+	ws.Route(
+		ws.GET("/settings/user/favorites").
+			To(self.handleSettingsUserGetFavorites).
+			Writes([]api.PinnedResource{}))
+	ws.Route(
+		ws.PUT("/settings/user/favorites").
+			To(self.handleSettingsUserSaveFavorite).
+			Reads(api.PinnedResource{}).
+			Writes(api.PinnedResource{}))
+	ws.Route(
+		ws.DELETE("/settings/user/favorites/{kind}/{name}").
+			To(self.handleSettingsUserDeleteFavorite))
+	ws.Route(
+		ws.DELETE("/settings/user/favorites/{kind}/{namespace}/{name}").
+			To(self.handleSettingsUserDeleteFavorite))
+	ws.Route(
+		ws.GET("/settings/user/recent").
+			To(self.handleSettingsUserGetRecent).
+			Writes([]api.RecentResource{}))
+	ws.Route(
+		ws.POST("/settings/user/recent").
+			To(self.handleSettingsUserSaveRecent).
+			Reads(api.PinnedResource{}))
+	ws.Route(
+		ws.GET("/settings/effective").
+			To(self.handleSettingsEffectiveGet).
+			Writes(api.EffectiveSettings{}))
+
 	ws.Route(
 		ws.GET("/settings/pinner").
 			To(self.handleSettingsGetPinned))
@@ -86,7 +126,7 @@ func (self *SettingsHandler) handleSettingsGlobalCanI(request *restful.Request,
 func (self *SettingsHandler) handleSettingsGlobalGet(request *restful.Request, response *restful.Response) {
 	client, err := self.clientManager.Client(request)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
@@ -97,27 +137,204 @@ func (self *SettingsHandler) handleSettingsGlobalGet(request *restful.Request, r
 func (self *SettingsHandler) handleSettingsGlobalSave(request *restful.Request, response *restful.Response) {
 	settings := new(api.Settings)
 	if err := request.ReadEntity(settings); err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
 	client, err := self.clientManager.Client(request)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
 	if err := self.manager.SaveGlobalSettings(client, settings); err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 	response.WriteHeaderAndEntity(http.StatusCreated, settings)
 }
 
+// User settings are read and written by Dashboard on behalf of the authenticated user, so users do not need
+// access to the config map in Dashboard namespace.
+func (self *SettingsHandler) handleSettingsUserGet(request *restful.Request, response *restful.Response) {
+	username, err := self.clientManager.Username(request)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	result, err := self.manager.GetUserSettings(self.clientManager.InsecureClient(), username)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (self *SettingsHandler) handleSettingsUserSave(request *restful.Request, response *restful.Response) {
+	settings := new(api.UserSettings)
+	if err := request.ReadEntity(settings); err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	username, err := self.clientManager.Username(request)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	if err := self.manager.SaveUserSettings(self.clientManager.InsecureClient(), username, settings); err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusCreated, settings)
+}
+
+func (self *SettingsHandler) handleSettingsUserDelete(request *restful.Request, response *restful.Response) {
+	username, err := self.clientManager.Username(request)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	if err := self.manager.DeleteUserSettings(self.clientManager.InsecureClient(), username); err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+	response.WriteHeader(http.StatusNoContent)
+}
+
+func (self *SettingsHandler) handleSettingsUserGetFavorites(request *restful.Request,
+	response *restful.Response) {
+	username, err := self.clientManager.Username(request)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	result, err := self.manager.GetFavoriteResources(self.clientManager.InsecureClient(), username)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (self *SettingsHandler) handleSettingsUserSaveFavorite(request *restful.Request,
+	response *restful.Response) {
+	favorite := new(api.PinnedResource)
+	if err := request.ReadEntity(favorite); err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	username, err := self.clientManager.Username(request)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	if err := self.manager.SaveFavoriteResource(self.clientManager.InsecureClient(), username, favorite); err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusCreated, favorite)
+}
+
+func (self *SettingsHandler) handleSettingsUserDeleteFavorite(request *restful.Request,
+	response *restful.Response) {
+	favorite := &api.PinnedResource{
+		Kind:      request.PathParameter("kind"),
+		Name:      request.PathParameter("name"),
+		Namespace: request.PathParameter("namespace"),
+	}
+
+	username, err := self.clientManager.Username(request)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	if err := self.manager.DeleteFavoriteResource(self.clientManager.InsecureClient(), username, favorite); err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+	response.WriteHeader(http.StatusNoContent)
+}
+
+func (self *SettingsHandler) handleSettingsUserGetRecent(request *restful.Request, response *restful.Response) {
+	username, err := self.clientManager.Username(request)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	result, err := self.manager.GetRecentResources(self.clientManager.InsecureClient(), username)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (self *SettingsHandler) handleSettingsUserSaveRecent(request *restful.Request, response *restful.Response) {
+	viewed := new(api.PinnedResource)
+	if err := request.ReadEntity(viewed); err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	username, err := self.clientManager.Username(request)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	if err := self.manager.SaveRecentResource(self.clientManager.InsecureClient(), username, viewed); err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+	response.WriteHeader(http.StatusNoContent)
+}
+
+// handleSettingsEffectiveGet returns global settings with overrides of the authenticated user. Global settings
+// are returned as they are, when the user can not be identified.
+func (self *SettingsHandler) handleSettingsEffectiveGet(request *restful.Request, response *restful.Response) {
+	result, err := GetEffectiveSettings(self.manager, self.clientManager, request)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+// GetEffectiveSettings returns global settings with applied overrides of the user that sent the request. Global
+// settings are returned when the user cannot be identified.
+func GetEffectiveSettings(manager api.SettingsManager, clientManager clientapi.ClientManager,
+	request *restful.Request) (*api.EffectiveSettings, error) {
+	client, err := clientManager.Client(request)
+	if err != nil {
+		return nil, err
+	}
+
+	userSettings := api.UserSettings{}
+	if username, err := clientManager.Username(request); err == nil {
+		userSettings, err = manager.GetUserSettings(clientManager.InsecureClient(), username)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	result := manager.GetGlobalSettings(client).WithUserSettings(userSettings)
+	result.Settings = boundRefreshInterval(result.Settings)
+	return &result, nil
+}
+
 func (self *SettingsHandler) handleSettingsGetPinned(request *restful.Request, response *restful.Response) {
 	client, err := self.clientManager.Client(request)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
@@ -128,18 +345,18 @@ func (self *SettingsHandler) handleSettingsGetPinned(request *restful.Request, r
 func (self *SettingsHandler) handleSettingsSavePinned(request *restful.Request, response *restful.Response) {
 	pinnedResource := new(api.PinnedResource)
 	if err := request.ReadEntity(pinnedResource); err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
 	client, err := self.clientManager.Client(request)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
 	if err := self.manager.SavePinnedResource(client, pinnedResource); err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 	response.WriteHeaderAndEntity(http.StatusCreated, pinnedResource)
@@ -154,12 +371,12 @@ func (self *SettingsHandler) handleSettingsDeletePinned(request *restful.Request
 
 	client, err := self.clientManager.Client(request)
 	if err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 
 	if err := self.manager.DeletePinnedResource(client, pinnedResource); err != nil {
-		errors.HandleInternalError(response, err)
+		errors.HandleInternalError(request, response, err)
 		return
 	}
 	response.WriteHeader(http.StatusNoContent)
@@ -15,12 +15,18 @@
 package settings
 
 import (
+	"fmt"
 	"log"
 	"net/http"
 	"reflect"
+	"sync"
+	"time"
 
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/kubernetes"
 
 	"github.com/kubernetes/dashboard/src/app/backend/args"
@@ -28,11 +34,19 @@ import (
 	"github.com/kubernetes/dashboard/src/app/backend/settings/api"
 )
 
+// watchRetryPeriod is a time after which closed or failed settings config map watch is started again.
+const watchRetryPeriod = 5 * time.Second
+
 // SettingsManager is a structure containing all settings manager members.
 type SettingsManager struct {
 	settings        map[string]api.Settings
 	pinnedResources []api.PinnedResource
 	rawSettings     map[string]string
+
+	// synced is true when settings are kept up to date by the config map watch, so they can be served
+	// without reading the config map on every request.
+	synced bool
+	mux    sync.RWMutex
 }
 
 // NewSettingsManager creates new settings manager.
@@ -53,11 +67,20 @@ func (sm *SettingsManager) load(client kubernetes.Interface) (configMap *v1.Conf
 		return
 	}
 
+	isDifferent = sm.update(configMap.Data)
+	return
+}
+
+// update replaces cached settings with the given config map data and returns true if they are different.
+func (sm *SettingsManager) update(data map[string]string) (isDifferent bool) {
+	sm.mux.Lock()
+	defer sm.mux.Unlock()
+
 	// Check if anything has changed from the last time when function was executed.
-	isDifferent = !reflect.DeepEqual(sm.rawSettings, configMap.Data)
+	isDifferent = !reflect.DeepEqual(sm.rawSettings, data)
 
 	if isDifferent {
-		sm.rawSettings = configMap.Data
+		sm.rawSettings = data
 		sm.settings = make(map[string]api.Settings)
 
 		for key, value := range sm.rawSettings {
@@ -82,6 +105,61 @@ func (sm *SettingsManager) load(client kubernetes.Interface) (configMap *v1.Conf
 	return
 }
 
+// Watch implements SettingsManager interface. Check it for more information.
+func (sm *SettingsManager) Watch(client kubernetes.Interface, stopCh <-chan struct{}) {
+	go wait.Until(func() { sm.watch(client, stopCh) }, watchRetryPeriod, stopCh)
+}
+
+// watch updates cached settings on every change of the settings config map until the watch is closed.
+func (sm *SettingsManager) watch(client kubernetes.Interface, stopCh <-chan struct{}) {
+	watcher, err := client.CoreV1().ConfigMaps(args.Holder.GetNamespace()).Watch(metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("metadata.name", api.SettingsConfigMapName).String(),
+	})
+	if err != nil {
+		log.Printf("Cannot watch settings config map: %s", err.Error())
+		return
+	}
+	defer watcher.Stop()
+	defer sm.setSynced(false)
+
+	for {
+		select {
+		case <-stopCh:
+			return
+		case event, ok := <-watcher.ResultChan():
+			if !ok {
+				return
+			}
+
+			switch event.Type {
+			case watch.Added, watch.Modified:
+				if configMap, ok := event.Object.(*v1.ConfigMap); ok {
+					sm.update(configMap.Data)
+					sm.setSynced(true)
+				}
+			case watch.Deleted:
+				// Settings are served from the config map again, so it is restored on the next request.
+				sm.setSynced(false)
+			case watch.Error:
+				log.Printf("Settings config map watch failed: %v", event.Object)
+				return
+			}
+		}
+	}
+}
+
+func (sm *SettingsManager) setSynced(synced bool) {
+	sm.mux.Lock()
+	defer sm.mux.Unlock()
+	sm.synced = synced
+}
+
+func (sm *SettingsManager) isSynced() bool {
+	sm.mux.RLock()
+	defer sm.mux.RUnlock()
+	return sm.synced
+}
+
 // restoreConfigMap restores settings config map using default global settings.
 func (sm *SettingsManager) restoreConfigMap(client kubernetes.Interface) {
 	restoredConfigMap, err := client.CoreV1().ConfigMaps(args.Holder.GetNamespace()).
@@ -89,29 +167,39 @@ func (sm *SettingsManager) restoreConfigMap(client kubernetes.Interface) {
 	if err != nil {
 		log.Printf("Cannot restore settings config map: %s", err.Error())
 	} else {
-		sm.settings = make(map[string]api.Settings)
-		sm.settings[api.GlobalSettingsKey] = api.GetDefaultSettings()
-		sm.rawSettings = restoredConfigMap.Data
+		sm.update(restoredConfigMap.Data)
 	}
 }
 
 // GetGlobalSettings implements SettingsManager interface. Check it for more information.
 func (sm *SettingsManager) GetGlobalSettings(client kubernetes.Interface) api.Settings {
-	cm, _ := sm.load(client)
-	if cm == nil {
-		return api.GetDefaultSettings()
+	if !sm.isSynced() {
+		cm, _ := sm.load(client)
+		if cm == nil {
+			return boundRefreshInterval(api.GetDefaultSettings())
+		}
 	}
 
+	sm.mux.RLock()
+	defer sm.mux.RUnlock()
 	s, ok := sm.settings[api.GlobalSettingsKey]
 	if !ok {
-		return api.GetDefaultSettings()
+		return boundRefreshInterval(api.GetDefaultSettings())
 	}
 
-	return s
+	return boundRefreshInterval(s)
 }
 
 // GetGlobalSettings implements SettingsManager interface. Check it for more information.
 func (sm *SettingsManager) SaveGlobalSettings(client kubernetes.Interface, s *api.Settings) error {
+	if err := validateRefreshInterval(s.ResourceAutoRefreshTimeInterval); err != nil {
+		return err
+	}
+
+	if b := s.SystemBanner; b != nil && b.Start != nil && b.End != nil && !b.End.After(*b.Start) {
+		return errors.NewBadRequest(api.InvalidSystemBannerScheduleError)
+	}
+
 	cm, isDiff := sm.load(client)
 	if isDiff {
 		return errors.NewInvalid(api.ConcurrentSettingsChangeError)
@@ -129,11 +217,15 @@ func (sm *SettingsManager) SaveGlobalSettings(client kubernetes.Interface, s *ap
 }
 
 func (sm *SettingsManager) GetPinnedResources(client kubernetes.Interface) (r []api.PinnedResource) {
-	cm, _ := sm.load(client)
-	if cm == nil {
-		return
+	if !sm.isSynced() {
+		cm, _ := sm.load(client)
+		if cm == nil {
+			return
+		}
 	}
 
+	sm.mux.RLock()
+	defer sm.mux.RUnlock()
 	return sm.pinnedResources
 }
 
@@ -148,8 +240,9 @@ func (sm *SettingsManager) SavePinnedResource(client kubernetes.Interface, r *ap
 		cm.Data = make(map[string]string)
 	}
 
+	pinnedResources := sm.copyPinnedResources()
 	exists := false
-	for _, pinnedResource := range sm.pinnedResources {
+	for _, pinnedResource := range pinnedResources {
 		if pinnedResource.IsEqual(r) {
 			exists = true
 		}
@@ -160,8 +253,8 @@ func (sm *SettingsManager) SavePinnedResource(client kubernetes.Interface, r *ap
 	}
 
 	defer sm.load(client)
-	sm.pinnedResources = append(sm.pinnedResources, *r)
-	cm.Data[api.PinnedResourcesKey] = api.MarshalPinnedResources(sm.pinnedResources)
+	pinnedResources = append(pinnedResources, *r)
+	cm.Data[api.PinnedResourcesKey] = api.MarshalPinnedResources(pinnedResources)
 	_, err := client.CoreV1().ConfigMaps(args.Holder.GetNamespace()).Update(cm)
 	return err
 }
@@ -177,20 +270,50 @@ func (sm *SettingsManager) DeletePinnedResource(client kubernetes.Interface, r *
 		return errors.NewNotFound(api.PinnedResourceNotFoundError)
 	}
 
-	index := len(sm.pinnedResources)
-	for i, pinnedResource := range sm.pinnedResources {
+	pinnedResources := sm.copyPinnedResources()
+	index := len(pinnedResources)
+	for i, pinnedResource := range pinnedResources {
 		if pinnedResource.IsEqual(r) {
 			index = i
 		}
 	}
 
-	if index == len(sm.pinnedResources) {
+	if index == len(pinnedResources) {
 		return errors.NewNotFound(api.PinnedResourceNotFoundError)
 	}
 
 	defer sm.load(client)
-	sm.pinnedResources = append(sm.pinnedResources[:index], sm.pinnedResources[index+1:]...)
-	cm.Data[api.PinnedResourcesKey] = api.MarshalPinnedResources(sm.pinnedResources)
+	pinnedResources = append(pinnedResources[:index], pinnedResources[index+1:]...)
+	cm.Data[api.PinnedResourcesKey] = api.MarshalPinnedResources(pinnedResources)
 	_, err := client.CoreV1().ConfigMaps(args.Holder.GetNamespace()).Update(cm)
 	return err
 }
+
+// copyPinnedResources returns a copy of cached pinned resources, that can be safely modified.
+func (sm *SettingsManager) copyPinnedResources() []api.PinnedResource {
+	sm.mux.RLock()
+	defer sm.mux.RUnlock()
+	return append([]api.PinnedResource{}, sm.pinnedResources...)
+}
+
+// boundRefreshInterval raises resource auto-refresh interval to the minimum allowed one, so misconfigured
+// settings can not make every open Dashboard hammer the apiserver. Zero disables auto-refresh and is kept.
+func boundRefreshInterval(s api.Settings) api.Settings {
+	min := args.Holder.GetMinResourceAutoRefreshInterval()
+	if s.ResourceAutoRefreshTimeInterval > 0 && s.ResourceAutoRefreshTimeInterval < min {
+		s.ResourceAutoRefreshTimeInterval = min
+	}
+
+	return s
+}
+
+// validateRefreshInterval checks that resource auto-refresh interval is either disabled or not lower than the
+// minimum allowed one.
+func validateRefreshInterval(interval int) error {
+	min := args.Holder.GetMinResourceAutoRefreshInterval()
+	if interval < 0 || (interval > 0 && interval < min) {
+		return errors.NewBadRequest(fmt.Sprintf(api.InvalidRefreshIntervalError, min))
+	}
+
+	return nil
+}
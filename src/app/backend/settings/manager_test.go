@@ -17,7 +17,11 @@ package settings
 import (
 	"reflect"
 	"testing"
+	"time"
 
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"github.com/kubernetes/dashboard/src/app/backend/args"
 	"github.com/kubernetes/dashboard/src/app/backend/settings/api"
 	"k8s.io/client-go/kubernetes/fake"
 )
@@ -63,3 +67,98 @@ func TestSettingsManager_SaveGlobalSettings(t *testing.T) {
 			err.Error())
 	}
 }
+
+func TestSettingsManager_Watch(t *testing.T) {
+	sm := NewSettingsManager().(*SettingsManager)
+	client := fake.NewSimpleClientset()
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	sm.Watch(client, stopCh)
+
+	configMap := api.GetDefaultSettingsConfigMap("")
+	expected := api.GetDefaultSettings()
+	expected.ClusterName = "watched"
+	configMap.Data[api.GlobalSettingsKey] = expected.Marshal()
+
+	if _, err := client.CoreV1().ConfigMaps("").Create(configMap); err != nil {
+		t.Fatal(err)
+	}
+
+	// Watch is started asynchronously, so the config map is updated until the change is received.
+	err := wait.PollImmediate(10*time.Millisecond, 5*time.Second, func() (bool, error) {
+		if _, err := client.CoreV1().ConfigMaps("").Update(configMap); err != nil {
+			return false, err
+		}
+		return sm.isSynced(), nil
+	})
+	if err != nil {
+		t.Fatalf("settings manager did not receive config map from watch: %s", err.Error())
+	}
+
+	// Settings are served from cache, so client without the config map is not used at all.
+	gs := sm.GetGlobalSettings(fake.NewSimpleClientset())
+	if !reflect.DeepEqual(expected, gs) {
+		t.Errorf("it should return watched settings \"%v\" instead of \"%v\"", expected, gs)
+	}
+}
+
+func TestSettingsManager_RefreshIntervalBound(t *testing.T) {
+	args.GetHolderBuilder().SetMinResourceAutoRefreshInterval(5)
+	defer args.GetHolderBuilder().SetMinResourceAutoRefreshInterval(0)
+
+	cases := []struct {
+		interval    int
+		expected    int
+		expectedErr bool
+	}{
+		{0, 0, false},
+		{2, 5, true},
+		{5, 5, false},
+		{30, 30, false},
+		{-1, -1, true},
+	}
+
+	for _, c := range cases {
+		sm := NewSettingsManager()
+		s := api.GetDefaultSettings()
+		s.ResourceAutoRefreshTimeInterval = c.interval
+		configMap := api.GetDefaultSettingsConfigMap("")
+		configMap.Data[api.GlobalSettingsKey] = s.Marshal()
+		client := fake.NewSimpleClientset(configMap)
+
+		if actual := sm.GetGlobalSettings(client).ResourceAutoRefreshTimeInterval; actual != c.expected {
+			t.Errorf("it should serve %d interval instead of %d for %d", c.expected, actual, c.interval)
+		}
+
+		if err := sm.SaveGlobalSettings(client, &s); (err != nil) != c.expectedErr {
+			t.Errorf("it should return error: %t when saving %d interval, got %v", c.expectedErr, c.interval, err)
+		}
+	}
+}
+
+func TestSettingsManager_SystemBannerSchedule(t *testing.T) {
+	now := time.Now()
+	later := now.Add(time.Hour)
+
+	cases := []struct {
+		banner      *api.SystemBannerSettings
+		expectedErr bool
+	}{
+		{nil, false},
+		{&api.SystemBannerSettings{Message: "Maintenance"}, false},
+		{&api.SystemBannerSettings{Message: "Maintenance", Start: &now, End: &later}, false},
+		{&api.SystemBannerSettings{Message: "Maintenance", Start: &later, End: &now}, true},
+		{&api.SystemBannerSettings{Message: "Maintenance", Start: &now, End: &now}, true},
+	}
+
+	for _, c := range cases {
+		sm := NewSettingsManager()
+		client := fake.NewSimpleClientset(api.GetDefaultSettingsConfigMap(""))
+		s := sm.GetGlobalSettings(client)
+		s.SystemBanner = c.banner
+
+		if err := sm.SaveGlobalSettings(client, &s); (err != nil) != c.expectedErr {
+			t.Errorf("it should return error: %t when saving banner %#v, got %v", c.expectedErr, c.banner, err)
+		}
+	}
+}
@@ -0,0 +1,176 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package settings
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"reflect"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
+
+	"github.com/kubernetes/dashboard/src/app/backend/args"
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/settings/api"
+)
+
+// userSettingsEntry is a value stored in user settings config map. Username is kept next to the settings,
+// because config map names are derived from usernames and can not be reversed.
+type userSettingsEntry struct {
+	Username  string               `json:"username"`
+	Settings  api.UserSettings     `json:"settings"`
+	Favorites []api.PinnedResource `json:"favorites,omitempty"`
+	Recent    []api.RecentResource `json:"recent,omitempty"`
+}
+
+// isEmpty returns true when the entry does not hold any data and can be removed.
+func (e *userSettingsEntry) isEmpty() bool {
+	return reflect.DeepEqual(e.Settings, api.UserSettings{}) && len(e.Favorites) == 0 && len(e.Recent) == 0
+}
+
+// userSettingsConfigMapName returns name of config map that stores data of the user. Usernames can contain
+// characters that are not allowed in object names, i.e. "system:serviceaccount:default:admin", so they are hashed.
+func userSettingsConfigMapName(username string) string {
+	hash := sha256.Sum256([]byte(username))
+	return api.UserSettingsConfigMapName + "-" + hex.EncodeToString(hash[:])
+}
+
+// GetUserSettings implements SettingsManager interface. Check it for more information.
+func (sm *SettingsManager) GetUserSettings(client kubernetes.Interface, username string) (api.UserSettings, error) {
+	entry, err := sm.getUserEntry(client, username)
+	if err != nil {
+		return api.UserSettings{}, err
+	}
+
+	return entry.Settings, nil
+}
+
+// SaveUserSettings implements SettingsManager interface. Check it for more information.
+func (sm *SettingsManager) SaveUserSettings(client kubernetes.Interface, username string, s *api.UserSettings) error {
+	if s.ItemsPerPage != nil && *s.ItemsPerPage <= 0 {
+		return errors.NewBadRequest("items per page must be greater than 0")
+	}
+	if s.ResourceAutoRefreshTimeInterval != nil {
+		if err := validateRefreshInterval(*s.ResourceAutoRefreshTimeInterval); err != nil {
+			return err
+		}
+	}
+
+	return sm.updateUserEntry(client, username, func(entry *userSettingsEntry) error {
+		entry.Settings = *s
+		return nil
+	})
+}
+
+// DeleteUserSettings implements SettingsManager interface. Check it for more information. Favorite and recent
+// resources of the user are kept.
+func (sm *SettingsManager) DeleteUserSettings(client kubernetes.Interface, username string) error {
+	return sm.updateUserEntry(client, username, func(entry *userSettingsEntry) error {
+		entry.Settings = api.UserSettings{}
+		return nil
+	})
+}
+
+// getUserEntry returns stored data of the user. Empty entry is returned when the user has not stored anything.
+func (sm *SettingsManager) getUserEntry(client kubernetes.Interface, username string) (*userSettingsEntry, error) {
+	configMap, err := client.CoreV1().ConfigMaps(args.Holder.GetNamespace()).
+		Get(userSettingsConfigMapName(username), metav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			return &userSettingsEntry{Username: username}, nil
+		}
+		return nil, err
+	}
+
+	return parseUserEntry(configMap.Data, username)
+}
+
+func parseUserEntry(data map[string]string, username string) (*userSettingsEntry, error) {
+	entry := &userSettingsEntry{Username: username}
+	value, ok := data[api.UserSettingsKey]
+	if !ok {
+		return entry, nil
+	}
+
+	err := json.Unmarshal([]byte(value), entry)
+	return entry, err
+}
+
+// updateUserEntry applies the change to stored data of the user. The config map of the user is created when it
+// does not exist yet and removed when the entry is empty after the change. Nothing is saved when the change
+// returns an error. Updates are retried on conflicts, as the user can save data from multiple browser tabs.
+func (sm *SettingsManager) updateUserEntry(client kubernetes.Interface, username string,
+	change func(*userSettingsEntry) error) error {
+	configMaps := client.CoreV1().ConfigMaps(args.Holder.GetNamespace())
+	name := userSettingsConfigMapName(username)
+	return retry.OnError(retry.DefaultRetry, isUserEntryRace, func() error {
+		configMap, err := configMaps.Get(name, metav1.GetOptions{})
+		exists := err == nil
+		if errors.IsNotFoundError(err) {
+			configMap = &v1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: args.Holder.GetNamespace(),
+				},
+			}
+		} else if err != nil {
+			return err
+		}
+
+		entry, err := parseUserEntry(configMap.Data, username)
+		if err != nil {
+			return err
+		}
+		if err := change(entry); err != nil {
+			return err
+		}
+
+		if entry.isEmpty() {
+			if !exists {
+				return nil
+			}
+			err := configMaps.Delete(name, &metav1.DeleteOptions{
+				Preconditions: &metav1.Preconditions{ResourceVersion: &configMap.ResourceVersion},
+			})
+			if errors.IsNotFoundError(err) {
+				return nil
+			}
+			return err
+		}
+
+		entry.Username = username
+		value, err := json.Marshal(entry)
+		if err != nil {
+			return err
+		}
+		configMap.Data = map[string]string{api.UserSettingsKey: string(value)}
+
+		if !exists {
+			_, err = configMaps.Create(configMap)
+			return err
+		}
+		_, err = configMaps.Update(configMap)
+		return err
+	})
+}
+
+// isUserEntryRace returns true when the config map of the user was changed, created or removed concurrently.
+func isUserEntryRace(err error) bool {
+	return errors.IsConflictError(err) || errors.IsAlreadyExists(err)
+}
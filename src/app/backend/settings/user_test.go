@@ -0,0 +1,111 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package settings
+
+import (
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/kubernetes/dashboard/src/app/backend/settings/api"
+)
+
+func TestSettingsManager_UserSettings(t *testing.T) {
+	sm := NewSettingsManager()
+	client := fake.NewSimpleClientset()
+	itemsPerPage := 50
+	language := "ja"
+
+	empty, err := sm.GetUserSettings(client, "jane")
+	if err != nil || !reflect.DeepEqual(empty, api.UserSettings{}) {
+		t.Errorf("it should return empty user settings without config map, got %v and %v", empty, err)
+	}
+
+	for _, username := range []string{"system:serviceaccount:default:jane", "john"} {
+		if err := sm.SaveUserSettings(client, username, &api.UserSettings{ItemsPerPage: &itemsPerPage}); err != nil {
+			t.Fatalf("it should save user settings, but failed with %s", err.Error())
+		}
+	}
+	err = sm.SaveUserSettings(client, "john", &api.UserSettings{ItemsPerPage: &itemsPerPage, Language: &language})
+	if err != nil {
+		t.Fatalf("it should update user settings, but failed with %s", err.Error())
+	}
+
+	john, err := sm.GetUserSettings(client, "john")
+	expected := api.UserSettings{ItemsPerPage: &itemsPerPage, Language: &language}
+	if err != nil || !reflect.DeepEqual(john, expected) {
+		t.Errorf("it should return %v user settings instead of %v, %v", expected, john, err)
+	}
+
+	if err := sm.DeleteUserSettings(client, "john"); err != nil {
+		t.Fatalf("it should delete user settings, but failed with %s", err.Error())
+	}
+
+	john, _ = sm.GetUserSettings(client, "john")
+	jane, _ := sm.GetUserSettings(client, "system:serviceaccount:default:jane")
+	if !reflect.DeepEqual(john, api.UserSettings{}) || !reflect.DeepEqual(jane,
+		api.UserSettings{ItemsPerPage: &itemsPerPage}) {
+		t.Errorf("it should delete only settings of the given user, got %v and %v", john, jane)
+	}
+
+	invalid := 0
+	if err := sm.SaveUserSettings(client, "john", &api.UserSettings{ItemsPerPage: &invalid}); err == nil {
+		t.Error("it should not save user settings with invalid items per page")
+	}
+}
+
+func TestSettingsManager_UserSettingsConfigMaps(t *testing.T) {
+	sm := NewSettingsManager()
+	client := fake.NewSimpleClientset()
+	itemsPerPage := 50
+
+	for _, username := range []string{"jane", "john"} {
+		if err := sm.SaveUserSettings(client, username, &api.UserSettings{ItemsPerPage: &itemsPerPage}); err != nil {
+			t.Fatalf("it should save user settings, but failed with %s", err.Error())
+		}
+	}
+
+	list, _ := client.CoreV1().ConfigMaps("").List(metav1.ListOptions{})
+	if len(list.Items) != 2 || list.Items[0].Name == list.Items[1].Name {
+		t.Fatalf("it should store settings of every user in own config map, got %v", list.Items)
+	}
+
+	if err := sm.DeleteUserSettings(client, "jane"); err != nil {
+		t.Fatalf("it should delete user settings, but failed with %s", err.Error())
+	}
+	list, _ = client.CoreV1().ConfigMaps("").List(metav1.ListOptions{})
+	if len(list.Items) != 1 || list.Items[0].Name != userSettingsConfigMapName("john") {
+		t.Errorf("it should remove config map of the user without any data, got %v", list.Items)
+	}
+}
+
+func TestSettings_WithUserSettings(t *testing.T) {
+	itemsPerPage := 50
+	language := "ja"
+	pinnedNamespaces := []string{"team-a"}
+	global := api.GetDefaultSettings()
+
+	actual := global.WithUserSettings(api.UserSettings{ItemsPerPage: &itemsPerPage, Language: &language,
+		PinnedNamespaces: &pinnedNamespaces})
+	expected := api.EffectiveSettings{Settings: global, Language: language}
+	expected.ItemsPerPage = itemsPerPage
+	expected.PinnedNamespaces = pinnedNamespaces
+
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("it should return %v effective settings instead of %v", expected, actual)
+	}
+}
@@ -14,10 +14,17 @@
 
 package api
 
+import (
+	"time"
+
+	settingsApi "github.com/kubernetes/dashboard/src/app/backend/settings/api"
+)
+
 // SystemBannerManager is used for user system banner management.
 type SystemBannerManager interface {
-	// Get system banner.
-	Get() *SystemBanner
+	// Get system banner that is active at given time. Banner scheduled in settings takes precedence over the one
+	// configured with arguments.
+	Get(settings settingsApi.Settings, now time.Time) SystemBanner
 }
 
 // SystemBanner represents system banner.
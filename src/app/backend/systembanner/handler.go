@@ -16,14 +16,21 @@ package systembanner
 
 import (
 	"net/http"
+	"time"
 
 	restful "github.com/emicklei/go-restful"
+
+	clientapi "github.com/kubernetes/dashboard/src/app/backend/client/api"
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	settingsApi "github.com/kubernetes/dashboard/src/app/backend/settings/api"
 	"github.com/kubernetes/dashboard/src/app/backend/systembanner/api"
 )
 
 // SystemBannerHandler manages all endpoints related to system banner management.
 type SystemBannerHandler struct {
-	manager SystemBannerManager
+	manager         SystemBannerManager
+	settingsManager settingsApi.SettingsManager
+	clientManager   clientapi.ClientManager
 }
 
 // Install creates new endpoints for system banner management.
@@ -32,13 +39,58 @@ func (self *SystemBannerHandler) Install(ws *restful.WebService) {
 		ws.GET("/systembanner").
 			To(self.handleGet).
 			Writes(api.SystemBanner{}))
+	ws.Route(
+		ws.PUT("/systembanner").
+			To(self.handleSave).
+			Reads(settingsApi.SystemBannerSettings{}).
+			Writes(settingsApi.SystemBannerSettings{}))
+	ws.Route(
+		ws.DELETE("/systembanner").
+			To(self.handleDelete))
 }
 
 func (self *SystemBannerHandler) handleGet(request *restful.Request, response *restful.Response) {
-	response.WriteHeaderAndEntity(http.StatusOK, self.manager.Get())
+	settings := self.settingsManager.GetGlobalSettings(self.clientManager.InsecureClient())
+	response.WriteHeaderAndEntity(http.StatusOK, self.manager.Get(settings, time.Now()))
+}
+
+// Banner is stored in global settings on behalf of the user, so only users allowed to update settings can set it.
+func (self *SystemBannerHandler) handleSave(request *restful.Request, response *restful.Response) {
+	banner := new(settingsApi.SystemBannerSettings)
+	if err := request.ReadEntity(banner); err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+	banner.Severity = string(api.GetSeverity(banner.Severity))
+
+	if err := self.saveBanner(request, banner); err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusCreated, banner)
+}
+
+func (self *SystemBannerHandler) handleDelete(request *restful.Request, response *restful.Response) {
+	if err := self.saveBanner(request, nil); err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+	response.WriteHeader(http.StatusOK)
+}
+
+func (self *SystemBannerHandler) saveBanner(request *restful.Request, banner *settingsApi.SystemBannerSettings) error {
+	client, err := self.clientManager.Client(request)
+	if err != nil {
+		return err
+	}
+
+	settings := self.settingsManager.GetGlobalSettings(client)
+	settings.SystemBanner = banner
+	return self.settingsManager.SaveGlobalSettings(client, &settings)
 }
 
 // NewSystemBannerHandler creates SystemBannerHandler.
-func NewSystemBannerHandler(manager SystemBannerManager) SystemBannerHandler {
-	return SystemBannerHandler{manager: manager}
+func NewSystemBannerHandler(manager SystemBannerManager, settingsManager settingsApi.SettingsManager,
+	clientManager clientapi.ClientManager) SystemBannerHandler {
+	return SystemBannerHandler{manager: manager, settingsManager: settingsManager, clientManager: clientManager}
 }
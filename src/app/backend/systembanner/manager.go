@@ -15,6 +15,9 @@
 package systembanner
 
 import (
+	"time"
+
+	settingsApi "github.com/kubernetes/dashboard/src/app/backend/settings/api"
 	"github.com/kubernetes/dashboard/src/app/backend/systembanner/api"
 )
 
@@ -34,6 +37,10 @@ func NewSystemBannerManager(message, severity string) SystemBannerManager {
 }
 
 // Get implements SystemBannerManager interface. Check it for more information.
-func (sbm *SystemBannerManager) Get() api.SystemBanner {
+func (sbm *SystemBannerManager) Get(settings settingsApi.Settings, now time.Time) api.SystemBanner {
+	if banner := settings.SystemBanner; banner.IsActive(now) {
+		return api.SystemBanner{Message: banner.Message, Severity: api.GetSeverity(banner.Severity)}
+	}
+
 	return sbm.systemBanner
 }
@@ -0,0 +1,56 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package systembanner
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	settingsApi "github.com/kubernetes/dashboard/src/app/backend/settings/api"
+	"github.com/kubernetes/dashboard/src/app/backend/systembanner/api"
+)
+
+func TestSystemBannerManager_Get(t *testing.T) {
+	now := time.Now()
+	past, future := now.Add(-time.Hour), now.Add(time.Hour)
+	argBanner := api.SystemBanner{Message: "Hello world!", Severity: api.SystemBannerSeverityInfo}
+
+	cases := []struct {
+		banner   *settingsApi.SystemBannerSettings
+		expected api.SystemBanner
+	}{
+		{nil, argBanner},
+		{&settingsApi.SystemBannerSettings{Severity: "ERROR"}, argBanner},
+		{
+			&settingsApi.SystemBannerSettings{Message: "Maintenance", Severity: "WARNING"},
+			api.SystemBanner{Message: "Maintenance", Severity: api.SystemBannerSeverityWarning},
+		},
+		{
+			&settingsApi.SystemBannerSettings{Message: "Maintenance", Severity: "ERROR", Start: &past, End: &future},
+			api.SystemBanner{Message: "Maintenance", Severity: api.SystemBannerSeverityError},
+		},
+		{&settingsApi.SystemBannerSettings{Message: "Maintenance", Start: &future}, argBanner},
+		{&settingsApi.SystemBannerSettings{Message: "Maintenance", End: &past}, argBanner},
+	}
+
+	manager := NewSystemBannerManager("Hello world!", "INFO")
+	for _, c := range cases {
+		actual := manager.Get(settingsApi.Settings{SystemBanner: c.banner}, now)
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("Get() == %#v, expected %#v for banner %#v", actual, c.expected, c.banner)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package systeminfo
+
+import (
+	"net/http"
+
+	restful "github.com/emicklei/go-restful"
+
+	authApi "github.com/kubernetes/dashboard/src/app/backend/auth/api"
+	clientapi "github.com/kubernetes/dashboard/src/app/backend/client/api"
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	featuresApi "github.com/kubernetes/dashboard/src/app/backend/features/api"
+	"github.com/kubernetes/dashboard/src/app/backend/integration"
+)
+
+// SystemInfoHandler manages endpoint, that reports diagnostics of the backend.
+type SystemInfoHandler struct {
+	clientManager      clientapi.ClientManager
+	integrationManager integration.IntegrationManager
+	authManager        authApi.AuthManager
+	featureManager     featuresApi.FeatureManager
+}
+
+// Install creates new endpoints for system info.
+func (self *SystemInfoHandler) Install(ws *restful.WebService) {
+	ws.Route(
+		ws.GET("/systeminfo").
+			To(self.handleGet).
+			Writes(SystemInfo{}))
+}
+
+// handleGet returns the full report only to users, that are allowed to list namespaces. Others, including users
+// that skipped login, get only the version of Dashboard.
+func (self *SystemInfoHandler) handleGet(request *restful.Request, response *restful.Response) {
+	if !self.clientManager.CanI(request, clientapi.ToSelfSubjectAccessReview("", "", "namespaces", "list")) {
+		response.WriteHeaderAndEntity(http.StatusOK, GetPublicSystemInfo())
+		return
+	}
+
+	k8sClient, err := self.clientManager.Client(request)
+	if err != nil {
+		errors.HandleInternalError(request, response, err)
+		return
+	}
+
+	response.WriteHeaderAndEntity(http.StatusOK, GetSystemInfo(k8sClient, self.clientManager,
+		self.integrationManager, self.authManager, self.featureManager))
+}
+
+// NewSystemInfoHandler creates SystemInfoHandler.
+func NewSystemInfoHandler(clientManager clientapi.ClientManager, integrationManager integration.IntegrationManager,
+	authManager authApi.AuthManager, featureManager featuresApi.FeatureManager) SystemInfoHandler {
+	return SystemInfoHandler{
+		clientManager:      clientManager,
+		integrationManager: integrationManager,
+		authManager:        authManager,
+		featureManager:     featureManager,
+	}
+}
@@ -0,0 +1,124 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package systeminfo
+
+import (
+	"fmt"
+	"runtime"
+
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/kubernetes/dashboard/src/app/backend/args"
+	authApi "github.com/kubernetes/dashboard/src/app/backend/auth/api"
+	"github.com/kubernetes/dashboard/src/app/backend/client"
+	clientapi "github.com/kubernetes/dashboard/src/app/backend/client/api"
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	featuresApi "github.com/kubernetes/dashboard/src/app/backend/features/api"
+	"github.com/kubernetes/dashboard/src/app/backend/integration"
+)
+
+// SystemInfo is a self-diagnostics report of the backend, that can be attached to support requests.
+type SystemInfo struct {
+	// Version of Dashboard.
+	Version string `json:"version"`
+	Build   Build  `json:"build"`
+	// APIServerVersion is a git version of the apiserver, i.e. v1.17.2. It is empty if it could not be detected.
+	APIServerVersion string `json:"apiServerVersion"`
+	// ConfigMode describes how Dashboard connects to the apiserver.
+	ConfigMode clientapi.ConfigMode `json:"configMode"`
+	// MetricsProvider is the value of --metrics-provider flag.
+	MetricsProvider string                   `json:"metricsProvider"`
+	Integrations    []Integration            `json:"integrations"`
+	Auth            Auth                     `json:"auth"`
+	FeatureGates    featuresApi.FeatureGates `json:"featureGates"`
+	// List of non-critical errors, that occurred during diagnostics.
+	Errors []error `json:"errors"`
+}
+
+// Build describes how the backend binary was built.
+type Build struct {
+	GoVersion string `json:"goVersion"`
+	Compiler  string `json:"compiler"`
+	Platform  string `json:"platform"`
+}
+
+// Integration is availability of an integrated application, i.e. metrics backend.
+type Integration struct {
+	ID        string `json:"id"`
+	Connected bool   `json:"connected"`
+	Error     string `json:"error,omitempty"`
+}
+
+// Auth describes configured authentication.
+type Auth struct {
+	Modes     []authApi.AuthenticationMode `json:"modes"`
+	Skippable bool                         `json:"skippable"`
+}
+
+// GetPublicSystemInfo returns the part of the report, that can be shown to anonymous users, i.e. version of
+// Dashboard only.
+func GetPublicSystemInfo() *SystemInfo {
+	return &SystemInfo{
+		Version:      client.Version,
+		Integrations: make([]Integration, 0),
+		Errors:       make([]error, 0),
+	}
+}
+
+// GetSystemInfo collects the report. Failures of individual checks are returned as non-critical errors, so that
+// the report is useful also when i.e. the apiserver can not be reached.
+func GetSystemInfo(k8sClient kubernetes.Interface, cManager clientapi.ClientManager,
+	iManager integration.IntegrationManager, authManager authApi.AuthManager,
+	fManager featuresApi.FeatureManager) *SystemInfo {
+	result := &SystemInfo{
+		Version: client.Version,
+		Build: Build{
+			GoVersion: runtime.Version(),
+			Compiler:  runtime.Compiler,
+			Platform:  fmt.Sprintf("%s/%s", runtime.GOOS, runtime.GOARCH),
+		},
+		ConfigMode:      cManager.ConfigMode(),
+		MetricsProvider: args.Holder.GetMetricsProvider(),
+		Integrations:    make([]Integration, 0),
+		Auth: Auth{
+			Modes:     authManager.AuthenticationModes(),
+			Skippable: authManager.AuthenticationSkippable(),
+		},
+		FeatureGates: fManager.List(),
+		Errors:       make([]error, 0),
+	}
+
+	serverVersion, err := k8sClient.Discovery().ServerVersion()
+	result.Errors = errors.AppendOptionalError(err, result.Errors)
+	if err == nil {
+		result.APIServerVersion = serverVersion.GitVersion
+	}
+
+	for _, i := range iManager.List() {
+		state, err := iManager.GetState(i.ID())
+		if err != nil {
+			result.Errors = errors.AppendOptionalError(err, result.Errors)
+			continue
+		}
+
+		info := Integration{ID: string(i.ID()), Connected: state.Connected}
+		if state.Error != nil {
+			info.Error = state.Error.Error()
+		}
+		result.Integrations = append(result.Integrations, info)
+	}
+
+	return result
+}
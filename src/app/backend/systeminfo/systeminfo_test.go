@@ -0,0 +1,78 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package systeminfo
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/version"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/kubernetes/dashboard/src/app/backend/auth"
+	authApi "github.com/kubernetes/dashboard/src/app/backend/auth/api"
+	"github.com/kubernetes/dashboard/src/app/backend/client"
+	clientapi "github.com/kubernetes/dashboard/src/app/backend/client/api"
+	"github.com/kubernetes/dashboard/src/app/backend/features"
+	"github.com/kubernetes/dashboard/src/app/backend/integration"
+)
+
+func TestGetSystemInfo(t *testing.T) {
+	k8sClient := fake.NewSimpleClientset()
+	k8sClient.Discovery().(*fakediscovery.FakeDiscovery).FakedServerVersion = &version.Info{GitVersion: "v1.17.2"}
+	cManager := client.NewClientManager("", "http://localhost:8080")
+	authModes := authApi.AuthenticationModes{}
+	authModes.Add(authApi.Token)
+	authManager := auth.NewAuthManager(cManager, nil, authModes, true)
+	fManager, err := features.NewFeatureManager("exec=false")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	info := GetSystemInfo(k8sClient, cManager, integration.NewIntegrationManager(cManager), authManager, fManager)
+
+	if info.Version != client.Version || len(info.Build.GoVersion) == 0 || len(info.Build.Platform) == 0 {
+		t.Errorf("GetSystemInfo() returned invalid version and build info: %#v", info)
+	}
+	if info.APIServerVersion != "v1.17.2" {
+		t.Errorf("GetSystemInfo() detected apiserver version %s, expected v1.17.2", info.APIServerVersion)
+	}
+	if info.ConfigMode != clientapi.ExplicitConfigMode {
+		t.Errorf("GetSystemInfo() reported config mode %s, expected %s", info.ConfigMode,
+			clientapi.ExplicitConfigMode)
+	}
+
+	expectedAuth := Auth{Modes: []authApi.AuthenticationMode{authApi.Token}, Skippable: true}
+	if !reflect.DeepEqual(info.Auth, expectedAuth) {
+		t.Errorf("GetSystemInfo() reported auth %#v, expected %#v", info.Auth, expectedAuth)
+	}
+	if !reflect.DeepEqual(info.FeatureGates, fManager.List()) {
+		t.Errorf("GetSystemInfo() reported feature gates %#v, expected %#v", info.FeatureGates, fManager.List())
+	}
+	if len(info.Errors) > 0 {
+		t.Errorf("GetSystemInfo() returned unexpected errors: %v", info.Errors)
+	}
+}
+
+func TestGetPublicSystemInfo(t *testing.T) {
+	info := GetPublicSystemInfo()
+
+	expected := &SystemInfo{Version: client.Version, Integrations: make([]Integration, 0),
+		Errors: make([]error, 0)}
+	if !reflect.DeepEqual(info, expected) {
+		t.Errorf("GetPublicSystemInfo() == %#v, expected %#v", info, expected)
+	}
+}
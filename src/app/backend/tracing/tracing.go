@@ -0,0 +1,151 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tracing instruments API requests and upstream calls to the apiserver and metrics providers with
+// OpenCensus spans. Trace context is propagated using W3C Trace Context headers and finished spans are written
+// to the log, so slow requests can be diagnosed end to end.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"go.opencensus.io/plugin/ochttp"
+	"go.opencensus.io/plugin/ochttp/propagation/tracecontext"
+	"go.opencensus.io/trace"
+
+	"github.com/kubernetes/dashboard/src/app/backend/logging"
+)
+
+var (
+	mux     sync.Mutex
+	enabled bool
+)
+
+// Init enables tracing of given fraction of requests. Rate has to be between 0 and 1, where 0 disables
+// tracing. Requests with sampled parent span are always traced.
+func Init(sampleRate float64) error {
+	if sampleRate < 0 || sampleRate > 1 {
+		return fmt.Errorf("tracing sample rate %v should be between 0 and 1", sampleRate)
+	}
+
+	mux.Lock()
+	defer mux.Unlock()
+	if sampleRate == 0 || enabled {
+		return nil
+	}
+
+	trace.ApplyConfig(trace.Config{DefaultSampler: trace.ProbabilitySampler(sampleRate)})
+	trace.RegisterExporter(&logExporter{})
+	enabled = true
+	return nil
+}
+
+// IsEnabled returns true when tracing was enabled.
+func IsEnabled() bool {
+	mux.Lock()
+	defer mux.Unlock()
+	return enabled
+}
+
+// Handler starts span for every request handled by given handler. Span is stored in the request context and
+// continues trace propagated by the caller.
+func Handler(handler http.Handler) http.Handler {
+	if !IsEnabled() {
+		return handler
+	}
+
+	return &ochttp.Handler{
+		Handler:     handler,
+		Propagation: &tracecontext.HTTPFormat{},
+		FormatSpanName: func(req *http.Request) string {
+			return req.Method + " " + req.URL.Path
+		},
+	}
+}
+
+// WrapTransport returns function that wraps round tripper, so every call made with it is traced and trace
+// headers are sent to the upstream server. Span stored in given context is used as a parent of calls that
+// are not made with traced context, which is useful for clients created for a single API request.
+func WrapTransport(ctx context.Context) func(http.RoundTripper) http.RoundTripper {
+	return func(rt http.RoundTripper) http.RoundTripper {
+		if !IsEnabled() {
+			return rt
+		}
+
+		return &parentRoundTripper{
+			parent: trace.FromContext(ctx),
+			rt: &ochttp.Transport{
+				Base:        rt,
+				Propagation: &tracecontext.HTTPFormat{},
+				FormatSpanName: func(req *http.Request) string {
+					return req.Method + " " + req.URL.Host + req.URL.Path
+				},
+			},
+		}
+	}
+}
+
+// parentRoundTripper attaches parent span to requests made without traced context.
+type parentRoundTripper struct {
+	parent *trace.Span
+	rt     http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (self *parentRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if self.parent != nil && trace.FromContext(req.Context()) == nil {
+		req = req.WithContext(trace.NewContext(req.Context(), self.parent))
+	}
+
+	return self.rt.RoundTrip(req)
+}
+
+// WrappedRoundTripper allows client-go to cancel requests made with wrapped round tripper.
+func (self *parentRoundTripper) WrappedRoundTripper() http.RoundTripper {
+	return self.rt
+}
+
+// logExporter writes finished spans to the log.
+type logExporter struct{}
+
+// ExportSpan implements trace.Exporter.
+func (self *logExporter) ExportSpan(span *trace.SpanData) {
+	fields := logging.Fields{
+		"traceID":    span.TraceID.String(),
+		"spanID":     span.SpanID.String(),
+		"durationMs": float64(span.EndTime.Sub(span.StartTime)) / float64(time.Millisecond),
+		"statusCode": span.Code,
+	}
+
+	if span.ParentSpanID != (trace.SpanID{}) {
+		fields["parentSpanID"] = span.ParentSpanID.String()
+	}
+	if len(span.Message) > 0 {
+		fields["statusMessage"] = span.Message
+	}
+	for key, value := range span.Attributes {
+		fields[key] = value
+	}
+
+	level := logging.LevelInfo
+	if span.Code != trace.StatusCodeOK {
+		level = logging.LevelWarning
+	}
+
+	logging.Log(level, "Span "+span.Name, fields)
+}
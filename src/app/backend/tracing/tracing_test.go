@@ -0,0 +1,78 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.opencensus.io/plugin/ochttp/propagation/tracecontext"
+	"go.opencensus.io/trace"
+)
+
+func TestInit(t *testing.T) {
+	cases := []struct {
+		rate        float64
+		expectedErr bool
+	}{
+		{-0.5, true},
+		{1.5, true},
+		{0, false},
+		{1, false},
+	}
+
+	for _, c := range cases {
+		if err := Init(c.rate); (err != nil) != c.expectedErr {
+			t.Errorf("Init(%v) returned error %v, expected error: %t", c.rate, err, c.expectedErr)
+		}
+	}
+
+	if !IsEnabled() {
+		t.Error("Expected tracing to be enabled")
+	}
+}
+
+func TestWrapTransport(t *testing.T) {
+	if err := Init(1); err != nil {
+		t.Fatal(err)
+	}
+
+	var received trace.SpanContext
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received, _ = (&tracecontext.HTTPFormat{}).SpanContextFromRequest(r)
+	}))
+	defer server.Close()
+
+	ctx, parent := trace.StartSpan(context.Background(), "parent")
+	defer parent.End()
+
+	client := &http.Client{Transport: WrapTransport(ctx)(http.DefaultTransport)}
+	response, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	response.Body.Close()
+
+	if received.TraceID != parent.SpanContext().TraceID {
+		t.Errorf("Expected upstream call to continue trace %s, but got %s", parent.SpanContext().TraceID,
+			received.TraceID)
+	}
+
+	if received.SpanID == parent.SpanContext().SpanID {
+		t.Error("Expected upstream call to be traced with child span")
+	}
+}
@@ -0,0 +1,196 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"regexp"
+
+	"github.com/docker/distribution/reference"
+	client "k8s.io/client-go/kubernetes"
+)
+
+const (
+	// tagsPageSize is the number of tags requested from the registry at once.
+	tagsPageSize = 500
+
+	// maxTagsPages limits the number of pages read, so that repositories with huge numbers of tags, i.e. nightly
+	// builds, do not block the request.
+	maxTagsPages = 10
+)
+
+// linkNextRegexp matches pagination Link header of the registry API, i.e. </v2/app/tags/list?last=v1>; rel="next".
+var linkNextRegexp = regexp.MustCompile(`<([^>]+)>\s*;\s*rel="?next"?`)
+
+// ImageTagsSpec is a specification of an image tags request.
+type ImageTagsSpec struct {
+	// Repository of the image, i.e. nginx or registry.example.com/team/app. Tag and digest are ignored.
+	Repository string `json:"repository"`
+
+	// Namespace of the image pull secret.
+	Namespace string `json:"namespace"`
+
+	// Optional name of an existing image pull secret with registry credentials.
+	ImagePullSecret *string `json:"imagePullSecret"`
+
+	// Optional registry credentials. They take precedence over the image pull secret.
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// ImageTags contains tags available in an image repository.
+type ImageTags struct {
+	// Tags in the order returned by the registry, that is usually lexical.
+	Tags []string `json:"tags"`
+
+	// True when the repository has more tags than were read.
+	Truncated bool `json:"truncated"`
+
+	// Reason why tags could not be listed.
+	Reason string `json:"reason,omitempty"`
+}
+
+// GetImageTags lists tags of the image repository using the Docker Registry v2 API. Registry errors are returned
+// as a reason, so that the tag picker can fall back to manual input. When error is returned, credentials could
+// not be read.
+func GetImageTags(spec *ImageTagsSpec, client client.Interface, httpClient *http.Client) (*ImageTags, error) {
+	log.Printf("Listing tags of %s image repository", spec.Repository)
+
+	named, err := reference.ParseNormalizedNamed(spec.Repository)
+	if err != nil {
+		return &ImageTags{Tags: []string{}, Reason: err.Error()}, nil
+	}
+	named = reference.TrimNamed(named)
+
+	credentials := &registryCredentials{username: spec.Username, password: spec.Password}
+	if len(spec.Username) == 0 && spec.ImagePullSecret != nil {
+		credentials, err = getRegistryCredentials(client, spec.Namespace, *spec.ImagePullSecret,
+			reference.Domain(named))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	result := &ImageTags{Tags: make([]string, 0)}
+	pageURL := getTagsURL(named)
+	authorization := ""
+	for page := 0; len(pageURL) > 0; page++ {
+		if page == maxTagsPages {
+			result.Truncated = true
+			break
+		}
+
+		tags, next, status, err := doTagsRequest(httpClient, pageURL, authorization)
+		if err == nil && status == http.StatusUnauthorized && len(authorization) == 0 {
+			authorization, err = authorize(httpClient, next, reference.Path(named), credentials)
+			if err == nil {
+				tags, next, status, err = doTagsRequest(httpClient, pageURL, authorization)
+			}
+		}
+		if err != nil {
+			result.Reason = err.Error()
+			return result, nil
+		}
+
+		switch status {
+		case http.StatusOK:
+		case http.StatusUnauthorized, http.StatusForbidden:
+			result.Reason = "access to the repository was denied by the registry"
+			return result, nil
+		case http.StatusNotFound:
+			result.Reason = "repository was not found in the registry"
+			return result, nil
+		default:
+			result.Reason = fmt.Sprintf("registry returned %d %s", status, http.StatusText(status))
+			return result, nil
+		}
+
+		result.Tags = append(result.Tags, tags...)
+		if pageURL, err = resolveNextPage(pageURL, next); err != nil {
+			result.Reason = err.Error()
+			return result, nil
+		}
+	}
+
+	return result, nil
+}
+
+func getTagsURL(named reference.Named) string {
+	host := reference.Domain(named)
+	if host == dockerHubDomain {
+		host = dockerHubRegistry
+	}
+	return fmt.Sprintf("https://%s/v2/%s/tags/list?n=%d", host, reference.Path(named), tagsPageSize)
+}
+
+// doTagsRequest reads a single page of tags. Second return value is the Link header of successful responses,
+// or authentication challenge of unauthorized ones.
+func doTagsRequest(httpClient *http.Client, pageURL, authorization string) ([]string, string, int, error) {
+	req, err := http.NewRequest(http.MethodGet, pageURL, nil)
+	if err != nil {
+		return nil, "", 0, err
+	}
+	if len(authorization) > 0 {
+		req.Header.Set("Authorization", authorization)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, "", 0, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusUnauthorized:
+		return nil, resp.Header.Get("WWW-Authenticate"), resp.StatusCode, nil
+	default:
+		return nil, "", resp.StatusCode, nil
+	}
+
+	tagList := struct {
+		Tags []string `json:"tags"`
+	}{}
+	if err := json.NewDecoder(resp.Body).Decode(&tagList); err != nil {
+		return nil, "", 0, err
+	}
+	return tagList.Tags, resp.Header.Get("Link"), resp.StatusCode, nil
+}
+
+// resolveNextPage returns absolute URL of the next page from the Link header, or empty string on the last page.
+// Links to other hosts are rejected, because registry credentials are sent with the request for the next page.
+func resolveNextPage(pageURL, link string) (string, error) {
+	match := linkNextRegexp.FindStringSubmatch(link)
+	if match == nil {
+		return "", nil
+	}
+
+	base, err := url.Parse(pageURL)
+	if err != nil {
+		return "", err
+	}
+	next, err := base.Parse(match[1])
+	if err != nil {
+		return "", err
+	}
+	if next.Scheme != base.Scheme || next.Host != base.Host {
+		return "", fmt.Errorf("registry returned link to the next page on another host: %s", next.Host)
+	}
+	return next.String(), nil
+}
@@ -0,0 +1,93 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestGetImageTags(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/token":
+			if username, password, ok := r.BasicAuth(); !ok || username != "user" || password != "pass" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			fmt.Fprint(w, `{"access_token":"secret-token"}`)
+		case r.URL.Path == "/v2/public/app/tags/list":
+			if r.URL.Query().Get("last") == "" {
+				w.Header().Set("Link", `</v2/public/app/tags/list?n=500&last=v1>; rel="next"`)
+				fmt.Fprint(w, `{"name":"public/app","tags":["v1"]}`)
+				return
+			}
+			fmt.Fprint(w, `{"name":"public/app","tags":["v2","v3"]}`)
+		case r.URL.Path == "/v2/private/app/tags/list":
+			if r.Header.Get("Authorization") != "Bearer secret-token" {
+				w.Header().Set("WWW-Authenticate",
+					fmt.Sprintf(`Bearer realm="%s/token",service="registry"`, server.URL))
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			fmt.Fprint(w, `{"name":"private/app","tags":["latest"]}`)
+		case r.URL.Path == "/v2/redirected/app/tags/list":
+			w.Header().Set("Link", `<https://attacker.example.com/v2/app/tags/list?last=v1>; rel="next"`)
+			fmt.Fprint(w, `{"name":"redirected/app","tags":["v1"]}`)
+		case r.URL.Path == "/v2/insecure/app/tags/list":
+			w.Header().Set("WWW-Authenticate", `Bearer realm="http://auth.example.com/token"`)
+			w.WriteHeader(http.StatusUnauthorized)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	host := strings.TrimPrefix(server.URL, "https://")
+	cases := []struct {
+		spec   *ImageTagsSpec
+		tags   []string
+		reason string
+	}{
+		{&ImageTagsSpec{Repository: host + "/public/app:v1"}, []string{"v1", "v2", "v3"}, ""},
+		{&ImageTagsSpec{Repository: host + "/private/app", Username: "user", Password: "pass"}, []string{"latest"},
+			""},
+		{&ImageTagsSpec{Repository: host + "/private/app"}, []string{}, "registry token service returned 401 Unauthorized"},
+		{&ImageTagsSpec{Repository: host + "/missing/app"}, []string{}, "repository was not found in the registry"},
+		{&ImageTagsSpec{Repository: host + "/redirected/app"}, []string{"v1"},
+			"registry returned link to the next page on another host: attacker.example.com"},
+		{&ImageTagsSpec{Repository: host + "/insecure/app", Username: "user", Password: "pass"}, []string{},
+			`token realm must use https: "http://auth.example.com/token"`},
+	}
+
+	for _, c := range cases {
+		tags, err := GetImageTags(c.spec, fake.NewSimpleClientset(), server.Client())
+		if err != nil {
+			t.Errorf("GetImageTags(%s) returned unexpected error: %s", c.spec.Repository, err)
+			continue
+		}
+
+		if !reflect.DeepEqual(tags.Tags, c.tags) || tags.Reason != c.reason {
+			t.Errorf("Expected %s tags to be %v (%q), but got %#v", c.spec.Repository, c.tags, c.reason, tags)
+		}
+	}
+}
@@ -15,9 +15,12 @@
 package validation
 
 import (
+	"fmt"
 	"log"
+	"regexp"
 
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8svalidation "k8s.io/apimachinery/pkg/util/validation"
 	client "k8s.io/client-go/kubernetes"
 
 	"github.com/kubernetes/dashboard/src/app/backend/errors"
@@ -29,42 +32,120 @@ type AppNameValiditySpec struct {
 	Namespace string `json:"namespace"`
 }
 
+// AppNameConstraint is a rule that the application name has to satisfy.
+type AppNameConstraint string
+
+// List of application name constraints. All but the last one are DNS-1035 label rules, as the name is also used
+// as the name of the service.
+const (
+	AppNameConstraintLength  AppNameConstraint = "length"
+	AppNameConstraintCharset AppNameConstraint = "charset"
+	AppNameConstraintStart   AppNameConstraint = "start"
+	AppNameConstraintEnd     AppNameConstraint = "end"
+	AppNameConstraintUnique  AppNameConstraint = "unique"
+)
+
+var appNameCharsetRegexp = regexp.MustCompile("^[a-z0-9-]*$")
+
 // AppNameValidity describes validity of the application name.
 type AppNameValidity struct {
 	// True when the application name is valid.
 	Valid bool `json:"valid"`
+
+	// Constraint that the invalid name does not satisfy.
+	Constraint AppNameConstraint `json:"constraint,omitempty"`
+
+	// Human readable description of the failed constraint.
+	Reason string `json:"reason,omitempty"`
+
+	// Kinds of the objects that already use the name, when the unique constraint is not satisfied.
+	Conflicts []string `json:"conflicts,omitempty"`
+}
+
+// appObjectKinds are kinds of the objects created by the deploy form. All of them are named after the app.
+var appObjectKinds = []string{"Deployment", "Service"}
+
+// nameGetters get object of given kind by name, so that availability of the name can be checked.
+var nameGetters = map[string]func(client client.Interface, namespace, name string) error{
+	"Deployment": func(client client.Interface, namespace, name string) error {
+		_, err := client.AppsV1().Deployments(namespace).Get(name, metaV1.GetOptions{})
+		return err
+	},
+	"Service": func(client client.Interface, namespace, name string) error {
+		_, err := client.CoreV1().Services(namespace).Get(name, metaV1.GetOptions{})
+		return err
+	},
 }
 
-// ValidateAppName validates application name. When error is returned, name validity could not be
-// determined.
+// ValidateAppName validates application name. Name has to be a DNS-1035 label that is not used by an object of
+// any kind created by the deploy form, i.e. a deployment or a service, in the namespace. Names that cannot be
+// read because of missing permissions are assumed to be free. When error is returned, name validity could not
+// be determined.
 func ValidateAppName(spec *AppNameValiditySpec, client client.Interface) (*AppNameValidity, error) {
 	log.Printf("Validating %s application name in %s namespace", spec.Name, spec.Namespace)
 
-	isValidDeployment := false
-	isValidService := false
-
-	_, err := client.AppsV1().Deployments(spec.Namespace).Get(spec.Name, metaV1.GetOptions{})
-	if err != nil {
-		if errors.IsNotFoundError(err) || errors.IsForbiddenError(err) {
-			isValidDeployment = true
-		} else {
-			return nil, err
-		}
+	if validity := validateAppNameFormat(spec.Name); !validity.Valid {
+		log.Printf("Application name %s does not satisfy %s constraint", spec.Name, validity.Constraint)
+		return validity, nil
 	}
 
-	_, err = client.CoreV1().Services(spec.Namespace).Get(spec.Name, metaV1.GetOptions{})
-	if err != nil {
-		if errors.IsNotFoundError(err) || errors.IsForbiddenError(err) {
-			isValidService = true
-		} else {
+	conflicts := make([]string, 0)
+	for _, kind := range appObjectKinds {
+		err := nameGetters[kind](client, spec.Namespace, spec.Name)
+		if err == nil {
+			conflicts = append(conflicts, kind)
+		} else if !errors.IsNotFoundError(err) && !errors.IsForbiddenError(err) {
 			return nil, err
 		}
 	}
 
-	isValid := isValidDeployment && isValidService
+	isValid := len(conflicts) == 0
 
 	log.Printf("Validation result for %s application name in %s namespace is %t", spec.Name,
 		spec.Namespace, isValid)
 
-	return &AppNameValidity{Valid: isValid}, nil
+	validity := &AppNameValidity{Valid: isValid}
+	if !isValid {
+		validity.Constraint = AppNameConstraintUnique
+		validity.Conflicts = conflicts
+		validity.Reason = fmt.Sprintf("name is already used by %s within namespace", joinKinds(conflicts))
+	}
+	return validity, nil
+}
+
+// joinKinds joins kinds into a human readable enumeration, i.e. "Deployment and Service".
+func joinKinds(kinds []string) string {
+	switch len(kinds) {
+	case 0:
+		return ""
+	case 1:
+		return kinds[0]
+	}
+
+	result := kinds[0]
+	for _, kind := range kinds[1 : len(kinds)-1] {
+		result += ", " + kind
+	}
+	return result + " and " + kinds[len(kinds)-1]
+}
+
+// validateAppNameFormat checks DNS-1035 label rules one by one, so that the first failed constraint can be
+// reported. Rules are the same as in ValidateAppDeploymentSpec.
+func validateAppNameFormat(name string) *AppNameValidity {
+	switch {
+	case len(name) == 0 || len(name) > k8svalidation.DNS1035LabelMaxLength:
+		return &AppNameValidity{Constraint: AppNameConstraintLength,
+			Reason: fmt.Sprintf("must be between 1 and %d characters long", k8svalidation.DNS1035LabelMaxLength)}
+	case !appNameCharsetRegexp.MatchString(name):
+		return &AppNameValidity{Constraint: AppNameConstraintCharset,
+			Reason: "must consist of lower case alphanumeric characters or '-'"}
+	case name[0] < 'a' || name[0] > 'z':
+		return &AppNameValidity{Constraint: AppNameConstraintStart,
+			Reason: "must start with a lower case letter"}
+	case name[len(name)-1] == '-':
+		return &AppNameValidity{Constraint: AppNameConstraintEnd,
+			Reason: "must end with an alphanumeric character"}
+	}
+
+	return &AppNameValidity{Valid: true}
 }
@@ -15,8 +15,11 @@
 package validation
 
 import (
+	"reflect"
+	"strings"
 	"testing"
 
+	apps "k8s.io/api/apps/v1"
 	api "k8s.io/api/core/v1"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -80,3 +83,67 @@ func TestValidateName(t *testing.T) {
 		}
 	}
 }
+
+func TestValidateNameConflicts(t *testing.T) {
+	spec := &AppNameValiditySpec{Namespace: "foo-namespace", Name: "foo-name"}
+	meta := metaV1.ObjectMeta{Name: "foo-name", Namespace: "foo-namespace"}
+	cases := []struct {
+		objects           []runtime.Object
+		expectedConflicts []string
+		expectedReason    string
+	}{
+		{
+			[]runtime.Object{&api.Service{ObjectMeta: meta}},
+			[]string{"Service"},
+			"name is already used by Service within namespace",
+		},
+		{
+			[]runtime.Object{&apps.Deployment{ObjectMeta: meta}, &api.Service{ObjectMeta: meta}},
+			[]string{"Deployment", "Service"},
+			"name is already used by Deployment and Service within namespace",
+		},
+	}
+
+	for _, c := range cases {
+		validity, err := ValidateAppName(spec, fake.NewSimpleClientset(c.objects...))
+		if err != nil {
+			t.Fatalf("ValidateAppName() returned unexpected error: %s", err)
+		}
+		if validity.Valid || validity.Constraint != AppNameConstraintUnique ||
+			!reflect.DeepEqual(validity.Conflicts, c.expectedConflicts) || validity.Reason != c.expectedReason {
+			t.Errorf("ValidateAppName() == %#v, expected conflicts %v with reason %q", validity,
+				c.expectedConflicts, c.expectedReason)
+		}
+	}
+}
+
+func TestValidateAppNameFormat(t *testing.T) {
+	cases := []struct {
+		name     string
+		expected AppNameConstraint
+	}{
+		{"foo-name", ""},
+		{"app1", ""},
+		{"1app", AppNameConstraintStart},
+		{"", AppNameConstraintLength},
+		{strings.Repeat("a", 64), AppNameConstraintLength},
+		{"Foo", AppNameConstraintCharset},
+		{"foo_name", AppNameConstraintCharset},
+		{"-foo", AppNameConstraintStart},
+		{"foo-", AppNameConstraintEnd},
+	}
+
+	for _, c := range cases {
+		validity, err := ValidateAppName(&AppNameValiditySpec{Name: c.name, Namespace: "default"},
+			fake.NewSimpleClientset())
+		if err != nil {
+			t.Errorf("ValidateAppName(%s) returned unexpected error: %s", c.name, err)
+			continue
+		}
+
+		if validity.Constraint != c.expected || validity.Valid != (len(c.expected) == 0) ||
+			(len(validity.Reason) == 0) != validity.Valid {
+			t.Errorf("ValidateAppName(%s) == %#v, expected failed constraint %q", c.name, validity, c.expected)
+		}
+	}
+}
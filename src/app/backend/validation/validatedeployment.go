@@ -0,0 +1,402 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"fmt"
+
+	api "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilnet "k8s.io/apimachinery/pkg/util/net"
+	k8svalidation "k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	client "k8s.io/client-go/kubernetes"
+
+	"github.com/kubernetes/dashboard/src/app/backend/args"
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/deployment"
+)
+
+// defaultServiceNodePortRange is the default value of service-node-port-range argument of the apiserver.
+const defaultServiceNodePortRange = "30000-32767"
+
+// FieldError describes a single invalid field of the validated object.
+type FieldError struct {
+	// JSON path of the field, i.e. portMappings[0].port.
+	Field string `json:"field"`
+
+	// Type of the error, i.e. FieldValueInvalid or FieldValueDuplicate.
+	Type field.ErrorType `json:"type"`
+
+	// Human readable description of the error.
+	Detail string `json:"detail"`
+}
+
+// AppDeploymentSpecValidity describes validity of the whole app deployment spec.
+type AppDeploymentSpecValidity struct {
+	// True when there are no field errors.
+	Valid bool `json:"valid"`
+
+	// List of all invalid fields.
+	Errors []FieldError `json:"errors"`
+
+	// Problems that do not prevent the deploy, but should be shown to the user, i.e. missing load balancer
+	// support for external services.
+	Warnings []string `json:"warnings"`
+}
+
+// ValidateAppDeploymentSpec validates all fields of the app deployment spec at once, so the deploy form can
+// show all of the errors before the deploy is submitted. When error is returned, validity could not be
+// determined.
+func ValidateAppDeploymentSpec(spec *deployment.AppDeploymentSpec, client client.Interface) (
+	*AppDeploymentSpecValidity, error) {
+	allErrs := field.ErrorList{}
+
+	namespaceValidity, err := ValidateNamespace(&NamespaceValiditySpec{Name: spec.Namespace}, client)
+	if err != nil {
+		return nil, err
+	}
+	if namespaceValidity.Terminating {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("namespace"), spec.Namespace,
+			"namespace is being deleted"))
+	} else if !namespaceValidity.Valid && !spec.CreateNamespace {
+		allErrs = append(allErrs, field.NotFound(field.NewPath("namespace"), spec.Namespace))
+	}
+
+	namePath := field.NewPath("name")
+	if msgs := k8svalidation.IsDNS1035Label(spec.Name); len(msgs) > 0 {
+		for _, msg := range msgs {
+			allErrs = append(allErrs, field.Invalid(namePath, spec.Name, msg))
+		}
+	} else if namespaceValidity.Valid {
+		nameValidity, err := ValidateAppName(&AppNameValiditySpec{Name: spec.Name, Namespace: spec.Namespace}, client)
+		if err != nil {
+			return nil, err
+		}
+		if !nameValidity.Valid {
+			allErrs = append(allErrs, field.Duplicate(namePath, spec.Name))
+		}
+	}
+
+	imageValidity, _ := ValidateImageReference(&ImageReferenceValiditySpec{Reference: spec.ContainerImage})
+	if !imageValidity.Valid {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("containerImage"), spec.ContainerImage,
+			imageValidity.Reason))
+	}
+
+	allErrs = append(allErrs, validateInitContainers(spec.InitContainers, spec.Name,
+		field.NewPath("initContainers"))...)
+
+	if spec.Replicas < 0 {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("replicas"), spec.Replicas,
+			"must be greater than or equal to 0"))
+	}
+
+	resourceErrs, err := validateResources(spec, namespaceValidity.Valid, client)
+	if err != nil {
+		return nil, err
+	}
+	allErrs = append(allErrs, resourceErrs...)
+
+	envErrs, err := validateEnvironmentVariables(spec.Variables, spec.Namespace, namespaceValidity.Valid, client,
+		field.NewPath("variables"))
+	if err != nil {
+		return nil, err
+	}
+	allErrs = append(allErrs, envErrs...)
+
+	allErrs = append(allErrs, validatePortMappings(spec.PortMappings, spec.IsExternal,
+		field.NewPath("portMappings"))...)
+
+	validity := toAppDeploymentSpecValidity(allErrs)
+	if spec.IsExternal && len(spec.PortMappings) > 0 {
+		support, err := CheckLoadBalancerSupport(client)
+		if err != nil {
+			return nil, err
+		}
+		if !support.Supported {
+			validity.Warnings = append(validity.Warnings, support.Warning)
+		}
+	}
+
+	return validity, nil
+}
+
+// ValidateAppDeploymentImages checks image references of the app container and all of its init containers.
+// Bad request error describing the first malformed reference is returned, so the deployment is not created
+// with an image that could never be pulled.
+func ValidateAppDeploymentImages(spec *deployment.AppDeploymentSpec) error {
+	images := []string{spec.ContainerImage}
+	for _, initContainer := range spec.InitContainers {
+		images = append(images, initContainer.ContainerImage)
+	}
+
+	for _, image := range images {
+		validity, _ := ValidateImageReference(&ImageReferenceValiditySpec{Reference: image})
+		if !validity.Valid {
+			return errors.NewBadRequest(fmt.Sprintf("invalid container image %q: %s", image, validity.Reason))
+		}
+	}
+
+	return nil
+}
+
+// validateInitContainers validates names and images of the init containers. Names have to be unique, also
+// with respect to the app container, which has the same name as the app.
+func validateInitContainers(initContainers []deployment.InitContainer, appName string,
+	fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	names := map[string]bool{appName: true}
+	for i, initContainer := range initContainers {
+		idxPath := fldPath.Index(i)
+		namePath := idxPath.Child("name")
+		if msgs := k8svalidation.IsDNS1123Label(initContainer.Name); len(msgs) > 0 {
+			for _, msg := range msgs {
+				allErrs = append(allErrs, field.Invalid(namePath, initContainer.Name, msg))
+			}
+		} else if names[initContainer.Name] {
+			allErrs = append(allErrs, field.Duplicate(namePath, initContainer.Name))
+		}
+		names[initContainer.Name] = true
+
+		imageValidity, _ := ValidateImageReference(&ImageReferenceValiditySpec{Reference: initContainer.ContainerImage})
+		if !imageValidity.Valid {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("containerImage"), initContainer.ContainerImage,
+				imageValidity.Reason))
+		}
+
+		for j, variable := range initContainer.Variables {
+			for _, msg := range k8svalidation.IsEnvVarName(variable.Name) {
+				allErrs = append(allErrs, field.Invalid(idxPath.Child("variables").Index(j).Child("name"),
+					variable.Name, msg))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+// ServiceNodePortRange returns port range reserved for node ports of services, as configured by
+// service-node-port-range argument. Default range of the apiserver is used when the argument is empty.
+func ServiceNodePortRange() (*utilnet.PortRange, error) {
+	nodePortRange := args.Holder.GetServiceNodePortRange()
+	if len(nodePortRange) == 0 {
+		nodePortRange = defaultServiceNodePortRange
+	}
+
+	return utilnet.ParsePortRange(nodePortRange)
+}
+
+// ValidateAppDeploymentPorts checks port mappings of the service that will be created for the app. Bad request
+// error listing all invalid port mappings is returned, so they can be fixed before anything is created.
+func ValidateAppDeploymentPorts(spec *deployment.AppDeploymentSpec) error {
+	allErrs := validatePortMappings(spec.PortMappings, spec.IsExternal, field.NewPath("portMappings"))
+	if len(allErrs) > 0 {
+		return errors.NewBadRequest(allErrs.ToAggregate().Error())
+	}
+
+	return nil
+}
+
+// validatePortMappings validates ports and their protocols against the type of the service that will be
+// created. Node ports are checked against service node port range of the cluster.
+func validatePortMappings(portMappings []deployment.PortMapping, isExternal bool, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	nodePortRange, err := ServiceNodePortRange()
+	if err != nil {
+		return field.ErrorList{field.InternalError(fldPath, err)}
+	}
+	nodePorts := make(map[int32]bool)
+	supported := make([]string, 0)
+	for _, protocol := range deployment.GetAvailableProtocols().Protocols {
+		supported = append(supported, string(protocol))
+	}
+
+	type portKey struct {
+		port     int32
+		protocol api.Protocol
+	}
+	seen := make(map[portKey]bool)
+
+	for i, portMapping := range portMappings {
+		idxPath := fldPath.Index(i)
+		for _, msg := range k8svalidation.IsValidPortNum(int(portMapping.Port)) {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("port"), portMapping.Port, msg))
+		}
+		for _, msg := range k8svalidation.IsValidPortNum(int(portMapping.TargetPort)) {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("targetPort"), portMapping.TargetPort, msg))
+		}
+
+		protocolPath := idxPath.Child("protocol")
+		if !containsString(supported, string(portMapping.Protocol)) {
+			allErrs = append(allErrs, field.NotSupported(protocolPath, portMapping.Protocol, supported))
+		} else if !ValidateProtocol(&ProtocolValiditySpec{Protocol: portMapping.Protocol, IsExternal: isExternal}).Valid {
+			allErrs = append(allErrs, field.Invalid(protocolPath, portMapping.Protocol,
+				fmt.Sprintf("%s protocol is not supported by external services", portMapping.Protocol)))
+		}
+
+		allErrs = append(allErrs, validateNodePort(portMapping.NodePort, isExternal, nodePortRange, nodePorts,
+			idxPath.Child("nodePort"))...)
+
+		key := portKey{port: portMapping.Port, protocol: portMapping.Protocol}
+		if seen[key] {
+			allErrs = append(allErrs, field.Duplicate(idxPath, fmt.Sprintf("%d/%s", key.port, key.protocol)))
+		}
+		seen[key] = true
+	}
+
+	return allErrs
+}
+
+// validateNodePort validates optional node port of a single port mapping. Node ports can be requested only for
+// external services and have to be unique within the range reserved by the cluster.
+func validateNodePort(nodePort int32, isExternal bool, nodePortRange *utilnet.PortRange, seen map[int32]bool,
+	fldPath *field.Path) field.ErrorList {
+	if nodePort == 0 {
+		return nil
+	}
+	if !isExternal {
+		return field.ErrorList{field.Forbidden(fldPath,
+			"may be set only for external services, leave it empty or change service type to external")}
+	}
+	if !nodePortRange.Contains(int(nodePort)) {
+		return field.ErrorList{field.Invalid(fldPath, nodePort, fmt.Sprintf(
+			"must be in %s range reserved for node ports by the cluster, leave it empty to allocate a free port",
+			nodePortRange))}
+	}
+	if seen[nodePort] {
+		return field.ErrorList{field.Duplicate(fldPath, nodePort)}
+	}
+	seen[nodePort] = true
+
+	return nil
+}
+
+// validateEnvironmentVariables validates names of the variables and checks that keys of referenced secrets and
+// config maps exist. References are checked only when the namespace exists.
+func validateEnvironmentVariables(variables []deployment.EnvironmentVariable, namespace string,
+	checkRefs bool, client client.Interface, fldPath *field.Path) (field.ErrorList, error) {
+	allErrs := field.ErrorList{}
+	for i, variable := range variables {
+		idxPath := fldPath.Index(i)
+		for _, msg := range k8svalidation.IsEnvVarName(variable.Name) {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("name"), variable.Name, msg))
+		}
+
+		if variable.ValueFrom == nil {
+			continue
+		}
+
+		sourcePath := idxPath.Child("valueFrom")
+		secretRef, configMapRef := variable.ValueFrom.SecretKeyRef, variable.ValueFrom.ConfigMapKeyRef
+		switch {
+		case secretRef != nil && configMapRef != nil:
+			allErrs = append(allErrs, field.Invalid(sourcePath, "",
+				"may not have more than one field specified at a time"))
+		case secretRef != nil && checkRefs:
+			keys, exists, err := getSecretKeys(client, namespace, secretRef.Name)
+			if err != nil {
+				return nil, err
+			}
+			allErrs = append(allErrs, validateKeySelector(secretRef, keys, exists,
+				sourcePath.Child("secretKeyRef"))...)
+		case configMapRef != nil && checkRefs:
+			keys, exists, err := getConfigMapKeys(client, namespace, configMapRef.Name)
+			if err != nil {
+				return nil, err
+			}
+			allErrs = append(allErrs, validateKeySelector(configMapRef, keys, exists,
+				sourcePath.Child("configMapKeyRef"))...)
+		}
+	}
+
+	return allErrs, nil
+}
+
+// validateKeySelector checks that the selected object exists and contains the selected key. Nil keys mean
+// that the object could not be read because of missing permissions, so they are not validated.
+func validateKeySelector(selector *deployment.KeySelector, keys map[string]bool, exists bool,
+	fldPath *field.Path) field.ErrorList {
+	if !exists {
+		return field.ErrorList{field.NotFound(fldPath.Child("name"), selector.Name)}
+	}
+	if keys != nil && !keys[selector.Key] {
+		return field.ErrorList{field.NotFound(fldPath.Child("key"), selector.Key)}
+	}
+
+	return nil
+}
+
+func getSecretKeys(client client.Interface, namespace, name string) (map[string]bool, bool, error) {
+	secret, err := client.CoreV1().Secrets(namespace).Get(name, metaV1.GetOptions{})
+	if err != nil {
+		return handleKeysError(err)
+	}
+
+	keys := map[string]bool{}
+	for key := range secret.Data {
+		keys[key] = true
+	}
+	for key := range secret.StringData {
+		keys[key] = true
+	}
+	return keys, true, nil
+}
+
+func getConfigMapKeys(client client.Interface, namespace, name string) (map[string]bool, bool, error) {
+	configMap, err := client.CoreV1().ConfigMaps(namespace).Get(name, metaV1.GetOptions{})
+	if err != nil {
+		return handleKeysError(err)
+	}
+
+	keys := map[string]bool{}
+	for key := range configMap.Data {
+		keys[key] = true
+	}
+	for key := range configMap.BinaryData {
+		keys[key] = true
+	}
+	return keys, true, nil
+}
+
+func handleKeysError(err error) (map[string]bool, bool, error) {
+	switch {
+	case errors.IsNotFoundError(err):
+		return nil, false, nil
+	case errors.IsForbiddenError(err):
+		return nil, true, nil
+	}
+	return nil, false, err
+}
+
+func toAppDeploymentSpecValidity(allErrs field.ErrorList) *AppDeploymentSpecValidity {
+	result := &AppDeploymentSpecValidity{Valid: len(allErrs) == 0, Errors: make([]FieldError, 0, len(allErrs)),
+		Warnings: make([]string, 0)}
+	for _, err := range allErrs {
+		result.Errors = append(result.Errors, FieldError{Field: err.Field, Type: err.Type, Detail: err.ErrorBody()})
+	}
+
+	return result
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,181 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	apps "k8s.io/api/apps/v1"
+	api "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/kubernetes/dashboard/src/app/backend/resource/deployment"
+)
+
+func TestValidateAppDeploymentSpec(t *testing.T) {
+	namespace := &api.Namespace{ObjectMeta: metaV1.ObjectMeta{Name: "default"}}
+	existing := &apps.Deployment{ObjectMeta: metaV1.ObjectMeta{Name: "taken", Namespace: "default"}}
+
+	cases := []struct {
+		spec     *deployment.AppDeploymentSpec
+		expected []string
+	}{
+		{
+			&deployment.AppDeploymentSpec{Name: "app", Namespace: "default", ContainerImage: "nginx",
+				PortMappings: []deployment.PortMapping{{Port: 80, TargetPort: 8080, Protocol: api.ProtocolTCP}}},
+			[]string{},
+		},
+		{
+			&deployment.AppDeploymentSpec{Name: "taken", Namespace: "default", ContainerImage: "nginx"},
+			[]string{"name"},
+		},
+		{
+			&deployment.AppDeploymentSpec{Name: "App", Namespace: "missing", ContainerImage: "Nginx", Replicas: -1},
+			[]string{"namespace", "name", "containerImage", "replicas"},
+		},
+		{
+			&deployment.AppDeploymentSpec{Name: "app", Namespace: "default", ContainerImage: "nginx",
+				IsExternal: true, PortMappings: []deployment.PortMapping{
+					{Port: 80, TargetPort: 0, Protocol: api.ProtocolTCP},
+					{Port: 53, TargetPort: 53, Protocol: api.ProtocolUDP},
+					{Port: 80, TargetPort: 80, Protocol: api.ProtocolTCP},
+					{Port: 70000, TargetPort: 80, Protocol: "HTTP"},
+				}},
+			[]string{"portMappings[0].targetPort", "portMappings[1].protocol", "portMappings[2]",
+				"portMappings[3].port", "portMappings[3].protocol"},
+		},
+		{
+			&deployment.AppDeploymentSpec{Name: "app", Namespace: "default", ContainerImage: "nginx",
+				Variables: []deployment.EnvironmentVariable{
+					{Name: "1nvalid", Value: "foo"},
+					{Name: "PASSWORD", ValueFrom: &deployment.EnvironmentVariableSource{
+						SecretKeyRef: &deployment.KeySelector{Name: "db", Key: "password"}}},
+					{Name: "USER", ValueFrom: &deployment.EnvironmentVariableSource{
+						SecretKeyRef: &deployment.KeySelector{Name: "db", Key: "user"}}},
+					{Name: "MODE", ValueFrom: &deployment.EnvironmentVariableSource{
+						ConfigMapKeyRef: &deployment.KeySelector{Name: "missing", Key: "mode"}}},
+					{Name: "BOTH", ValueFrom: &deployment.EnvironmentVariableSource{
+						SecretKeyRef:    &deployment.KeySelector{Name: "db", Key: "password"},
+						ConfigMapKeyRef: &deployment.KeySelector{Name: "app", Key: "mode"}}},
+				}},
+			[]string{"variables[0].name", "variables[2].valueFrom.secretKeyRef.key",
+				"variables[3].valueFrom.configMapKeyRef.name", "variables[4].valueFrom"},
+		},
+		{
+			&deployment.AppDeploymentSpec{Name: "app", Namespace: "default", ContainerImage: "nginx",
+				InitContainers: []deployment.InitContainer{
+					{Name: "init", ContainerImage: "busybox"},
+					{Name: "app", ContainerImage: "busybox"},
+					{Name: "init", ContainerImage: "Busybox",
+						Variables: []deployment.EnvironmentVariable{{Name: "1nvalid"}}},
+				}},
+			[]string{"initContainers[1].name", "initContainers[2].name", "initContainers[2].containerImage",
+				"initContainers[2].variables[0].name"},
+		},
+	}
+
+	secret := &api.Secret{ObjectMeta: metaV1.ObjectMeta{Name: "db", Namespace: "default"},
+		Data: map[string][]byte{"password": []byte("secret")}}
+
+	for _, c := range cases {
+		validity, err := ValidateAppDeploymentSpec(c.spec, fake.NewSimpleClientset(namespace, existing, secret))
+		if err != nil {
+			t.Errorf("ValidateAppDeploymentSpec(%#v) returned unexpected error: %s", c.spec, err)
+			continue
+		}
+
+		actual := make([]string, 0)
+		for _, fieldErr := range validity.Errors {
+			actual = append(actual, fieldErr.Field)
+		}
+
+		if !reflect.DeepEqual(actual, c.expected) || validity.Valid != (len(c.expected) == 0) {
+			t.Errorf("ValidateAppDeploymentSpec(%#v) == %#v, expected errors for fields %#v", c.spec, validity,
+				c.expected)
+		}
+	}
+}
+
+func TestValidateAppDeploymentImages(t *testing.T) {
+	cases := []struct {
+		spec     *deployment.AppDeploymentSpec
+		expected bool
+	}{
+		{&deployment.AppDeploymentSpec{ContainerImage: "nginx:1.17"}, true},
+		{&deployment.AppDeploymentSpec{ContainerImage: "nginx:-1"}, false},
+		{&deployment.AppDeploymentSpec{ContainerImage: "nginx",
+			InitContainers: []deployment.InitContainer{{Name: "init", ContainerImage: "Busybox"}}}, false},
+	}
+
+	for _, c := range cases {
+		err := ValidateAppDeploymentImages(c.spec)
+		if (err == nil) != c.expected {
+			t.Errorf("Expected %#v images to be valid: %t, but got error %v", c.spec, c.expected, err)
+		}
+		if err != nil && !errors.IsBadRequest(err) {
+			t.Errorf("Expected bad request error, but got %#v", err)
+		}
+	}
+}
+
+func TestValidateAppDeploymentPorts(t *testing.T) {
+	cases := []struct {
+		spec     *deployment.AppDeploymentSpec
+		expected []string
+	}{
+		{
+			&deployment.AppDeploymentSpec{IsExternal: true, PortMappings: []deployment.PortMapping{
+				{Port: 80, TargetPort: 8080, Protocol: api.ProtocolTCP, NodePort: 30080},
+				{Port: 443, TargetPort: 8443, Protocol: api.ProtocolTCP},
+			}},
+			nil,
+		},
+		{
+			&deployment.AppDeploymentSpec{PortMappings: []deployment.PortMapping{
+				{Port: 80, TargetPort: 8080, Protocol: api.ProtocolTCP, NodePort: 30080},
+			}},
+			[]string{"portMappings[0].nodePort: Forbidden"},
+		},
+		{
+			&deployment.AppDeploymentSpec{IsExternal: true, PortMappings: []deployment.PortMapping{
+				{Port: 80, TargetPort: 8080, Protocol: api.ProtocolTCP, NodePort: 8080},
+				{Port: 81, TargetPort: 8081, Protocol: api.ProtocolTCP, NodePort: 30081},
+				{Port: 82, TargetPort: 8082, Protocol: api.ProtocolTCP, NodePort: 30081},
+			}},
+			[]string{"portMappings[0].nodePort: Invalid value: 8080: must be in 30000-32767 range",
+				"portMappings[2].nodePort: Duplicate value: 30081"},
+		},
+	}
+
+	for _, c := range cases {
+		err := ValidateAppDeploymentPorts(c.spec)
+		if (err == nil) != (len(c.expected) == 0) {
+			t.Errorf("Expected %#v ports to be valid: %t, but got error %v", c.spec, len(c.expected) == 0, err)
+			continue
+		}
+		if err != nil && !errors.IsBadRequest(err) {
+			t.Errorf("Expected bad request error, but got %#v", err)
+		}
+		for _, expected := range c.expected {
+			if !strings.Contains(err.Error(), expected) {
+				t.Errorf("Expected error %q to contain %q", err.Error(), expected)
+			}
+		}
+	}
+}
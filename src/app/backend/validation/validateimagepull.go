@@ -0,0 +1,314 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/docker/distribution/reference"
+	api "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	client "k8s.io/client-go/kubernetes"
+)
+
+const (
+	dockerHubDomain   = "docker.io"
+	dockerHubRegistry = "registry-1.docker.io"
+)
+
+// manifestMediaTypes are accepted by the manifest request, so registries do not reject it because of
+// unsupported schema.
+var manifestMediaTypes = []string{
+	"application/vnd.docker.distribution.manifest.v2+json",
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+	"application/vnd.oci.image.manifest.v1+json",
+	"application/vnd.oci.image.index.v1+json",
+	"application/vnd.docker.distribution.manifest.v1+prettyjws",
+}
+
+// ImagePullValiditySpec is a specification of an image pull validation request.
+type ImagePullValiditySpec struct {
+	// Reference of the image.
+	Reference string `json:"reference"`
+
+	// Namespace of the image pull secret.
+	Namespace string `json:"namespace"`
+
+	// Optional name of an existing image pull secret with registry credentials.
+	ImagePullSecret *string `json:"imagePullSecret"`
+
+	// Optional registry credentials. They take precedence over the image pull secret.
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// ImagePullValidity describes whether the image can be pulled from its registry.
+type ImagePullValidity struct {
+	// True when the image manifest is accessible.
+	Valid bool `json:"valid"`
+
+	// Reason why the image can not be pulled.
+	Reason string `json:"reason"`
+}
+
+type registryCredentials struct {
+	username string
+	password string
+}
+
+// ValidateImagePull checks that the manifest of the image is accessible with the given credentials, by sending
+// a HEAD request to the registry API. When error is returned, validity could not be determined.
+func ValidateImagePull(spec *ImagePullValiditySpec, client client.Interface, httpClient *http.Client) (
+	*ImagePullValidity, error) {
+	log.Printf("Validating pull of %s image", spec.Reference)
+
+	named, err := reference.ParseNormalizedNamed(spec.Reference)
+	if err != nil {
+		return &ImagePullValidity{Valid: false, Reason: err.Error()}, nil
+	}
+	named = reference.TagNameOnly(named)
+
+	domain := reference.Domain(named)
+	credentials := &registryCredentials{username: spec.Username, password: spec.Password}
+	if len(spec.Username) == 0 && spec.ImagePullSecret != nil {
+		credentials, err = getRegistryCredentials(client, spec.Namespace, *spec.ImagePullSecret, domain)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	manifestURL := getManifestURL(named)
+	resp, err := doManifestRequest(httpClient, manifestURL, "")
+	if err != nil {
+		return &ImagePullValidity{Valid: false, Reason: err.Error()}, nil
+	}
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		authorization, err := authorize(httpClient, resp.Header.Get("WWW-Authenticate"),
+			reference.Path(named), credentials)
+		if err != nil {
+			return &ImagePullValidity{Valid: false, Reason: err.Error()}, nil
+		}
+
+		if resp, err = doManifestRequest(httpClient, manifestURL, authorization); err != nil {
+			return &ImagePullValidity{Valid: false, Reason: err.Error()}, nil
+		}
+	}
+
+	log.Printf("Manifest of %s image returned %s", spec.Reference, resp.Status)
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return &ImagePullValidity{Valid: true}, nil
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return &ImagePullValidity{Valid: false, Reason: "access to the image was denied by the registry"}, nil
+	case http.StatusNotFound:
+		return &ImagePullValidity{Valid: false, Reason: "image was not found in the registry"}, nil
+	}
+
+	return &ImagePullValidity{Valid: false, Reason: fmt.Sprintf("registry returned %s", resp.Status)}, nil
+}
+
+func getManifestURL(named reference.Named) string {
+	host := reference.Domain(named)
+	if host == dockerHubDomain {
+		host = dockerHubRegistry
+	}
+
+	manifest := ""
+	if digested, ok := named.(reference.Digested); ok {
+		manifest = digested.Digest().String()
+	} else if tagged, ok := named.(reference.Tagged); ok {
+		manifest = tagged.Tag()
+	}
+
+	return fmt.Sprintf("https://%s/v2/%s/manifests/%s", host, reference.Path(named), manifest)
+}
+
+func doManifestRequest(httpClient *http.Client, manifestURL, authorization string) (*http.Response, error) {
+	req, err := http.NewRequest(http.MethodHead, manifestURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", strings.Join(manifestMediaTypes, ", "))
+	if len(authorization) > 0 {
+		req.Header.Set("Authorization", authorization)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+	return resp, nil
+}
+
+// authorize returns value of the Authorization header based on the challenge returned by the registry. Bearer
+// challenges are exchanged for a pull token at the token service of the registry.
+func authorize(httpClient *http.Client, challenge, repository string, credentials *registryCredentials) (
+	string, error) {
+	scheme, params := parseChallenge(challenge)
+	switch scheme {
+	case "basic":
+		if len(credentials.username) == 0 {
+			return "", fmt.Errorf("registry requires credentials")
+		}
+		return "Basic " + basicAuth(credentials), nil
+	case "bearer":
+		return requestToken(httpClient, params, repository, credentials)
+	}
+
+	return "", fmt.Errorf("unsupported registry authentication challenge: %q", challenge)
+}
+
+func requestToken(httpClient *http.Client, params map[string]string, repository string,
+	credentials *registryCredentials) (string, error) {
+	realm, err := url.Parse(params["realm"])
+	if err != nil || len(realm.Host) == 0 {
+		return "", fmt.Errorf("invalid token realm: %q", params["realm"])
+	}
+	// Credentials are sent to the realm, so they must not travel in plain text.
+	if realm.Scheme != "https" {
+		return "", fmt.Errorf("token realm must use https: %q", params["realm"])
+	}
+
+	query := realm.Query()
+	if service, ok := params["service"]; ok {
+		query.Set("service", service)
+	}
+	query.Set("scope", fmt.Sprintf("repository:%s:pull", repository))
+	realm.RawQuery = query.Encode()
+
+	req, err := http.NewRequest(http.MethodGet, realm.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	if len(credentials.username) > 0 {
+		req.SetBasicAuth(credentials.username, credentials.password)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("registry token service returned %s", resp.Status)
+	}
+
+	token := struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}{}
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return "", err
+	}
+	if len(token.Token) == 0 {
+		token.Token = token.AccessToken
+	}
+
+	return "Bearer " + token.Token, nil
+}
+
+// parseChallenge parses WWW-Authenticate header, i.e. Bearer realm="https://auth.docker.io/token",service="x".
+func parseChallenge(challenge string) (string, map[string]string) {
+	params := map[string]string{}
+	parts := strings.SplitN(strings.TrimSpace(challenge), " ", 2)
+	if len(parts) < 2 {
+		return strings.ToLower(parts[0]), params
+	}
+
+	for _, param := range strings.Split(parts[1], ",") {
+		keyValue := strings.SplitN(strings.TrimSpace(param), "=", 2)
+		if len(keyValue) == 2 {
+			params[strings.ToLower(keyValue[0])] = strings.Trim(keyValue[1], `"`)
+		}
+	}
+
+	return strings.ToLower(parts[0]), params
+}
+
+func basicAuth(credentials *registryCredentials) string {
+	return base64.StdEncoding.EncodeToString([]byte(credentials.username + ":" + credentials.password))
+}
+
+// getRegistryCredentials reads credentials of the given registry from dockercfg or dockerconfigjson secret.
+func getRegistryCredentials(client client.Interface, namespace, name, domain string) (*registryCredentials,
+	error) {
+	secret, err := client.CoreV1().Secrets(namespace).Get(name, metaV1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	type authEntry struct {
+		Username string `json:"username"`
+		Password string `json:"password"`
+		Auth     string `json:"auth"`
+	}
+	auths := map[string]authEntry{}
+	switch secret.Type {
+	case api.SecretTypeDockerConfigJson:
+		config := struct {
+			Auths map[string]authEntry `json:"auths"`
+		}{}
+		err = json.Unmarshal(secret.Data[api.DockerConfigJsonKey], &config)
+		auths = config.Auths
+	case api.SecretTypeDockercfg:
+		err = json.Unmarshal(secret.Data[api.DockerConfigKey], &auths)
+	default:
+		return nil, fmt.Errorf("secret %s is not an image pull secret", name)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for registry, entry := range auths {
+		if normalizeRegistry(registry) != normalizeRegistry(domain) {
+			continue
+		}
+
+		if len(entry.Username) == 0 && len(entry.Auth) > 0 {
+			decoded, err := base64.StdEncoding.DecodeString(entry.Auth)
+			if err != nil {
+				return nil, err
+			}
+			userPass := strings.SplitN(string(decoded), ":", 2)
+			if len(userPass) == 2 {
+				entry.Username, entry.Password = userPass[0], userPass[1]
+			}
+		}
+		return &registryCredentials{username: entry.Username, password: entry.Password}, nil
+	}
+
+	return &registryCredentials{}, nil
+}
+
+// normalizeRegistry strips scheme and path from the registry address and unifies Docker Hub aliases.
+func normalizeRegistry(registry string) string {
+	registry = strings.TrimPrefix(strings.TrimPrefix(registry, "https://"), "http://")
+	registry = strings.SplitN(registry, "/", 2)[0]
+	switch registry {
+	case "index.docker.io", dockerHubRegistry:
+		return dockerHubDomain
+	}
+	return registry
+}
@@ -0,0 +1,93 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	api "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestValidateImagePull(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/token":
+			username, password, ok := r.BasicAuth()
+			if !ok || username != "user" || password != "pass" ||
+				r.URL.Query().Get("scope") != "repository:private/app:pull" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			fmt.Fprint(w, `{"token":"secret-token"}`)
+		case r.Method != http.MethodHead:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		case r.URL.Path == "/v2/public/app/manifests/latest":
+			w.WriteHeader(http.StatusOK)
+		case strings.HasPrefix(r.URL.Path, "/v2/private/app/manifests/"):
+			if r.Header.Get("Authorization") != "Bearer secret-token" {
+				w.Header().Set("WWW-Authenticate",
+					fmt.Sprintf(`Bearer realm="%s/token",service="registry"`, server.URL))
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	host := strings.TrimPrefix(server.URL, "https://")
+	secretName := "registry"
+	secret := &api.Secret{
+		ObjectMeta: metaV1.ObjectMeta{Name: secretName, Namespace: "default"},
+		Type:       api.SecretTypeDockerConfigJson,
+		Data: map[string][]byte{api.DockerConfigJsonKey: []byte(
+			fmt.Sprintf(`{"auths":{"https://%s":{"auth":"dXNlcjpwYXNz"}}}`, host))},
+	}
+
+	cases := []struct {
+		spec     *ImagePullValiditySpec
+		expected bool
+	}{
+		{&ImagePullValiditySpec{Reference: host + "/public/app"}, true},
+		{&ImagePullValiditySpec{Reference: host + "/missing/app:v1"}, false},
+		{&ImagePullValiditySpec{Reference: host + "/private/app:v1"}, false},
+		{&ImagePullValiditySpec{Reference: host + "/private/app:v1", Username: "user", Password: "wrong"}, false},
+		{&ImagePullValiditySpec{Reference: host + "/private/app:v1", Username: "user", Password: "pass"}, true},
+		{&ImagePullValiditySpec{Reference: host + "/private/app:v1", Namespace: "default",
+			ImagePullSecret: &secretName}, true},
+		{&ImagePullValiditySpec{Reference: "Invalid:Reference"}, false},
+	}
+
+	for _, c := range cases {
+		validity, err := ValidateImagePull(c.spec, fake.NewSimpleClientset(secret), server.Client())
+		if err != nil {
+			t.Errorf("ValidateImagePull(%s) returned unexpected error: %s", c.spec.Reference, err)
+			continue
+		}
+
+		if validity.Valid != c.expected {
+			t.Errorf("Expected %s pull validity to be %t, but was %#v", c.spec.Reference, c.expected, validity)
+		}
+	}
+}
@@ -15,9 +15,19 @@
 package validation
 
 import (
+	"fmt"
+	"regexp"
+	"strings"
+
 	"github.com/docker/distribution/reference"
 )
 
+var (
+	anchoredTagRegexp    = regexp.MustCompile("^" + reference.TagRegexp.String() + "$")
+	anchoredDigestRegexp = regexp.MustCompile("^" + reference.DigestRegexp.String() + "$")
+	anchoredDomainRegexp = regexp.MustCompile("^" + reference.DomainRegexp.String() + "$")
+)
+
 // ImageReferenceValiditySpec is a specification of an image reference validation request.
 type ImageReferenceValiditySpec struct {
 	// Reference of the image
@@ -30,14 +40,80 @@ type ImageReferenceValidity struct {
 	Valid bool `json:"valid"`
 	// Error reason when image reference is valid
 	Reason string `json:"reason"`
+
+	// Parts of the valid reference. Registry and repository are normalized, i.e. nginx is docker.io/library/nginx.
+	Registry   string `json:"registry,omitempty"`
+	Repository string `json:"repository,omitempty"`
+	Tag        string `json:"tag,omitempty"`
+	Digest     string `json:"digest,omitempty"`
 }
 
-// ValidateImageReference validates image reference.
+// ValidateImageReference validates image reference using the docker reference grammar, i.e.
+// registry:port/repository:tag@digest. Reason of invalid reference points to the malformed part.
 func ValidateImageReference(spec *ImageReferenceValiditySpec) (*ImageReferenceValidity, error) {
 	s := spec.Reference
 	_, err := reference.Parse(s)
 	if err != nil {
-		return &ImageReferenceValidity{Valid: false, Reason: err.Error()}, nil
+		return &ImageReferenceValidity{Valid: false, Reason: describeReferenceError(s, err)}, nil
 	}
-	return &ImageReferenceValidity{Valid: true}, nil
+
+	validity := &ImageReferenceValidity{Valid: true}
+	named, err := reference.ParseNormalizedNamed(s)
+	if err != nil {
+		return validity, nil
+	}
+
+	validity.Registry = reference.Domain(named)
+	validity.Repository = reference.Path(named)
+	if tagged, ok := named.(reference.Tagged); ok {
+		validity.Tag = tagged.Tag()
+	}
+	if digested, ok := named.(reference.Digested); ok {
+		validity.Digest = digested.Digest().String()
+	}
+	return validity, nil
+}
+
+// describeReferenceError replaces generic invalid format error with a description of the malformed part of
+// the reference. Other errors are already precise.
+func describeReferenceError(s string, err error) string {
+	if len(s) == 0 {
+		return "image reference must not be empty"
+	}
+	if err != reference.ErrReferenceInvalidFormat {
+		return err.Error()
+	}
+
+	name := s
+	if i := strings.Index(name, "@"); i >= 0 {
+		digest := name[i+1:]
+		name = name[:i]
+		if !anchoredDigestRegexp.MatchString(digest) {
+			return fmt.Sprintf("invalid digest %q: digest must have algorithm:hex format, i.e. sha256 "+
+				"followed by 64 hexadecimal characters", digest)
+		}
+	}
+
+	if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		tag := name[i+1:]
+		name = name[:i]
+		if !anchoredTagRegexp.MatchString(tag) {
+			return fmt.Sprintf("invalid tag %q: tag may contain up to 128 letters, digits, underscores, periods "+
+				"and dashes and must not start with a period or a dash", tag)
+		}
+	}
+
+	if i := strings.Index(name, "/"); i >= 0 {
+		domain := name[:i]
+		if strings.ContainsAny(domain, ".:") || domain == "localhost" {
+			if !anchoredDomainRegexp.MatchString(domain) {
+				return fmt.Sprintf("invalid registry %q: registry must be a host name with optional numeric "+
+					"port", domain)
+			}
+			name = name[i+1:]
+		}
+	}
+
+	return fmt.Sprintf("invalid repository name %q: path components may contain only lowercase letters, "+
+		"digits and separators and must start and end with a letter or digit", name)
 }
@@ -14,7 +14,10 @@
 
 package validation
 
-import "testing"
+import (
+	"strings"
+	"testing"
+)
 
 func TestValidateImageReference(t *testing.T) {
 	cases := []struct {
@@ -98,3 +101,53 @@ func TestValidateImageReference(t *testing.T) {
 		}
 	}
 }
+
+func TestValidateImageReferenceParts(t *testing.T) {
+	cases := []struct {
+		reference string
+		expected  ImageReferenceValidity
+	}{
+		{
+			"nginx",
+			ImageReferenceValidity{Valid: true, Registry: "docker.io", Repository: "library/nginx"},
+		},
+		{
+			"private.registry:5000/namespace/test:1",
+			ImageReferenceValidity{Valid: true, Registry: "private.registry:5000", Repository: "namespace/test",
+				Tag: "1"},
+		},
+		{
+			"test:1@sha256:ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+			ImageReferenceValidity{Valid: true, Registry: "docker.io", Repository: "library/test", Tag: "1",
+				Digest: "sha256:ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"},
+		},
+	}
+
+	for _, c := range cases {
+		validity, _ := ValidateImageReference(&ImageReferenceValiditySpec{Reference: c.reference})
+		if *validity != c.expected {
+			t.Errorf("Expected %#v validity to be %#v, but was %#v\n", c.reference, c.expected, *validity)
+		}
+	}
+}
+
+func TestValidateImageReferenceReason(t *testing.T) {
+	cases := []struct {
+		reference string
+		expected  string
+	}{
+		{"", "must not be empty"},
+		{"test:-1", "invalid tag \"-1\""},
+		{"private.registry:port/namespace/test:1", "invalid registry \"private.registry:port\""},
+		{"test@sha256:fff", "invalid digest \"sha256:fff\""},
+		{"private.registry:5000/name_/test", "invalid repository name \"name_/test\""},
+		{"Test", "repository name must be lowercase"},
+	}
+
+	for _, c := range cases {
+		validity, _ := ValidateImageReference(&ImageReferenceValiditySpec{Reference: c.reference})
+		if validity.Valid || !strings.Contains(validity.Reason, c.expected) {
+			t.Errorf("Expected %#v reason to contain %#v, but was %#v\n", c.reference, c.expected, validity.Reason)
+		}
+	}
+}
@@ -0,0 +1,158 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	api "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	client "k8s.io/client-go/kubernetes"
+
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+)
+
+// localProviderIDPrefixes are provider ID prefixes of nodes of local clusters, that have no cloud provider which
+// would provision load balancers.
+var localProviderIDPrefixes = []string{"kind://"}
+
+// loadBalancerCacheTTL is how long the result of the cluster-wide service lookup is reused.
+const loadBalancerCacheTTL = 5 * time.Minute
+
+// provisionedLoadBalancers caches whether any LoadBalancer service of the cluster has an ingress address.
+var provisionedLoadBalancers = &loadBalancerCache{}
+
+// loadBalancerCache caches the result of listing all services of the cluster, so that it is not done on every
+// validation of a spec with external ports. Services can not be filtered by type with a field selector.
+type loadBalancerCache struct {
+	mu          sync.Mutex
+	provisioned bool
+	expires     time.Time
+}
+
+func (self *loadBalancerCache) get() (provisioned bool, ok bool) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+	if time.Now().After(self.expires) {
+		return false, false
+	}
+	return self.provisioned, true
+}
+
+func (self *loadBalancerCache) set(provisioned bool) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+	self.provisioned = provisioned
+	self.expires = time.Now().Add(loadBalancerCacheTTL)
+}
+
+// LoadBalancerSupport describes whether the cluster is able to provision external load balancers.
+type LoadBalancerSupport struct {
+	// False when the cluster most likely does not provision load balancers, i.e. because there is no cloud
+	// provider. True when support could not be determined.
+	Supported bool `json:"supported"`
+
+	// Human readable warning shown when load balancers are not supported.
+	Warning string `json:"warning,omitempty"`
+
+	// Service type suggested instead of LoadBalancer when load balancers are not supported.
+	Fallback api.ServiceType `json:"fallback,omitempty"`
+}
+
+// CheckLoadBalancerSupport checks whether external services of LoadBalancer type get an external address in the
+// cluster. It is assumed that they do when any LoadBalancer service already has an ingress address or when any
+// node is managed by a cloud provider. Lack of permissions to check is treated as support. When error is
+// returned, support could not be determined.
+func CheckLoadBalancerSupport(client client.Interface) (*LoadBalancerSupport, error) {
+	supported, err := hasProvisionedLoadBalancer(client)
+	if err != nil {
+		return nil, err
+	}
+
+	if !supported {
+		if supported, err = hasCloudProvider(client); err != nil {
+			return nil, err
+		}
+	}
+
+	log.Printf("Load balancer support of the cluster is %t", supported)
+	if supported {
+		return &LoadBalancerSupport{Supported: true}, nil
+	}
+
+	return &LoadBalancerSupport{
+		Warning: "cluster has no cloud provider, external address of LoadBalancer service may never be " +
+			"assigned; NodePort service can be used instead",
+		Fallback: api.ServiceTypeNodePort,
+	}, nil
+}
+
+// hasProvisionedLoadBalancer checks whether any LoadBalancer service of the cluster has an ingress address. The
+// result is cached, unless the user is not allowed to list services.
+func hasProvisionedLoadBalancer(client client.Interface) (bool, error) {
+	if provisioned, ok := provisionedLoadBalancers.get(); ok {
+		return provisioned, nil
+	}
+
+	services, err := client.CoreV1().Services(api.NamespaceAll).List(metaV1.ListOptions{})
+	if err != nil {
+		if errors.IsForbiddenError(err) {
+			return true, nil
+		}
+		return false, err
+	}
+
+	provisioned := false
+	for _, service := range services.Items {
+		if service.Spec.Type == api.ServiceTypeLoadBalancer && len(service.Status.LoadBalancer.Ingress) > 0 {
+			provisioned = true
+			break
+		}
+	}
+
+	provisionedLoadBalancers.set(provisioned)
+	return provisioned, nil
+}
+
+func hasCloudProvider(client client.Interface) (bool, error) {
+	nodes, err := client.CoreV1().Nodes().List(metaV1.ListOptions{})
+	if err != nil {
+		if errors.IsForbiddenError(err) {
+			return true, nil
+		}
+		return false, err
+	}
+
+	for _, node := range nodes.Items {
+		if len(node.Spec.ProviderID) > 0 && !isLocalProviderID(node.Spec.ProviderID) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func isLocalProviderID(providerID string) bool {
+	for _, prefix := range localProviderIDPrefixes {
+		if strings.HasPrefix(providerID, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
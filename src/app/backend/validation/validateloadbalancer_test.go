@@ -0,0 +1,85 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"testing"
+
+	api "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestCheckLoadBalancerSupport(t *testing.T) {
+	cases := []struct {
+		objects  []runtime.Object
+		expected bool
+	}{
+		{nil, false},
+		{
+			[]runtime.Object{&api.Node{ObjectMeta: metaV1.ObjectMeta{Name: "node"},
+				Spec: api.NodeSpec{ProviderID: "kind://docker/kind/kind-control-plane"}}},
+			false,
+		},
+		{
+			[]runtime.Object{&api.Node{ObjectMeta: metaV1.ObjectMeta{Name: "node"},
+				Spec: api.NodeSpec{ProviderID: "gce://project/zone/node"}}},
+			true,
+		},
+		{
+			[]runtime.Object{&api.Service{ObjectMeta: metaV1.ObjectMeta{Name: "lb", Namespace: "default"},
+				Spec: api.ServiceSpec{Type: api.ServiceTypeLoadBalancer},
+				Status: api.ServiceStatus{LoadBalancer: api.LoadBalancerStatus{
+					Ingress: []api.LoadBalancerIngress{{IP: "10.0.0.1"}}}}}},
+			true,
+		},
+	}
+
+	for _, c := range cases {
+		provisionedLoadBalancers = &loadBalancerCache{}
+		support, err := CheckLoadBalancerSupport(fake.NewSimpleClientset(c.objects...))
+		if err != nil {
+			t.Errorf("CheckLoadBalancerSupport() returned unexpected error: %s", err)
+			continue
+		}
+		if support.Supported != c.expected {
+			t.Errorf("CheckLoadBalancerSupport() == %#v for objects %#v, expected support %t", support,
+				c.objects, c.expected)
+		}
+		if !support.Supported && support.Fallback != api.ServiceTypeNodePort {
+			t.Errorf("CheckLoadBalancerSupport() should suggest NodePort fallback, got %#v", support)
+		}
+	}
+}
+
+func TestHasProvisionedLoadBalancerCache(t *testing.T) {
+	provisionedLoadBalancers = &loadBalancerCache{}
+	client := fake.NewSimpleClientset(&api.Service{ObjectMeta: metaV1.ObjectMeta{Name: "lb", Namespace: "default"},
+		Spec: api.ServiceSpec{Type: api.ServiceTypeLoadBalancer},
+		Status: api.ServiceStatus{LoadBalancer: api.LoadBalancerStatus{
+			Ingress: []api.LoadBalancerIngress{{IP: "10.0.0.1"}}}}})
+
+	for i := 0; i < 2; i++ {
+		provisioned, err := hasProvisionedLoadBalancer(client)
+		if err != nil || !provisioned {
+			t.Fatalf("hasProvisionedLoadBalancer() == %t, %v, expected true", provisioned, err)
+		}
+	}
+
+	if actions := len(client.Actions()); actions != 1 {
+		t.Errorf("Services should be listed once and then cached, got %d actions", actions)
+	}
+}
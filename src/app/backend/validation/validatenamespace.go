@@ -0,0 +1,87 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"fmt"
+	"log"
+
+	api "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	client "k8s.io/client-go/kubernetes"
+
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/deployment"
+	ns "github.com/kubernetes/dashboard/src/app/backend/resource/namespace"
+)
+
+// NamespaceValiditySpec is a specification of namespace validation request.
+type NamespaceValiditySpec struct {
+	// Name of the namespace.
+	Name string `json:"name"`
+}
+
+// NamespaceValidity describes validity of the namespace.
+type NamespaceValidity struct {
+	// True when the namespace exists and is not being deleted or when user is not allowed to check it.
+	Valid bool `json:"valid"`
+
+	// True when the namespace is being deleted. New objects cannot be created in such namespace.
+	Terminating bool `json:"terminating"`
+}
+
+// ValidateNamespace validates that namespace exists and is not being deleted. When error is returned, namespace
+// validity could not be determined.
+func ValidateNamespace(spec *NamespaceValiditySpec, client client.Interface) (*NamespaceValidity, error) {
+	log.Printf("Validating existence of %s namespace", spec.Name)
+
+	validity := &NamespaceValidity{Valid: true}
+	namespace, err := client.CoreV1().Namespaces().Get(spec.Name, metaV1.GetOptions{})
+	if err != nil {
+		switch {
+		case errors.IsNotFoundError(err):
+			validity.Valid = false
+		case !errors.IsForbiddenError(err):
+			return nil, err
+		}
+	} else if namespace.Status.Phase == api.NamespaceTerminating {
+		validity.Valid = false
+		validity.Terminating = true
+	}
+
+	log.Printf("Validation result for %s namespace is %t", spec.Name, validity.Valid)
+	return validity, nil
+}
+
+// EnsureAppDeploymentNamespace checks that objects of the app can be created in its target namespace. Namespace
+// that does not exist is created when requested by the spec, provided that user is allowed to create it. Bad
+// request error is returned when the namespace does not exist or is being deleted.
+func EnsureAppDeploymentNamespace(spec *deployment.AppDeploymentSpec, client client.Interface) error {
+	validity, err := ValidateNamespace(&NamespaceValiditySpec{Name: spec.Namespace}, client)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case validity.Valid:
+		return nil
+	case validity.Terminating:
+		return errors.NewBadRequest(fmt.Sprintf("namespace %q is being deleted", spec.Namespace))
+	case !spec.CreateNamespace:
+		return errors.NewBadRequest(fmt.Sprintf("namespace %q does not exist", spec.Namespace))
+	}
+
+	return ns.CreateNamespace(&ns.NamespaceSpec{Name: spec.Namespace}, client)
+}
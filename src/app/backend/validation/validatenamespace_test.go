@@ -0,0 +1,102 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"testing"
+
+	api "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/kubernetes/dashboard/src/app/backend/resource/deployment"
+)
+
+func TestValidateNamespace(t *testing.T) {
+	cases := []struct {
+		spec     *NamespaceValiditySpec
+		objects  []runtime.Object
+		expected bool
+	}{
+		{
+			&NamespaceValiditySpec{Name: "foo"},
+			nil,
+			false,
+		},
+		{
+			&NamespaceValiditySpec{Name: "foo"},
+			[]runtime.Object{&api.Namespace{ObjectMeta: metaV1.ObjectMeta{Name: "foo"}}},
+			true,
+		},
+		{
+			&NamespaceValiditySpec{Name: "foo"},
+			[]runtime.Object{&api.Namespace{ObjectMeta: metaV1.ObjectMeta{Name: "foo"},
+				Status: api.NamespaceStatus{Phase: api.NamespaceTerminating}}},
+			false,
+		},
+	}
+
+	for _, c := range cases {
+		validity, err := ValidateNamespace(c.spec, fake.NewSimpleClientset(c.objects...))
+		if err != nil {
+			t.Errorf("ValidateNamespace(%#v) returned unexpected error: %s", c.spec, err)
+			continue
+		}
+
+		if validity.Valid != c.expected {
+			t.Errorf("Expected %#v validity to be %#v for objects %#v, but was %#v\n",
+				c.spec, c.expected, c.objects, validity)
+		}
+	}
+}
+
+func TestEnsureAppDeploymentNamespace(t *testing.T) {
+	terminating := &api.Namespace{ObjectMeta: metaV1.ObjectMeta{Name: "foo"},
+		Status: api.NamespaceStatus{Phase: api.NamespaceTerminating}}
+
+	cases := []struct {
+		spec       *deployment.AppDeploymentSpec
+		objects    []runtime.Object
+		badRequest bool
+		created    bool
+	}{
+		{&deployment.AppDeploymentSpec{Namespace: "foo"}, nil, true, false},
+		{&deployment.AppDeploymentSpec{Namespace: "foo", CreateNamespace: true}, nil, false, true},
+		{&deployment.AppDeploymentSpec{Namespace: "foo", CreateNamespace: true}, []runtime.Object{terminating},
+			true, false},
+		{&deployment.AppDeploymentSpec{Namespace: "foo"},
+			[]runtime.Object{&api.Namespace{ObjectMeta: metaV1.ObjectMeta{Name: "foo"}}}, false, false},
+	}
+
+	for _, c := range cases {
+		client := fake.NewSimpleClientset(c.objects...)
+		err := EnsureAppDeploymentNamespace(c.spec, client)
+		if k8serrors.IsBadRequest(err) != c.badRequest || (!c.badRequest && err != nil) {
+			t.Errorf("EnsureAppDeploymentNamespace(%#v) returned %v, expected bad request %t", c.spec, err,
+				c.badRequest)
+		}
+
+		created := false
+		for _, action := range client.Actions() {
+			created = created || action.GetVerb() == "create"
+		}
+		if created != c.created {
+			t.Errorf("EnsureAppDeploymentNamespace(%#v) created namespace %t, expected %t", c.spec, created,
+				c.created)
+		}
+	}
+}
@@ -0,0 +1,274 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	api "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	client "k8s.io/client-go/kubernetes"
+
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/deployment"
+)
+
+// QuotaUsage describes projected usage of a single resource tracked by a resource quota of the namespace, as if
+// the app was deployed.
+type QuotaUsage struct {
+	// Name of the resource quota.
+	Quota string `json:"quota"`
+
+	// Tracked resource, i.e. requests.cpu or count/deployments.apps.
+	Resource api.ResourceName `json:"resource"`
+
+	// Hard limit of the resource quota.
+	Hard resource.Quantity `json:"hard"`
+
+	// Amount that is already used in the namespace.
+	Used resource.Quantity `json:"used"`
+
+	// Amount that the app would consume.
+	Requested resource.Quantity `json:"requested"`
+
+	// Used and requested amount together.
+	Projected resource.Quantity `json:"projected"`
+
+	// Amount by which projected usage exceeds the hard limit. Zero when the app fits into the quota.
+	Overage resource.Quantity `json:"overage"`
+
+	// True when the quota requires the resource to be specified for the app container, but it is not. Pods of
+	// the app would be rejected regardless of the remaining amount.
+	Unspecified bool `json:"unspecified"`
+}
+
+// QuotaCheck describes whether the app fits into resource quotas of the namespace.
+type QuotaCheck struct {
+	// True when the app fits into all of the resource quotas.
+	Fits bool `json:"fits"`
+
+	// Projected usage of all of the resources that are tracked by the quotas and consumed by the app.
+	Usages []QuotaUsage `json:"usages"`
+}
+
+// CheckAppDeploymentQuotas compares resources that the app would consume with remaining resource quotas of its
+// namespace, so the projected overage can be reported before anything is created. Deployment itself is not
+// rejected by the quota when only its pods exceed it, they would just never be created. Missing requests and
+// limits are defaulted from limit ranges. Quotas and limit ranges that can not be read are skipped.
+func CheckAppDeploymentQuotas(spec *deployment.AppDeploymentSpec, client client.Interface) (*QuotaCheck, error) {
+	result := &QuotaCheck{Fits: true, Usages: make([]QuotaUsage, 0)}
+
+	quotas, err := client.CoreV1().ResourceQuotas(spec.Namespace).List(metaV1.ListOptions{})
+	if err != nil {
+		if errors.IsForbiddenError(err) {
+			return result, nil
+		}
+		return nil, err
+	}
+	if len(quotas.Items) == 0 {
+		return result, nil
+	}
+
+	resources := getContainerResources(spec)
+	limitRanges, err := client.CoreV1().LimitRanges(spec.Namespace).List(metaV1.ListOptions{})
+	if err != nil && !errors.IsForbiddenError(err) {
+		return nil, err
+	}
+	for i := range resources {
+		if limitRanges != nil {
+			validateLimitRanges(&resources[i], limitRanges.Items)
+		}
+		if resources[i].request == nil {
+			resources[i].request = resources[i].limit
+		}
+	}
+
+	requested, podResources, bestEffort := getAppDeploymentConsumption(spec, resources)
+	for _, quota := range quotas.Items {
+		if !quotaMatchesPods(quota, bestEffort) {
+			continue
+		}
+
+		hard := quota.Status.Hard
+		if len(hard) == 0 {
+			hard = quota.Spec.Hard
+		}
+		for _, name := range sortedResourceNames(hard) {
+			usage, ok := getQuotaUsage(quota, name, hard[name], requested, podResources)
+			if !ok {
+				continue
+			}
+			if usage.Unspecified || !usage.Overage.IsZero() {
+				result.Fits = false
+			}
+			result.Usages = append(result.Usages, usage)
+		}
+	}
+
+	return result, nil
+}
+
+// ValidateAppDeploymentQuotas returns forbidden error describing the projected overage, when the app does not
+// fit into resource quotas of its namespace.
+func ValidateAppDeploymentQuotas(spec *deployment.AppDeploymentSpec, client client.Interface) error {
+	check, err := CheckAppDeploymentQuotas(spec, client)
+	if err != nil || check.Fits {
+		return err
+	}
+
+	reasons := make([]string, 0)
+	for _, usage := range check.Usages {
+		switch {
+		case usage.Unspecified:
+			reasons = append(reasons, fmt.Sprintf("%s resource quota requires %s to be specified", usage.Quota,
+				usage.Resource))
+		case !usage.Overage.IsZero():
+			reasons = append(reasons, fmt.Sprintf("%s of %s resource quota would be exceeded by %s: hard %s, "+
+				"used %s, requested %s", usage.Resource, usage.Quota, usage.Overage.String(), usage.Hard.String(),
+				usage.Used.String(), usage.Requested.String()))
+		}
+	}
+	return errors.NewForbidden(fmt.Sprintf("app %s does not fit into resource quotas of %s namespace: %s",
+		spec.Name, spec.Namespace, strings.Join(reasons, "; ")))
+}
+
+// getAppDeploymentConsumption returns amounts of quota resources that would be consumed by the app and set of
+// the resources that are consumed by its pods. Resources that the container does not specify are missing from
+// the amounts, so the quotas that require them can be recognized.
+func getAppDeploymentConsumption(spec *deployment.AppDeploymentSpec, resources []containerResource) (
+	api.ResourceList, map[api.ResourceName]bool, bool) {
+	replicas := int64(spec.Replicas)
+	requested := api.ResourceList{
+		api.ResourcePods:         *resource.NewQuantity(replicas, resource.DecimalSI),
+		"count/pods":             *resource.NewQuantity(replicas, resource.DecimalSI),
+		"count/deployments.apps": *resource.NewQuantity(1, resource.DecimalSI),
+		"count/replicasets.apps": *resource.NewQuantity(1, resource.DecimalSI),
+	}
+	podResources := map[api.ResourceName]bool{api.ResourcePods: true, "count/pods": true}
+
+	bestEffort := true
+	for _, res := range resources {
+		requestNames := []api.ResourceName{res.name, res.quotaRequest}
+		limitName := res.quotaLimit
+		podResources[limitName] = true
+		for _, name := range requestNames {
+			podResources[name] = true
+		}
+
+		if res.request != nil {
+			bestEffort = false
+			for _, name := range requestNames {
+				requested[name] = multiplyQuantity(*res.request, replicas)
+			}
+		}
+		if res.limit != nil {
+			bestEffort = false
+			requested[limitName] = multiplyQuantity(*res.limit, replicas)
+		}
+	}
+
+	if len(spec.PortMappings) > 0 {
+		requested[api.ResourceServices] = *resource.NewQuantity(1, resource.DecimalSI)
+		requested["count/services"] = *resource.NewQuantity(1, resource.DecimalSI)
+		if spec.IsExternal {
+			requested[api.ResourceServicesLoadBalancers] = *resource.NewQuantity(1, resource.DecimalSI)
+			requested[api.ResourceServicesNodePorts] = *resource.NewQuantity(int64(len(spec.PortMappings)),
+				resource.DecimalSI)
+		}
+	}
+
+	return requested, podResources, bestEffort
+}
+
+// getQuotaUsage returns projected usage of the quota resource. False is returned when the app does not consume
+// the resource.
+func getQuotaUsage(quota api.ResourceQuota, name api.ResourceName, hard resource.Quantity,
+	requested api.ResourceList, podResources map[api.ResourceName]bool) (QuotaUsage, bool) {
+	used := quota.Status.Used[name]
+	usage := QuotaUsage{Quota: quota.Name, Resource: name, Hard: hard, Used: used.DeepCopy()}
+
+	amount, ok := requested[name]
+	if !ok {
+		// Pods have to specify compute resources that are tracked by the quota.
+		pods := requested[api.ResourcePods]
+		usage.Unspecified = podResources[name] && !pods.IsZero()
+		return usage, usage.Unspecified
+	}
+	if amount.IsZero() {
+		return usage, false
+	}
+
+	usage.Requested = amount.DeepCopy()
+	usage.Projected = used.DeepCopy()
+	usage.Projected.Add(amount)
+	if usage.Projected.Cmp(hard) > 0 {
+		usage.Overage = usage.Projected.DeepCopy()
+		usage.Overage.Sub(hard)
+	}
+	return usage, true
+}
+
+// quotaMatchesPods returns true when scopes of the quota match pods of the app. Pods of deployments do not
+// have active deadline and the app does not set priority class.
+func quotaMatchesPods(quota api.ResourceQuota, bestEffort bool) bool {
+	matches := func(scope api.ResourceQuotaScope, operator api.ScopeSelectorOperator) bool {
+		switch scope {
+		case api.ResourceQuotaScopeBestEffort:
+			return bestEffort
+		case api.ResourceQuotaScopeNotBestEffort:
+			return !bestEffort
+		case api.ResourceQuotaScopeNotTerminating:
+			return true
+		case api.ResourceQuotaScopePriorityClass:
+			return operator == api.ScopeSelectorOpNotIn || operator == api.ScopeSelectorOpDoesNotExist
+		}
+		return false
+	}
+
+	for _, scope := range quota.Spec.Scopes {
+		if !matches(scope, api.ScopeSelectorOpExists) {
+			return false
+		}
+	}
+	if quota.Spec.ScopeSelector != nil {
+		for _, expression := range quota.Spec.ScopeSelector.MatchExpressions {
+			if !matches(expression.ScopeName, expression.Operator) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func multiplyQuantity(quantity resource.Quantity, n int64) resource.Quantity {
+	return *resource.NewMilliQuantity(quantity.MilliValue()*n, quantity.Format)
+}
+
+func sortedResourceNames(list api.ResourceList) []api.ResourceName {
+	names := make([]string, 0, len(list))
+	for name := range list {
+		names = append(names, string(name))
+	}
+	sort.Strings(names)
+
+	result := make([]api.ResourceName, 0, len(names))
+	for _, name := range names {
+		result = append(result, api.ResourceName(name))
+	}
+	return result
+}
@@ -0,0 +1,158 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	api "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/kubernetes/dashboard/src/app/backend/resource/deployment"
+)
+
+func TestCheckAppDeploymentQuotas(t *testing.T) {
+	limitRange := &api.LimitRange{
+		ObjectMeta: metaV1.ObjectMeta{Name: "limits", Namespace: "default"},
+		Spec: api.LimitRangeSpec{Limits: []api.LimitRangeItem{{
+			Type:           api.LimitTypeContainer,
+			DefaultRequest: api.ResourceList{api.ResourceCPU: resource.MustParse("200m")},
+		}}},
+	}
+	quota := &api.ResourceQuota{
+		ObjectMeta: metaV1.ObjectMeta{Name: "quota", Namespace: "default"},
+		Status: api.ResourceQuotaStatus{
+			Hard: api.ResourceList{
+				api.ResourceRequestsCPU:       resource.MustParse("1"),
+				api.ResourcePods:              resource.MustParse("10"),
+				api.ResourceServicesNodePorts: resource.MustParse("2"),
+				api.ResourceConfigMaps:        resource.MustParse("5"),
+			},
+			Used: api.ResourceList{
+				api.ResourceRequestsCPU:       resource.MustParse("500m"),
+				api.ResourcePods:              resource.MustParse("4"),
+				api.ResourceServicesNodePorts: resource.MustParse("1"),
+			},
+		},
+	}
+	bestEffortQuota := &api.ResourceQuota{
+		ObjectMeta: metaV1.ObjectMeta{Name: "best-effort", Namespace: "default"},
+		Spec:       api.ResourceQuotaSpec{Scopes: []api.ResourceQuotaScope{api.ResourceQuotaScopeBestEffort}},
+		Status: api.ResourceQuotaStatus{
+			Hard: api.ResourceList{api.ResourcePods: resource.MustParse("0")},
+		},
+	}
+
+	cases := []struct {
+		spec     *deployment.AppDeploymentSpec
+		objects  []runtime.Object
+		expected map[string]string
+		fits     bool
+	}{
+		{
+			&deployment.AppDeploymentSpec{Namespace: "default", Replicas: 2, CpuRequirement: quantity("200m")},
+			[]runtime.Object{quota, bestEffortQuota},
+			map[string]string{"quota/pods": "0", "quota/requests.cpu": "0"},
+			true,
+		},
+		{
+			&deployment.AppDeploymentSpec{Namespace: "default", Replicas: 3, IsExternal: true,
+				PortMappings: []deployment.PortMapping{{Port: 80}, {Port: 443}}},
+			[]runtime.Object{quota, limitRange},
+			map[string]string{"quota/pods": "0", "quota/requests.cpu": "100m", "quota/services.nodeports": "1"},
+			false,
+		},
+		{
+			&deployment.AppDeploymentSpec{Namespace: "default", Replicas: 1},
+			[]runtime.Object{quota, bestEffortQuota},
+			map[string]string{"quota/pods": "0", "quota/requests.cpu": "unspecified", "best-effort/pods": "1"},
+			false,
+		},
+		{
+			&deployment.AppDeploymentSpec{Namespace: "default", Replicas: 1},
+			nil,
+			map[string]string{},
+			true,
+		},
+	}
+
+	for _, c := range cases {
+		check, err := CheckAppDeploymentQuotas(c.spec, fake.NewSimpleClientset(c.objects...))
+		if err != nil {
+			t.Errorf("CheckAppDeploymentQuotas(%#v) returned unexpected error: %s", c.spec, err)
+			continue
+		}
+
+		actual := make(map[string]string)
+		for _, usage := range check.Usages {
+			overage := usage.Overage.String()
+			if usage.Unspecified {
+				overage = "unspecified"
+			}
+			actual[usage.Quota+"/"+string(usage.Resource)] = overage
+		}
+
+		if !reflect.DeepEqual(actual, c.expected) || check.Fits != c.fits {
+			t.Errorf("CheckAppDeploymentQuotas(%#v) == %#v, expected overages %#v and fits %t", c.spec, check,
+				c.expected, c.fits)
+		}
+	}
+}
+
+func TestValidateAppDeploymentQuotas(t *testing.T) {
+	quota := &api.ResourceQuota{
+		ObjectMeta: metaV1.ObjectMeta{Name: "quota", Namespace: "default"},
+		Status: api.ResourceQuotaStatus{
+			Hard: api.ResourceList{api.ResourcePods: resource.MustParse("2")},
+			Used: api.ResourceList{api.ResourcePods: resource.MustParse("1")},
+		},
+	}
+	spec := &deployment.AppDeploymentSpec{Name: "app", Namespace: "default", Replicas: 3}
+
+	err := ValidateAppDeploymentQuotas(spec, fake.NewSimpleClientset(quota))
+	if !errors.IsForbidden(err) {
+		t.Fatalf("Expected forbidden error, but got %#v", err)
+	}
+
+	expected := "pods of quota resource quota would be exceeded by 2: hard 2, used 1, requested 3"
+	if !strings.Contains(err.Error(), expected) {
+		t.Errorf("Expected error %q to contain %q", err.Error(), expected)
+	}
+}
+
+func TestMultiplyQuantity(t *testing.T) {
+	cases := []struct {
+		quantity string
+		n        int64
+		expected string
+	}{
+		{"100m", 0, "0"},
+		{"100m", 2147483647, "214748364700m"},
+		{"256Mi", 3, "768Mi"},
+	}
+
+	for _, c := range cases {
+		actual := multiplyQuantity(resource.MustParse(c.quantity), c.n)
+		if expected := resource.MustParse(c.expected); actual.Cmp(expected) != 0 {
+			t.Errorf("multiplyQuantity(%s, %d) == %s, expected %s", c.quantity, c.n, actual.String(), c.expected)
+		}
+	}
+}
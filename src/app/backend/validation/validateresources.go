@@ -0,0 +1,215 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"fmt"
+
+	api "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	client "k8s.io/client-go/kubernetes"
+
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/deployment"
+)
+
+// containerResource groups requested and limited quantity of a single resource of the deployed container
+// together with JSON paths of the spec fields.
+type containerResource struct {
+	name         api.ResourceName
+	request      *resource.Quantity
+	limit        *resource.Quantity
+	requestPath  *field.Path
+	limitPath    *field.Path
+	quotaRequest api.ResourceName
+	quotaLimit   api.ResourceName
+}
+
+// getContainerResources returns CPU and memory of the app container as specified by the user.
+func getContainerResources(spec *deployment.AppDeploymentSpec) []containerResource {
+	return []containerResource{
+		{api.ResourceCPU, spec.CpuRequirement, spec.CpuLimit, field.NewPath("cpuRequirement"),
+			field.NewPath("cpuLimit"), api.ResourceRequestsCPU, api.ResourceLimitsCPU},
+		{api.ResourceMemory, spec.MemoryRequirement, spec.MemoryLimit, field.NewPath("memoryRequirement"),
+			field.NewPath("memoryLimit"), api.ResourceRequestsMemory, api.ResourceLimitsMemory},
+	}
+}
+
+// validateResources validates CPU and memory requests and limits of the app container. Limits must not be
+// lower than requests and both of them must satisfy limit ranges and resource quotas of the namespace, when
+// they can be read.
+func validateResources(spec *deployment.AppDeploymentSpec, checkNamespace bool, client client.Interface) (
+	field.ErrorList, error) {
+	resources := getContainerResources(spec)
+	allErrs := field.ErrorList{}
+	for _, res := range resources {
+		if res.request != nil && res.request.Sign() < 0 {
+			allErrs = append(allErrs, field.Invalid(res.requestPath, res.request.String(),
+				"must be greater than or equal to 0"))
+		}
+		if res.limit != nil && res.request != nil && res.limit.Cmp(*res.request) < 0 {
+			allErrs = append(allErrs, field.Invalid(res.limitPath, res.limit.String(),
+				fmt.Sprintf("must be greater than or equal to %s request", res.name)))
+		}
+	}
+
+	if !checkNamespace || len(allErrs) > 0 {
+		return allErrs, nil
+	}
+
+	limitRanges, err := client.CoreV1().LimitRanges(spec.Namespace).List(metaV1.ListOptions{})
+	if err != nil && !errors.IsForbiddenError(err) {
+		return nil, err
+	}
+	if limitRanges != nil {
+		for i := range resources {
+			allErrs = append(allErrs, validateLimitRanges(&resources[i], limitRanges.Items)...)
+		}
+	}
+
+	quotaErrs, err := validateResourceQuotas(spec, resources, client)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(allErrs, quotaErrs...), nil
+}
+
+// validateLimitRanges checks container limit ranges. Missing request and limit are defaulted from the limit
+// range in the same way as the LimitRanger admission plugin does it, so the quota check sees the values that
+// will be really used.
+func validateLimitRanges(res *containerResource, limitRanges []api.LimitRange) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for _, limitRange := range limitRanges {
+		for _, item := range limitRange.Spec.Limits {
+			if item.Type != api.LimitTypeContainer {
+				continue
+			}
+
+			if res.limit == nil {
+				if def, ok := item.Default[res.name]; ok {
+					res.limit = &def
+				}
+			}
+			if res.request == nil {
+				if def, ok := item.DefaultRequest[res.name]; ok {
+					res.request = &def
+				} else if res.limit != nil {
+					res.request = res.limit
+				}
+			}
+
+			if min, ok := item.Min[res.name]; ok {
+				allErrs = append(allErrs, validateMin(res.request, min, res.requestPath, limitRange.Name)...)
+				allErrs = append(allErrs, validateMin(res.limit, min, res.limitPath, limitRange.Name)...)
+			}
+			if max, ok := item.Max[res.name]; ok {
+				if res.limit == nil {
+					allErrs = append(allErrs, field.Required(res.limitPath,
+						fmt.Sprintf("%s limit range requires %s limit", limitRange.Name, res.name)))
+				}
+				allErrs = append(allErrs, validateMax(res.request, max, res.requestPath, limitRange.Name)...)
+				allErrs = append(allErrs, validateMax(res.limit, max, res.limitPath, limitRange.Name)...)
+			}
+			if ratio, ok := item.MaxLimitRequestRatio[res.name]; ok && res.request != nil && res.limit != nil &&
+				!res.request.IsZero() {
+				actual := float64(res.limit.MilliValue()) / float64(res.request.MilliValue())
+				if actual > float64(ratio.MilliValue())/1000 {
+					allErrs = append(allErrs, field.Invalid(res.limitPath, res.limit.String(),
+						fmt.Sprintf("limit to request ratio must be at most %s as defined by %s limit range",
+							ratio.String(), limitRange.Name)))
+				}
+			}
+		}
+	}
+
+	return allErrs
+}
+
+func validateMin(value *resource.Quantity, min resource.Quantity, fldPath *field.Path,
+	limitRange string) field.ErrorList {
+	if value == nil || value.Cmp(min) >= 0 {
+		return nil
+	}
+
+	return field.ErrorList{field.Invalid(fldPath, value.String(),
+		fmt.Sprintf("must be at least %s as defined by %s limit range", min.String(), limitRange))}
+}
+
+func validateMax(value *resource.Quantity, max resource.Quantity, fldPath *field.Path,
+	limitRange string) field.ErrorList {
+	if value == nil || value.Cmp(max) <= 0 {
+		return nil
+	}
+
+	return field.ErrorList{field.Invalid(fldPath, value.String(),
+		fmt.Sprintf("must be at most %s as defined by %s limit range", max.String(), limitRange))}
+}
+
+// validateResourceQuotas checks that the app fits into remaining resource quotas of its namespace. Projected
+// usage is computed by CheckAppDeploymentQuotas and reported on the spec field that consumes the resource.
+func validateResourceQuotas(spec *deployment.AppDeploymentSpec, resources []containerResource,
+	client client.Interface) (field.ErrorList, error) {
+	check, err := CheckAppDeploymentQuotas(spec, client)
+	if err != nil {
+		return nil, err
+	}
+
+	allErrs := field.ErrorList{}
+	for _, usage := range check.Usages {
+		fldPath, value := quotaUsageField(usage.Resource, spec, resources)
+		switch {
+		case usage.Unspecified:
+			allErrs = append(allErrs, field.Required(fldPath,
+				fmt.Sprintf("%s resource quota requires %s to be specified", usage.Quota, usage.Resource)))
+		case !usage.Overage.IsZero():
+			allErrs = append(allErrs, field.Invalid(fldPath, value,
+				fmt.Sprintf("%d replicas exceed %s of %s resource quota: hard %s, requested total %s",
+					spec.Replicas, usage.Resource, usage.Quota, usage.Hard.String(), usage.Projected.String())))
+		}
+	}
+
+	return allErrs, nil
+}
+
+// quotaUsageField returns path and value of the spec field that consumes given quota resource.
+func quotaUsageField(name api.ResourceName, spec *deployment.AppDeploymentSpec,
+	resources []containerResource) (*field.Path, interface{}) {
+	for _, res := range resources {
+		switch name {
+		case res.name, res.quotaRequest:
+			return res.requestPath, quantityString(res.request)
+		case res.quotaLimit:
+			return res.limitPath, quantityString(res.limit)
+		}
+	}
+
+	switch name {
+	case api.ResourceServices, api.ResourceServicesLoadBalancers, api.ResourceServicesNodePorts,
+		"count/services":
+		return field.NewPath("portMappings"), len(spec.PortMappings)
+	}
+
+	return field.NewPath("replicas"), spec.Replicas
+}
+
+func quantityString(quantity *resource.Quantity) string {
+	if quantity == nil {
+		return ""
+	}
+	return quantity.String()
+}
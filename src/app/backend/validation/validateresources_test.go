@@ -0,0 +1,113 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"reflect"
+	"testing"
+
+	api "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/kubernetes/dashboard/src/app/backend/resource/deployment"
+)
+
+func quantity(value string) *resource.Quantity {
+	q := resource.MustParse(value)
+	return &q
+}
+
+func TestValidateResources(t *testing.T) {
+	limitRange := &api.LimitRange{
+		ObjectMeta: metaV1.ObjectMeta{Name: "limits", Namespace: "default"},
+		Spec: api.LimitRangeSpec{Limits: []api.LimitRangeItem{{
+			Type:                 api.LimitTypeContainer,
+			Min:                  api.ResourceList{api.ResourceCPU: resource.MustParse("50m")},
+			Max:                  api.ResourceList{api.ResourceCPU: resource.MustParse("2")},
+			Default:              api.ResourceList{api.ResourceCPU: resource.MustParse("400m")},
+			DefaultRequest:       api.ResourceList{api.ResourceCPU: resource.MustParse("100m")},
+			MaxLimitRequestRatio: api.ResourceList{api.ResourceCPU: resource.MustParse("4")},
+		}}},
+	}
+	quota := &api.ResourceQuota{
+		ObjectMeta: metaV1.ObjectMeta{Name: "quota", Namespace: "default"},
+		Spec: api.ResourceQuotaSpec{Hard: api.ResourceList{
+			api.ResourceLimitsMemory: resource.MustParse("1Gi"),
+		}},
+		Status: api.ResourceQuotaStatus{
+			Hard: api.ResourceList{api.ResourceLimitsMemory: resource.MustParse("1Gi")},
+			Used: api.ResourceList{api.ResourceLimitsMemory: resource.MustParse("512Mi")},
+		},
+	}
+
+	cases := []struct {
+		spec     *deployment.AppDeploymentSpec
+		objects  []runtime.Object
+		expected []string
+	}{
+		{
+			&deployment.AppDeploymentSpec{Namespace: "default", Replicas: 1,
+				CpuRequirement: quantity("1"), CpuLimit: quantity("500m")},
+			nil,
+			[]string{"cpuLimit"},
+		},
+		{
+			&deployment.AppDeploymentSpec{Namespace: "default", Replicas: 1},
+			[]runtime.Object{limitRange},
+			[]string{},
+		},
+		{
+			&deployment.AppDeploymentSpec{Namespace: "default", Replicas: 1,
+				CpuRequirement: quantity("10m"), CpuLimit: quantity("3")},
+			[]runtime.Object{limitRange},
+			[]string{"cpuRequirement", "cpuLimit", "cpuLimit"},
+		},
+		{
+			&deployment.AppDeploymentSpec{Namespace: "default", Replicas: 2},
+			[]runtime.Object{quota},
+			[]string{"memoryLimit"},
+		},
+		{
+			&deployment.AppDeploymentSpec{Namespace: "default", Replicas: 2, MemoryLimit: quantity("256Mi")},
+			[]runtime.Object{quota},
+			[]string{},
+		},
+		{
+			&deployment.AppDeploymentSpec{Namespace: "default", Replicas: 3, MemoryLimit: quantity("256Mi")},
+			[]runtime.Object{quota},
+			[]string{"memoryLimit"},
+		},
+	}
+
+	for _, c := range cases {
+		allErrs, err := validateResources(c.spec, true, fake.NewSimpleClientset(c.objects...))
+		if err != nil {
+			t.Errorf("validateResources(%#v) returned unexpected error: %s", c.spec, err)
+			continue
+		}
+
+		actual := make([]string, 0)
+		for _, fieldErr := range allErrs {
+			actual = append(actual, fieldErr.Field)
+		}
+
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("validateResources(%#v) == %v, expected errors for fields %v", c.spec, allErrs, c.expected)
+		}
+	}
+}
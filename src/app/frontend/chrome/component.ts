@@ -15,8 +15,10 @@
 import {HttpClient} from '@angular/common/http';
 import {Component, OnInit} from '@angular/core';
 import {Router} from '@angular/router';
+import {Branding} from '@api/backendapi';
 
 import {AssetsService} from '../common/services/global/assets';
+import {ThemeService} from '../common/services/global/theme';
 
 class SystemBanner {
   message: string;
@@ -30,13 +32,16 @@ class SystemBanner {
 })
 export class ChromeComponent implements OnInit {
   private static readonly systemBannerEndpoint = 'api/v1/systembanner';
+  private static readonly brandingEndpoint = 'api/v1/branding';
   private systemBanner_: SystemBanner;
+  branding: Branding;
   loading = false;
 
   constructor(
     public assets: AssetsService,
     private readonly http_: HttpClient,
     private readonly router_: Router,
+    private readonly theme_: ThemeService,
   ) {}
 
   ngOnInit(): void {
@@ -46,6 +51,16 @@ export class ChromeComponent implements OnInit {
       .then(sb => {
         this.systemBanner_ = sb;
       });
+
+    this.http_
+      .get<Branding>(ChromeComponent.brandingEndpoint)
+      .toPromise()
+      .then(branding => {
+        this.branding = branding;
+        if (branding.theme) {
+          this.theme_.switchTheme(branding.theme === 'light');
+        }
+      });
   }
 
   getOverviewStateName(): string {
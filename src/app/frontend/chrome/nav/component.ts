@@ -16,6 +16,7 @@ import {Component, OnInit, ViewChild} from '@angular/core';
 import {MatDrawer} from '@angular/material';
 
 import {NavService} from '../../common/services/nav/service';
+import {NamespaceService} from '../../common/services/global/namespace';
 import {PluginsConfigService} from '../../common/services/global/plugin';
 
 @Component({
@@ -29,6 +30,7 @@ export class NavComponent implements OnInit {
   constructor(
     private readonly navService_: NavService,
     private readonly pluginsConfigService_: PluginsConfigService,
+    private readonly namespaceService_: NamespaceService,
   ) {}
 
   ngOnInit(): void {
@@ -39,4 +41,9 @@ export class NavComponent implements OnInit {
   showPlugin(): boolean {
     return this.pluginsConfigService_.status() === 200;
   }
+
+  // Virtual dashboards are pinned to a single namespace, so it can not be changed.
+  showNamespaceSelector(): boolean {
+    return !this.namespaceService_.isTenant();
+  }
 }
@@ -12,7 +12,9 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-import {Component, Input} from '@angular/core';
+import {Component, HostBinding, Input} from '@angular/core';
+
+import {GlobalSettingsService} from '../../../common/services/global/globalsettings';
 
 @Component({
   selector: 'kd-nav-item',
@@ -22,4 +24,13 @@ import {Component, Input} from '@angular/core';
 export class NavItemComponent {
   @Input() state: string;
   @Input() exact = false;
+
+  constructor(private readonly settings_: GlobalSettingsService) {}
+
+  // Items of resource kinds hidden in settings are removed from navigation.
+  @HostBinding('style.display')
+  get display(): string {
+    const kind = this.state ? this.state.replace(/^\//, '') : '';
+    return this.settings_.isResourceKindHidden(kind) ? 'none' : null;
+  }
 }
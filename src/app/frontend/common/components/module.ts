@@ -80,6 +80,7 @@ import {PersistentVolumeListComponent} from './resourcelist/persistentvolume/com
 import {PersistentVolumeClaimListComponent} from './resourcelist/persistentvolumeclaim/component';
 import {PluginListComponent} from './resourcelist/plugin/component';
 import {PodListComponent} from './resourcelist/pod/component';
+import {ReleaseListComponent} from './resourcelist/release/component';
 import {ReplicaSetListComponent} from './resourcelist/replicaset/component';
 import {ReplicationControllerListComponent} from './resourcelist/replicationcontroller/component';
 import {SecretListComponent} from './resourcelist/secret/component';
@@ -171,6 +172,7 @@ const components = [
   ResourceLimitListComponent,
   ReplicaSetListComponent,
   ReplicationControllerListComponent,
+  ReleaseListComponent,
   RowDetailComponent,
 
   StorageClassListComponent,
@@ -15,7 +15,7 @@
 import {Component, ElementRef, OnDestroy, OnInit, ViewChild} from '@angular/core';
 import {MatDialog, MatSelect} from '@angular/material';
 import {ActivatedRoute, NavigationEnd, Router} from '@angular/router';
-import {NamespaceList} from '@api/backendapi';
+import {Namespace, NamespaceList} from '@api/backendapi';
 import {Subject} from 'rxjs';
 import {startWith, switchMap, takeUntil} from 'rxjs/operators';
 
@@ -63,7 +63,7 @@ export class NamespaceSelectorComponent implements OnInit, OnDestroy {
   ngOnInit(): void {
     this._activatedRoute.queryParams.pipe(takeUntil(this.unsubscribe_)).subscribe(params => {
       const namespace = params.namespace;
-      if (!namespace) {
+      if (!namespace || (this.namespaceService_.isTenant() && namespace !== this.namespaceService_.tenant())) {
         this.setDefaultQueryParams_();
         return;
       }
@@ -152,7 +152,12 @@ export class NamespaceSelectorComponent implements OnInit, OnDestroy {
       .pipe(switchMap(() => this.namespace_.get(this.endpoint_.list())))
       .subscribe(
         namespaceList => {
-          this.namespaces = namespaceList.namespaces.map(n => n.objectMeta.name);
+          // Pinned and default namespaces are shown first.
+          const isPreferred = (n: Namespace) => n.isPinned || n.isDefault;
+          this.namespaces = namespaceList.namespaces
+            .filter(isPreferred)
+            .concat(namespaceList.namespaces.filter(n => !isPreferred(n)))
+            .map(n => n.objectMeta.name);
 
           if (namespaceList.errors.length > 0) {
             for (const err of namespaceList.errors) {
@@ -245,7 +250,7 @@ export class NamespaceSelectorComponent implements OnInit, OnDestroy {
 
   setDefaultQueryParams_() {
     this.router_.navigate([this._activatedRoute.snapshot.url], {
-      queryParams: {[NAMESPACE_STATE_PARAM]: CONFIG.defaultNamespace},
+      queryParams: {[NAMESPACE_STATE_PARAM]: this.namespaceService_.tenant() || CONFIG.defaultNamespace},
       queryParamsHandling: 'merge',
     });
   }
@@ -0,0 +1,99 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import {HttpClient} from '@angular/common/http';
+import {Component, OnDestroy, OnInit} from '@angular/core';
+import {Release, ReleaseList} from 'typings/backendapi';
+import {of, Subject} from 'rxjs';
+import {catchError, startWith, switchMap, takeUntil} from 'rxjs/operators';
+
+import {NamespaceService} from '../../../services/global/namespace';
+
+/**
+ * Lists Helm releases of the selected namespace together with their status. Releases are stored in secrets, so
+ * the list stays hidden for users that cannot read them.
+ */
+@Component({
+  selector: 'kd-release-list',
+  templateUrl: './template.html',
+})
+export class ReleaseListComponent implements OnInit, OnDestroy {
+  releases: Release[] = [];
+  isLoading = false;
+  private readonly unsubscribe_ = new Subject<void>();
+
+  constructor(private readonly http_: HttpClient, private readonly namespace_: NamespaceService) {}
+
+  ngOnInit(): void {
+    this.namespace_.onNamespaceChangeEvent
+      .pipe(startWith({}))
+      .pipe(
+        switchMap(() => {
+          this.isLoading = true;
+          return this.http_
+            .get<ReleaseList>(this.getEndpoint_())
+            .pipe(catchError(() => of({listMeta: {totalItems: 0}, items: []} as ReleaseList)));
+        }),
+      )
+      .pipe(takeUntil(this.unsubscribe_))
+      .subscribe(list => {
+        this.releases = list.items;
+        this.isLoading = false;
+      });
+  }
+
+  ngOnDestroy(): void {
+    this.unsubscribe_.next();
+    this.unsubscribe_.complete();
+  }
+
+  getColumns(): string[] {
+    const columns = ['statusicon', 'name', 'chart', 'appVersion', 'revision', 'status', 'lastDeployed'];
+    if (this.namespace_.areMultipleNamespacesSelected()) {
+      columns.splice(2, 0, 'namespace');
+    }
+    return columns;
+  }
+
+  getStatusIcon(release: Release): string {
+    switch (release.status) {
+      case 'deployed':
+        return 'check_circle';
+      case 'failed':
+        return 'error';
+      case 'pending-install':
+      case 'pending-upgrade':
+      case 'pending-rollback':
+      case 'uninstalling':
+        return 'timelapse';
+      default:
+        return 'help';
+    }
+  }
+
+  getStatusClass(release: Release): {[className: string]: boolean} {
+    return {
+      'kd-success': release.status === 'deployed',
+      'kd-error': release.status === 'failed',
+      'kd-muted': release.status !== 'deployed' && release.status !== 'failed',
+    };
+  }
+
+  private getEndpoint_(): string {
+    if (this.namespace_.areMultipleNamespacesSelected()) {
+      return 'api/v1/chart/release';
+    }
+    return `api/v1/chart/release/${this.namespace_.current()}`;
+  }
+}
@@ -19,6 +19,7 @@ export interface AlertDialogConfig {
   title: string;
   message: string;
   confirmLabel: string;
+  cancelLabel?: string;
 }
 
 @Component({
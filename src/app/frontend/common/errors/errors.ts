@@ -39,6 +39,12 @@ const localizedErrors: {[key: string]: string} = {
   MSG_ENCRYPTION_KEY_CHANGED: 'You have been logged out because your token is invalid.',
   MSG_ACCESS_DENIED: 'Access denied.',
   MSG_DASHBOARD_EXCLUSIVE_RESOURCE_ERROR: 'Trying to access/modify dashboard exclusive resource.',
+  MSG_NAMESPACE_RESTRICTED_ERROR: 'Access to this namespace is restricted by Dashboard settings.',
+  MSG_FEATURE_DISABLED_ERROR: 'This feature has been disabled by the Dashboard administrator.',
+  MSG_RESOURCE_KIND_HIDDEN_ERROR: 'This resource kind is hidden by Dashboard settings.',
+  MSG_TENANT_NAMESPACE_ERROR: 'This dashboard is limited to a single namespace.',
+  MSG_LOGIN_NOTICE_NOT_ACKNOWLEDGED_ERROR: 'Login notice has to be acknowledged before signing in.',
+  MSG_UNEXPECTED_SERVER_ERROR: 'Unexpected server error occurred. Check Dashboard logs for details.',
   MSG_LOGIN_UNAUTHORIZED_ERROR: 'Invalid credentials provided',
 };
 
@@ -66,13 +72,13 @@ export class KdError implements KdApiError {
   constructor(public status: string, public code: number, public message: string) {}
 
   static isError(error: HttpErrorResponse, ...apiErrors: string[]): boolean {
-    // API errors will set 'error' as a string.
-    if (typeof error.error === 'object') {
+    const message = errorMessage(error);
+    if (message === undefined) {
       return false;
     }
 
     for (const apiErr of apiErrors) {
-      if (apiErr === (error.error as string).trim()) {
+      if (apiErr === message.trim()) {
         return true;
       }
     }
@@ -92,6 +98,28 @@ export class KdError implements KdApiError {
   }
 }
 
+/**
+ * Returns message sent by the backend as a part of given error response. API errors are returned either as plain
+ * text or, for requests that prefer JSON, as an object with the 'message' field. The object is not parsed by
+ * HttpClient for requests made with the 'text' response type.
+ */
+export function errorMessage(error: HttpErrorResponse): string | undefined {
+  let body = error.error;
+  if (typeof body === 'string') {
+    try {
+      body = JSON.parse(body);
+    } catch (e) {
+      return body;
+    }
+  }
+
+  if (body && typeof body.message === 'string') {
+    return body.message;
+  }
+
+  return typeof error.error === 'string' ? error.error : undefined;
+}
+
 export function AsKdError(error: HttpErrorResponse): KdError {
   const result = {} as KdError;
   let status: string;
@@ -99,8 +127,9 @@ export function AsKdError(error: HttpErrorResponse): KdError {
   result.message = error.message;
   result.code = error.status;
 
-  if (typeof error.error !== 'object') {
-    result.message = error.error;
+  const message = errorMessage(error);
+  if (message !== undefined) {
+    result.message = message;
   }
 
   switch (error.status) {
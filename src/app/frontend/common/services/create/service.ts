@@ -20,10 +20,12 @@ import {
   AppDeploymentContentResponse,
   AppDeploymentContentSpec,
   AppDeploymentSpec,
+  ManifestValidity,
 } from '@api/backendapi';
 
 import {Config, CONFIG_DI_TOKEN} from '../../../index.config';
 import {AlertDialog, AlertDialogConfig} from '../../dialogs/alert/dialog';
+import {errorMessage} from '../../errors/errors';
 import {NAMESPACE_STATE_PARAM} from '../../params/params';
 import {CsrfTokenService} from '../global/csrftoken';
 import {NamespaceService} from '../global/namespace';
@@ -92,8 +94,12 @@ export class CreateService {
     }
     this.isDeployInProgress_ = false;
 
+    if (error && validate && error.status === 422) {
+      return this.deployAnyway_(content, name, error);
+    }
+
     if (error) {
-      this.reportError(i18n.MSG_DEPLOY_DIALOG_ERROR, error.error);
+      this.reportError(i18n.MSG_DEPLOY_DIALOG_ERROR, errorMessage(error));
       throw error;
     } else {
       this.router_.navigate(['overview']);
@@ -120,7 +126,7 @@ export class CreateService {
     this.isDeployInProgress_ = false;
 
     if (error) {
-      this.reportError(i18n.MSG_DEPLOY_DIALOG_ERROR, error.error);
+      this.reportError(i18n.MSG_DEPLOY_DIALOG_ERROR, errorMessage(error));
       throw error;
     } else {
       this.router_.navigate(['overview'], {
@@ -135,6 +141,36 @@ export class CreateService {
     return this.isDeployInProgress_;
   }
 
+  /**
+   * Shows schema validation errors of the content and deploys it without validation when user confirms.
+   */
+  private async deployAnyway_(
+    content: string,
+    name: string,
+    error: HttpErrorResponse,
+  ): Promise<AppDeploymentContentResponse> {
+    const validity = error.error as ManifestValidity;
+    const errors = validity.errors.map(
+      e => `Document ${e.document}, line ${e.line}: ${e.field ? `${e.field}: ` : ''}${e.detail}`,
+    );
+    const configData: AlertDialogConfig = {
+      title: i18n.MSG_DEPLOY_ANYWAY_DIALOG_TITLE,
+      message: `${errors.join('\n')}\n\n${i18n.MSG_DEPLOY_ANYWAY_DIALOG_CONTENT}`,
+      confirmLabel: i18n.MSG_DEPLOY_ANYWAY_DIALOG_OK,
+      cancelLabel: i18n.MSG_DEPLOY_ANYWAY_DIALOG_CANCEL,
+    };
+
+    const confirmed = await this.matDialog_
+      .open(AlertDialog, {data: configData})
+      .afterClosed()
+      .toPromise();
+    if (!confirmed) {
+      throw error;
+    }
+
+    return this.createContent(content, false, name);
+  }
+
   private reportError(title: string, message: string): void {
     const configData: AlertDialogConfig = {
       title,
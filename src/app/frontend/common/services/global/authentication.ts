@@ -86,6 +86,19 @@ export class AuthService {
     this.cookies_.delete(this._config.skipLoginPageCookieName);
   }
 
+  /**
+   * Records acknowledgment of the login notice by user that skips login page.
+   */
+  acknowledgeLoginNotice(): Observable<{}> {
+    return this.csrfTokenService_.getTokenForAction('login').pipe(
+      switchMap((csrfToken: CsrfToken) =>
+        this.http_.post('api/v1/login/notice/acknowledgment', {}, {
+          headers: new HttpHeaders().set(this._config.csrfHeaderName, csrfToken.token),
+        }),
+      ),
+    );
+  }
+
   /**
    * Sends a login request to the backend with filled in login spec structure.
    */
@@ -14,7 +14,7 @@
 
 import {HttpClient, HttpHeaders} from '@angular/common/http';
 import {EventEmitter, Injectable} from '@angular/core';
-import {GlobalSettings} from '@api/backendapi';
+import {GlobalSettings, SystemBannerSettings} from '@api/backendapi';
 import {onSettingsFailCallback, onSettingsLoadCallback} from '@api/frontendapi';
 import {ReplaySubject} from 'rxjs';
 import {Observable} from 'rxjs/Observable';
@@ -32,6 +32,11 @@ export class GlobalSettingsService {
     clusterName: '',
     logsAutoRefreshTimeInterval: 5,
     resourceAutoRefreshTimeInterval: 5,
+    defaultNamespace: 'default',
+    allowedNamespaces: [],
+    deniedNamespaces: [],
+    pinnedNamespaces: [],
+    hiddenResourceKinds: [],
   };
   private isInitialized_ = false;
 
@@ -92,4 +97,33 @@ export class GlobalSettingsService {
   getResourceAutoRefreshTimeInterval(): number {
     return this.settings_.resourceAutoRefreshTimeInterval;
   }
+
+  getDefaultNamespace(): string {
+    return this.settings_.defaultNamespace;
+  }
+
+  getAllowedNamespaces(): string[] {
+    return this.settings_.allowedNamespaces;
+  }
+
+  getDeniedNamespaces(): string[] {
+    return this.settings_.deniedNamespaces;
+  }
+
+  getPinnedNamespaces(): string[] {
+    return this.settings_.pinnedNamespaces;
+  }
+
+  getHiddenResourceKinds(): string[] {
+    return this.settings_.hiddenResourceKinds;
+  }
+
+  isResourceKindHidden(kind: string): boolean {
+    const hidden = this.settings_.hiddenResourceKinds || [];
+    return hidden.some(k => k.toLowerCase() === kind.toLowerCase());
+  }
+
+  getSystemBanner(): SystemBannerSettings {
+    return this.settings_.systemBanner;
+  }
 }
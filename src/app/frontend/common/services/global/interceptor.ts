@@ -12,10 +12,11 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-import {HttpEvent, HttpHandler, HttpInterceptor, HttpRequest} from '@angular/common/http';
+import {HttpEvent, HttpHandler, HttpInterceptor, HttpRequest, HttpResponse} from '@angular/common/http';
 import {Injectable} from '@angular/core';
 import {CookieService} from 'ngx-cookie-service';
 import {Observable} from 'rxjs/Observable';
+import {tap} from 'rxjs/operators';
 import {CONFIG} from '../../../index.config';
 
 /* tslint:disable */
@@ -33,10 +34,24 @@ export class AuthInterceptor implements HttpInterceptor {
         headers: req.headers.set(CONFIG.authTokenHeaderName, authCookie),
       });
 
-      return next.handle(authReq);
+      // Backend returns new token when credentials stored in the token were refreshed.
+      return next.handle(authReq).pipe(
+        tap(event => {
+          if (event instanceof HttpResponse && event.headers.has(CONFIG.authTokenHeaderName)) {
+            this.setTokenCookie_(event.headers.get(CONFIG.authTokenHeaderName));
+          }
+        }),
+      );
     }
 
     return next.handle(req);
   }
+
+  // Keep in sync with AuthService, that can not be injected here, as it depends on HttpClient.
+  private setTokenCookie_(token: string): void {
+    this.cookies_.set(CONFIG.authTokenCookieName, token, null, null, null, true, 'Strict');
+    this.cookies_.set(CONFIG.authTokenCookieName, token, null, null, 'localhost', false, 'Strict');
+    this.cookies_.set(CONFIG.authTokenCookieName, token, null, null, '127.0.0.1', false, 'Strict');
+  }
 }
 /* tslint:enable */
@@ -27,6 +27,10 @@ export class NamespaceService {
    * Regular expression for namespace validation.
    */
   private readonly namespaceRegex = /^([a-z0-9]([-a-z0-9]*[a-z0-9])?|_all)$/;
+  /**
+   * Regular expression matching path of a virtual dashboard pinned to a single namespace, i.e. /t/team-a/.
+   */
+  private readonly tenantPathRegex = /^\/t\/([a-z0-9]([-a-z0-9]*[a-z0-9])?)\//;
   /**
    * Holds the currently selected namespace.
    */
@@ -37,7 +41,20 @@ export class NamespaceService {
   }
 
   current(): string {
-    return this.currentNamespace_ || CONFIG.defaultNamespace;
+    return this.tenant() || this.currentNamespace_ || CONFIG.defaultNamespace;
+  }
+
+  /**
+   * Returns namespace of the virtual dashboard, that the application is served from, or empty string if it is
+   * not pinned to a namespace.
+   */
+  tenant(): string {
+    const match = this.tenantPathRegex.exec(window.location.pathname);
+    return match ? match[1] : '';
+  }
+
+  isTenant(): boolean {
+    return !!this.tenant();
   }
 
   getAllNamespacesKey(): string {
@@ -57,6 +74,9 @@ export class NamespaceService {
   }
 
   areMultipleNamespacesSelected(): boolean {
+    if (this.isTenant()) {
+      return false;
+    }
     return this.currentNamespace_ ? this.currentNamespace_ === this.allNamespacesKey_ : true;
   }
 }
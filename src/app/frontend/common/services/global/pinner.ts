@@ -18,6 +18,7 @@ import {HttpClient, HttpErrorResponse} from '@angular/common/http';
 import {Subject} from 'rxjs';
 import {MatDialog, MatDialogConfig} from '@angular/material';
 import {AlertDialog, AlertDialogConfig} from '../../dialogs/alert/dialog';
+import {errorMessage} from '../../errors/errors';
 import {VerberService} from './verber';
 
 @Injectable()
@@ -102,7 +103,7 @@ export class PinnerService {
         width: '630px',
         data: {
           title: err.statusText === 'OK' ? 'Internal server error' : err.statusText,
-          message: err.error || 'Could not perform the operation.',
+          message: errorMessage(err) || 'Could not perform the operation.',
           confirmLabel: 'OK',
         },
       };
@@ -15,13 +15,14 @@
 import {HttpClient, HttpErrorResponse, HttpHeaders} from '@angular/common/http';
 import {EventEmitter, Injectable} from '@angular/core';
 import {MatDialog, MatDialogConfig} from '@angular/material';
-import {ObjectMeta, TypeMeta} from '@api/backendapi';
+import {ObjectMeta, ResourceConflict, TypeMeta} from '@api/backendapi';
 
 import {AlertDialog, AlertDialogConfig} from '../../dialogs/alert/dialog';
 import {DeleteResourceDialog} from '../../dialogs/deleteresource/dialog';
 import {EditResourceDialog} from '../../dialogs/editresource/dialog';
 import {ScaleResourceDialog} from '../../dialogs/scaleresource/dialog';
 import {TriggerResourceDialog} from '../../dialogs/triggerresource/dialog';
+import {errorMessage} from '../../errors/errors';
 import {RawResource} from '../../resources/rawresource';
 
 import {ResourceMeta} from './actionbar';
@@ -60,7 +61,16 @@ export class VerberService {
           const url = RawResource.getUrl(typeMeta, objectMeta);
           this.http_
             .put(url, JSON.parse(result), {headers: this.getHttpHeaders_(), responseType: 'text'})
-            .subscribe(() => this.onEdit.emit(true), this.handleErrorResponse_.bind(this));
+            .subscribe(
+              () => this.onEdit.emit(true),
+              (err: HttpErrorResponse) => {
+                if (err && err.status === 409) {
+                  this.handleEditConflict_(displayName, typeMeta, objectMeta, err);
+                  return;
+                }
+                this.handleErrorResponse_(err);
+              },
+            );
         }
       });
   }
@@ -115,7 +125,7 @@ export class VerberService {
         width: '630px',
         data: {
           title: err.statusText === 'OK' ? 'Internal server error' : err.statusText,
-          message: err.error || 'Could not perform the operation.',
+          message: errorMessage(err) || 'Could not perform the operation.',
           confirmLabel: 'OK',
         },
       };
@@ -123,6 +133,43 @@ export class VerberService {
     }
   }
 
+  /**
+   * Shows fields that were changed after the resource was read into the editor and reopens the editor with
+   * the current version of the resource when user confirms.
+   */
+  handleEditConflict_(
+    displayName: string,
+    typeMeta: TypeMeta,
+    objectMeta: ObjectMeta,
+    err: HttpErrorResponse,
+  ): void {
+    const conflict: ResourceConflict = JSON.parse(err.error);
+    const format = (value: {}) => (value === undefined ? '<none>' : JSON.stringify(value));
+    const diff = conflict.diff.map(
+      d => `${d.path}: edited ${format(d.edited)}, current ${format(d.current)}`,
+    );
+    const alertDialogConfig: MatDialogConfig<AlertDialogConfig> = {
+      width: '630px',
+      data: {
+        title: 'Resource has been changed',
+        message:
+          `${displayName} has been changed after it was opened in the editor ` +
+          `(version ${conflict.editedResourceVersion}, current ${conflict.currentResourceVersion}).\n\n` +
+          `${diff.join('\n')}\n\nReopen the editor with the current version? Your changes will be lost.`,
+        confirmLabel: 'Reopen',
+        cancelLabel: 'Cancel',
+      },
+    };
+    this.dialog_
+      .open(AlertDialog, alertDialogConfig)
+      .afterClosed()
+      .subscribe(reopen => {
+        if (reopen) {
+          this.showEditDialog(displayName, typeMeta, objectMeta);
+        }
+      });
+  }
+
   getHttpHeaders_(): HttpHeaders {
     const headers = new HttpHeaders();
     headers.set('Content-Type', 'application/json');
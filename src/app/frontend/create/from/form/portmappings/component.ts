@@ -125,6 +125,11 @@ export class PortMappingsComponent implements OnInit, ControlValueAccessor {
     this.changeExternal.emit(this.isExternal);
 
     for (let i = 0; i < this.portMappings.length; i++) {
+      // Node ports can be requested only for external services.
+      if (!this.isExternal) {
+        this.portMappings.at(i).get('nodePort').setValue(null);
+      }
+
       const ele = this.portMappings.at(i).get('protocol');
       ele.clearAsyncValidators();
       ele.setAsyncValidators(validateProtocol(this.http_, this.isExternal));
@@ -151,6 +156,10 @@ export class PortMappingsComponent implements OnInit, ControlValueAccessor {
         Validators.compose([FormValidators.isInteger, Validators.min(1), Validators.max(65535)]),
       ],
       protocol: [defaultProtocol],
+      nodePort: [
+        null,
+        Validators.compose([FormValidators.isInteger, Validators.min(1), Validators.max(65535)]),
+      ],
     });
   }
 
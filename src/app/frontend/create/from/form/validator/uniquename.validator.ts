@@ -13,6 +13,7 @@
 // limitations under the License.
 
 import {HttpClient} from '@angular/common/http';
+import {AppNameValidity} from '@api/backendapi';
 import {Directive, forwardRef, Input} from '@angular/core';
 import {
   AbstractControl,
@@ -24,10 +25,12 @@ import {Observable} from 'rxjs/Observable';
 import {debounceTime, map} from 'rxjs/operators';
 
 export const uniqueNameValidationKey = 'uniqueName';
+export const validNameValidationKey = 'validName';
 
 /**
  * A validator directive which checks the underlining ngModel's given name is unique or not.
- * If the name exists, error with name `uniqueName` will be added to errors.
+ * If the name exists, error with name `uniqueName` will be added to errors. If the name is not a valid
+ * DNS-1123 label, error with name `validName` and the reason of the failure will be added to errors.
  */
 @Directive({
   selector: '[kdUniqueName]',
@@ -44,26 +47,34 @@ export class UniqueNameValidator implements AsyncValidator {
 
   constructor(private readonly http: HttpClient) {}
 
-  validate(control: AbstractControl): Observable<{[key: string]: boolean} | null> {
+  validate(control: AbstractControl): Observable<{[key: string]: string} | null> {
     return validateUniqueName(this.http, this.namespace)(control) as Observable<{
-      [key: string]: boolean;
+      [key: string]: string;
     } | null>;
   }
 }
 
 export function validateUniqueName(http: HttpClient, namespace: string): AsyncValidatorFn {
-  return (control: AbstractControl): Observable<{[key: string]: boolean} | null> => {
+  return (control: AbstractControl): Observable<{[key: string]: string} | null> => {
     if (!control.value) {
       return Observable.of(null);
     } else {
       return http
-        .post<{valid: boolean}>('api/v1/appdeployment/validate/name', {
+        .post<AppNameValidity>('api/v1/appdeployment/validate/name', {
           name: control.value,
           namespace,
         })
         .pipe(
           debounceTime(500),
-          map(res => (!res.valid ? {[uniqueNameValidationKey]: control.value} : null)),
+          map(res => {
+            if (res.valid) {
+              return null;
+            }
+
+            return res.constraint === 'unique'
+              ? {[uniqueNameValidationKey]: control.value}
+              : {[validNameValidationKey]: res.reason};
+          }),
         );
     }
   };
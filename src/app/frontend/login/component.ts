@@ -18,6 +18,7 @@ import {ActivatedRoute, Router} from '@angular/router';
 import {
   AuthenticationMode,
   EnabledAuthenticationModes,
+  LoginNoticeResponse,
   LoginSkippableResponse,
   LoginSpec,
 } from '@api/backendapi';
@@ -43,6 +44,8 @@ export class LoginComponent implements OnInit {
   loginModes = LoginModes;
   selectedAuthenticationMode = LoginModes.Kubeconfig;
   errors: KdError[] = [];
+  notice: LoginNoticeResponse = {message: '', acknowledgmentRequired: false};
+  noticeAcknowledged = false;
 
   private enabledAuthenticationModes_: AuthenticationMode[] = [];
   private isLoginSkippable_ = false;
@@ -73,6 +76,12 @@ export class LoginComponent implements OnInit {
         this.isLoginSkippable_ = loginSkippableResponse.skippable;
       });
 
+    this.http_
+      .get<LoginNoticeResponse>('api/v1/login/notice')
+      .subscribe((notice: LoginNoticeResponse) => {
+        this.notice = notice;
+      });
+
     this.route_.paramMap.pipe(map(() => window.history.state)).subscribe((state: StateError) => {
       if (state.error) {
         this.errors = [state.error];
@@ -114,10 +123,22 @@ export class LoginComponent implements OnInit {
   }
 
   skip(): void {
+    if (this.noticeAcknowledged) {
+      this.authService_.acknowledgeLoginNotice().subscribe();
+    }
+
     this.authService_.skipLoginPage(true);
     this.state_.navigate(['overview']);
   }
 
+  isNoticeVisible(): boolean {
+    return this.notice.message.length > 0;
+  }
+
+  isNoticeAcknowledgmentMissing(): boolean {
+    return this.notice.acknowledgmentRequired && !this.noticeAcknowledged;
+  }
+
   isSkipButtonEnabled(): boolean {
     return this.isLoginSkippable_;
   }
@@ -146,6 +167,15 @@ export class LoginComponent implements OnInit {
   }
 
   private getLoginSpec_(): LoginSpec {
+    const spec = this.getAuthenticationSpec_();
+    if (this.noticeAcknowledged) {
+      spec.noticeAcknowledged = true;
+    }
+
+    return spec;
+  }
+
+  private getAuthenticationSpec_(): LoginSpec {
     switch (this.selectedAuthenticationMode) {
       case LoginModes.Kubeconfig:
         return {kubeConfig: this.kubeconfig_} as LoginSpec;
@@ -34,6 +34,7 @@ import {Helper, ResourceRatioModes} from './helper';
 
 const mockDaemonSetData: DaemonSetList = {
   cumulativeMetrics: [],
+  metricsAvailable: false,
   listMeta: {totalItems: 1},
   daemonSets: [],
   status: {running: 1, pending: 0, succeeded: 0, failed: 0},
@@ -44,12 +45,14 @@ const mockPodsData: PodList = {
   listMeta: {totalItems: 12},
   pods: [],
   cumulativeMetrics: null,
+  metricsAvailable: false,
   status: {running: 9, pending: 1, succeeded: 0, failed: 2},
   errors: [],
 };
 
 const mockCronJobsData: CronJobList = {
   cumulativeMetrics: [],
+  metricsAvailable: false,
   listMeta: {totalItems: 18},
   items: [],
   status: {running: 8, pending: 1, succeeded: 4, failed: 5},
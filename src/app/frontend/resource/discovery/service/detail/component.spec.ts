@@ -118,6 +118,7 @@ class MaxiTestComponent {
       ],
       status: {failed: 2, pending: 1, running: 3, succeeded: 5},
       cumulativeMetrics: [],
+      metricsAvailable: false,
       listMeta: {totalItems: 1},
       errors: [
         {
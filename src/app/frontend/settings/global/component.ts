@@ -55,6 +55,12 @@ export class GlobalSettingsComponent implements OnInit {
     this.settings.clusterName = this.settings_.getClusterName();
     this.settings.logsAutoRefreshTimeInterval = this.settings_.getLogsAutoRefreshTimeInterval();
     this.settings.resourceAutoRefreshTimeInterval = this.settings_.getResourceAutoRefreshTimeInterval();
+    this.settings.defaultNamespace = this.settings_.getDefaultNamespace();
+    this.settings.allowedNamespaces = this.settings_.getAllowedNamespaces();
+    this.settings.deniedNamespaces = this.settings_.getDeniedNamespaces();
+    this.settings.pinnedNamespaces = this.settings_.getPinnedNamespaces();
+    this.settings.hiddenResourceKinds = this.settings_.getHiddenResourceKinds();
+    this.settings.systemBanner = this.settings_.getSystemBanner();
   }
 
   onLoadError(): void {
@@ -76,6 +76,7 @@ export interface ConfigMapList extends ResourceList {
 
 export interface CronJobList extends ResourceList {
   cumulativeMetrics: Metric[] | null;
+  metricsAvailable: boolean;
   items: CronJob[];
   status: Status;
 }
@@ -91,12 +92,14 @@ export interface CRDObjectList extends ResourceList {
 
 export interface DaemonSetList extends ResourceList {
   cumulativeMetrics: Metric[] | null;
+  metricsAvailable: boolean;
   daemonSets: DaemonSet[];
   status: Status;
 }
 
 export interface DeploymentList extends ResourceList {
   cumulativeMetrics: Metric[] | null;
+  metricsAvailable: boolean;
   deployments: Deployment[];
   status: Status;
 }
@@ -117,8 +120,44 @@ export interface IngressList extends ResourceList {
   items: Ingress[];
 }
 
+export interface MeshObjectList extends ResourceList {
+  items: MeshObject[];
+  installed: boolean;
+}
+
+export interface KnativeServiceList extends ResourceList {
+  items: KnativeService[];
+  installed: boolean;
+}
+
+export interface RevisionList extends ResourceList {
+  items: Revision[];
+  installed: boolean;
+}
+
+export interface CertificateList extends ResourceList {
+  items: Certificate[];
+  installed: boolean;
+}
+
+export interface ConstraintList extends ResourceList {
+  items: Constraint[];
+  installed: boolean;
+}
+
+export interface BackupList extends ResourceList {
+  items: Backup[];
+  installed: boolean;
+}
+
+export interface RestoreList extends ResourceList {
+  items: Restore[];
+  installed: boolean;
+}
+
 export interface JobList extends ResourceList {
   cumulativeMetrics: Metric[] | null;
+  metricsAvailable: boolean;
   jobs: Job[];
   status: Status;
 }
@@ -129,6 +168,7 @@ export interface NamespaceList extends ResourceList {
 
 export interface NodeList extends ResourceList {
   cumulativeMetrics: Metric[] | null;
+  metricsAvailable: boolean;
   nodes: Node[];
 }
 
@@ -149,10 +189,12 @@ export interface PodList extends ResourceList {
   status: Status;
   podInfo?: PodInfo;
   cumulativeMetrics: Metric[] | null;
+  metricsAvailable: boolean;
 }
 
 export interface ReplicaSetList extends ResourceList {
   cumulativeMetrics: Metric[] | null;
+  metricsAvailable: boolean;
   replicaSets: ReplicaSet[];
   status: Status;
 }
@@ -176,6 +218,7 @@ export interface ServiceList extends ResourceList {
 
 export interface StatefulSetList extends ResourceList {
   cumulativeMetrics: Metric[] | null;
+  metricsAvailable: boolean;
   statefulSets: StatefulSet[];
   status: Status;
 }
@@ -280,6 +323,106 @@ export interface Ingress extends Resource {
   endpoints: Endpoint[];
 }
 
+export interface MeshObject extends Resource {
+  hosts: string[];
+}
+
+export interface TrafficTarget {
+  revisionName?: string;
+  latestRevision: boolean;
+  tag?: string;
+  percent: number;
+  url?: string;
+}
+
+export interface KnativeService extends Resource {
+  url: string;
+  ready: string;
+  latestReadyRevisionName: string;
+  latestCreatedRevisionName: string;
+  traffic: TrafficTarget[];
+}
+
+export interface Revision extends Resource {
+  serviceName: string;
+  ready: string;
+  containerImage: string;
+  trafficPercent: number;
+  minScale: number;
+  scaledToZero: boolean;
+}
+
+export interface PolicyViolation {
+  constraintKind: string;
+  constraintName: string;
+  enforcementAction: string;
+  kind: string;
+  namespace?: string;
+  name: string;
+  message: string;
+}
+
+export interface Constraint extends Resource {
+  kind: string;
+  enforcementAction: string;
+  totalViolations: number;
+  auditTimestamp?: string;
+  violations: PolicyViolation[];
+}
+
+export interface Certificate extends Resource {
+  secretName: string;
+  dnsNames: string[];
+  issuerKind: string;
+  issuerName: string;
+  status: string;
+  reason?: string;
+  message?: string;
+  notAfter?: string;
+  renewalTime?: string;
+}
+
+export interface VeleroStatus {
+  phase: string;
+  includedNamespaces: string[];
+  excludedNamespaces: string[];
+  errorCount: number;
+  warningCount: number;
+  validationErrors: string[];
+  startTimestamp?: string;
+  completionTimestamp?: string;
+}
+
+export interface Backup extends Resource, VeleroStatus {
+  storageLocation: string;
+  expiration?: string;
+}
+
+export interface Restore extends Resource, VeleroStatus {
+  backupName: string;
+  failureReason?: string;
+}
+
+export interface BackupSpec {
+  ttl?: string;
+  storageLocation?: string;
+}
+
+export interface VulnerabilitySummary {
+  image: string;
+  digest?: string;
+  status: string;
+  message?: string;
+  counts: {[severity: string]: number};
+  fixable: number;
+  scannedAt?: string;
+}
+
+export interface VulnerabilityReport {
+  enabled: boolean;
+  images: VulnerabilitySummary[];
+}
+
 export interface Job extends Resource {
   podInfo: PodInfo;
   containerImages: string[];
@@ -289,6 +432,8 @@ export interface Job extends Resource {
 
 export interface Namespace extends Resource {
   phase: string;
+  isDefault: boolean;
+  isPinned: boolean;
 }
 
 export interface Node extends Resource {
@@ -376,6 +521,26 @@ export interface ResourceQuotaDetail extends ResourceDetail {
   statusList: {[key: string]: ResourceQuotaStatus};
 }
 
+export interface ResourceRecommendation {
+  request?: string;
+  target?: string;
+  lowerBound?: string;
+  upperBound?: string;
+  status: string;
+}
+
+export interface ContainerRecommendation {
+  containerName: string;
+  cpu: ResourceRecommendation;
+  memory: ResourceRecommendation;
+}
+
+export interface ResourceRecommendations {
+  name: string;
+  updateMode: string;
+  containers: ContainerRecommendation[];
+}
+
 export interface DeploymentDetail extends ResourceDetail {
   selector: Label[];
   statusInfo: DeploymentInfo;
@@ -385,6 +550,8 @@ export interface DeploymentDetail extends ResourceDetail {
   revisionHistoryLimit?: number;
   rollingUpdateStrategy?: RollingUpdateStrategy;
   events: EventList;
+  policyViolations: PolicyViolation[];
+  resourceRecommendations?: ResourceRecommendations;
 }
 
 export interface ReplicationControllerDetail extends ResourceDetail {
@@ -407,6 +574,16 @@ export interface ServiceDetail extends ResourceDetail {
   clusterIP: string;
   podList: PodList;
   sessionAffinity: string;
+  policyViolations: PolicyViolation[];
+  traffic?: ServiceTraffic;
+}
+
+export interface ServiceTraffic {
+  mesh: string;
+  window: string;
+  requestRate: number;
+  errorRate?: number;
+  p99Latency?: number;
 }
 
 export interface DaemonSetDetail extends ResourceDetail {
@@ -414,6 +591,8 @@ export interface DaemonSetDetail extends ResourceDetail {
   containerImages: string[];
   initContainerImages: string[];
   podInfo: PodInfo;
+  policyViolations: PolicyViolation[];
+  resourceRecommendations?: ResourceRecommendations;
 }
 
 export interface NamespaceDetail extends ResourceDetail {
@@ -421,6 +600,7 @@ export interface NamespaceDetail extends ResourceDetail {
   eventList: EventList;
   resourceLimits: LimitRange[];
   resourceQuotaList: ResourceQuotaDetailList;
+  policyViolations: PolicyViolation[];
 }
 
 export interface PolicyRule {
@@ -440,7 +620,50 @@ export interface SecretDetail extends ResourceDetail {
   data: StringMap;
 }
 
-export interface IngressDetail extends ResourceDetail {}
+export interface HostResolution {
+  host: string;
+  addresses: string[];
+  status: string;
+  message?: string;
+}
+
+export interface IngressDetail extends ResourceDetail {
+  certificates: Certificate[];
+  hostResolutions: HostResolution[];
+  policyViolations: PolicyViolation[];
+}
+
+export interface MeshObjectDetail extends ResourceDetail {
+  hosts: string[];
+  spec: {};
+}
+
+export interface KnativeServiceDetail extends ResourceDetail {
+  url: string;
+  ready: string;
+  latestReadyRevisionName: string;
+  latestCreatedRevisionName: string;
+  traffic: TrafficTarget[];
+  revisions: Revision[];
+}
+
+export interface RevisionDetail extends ResourceDetail {
+  serviceName: string;
+  ready: string;
+  containerImage: string;
+  trafficPercent: number;
+  minScale: number;
+  scaledToZero: boolean;
+  spec: {};
+}
+
+export interface ServiceMeshRouting {
+  virtualServices: MeshObject[];
+  destinationRules: MeshObject[];
+  gateways: MeshObject[];
+  installed: boolean;
+  errors: K8sError[];
+}
 
 export interface PersistentVolumeClaimDetail extends ResourceDetail {
   status: string;
@@ -497,6 +720,8 @@ export interface StatefulSetDetail extends ResourceDetail {
   containerImages: string[];
   initContainerImages: string[];
   eventList: EventList;
+  policyViolations: PolicyViolation[];
+  resourceRecommendations?: ResourceRecommendations;
 }
 
 export interface PersistentVolumeDetail extends ResourceDetail {
@@ -520,10 +745,25 @@ export interface PodDetail extends ResourceDetail {
   restartCount: number;
   qosClass: string;
   metrics: Metric[];
+  metricsAvailable: boolean;
   conditions: Condition[];
   controller: Resource;
   eventList: EventList;
   persistentVolumeClaimList: PersistentVolumeClaimList;
+  security: PodSecurityEvaluation;
+  policyViolations: PolicyViolation[];
+}
+
+export interface PodSecurityViolation {
+  level: string;
+  check: string;
+  field: string;
+  message: string;
+}
+
+export interface PodSecurityEvaluation {
+  level: string;
+  violations: PodSecurityViolation[];
 }
 
 export interface NodeDetail extends ResourceDetail {
@@ -538,11 +778,353 @@ export interface NodeDetail extends ResourceDetail {
   addresses: NodeAddress[];
   taints: NodeTaint[];
   metrics: Metric[];
+  metricsAvailable: boolean;
   conditions: Condition[];
   podList: PodList;
   eventList: EventList;
 }
 
+export interface AutoscalerCondition {
+  status: string;
+  counts: {[key: string]: number};
+  lastProbeTime?: string;
+  lastTransitionTime?: string;
+}
+
+export interface AutoscalerStatus {
+  health?: AutoscalerCondition;
+  scaleUp?: AutoscalerCondition;
+  scaleDown?: AutoscalerCondition;
+}
+
+export interface NodeGroup {
+  name: string;
+  minSize?: number;
+  maxSize?: number;
+  targetSize?: number;
+  nodes: string[];
+  readyNodes: number;
+  scaleDownDisabledNodes: string[];
+  autoscaler?: AutoscalerStatus;
+}
+
+export interface NodeGroupOverview {
+  autoscalerInstalled: boolean;
+  lastUpdated?: string;
+  clusterWide?: AutoscalerStatus;
+  nodeGroups: NodeGroup[];
+  ungroupedNodes: number;
+  errors: K8sError[];
+}
+
+export type KubeBenchCheckStatus = 'PASS' | 'FAIL' | 'WARN' | 'INFO';
+
+export interface KubeBenchCheck {
+  id: string;
+  description: string;
+  status: KubeBenchCheckStatus;
+  remediation?: string;
+  scored: boolean;
+}
+
+export interface KubeBenchSection {
+  id: string;
+  description: string;
+  checks: KubeBenchCheck[];
+}
+
+export interface KubeBenchTotals {
+  pass: number;
+  fail: number;
+  warn: number;
+  info: number;
+}
+
+export interface KubeBenchControl {
+  id: string;
+  description: string;
+  nodeType?: string;
+  sections: KubeBenchSection[];
+  totals: KubeBenchTotals;
+}
+
+export interface KubeBenchRun extends Resource {
+  status: {status: string; message: string};
+  nodeName?: string;
+  completionTime?: string;
+  controls: KubeBenchControl[];
+  totals: KubeBenchTotals;
+}
+
+export interface KubeBenchRunList extends ResourceList {
+  items: KubeBenchRun[];
+}
+
+export interface KubeBenchRunSpec {
+  targets?: string[];
+  nodeName?: string;
+}
+
+export interface CostPricing {
+  cpuCoreMonth: number;
+  memoryGiBMonth: number;
+  currency: string;
+}
+
+export interface CostEstimate {
+  pods: number;
+  cpuRequests: number;
+  memoryRequests: number;
+  cpuUsage?: number;
+  memoryUsage?: number;
+  requestsCost: number;
+  usageCost?: number;
+  cost: number;
+}
+
+export interface WorkloadCost extends CostEstimate {
+  kind: string;
+  name: string;
+}
+
+export interface NamespaceCost extends CostEstimate {
+  name: string;
+  workloads?: WorkloadCost[];
+}
+
+export interface CostReport {
+  pricing: CostPricing;
+  metricsAvailable: boolean;
+  total: CostEstimate;
+  namespaces: NamespaceCost[];
+  errors: K8sError[];
+}
+
+export interface FalcoEvent {
+  time: string;
+  rule: string;
+  priority: string;
+  output: string;
+  hostname?: string;
+  namespace?: string;
+  pod?: string;
+  container?: string;
+  tags?: string[];
+  outputFields?: {[key: string]: any};
+}
+
+export interface FalcoEventList {
+  listMeta: ListMeta;
+  events: FalcoEvent[];
+  enabled: boolean;
+}
+
+export interface GitOpsOwner {
+  tool: string;
+  kind: string;
+  namespace: string;
+  name: string;
+  found: boolean;
+  syncStatus?: string;
+  healthStatus?: string;
+  revision?: string;
+  message?: string;
+  revertsChanges: boolean;
+}
+
+export interface GitOpsStatus {
+  managed: boolean;
+  owner?: GitOpsOwner;
+  errors: K8sError[];
+}
+
+export interface GroupMappingBinding {
+  namespace: string;
+  clusterRole: string;
+  name?: string;
+  inSync: boolean;
+}
+
+export interface GroupMapping {
+  group: string;
+  bindings: GroupMappingBinding[];
+}
+
+export interface GroupMappingList {
+  items: GroupMapping[];
+  errors: K8sError[];
+}
+
+export interface ControlPlaneComponentStatus {
+  name: string;
+  healthy: boolean;
+  message?: string;
+}
+
+export interface ControlPlaneHealthCheck {
+  path: string;
+  healthy: boolean;
+  message?: string;
+}
+
+export interface ControlPlanePod {
+  objectMeta: ObjectMeta;
+  component: string;
+  nodeName: string;
+  phase: string;
+  ready: boolean;
+  restartCount: number;
+}
+
+export interface ControlPlaneHealth {
+  healthy: boolean;
+  components: ControlPlaneComponentStatus[];
+  healthChecks: ControlPlaneHealthCheck[];
+  pods: ControlPlanePod[];
+  podsVisible: boolean;
+  errors: K8sError[];
+}
+
+export interface SystemInfoBuild {
+  goVersion: string;
+  compiler: string;
+  platform: string;
+}
+
+export interface SystemInfoIntegration {
+  id: string;
+  connected: boolean;
+  error?: string;
+}
+
+export interface SystemInfo {
+  version: string;
+  build: SystemInfoBuild;
+  apiServerVersion: string;
+  configMode: string;
+  metricsProvider: string;
+  integrations: SystemInfoIntegration[];
+  auth: {modes: string[]; skippable: boolean};
+  featureGates: {[feature: string]: boolean};
+  errors: K8sError[];
+}
+
+export interface WorkloadHealthStatus {
+  healthy: number;
+  degraded: number;
+}
+
+export interface NamespaceWorkloadStatus {
+  namespace?: string;
+  pods: Status;
+  deployments: WorkloadHealthStatus;
+  daemonSets: WorkloadHealthStatus;
+  jobs: WorkloadHealthStatus;
+}
+
+export interface WorkloadStatusSummary {
+  namespaces: NamespaceWorkloadStatus[];
+  total: NamespaceWorkloadStatus;
+  errors: K8sError[];
+}
+
+export interface NamespaceOverview {
+  namespace: Namespace;
+  workloads: NamespaceWorkloadStatus;
+  serviceList: ServiceList;
+  configMapList: ConfigMapList;
+  secretList: SecretList;
+  resourceQuotaList: ResourceQuotaDetailList;
+  warningEvents: Event[];
+  errors: K8sError[];
+}
+
+export interface NodePlacement {
+  node: string;
+  pods: string[];
+}
+
+export interface ZonePlacement {
+  zone: string;
+  podCount: number;
+  nodes: NodePlacement[];
+}
+
+export interface PodPlacement {
+  zones: ZonePlacement[];
+  unscheduledPods: string[];
+  maxSkew: number;
+  singleZone: boolean;
+  singleNode: boolean;
+  errors: K8sError[];
+}
+
+export interface HeatmapValue {
+  percentage: number;
+  bucket: number;
+}
+
+export interface HeatmapResource {
+  requested: HeatmapValue;
+  used?: HeatmapValue;
+}
+
+export interface NodeHeatmapCell {
+  name: string;
+  ready: string;
+  cpu: HeatmapResource;
+  memory: HeatmapResource;
+}
+
+export interface NodeHeatmap {
+  nodes: NodeHeatmapCell[];
+  bucketCount: number;
+  metricsAvailable: boolean;
+  errors: K8sError[];
+}
+
+export interface ResourceChange {
+  path: string;
+  operation: string;
+  live?: {};
+  desired?: {};
+}
+
+export interface ResourceDiff {
+  source: string;
+  identical: boolean;
+  changes: ResourceChange[];
+  unified: string;
+}
+
+export interface ExecSession {
+  id: string;
+  user: string;
+  namespace: string;
+  pod: string;
+  container: string;
+  shell?: string;
+  startedAt: string;
+  endedAt?: string;
+}
+
+export interface ExecSessionList {
+  listMeta: ListMeta;
+  sessions: ExecSession[];
+  enabled: boolean;
+}
+
+export interface ExecSessionEvent {
+  time: number;
+  type: string;
+  data: string;
+}
+
+export interface ExecRecording extends ExecSession {
+  events: ExecSessionEvent[];
+  totalEvents: number;
+}
+
 export interface HorizontalPodAutoscalerDetail extends ResourceDetail {
   scaleTargetRef: ScaleTargetRef;
   minReplicas: number;
@@ -557,6 +1139,9 @@ export interface HorizontalPodAutoscalerDetail extends ResourceDetail {
 // Validation types
 export interface AppNameValidity {
   valid: boolean;
+  constraint?: string;
+  reason?: string;
+  conflicts?: string[];
 }
 
 export interface AppNameValiditySpec {
@@ -564,15 +1149,74 @@ export interface AppNameValiditySpec {
   namespace: string;
 }
 
+export interface LoadBalancerSupport {
+  supported: boolean;
+  warning?: string;
+  fallback?: string;
+}
+
+export interface NamespaceValidity {
+  valid: boolean;
+  terminating: boolean;
+}
+
 export interface ImageReferenceValidity {
   valid: boolean;
   reason: string;
+  registry?: string;
+  repository?: string;
+  tag?: string;
+  digest?: string;
 }
 
 export interface ImageReferenceValiditySpec {
   reference: string;
 }
 
+export interface ImageTagsSpec {
+  repository: string;
+  namespace?: string;
+  imagePullSecret?: string;
+  username?: string;
+  password?: string;
+}
+
+export interface ImageTags {
+  tags: string[];
+  truncated: boolean;
+  reason?: string;
+}
+
+export interface FieldDiff {
+  path: string;
+  edited?: {};
+  current?: {};
+}
+
+export interface ResourceConflict {
+  message: string;
+  editedResourceVersion: string;
+  currentResourceVersion: string;
+  diff: FieldDiff[];
+  current: {};
+}
+
+export interface QuotaUsage {
+  quota: string;
+  resource: string;
+  hard: string;
+  used: string;
+  requested: string;
+  projected: string;
+  overage: string;
+  unspecified: boolean;
+}
+
+export interface QuotaCheck {
+  fits: boolean;
+  usages: QuotaUsage[];
+}
+
 export interface ProtocolValidity {
   valid: boolean;
 }
@@ -609,6 +1253,7 @@ export interface AppDeploymentContentSpec {
   name: string;
   namespace: string;
   content: string;
+  url?: string;
   validate: boolean;
 }
 
@@ -616,6 +1261,33 @@ export interface AppDeploymentContentResponse {
   error: string;
   contet: string;
   name: string;
+  objects: ObjectDeployment[];
+}
+
+export interface ObjectDeployment {
+  kind: string;
+  name: string;
+  namespace?: string;
+  success: boolean;
+  error?: string;
+}
+
+export interface AppDeploymentPreview {
+  content: string;
+  security: PodSecurityEvaluation;
+}
+
+export interface ManifestFieldError {
+  document: number;
+  line: number;
+  kind: string;
+  field: string;
+  detail: string;
+}
+
+export interface ManifestValidity {
+  valid: boolean;
+  errors: ManifestFieldError[];
 }
 
 export interface AppDeploymentSpec {
@@ -629,6 +1301,7 @@ export interface AppDeploymentSpec {
   labels: Label[];
   replicas: number;
   namespace: string;
+  createNamespace?: boolean;
   memoryRequirement?: string;
   cpuRequirement?: number;
   runAsPrivileged: boolean;
@@ -659,6 +1332,22 @@ export interface ErrStatus {
   reason: string;
 }
 
+export interface APIErrorCause {
+  type?: string;
+  field?: string;
+  message: string;
+}
+
+export interface APIError {
+  code: number;
+  reason: string;
+  message: string;
+  kind?: string;
+  group?: string;
+  name?: string;
+  causes?: APIErrorCause[];
+}
+
 /* tslint:disable */
 export interface K8sError {
   ErrStatus: ErrStatus;
@@ -836,6 +1525,7 @@ export interface PortMapping {
   port: number | null;
   protocol: string;
   targetPort: number | null;
+  nodePort?: number | null;
 }
 
 export interface EnvironmentVariable {
@@ -1042,6 +1732,19 @@ export interface GlobalSettings {
   itemsPerPage: number;
   logsAutoRefreshTimeInterval: number;
   resourceAutoRefreshTimeInterval: number;
+  defaultNamespace: string;
+  allowedNamespaces: string[];
+  deniedNamespaces: string[];
+  pinnedNamespaces: string[];
+  hiddenResourceKinds: string[];
+  systemBanner?: SystemBannerSettings;
+}
+
+export interface SystemBannerSettings {
+  message: string;
+  severity: string;
+  start?: string;
+  end?: string;
 }
 
 export interface PinnedResource {
@@ -1050,6 +1753,10 @@ export interface PinnedResource {
   namespace?: string;
 }
 
+export interface RecentResource extends PinnedResource {
+  viewedAt: string;
+}
+
 export interface APIVersion {
   name: string;
 }
@@ -1059,6 +1766,7 @@ export interface LoginSpec {
   password: string;
   token: string;
   kubeConfig: string;
+  noticeAcknowledged?: boolean;
 }
 
 export interface AuthResponse {
@@ -1082,11 +1790,25 @@ export interface LoginSkippableResponse {
   skippable: boolean;
 }
 
+export interface LoginNoticeResponse {
+  message: string;
+  acknowledgmentRequired: boolean;
+}
+
 export interface SystemBanner {
   message: string;
   severity: string;
 }
 
+export interface Branding {
+  title: string;
+  logoURL: string;
+  theme: string;
+  primaryColor: string;
+  environmentLabel: string;
+  environmentColor: string;
+}
+
 export interface PersistentVolumeSource {
   gcePersistentDisk: GCEPersistentDiskVolumeSource;
   awsElasticBlockStore: AWSElasticBlockStorageVolumeSource;
@@ -1146,3 +1868,20 @@ export interface Plugin extends Resource {
 export interface PluginList extends ResourceList {
   items?: Plugin[];
 }
+
+export interface Release {
+  name: string;
+  namespace: string;
+  revision: number;
+  chart: string;
+  chartVersion: string;
+  appVersion: string;
+  status: string;
+  description?: string;
+  lastDeployed: string;
+}
+
+export interface ReleaseList {
+  listMeta: ListMeta;
+  items: Release[];
+}